@@ -6,6 +6,7 @@ import (
 	outputdto "libs/golang/ddd/dtos/config-vault/output"
 	"libs/golang/shared/go-request/requests"
 	"net/http"
+	"net/url"
 	"time"
 )
 
@@ -81,6 +82,7 @@ func (c *Client) UpdateConfig(configInput inputdto.ConfigDTO) (outputdto.ConfigD
 }
 
 // ListAllConfigs sends a request to retrieve all configurations.
+// Every page is requested, so prefer IterateConfigs to walk a large listing.
 //
 // Returns:
 //   - []outputdto.ConfigDTO: A slice of configuration data transfer objects.
@@ -88,18 +90,20 @@ func (c *Client) UpdateConfig(configInput inputdto.ConfigDTO) (outputdto.ConfigD
 func (c *Client) ListAllConfigs() ([]outputdto.ConfigDTO, error) {
 	pathParams := []string{"config"}
 
-	req, err := requests.CreateRequest(c.ctx, c.baseURL, pathParams, nil, nil, defaultHeaders, http.MethodGet)
-	if err != nil {
-		return nil, err
-	}
-
-	var configList []outputdto.ConfigDTO
-	err = requests.SendRequest(c.ctx, req, requests.DefaultHTTPClient, &configList, c.timeout)
-	if err != nil {
-		return nil, err
-	}
+	return c.iterateConfigs(pathParams, inputdto.ConfigFilterDTO{}).All()
+}
 
-	return configList, nil
+// ListConfigs sends a request to retrieve the configurations selected by a filter, as the query parameters
+// `?provider=acme&active=true`. An empty filter selects every config.
+//
+// Parameters:
+//   - filter: The filter selecting the configurations.
+//
+// Returns:
+//   - outputdto.ConfigPageDTO: The page of config data transfer objects, with the cursor of the next page.
+//   - error: An error if the request fails.
+func (c *Client) ListConfigs(filter inputdto.ConfigFilterDTO) (outputdto.ConfigPageDTO, error) {
+	return c.listConfigPage([]string{"config"}, filter)
 }
 
 // ListConfigByID sends a request to retrieve a configuration by its ID.
@@ -151,6 +155,7 @@ func (c *Client) DeleteConfig(id string) error {
 }
 
 // ListConfigsByServiceAndProvider sends a request to retrieve configurations by service and provider.
+// Every page is requested, so prefer IterateConfigs to walk a large listing.
 //
 // Parameters:
 //   - service: The service name.
@@ -162,21 +167,11 @@ func (c *Client) DeleteConfig(id string) error {
 func (c *Client) ListConfigsByServiceAndProvider(service, provider string) ([]outputdto.ConfigDTO, error) {
 	pathParams := []string{"config", "provider", provider, "service", service}
 
-	req, err := requests.CreateRequest(c.ctx, c.baseURL, pathParams, nil, nil, defaultHeaders, http.MethodGet)
-	if err != nil {
-		return nil, err
-	}
-
-	var configList []outputdto.ConfigDTO
-	err = requests.SendRequest(c.ctx, req, requests.DefaultHTTPClient, &configList, c.timeout)
-	if err != nil {
-		return nil, err
-	}
-
-	return configList, nil
+	return c.iterateConfigs(pathParams, inputdto.ConfigFilterDTO{}).All()
 }
 
 // ListConfigsBySourceAndProvider sends a request to retrieve configurations by source and provider.
+// Every page is requested, so prefer IterateConfigs to walk a large listing.
 //
 // Parameters:
 //   - source: The source name.
@@ -188,21 +183,11 @@ func (c *Client) ListConfigsByServiceAndProvider(service, provider string) ([]ou
 func (c *Client) ListConfigsBySourceAndProvider(source, provider string) ([]outputdto.ConfigDTO, error) {
 	pathParams := []string{"config", "provider", provider, "source", source}
 
-	req, err := requests.CreateRequest(c.ctx, c.baseURL, pathParams, nil, nil, defaultHeaders, http.MethodGet)
-	if err != nil {
-		return nil, err
-	}
-
-	var configList []outputdto.ConfigDTO
-	err = requests.SendRequest(c.ctx, req, requests.DefaultHTTPClient, &configList, c.timeout)
-	if err != nil {
-		return nil, err
-	}
-
-	return configList, nil
+	return c.iterateConfigs(pathParams, inputdto.ConfigFilterDTO{}).All()
 }
 
 // ListConfigsByServiceAndProviderAndActive sends a request to retrieve configurations by service, provider, and active status.
+// Every page is requested, so prefer IterateConfigs to walk a large listing.
 //
 // Parameters:
 //   - service: The service name.
@@ -215,21 +200,11 @@ func (c *Client) ListConfigsBySourceAndProvider(source, provider string) ([]outp
 func (c *Client) ListConfigsByServiceAndProviderAndActive(service, provider, active string) ([]outputdto.ConfigDTO, error) {
 	pathParams := []string{"config", "provider", provider, "service", service, "active", active}
 
-	req, err := requests.CreateRequest(c.ctx, c.baseURL, pathParams, nil, nil, defaultHeaders, http.MethodGet)
-	if err != nil {
-		return nil, err
-	}
-
-	var configList []outputdto.ConfigDTO
-	err = requests.SendRequest(c.ctx, req, requests.DefaultHTTPClient, &configList, c.timeout)
-	if err != nil {
-		return nil, err
-	}
-
-	return configList, nil
+	return c.iterateConfigs(pathParams, inputdto.ConfigFilterDTO{}).All()
 }
 
 // ListConfigsByServiceAndSourceAndProvider sends a request to retrieve configurations by service, source, and provider.
+// Every page is requested, so prefer IterateConfigs to walk a large listing.
 //
 // Parameters:
 //   - service: The service name.
@@ -242,6 +217,22 @@ func (c *Client) ListConfigsByServiceAndProviderAndActive(service, provider, act
 func (c *Client) ListConfigsByServiceAndSourceAndProvider(service, source, provider string) ([]outputdto.ConfigDTO, error) {
 	pathParams := []string{"config", "provider", provider, "service", service, "source", source}
 
+	return c.iterateConfigs(pathParams, inputdto.ConfigFilterDTO{}).All()
+}
+
+// ListConfigsByProviderAndDependencies sends a request to retrieve configurations by provider and dependencies.
+//
+// Parameters:
+//   - provider: The provider name.
+//   - service: The service name.
+//   - source: The source name.
+//
+// Returns:
+//   - []outputdto.ConfigDTO: A slice of configuration data transfer objects.
+//   - error: An error if the request fails.
+func (c *Client) ListConfigsByProviderAndDependencies(provider, service, source string) ([]outputdto.ConfigDTO, error) {
+	pathParams := []string{"config", "provider", provider, "dependencies", "service", service, "source", source}
+
 	req, err := requests.CreateRequest(c.ctx, c.baseURL, pathParams, nil, nil, defaultHeaders, http.MethodGet)
 	if err != nil {
 		return nil, err
@@ -256,29 +247,60 @@ func (c *Client) ListConfigsByServiceAndSourceAndProvider(service, source, provi
 	return configList, nil
 }
 
-// ListConfigsByProviderAndDependencies sends a request to retrieve configurations by provider and dependencies.
+// GetConfigGraph sends a request to retrieve the dependency graph of the configurations of a provider.
 //
 // Parameters:
 //   - provider: The provider name.
-//   - service: The service name.
-//   - source: The source name.
 //
 // Returns:
-//   - []outputdto.ConfigDTO: A slice of configuration data transfer objects.
+//   - outputdto.ConfigGraphDTO: The nodes, edges and dependency order of the graph.
 //   - error: An error if the request fails.
-func (c *Client) ListConfigsByProviderAndDependencies(provider, service, source string) ([]outputdto.ConfigDTO, error) {
-	pathParams := []string{"config", "provider", provider, "dependencies", "service", service, "source", source}
+func (c *Client) GetConfigGraph(provider string) (outputdto.ConfigGraphDTO, error) {
+	pathParams := []string{"config", "provider", provider, "graph"}
 
 	req, err := requests.CreateRequest(c.ctx, c.baseURL, pathParams, nil, nil, defaultHeaders, http.MethodGet)
 	if err != nil {
-		return nil, err
+		return outputdto.ConfigGraphDTO{}, err
 	}
 
-	var configList []outputdto.ConfigDTO
-	err = requests.SendRequest(c.ctx, req, requests.DefaultHTTPClient, &configList, c.timeout)
+	var graph outputdto.ConfigGraphDTO
+	err = requests.SendRequest(c.ctx, req, requests.DefaultHTTPClient, &graph, c.timeout)
 	if err != nil {
-		return nil, err
+		return outputdto.ConfigGraphDTO{}, err
 	}
 
-	return configList, nil
+	return graph, nil
+}
+
+// listConfigPage sends a request to retrieve a page of configs.
+//
+// Parameters:
+//   - pathParams: The path of the listing route.
+//   - filter: The filter selecting the configs and the page, sent as query parameters.
+//
+// Returns:
+//   - outputdto.ConfigPageDTO: The page of config data transfer objects, with the cursor of the next page.
+//   - error: An error if the request fails.
+func (c *Client) listConfigPage(pathParams []string, filter inputdto.ConfigFilterDTO) (outputdto.ConfigPageDTO, error) {
+	req, err := requests.CreateRequest(c.ctx, c.baseURL, pathParams, queryParams(filter.Values()), nil, defaultHeaders, http.MethodGet)
+	if err != nil {
+		return outputdto.ConfigPageDTO{}, err
+	}
+
+	var page outputdto.ConfigPageDTO
+	err = requests.SendRequest(c.ctx, req, requests.DefaultHTTPClient, &page, c.timeout)
+	if err != nil {
+		return outputdto.ConfigPageDTO{}, err
+	}
+
+	return page, nil
+}
+
+// queryParams flattens query parameters into the single values sent by requests.CreateRequest.
+func queryParams(values url.Values) map[string]string {
+	params := make(map[string]string, len(values))
+	for key := range values {
+		params[key] = values.Get(key)
+	}
+	return params
 }
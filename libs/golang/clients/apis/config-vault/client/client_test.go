@@ -3,9 +3,11 @@ package client
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	inputdto "libs/golang/ddd/dtos/config-vault/input"
 	outputdto "libs/golang/ddd/dtos/config-vault/output"
 	shareddto "libs/golang/ddd/dtos/config-vault/shared"
+	"libs/golang/shared/go-problem/problem"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -73,6 +75,24 @@ func (suite *ClientTestSuite) SetupTest() {
 			w.WriteHeader(http.StatusOK)
 			json.NewEncoder(w).Encode(configOutput)
 
+		case r.URL.Path == "/config" && r.Method == http.MethodGet && r.URL.Query().Get("provider") == "paged":
+			// Serve two pages chained by their cursor, and fail on the "broken" cursor
+			page := outputdto.ConfigPageDTO{Items: []outputdto.ConfigDTO{{ID: "1"}, {ID: "2"}}, NextCursor: "next"}
+			switch r.URL.Query().Get("cursor") {
+			case "next":
+				page = outputdto.ConfigPageDTO{Items: []outputdto.ConfigDTO{{ID: "3"}}}
+			case "broken":
+				http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(page)
+
+		case r.URL.Path == "/config" && r.Method == http.MethodGet && r.URL.RawQuery != "":
+			// Echo the query, so the tests check the parameters sent
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(outputdto.ConfigPageDTO{Items: []outputdto.ConfigDTO{{ID: r.URL.Query().Encode()}}})
+
 		case r.URL.Path == "/config" && r.Method == http.MethodGet:
 			configList := []outputdto.ConfigDTO{
 				{
@@ -90,7 +110,7 @@ func (suite *ClientTestSuite) SetupTest() {
 				},
 			}
 			w.WriteHeader(http.StatusOK)
-			json.NewEncoder(w).Encode(configList)
+			json.NewEncoder(w).Encode(outputdto.ConfigPageDTO{Items: configList})
 
 		case r.URL.Path == "/config/1" && r.Method == http.MethodGet:
 			configOutput := outputdto.ConfigDTO{
@@ -129,7 +149,7 @@ func (suite *ClientTestSuite) SetupTest() {
 				},
 			}
 			w.WriteHeader(http.StatusOK)
-			json.NewEncoder(w).Encode(configList)
+			json.NewEncoder(w).Encode(outputdto.ConfigPageDTO{Items: configList})
 
 		case r.URL.Path == "/config/provider/provider1/source/source1" && r.Method == http.MethodGet:
 			configList := []outputdto.ConfigDTO{
@@ -148,7 +168,7 @@ func (suite *ClientTestSuite) SetupTest() {
 				},
 			}
 			w.WriteHeader(http.StatusOK)
-			json.NewEncoder(w).Encode(configList)
+			json.NewEncoder(w).Encode(outputdto.ConfigPageDTO{Items: configList})
 
 		case r.URL.Path == "/config/provider/provider1/service/service1/active/true" && r.Method == http.MethodGet:
 			configList := []outputdto.ConfigDTO{
@@ -167,7 +187,7 @@ func (suite *ClientTestSuite) SetupTest() {
 				},
 			}
 			w.WriteHeader(http.StatusOK)
-			json.NewEncoder(w).Encode(configList)
+			json.NewEncoder(w).Encode(outputdto.ConfigPageDTO{Items: configList})
 
 		case r.URL.Path == "/config/provider/provider1/service/service1/source/source1" && r.Method == http.MethodGet:
 			configList := []outputdto.ConfigDTO{
@@ -186,7 +206,7 @@ func (suite *ClientTestSuite) SetupTest() {
 				},
 			}
 			w.WriteHeader(http.StatusOK)
-			json.NewEncoder(w).Encode(configList)
+			json.NewEncoder(w).Encode(outputdto.ConfigPageDTO{Items: configList})
 
 		case r.URL.Path == "/config/provider/provider1/dependencies/service/service1/source/source1" && r.Method == http.MethodGet:
 			configList := []outputdto.ConfigDTO{
@@ -207,6 +227,25 @@ func (suite *ClientTestSuite) SetupTest() {
 			w.WriteHeader(http.StatusOK)
 			json.NewEncoder(w).Encode(configList)
 
+		case r.URL.Path == "/config/provider/provider1/graph" && r.Method == http.MethodGet:
+			graph := outputdto.ConfigGraphDTO{
+				Provider: "provider1",
+				Nodes: []outputdto.ConfigGraphNodeDTO{
+					{Service: "dep_service1", Source: "dep_source1"},
+					{Service: "service1", Source: "source1", ConfigID: "1", Active: true},
+				},
+				Edges: []outputdto.ConfigGraphEdgeDTO{{
+					From: shareddto.JobDependenciesDTO{Service: "dep_service1", Source: "dep_source1"},
+					To:   shareddto.JobDependenciesDTO{Service: "service1", Source: "source1"},
+				}},
+				Order: []shareddto.JobDependenciesDTO{
+					{Service: "dep_service1", Source: "dep_source1"},
+					{Service: "service1", Source: "source1"},
+				},
+			}
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(graph)
+
 		default:
 			http.NotFound(w, r)
 		}
@@ -354,6 +393,14 @@ func (suite *ClientTestSuite) TestListAllConfigsWhenSuccess() {
 	assert.Equal(suite.T(), expectedOutput, configOutput)
 }
 
+func (suite *ClientTestSuite) TestListConfigsWhenSuccess() {
+	active := true
+	configList, err := suite.client.ListConfigs(inputdto.ConfigFilterDTO{Provider: "provider1", Active: &active})
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), outputdto.ConfigPageDTO{Items: []outputdto.ConfigDTO{{ID: "active=true&provider=provider1"}}}, configList)
+}
+
 func (suite *ClientTestSuite) TestListConfigByIDWhenSuccess() {
 	expectedOutput := outputdto.ConfigDTO{
 		ID:              "1",
@@ -495,3 +542,33 @@ func (suite *ClientTestSuite) TestListConfigsByProviderAndDependenciesWhenSucces
 	assert.Nil(suite.T(), err)
 	assert.Equal(suite.T(), expectedOutput, configOutput)
 }
+
+func (suite *ClientTestSuite) TestGetConfigGraphWhenSuccess() {
+	graph, err := suite.client.GetConfigGraph("provider1")
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), "provider1", graph.Provider)
+	assert.Equal(suite.T(), 2, len(graph.Nodes))
+	assert.Equal(suite.T(), "service1", graph.Edges[0].To.Service)
+	assert.Equal(suite.T(), "dep_service1", graph.Order[0].Service)
+}
+
+func (suite *ClientTestSuite) TestGetConfigGraphWhenNotFound() {
+	_, err := suite.client.GetConfigGraph("unknown")
+
+	assert.NotNil(suite.T(), err)
+}
+
+func (suite *ClientTestSuite) TestListConfigByIDWhenNotFound() {
+	suite.mockServer.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		problem.Write(w, r, problem.ErrNotFound.WithDetail("config not found: ID unknown"))
+	})
+
+	_, err := suite.client.ListConfigByID("unknown")
+
+	assert.ErrorIs(suite.T(), err, problem.ErrNotFound)
+	var problemDetails *problem.Problem
+	assert.True(suite.T(), errors.As(err, &problemDetails))
+	assert.Equal(suite.T(), "config not found: ID unknown", problemDetails.Detail)
+	assert.Equal(suite.T(), "/config/unknown", problemDetails.Instance)
+}
@@ -0,0 +1,96 @@
+package client
+
+import (
+	inputdto "libs/golang/ddd/dtos/config-vault/input"
+	outputdto "libs/golang/ddd/dtos/config-vault/output"
+)
+
+// ConfigIterator walks the configs of a listing page by page, requesting each page when the previous one has been
+// read. It is used like a bufio.Scanner:
+//
+//	it := client.IterateConfigs(inputdto.ConfigFilterDTO{Provider: "acme"})
+//	for it.Next() {
+//	    fmt.Println(it.Config().ID)
+//	}
+//	if err := it.Err(); err != nil {
+//	    log.Fatal(err)
+//	}
+type ConfigIterator struct {
+	client     *Client
+	pathParams []string
+	filter     inputdto.ConfigFilterDTO
+	items      []outputdto.ConfigDTO
+	current    outputdto.ConfigDTO
+	last       bool
+	err        error
+}
+
+// IterateConfigs returns an iterator over the configs selected by the filter. The limit and sort of the filter
+// apply to every page, and its cursor selects the first page read.
+//
+// Parameters:
+//   - filter: The filter selecting the configs.
+//
+// Returns:
+//   - *ConfigIterator: The iterator over the configs, which sends no request before the first call to Next.
+func (c *Client) IterateConfigs(filter inputdto.ConfigFilterDTO) *ConfigIterator {
+	return c.iterateConfigs([]string{"config"}, filter)
+}
+
+func (c *Client) iterateConfigs(pathParams []string, filter inputdto.ConfigFilterDTO) *ConfigIterator {
+	return &ConfigIterator{client: c, pathParams: pathParams, filter: filter}
+}
+
+// Next advances the iterator to the next config, requesting the next page when the current one has been read.
+//
+// Returns:
+//   - bool: true if a config was read, false after the last config or when a request fails.
+func (it *ConfigIterator) Next() bool {
+	for len(it.items) == 0 {
+		if it.last || it.err != nil {
+			return false
+		}
+		page, err := it.client.listConfigPage(it.pathParams, it.filter)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.items = page.Items
+		it.filter.Cursor = page.NextCursor
+		it.last = page.NextCursor == ""
+	}
+	it.current, it.items = it.items[0], it.items[1:]
+	return true
+}
+
+// Config returns the config read by the last call to Next.
+//
+// Returns:
+//   - outputdto.ConfigDTO: The current config.
+func (it *ConfigIterator) Config() outputdto.ConfigDTO {
+	return it.current
+}
+
+// Err returns the error of the request that stopped the iteration, if any.
+//
+// Returns:
+//   - error: The error of the failed request, nil when the iteration reached the last config.
+func (it *ConfigIterator) Err() error {
+	return it.err
+}
+
+// All reads the remaining configs of every page.
+//
+// Returns:
+//   - []outputdto.ConfigDTO: The configs not read yet.
+//   - error: An error if a request fails.
+func (it *ConfigIterator) All() ([]outputdto.ConfigDTO, error) {
+	configs := []outputdto.ConfigDTO{}
+	for it.Next() {
+		configs = append(configs, it.Config())
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return configs, nil
+}
@@ -0,0 +1,42 @@
+package client
+
+import (
+	inputdto "libs/golang/ddd/dtos/config-vault/input"
+	outputdto "libs/golang/ddd/dtos/config-vault/output"
+	"libs/golang/shared/go-criteria/criteria"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *ClientTestSuite) TestIterateConfigsWalksEveryPage() {
+	it := suite.client.IterateConfigs(inputdto.ConfigFilterDTO{Provider: "paged"})
+
+	var ids []string
+	for it.Next() {
+		ids = append(ids, it.Config().ID)
+	}
+
+	assert.Nil(suite.T(), it.Err())
+	assert.Equal(suite.T(), []string{"1", "2", "3"}, ids)
+}
+
+func (suite *ClientTestSuite) TestIterateConfigsAll() {
+	it := suite.client.IterateConfigs(inputdto.ConfigFilterDTO{Provider: "paged"})
+	assert.True(suite.T(), it.Next())
+
+	rest, err := it.All()
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), []outputdto.ConfigDTO{{ID: "2"}, {ID: "3"}}, rest)
+}
+
+func (suite *ClientTestSuite) TestIterateConfigsWhenRequestFails() {
+	it := suite.client.IterateConfigs(inputdto.ConfigFilterDTO{Provider: "paged", ListQuery: criteria.ListQuery{Cursor: "broken"}})
+
+	assert.False(suite.T(), it.Next())
+	assert.NotNil(suite.T(), it.Err())
+	assert.False(suite.T(), it.Next())
+
+	_, err := suite.client.IterateConfigs(inputdto.ConfigFilterDTO{Provider: "paged", ListQuery: criteria.ListQuery{Cursor: "broken"}}).All()
+	assert.NotNil(suite.T(), err)
+}
@@ -8,6 +8,7 @@ import (
 	shareddto "libs/golang/ddd/dtos/input-broker/shared"
 	"libs/golang/shared/go-request/requests"
 	"net/http"
+	"net/url"
 	"time"
 )
 
@@ -57,6 +58,37 @@ func (c *Client) CreateInput(inputInput inputdto.InputDTO) (outputdto.InputDTO,
 	return inputOutput, nil
 }
 
+// CreateInputWithIdempotencyKey sends a request to create a new input that can be retried safely: the
+// input-broker returns the input created by an earlier request with the same key instead of creating it again.
+//
+// Parameters:
+//   - inputInput: The input data transfer object.
+//   - idempotencyKey: The key identifying the request across its retries, such as a UUID.
+//
+// Returns:
+//   - outputdto.InputDTO: The created or previously created input data transfer object.
+//   - error: An error if the request fails, or if the key was already used for a different input.
+func (c *Client) CreateInputWithIdempotencyKey(inputInput inputdto.InputDTO, idempotencyKey string) (outputdto.InputDTO, error) {
+	pathParams := []string{"input"}
+	headers := map[string]string{"Idempotency-Key": idempotencyKey}
+	for key, value := range defaultHeaders {
+		headers[key] = value
+	}
+
+	req, err := requests.CreateRequest(c.ctx, c.baseURL, pathParams, nil, inputInput, headers, http.MethodPost)
+	if err != nil {
+		return outputdto.InputDTO{}, err
+	}
+
+	var inputOutput outputdto.InputDTO
+	err = requests.SendRequest(c.ctx, req, requests.DefaultHTTPClient, &inputOutput, c.timeout)
+	if err != nil {
+		return outputdto.InputDTO{}, err
+	}
+
+	return inputOutput, nil
+}
+
 // UpdateInput sends a request to update an existing input.
 //
 // Parameters:
@@ -107,6 +139,7 @@ func (c *Client) DeleteInput(id string) error {
 }
 
 // ListAllInputs sends a request to retrieve all inputs.
+// Every page is requested, so prefer IterateInputs to walk a large listing.
 //
 // Returns:
 //   - []outputdto.InputDTO: A list of input data transfer objects.
@@ -114,18 +147,21 @@ func (c *Client) DeleteInput(id string) error {
 func (c *Client) ListAllInputs() ([]outputdto.InputDTO, error) {
 	pathParams := []string{"input"}
 
-	req, err := requests.CreateRequest(c.ctx, c.baseURL, pathParams, nil, nil, defaultHeaders, http.MethodGet)
-	if err != nil {
-		return nil, err
-	}
-
-	var inputs []outputdto.InputDTO
-	err = requests.SendRequest(c.ctx, req, requests.DefaultHTTPClient, &inputs, c.timeout)
-	if err != nil {
-		return nil, err
-	}
+	return c.iterateInputs(pathParams, inputdto.InputFilterDTO{}).All()
+}
 
-	return inputs, nil
+// ListInputs sends a request to retrieve a page of the inputs selected by a filter, as the query parameters
+// `?provider=acme&status=0&limit=50`. An empty filter selects the first page of every input, and the next page is
+// read by setting the cursor of the filter to the next cursor of the page.
+//
+// Parameters:
+//   - filter: The filter selecting the inputs and the page.
+//
+// Returns:
+//   - outputdto.InputPageDTO: The page of input data transfer objects, with the cursor of the next page.
+//   - error: An error if the request fails.
+func (c *Client) ListInputs(filter inputdto.InputFilterDTO) (outputdto.InputPageDTO, error) {
+	return c.listInputPage([]string{"input"}, filter)
 }
 
 // GetInputByID sends a request to retrieve an input by ID.
@@ -180,6 +216,7 @@ func (c *Client) UpdateInputStatus(id string, status shareddto.StatusDTO) (outpu
 }
 
 // ListInputsByServiceAndProvider sends a request to retrieve inputs by service and provider.
+// Every page is requested, so prefer IterateInputs to walk a large listing.
 //
 // Parameters:
 //   - service: The service name.
@@ -191,21 +228,11 @@ func (c *Client) UpdateInputStatus(id string, status shareddto.StatusDTO) (outpu
 func (c *Client) ListInputsByServiceAndProvider(service, provider string) ([]outputdto.InputDTO, error) {
 	pathParams := []string{"input", "provider", provider, "service", service}
 
-	req, err := requests.CreateRequest(c.ctx, c.baseURL, pathParams, nil, nil, defaultHeaders, http.MethodGet)
-	if err != nil {
-		return nil, err
-	}
-
-	var inputs []outputdto.InputDTO
-	err = requests.SendRequest(c.ctx, req, requests.DefaultHTTPClient, &inputs, c.timeout)
-	if err != nil {
-		return nil, err
-	}
-
-	return inputs, nil
+	return c.iterateInputs(pathParams, inputdto.InputFilterDTO{}).All()
 }
 
 // ListInputsBySourceAndProvider sends a request to retrieve inputs by source and provider.
+// Every page is requested, so prefer IterateInputs to walk a large listing.
 //
 // Parameters:
 //   - source: The source name.
@@ -217,21 +244,11 @@ func (c *Client) ListInputsByServiceAndProvider(service, provider string) ([]out
 func (c *Client) ListInputsBySourceAndProvider(source, provider string) ([]outputdto.InputDTO, error) {
 	pathParams := []string{"input", "provider", provider, "source", source}
 
-	req, err := requests.CreateRequest(c.ctx, c.baseURL, pathParams, nil, nil, defaultHeaders, http.MethodGet)
-	if err != nil {
-		return nil, err
-	}
-
-	var inputs []outputdto.InputDTO
-	err = requests.SendRequest(c.ctx, req, requests.DefaultHTTPClient, &inputs, c.timeout)
-	if err != nil {
-		return nil, err
-	}
-
-	return inputs, nil
+	return c.iterateInputs(pathParams, inputdto.InputFilterDTO{}).All()
 }
 
 // ListInputsByServiceAndSourceAndProvider sends a request to retrieve inputs by service, source, and provider.
+// Every page is requested, so prefer IterateInputs to walk a large listing.
 //
 // Parameters:
 //   - service: The service name.
@@ -244,21 +261,11 @@ func (c *Client) ListInputsBySourceAndProvider(source, provider string) ([]outpu
 func (c *Client) ListInputsByServiceAndSourceAndProvider(service, source, provider string) ([]outputdto.InputDTO, error) {
 	pathParams := []string{"input", "provider", provider, "service", service, "source", source}
 
-	req, err := requests.CreateRequest(c.ctx, c.baseURL, pathParams, nil, nil, defaultHeaders, http.MethodGet)
-	if err != nil {
-		return nil, err
-	}
-
-	var inputs []outputdto.InputDTO
-	err = requests.SendRequest(c.ctx, req, requests.DefaultHTTPClient, &inputs, c.timeout)
-	if err != nil {
-		return nil, err
-	}
-
-	return inputs, nil
+	return c.iterateInputs(pathParams, inputdto.InputFilterDTO{}).All()
 }
 
 // ListInputsByStatusAndProvider sends a request to retrieve inputs by status and provider.
+// Every page is requested, so prefer IterateInputs to walk a large listing.
 //
 // Parameters:
 //   - status: The status code.
@@ -270,21 +277,11 @@ func (c *Client) ListInputsByServiceAndSourceAndProvider(service, source, provid
 func (c *Client) ListInputsByStatusAndProvider(status int, provider string) ([]outputdto.InputDTO, error) {
 	pathParams := []string{"input", "provider", provider, "status", fmt.Sprintf("%d", status)}
 
-	req, err := requests.CreateRequest(c.ctx, c.baseURL, pathParams, nil, nil, defaultHeaders, http.MethodGet)
-	if err != nil {
-		return nil, err
-	}
-
-	var inputs []outputdto.InputDTO
-	err = requests.SendRequest(c.ctx, req, requests.DefaultHTTPClient, &inputs, c.timeout)
-	if err != nil {
-		return nil, err
-	}
-
-	return inputs, nil
+	return c.iterateInputs(pathParams, inputdto.InputFilterDTO{}).All()
 }
 
 // ListInputsByStatusAndServiceAndProvider sends a request to retrieve inputs by status, service, and provider.
+// Every page is requested, so prefer IterateInputs to walk a large listing.
 //
 // Parameters:
 //   - status: The status code.
@@ -297,21 +294,11 @@ func (c *Client) ListInputsByStatusAndProvider(status int, provider string) ([]o
 func (c *Client) ListInputsByStatusAndServiceAndProvider(status int, service, provider string) ([]outputdto.InputDTO, error) {
 	pathParams := []string{"input", "provider", provider, "service", service, "status", fmt.Sprintf("%d", status)}
 
-	req, err := requests.CreateRequest(c.ctx, c.baseURL, pathParams, nil, nil, defaultHeaders, http.MethodGet)
-	if err != nil {
-		return nil, err
-	}
-
-	var inputs []outputdto.InputDTO
-	err = requests.SendRequest(c.ctx, req, requests.DefaultHTTPClient, &inputs, c.timeout)
-	if err != nil {
-		return nil, err
-	}
-
-	return inputs, nil
+	return c.iterateInputs(pathParams, inputdto.InputFilterDTO{}).All()
 }
 
 // ListInputsByStatusAndSourceAndProvider sends a request to retrieve inputs by status, source, and provider.
+// Every page is requested, so prefer IterateInputs to walk a large listing.
 //
 // Parameters:
 //   - status: The status code.
@@ -324,21 +311,11 @@ func (c *Client) ListInputsByStatusAndServiceAndProvider(status int, service, pr
 func (c *Client) ListInputsByStatusAndSourceAndProvider(status int, source, provider string) ([]outputdto.InputDTO, error) {
 	pathParams := []string{"input", "provider", provider, "source", source, "status", fmt.Sprintf("%d", status)}
 
-	req, err := requests.CreateRequest(c.ctx, c.baseURL, pathParams, nil, nil, defaultHeaders, http.MethodGet)
-	if err != nil {
-		return nil, err
-	}
-
-	var inputs []outputdto.InputDTO
-	err = requests.SendRequest(c.ctx, req, requests.DefaultHTTPClient, &inputs, c.timeout)
-	if err != nil {
-		return nil, err
-	}
-
-	return inputs, nil
+	return c.iterateInputs(pathParams, inputdto.InputFilterDTO{}).All()
 }
 
 // ListInputsByStatusAndServiceAndSourceAndProvider sends a request to retrieve inputs by status, service, source, and provider.
+// Every page is requested, so prefer IterateInputs to walk a large listing.
 //
 // Parameters:
 //   - status: The status code.
@@ -352,16 +329,38 @@ func (c *Client) ListInputsByStatusAndSourceAndProvider(status int, source, prov
 func (c *Client) ListInputsByStatusAndServiceAndSourceAndProvider(status int, service, source, provider string) ([]outputdto.InputDTO, error) {
 	pathParams := []string{"input", "provider", provider, "service", service, "source", source, "status", fmt.Sprintf("%d", status)}
 
-	req, err := requests.CreateRequest(c.ctx, c.baseURL, pathParams, nil, nil, defaultHeaders, http.MethodGet)
+	return c.iterateInputs(pathParams, inputdto.InputFilterDTO{}).All()
+}
+
+// listInputPage sends a request to retrieve a page of inputs.
+//
+// Parameters:
+//   - pathParams: The path of the listing route.
+//   - filter: The filter selecting the inputs and the page, sent as query parameters.
+//
+// Returns:
+//   - outputdto.InputPageDTO: The page of input data transfer objects, with the cursor of the next page.
+//   - error: An error if the request fails.
+func (c *Client) listInputPage(pathParams []string, filter inputdto.InputFilterDTO) (outputdto.InputPageDTO, error) {
+	req, err := requests.CreateRequest(c.ctx, c.baseURL, pathParams, queryParams(filter.Values()), nil, defaultHeaders, http.MethodGet)
 	if err != nil {
-		return nil, err
+		return outputdto.InputPageDTO{}, err
 	}
 
-	var inputs []outputdto.InputDTO
-	err = requests.SendRequest(c.ctx, req, requests.DefaultHTTPClient, &inputs, c.timeout)
+	var page outputdto.InputPageDTO
+	err = requests.SendRequest(c.ctx, req, requests.DefaultHTTPClient, &page, c.timeout)
 	if err != nil {
-		return nil, err
+		return outputdto.InputPageDTO{}, err
 	}
 
-	return inputs, nil
+	return page, nil
+}
+
+// queryParams flattens query parameters into the single values sent by requests.CreateRequest.
+func queryParams(values url.Values) map[string]string {
+	params := make(map[string]string, len(values))
+	for key := range values {
+		params[key] = values.Get(key)
+	}
+	return params
 }
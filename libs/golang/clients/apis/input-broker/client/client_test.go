@@ -3,9 +3,11 @@ package client
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	inputdto "libs/golang/ddd/dtos/input-broker/input"
 	outputdto "libs/golang/ddd/dtos/input-broker/output"
 	shareddto "libs/golang/ddd/dtos/input-broker/shared"
+	"libs/golang/shared/go-problem/problem"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -42,6 +44,9 @@ func (suite *ClientSuite) SetupTest() {
 				CreatedAt: "2023-06-01T00:00:00Z",
 				UpdatedAt: "2023-06-01T00:00:00Z",
 			}
+			if key := r.Header.Get("Idempotency-Key"); key != "" {
+				inputOutput.Status.Detail = "Replayed " + key
+			}
 			w.WriteHeader(http.StatusCreated)
 			json.NewEncoder(w).Encode(inputOutput)
 
@@ -65,6 +70,24 @@ func (suite *ClientSuite) SetupTest() {
 		case r.URL.Path == "/input/1" && r.Method == http.MethodDelete:
 			w.WriteHeader(http.StatusOK)
 
+		case r.URL.Path == "/input" && r.Method == http.MethodGet && r.URL.Query().Get("provider") == "paged":
+			// Serve two pages chained by their cursor, and fail on the "broken" cursor
+			page := outputdto.InputPageDTO{Items: []outputdto.InputDTO{{ID: "1"}, {ID: "2"}}, NextCursor: "next"}
+			switch r.URL.Query().Get("cursor") {
+			case "next":
+				page = outputdto.InputPageDTO{Items: []outputdto.InputDTO{{ID: "3"}}}
+			case "broken":
+				http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(page)
+
+		case r.URL.Path == "/input" && r.Method == http.MethodGet && r.URL.RawQuery != "":
+			// Echo the query, so the tests check the parameters sent
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(outputdto.InputPageDTO{Items: []outputdto.InputDTO{{ID: r.URL.Query().Encode()}}})
+
 		case r.URL.Path == "/input" && r.Method == http.MethodGet:
 			inputs := []outputdto.InputDTO{
 				{
@@ -77,7 +100,7 @@ func (suite *ClientSuite) SetupTest() {
 				},
 			}
 			w.WriteHeader(http.StatusOK)
-			json.NewEncoder(w).Encode(inputs)
+			json.NewEncoder(w).Encode(outputdto.InputPageDTO{Items: inputs})
 
 		case r.URL.Path == "/input/1" && r.Method == http.MethodGet:
 			inputOutput := outputdto.InputDTO{
@@ -120,7 +143,7 @@ func (suite *ClientSuite) SetupTest() {
 				},
 			}
 			w.WriteHeader(http.StatusOK)
-			json.NewEncoder(w).Encode(inputs)
+			json.NewEncoder(w).Encode(outputdto.InputPageDTO{Items: inputs})
 
 		case r.URL.Path == "/input/provider/test_provider/source/test_source" && r.Method == http.MethodGet:
 			inputs := []outputdto.InputDTO{
@@ -134,7 +157,7 @@ func (suite *ClientSuite) SetupTest() {
 				},
 			}
 			w.WriteHeader(http.StatusOK)
-			json.NewEncoder(w).Encode(inputs)
+			json.NewEncoder(w).Encode(outputdto.InputPageDTO{Items: inputs})
 
 		case r.URL.Path == "/input/provider/test_provider/service/test_service/source/test_source" && r.Method == http.MethodGet:
 			inputs := []outputdto.InputDTO{
@@ -148,7 +171,7 @@ func (suite *ClientSuite) SetupTest() {
 				},
 			}
 			w.WriteHeader(http.StatusOK)
-			json.NewEncoder(w).Encode(inputs)
+			json.NewEncoder(w).Encode(outputdto.InputPageDTO{Items: inputs})
 
 		case r.URL.Path == "/input/provider/test_provider/status/200" && r.Method == http.MethodGet:
 			inputs := []outputdto.InputDTO{
@@ -162,7 +185,7 @@ func (suite *ClientSuite) SetupTest() {
 				},
 			}
 			w.WriteHeader(http.StatusOK)
-			json.NewEncoder(w).Encode(inputs)
+			json.NewEncoder(w).Encode(outputdto.InputPageDTO{Items: inputs})
 
 		case r.URL.Path == "/input/provider/test_provider/service/test_service/status/200" && r.Method == http.MethodGet:
 			inputs := []outputdto.InputDTO{
@@ -176,7 +199,7 @@ func (suite *ClientSuite) SetupTest() {
 				},
 			}
 			w.WriteHeader(http.StatusOK)
-			json.NewEncoder(w).Encode(inputs)
+			json.NewEncoder(w).Encode(outputdto.InputPageDTO{Items: inputs})
 
 		case r.URL.Path == "/input/provider/test_provider/source/test_source/status/200" && r.Method == http.MethodGet:
 			inputs := []outputdto.InputDTO{
@@ -190,7 +213,7 @@ func (suite *ClientSuite) SetupTest() {
 				},
 			}
 			w.WriteHeader(http.StatusOK)
-			json.NewEncoder(w).Encode(inputs)
+			json.NewEncoder(w).Encode(outputdto.InputPageDTO{Items: inputs})
 
 		case r.URL.Path == "/input/provider/test_provider/service/test_service/source/test_source/status/200" && r.Method == http.MethodGet:
 			inputs := []outputdto.InputDTO{
@@ -204,7 +227,7 @@ func (suite *ClientSuite) SetupTest() {
 				},
 			}
 			w.WriteHeader(http.StatusOK)
-			json.NewEncoder(w).Encode(inputs)
+			json.NewEncoder(w).Encode(outputdto.InputPageDTO{Items: inputs})
 
 		default:
 			http.NotFound(w, r)
@@ -246,6 +269,22 @@ func (suite *ClientSuite) TestCreateInputWhenSuccess() {
 	assert.Equal(suite.T(), expectedOutput, inputOutput)
 }
 
+func (suite *ClientSuite) TestCreateInputWithIdempotencyKeyWhenSuccess() {
+	inputInput := inputdto.InputDTO{
+		Provider: "test_provider",
+		Service:  "test_service",
+		Source:   "test_source",
+		Data:     map[string]interface{}{"key": "value"},
+	}
+
+	inputOutput, err := suite.client.CreateInputWithIdempotencyKey(inputInput, "request-1")
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), "1", inputOutput.ID)
+	assert.Equal(suite.T(), "Replayed request-1", inputOutput.Status.Detail)
+	assert.Equal(suite.T(), map[string]string{"Content-Type": "application/json"}, defaultHeaders)
+}
+
 func (suite *ClientSuite) TestUpdateInputWhenSuccess() {
 	inputInput := inputdto.InputDTO{
 		Provider: "test_provider",
@@ -293,6 +332,14 @@ func (suite *ClientSuite) TestListAllInputsWhenSuccess() {
 	assert.Equal(suite.T(), expectedOutput, inputs)
 }
 
+func (suite *ClientSuite) TestListInputsWhenSuccess() {
+	status := 0
+	inputs, err := suite.client.ListInputs(inputdto.InputFilterDTO{Provider: "test_provider", Status: &status})
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), outputdto.InputPageDTO{Items: []outputdto.InputDTO{{ID: "provider=test_provider&status=0"}}}, inputs)
+}
+
 func (suite *ClientSuite) TestGetInputByIDWhenSuccess() {
 	expectedOutput := outputdto.InputDTO{
 		ID:        "1",
@@ -455,3 +502,17 @@ func (suite *ClientSuite) TestListInputsByStatusAndServiceAndSourceAndProviderWh
 	assert.Nil(suite.T(), err)
 	assert.Equal(suite.T(), expectedOutput, inputs)
 }
+
+func (suite *ClientSuite) TestGetInputByIDWhenNotFound() {
+	suite.mockServer.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		problem.Write(w, r, problem.ErrNotFound.WithDetail("input not found: ID unknown"))
+	})
+
+	_, err := suite.client.GetInputByID("unknown")
+
+	assert.ErrorIs(suite.T(), err, problem.ErrNotFound)
+	var problemDetails *problem.Problem
+	assert.True(suite.T(), errors.As(err, &problemDetails))
+	assert.Equal(suite.T(), "input not found: ID unknown", problemDetails.Detail)
+	assert.Equal(suite.T(), "/input/unknown", problemDetails.Instance)
+}
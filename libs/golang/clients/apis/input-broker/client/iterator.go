@@ -0,0 +1,96 @@
+package client
+
+import (
+	inputdto "libs/golang/ddd/dtos/input-broker/input"
+	outputdto "libs/golang/ddd/dtos/input-broker/output"
+)
+
+// InputIterator walks the inputs of a listing page by page, requesting each page when the previous one has been
+// read. It is used like a bufio.Scanner:
+//
+//	it := client.IterateInputs(inputdto.InputFilterDTO{Provider: "acme"})
+//	for it.Next() {
+//	    fmt.Println(it.Input().ID)
+//	}
+//	if err := it.Err(); err != nil {
+//	    log.Fatal(err)
+//	}
+type InputIterator struct {
+	client     *Client
+	pathParams []string
+	filter     inputdto.InputFilterDTO
+	items      []outputdto.InputDTO
+	current    outputdto.InputDTO
+	last       bool
+	err        error
+}
+
+// IterateInputs returns an iterator over the inputs selected by the filter. The limit and sort of the filter
+// apply to every page, and its cursor selects the first page read.
+//
+// Parameters:
+//   - filter: The filter selecting the inputs.
+//
+// Returns:
+//   - *InputIterator: The iterator over the inputs, which sends no request before the first call to Next.
+func (c *Client) IterateInputs(filter inputdto.InputFilterDTO) *InputIterator {
+	return c.iterateInputs([]string{"input"}, filter)
+}
+
+func (c *Client) iterateInputs(pathParams []string, filter inputdto.InputFilterDTO) *InputIterator {
+	return &InputIterator{client: c, pathParams: pathParams, filter: filter}
+}
+
+// Next advances the iterator to the next input, requesting the next page when the current one has been read.
+//
+// Returns:
+//   - bool: true if an input was read, false after the last input or when a request fails.
+func (it *InputIterator) Next() bool {
+	for len(it.items) == 0 {
+		if it.last || it.err != nil {
+			return false
+		}
+		page, err := it.client.listInputPage(it.pathParams, it.filter)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.items = page.Items
+		it.filter.Cursor = page.NextCursor
+		it.last = page.NextCursor == ""
+	}
+	it.current, it.items = it.items[0], it.items[1:]
+	return true
+}
+
+// Input returns the input read by the last call to Next.
+//
+// Returns:
+//   - outputdto.InputDTO: The current input.
+func (it *InputIterator) Input() outputdto.InputDTO {
+	return it.current
+}
+
+// Err returns the error of the request that stopped the iteration, if any.
+//
+// Returns:
+//   - error: The error of the failed request, nil when the iteration reached the last input.
+func (it *InputIterator) Err() error {
+	return it.err
+}
+
+// All reads the remaining inputs of every page.
+//
+// Returns:
+//   - []outputdto.InputDTO: The inputs not read yet.
+//   - error: An error if a request fails.
+func (it *InputIterator) All() ([]outputdto.InputDTO, error) {
+	inputs := []outputdto.InputDTO{}
+	for it.Next() {
+		inputs = append(inputs, it.Input())
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
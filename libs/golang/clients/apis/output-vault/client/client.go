@@ -6,6 +6,7 @@ import (
 	outputdto "libs/golang/ddd/dtos/output-vault/output"
 	"libs/golang/shared/go-request/requests"
 	"net/http"
+	"net/url"
 	"time"
 )
 
@@ -81,6 +82,7 @@ func (c *Client) UpdateOutput(outputInput inputdto.OutputDTO) (outputdto.OutputD
 }
 
 // ListAllOutputs sends a request to retrieve all outputs.
+// Every page is requested, so prefer IterateOutputs to walk a large listing.
 //
 // Returns:
 //   - []outputdto.OutputDTO: A slice of output data transfer objects.
@@ -88,18 +90,21 @@ func (c *Client) UpdateOutput(outputInput inputdto.OutputDTO) (outputdto.OutputD
 func (c *Client) ListAllOutputs() ([]outputdto.OutputDTO, error) {
 	pathParams := []string{"output"}
 
-	req, err := requests.CreateRequest(c.ctx, c.baseURL, pathParams, nil, nil, defaultHeaders, http.MethodGet)
-	if err != nil {
-		return nil, err
-	}
-
-	var outputList []outputdto.OutputDTO
-	err = requests.SendRequest(c.ctx, req, requests.DefaultHTTPClient, &outputList, c.timeout)
-	if err != nil {
-		return nil, err
-	}
+	return c.iterateOutputs(pathParams, inputdto.OutputFilterDTO{}).All()
+}
 
-	return outputList, nil
+// ListOutputs sends a request to retrieve a page of the outputs selected by a filter, as the query parameters
+// `?provider=acme&service=billing&limit=50`. An empty filter selects the first page of every output, and the next page is
+// read by setting the cursor of the filter to the next cursor of the page.
+//
+// Parameters:
+//   - filter: The filter selecting the outputs and the page.
+//
+// Returns:
+//   - outputdto.OutputPageDTO: The page of output data transfer objects, with the cursor of the next page.
+//   - error: An error if the request fails.
+func (c *Client) ListOutputs(filter inputdto.OutputFilterDTO) (outputdto.OutputPageDTO, error) {
+	return c.listOutputPage([]string{"output"}, filter)
 }
 
 // ListOutputByID sends a request to retrieve an output by its ID.
@@ -151,6 +156,7 @@ func (c *Client) DeleteOutput(id string) error {
 }
 
 // ListOutputsByServiceAndProvider sends a request to retrieve outputs by service and provider.
+// Every page is requested, so prefer IterateOutputs to walk a large listing.
 //
 // Parameters:
 //   - service: The service name.
@@ -162,21 +168,11 @@ func (c *Client) DeleteOutput(id string) error {
 func (c *Client) ListOutputsByServiceAndProvider(service, provider string) ([]outputdto.OutputDTO, error) {
 	pathParams := []string{"output", "provider", provider, "service", service}
 
-	req, err := requests.CreateRequest(c.ctx, c.baseURL, pathParams, nil, nil, defaultHeaders, http.MethodGet)
-	if err != nil {
-		return nil, err
-	}
-
-	var outputList []outputdto.OutputDTO
-	err = requests.SendRequest(c.ctx, req, requests.DefaultHTTPClient, &outputList, c.timeout)
-	if err != nil {
-		return nil, err
-	}
-
-	return outputList, nil
+	return c.iterateOutputs(pathParams, inputdto.OutputFilterDTO{}).All()
 }
 
 // ListOutputsBySourceAndProvider sends a request to retrieve outputs by source and provider.
+// Every page is requested, so prefer IterateOutputs to walk a large listing.
 //
 // Parameters:
 //   - source: The source name.
@@ -188,21 +184,11 @@ func (c *Client) ListOutputsByServiceAndProvider(service, provider string) ([]ou
 func (c *Client) ListOutputsBySourceAndProvider(source, provider string) ([]outputdto.OutputDTO, error) {
 	pathParams := []string{"output", "provider", provider, "source", source}
 
-	req, err := requests.CreateRequest(c.ctx, c.baseURL, pathParams, nil, nil, defaultHeaders, http.MethodGet)
-	if err != nil {
-		return nil, err
-	}
-
-	var outputList []outputdto.OutputDTO
-	err = requests.SendRequest(c.ctx, req, requests.DefaultHTTPClient, &outputList, c.timeout)
-	if err != nil {
-		return nil, err
-	}
-
-	return outputList, nil
+	return c.iterateOutputs(pathParams, inputdto.OutputFilterDTO{}).All()
 }
 
 // ListOutputsByServiceAndSourceAndProvider sends a request to retrieve outputs by service, source, and provider.
+// Every page is requested, so prefer IterateOutputs to walk a large listing.
 //
 // Parameters:
 //   - service: The service name.
@@ -215,16 +201,38 @@ func (c *Client) ListOutputsBySourceAndProvider(source, provider string) ([]outp
 func (c *Client) ListOutputsByServiceAndSourceAndProvider(service, source, provider string) ([]outputdto.OutputDTO, error) {
 	pathParams := []string{"output", "provider", provider, "service", service, "source", source}
 
-	req, err := requests.CreateRequest(c.ctx, c.baseURL, pathParams, nil, nil, defaultHeaders, http.MethodGet)
+	return c.iterateOutputs(pathParams, inputdto.OutputFilterDTO{}).All()
+}
+
+// listOutputPage sends a request to retrieve a page of outputs.
+//
+// Parameters:
+//   - pathParams: The path of the listing route.
+//   - filter: The filter selecting the outputs and the page, sent as query parameters.
+//
+// Returns:
+//   - outputdto.OutputPageDTO: The page of output data transfer objects, with the cursor of the next page.
+//   - error: An error if the request fails.
+func (c *Client) listOutputPage(pathParams []string, filter inputdto.OutputFilterDTO) (outputdto.OutputPageDTO, error) {
+	req, err := requests.CreateRequest(c.ctx, c.baseURL, pathParams, queryParams(filter.Values()), nil, defaultHeaders, http.MethodGet)
 	if err != nil {
-		return nil, err
+		return outputdto.OutputPageDTO{}, err
 	}
 
-	var outputList []outputdto.OutputDTO
-	err = requests.SendRequest(c.ctx, req, requests.DefaultHTTPClient, &outputList, c.timeout)
+	var page outputdto.OutputPageDTO
+	err = requests.SendRequest(c.ctx, req, requests.DefaultHTTPClient, &page, c.timeout)
 	if err != nil {
-		return nil, err
+		return outputdto.OutputPageDTO{}, err
 	}
 
-	return outputList, nil
+	return page, nil
+}
+
+// queryParams flattens query parameters into the single values sent by requests.CreateRequest.
+func queryParams(values url.Values) map[string]string {
+	params := make(map[string]string, len(values))
+	for key := range values {
+		params[key] = values.Get(key)
+	}
+	return params
 }
@@ -3,9 +3,11 @@ package client
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	inputdto "libs/golang/ddd/dtos/output-vault/input"
 	outputdto "libs/golang/ddd/dtos/output-vault/output"
 	shareddto "libs/golang/ddd/dtos/output-vault/shared"
+	"libs/golang/shared/go-problem/problem"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -66,6 +68,24 @@ func (suite *ClientTestSuite) SetupTest() {
 			w.WriteHeader(http.StatusOK)
 			json.NewEncoder(w).Encode(outputOutput)
 
+		case r.URL.Path == "/output" && r.Method == http.MethodGet && r.URL.Query().Get("provider") == "paged":
+			// Serve two pages chained by their cursor, and fail on the "broken" cursor
+			page := outputdto.OutputPageDTO{Items: []outputdto.OutputDTO{{ID: "1"}, {ID: "2"}}, NextCursor: "next"}
+			switch r.URL.Query().Get("cursor") {
+			case "next":
+				page = outputdto.OutputPageDTO{Items: []outputdto.OutputDTO{{ID: "3"}}}
+			case "broken":
+				http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(page)
+
+		case r.URL.Path == "/output" && r.Method == http.MethodGet && r.URL.RawQuery != "":
+			// Echo the query, so the tests check the parameters sent
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(outputdto.OutputPageDTO{Items: []outputdto.OutputDTO{{ID: r.URL.Query().Encode()}}})
+
 		case r.URL.Path == "/output" && r.Method == http.MethodGet:
 			outputList := []outputdto.OutputDTO{
 				{
@@ -80,7 +100,7 @@ func (suite *ClientTestSuite) SetupTest() {
 				},
 			}
 			w.WriteHeader(http.StatusOK)
-			json.NewEncoder(w).Encode(outputList)
+			json.NewEncoder(w).Encode(outputdto.OutputPageDTO{Items: outputList})
 
 		case r.URL.Path == "/output/1" && r.Method == http.MethodGet:
 			outputOutput := outputdto.OutputDTO{
@@ -113,7 +133,7 @@ func (suite *ClientTestSuite) SetupTest() {
 				},
 			}
 			w.WriteHeader(http.StatusOK)
-			json.NewEncoder(w).Encode(outputList)
+			json.NewEncoder(w).Encode(outputdto.OutputPageDTO{Items: outputList})
 
 		case r.URL.Path == "/output/provider/provider1/source/source1" && r.Method == http.MethodGet:
 			outputList := []outputdto.OutputDTO{
@@ -129,7 +149,7 @@ func (suite *ClientTestSuite) SetupTest() {
 				},
 			}
 			w.WriteHeader(http.StatusOK)
-			json.NewEncoder(w).Encode(outputList)
+			json.NewEncoder(w).Encode(outputdto.OutputPageDTO{Items: outputList})
 
 		case r.URL.Path == "/output/provider/provider1/service/service1/source/source1" && r.Method == http.MethodGet:
 			outputList := []outputdto.OutputDTO{
@@ -145,7 +165,7 @@ func (suite *ClientTestSuite) SetupTest() {
 				},
 			}
 			w.WriteHeader(http.StatusOK)
-			json.NewEncoder(w).Encode(outputList)
+			json.NewEncoder(w).Encode(outputdto.OutputPageDTO{Items: outputList})
 
 		default:
 			http.NotFound(w, r)
@@ -236,6 +256,13 @@ func (suite *ClientTestSuite) TestListAllOutputsWhenSuccess() {
 	assert.Equal(suite.T(), expectedOutput, outputOutput)
 }
 
+func (suite *ClientTestSuite) TestListOutputsWhenSuccess() {
+	outputList, err := suite.client.ListOutputs(inputdto.OutputFilterDTO{Provider: "provider1", Service: "service1"})
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), outputdto.OutputPageDTO{Items: []outputdto.OutputDTO{{ID: "provider=provider1&service=service1"}}}, outputList)
+}
+
 func (suite *ClientTestSuite) TestListOutputByIDWhenSuccess() {
 	expectedOutput := outputdto.OutputDTO{
 		ID:        "1",
@@ -319,3 +346,17 @@ func (suite *ClientTestSuite) TestListOutputsByServiceAndSourceAndProviderWhenSu
 	assert.Nil(suite.T(), err)
 	assert.Equal(suite.T(), expectedOutput, outputOutput)
 }
+
+func (suite *ClientTestSuite) TestListOutputByIDWhenNotFound() {
+	suite.mockServer.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		problem.Write(w, r, problem.ErrNotFound.WithDetail("output not found: ID unknown"))
+	})
+
+	_, err := suite.client.ListOutputByID("unknown")
+
+	assert.ErrorIs(suite.T(), err, problem.ErrNotFound)
+	var problemDetails *problem.Problem
+	assert.True(suite.T(), errors.As(err, &problemDetails))
+	assert.Equal(suite.T(), "output not found: ID unknown", problemDetails.Detail)
+	assert.Equal(suite.T(), "/output/unknown", problemDetails.Instance)
+}
@@ -0,0 +1,96 @@
+package client
+
+import (
+	inputdto "libs/golang/ddd/dtos/output-vault/input"
+	outputdto "libs/golang/ddd/dtos/output-vault/output"
+)
+
+// OutputIterator walks the outputs of a listing page by page, requesting each page when the previous one has been
+// read. It is used like a bufio.Scanner:
+//
+//	it := client.IterateOutputs(inputdto.OutputFilterDTO{Provider: "acme"})
+//	for it.Next() {
+//	    fmt.Println(it.Output().ID)
+//	}
+//	if err := it.Err(); err != nil {
+//	    log.Fatal(err)
+//	}
+type OutputIterator struct {
+	client     *Client
+	pathParams []string
+	filter     inputdto.OutputFilterDTO
+	items      []outputdto.OutputDTO
+	current    outputdto.OutputDTO
+	last       bool
+	err        error
+}
+
+// IterateOutputs returns an iterator over the outputs selected by the filter. The limit and sort of the filter
+// apply to every page, and its cursor selects the first page read.
+//
+// Parameters:
+//   - filter: The filter selecting the outputs.
+//
+// Returns:
+//   - *OutputIterator: The iterator over the outputs, which sends no request before the first call to Next.
+func (c *Client) IterateOutputs(filter inputdto.OutputFilterDTO) *OutputIterator {
+	return c.iterateOutputs([]string{"output"}, filter)
+}
+
+func (c *Client) iterateOutputs(pathParams []string, filter inputdto.OutputFilterDTO) *OutputIterator {
+	return &OutputIterator{client: c, pathParams: pathParams, filter: filter}
+}
+
+// Next advances the iterator to the next output, requesting the next page when the current one has been read.
+//
+// Returns:
+//   - bool: true if an output was read, false after the last output or when a request fails.
+func (it *OutputIterator) Next() bool {
+	for len(it.items) == 0 {
+		if it.last || it.err != nil {
+			return false
+		}
+		page, err := it.client.listOutputPage(it.pathParams, it.filter)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.items = page.Items
+		it.filter.Cursor = page.NextCursor
+		it.last = page.NextCursor == ""
+	}
+	it.current, it.items = it.items[0], it.items[1:]
+	return true
+}
+
+// Output returns the output read by the last call to Next.
+//
+// Returns:
+//   - outputdto.OutputDTO: The current output.
+func (it *OutputIterator) Output() outputdto.OutputDTO {
+	return it.current
+}
+
+// Err returns the error of the request that stopped the iteration, if any.
+//
+// Returns:
+//   - error: The error of the failed request, nil when the iteration reached the last output.
+func (it *OutputIterator) Err() error {
+	return it.err
+}
+
+// All reads the remaining outputs of every page.
+//
+// Returns:
+//   - []outputdto.OutputDTO: The outputs not read yet.
+//   - error: An error if a request fails.
+func (it *OutputIterator) All() ([]outputdto.OutputDTO, error) {
+	outputs := []outputdto.OutputDTO{}
+	for it.Next() {
+		outputs = append(outputs, it.Output())
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return outputs, nil
+}
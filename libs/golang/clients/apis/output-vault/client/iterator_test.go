@@ -0,0 +1,42 @@
+package client
+
+import (
+	inputdto "libs/golang/ddd/dtos/output-vault/input"
+	outputdto "libs/golang/ddd/dtos/output-vault/output"
+	"libs/golang/shared/go-criteria/criteria"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *ClientTestSuite) TestIterateOutputsWalksEveryPage() {
+	it := suite.client.IterateOutputs(inputdto.OutputFilterDTO{Provider: "paged"})
+
+	var ids []string
+	for it.Next() {
+		ids = append(ids, it.Output().ID)
+	}
+
+	assert.Nil(suite.T(), it.Err())
+	assert.Equal(suite.T(), []string{"1", "2", "3"}, ids)
+}
+
+func (suite *ClientTestSuite) TestIterateOutputsAll() {
+	it := suite.client.IterateOutputs(inputdto.OutputFilterDTO{Provider: "paged"})
+	assert.True(suite.T(), it.Next())
+
+	rest, err := it.All()
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), []outputdto.OutputDTO{{ID: "2"}, {ID: "3"}}, rest)
+}
+
+func (suite *ClientTestSuite) TestIterateOutputsWhenRequestFails() {
+	it := suite.client.IterateOutputs(inputdto.OutputFilterDTO{Provider: "paged", ListQuery: criteria.ListQuery{Cursor: "broken"}})
+
+	assert.False(suite.T(), it.Next())
+	assert.NotNil(suite.T(), it.Err())
+	assert.False(suite.T(), it.Next())
+
+	_, err := suite.client.IterateOutputs(inputdto.OutputFilterDTO{Provider: "paged", ListQuery: criteria.ListQuery{Cursor: "broken"}}).All()
+	assert.NotNil(suite.T(), err)
+}
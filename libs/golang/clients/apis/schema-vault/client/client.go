@@ -6,6 +6,7 @@ import (
 	outputdto "libs/golang/ddd/dtos/schema-vault/output"
 	"libs/golang/shared/go-request/requests"
 	"net/http"
+	"net/url"
 	"time"
 )
 
@@ -81,6 +82,7 @@ func (c *Client) UpdateSchema(schemaInput inputdto.SchemaDTO) (outputdto.SchemaD
 }
 
 // ListAllSchemas sends a request to retrieve all schemas.
+// Every page is requested, so prefer IterateSchemas to walk a large listing.
 //
 // Returns:
 //   - []outputdto.SchemaDTO: A slice of schema data transfer objects.
@@ -88,18 +90,21 @@ func (c *Client) UpdateSchema(schemaInput inputdto.SchemaDTO) (outputdto.SchemaD
 func (c *Client) ListAllSchemas() ([]outputdto.SchemaDTO, error) {
 	pathParams := []string{"schema"}
 
-	req, err := requests.CreateRequest(c.ctx, c.baseURL, pathParams, nil, nil, defaultHeaders, http.MethodGet)
-	if err != nil {
-		return nil, err
-	}
-
-	var schemaList []outputdto.SchemaDTO
-	err = requests.SendRequest(c.ctx, req, requests.DefaultHTTPClient, &schemaList, c.timeout)
-	if err != nil {
-		return nil, err
-	}
+	return c.iterateSchemas(pathParams, inputdto.SchemaFilterDTO{}).All()
+}
 
-	return schemaList, nil
+// ListSchemas sends a request to retrieve a page of the schemas selected by a filter, as the query parameters
+// `?provider=acme&schema_type=input&limit=50`. An empty filter selects the first page of every schema, and the next page is
+// read by setting the cursor of the filter to the next cursor of the page.
+//
+// Parameters:
+//   - filter: The filter selecting the schemas and the page.
+//
+// Returns:
+//   - outputdto.SchemaPageDTO: The page of schema data transfer objects, with the cursor of the next page.
+//   - error: An error if the request fails.
+func (c *Client) ListSchemas(filter inputdto.SchemaFilterDTO) (outputdto.SchemaPageDTO, error) {
+	return c.listSchemaPage([]string{"schema"}, filter)
 }
 
 // ListSchemaByID sends a request to retrieve a schema by its ID.
@@ -151,6 +156,7 @@ func (c *Client) DeleteSchema(id string) error {
 }
 
 // ListSchemasByServiceAndProvider sends a request to retrieve schemas by service and provider.
+// Every page is requested, so prefer IterateSchemas to walk a large listing.
 //
 // Parameters:
 //   - service: The service name.
@@ -162,21 +168,11 @@ func (c *Client) DeleteSchema(id string) error {
 func (c *Client) ListSchemasByServiceAndProvider(service, provider string) ([]outputdto.SchemaDTO, error) {
 	pathParams := []string{"schema", "provider", provider, "service", service}
 
-	req, err := requests.CreateRequest(c.ctx, c.baseURL, pathParams, nil, nil, defaultHeaders, http.MethodGet)
-	if err != nil {
-		return nil, err
-	}
-
-	var schemaList []outputdto.SchemaDTO
-	err = requests.SendRequest(c.ctx, req, requests.DefaultHTTPClient, &schemaList, c.timeout)
-	if err != nil {
-		return nil, err
-	}
-
-	return schemaList, nil
+	return c.iterateSchemas(pathParams, inputdto.SchemaFilterDTO{}).All()
 }
 
 // ListSchemasBySourceAndProvider sends a request to retrieve schemas by source and provider.
+// Every page is requested, so prefer IterateSchemas to walk a large listing.
 //
 // Parameters:
 //   - source: The source name.
@@ -188,21 +184,11 @@ func (c *Client) ListSchemasByServiceAndProvider(service, provider string) ([]ou
 func (c *Client) ListSchemasBySourceAndProvider(source, provider string) ([]outputdto.SchemaDTO, error) {
 	pathParams := []string{"schema", "provider", provider, "source", source}
 
-	req, err := requests.CreateRequest(c.ctx, c.baseURL, pathParams, nil, nil, defaultHeaders, http.MethodGet)
-	if err != nil {
-		return nil, err
-	}
-
-	var schemaList []outputdto.SchemaDTO
-	err = requests.SendRequest(c.ctx, req, requests.DefaultHTTPClient, &schemaList, c.timeout)
-	if err != nil {
-		return nil, err
-	}
-
-	return schemaList, nil
+	return c.iterateSchemas(pathParams, inputdto.SchemaFilterDTO{}).All()
 }
 
 // ListSchemasByServiceAndSourceAndProvider sends a request to retrieve schemas by service, source, and provider.
+// Every page is requested, so prefer IterateSchemas to walk a large listing.
 //
 // Parameters:
 //   - service: The service name.
@@ -215,18 +201,7 @@ func (c *Client) ListSchemasBySourceAndProvider(source, provider string) ([]outp
 func (c *Client) ListSchemasByServiceAndSourceAndProvider(service, source, provider string) ([]outputdto.SchemaDTO, error) {
 	pathParams := []string{"schema", "provider", provider, "service", service, "source", source}
 
-	req, err := requests.CreateRequest(c.ctx, c.baseURL, pathParams, nil, nil, defaultHeaders, http.MethodGet)
-	if err != nil {
-		return nil, err
-	}
-
-	var schemaList []outputdto.SchemaDTO
-	err = requests.SendRequest(c.ctx, req, requests.DefaultHTTPClient, &schemaList, c.timeout)
-	if err != nil {
-		return nil, err
-	}
-
-	return schemaList, nil
+	return c.iterateSchemas(pathParams, inputdto.SchemaFilterDTO{}).All()
 }
 
 // ListSchemaByServiceAndSourceAndProviderAndSchemaType sends a request to retrieve schemas by service, source, provider, and schema type.
@@ -279,3 +254,36 @@ func (c *Client) ValidateSchema(schemaData inputdto.SchemaDataDTO) error {
 
 	return nil
 }
+
+// listSchemaPage sends a request to retrieve a page of schemas.
+//
+// Parameters:
+//   - pathParams: The path of the listing route.
+//   - filter: The filter selecting the schemas and the page, sent as query parameters.
+//
+// Returns:
+//   - outputdto.SchemaPageDTO: The page of schema data transfer objects, with the cursor of the next page.
+//   - error: An error if the request fails.
+func (c *Client) listSchemaPage(pathParams []string, filter inputdto.SchemaFilterDTO) (outputdto.SchemaPageDTO, error) {
+	req, err := requests.CreateRequest(c.ctx, c.baseURL, pathParams, queryParams(filter.Values()), nil, defaultHeaders, http.MethodGet)
+	if err != nil {
+		return outputdto.SchemaPageDTO{}, err
+	}
+
+	var page outputdto.SchemaPageDTO
+	err = requests.SendRequest(c.ctx, req, requests.DefaultHTTPClient, &page, c.timeout)
+	if err != nil {
+		return outputdto.SchemaPageDTO{}, err
+	}
+
+	return page, nil
+}
+
+// queryParams flattens query parameters into the single values sent by requests.CreateRequest.
+func queryParams(values url.Values) map[string]string {
+	params := make(map[string]string, len(values))
+	for key := range values {
+		params[key] = values.Get(key)
+	}
+	return params
+}
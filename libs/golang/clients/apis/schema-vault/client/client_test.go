@@ -3,9 +3,11 @@ package client
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	inputdto "libs/golang/ddd/dtos/schema-vault/input"
 	outputdto "libs/golang/ddd/dtos/schema-vault/output"
 	shareddto "libs/golang/ddd/dtos/schema-vault/shared"
+	"libs/golang/shared/go-problem/problem"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -68,6 +70,24 @@ func (suite *ClientTestSuite) SetupTest() {
 			w.WriteHeader(http.StatusOK)
 			json.NewEncoder(w).Encode(schemaOutput)
 
+		case r.URL.Path == "/schema" && r.Method == http.MethodGet && r.URL.Query().Get("provider") == "paged":
+			// Serve two pages chained by their cursor, and fail on the "broken" cursor
+			page := outputdto.SchemaPageDTO{Items: []outputdto.SchemaDTO{{ID: "1"}, {ID: "2"}}, NextCursor: "next"}
+			switch r.URL.Query().Get("cursor") {
+			case "next":
+				page = outputdto.SchemaPageDTO{Items: []outputdto.SchemaDTO{{ID: "3"}}}
+			case "broken":
+				http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(page)
+
+		case r.URL.Path == "/schema" && r.Method == http.MethodGet && r.URL.RawQuery != "":
+			// Echo the query, so the tests check the parameters sent
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(outputdto.SchemaPageDTO{Items: []outputdto.SchemaDTO{{ID: r.URL.Query().Encode()}}})
+
 		case r.URL.Path == "/schema" && r.Method == http.MethodGet:
 			schemaList := []outputdto.SchemaDTO{
 				{
@@ -83,7 +103,7 @@ func (suite *ClientTestSuite) SetupTest() {
 				},
 			}
 			w.WriteHeader(http.StatusOK)
-			json.NewEncoder(w).Encode(schemaList)
+			json.NewEncoder(w).Encode(outputdto.SchemaPageDTO{Items: schemaList})
 
 		case r.URL.Path == "/schema/1" && r.Method == http.MethodGet:
 			schemaOutput := outputdto.SchemaDTO{
@@ -118,7 +138,7 @@ func (suite *ClientTestSuite) SetupTest() {
 				},
 			}
 			w.WriteHeader(http.StatusOK)
-			json.NewEncoder(w).Encode(schemaList)
+			json.NewEncoder(w).Encode(outputdto.SchemaPageDTO{Items: schemaList})
 
 		case r.URL.Path == "/schema/provider/provider1/service/service1/source/source1" && r.Method == http.MethodGet:
 			schemaList := []outputdto.SchemaDTO{
@@ -135,7 +155,7 @@ func (suite *ClientTestSuite) SetupTest() {
 				},
 			}
 			w.WriteHeader(http.StatusOK)
-			json.NewEncoder(w).Encode(schemaList)
+			json.NewEncoder(w).Encode(outputdto.SchemaPageDTO{Items: schemaList})
 
 		case r.URL.Path == "/schema/provider/provider1/source/source1" && r.Method == http.MethodGet:
 			schemaList := []outputdto.SchemaDTO{
@@ -152,7 +172,7 @@ func (suite *ClientTestSuite) SetupTest() {
 				},
 			}
 			w.WriteHeader(http.StatusOK)
-			json.NewEncoder(w).Encode(schemaList)
+			json.NewEncoder(w).Encode(outputdto.SchemaPageDTO{Items: schemaList})
 
 		case r.URL.Path == "/schema/provider/provider1/service/service1/source/source1/schema-type/input" && r.Method == http.MethodGet:
 			schema := outputdto.SchemaDTO{
@@ -272,6 +292,13 @@ func (suite *ClientTestSuite) TestListAllSchemasWhenSuccess() {
 	assert.Equal(suite.T(), expectedOutput, schemaOutput)
 }
 
+func (suite *ClientTestSuite) TestListSchemasWhenSuccess() {
+	schemaList, err := suite.client.ListSchemas(inputdto.SchemaFilterDTO{Provider: "provider1", SchemaType: "input"})
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), outputdto.SchemaPageDTO{Items: []outputdto.SchemaDTO{{ID: "provider=provider1&schema_type=input"}}}, schemaList)
+}
+
 func (suite *ClientTestSuite) TestListSchemaByIDWhenSuccess() {
 	expectedOutput := outputdto.SchemaDTO{
 		ID:              "1",
@@ -443,3 +470,17 @@ func (suite *ClientTestSuite) TestListSchemaByServiceAndSourceAndProviderAndSche
 	assert.Contains(suite.T(), err.Error(), "404")
 	assert.Equal(suite.T(), outputdto.SchemaDTO{}, schemaOutput)
 }
+
+func (suite *ClientTestSuite) TestListSchemaByIDWhenNotFound() {
+	suite.mockServer.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		problem.Write(w, r, problem.ErrNotFound.WithDetail("schema not found: ID unknown"))
+	})
+
+	_, err := suite.client.ListSchemaByID("unknown")
+
+	assert.ErrorIs(suite.T(), err, problem.ErrNotFound)
+	var problemDetails *problem.Problem
+	assert.True(suite.T(), errors.As(err, &problemDetails))
+	assert.Equal(suite.T(), "schema not found: ID unknown", problemDetails.Detail)
+	assert.Equal(suite.T(), "/schema/unknown", problemDetails.Instance)
+}
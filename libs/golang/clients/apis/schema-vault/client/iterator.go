@@ -0,0 +1,96 @@
+package client
+
+import (
+	inputdto "libs/golang/ddd/dtos/schema-vault/input"
+	outputdto "libs/golang/ddd/dtos/schema-vault/output"
+)
+
+// SchemaIterator walks the schemas of a listing page by page, requesting each page when the previous one has been
+// read. It is used like a bufio.Scanner:
+//
+//	it := client.IterateSchemas(inputdto.SchemaFilterDTO{Provider: "acme"})
+//	for it.Next() {
+//	    fmt.Println(it.Schema().ID)
+//	}
+//	if err := it.Err(); err != nil {
+//	    log.Fatal(err)
+//	}
+type SchemaIterator struct {
+	client     *Client
+	pathParams []string
+	filter     inputdto.SchemaFilterDTO
+	items      []outputdto.SchemaDTO
+	current    outputdto.SchemaDTO
+	last       bool
+	err        error
+}
+
+// IterateSchemas returns an iterator over the schemas selected by the filter. The limit and sort of the filter
+// apply to every page, and its cursor selects the first page read.
+//
+// Parameters:
+//   - filter: The filter selecting the schemas.
+//
+// Returns:
+//   - *SchemaIterator: The iterator over the schemas, which sends no request before the first call to Next.
+func (c *Client) IterateSchemas(filter inputdto.SchemaFilterDTO) *SchemaIterator {
+	return c.iterateSchemas([]string{"schema"}, filter)
+}
+
+func (c *Client) iterateSchemas(pathParams []string, filter inputdto.SchemaFilterDTO) *SchemaIterator {
+	return &SchemaIterator{client: c, pathParams: pathParams, filter: filter}
+}
+
+// Next advances the iterator to the next schema, requesting the next page when the current one has been read.
+//
+// Returns:
+//   - bool: true if a schema was read, false after the last schema or when a request fails.
+func (it *SchemaIterator) Next() bool {
+	for len(it.items) == 0 {
+		if it.last || it.err != nil {
+			return false
+		}
+		page, err := it.client.listSchemaPage(it.pathParams, it.filter)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.items = page.Items
+		it.filter.Cursor = page.NextCursor
+		it.last = page.NextCursor == ""
+	}
+	it.current, it.items = it.items[0], it.items[1:]
+	return true
+}
+
+// Schema returns the schema read by the last call to Next.
+//
+// Returns:
+//   - outputdto.SchemaDTO: The current schema.
+func (it *SchemaIterator) Schema() outputdto.SchemaDTO {
+	return it.current
+}
+
+// Err returns the error of the request that stopped the iteration, if any.
+//
+// Returns:
+//   - error: The error of the failed request, nil when the iteration reached the last schema.
+func (it *SchemaIterator) Err() error {
+	return it.err
+}
+
+// All reads the remaining schemas of every page.
+//
+// Returns:
+//   - []outputdto.SchemaDTO: The schemas not read yet.
+//   - error: An error if a request fails.
+func (it *SchemaIterator) All() ([]outputdto.SchemaDTO, error) {
+	schemas := []outputdto.SchemaDTO{}
+	for it.Next() {
+		schemas = append(schemas, it.Schema())
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return schemas, nil
+}
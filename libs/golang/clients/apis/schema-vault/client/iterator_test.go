@@ -0,0 +1,42 @@
+package client
+
+import (
+	inputdto "libs/golang/ddd/dtos/schema-vault/input"
+	outputdto "libs/golang/ddd/dtos/schema-vault/output"
+	"libs/golang/shared/go-criteria/criteria"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *ClientTestSuite) TestIterateSchemasWalksEveryPage() {
+	it := suite.client.IterateSchemas(inputdto.SchemaFilterDTO{Provider: "paged"})
+
+	var ids []string
+	for it.Next() {
+		ids = append(ids, it.Schema().ID)
+	}
+
+	assert.Nil(suite.T(), it.Err())
+	assert.Equal(suite.T(), []string{"1", "2", "3"}, ids)
+}
+
+func (suite *ClientTestSuite) TestIterateSchemasAll() {
+	it := suite.client.IterateSchemas(inputdto.SchemaFilterDTO{Provider: "paged"})
+	assert.True(suite.T(), it.Next())
+
+	rest, err := it.All()
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), []outputdto.SchemaDTO{{ID: "2"}, {ID: "3"}}, rest)
+}
+
+func (suite *ClientTestSuite) TestIterateSchemasWhenRequestFails() {
+	it := suite.client.IterateSchemas(inputdto.SchemaFilterDTO{Provider: "paged", ListQuery: criteria.ListQuery{Cursor: "broken"}})
+
+	assert.False(suite.T(), it.Next())
+	assert.NotNil(suite.T(), it.Err())
+	assert.False(suite.T(), it.Next())
+
+	_, err := suite.client.IterateSchemas(inputdto.SchemaFilterDTO{Provider: "paged", ListQuery: criteria.ListQuery{Cursor: "broken"}}).All()
+	assert.NotNil(suite.T(), err)
+}
@@ -158,23 +158,23 @@ func (c *Client) FindOne(
 //
 // Parameters:
 //   - collectionName: The name of the collection to search.
+//   - opts: Optional sort keys, projection, skip and limit. Without options the order is unspecified.
 //
 // Returns:
 //   - A slice of documents if found.
-//   - An error if the collection does not exist.
+//   - An error if the collection does not exist or the options are invalid.
 func (c *Client) FindAll(
 	collectionName string,
+	opts ...*database.FindOptions,
 ) ([]map[string]interface{}, error) {
+	if len(opts) > 0 {
+		return c.Find(collectionName, nil, opts...)
+	}
 	collection, err := c.getCollection(collectionName)
 	if err != nil {
 		return nil, err
 	}
-	docs := collection.FindAll()
-	documents := make([]map[string]interface{}, 0, len(docs))
-	for _, doc := range docs {
-		documents = append(documents, map[string]interface{}(doc))
-	}
-	return documents, nil
+	return toMaps(collection.FindAll()), nil
 }
 
 // Find searches for documents matching a given query in the specified collection.
@@ -182,24 +182,99 @@ func (c *Client) FindAll(
 // Parameters:
 //   - collectionName: The name of the collection to search.
 //   - filter: The query criteria to match documents against.
+//   - opts: Optional sort keys, projection, skip and limit. Without options the order is unspecified.
 //
 // Returns:
 //   - A slice of documents that match the query.
-//   - An error if the collection does not exist.
+//   - An error if the collection does not exist or the options are invalid.
 func (c *Client) Find(
 	collectionName string,
 	filter map[string]interface{},
+	opts ...*database.FindOptions,
 ) ([]map[string]interface{}, error) {
 	collection, err := c.getCollection(collectionName)
 	if err != nil {
 		return nil, err
 	}
-	docs := collection.Find(filter)
+	if len(opts) == 0 {
+		return toMaps(collection.Find(filter)), nil
+	}
+	docs, err := collection.FindWithOptions(filter, mergeFindOptions(opts))
+	if err != nil {
+		return nil, err
+	}
+	return toMaps(docs), nil
+}
+
+// FindCursor searches for documents matching a given query in the specified collection
+// and returns a cursor over them, like the Find method of the MongoDB driver.
+//
+// Parameters:
+//   - collectionName: The name of the collection to search.
+//   - filter: The query criteria to match documents against.
+//   - opts: Optional sort keys, projection, skip and limit. Documents are ordered by "_id" by default.
+//
+// Returns:
+//   - A cursor over the matching documents.
+//   - An error if the collection does not exist or the options are invalid.
+func (c *Client) FindCursor(
+	collectionName string,
+	filter map[string]interface{},
+	opts ...*database.FindOptions,
+) (*Cursor, error) {
+	collection, err := c.getCollection(collectionName)
+	if err != nil {
+		return nil, err
+	}
+	docs, err := collection.FindWithOptions(filter, mergeFindOptions(opts))
+	if err != nil {
+		return nil, err
+	}
+	return newCursor(toMaps(docs)), nil
+}
+
+// mergeFindOptions combines several FindOptions; later non-zero settings win.
+//
+// Parameters:
+//   - opts: The options to merge, nil entries are ignored.
+//
+// Returns:
+//   - The merged options.
+func mergeFindOptions(opts []*database.FindOptions) *database.FindOptions {
+	merged := &database.FindOptions{}
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if opt.Sort != nil {
+			merged.Sort = opt.Sort
+		}
+		if opt.Projection != nil {
+			merged.Projection = opt.Projection
+		}
+		if opt.Skip != 0 {
+			merged.Skip = opt.Skip
+		}
+		if opt.Limit != 0 {
+			merged.Limit = opt.Limit
+		}
+	}
+	return merged
+}
+
+// toMaps converts documents to plain maps.
+//
+// Parameters:
+//   - docs: The documents to convert.
+//
+// Returns:
+//   - The documents as maps.
+func toMaps(docs []database.Document) []map[string]interface{} {
 	documents := make([]map[string]interface{}, 0, len(docs))
 	for _, doc := range docs {
 		documents = append(documents, map[string]interface{}(doc))
 	}
-	return documents, nil
+	return documents
 }
 
 // UpdateOne updates a document by its ID in the specified collection.
@@ -226,6 +301,60 @@ func (c *Client) UpdateOne(
 	return collection.UpdateOne(id, update)
 }
 
+// UpdateByID updates a document by its ID in the specified collection and reports what changed.
+//
+// Parameters:
+//   - collectionName: The name of the collection to update the document in.
+//   - id: The ID of the document to update.
+//   - update: The fields to merge into the document, or update operators such as {"$set": {...}}.
+//   - opts: Optional settings, such as database.WithUpsert().
+//
+// Returns:
+//   - The number of matched, modified and upserted documents.
+//   - An error if the collection does not exist or the update is invalid.
+func (c *Client) UpdateByID(
+	collectionName string,
+	id string,
+	update map[string]interface{},
+	opts ...database.UpdateOption,
+) (*database.UpdateResult, error) {
+	collection, err := c.getCollection(collectionName)
+	if err != nil {
+		return nil, err
+	}
+	if len(update) == 0 {
+		return nil, errors.New("update is empty")
+	}
+	return collection.UpdateByID(id, update, opts...)
+}
+
+// UpdateMany updates every document matching a filter in the specified collection.
+//
+// Parameters:
+//   - collectionName: The name of the collection to update the documents in.
+//   - filter: The query selecting the documents to update.
+//   - update: The fields to merge into the documents, or update operators such as {"$set": {...}}.
+//   - opts: Optional settings, such as database.WithUpsert().
+//
+// Returns:
+//   - The number of matched, modified and upserted documents.
+//   - An error if the collection does not exist or the update is invalid.
+func (c *Client) UpdateMany(
+	collectionName string,
+	filter map[string]interface{},
+	update map[string]interface{},
+	opts ...database.UpdateOption,
+) (*database.UpdateResult, error) {
+	collection, err := c.getCollection(collectionName)
+	if err != nil {
+		return nil, err
+	}
+	if len(update) == 0 {
+		return nil, errors.New("update is empty")
+	}
+	return collection.UpdateMany(filter, update, opts...)
+}
+
 // DeleteOne deletes a document by its ID from the specified collection.
 //
 // Parameters:
@@ -261,3 +390,107 @@ func (c *Client) DeleteAll(
 	}
 	return collection.DeleteAll()
 }
+
+// CreateIndex creates an index over the given fields of the specified collection.
+//
+// Parameters:
+//   - collectionName: The name of the collection to index.
+//   - fields: The fields covered by the index, which may be dotted paths such as "metadata.provider".
+//
+// Returns:
+//   - An error if the collection does not exist or the index cannot be created.
+func (c *Client) CreateIndex(
+	collectionName string,
+	fields ...string,
+) error {
+	collection, err := c.getCollection(collectionName)
+	if err != nil {
+		return err
+	}
+	return collection.CreateIndex(fields...)
+}
+
+// CreateUniqueIndex creates a unique index over the given fields of the specified collection.
+//
+// Parameters:
+//   - collectionName: The name of the collection to index.
+//   - fields: The fields covered by the index, which may be dotted paths such as "metadata.provider".
+//
+// Returns:
+//   - An error if the collection does not exist, already contains duplicates, or the index cannot be created.
+func (c *Client) CreateUniqueIndex(
+	collectionName string,
+	fields ...string,
+) error {
+	collection, err := c.getCollection(collectionName)
+	if err != nil {
+		return err
+	}
+	return collection.CreateUniqueIndex(fields...)
+}
+
+// Explain reports how a query on the specified collection is executed.
+//
+// Parameters:
+//   - collectionName: The name of the collection to search.
+//   - filter: The query criteria to explain.
+//
+// Returns:
+//   - The plan of the query.
+//   - An error if the collection does not exist.
+func (c *Client) Explain(
+	collectionName string,
+	filter map[string]interface{},
+) (database.QueryPlan, error) {
+	collection, err := c.getCollection(collectionName)
+	if err != nil {
+		return database.QueryPlan{}, err
+	}
+	return collection.Explain(filter), nil
+}
+
+// StartSession starts a session on the database, like the StartSession method of the MongoDB driver.
+//
+// Returns:
+//   - A pointer to the new session. Call EndSession when done.
+func (c *Client) StartSession() *database.Session {
+	return c.db.StartSession()
+}
+
+// WithTransaction runs fn in a transaction on a new session and commits it. Writes made
+// through tx across collections are applied atomically; an error returned by fn rolls them back.
+// Write conflicts with concurrent writers restart the transaction a few times before failing.
+//
+// Parameters:
+//   - fn: The function performing the reads and writes through tx.
+//
+// Returns:
+//   - The error of fn, or of the commit.
+func (c *Client) WithTransaction(fn func(tx *database.Transaction) error) error {
+	session := c.db.StartSession()
+	defer session.EndSession()
+	return session.WithTransaction(fn)
+}
+
+// Watch opens a change stream on the specified collection, like the Watch method of the MongoDB driver.
+// The filter applies to the change events, for instance {"operationType": "update", "fullDocument.stage": "completed"}.
+//
+// Parameters:
+//   - collectionName: The name of the collection to watch.
+//   - filter: The query selecting the events, or nil for all of them.
+//   - opts: Optional settings, such as database.WithResumeAfter.
+//
+// Returns:
+//   - The change stream. Close it when done.
+//   - An error if the collection does not exist or the stream cannot resume from the given token.
+func (c *Client) Watch(
+	collectionName string,
+	filter map[string]interface{},
+	opts ...database.WatchOption,
+) (*database.ChangeStream, error) {
+	collection, err := c.getCollection(collectionName)
+	if err != nil {
+		return nil, err
+	}
+	return collection.Watch(filter, opts...)
+}
@@ -379,3 +379,202 @@ func (suite *InMemoryDocDBClientTestSuite) TestClientDeleteAllError() {
 	err = suite.client.DeleteAll(suite.collectionName2)
 	assert.NotNil(suite.T(), err)
 }
+
+func (suite *InMemoryDocDBClientTestSuite) TestClientCreateIndex() {
+	err := suite.client.CreateCollection(suite.collectionName1)
+	assert.Nil(suite.T(), err)
+
+	err = suite.client.InsertOne(suite.collectionName1, suite.document1)
+	assert.Nil(suite.T(), err)
+
+	err = suite.client.InsertOne(suite.collectionName1, suite.document2)
+	assert.Nil(suite.T(), err)
+
+	err = suite.client.CreateIndex(suite.collectionName1, "name")
+	assert.Nil(suite.T(), err)
+
+	plan, err := suite.client.Explain(suite.collectionName1, map[string]interface{}{"name": "Bob"})
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), database.ScanIndex, plan.Strategy)
+	assert.Equal(suite.T(), 1, plan.DocumentsExamined)
+	assert.Equal(suite.T(), 1, plan.DocumentsReturned)
+}
+
+func (suite *InMemoryDocDBClientTestSuite) TestClientCreateIndexError() {
+	err := suite.client.CreateIndex(suite.collectionName1, "name")
+	assert.NotNil(suite.T(), err)
+
+	_, err = suite.client.Explain(suite.collectionName1, map[string]interface{}{"name": "Bob"})
+	assert.NotNil(suite.T(), err)
+}
+
+func (suite *InMemoryDocDBClientTestSuite) TestClientCreateUniqueIndex() {
+	err := suite.client.CreateCollection(suite.collectionName1)
+	assert.Nil(suite.T(), err)
+
+	err = suite.client.CreateUniqueIndex(suite.collectionName1, "name")
+	assert.Nil(suite.T(), err)
+
+	err = suite.client.InsertOne(suite.collectionName1, suite.document1)
+	assert.Nil(suite.T(), err)
+
+	err = suite.client.InsertOne(suite.collectionName1, map[string]interface{}{"_id": "3", "name": "Alice"})
+	assert.ErrorIs(suite.T(), err, database.ErrDuplicateKey)
+}
+
+func (suite *InMemoryDocDBClientTestSuite) TestClientUpdateByID() {
+	err := suite.client.CreateCollection(suite.collectionName1)
+	assert.Nil(suite.T(), err)
+
+	err = suite.client.InsertOne(suite.collectionName1, suite.document1)
+	assert.Nil(suite.T(), err)
+
+	result, err := suite.client.UpdateByID(suite.collectionName1, "1", map[string]interface{}{
+		"$inc": map[string]interface{}{"age": 1},
+	})
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 1, result.ModifiedCount)
+
+	document, err := suite.client.FindOne(suite.collectionName1, "1")
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 31, document["age"])
+
+	result, err = suite.client.UpdateByID(suite.collectionName1, "3", map[string]interface{}{
+		"$set": map[string]interface{}{"name": "Carol"},
+	}, database.WithUpsert())
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), "3", result.UpsertedID)
+}
+
+func (suite *InMemoryDocDBClientTestSuite) TestClientUpdateByIDError() {
+	_, err := suite.client.UpdateByID(suite.collectionName1, "1", map[string]interface{}{"age": 1})
+	assert.NotNil(suite.T(), err)
+
+	err = suite.client.CreateCollection(suite.collectionName1)
+	assert.Nil(suite.T(), err)
+
+	_, err = suite.client.UpdateByID(suite.collectionName1, "1", map[string]interface{}{})
+	assert.NotNil(suite.T(), err)
+}
+
+func (suite *InMemoryDocDBClientTestSuite) TestClientUpdateMany() {
+	err := suite.client.CreateCollection(suite.collectionName1)
+	assert.Nil(suite.T(), err)
+
+	err = suite.client.InsertOne(suite.collectionName1, suite.document1)
+	assert.Nil(suite.T(), err)
+
+	err = suite.client.InsertOne(suite.collectionName1, suite.document2)
+	assert.Nil(suite.T(), err)
+
+	result, err := suite.client.UpdateMany(
+		suite.collectionName1,
+		map[string]interface{}{"age": map[string]interface{}{"$lt": 100}},
+		map[string]interface{}{"$set": map[string]interface{}{"active": true}},
+	)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 2, result.MatchedCount)
+	assert.Equal(suite.T(), 2, result.ModifiedCount)
+
+	documents, err := suite.client.Find(suite.collectionName1, map[string]interface{}{"active": true})
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 2, len(documents))
+}
+
+func (suite *InMemoryDocDBClientTestSuite) TestClientUpdateManyError() {
+	_, err := suite.client.UpdateMany(suite.collectionName1, map[string]interface{}{}, map[string]interface{}{"age": 1})
+	assert.NotNil(suite.T(), err)
+}
+
+func (suite *InMemoryDocDBClientTestSuite) TestClientFindWithOptions() {
+	err := suite.client.CreateCollection(suite.collectionName1)
+	assert.Nil(suite.T(), err)
+
+	err = suite.client.InsertOne(suite.collectionName1, suite.document1)
+	assert.Nil(suite.T(), err)
+
+	err = suite.client.InsertOne(suite.collectionName1, suite.document2)
+	assert.Nil(suite.T(), err)
+
+	documents, err := suite.client.Find(suite.collectionName1, nil, &database.FindOptions{
+		Sort:       []database.SortField{{Field: "age", Order: 1}},
+		Projection: map[string]int{"name": 1},
+	})
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), []map[string]interface{}{
+		{"_id": "2", "name": "Bob"},
+		{"_id": "1", "name": "Alice"},
+	}, documents)
+
+	documents, err = suite.client.FindAll(suite.collectionName1, &database.FindOptions{Skip: 1})
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), []map[string]interface{}{suite.document2}, documents)
+
+	_, err = suite.client.Find(suite.collectionName1, nil, &database.FindOptions{Limit: -1})
+	assert.NotNil(suite.T(), err)
+}
+
+func (suite *InMemoryDocDBClientTestSuite) TestClientWithTransaction() {
+	err := suite.client.CreateCollection(suite.collectionName1)
+	assert.Nil(suite.T(), err)
+
+	err = suite.client.CreateCollection(suite.collectionName2)
+	assert.Nil(suite.T(), err)
+
+	err = suite.client.InsertOne(suite.collectionName1, suite.document1)
+	assert.Nil(suite.T(), err)
+
+	err = suite.client.WithTransaction(func(tx *database.Transaction) error {
+		if err := tx.InsertOne(suite.collectionName2, map[string]interface{}{"_id": "p1", "owner": "1"}); err != nil {
+			return err
+		}
+		return tx.UpdateOne(suite.collectionName1, "1", map[string]interface{}{"$push": map[string]interface{}{"products": "p1"}})
+	})
+	assert.Nil(suite.T(), err)
+
+	product, err := suite.client.FindOne(suite.collectionName2, "p1")
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), "1", product["owner"])
+	user, err := suite.client.FindOne(suite.collectionName1, "1")
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), []interface{}{"p1"}, user["products"])
+}
+
+func (suite *InMemoryDocDBClientTestSuite) TestClientWithTransactionError() {
+	err := suite.client.CreateCollection(suite.collectionName1)
+	assert.Nil(suite.T(), err)
+
+	err = suite.client.WithTransaction(func(tx *database.Transaction) error {
+		if err := tx.InsertOne(suite.collectionName1, suite.document1); err != nil {
+			return err
+		}
+		return tx.InsertOne(suite.collectionName2, suite.document2)
+	})
+	assert.NotNil(suite.T(), err)
+
+	documents, err := suite.client.FindAll(suite.collectionName1)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 0, len(documents))
+}
+
+func (suite *InMemoryDocDBClientTestSuite) TestClientWatch() {
+	_, err := suite.client.Watch(suite.collectionName1, nil)
+	assert.NotNil(suite.T(), err)
+
+	err = suite.client.CreateCollection(suite.collectionName1)
+	assert.Nil(suite.T(), err)
+
+	stream, err := suite.client.Watch(suite.collectionName1, map[string]interface{}{"operationType": database.OperationInsert})
+	assert.Nil(suite.T(), err)
+	defer stream.Close()
+
+	err = suite.client.InsertOne(suite.collectionName1, suite.document1)
+	assert.Nil(suite.T(), err)
+	err = suite.client.DeleteOne(suite.collectionName1, "1")
+	assert.Nil(suite.T(), err)
+
+	event := <-stream.Events()
+	assert.Equal(suite.T(), database.OperationInsert, event.OperationType)
+	assert.Equal(suite.T(), "1", event.DocumentKey)
+	assert.Equal(suite.T(), 0, len(stream.Events()))
+}
@@ -0,0 +1,156 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Cursor iterates over the result of a query, with the same methods as the cursor of the
+// MongoDB driver so repositories can be written against a single shape for both backends.
+//
+// The result is captured when the query runs; later writes do not affect an open cursor.
+type Cursor struct {
+	// Current is the document the cursor points to after a successful call to Next.
+	Current   map[string]interface{}
+	documents []map[string]interface{}
+	position  int
+	err       error
+	closed    bool
+}
+
+// newCursor creates a cursor over the given documents.
+//
+// Parameters:
+//   - documents: The documents returned by the query, in order.
+//
+// Returns:
+//   - A pointer to the newly created Cursor, positioned before the first document.
+func newCursor(documents []map[string]interface{}) *Cursor {
+	return &Cursor{documents: documents}
+}
+
+// Next advances the cursor to the next document.
+//
+// Parameters:
+//   - ctx: The context of the iteration.
+//
+// Returns:
+//   - True if Current holds a new document; false when the cursor is exhausted,
+//     closed, or the context is done, in which case Err reports the cause.
+func (c *Cursor) Next(ctx context.Context) bool {
+	if c.closed || c.err != nil {
+		return false
+	}
+	if err := ctx.Err(); err != nil {
+		c.err = err
+		return false
+	}
+	if c.position >= len(c.documents) {
+		c.Current = nil
+		return false
+	}
+	c.Current = c.documents[c.position]
+	c.position++
+	return true
+}
+
+// Decode decodes the current document into a value.
+//
+// Parameters:
+//   - value: A pointer to a map[string]interface{}, or to a struct decoded using its bson tags.
+//
+// Returns:
+//   - An error if there is no current document or it cannot be decoded into the value.
+func (c *Cursor) Decode(value interface{}) error {
+	if c.Current == nil {
+		return errors.New("cursor has no current document")
+	}
+	return decodeDocument(c.Current, value)
+}
+
+// All decodes every remaining document into a slice and closes the cursor.
+//
+// Parameters:
+//   - ctx: The context of the iteration.
+//   - results: A pointer to a slice of maps or structs.
+//
+// Returns:
+//   - An error if results is not a pointer to a slice, or a document cannot be decoded.
+func (c *Cursor) All(ctx context.Context, results interface{}) error {
+	defer c.Close(ctx)
+	target := reflect.ValueOf(results)
+	if target.Kind() != reflect.Ptr || target.Elem().Kind() != reflect.Slice {
+		return errors.New("results must be a pointer to a slice")
+	}
+	slice := target.Elem()
+	slice.SetLen(0)
+	for c.Next(ctx) {
+		element := reflect.New(slice.Type().Elem())
+		if err := decodeDocument(c.Current, element.Interface()); err != nil {
+			return err
+		}
+		slice.Set(reflect.Append(slice, element.Elem()))
+	}
+	return c.Err()
+}
+
+// RemainingBatchLength returns the number of documents left in the cursor.
+//
+// Returns:
+//   - The number of documents Next has yet to return.
+func (c *Cursor) RemainingBatchLength() int {
+	if c.closed {
+		return 0
+	}
+	return len(c.documents) - c.position
+}
+
+// Err returns the error that stopped the iteration, if any.
+//
+// Returns:
+//   - The error, or nil if the cursor was exhausted or closed normally.
+func (c *Cursor) Err() error {
+	return c.err
+}
+
+// Close releases the documents held by the cursor. Closing twice is a no-op.
+//
+// Parameters:
+//   - ctx: The context of the call, unused but kept for parity with the MongoDB driver.
+//
+// Returns:
+//   - Always nil.
+func (c *Cursor) Close(ctx context.Context) error {
+	c.closed = true
+	c.documents = nil
+	c.Current = nil
+	return nil
+}
+
+// decodeDocument copies a document into a map, or decodes it into a struct through BSON
+// so the bson tags used with the MongoDB driver apply.
+//
+// Parameters:
+//   - document: The document to decode.
+//   - value: A pointer to the destination.
+//
+// Returns:
+//   - An error if the document cannot be decoded into the value.
+func decodeDocument(document map[string]interface{}, value interface{}) error {
+	if target, ok := value.(*map[string]interface{}); ok {
+		copied := make(map[string]interface{}, len(document))
+		for key, field := range document {
+			copied[key] = field
+		}
+		*target = copied
+		return nil
+	}
+	data, err := bson.Marshal(document)
+	if err != nil {
+		return err
+	}
+	return bson.Unmarshal(data, value)
+}
@@ -0,0 +1,99 @@
+package client
+
+import (
+	"context"
+	"libs/golang/database/go-docdb/database"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type person struct {
+	ID   string `bson:"_id"`
+	Name string `bson:"name"`
+	Age  int    `bson:"age"`
+}
+
+type CursorTestSuite struct {
+	suite.Suite
+	client *Client
+	ctx    context.Context
+}
+
+func TestCursorTestSuite(t *testing.T) {
+	suite.Run(t, new(CursorTestSuite))
+}
+
+func (suite *CursorTestSuite) SetupTest() {
+	suite.client = NewClient(database.NewInMemoryDocBD("test-db"))
+	suite.ctx = context.Background()
+	suite.Require().NoError(suite.client.CreateCollection("users"))
+	for _, document := range []map[string]interface{}{
+		{"_id": "1", "name": "Alice", "age": 30},
+		{"_id": "2", "name": "Bob", "age": 25},
+		{"_id": "3", "name": "Carol", "age": 41},
+	} {
+		suite.Require().NoError(suite.client.InsertOne("users", document))
+	}
+}
+
+func (suite *CursorTestSuite) TestNextAndDecode() {
+	cursor, err := suite.client.FindCursor("users", map[string]interface{}{"age": map[string]interface{}{"$gte": 30}})
+	suite.Require().NoError(err)
+	defer cursor.Close(suite.ctx)
+
+	var people []person
+	for cursor.Next(suite.ctx) {
+		var p person
+		suite.Require().NoError(cursor.Decode(&p))
+		people = append(people, p)
+	}
+	suite.NoError(cursor.Err())
+	suite.Equal([]person{{ID: "1", Name: "Alice", Age: 30}, {ID: "3", Name: "Carol", Age: 41}}, people)
+	suite.Error(cursor.Decode(&person{}))
+}
+
+func (suite *CursorTestSuite) TestDecodeIntoMap() {
+	cursor, err := suite.client.FindCursor("users", map[string]interface{}{"_id": "2"})
+	suite.Require().NoError(err)
+
+	suite.True(cursor.Next(suite.ctx))
+	var document map[string]interface{}
+	suite.NoError(cursor.Decode(&document))
+	suite.Equal(map[string]interface{}{"_id": "2", "name": "Bob", "age": 25}, document)
+	suite.False(cursor.Next(suite.ctx))
+}
+
+func (suite *CursorTestSuite) TestAll() {
+	cursor, err := suite.client.FindCursor("users", nil, &database.FindOptions{
+		Sort:  []database.SortField{{Field: "age", Order: -1}},
+		Limit: 2,
+	})
+	suite.Require().NoError(err)
+	suite.Equal(2, cursor.RemainingBatchLength())
+
+	var people []person
+	suite.NoError(cursor.All(suite.ctx, &people))
+	suite.Equal([]string{"Carol", "Alice"}, []string{people[0].Name, people[1].Name})
+	suite.False(cursor.Next(suite.ctx))
+
+	suite.Error(cursor.All(suite.ctx, people))
+}
+
+func (suite *CursorTestSuite) TestCancelledContextStopsIteration() {
+	cursor, err := suite.client.FindCursor("users", nil)
+	suite.Require().NoError(err)
+
+	ctx, cancel := context.WithCancel(suite.ctx)
+	cancel()
+	suite.False(cursor.Next(ctx))
+	suite.ErrorIs(cursor.Err(), context.Canceled)
+}
+
+func (suite *CursorTestSuite) TestFindCursorErrors() {
+	_, err := suite.client.FindCursor("missing", nil)
+	suite.Error(err)
+
+	_, err = suite.client.FindCursor("users", nil, &database.FindOptions{Skip: -1})
+	suite.Error(err)
+}
@@ -44,10 +44,12 @@ func NewClient(config Config) (*Client, error) {
 	uri := fmt.Sprintf("mongodb://%s:%s@%s:%s/%s",
 		config.User, config.Password, config.Host, config.Port, config.DBName)
 
+	// The single configured host is used directly, which also allows transactions
+	// against a single-node replica set whose member name is not resolvable by the client.
 	clientOptions := options.Client().ApplyURI(uri).SetAuth(options.Credential{
 		Username: config.User,
 		Password: config.Password,
-	})
+	}).SetDirect(true)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -2,17 +2,28 @@ package gorabbitmq
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
 	"sync"
 
 	amqp "github.com/rabbitmq/amqp091-go"
 )
 
+var (
+	// ErrQueueArguments is returned when a queue already exists with other arguments than the ones it is
+	// declared with, such as a queue created without a dead-letter exchange. The broker cannot change the
+	// arguments of a queue: it must be deleted once drained, so it is declared again with its dead-letter exchange.
+	ErrQueueArguments = errors.New("queue already exists with other arguments")
+)
+
 // RabbitMQConsumer represents a RabbitMQ consumer.
 type RabbitMQConsumer struct {
 	rmqClient    *Client         // RabbitMQ client instance
 	autoAck      bool            // Automatic acknowledgment flag
 	args         amqp.Table      // Additional arguments for the queue declaration
+	maxRetries   int             // Number of redeliveries allowed before dead-lettering
+	prefetch     int             // Number of unacknowledged messages delivered at once, unlimited when 0
 	ConsumerName string          // Name of the consumer
 	wg           *sync.WaitGroup // WaitGroup to manage goroutines
 }
@@ -22,6 +33,8 @@ type ConsumerConfig struct {
 	ConsumerName string     // Name of the consumer
 	AutoAck      bool       // Automatic acknowledgment flag
 	Args         amqp.Table // Additional arguments for the queue declaration
+	MaxRetries   int        // Number of redeliveries allowed before dead-lettering; 0 dead-letters on the first failure
+	Prefetch     int        // Number of unacknowledged messages delivered at once (basic.qos); unlimited when 0
 }
 
 // NewRabbitMQConsumer creates a new RabbitMQ consumer with the given configuration.
@@ -37,13 +50,72 @@ func NewRabbitMQConsumer(rmqClient *Client, config ConsumerConfig) *RabbitMQCons
 		rmqClient:    rmqClient,
 		autoAck:      config.AutoAck,
 		args:         config.Args,
+		maxRetries:   config.MaxRetries,
+		prefetch:     config.Prefetch,
 		ConsumerName: config.ConsumerName,
 		wg:           &sync.WaitGroup{},
 	}
 }
 
+// SetPrefetch sets the number of unacknowledged messages the broker delivers at once. It applies to the
+// next call to Consume.
+//
+// Parameters:
+//   - count: The prefetch count, unlimited when 0.
+func (c *RabbitMQConsumer) SetPrefetch(count int) {
+	c.prefetch = count
+}
+
+// queueArgs returns the queue declaration arguments, including the dead-letter exchange
+// when messages are acknowledged manually.
+//
+// Parameters:
+//   - queueName: The name of the queue being declared.
+//
+// Returns:
+//   - The arguments to use for the queue declaration.
+//   - An error if the dead-letter topology could not be declared.
+func (c *RabbitMQConsumer) queueArgs(queueName string) (amqp.Table, error) {
+	if c.autoAck {
+		return c.args, nil
+	}
+	deadLetterExchange, err := c.rmqClient.declareDeadLetter(queueName)
+	if err != nil {
+		return nil, err
+	}
+	args := amqp.Table{}
+	for key, value := range c.args {
+		args[key] = value
+	}
+	args["x-dead-letter-exchange"] = deadLetterExchange
+	return args, nil
+}
+
+// queueDeclareError classifies the error of a queue declaration.
+//
+// Parameters:
+//   - queueName: The name of the declared queue.
+//   - err: The error returned by the broker.
+//
+// Returns:
+//   - An error wrapping ErrQueueArguments if the queue exists with other arguments, otherwise err.
+func queueDeclareError(queueName string, err error) error {
+	var amqpErr *amqp.Error
+	if errors.As(err, &amqpErr) && amqpErr.Code == amqp.PreconditionFailed {
+		return fmt.Errorf("%w: queue %s must be deleted once drained: %v", ErrQueueArguments, queueName, err)
+	}
+	return err
+}
+
 // Consume starts consuming messages from the specified queue and sends them to the provided channel.
 //
+// Messages are not acknowledged on arrival: each Delivery must be settled by its receiver with
+// Ack or Nack. Rejected messages are routed to a per-queue dead-letter exchange named "<queue>.dlx".
+//
+// When the context is done, msgCh is closed and the consumer is cancelled on the broker. The messages
+// delivered but not yet received from msgCh are requeued; the ones already received must still be settled.
+// The consumer is tagged "<consumer name>.<queue>", so several consumers can share the client's channel.
+//
 // Parameters:
 //   - ctx: The context to use for the consumer.
 //   - msgCh: A channel to send the consumed messages to.
@@ -51,12 +123,17 @@ func NewRabbitMQConsumer(rmqClient *Client, config ConsumerConfig) *RabbitMQCons
 //   - routingKey: The routing key to use for binding the queue.
 //
 // This method will panic if the queue declaration, binding, or consumption fails.
-func (c *RabbitMQConsumer) Consume(ctx context.Context, msgCh chan amqp.Delivery, queueName string, routingKey string) {
+func (c *RabbitMQConsumer) Consume(ctx context.Context, msgCh chan *Delivery, queueName string, routingKey string) {
 	if c.rmqClient == nil || c.rmqClient.Channel == nil {
 		panic("rmqClient or Channel is nil")
 	}
 
-	q, err := c.rmqClient.declareQueue(queueName, c.args)
+	args, err := c.queueArgs(queueName)
+	if err != nil {
+		panic(err)
+	}
+
+	q, err := c.rmqClient.declareQueue(queueName, args)
 	if err != nil {
 		panic(err)
 	}
@@ -66,7 +143,7 @@ func (c *RabbitMQConsumer) Consume(ctx context.Context, msgCh chan amqp.Delivery
 	}
 
 	deliveryCh := make(chan amqp.Delivery)
-	go c.rmqClient.consume(deliveryCh, c.ConsumerName, q.Name, c.autoAck)
+	go c.rmqClient.consume(ctx, deliveryCh, c.ConsumerName+"."+q.Name, q.Name, c.autoAck, c.prefetch)
 	log.Println("Started internal consume routine")
 
 	c.wg.Add(1)
@@ -80,12 +157,15 @@ func (c *RabbitMQConsumer) Consume(ctx context.Context, msgCh chan amqp.Delivery
 					close(msgCh)
 					return
 				}
-				log.Println("Incoming new message")
-				if !c.autoAck {
-					message.Ack(false)
-				}
 				log.Printf("Received message %s from queue: %s", string(message.Body), queueName)
-				msgCh <- message
+				select {
+				case msgCh <- newDelivery(message, c.rmqClient, q.Name, c.maxRetries, c.autoAck):
+				case <-ctx.Done():
+					requeue(message, c.autoAck)
+					log.Println("Context done, stopping consumer")
+					close(msgCh)
+					return
+				}
 			case <-ctx.Done():
 				log.Println("Context done, stopping consumer")
 				close(msgCh)
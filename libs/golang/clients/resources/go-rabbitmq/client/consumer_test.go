@@ -6,7 +6,6 @@ import (
 	"testing"
 	"time"
 
-	amqp "github.com/rabbitmq/amqp091-go"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 )
@@ -37,6 +36,7 @@ func (suite *GoRabbitMQConsumerSuite) SetupSuite() {
 		ConsumerName: "test_consumer",
 		AutoAck:      false,
 		Args:         nil,
+		MaxRetries:   1,
 	}
 }
 
@@ -59,12 +59,13 @@ func (suite *GoRabbitMQConsumerSuite) TestNewRabbitMQConsumer() {
 	assert.Equal(suite.T(), suite.client, consumer.rmqClient)
 	assert.Equal(suite.T(), suite.consumerConfig.AutoAck, consumer.autoAck)
 	assert.Equal(suite.T(), suite.consumerConfig.Args, consumer.args)
+	assert.Equal(suite.T(), suite.consumerConfig.MaxRetries, consumer.maxRetries)
 }
 
 func (suite *GoRabbitMQConsumerSuite) TestConsume() {
 	queueName := "test_queue_consumer"
 	routingKey := "test_key_consumer"
-	msgCh := make(chan amqp.Delivery, 1)
+	msgCh := make(chan *Delivery, 1)
 
 	consumer := NewRabbitMQConsumer(suite.client, suite.consumerConfig)
 
@@ -84,10 +85,49 @@ func (suite *GoRabbitMQConsumerSuite) TestConsume() {
 
 	select {
 	case msg := <-msgCh:
-		assert.Equal(suite.T(), message, msg.Body)
-		log.Println("Message received:", string(msg.Body))
+		assert.Equal(suite.T(), message, msg.Body())
+		assert.NoError(suite.T(), msg.Ack())
+		log.Println("Message received:", string(msg.Body()))
 	case <-ctx.Done():
 		suite.T().Error("Did not receive message in time")
 	}
 	consumer.Wait()
 }
+
+func (suite *GoRabbitMQConsumerSuite) TestConsumeNackRequeue() {
+	queueName := "test_queue_consumer_retry"
+	routingKey := "test_key_consumer_retry"
+	msgCh := make(chan *Delivery, 1)
+
+	consumer := NewRabbitMQConsumer(suite.client, suite.consumerConfig)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	go consumer.Consume(ctx, msgCh, queueName, routingKey)
+
+	// Add a delay to ensure the consumer is ready
+	time.Sleep(1 * time.Second)
+
+	message := []byte("retry message")
+	err := suite.client.publish(context.Background(), "text/plain", message, routingKey)
+	assert.NoError(suite.T(), err)
+
+	select {
+	case msg := <-msgCh:
+		assert.Equal(suite.T(), 0, msg.RetryCount())
+		assert.NoError(suite.T(), msg.Nack(true))
+	case <-ctx.Done():
+		suite.T().Error("Did not receive message in time")
+	}
+
+	select {
+	case msg := <-msgCh:
+		assert.Equal(suite.T(), message, msg.Body())
+		assert.Equal(suite.T(), 1, msg.RetryCount())
+		assert.NoError(suite.T(), msg.Nack(true))
+	case <-ctx.Done():
+		suite.T().Error("Did not receive redelivered message in time")
+	}
+	consumer.Wait()
+}
@@ -0,0 +1,164 @@
+package gorabbitmq
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+var (
+	// RetryCountHeader is the header used to track how many times a message has been redelivered.
+	RetryCountHeader = "x-retry-count"
+)
+
+// Delivery is a handle over a consumed RabbitMQ message. It carries the acknowledgement
+// decision down to whoever processes the message.
+type Delivery struct {
+	raw        amqp.Delivery // Original AMQP delivery
+	rmqClient  *Client       // RabbitMQ client used to republish retried messages
+	queueName  string        // Name of the queue the message was consumed from
+	maxRetries int           // Number of redeliveries allowed before dead-lettering
+	autoAck    bool          // Whether the broker already acknowledged the message
+}
+
+// newDelivery creates a new Delivery wrapping the given AMQP delivery.
+//
+// Parameters:
+//   - raw: The AMQP delivery to wrap.
+//   - rmqClient: The RabbitMQ client used to republish retried messages.
+//   - queueName: The name of the queue the message was consumed from.
+//   - maxRetries: The number of redeliveries allowed before dead-lettering.
+//   - autoAck: Whether the broker already acknowledged the message.
+//
+// Returns:
+//   - A pointer to the newly created Delivery.
+func newDelivery(raw amqp.Delivery, rmqClient *Client, queueName string, maxRetries int, autoAck bool) *Delivery {
+	return &Delivery{
+		raw:        raw,
+		rmqClient:  rmqClient,
+		queueName:  queueName,
+		maxRetries: maxRetries,
+		autoAck:    autoAck,
+	}
+}
+
+// Body returns the payload of the message.
+//
+// Returns:
+//   - The message body as a byte slice.
+func (d *Delivery) Body() []byte {
+	return d.raw.Body
+}
+
+// Raw returns the underlying AMQP delivery.
+//
+// Returns:
+//   - The wrapped amqp.Delivery.
+func (d *Delivery) Raw() amqp.Delivery {
+	return d.raw
+}
+
+// RetryCount returns how many times the message has been redelivered, as tracked by the x-retry-count header.
+//
+// Returns:
+//   - The number of previous attempts.
+func (d *Delivery) RetryCount() int {
+	return retryCount(d.raw.Headers)
+}
+
+// Ack acknowledges the message after it has been processed successfully.
+//
+// Returns:
+//   - An error if the acknowledgement could not be sent.
+func (d *Delivery) Ack() error {
+	if d.autoAck {
+		return nil
+	}
+	if err := d.raw.Ack(false); err != nil {
+		return fmt.Errorf("failed to ack message: %w", err)
+	}
+	return nil
+}
+
+// Nack rejects the message.
+//
+// When requeue is true the failure is treated as transient: the message is republished to its queue
+// with an incremented x-retry-count header until the retry budget is exhausted, after which it is
+// routed to the queue's dead-letter exchange. The original is acknowledged only once the broker confirms
+// the republished copy; when the copy cannot be republished, the original is dead-lettered rather than
+// requeued, since a requeued message would keep its retry count. When requeue is false the message is
+// dead-lettered immediately.
+//
+// Parameters:
+//   - requeue: Whether the message should be retried.
+//
+// Returns:
+//   - An error if the message could not be republished or rejected.
+func (d *Delivery) Nack(requeue bool) error {
+	if d.autoAck {
+		return nil
+	}
+	attempts := d.RetryCount() + 1
+	if !requeue || attempts > d.maxRetries {
+		log.Printf("Dead-lettering message from queue: %s after %d attempt(s)", d.queueName, attempts)
+		if err := d.raw.Nack(false, false); err != nil {
+			return fmt.Errorf("failed to dead-letter message: %w", err)
+		}
+		return nil
+	}
+
+	headers := amqp.Table{}
+	for key, value := range d.raw.Headers {
+		headers[key] = value
+	}
+	headers[RetryCountHeader] = int32(attempts)
+
+	msg := amqp.Publishing{
+		ContentType:   d.raw.ContentType,
+		CorrelationId: d.raw.CorrelationId,
+		MessageId:     d.raw.MessageId,
+		Headers:       headers,
+		DeliveryMode:  amqp.Persistent,
+		Body:          d.raw.Body,
+	}
+	if err := d.rmqClient.republish(context.Background(), d.queueName, msg); err != nil {
+		log.Printf("Dead-lettering message from queue: %s after %d attempt(s), it could not be requeued: %v", d.queueName, attempts, err)
+		if nackErr := d.raw.Nack(false, false); nackErr != nil {
+			return fmt.Errorf("failed to dead-letter message: %w", nackErr)
+		}
+		return fmt.Errorf("failed to requeue message, dead-lettered it: %w", err)
+	}
+	log.Printf("Requeued message to queue: %s (attempt %d/%d)", d.queueName, attempts, d.maxRetries)
+	if err := d.raw.Ack(false); err != nil {
+		return fmt.Errorf("failed to ack requeued message: %w", err)
+	}
+	return nil
+}
+
+// retryCount reads the x-retry-count header from the given headers.
+//
+// Parameters:
+//   - headers: The AMQP headers of a message.
+//
+// Returns:
+//   - The number of previous attempts, or 0 if the header is missing or has an unexpected type.
+func retryCount(headers amqp.Table) int {
+	value, ok := headers[RetryCountHeader]
+	if !ok {
+		return 0
+	}
+	switch v := value.(type) {
+	case int:
+		return v
+	case int16:
+		return int(v)
+	case int32:
+		return int(v)
+	case int64:
+		return int(v)
+	default:
+		return 0
+	}
+}
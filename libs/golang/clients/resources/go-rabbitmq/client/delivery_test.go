@@ -0,0 +1,37 @@
+package gorabbitmq
+
+import (
+	"testing"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryCount(t *testing.T) {
+	assert.Equal(t, 0, retryCount(nil))
+	assert.Equal(t, 0, retryCount(amqp.Table{}))
+	assert.Equal(t, 2, retryCount(amqp.Table{RetryCountHeader: int32(2)}))
+	assert.Equal(t, 3, retryCount(amqp.Table{RetryCountHeader: int64(3)}))
+	assert.Equal(t, 0, retryCount(amqp.Table{RetryCountHeader: "3"}))
+}
+
+func TestDeliveryAutoAck(t *testing.T) {
+	delivery := newDelivery(amqp.Delivery{Body: []byte("body")}, nil, "queue", 3, true)
+	assert.Equal(t, []byte("body"), delivery.Body())
+	assert.NoError(t, delivery.Ack())
+	assert.NoError(t, delivery.Nack(true))
+}
+
+func TestNackDeadLettersWhenRequeueFails(t *testing.T) {
+	acknowledger := &acknowledgerMock{}
+	client := newDisconnectedClient()
+	client.setState(StateClosed)
+	raw := amqp.Delivery{Acknowledger: acknowledger, DeliveryTag: 4, Headers: amqp.Table{RetryCountHeader: int32(1)}}
+	delivery := newDelivery(raw, client, "queue", 3, false)
+
+	err := delivery.Nack(true)
+
+	assert.ErrorContains(t, err, "client is closed")
+	assert.Empty(t, acknowledger.requeued, "A requeued message would keep its retry count")
+	assert.Equal(t, []uint64{4}, acknowledger.deadLettered)
+}
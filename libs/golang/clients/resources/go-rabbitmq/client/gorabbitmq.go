@@ -2,8 +2,10 @@ package gorabbitmq
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"sync"
 	"time"
 
 	amqp "github.com/rabbitmq/amqp091-go"
@@ -21,6 +23,9 @@ type Config struct {
 }
 
 // Client represents a RabbitMQ client.
+//
+// The client watches its connection and channel and transparently recovers them when the broker
+// goes away, redeclaring the topology it created and resuming its consumers.
 type Client struct {
 	Dsn           string           // Data Source Name for connecting to RabbitMQ
 	Conn          *amqp.Connection // RabbitMQ connection instance
@@ -28,6 +33,15 @@ type Client struct {
 	ExchangeName  string           // Name of the RabbitMQ exchange in use
 	ExchangeType  string           // Type of the RabbitMQ exchange in use
 	totalAttempts int              // Total number of attempts to connect/reconnect
+
+	mu             sync.RWMutex                  // Guards the connection, channel, state and topology
+	state          ConnectionState               // Current state of the connection
+	changed        chan struct{}                 // Closed and replaced on every state change
+	done           chan struct{}                 // Closed when the client is closed
+	closeOnce      sync.Once                     // Ensures done is closed only once
+	stateCallbacks []func(state ConnectionState) // Callbacks notified on every state change
+	topology       *topology                     // Exchanges, queues and bindings to redeclare on reconnect
+	consumeMu      sync.Mutex                    // Serializes setting the prefetch and starting each consumer
 }
 
 // NewClient creates a new RabbitMQ client with the given configuration.
@@ -46,6 +60,10 @@ func NewClient(config Config) (*Client, error) {
 		ExchangeName:  config.ExchangeName,
 		ExchangeType:  config.ExchangeType,
 		totalAttempts: 20,
+		state:         StateReconnecting,
+		changed:       make(chan struct{}),
+		done:          make(chan struct{}),
+		topology:      newTopology(),
 	}
 
 	var err error
@@ -69,6 +87,9 @@ func NewClient(config Config) (*Client, error) {
 		return nil, fmt.Errorf("failed to declare exchange: %w", err)
 	}
 
+	rabbitClient.setState(StateConnected)
+	go rabbitClient.watch()
+
 	return rabbitClient, nil
 }
 
@@ -78,42 +99,73 @@ func NewClient(config Config) (*Client, error) {
 //   - An error if the connection could not be established.
 func (c *Client) connect() error {
 	log.Println("Connecting to RabbitMQ...")
-	var err error
-	c.Conn, err = amqp.Dial(c.Dsn)
+	conn, err := amqp.Dial(c.Dsn)
 	if err != nil {
 		return fmt.Errorf("failed to connect to RabbitMQ: %w", err)
 	}
+	c.mu.Lock()
+	c.Conn = conn
+	c.mu.Unlock()
 	log.Println("Connected to RabbitMQ")
 	return nil
 }
 
-// channel opens a channel for the RabbitMQ client.
+// channel opens a channel for the RabbitMQ client and puts it in confirm mode,
+// so publishers can wait for the broker to take responsibility for a message.
 //
 // Returns:
 //   - An error if the channel could not be opened.
 func (c *Client) channel() error {
-	if c.Conn == nil {
+	conn := c.getConnection()
+	if conn == nil {
 		return fmt.Errorf("connection is nil")
 	}
 	log.Println("Opening channel...")
-	var err error
-	c.Channel, err = c.Conn.Channel()
+	ch, err := conn.Channel()
 	if err != nil {
 		return fmt.Errorf("failed to open a channel: %w", err)
 	}
+	if err := ch.Confirm(false); err != nil {
+		ch.Close()
+		return fmt.Errorf("failed to put channel in confirm mode: %w", err)
+	}
+	c.mu.Lock()
+	c.Channel = ch
+	c.mu.Unlock()
 	log.Println("Opened channel")
 	return nil
 }
 
+// getConnection returns the current connection.
+//
+// Returns:
+//   - The current connection, or nil if none has been established.
+func (c *Client) getConnection() *amqp.Connection {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.Conn
+}
+
+// getChannel returns the current channel.
+//
+// Returns:
+//   - The current channel, or nil if none has been opened.
+func (c *Client) getChannel() *amqp.Channel {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.Channel
+}
+
 // declareExchange declares an exchange for the RabbitMQ client.
 //
 // Returns:
 //   - An error if the exchange could not be declared.
 func (c *Client) declareExchange() error {
-	if c.Channel == nil {
+	ch := c.getChannel()
+	if ch == nil {
 		return fmt.Errorf("channel is nil")
 	}
-	err := c.Channel.ExchangeDeclare(
+	err := ch.ExchangeDeclare(
 		c.ExchangeName,
 		c.ExchangeType,
 		true,
@@ -137,14 +189,16 @@ func (c *Client) declareExchange() error {
 //
 // Returns:
 //   - A pointer to the declared queue.
-//   - An error if the queue could not be declared.
+//   - An error if the queue could not be declared, wrapping ErrQueueArguments without retrying when the
+//     queue exists with other arguments.
 func (c *Client) declareQueue(queueName string, args amqp.Table) (*amqp.Queue, error) {
-	if c.Channel == nil {
+	if c.getChannel() == nil {
 		return nil, fmt.Errorf("channel is nil")
 	}
 	var err error
 	for i := 0; i < c.totalAttempts; i++ {
-		q, err := c.Channel.QueueDeclare(
+		var q amqp.Queue
+		q, err = c.getChannel().QueueDeclare(
 			queueName,
 			true,
 			false,
@@ -154,8 +208,12 @@ func (c *Client) declareQueue(queueName string, args amqp.Table) (*amqp.Queue, e
 		)
 		if err == nil {
 			log.Printf("Declared queue: %s", queueName)
+			c.topology.addQueue(queueName, args)
 			return &q, nil
 		}
+		if err = queueDeclareError(queueName, err); errors.Is(err, ErrQueueArguments) {
+			return nil, err
+		}
 		log.Printf("Failed to declare queue: %s, retrying... (%d/%d)", queueName, i+1, c.totalAttempts)
 		time.Sleep(2 * time.Second)
 	}
@@ -171,12 +229,12 @@ func (c *Client) declareQueue(queueName string, args amqp.Table) (*amqp.Queue, e
 // Returns:
 //   - An error if the queue could not be bound.
 func (c *Client) bindQueue(queueName, routingKey string) error {
-	if c.Channel == nil {
+	if c.getChannel() == nil {
 		return fmt.Errorf("channel is nil")
 	}
 	var err error
 	for i := 0; i < c.totalAttempts; i++ {
-		err = c.Channel.QueueBind(
+		err = c.getChannel().QueueBind(
 			queueName,
 			routingKey,
 			c.ExchangeName,
@@ -185,6 +243,7 @@ func (c *Client) bindQueue(queueName, routingKey string) error {
 		)
 		if err == nil {
 			log.Printf("Bound queue: %s with routing key: %s", queueName, routingKey)
+			c.topology.addBinding(queueName, routingKey, c.ExchangeName)
 			return nil
 		}
 		log.Printf("Failed to bind queue: %s, retrying... (%d/%d)", queueName, i+1, c.totalAttempts)
@@ -193,19 +252,186 @@ func (c *Client) bindQueue(queueName, routingKey string) error {
 	return fmt.Errorf("failed to bind queue: %w", err)
 }
 
+// declareDeadLetter declares the dead-letter exchange and queue for the given queue and binds them.
+//
+// Parameters:
+//   - queueName: The name of the queue whose rejected messages should be dead-lettered.
+//
+// Returns:
+//   - The name of the declared dead-letter exchange.
+//   - An error if the exchange, queue or binding could not be declared.
+func (c *Client) declareDeadLetter(queueName string) (string, error) {
+	ch := c.getChannel()
+	if ch == nil {
+		return "", fmt.Errorf("channel is nil")
+	}
+	exchangeName := fmt.Sprintf("%s.dlx", queueName)
+	deadLetterQueueName := fmt.Sprintf("%s.dlq", queueName)
+
+	err := ch.ExchangeDeclare(
+		exchangeName,
+		amqp.ExchangeFanout,
+		true,
+		false,
+		false,
+		false,
+		nil,
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to declare dead-letter exchange: %w", err)
+	}
+	c.topology.addExchange(exchangeName, amqp.ExchangeFanout)
+
+	if _, err := c.declareQueue(deadLetterQueueName, nil); err != nil {
+		return "", err
+	}
+
+	err = ch.QueueBind(
+		deadLetterQueueName,
+		"",
+		exchangeName,
+		false,
+		nil,
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to bind dead-letter queue: %w", err)
+	}
+	c.topology.addBinding(deadLetterQueueName, "", exchangeName)
+	log.Printf("Declared dead-letter exchange: %s for queue: %s", exchangeName, queueName)
+	return exchangeName, nil
+}
+
 // consume starts consuming messages from the specified queue.
 //
+// If the channel is lost, consumption resumes on the recovered channel without closing msgCh.
+// The msgCh channel is closed once the context is done or the client itself is closed. When the context
+// is done, the consumer is cancelled on the broker and the messages it still receives are requeued.
+//
 // Parameters:
+//   - ctx: The context that stops the consumption.
 //   - msgCh: A channel to receive the messages.
-//   - consumerName: The name of the consumer.
+//   - consumerName: The tag of the consumer, unique within the channel.
 //   - queueName: The name of the queue to consume from.
 //   - autoAck: Whether to automatically acknowledge messages.
-func (c *Client) consume(msgCh chan amqp.Delivery, consumerName string, queueName string, autoAck bool) {
-	if c.Channel == nil {
+//   - prefetch: The number of unacknowledged messages the broker delivers to the consumer, unlimited when 0.
+func (c *Client) consume(ctx context.Context, msgCh chan amqp.Delivery, consumerName string, queueName string, autoAck bool, prefetch int) {
+	if c.getChannel() == nil {
 		log.Println("Channel is nil")
 		return
 	}
-	deliveryCh, err := c.Channel.Consume(
+	go func() {
+		defer close(msgCh)
+		ch := c.getChannel()
+		for {
+			deliveryCh, err := c.startConsumer(ch, consumerName, queueName, autoAck, prefetch)
+			if err != nil {
+				log.Printf("Failed to consume messages from queue: %s: %v", queueName, err)
+			} else {
+				log.Printf("Started consuming messages from queue: %s", queueName)
+				if !forwardDeliveries(ctx, deliveryCh, msgCh, queueName, autoAck) {
+					cancelConsumer(ch, deliveryCh, consumerName, autoAck)
+					log.Printf("Cancelled consumer %s of queue: %s", consumerName, queueName)
+					return
+				}
+				log.Println("RabbitMQ channel closed")
+			}
+
+			var ok bool
+			ch, ok = c.waitForChannel(ch)
+			if !ok || ctx.Err() != nil {
+				log.Printf("Client closed, stopped consuming messages from queue: %s", queueName)
+				return
+			}
+		}
+	}()
+}
+
+// forwardDeliveries sends the deliveries of a consumer to msgCh until the deliveries channel closes or
+// the context is done.
+//
+// Parameters:
+//   - ctx: The context that stops the forwarding.
+//   - deliveryCh: The channel of the deliveries of the consumer.
+//   - msgCh: The channel the deliveries are sent to.
+//   - queueName: The name of the queue of the consumer.
+//   - autoAck: Whether the broker already acknowledged the messages.
+//
+// Returns:
+//   - True if the deliveries channel closed, false if the context is done. A delivery that could not
+//     be sent before the context was done is requeued.
+func forwardDeliveries(ctx context.Context, deliveryCh <-chan amqp.Delivery, msgCh chan amqp.Delivery, queueName string, autoAck bool) bool {
+	for {
+		select {
+		case message, ok := <-deliveryCh:
+			if !ok {
+				return true
+			}
+			log.Printf("Received message %s from queue: %s", string(message.Body), queueName)
+			select {
+			case msgCh <- message:
+			case <-ctx.Done():
+				requeue(message, autoAck)
+				return false
+			}
+		case <-ctx.Done():
+			return false
+		}
+	}
+}
+
+// cancelConsumer cancels a consumer on the broker, so it stops receiving messages, and requeues the
+// messages delivered before the cancellation took effect.
+//
+// Parameters:
+//   - ch: The channel of the consumer.
+//   - deliveryCh: The channel of the deliveries of the consumer, closed by the cancellation.
+//   - consumerName: The tag of the consumer.
+//   - autoAck: Whether the broker already acknowledged the messages.
+func cancelConsumer(ch *amqp.Channel, deliveryCh <-chan amqp.Delivery, consumerName string, autoAck bool) {
+	if err := ch.Cancel(consumerName, false); err != nil {
+		log.Printf("Failed to cancel consumer %s: %v", consumerName, err)
+	}
+	for message := range deliveryCh {
+		requeue(message, autoAck)
+	}
+}
+
+// requeue returns a message to its queue without counting a retry, for messages that were not processed.
+// Messages the broker already acknowledged cannot be requeued.
+//
+// Parameters:
+//   - message: The message to requeue.
+//   - autoAck: Whether the broker already acknowledged the message.
+func requeue(message amqp.Delivery, autoAck bool) {
+	if autoAck {
+		log.Printf("Dropped unprocessed message %s, it was already acknowledged", message.MessageId)
+		return
+	}
+	if err := message.Nack(false, true); err != nil {
+		log.Printf("Failed to requeue message: %v", err)
+	}
+}
+
+// startConsumer sets the prefetch of a consumer and starts it. The prefetch set on a channel applies to
+// the consumers started after it, so both steps are serialized between the consumers sharing the channel.
+//
+// Parameters:
+//   - ch: The channel to consume on.
+//   - consumerName: The name of the consumer.
+//   - queueName: The name of the queue to consume from.
+//   - autoAck: Whether to automatically acknowledge messages.
+//   - prefetch: The number of unacknowledged messages the broker delivers to the consumer, unlimited when 0.
+//
+// Returns:
+//   - The channel of the deliveries of the consumer.
+//   - An error if the prefetch cannot be set or the consumer cannot be started.
+func (c *Client) startConsumer(ch *amqp.Channel, consumerName, queueName string, autoAck bool, prefetch int) (<-chan amqp.Delivery, error) {
+	c.consumeMu.Lock()
+	defer c.consumeMu.Unlock()
+	if err := ch.Qos(prefetch, 0, false); err != nil {
+		return nil, fmt.Errorf("failed to set prefetch: %w", err)
+	}
+	return ch.Consume(
 		queueName,
 		consumerName,
 		autoAck,
@@ -214,19 +440,6 @@ func (c *Client) consume(msgCh chan amqp.Delivery, consumerName string, queueNam
 		false,
 		nil,
 	)
-	if err != nil {
-		log.Printf("Failed to consume messages from queue: %s", queueName)
-		return
-	}
-	log.Printf("Started consuming messages from queue: %s", queueName)
-	go func() {
-		for message := range deliveryCh {
-			log.Printf("Received message %s from queue: %s", string(message.Body), queueName)
-			msgCh <- message
-		}
-		log.Println("RabbitMQ channel closed")
-		close(msgCh)
-	}()
 }
 
 // publish sends a message to the RabbitMQ exchange.
@@ -240,10 +453,12 @@ func (c *Client) consume(msgCh chan amqp.Delivery, consumerName string, queueNam
 // Returns:
 //   - An error if the message could not be published.
 func (c *Client) publish(ctx context.Context, contentType string, message []byte, routingKey string) error {
-	if c.Channel == nil {
-		return fmt.Errorf("channel is nil")
+	ch, err := c.awaitChannel(ctx)
+	if err != nil {
+		log.Printf("Failed to publish message: %v", err)
+		return fmt.Errorf("failed to publish message: %w", err)
 	}
-	err := c.Channel.PublishWithContext(
+	err = ch.PublishWithContext(
 		ctx,
 		c.ExchangeName,
 		routingKey,
@@ -262,22 +477,122 @@ func (c *Client) publish(ctx context.Context, contentType string, message []byte
 	return nil
 }
 
+// publishWithConfirm sends a message to the RabbitMQ exchange and waits for the broker to confirm it.
+//
+// Parameters:
+//   - ctx: The context bounding the publish and the wait for the confirmation.
+//   - contentType: The content type of the message.
+//   - message: The message to be sent as a byte slice.
+//   - routingKey: The routing key to use for routing the message.
+//
+// Returns:
+//   - An error if the message could not be published or was not acknowledged by the broker.
+func (c *Client) publishWithConfirm(ctx context.Context, contentType string, message []byte, routingKey string) error {
+	ch, err := c.awaitChannel(ctx)
+	if err != nil {
+		log.Printf("Failed to publish message: %v", err)
+		return fmt.Errorf("failed to publish message: %w", err)
+	}
+	confirmation, err := ch.PublishWithDeferredConfirmWithContext(
+		ctx,
+		c.ExchangeName,
+		routingKey,
+		false,
+		false,
+		amqp.Publishing{
+			ContentType:  contentType,
+			DeliveryMode: amqp.Persistent,
+			Body:         message,
+		},
+	)
+	if err != nil {
+		log.Printf("Failed to publish message: %v", err)
+		return fmt.Errorf("failed to publish message: %w", err)
+	}
+	if confirmation == nil {
+		return fmt.Errorf("failed to publish message: channel is not in confirm mode")
+	}
+	acked, err := confirmation.WaitContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to wait for publisher confirm: %w", err)
+	}
+	if !acked {
+		return fmt.Errorf("message to routing key %s was nacked by the broker", routingKey)
+	}
+	log.Printf("Published and confirmed message to routing key: %s", routingKey)
+	return nil
+}
+
+// republish sends a message straight back to the given queue through the default exchange and waits
+// for the broker to confirm it.
+//
+// Parameters:
+//   - ctx: The context bounding the publish and the wait for the confirmation.
+//   - queueName: The name of the queue to publish the message to.
+//   - msg: The message to be published.
+//
+// Returns:
+//   - An error if the message could not be published or was not acknowledged by the broker.
+func (c *Client) republish(ctx context.Context, queueName string, msg amqp.Publishing) error {
+	ch, err := c.awaitChannel(ctx)
+	if err != nil {
+		log.Printf("Failed to republish message: %v", err)
+		return fmt.Errorf("failed to republish message: %w", err)
+	}
+	confirmation, err := ch.PublishWithDeferredConfirmWithContext(
+		ctx,
+		"",
+		queueName,
+		false,
+		false,
+		msg,
+	)
+	if err != nil {
+		log.Printf("Failed to republish message: %v", err)
+		return fmt.Errorf("failed to republish message: %w", err)
+	}
+	if confirmation == nil {
+		return fmt.Errorf("failed to republish message: channel is not in confirm mode")
+	}
+	acked, err := confirmation.WaitContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to wait for publisher confirm: %w", err)
+	}
+	if !acked {
+		return fmt.Errorf("message to queue %s was nacked by the broker", queueName)
+	}
+	return nil
+}
+
 // Close closes the RabbitMQ client's channel and connection.
 //
+// Closing the client stops connection recovery and ends every running consumer.
+//
 // Returns:
 //   - An error if the channel or connection could not be closed.
 func (c *Client) Close() error {
+	if c.done != nil {
+		c.closeOnce.Do(func() {
+			close(c.done)
+		})
+	}
+
 	var err error
-	if c.Channel != nil && !c.Channel.IsClosed() {
-		if closeErr := c.Channel.Close(); closeErr != nil {
+	ch := c.getChannel()
+	if ch != nil && !ch.IsClosed() {
+		if closeErr := ch.Close(); closeErr != nil {
 			err = closeErr
 		}
 	}
-	if c.Conn != nil && !c.Conn.IsClosed() {
-		if closeErr := c.Conn.Close(); closeErr != nil {
+	conn := c.getConnection()
+	if conn != nil && !conn.IsClosed() {
+		if closeErr := conn.Close(); closeErr != nil {
 			err = closeErr
 		}
 	}
+	if c.changed != nil {
+		c.setState(StateClosed)
+	}
 	log.Println("Closed RabbitMQ connection and channel")
 	return err
 }
@@ -131,7 +131,7 @@ func (suite *GoRabbitMQSuite) TestConsume() {
 
 	// Test the consume method
 	msgCh := make(chan amqp.Delivery, 1)
-	go suite.client.consume(msgCh, "consumer-name", queueName, false)
+	go suite.client.consume(context.Background(), msgCh, "consumer-name", queueName, false, 0)
 
 	// Publish a test message
 	message := []byte("test message")
@@ -37,3 +37,18 @@ func (n *RabbitMQNotifier) Notify(message []byte, routingKey string) error {
 	)
 	return n.rmqClient.publish(ctx, contentType, message, routingKey)
 }
+
+// NotifyWithConfirm sends a notification message to the RabbitMQ exchange using the specified routing key
+// and waits until the broker confirms it.
+//
+// Parameters:
+//   - ctx: The context bounding the publish and the wait for the confirmation.
+//   - message: The message to be sent as a byte slice.
+//   - routingKey: The routing key to be used for routing the message.
+//
+// Returns:
+//   - An error if the message could not be published or was not confirmed by the broker.
+func (n *RabbitMQNotifier) NotifyWithConfirm(ctx context.Context, message []byte, routingKey string) error {
+	contentType := "application/json"
+	return n.rmqClient.publishWithConfirm(ctx, contentType, message, routingKey)
+}
@@ -1,6 +1,7 @@
 package gorabbitmq
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -54,3 +55,10 @@ func (suite *GoRabbitMQNotifierSuite) TestNotify() {
 	err := notifier.Notify([]byte("test message"), routingKey)
 	assert.NoError(suite.T(), err)
 }
+
+func (suite *GoRabbitMQNotifierSuite) TestNotifyWithConfirm() {
+	routingKey := "test_routing_key_notifier_confirm"
+	notifier := NewRabbitMQNotifier(suite.client)
+	err := notifier.NotifyWithConfirm(context.Background(), []byte("test message"), routingKey)
+	assert.NoError(suite.T(), err)
+}
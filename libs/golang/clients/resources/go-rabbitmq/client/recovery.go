@@ -0,0 +1,400 @@
+package gorabbitmq
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+var (
+	// initialReconnectDelay is the delay before the first reconnection attempt.
+	initialReconnectDelay = 500 * time.Millisecond
+
+	// maxReconnectDelay caps the exponential backoff between reconnection attempts.
+	maxReconnectDelay = 30 * time.Second
+
+	// publishRecoveryTimeout is how long a publisher waits for the client to reconnect before failing.
+	publishRecoveryTimeout = 10 * time.Second
+)
+
+// ConnectionState represents the state of the connection between the client and the broker.
+type ConnectionState int
+
+const (
+	// StateConnected means the connection and channel are open.
+	StateConnected ConnectionState = iota
+	// StateReconnecting means the connection or channel was lost and is being recovered.
+	StateReconnecting
+	// StateClosed means the client was closed and will not reconnect.
+	StateClosed
+)
+
+// String returns the name of the connection state.
+func (s ConnectionState) String() string {
+	switch s {
+	case StateConnected:
+		return "connected"
+	case StateReconnecting:
+		return "reconnecting"
+	case StateClosed:
+		return "closed"
+	default:
+		return "unknown"
+	}
+}
+
+// exchangeDeclaration records an exchange declared by the client.
+type exchangeDeclaration struct {
+	name string
+	kind string
+}
+
+// queueDeclaration records a queue declared by the client.
+type queueDeclaration struct {
+	name string
+	args amqp.Table
+}
+
+// queueBinding records a binding created by the client.
+type queueBinding struct {
+	queueName    string
+	routingKey   string
+	exchangeName string
+}
+
+// topology keeps track of everything the client declared so it can be redeclared after a reconnect.
+type topology struct {
+	mu        sync.Mutex
+	exchanges []exchangeDeclaration
+	queues    []queueDeclaration
+	bindings  []queueBinding
+}
+
+// newTopology creates an empty topology.
+//
+// Returns:
+//   - A pointer to the newly created topology.
+func newTopology() *topology {
+	return &topology{}
+}
+
+// addExchange records an exchange declaration, ignoring duplicates.
+//
+// Parameters:
+//   - name: The name of the exchange.
+//   - kind: The type of the exchange.
+func (t *topology) addExchange(name, kind string) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, e := range t.exchanges {
+		if e.name == name {
+			return
+		}
+	}
+	t.exchanges = append(t.exchanges, exchangeDeclaration{name: name, kind: kind})
+}
+
+// addQueue records a queue declaration, replacing the arguments of a previous declaration.
+//
+// Parameters:
+//   - name: The name of the queue.
+//   - args: The arguments used to declare the queue.
+func (t *topology) addQueue(name string, args amqp.Table) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for i, q := range t.queues {
+		if q.name == name {
+			t.queues[i].args = args
+			return
+		}
+	}
+	t.queues = append(t.queues, queueDeclaration{name: name, args: args})
+}
+
+// addBinding records a queue binding, ignoring duplicates.
+//
+// Parameters:
+//   - queueName: The name of the bound queue.
+//   - routingKey: The routing key of the binding.
+//   - exchangeName: The name of the exchange the queue is bound to.
+func (t *topology) addBinding(queueName, routingKey, exchangeName string) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	binding := queueBinding{queueName: queueName, routingKey: routingKey, exchangeName: exchangeName}
+	for _, b := range t.bindings {
+		if b == binding {
+			return
+		}
+	}
+	t.bindings = append(t.bindings, binding)
+}
+
+// redeclare declares every recorded exchange, queue and binding on the given channel.
+//
+// Parameters:
+//   - ch: The channel to declare the topology on.
+//
+// Returns:
+//   - An error if any declaration fails.
+func (t *topology) redeclare(ch *amqp.Channel) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, e := range t.exchanges {
+		if err := ch.ExchangeDeclare(e.name, e.kind, true, false, false, false, nil); err != nil {
+			return fmt.Errorf("failed to redeclare exchange %s: %w", e.name, err)
+		}
+	}
+	for _, q := range t.queues {
+		if _, err := ch.QueueDeclare(q.name, true, false, false, false, q.args); err != nil {
+			return fmt.Errorf("failed to redeclare queue %s: %w", q.name, queueDeclareError(q.name, err))
+		}
+	}
+	for _, b := range t.bindings {
+		if err := ch.QueueBind(b.queueName, b.routingKey, b.exchangeName, false, nil); err != nil {
+			return fmt.Errorf("failed to rebind queue %s: %w", b.queueName, err)
+		}
+	}
+	return nil
+}
+
+// OnStateChange registers a callback that is invoked every time the connection state changes.
+// Callbacks run synchronously and should return quickly.
+//
+// Parameters:
+//   - callback: The function to call with the new connection state.
+func (c *Client) OnStateChange(callback func(state ConnectionState)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stateCallbacks = append(c.stateCallbacks, callback)
+}
+
+// State returns the current connection state.
+//
+// Returns:
+//   - The current ConnectionState.
+func (c *Client) State() ConnectionState {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.state
+}
+
+// IsConnected reports whether the client currently holds an open connection and channel.
+//
+// Returns:
+//   - True if the client is connected, otherwise false.
+func (c *Client) IsConnected() bool {
+	return c.State() == StateConnected
+}
+
+// setState updates the connection state, wakes up waiters and notifies the registered callbacks.
+//
+// Parameters:
+//   - state: The new connection state.
+func (c *Client) setState(state ConnectionState) {
+	c.mu.Lock()
+	if c.state == StateClosed {
+		c.mu.Unlock()
+		return
+	}
+	c.state = state
+	close(c.changed)
+	c.changed = make(chan struct{})
+	callbacks := make([]func(state ConnectionState), len(c.stateCallbacks))
+	copy(callbacks, c.stateCallbacks)
+	c.mu.Unlock()
+
+	log.Printf("RabbitMQ connection state changed to: %s", state)
+	for _, callback := range callbacks {
+		callback(state)
+	}
+}
+
+// waitForChannel blocks until a channel other than the stale one is open and the client is connected.
+//
+// Parameters:
+//   - stale: The channel that was lost.
+//
+// Returns:
+//   - The recovered channel.
+//   - False if the client was closed while waiting.
+func (c *Client) waitForChannel(stale *amqp.Channel) (*amqp.Channel, bool) {
+	for {
+		c.mu.RLock()
+		ch, state, changed := c.Channel, c.state, c.changed
+		c.mu.RUnlock()
+
+		switch {
+		case state == StateClosed:
+			return nil, false
+		case state == StateConnected && ch != stale:
+			return ch, true
+		}
+
+		select {
+		case <-c.done:
+			return nil, false
+		case <-changed:
+		}
+	}
+}
+
+// CheckConnection reports whether the client is connected, suitable for health checks.
+//
+// Returns:
+//   - An error describing the connection state if the client is not connected, otherwise nil.
+func (c *Client) CheckConnection() error {
+	if state := c.State(); state != StateConnected {
+		return fmt.Errorf("rabbitmq connection is %s", state)
+	}
+	return nil
+}
+
+// awaitChannel returns the current channel, waiting for an ongoing recovery to finish if needed.
+//
+// Parameters:
+//   - ctx: The context bounding the wait, further limited by publishRecoveryTimeout.
+//
+// Returns:
+//   - The open channel.
+//   - An error if the client is closed or did not reconnect in time.
+func (c *Client) awaitChannel(ctx context.Context) (*amqp.Channel, error) {
+	ctx, cancel := context.WithTimeout(ctx, publishRecoveryTimeout)
+	defer cancel()
+	for {
+		c.mu.RLock()
+		ch, state, changed := c.Channel, c.state, c.changed
+		c.mu.RUnlock()
+
+		switch {
+		case changed == nil, state == StateConnected:
+			if ch == nil {
+				return nil, fmt.Errorf("channel is nil")
+			}
+			return ch, nil
+		case state == StateClosed:
+			return nil, fmt.Errorf("client is closed")
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("rabbitmq connection is %s: %w", state, ctx.Err())
+		case <-changed:
+		}
+	}
+}
+
+// isClosed reports whether Close was called on the client.
+//
+// Returns:
+//   - True if the client was closed, otherwise false.
+func (c *Client) isClosed() bool {
+	select {
+	case <-c.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// watch monitors the connection and channel and recovers them whenever either one is closed
+// by anything other than Close.
+func (c *Client) watch() {
+	for {
+		conn, ch := c.getConnection(), c.getChannel()
+		connClosed := conn.NotifyClose(make(chan *amqp.Error, 1))
+		chClosed := ch.NotifyClose(make(chan *amqp.Error, 1))
+
+		select {
+		case <-c.done:
+			return
+		case err := <-connClosed:
+			log.Printf("RabbitMQ connection closed: %v", err)
+		case err := <-chClosed:
+			log.Printf("RabbitMQ channel closed: %v", err)
+		}
+
+		if c.isClosed() {
+			return
+		}
+		c.setState(StateReconnecting)
+		if !c.reconnect() {
+			return
+		}
+		c.setState(StateConnected)
+	}
+}
+
+// reconnect re-establishes the connection and channel with exponential backoff and redeclares
+// the exchange, queues and bindings created by the client.
+//
+// Returns:
+//   - True once the client is reconnected, or false if the client was closed meanwhile.
+func (c *Client) reconnect() bool {
+	return c.retryRecovery(c.recover)
+}
+
+// retryRecovery runs recovery attempts with exponential backoff until one succeeds. Every failure is
+// retried, including a queue that exists with other arguments: the recovery resumes once the queue
+// is deleted.
+//
+// Parameters:
+//   - recoverOnce: A single recovery attempt.
+//
+// Returns:
+//   - True once an attempt succeeds, or false if the client was closed meanwhile.
+func (c *Client) retryRecovery(recoverOnce func() error) bool {
+	delay := initialReconnectDelay
+	for attempt := 1; ; attempt++ {
+		err := recoverOnce()
+		if err == nil {
+			log.Printf("Reconnected to RabbitMQ after %d attempt(s)", attempt)
+			return true
+		}
+		log.Printf("Failed to reconnect to RabbitMQ (attempt %d), retrying in %s: %v", attempt, delay, err)
+
+		select {
+		case <-c.done:
+			return false
+		case <-time.After(delay):
+		}
+		delay *= 2
+		if delay > maxReconnectDelay {
+			delay = maxReconnectDelay
+		}
+	}
+}
+
+// recover performs a single recovery attempt, reusing the connection when only the channel was lost.
+//
+// Returns:
+//   - An error if the connection, channel or topology could not be restored.
+func (c *Client) recover() error {
+	if conn := c.getConnection(); conn == nil || conn.IsClosed() {
+		if err := c.connect(); err != nil {
+			return err
+		}
+	}
+	if err := c.channel(); err != nil {
+		if conn := c.getConnection(); conn != nil {
+			conn.Close()
+		}
+		return err
+	}
+	if err := c.declareExchange(); err != nil {
+		return err
+	}
+	return c.topology.redeclare(c.getChannel())
+}
@@ -0,0 +1,182 @@
+package gorabbitmq
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func newDisconnectedClient() *Client {
+	return &Client{
+		state:    StateReconnecting,
+		changed:  make(chan struct{}),
+		done:     make(chan struct{}),
+		topology: newTopology(),
+	}
+}
+
+func TestConnectionStateString(t *testing.T) {
+	assert.Equal(t, "connected", StateConnected.String())
+	assert.Equal(t, "reconnecting", StateReconnecting.String())
+	assert.Equal(t, "closed", StateClosed.String())
+	assert.Equal(t, "unknown", ConnectionState(42).String())
+}
+
+func TestSetStateNotifiesCallbacks(t *testing.T) {
+	client := newDisconnectedClient()
+	var states []ConnectionState
+	client.OnStateChange(func(state ConnectionState) {
+		states = append(states, state)
+	})
+
+	client.setState(StateConnected)
+	client.setState(StateReconnecting)
+
+	assert.Equal(t, []ConnectionState{StateConnected, StateReconnecting}, states)
+	assert.EqualError(t, client.CheckConnection(), "rabbitmq connection is reconnecting")
+}
+
+func TestSetStateIgnoredAfterClose(t *testing.T) {
+	client := newDisconnectedClient()
+	client.setState(StateClosed)
+	client.setState(StateConnected)
+
+	assert.Equal(t, StateClosed, client.State())
+	assert.False(t, client.IsConnected())
+}
+
+func TestWaitForChannelReturnsFalseWhenClosed(t *testing.T) {
+	client := newDisconnectedClient()
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		close(client.done)
+	}()
+
+	ch, ok := client.waitForChannel(nil)
+	assert.False(t, ok)
+	assert.Nil(t, ch)
+}
+
+func TestAwaitChannelTimesOutWhileReconnecting(t *testing.T) {
+	client := newDisconnectedClient()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := client.awaitChannel(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestRecoveryRetriesQueueArgumentMismatch(t *testing.T) {
+	delay := initialReconnectDelay
+	initialReconnectDelay = time.Millisecond
+	defer func() { initialReconnectDelay = delay }()
+	client := newDisconnectedClient()
+	attempts := 0
+
+	recovered := client.retryRecovery(func() error {
+		attempts++
+		if attempts < 3 {
+			return queueDeclareError("pre-processing", &amqp.Error{Code: amqp.PreconditionFailed})
+		}
+		return nil
+	})
+
+	assert.True(t, recovered, "The recovery resumes once the queue is deleted")
+	assert.Equal(t, 3, attempts)
+}
+
+func TestRecoveryStopsWhenClosed(t *testing.T) {
+	client := newDisconnectedClient()
+	close(client.done)
+
+	recovered := client.retryRecovery(func() error {
+		return queueDeclareError("pre-processing", &amqp.Error{Code: amqp.PreconditionFailed})
+	})
+
+	assert.False(t, recovered)
+}
+
+func TestTopologyIgnoresDuplicates(t *testing.T) {
+	topo := newTopology()
+	topo.addExchange("test-exchange", amqp.ExchangeTopic)
+	topo.addExchange("test-exchange", amqp.ExchangeTopic)
+	topo.addQueue("test-queue", nil)
+	topo.addQueue("test-queue", amqp.Table{"x-dead-letter-exchange": "test-queue.dlx"})
+	topo.addBinding("test-queue", "test.key", "test-exchange")
+	topo.addBinding("test-queue", "test.key", "test-exchange")
+
+	assert.Len(t, topo.exchanges, 1)
+	assert.Len(t, topo.queues, 1)
+	assert.Equal(t, "test-queue.dlx", topo.queues[0].args["x-dead-letter-exchange"])
+	assert.Len(t, topo.bindings, 1)
+}
+
+// acknowledgerMock records the requeued and dead-lettered delivery tags.
+type acknowledgerMock struct {
+	requeued     []uint64
+	deadLettered []uint64
+}
+
+func (a *acknowledgerMock) Ack(tag uint64, multiple bool) error {
+	return nil
+}
+
+func (a *acknowledgerMock) Nack(tag uint64, multiple bool, requeue bool) error {
+	if requeue {
+		a.requeued = append(a.requeued, tag)
+	} else {
+		a.deadLettered = append(a.deadLettered, tag)
+	}
+	return nil
+}
+
+func (a *acknowledgerMock) Reject(tag uint64, requeue bool) error {
+	return nil
+}
+
+func TestForwardDeliveriesUntilClosed(t *testing.T) {
+	deliveryCh := make(chan amqp.Delivery, 1)
+	msgCh := make(chan amqp.Delivery, 1)
+	deliveryCh <- amqp.Delivery{DeliveryTag: 1}
+	close(deliveryCh)
+
+	assert.True(t, forwardDeliveries(context.Background(), deliveryCh, msgCh, "queue", false))
+	assert.Equal(t, uint64(1), (<-msgCh).DeliveryTag)
+}
+
+func TestForwardDeliveriesRequeuesOnCancel(t *testing.T) {
+	acknowledger := &acknowledgerMock{}
+	deliveryCh := make(chan amqp.Delivery, 1)
+	deliveryCh <- amqp.Delivery{Acknowledger: acknowledger, DeliveryTag: 7}
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	forwarded := forwardDeliveries(ctx, deliveryCh, make(chan amqp.Delivery), "queue", false)
+
+	assert.False(t, forwarded)
+	assert.Equal(t, []uint64{7}, acknowledger.requeued, "A delivery nobody received should be requeued")
+}
+
+func TestRequeueSkipsAutoAck(t *testing.T) {
+	acknowledger := &acknowledgerMock{}
+	requeue(amqp.Delivery{Acknowledger: acknowledger, DeliveryTag: 1}, true)
+	requeue(amqp.Delivery{Acknowledger: acknowledger, DeliveryTag: 2}, false)
+	assert.Equal(t, []uint64{2}, acknowledger.requeued)
+}
+
+func TestQueueDeclareErrorClassifiesArgumentMismatch(t *testing.T) {
+	mismatch := &amqp.Error{Code: amqp.PreconditionFailed, Reason: "PRECONDITION_FAILED - inequivalent arg 'x-dead-letter-exchange'"}
+	err := queueDeclareError("pre-processing", mismatch)
+	assert.ErrorIs(t, err, ErrQueueArguments)
+	assert.Contains(t, err.Error(), "pre-processing")
+
+	other := &amqp.Error{Code: amqp.NotFound, Reason: "NOT_FOUND"}
+	assert.Equal(t, other, queueDeclareError("pre-processing", other))
+
+	plain := errors.New("channel is nil")
+	assert.Equal(t, plain, queueDeclareError("pre-processing", plain))
+}
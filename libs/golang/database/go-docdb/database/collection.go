@@ -14,8 +14,21 @@ type Document map[string]interface{}
 
 // Collection represents a collection of documents with thread-safe operations.
 type Collection struct {
-	data map[string]Document
-	mu   sync.RWMutex
+	name    string
+	data    map[string]Document
+	mu      sync.RWMutex
+	store   *store
+	indexes []*index
+
+	// Versions are used by transactions to detect concurrent writes.
+	versions   map[string]uint64 // Version of the last write of each document, deletes included
+	clearedAt  uint64            // Version of documents absent from versions
+	lastDelete uint64            // Version of the last delete
+
+	// Change streams.
+	changes   []ChangeEvent // Last changes, retained to resume change streams
+	changeSeq ResumeToken   // Token of the last change
+	watchers  []*ChangeStream
 }
 
 // NewCollection creates a new collection and initializes its data map.
@@ -23,8 +36,23 @@ type Collection struct {
 // Returns:
 //   - A pointer to the newly created Collection instance.
 func NewCollection() *Collection {
+	return newCollection("", nil)
+}
+
+// newCollection creates a new collection whose writes are logged to the given store.
+//
+// Parameters:
+//   - name: The name of the collection.
+//   - store: The store persisting the writes, or nil for a purely in-memory collection.
+//
+// Returns:
+//   - A pointer to the newly created Collection instance.
+func newCollection(name string, store *store) *Collection {
 	return &Collection{
-		data: make(map[string]Document),
+		name:     name,
+		data:     make(map[string]Document),
+		store:    store,
+		versions: make(map[string]uint64),
 	}
 }
 
@@ -38,6 +66,8 @@ func NewCollection() *Collection {
 func (c *Collection) InsertOne(
 	document Document,
 ) error {
+	endWrite := c.store.beginWrite()
+	defer endWrite()
 	c.mu.Lock() // Lock for writing
 	defer c.mu.Unlock()
 
@@ -46,11 +76,25 @@ func (c *Collection) InsertOne(
 	if !ok {
 		return errors.New("_id field is required")
 	}
-	_, ok = c.data[documentID.(string)]
+	id, ok := documentID.(string)
+	if !ok {
+		return errors.New("_id field must be a string")
+	}
+	_, ok = c.data[id]
 	if ok {
 		return errors.New("document already exists")
 	}
-	c.data[documentID.(string)] = document
+	if err := c.checkUnique(id, document); err != nil {
+		return err
+	}
+	stored, err := c.store.append(walRecord{Op: opPut, Collection: c.name, ID: id, Document: document})
+	if err != nil {
+		return err
+	}
+	c.data[id] = stored
+	c.reindex(id, nil, stored)
+	c.stamp(id, nextVersion(), false)
+	c.publish(id, nil, stored)
 	return nil
 }
 
@@ -89,38 +133,8 @@ func (c *Collection) FindAll() []Document {
 	return documents
 }
 
-// matchesQuery checks if a document matches the query criteria.
-//
-// Parameters:
-//   - document: The document to check against the query.
-//   - query: The query criteria to match against the document.
-//
-// Returns:
-//   - A boolean indicating if the document matches the query.
-func matchesQuery(document, query map[string]interface{}) bool {
-	for key, value := range query {
-		docValue, exists := document[key]
-		if !exists {
-			return false
-		}
-
-		// If the value is a map, recurse into it.
-		if queryMap, ok := value.(map[string]interface{}); ok {
-			docMap, ok := docValue.(map[string]interface{})
-			if !ok || !matchesQuery(docMap, queryMap) {
-				return false
-			}
-		} else {
-			// Direct comparison for non-map values
-			if docValue != value {
-				return false
-			}
-		}
-	}
-	return true
-}
-
-// Find searches for documents matching a given query.
+// Find searches for documents matching a given query. When an index covers
+// equality predicates of the query, only the documents it references are examined.
 //
 // Parameters:
 //   - query: The query criteria to match documents against.
@@ -130,12 +144,7 @@ func matchesQuery(document, query map[string]interface{}) bool {
 func (c *Collection) Find(query map[string]interface{}) []Document {
 	c.mu.RLock() // Lock for reading
 	defer c.mu.RUnlock()
-	documents := make([]Document, 0, len(c.data))
-	for _, document := range c.data {
-		if matchesQuery(document, query) {
-			documents = append(documents, document)
-		}
-	}
+	documents, _ := c.execute(query)
 	return documents
 }
 
@@ -149,13 +158,21 @@ func (c *Collection) Find(query map[string]interface{}) []Document {
 func (c *Collection) DeleteOne(
 	id string,
 ) error {
+	endWrite := c.store.beginWrite()
+	defer endWrite()
 	c.mu.Lock() // Lock for writing
 	defer c.mu.Unlock()
-	_, ok := c.data[id]
+	current, ok := c.data[id]
 	if !ok {
 		return errors.New("document not found")
 	}
+	if _, err := c.store.append(walRecord{Op: opDelete, Collection: c.name, ID: id}); err != nil {
+		return err
+	}
 	delete(c.data, id)
+	c.reindex(id, current, nil)
+	c.stamp(id, nextVersion(), true)
+	c.publish(id, current, nil)
 	return nil
 }
 
@@ -163,22 +180,21 @@ func (c *Collection) DeleteOne(
 //
 // Parameters:
 //   - id: The ID of the document to update.
-//   - update: The document fields to update.
+//   - update: The document fields to merge into the document, or update operators such as
+//     {"$set": {"stage": "completed"}}. See UpdateByID for the result counts and upserts.
 //
 // Returns:
-//   - An error if the document does not exist.
+//   - An error if the document does not exist or the update is invalid.
 func (c *Collection) UpdateOne(
 	id string,
 	update Document,
 ) error {
-	c.mu.Lock() // Lock for writing
-	defer c.mu.Unlock()
-	_, ok := c.data[id]
-	if !ok {
-		return errors.New("document not found")
+	result, err := c.UpdateByID(id, update)
+	if err != nil {
+		return err
 	}
-	for key, value := range update {
-		c.data[id][key] = value
+	if result.MatchedCount == 0 {
+		return errors.New("document not found")
 	}
 	return nil
 }
@@ -188,8 +204,20 @@ func (c *Collection) UpdateOne(
 // Returns:
 //   - An error if there is an issue during deletion.
 func (c *Collection) DeleteAll() error {
+	endWrite := c.store.beginWrite()
+	defer endWrite()
 	c.mu.Lock() // Lock for writing
 	defer c.mu.Unlock()
+	if _, err := c.store.append(walRecord{Op: opClear, Collection: c.name}); err != nil {
+		return err
+	}
+	for id, document := range c.data {
+		c.publish(id, document, nil)
+	}
 	c.data = make(map[string]Document)
+	c.versions = make(map[string]uint64)
+	c.clearedAt = nextVersion()
+	c.lastDelete = c.clearedAt
+	c.clearIndexes()
 	return nil
 }
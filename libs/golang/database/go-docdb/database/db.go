@@ -1,13 +1,19 @@
 package database
 
-import "errors"
+import (
+	"errors"
+	"sync"
+)
 
 // InMemoryDocBD represents an in-memory document-based database.
 // It stores collections of documents in memory and provides methods
-// to interact with these collections.
+// to interact with these collections. With WithPersistence, every write
+// is also logged to disk and the database is restored when it is opened again.
 type InMemoryDocBD struct {
 	Name        string
 	Collections map[string]*Collection
+	mu          sync.RWMutex
+	store       *store
 }
 
 // NewInMemoryDocBD creates a new in-memory document-based database
@@ -15,14 +21,42 @@ type InMemoryDocBD struct {
 //
 // Parameters:
 //   - name: The name of the in-memory database.
+//   - opts: Optional settings, such as WithPersistence.
 //
 // Returns:
 //   - A pointer to the newly created InMemoryDocBD instance.
-func NewInMemoryDocBD(name string) *InMemoryDocBD {
-	return &InMemoryDocBD{
+//
+// Panics if an option fails, for instance when the persisted data cannot be read.
+// Use OpenInMemoryDocBD to handle that error.
+func NewInMemoryDocBD(name string, opts ...Option) *InMemoryDocBD {
+	db, err := OpenInMemoryDocBD(name, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return db
+}
+
+// OpenInMemoryDocBD creates a new document-based database with the specified name
+// and applies the given options, restoring the persisted state when WithPersistence is used.
+//
+// Parameters:
+//   - name: The name of the database.
+//   - opts: Optional settings, such as WithPersistence.
+//
+// Returns:
+//   - A pointer to the newly created InMemoryDocBD instance.
+//   - An error if an option fails.
+func OpenInMemoryDocBD(name string, opts ...Option) (*InMemoryDocBD, error) {
+	db := &InMemoryDocBD{
 		Name:        name,
 		Collections: make(map[string]*Collection),
 	}
+	for _, opt := range opts {
+		if err := opt(db); err != nil {
+			return nil, err
+		}
+	}
+	return db, nil
 }
 
 // GetCollection retrieves a collection by its name.
@@ -36,6 +70,8 @@ func NewInMemoryDocBD(name string) *InMemoryDocBD {
 func (d *InMemoryDocBD) GetCollection(
 	collectionName string,
 ) (*Collection, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
 	collection, ok := d.Collections[collectionName]
 	if !ok {
 		return nil, errors.New("collection not found")
@@ -53,10 +89,17 @@ func (d *InMemoryDocBD) GetCollection(
 func (d *InMemoryDocBD) CreateCollection(
 	collectionName string,
 ) error {
+	endWrite := d.store.beginWrite()
+	defer endWrite()
+	d.mu.Lock()
+	defer d.mu.Unlock()
 	if _, ok := d.Collections[collectionName]; ok {
 		return errors.New("collection already exists")
 	}
-	d.Collections[collectionName] = NewCollection()
+	if _, err := d.store.append(walRecord{Op: opCreateCollection, Collection: collectionName}); err != nil {
+		return err
+	}
+	d.Collections[collectionName] = newCollection(collectionName, d.store)
 	return nil
 }
 
@@ -70,10 +113,19 @@ func (d *InMemoryDocBD) CreateCollection(
 func (d *InMemoryDocBD) DropCollection(
 	collectionName string,
 ) error {
-	if _, ok := d.Collections[collectionName]; !ok {
+	endWrite := d.store.beginWrite()
+	defer endWrite()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	collection, ok := d.Collections[collectionName]
+	if !ok {
 		return errors.New("collection not found")
 	}
+	if _, err := d.store.append(walRecord{Op: opDropCollection, Collection: collectionName}); err != nil {
+		return err
+	}
 	delete(d.Collections, collectionName)
+	collection.invalidate()
 	return nil
 }
 
@@ -82,9 +134,35 @@ func (d *InMemoryDocBD) DropCollection(
 // Returns:
 //   - A slice of strings containing the names of all collections.
 func (d *InMemoryDocBD) ListCollections() []string {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
 	collectionNames := make([]string, 0, len(d.Collections))
 	for collectionName := range d.Collections {
 		collectionNames = append(collectionNames, collectionName)
 	}
 	return collectionNames
 }
+
+// Snapshot compacts the write-ahead log into a new snapshot.
+// It is a no-op when persistence is disabled.
+//
+// Returns:
+//   - An error if the snapshot cannot be written.
+func (d *InMemoryDocBD) Snapshot() error {
+	if d.store == nil {
+		return nil
+	}
+	return d.store.snapshot(true)
+}
+
+// Close takes a final snapshot and releases the files used by the database.
+// It is a no-op when persistence is disabled.
+//
+// Returns:
+//   - An error if the final snapshot cannot be written or the files cannot be closed.
+func (d *InMemoryDocBD) Close() error {
+	if d.store == nil {
+		return nil
+	}
+	return d.store.close()
+}
@@ -0,0 +1,221 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// SortField is a sort key of a query.
+type SortField struct {
+	Field string // Field path, possibly dotted
+	Order int    // 1 for ascending, -1 for descending
+}
+
+// FindOptions configures the documents returned by FindWithOptions.
+type FindOptions struct {
+	Sort       []SortField    // Sort keys, applied in order; ties are broken by "_id"
+	Projection map[string]int // Fields to include (1) or exclude (0); "_id" is included unless excluded
+	Skip       int            // Number of documents to skip
+	Limit      int            // Maximum number of documents to return, 0 for no limit
+}
+
+// FindWithOptions searches for documents matching a query and returns them sorted,
+// paginated and projected. The order is always deterministic: documents equal on every
+// sort key, or all documents when no sort key is given, are ordered by "_id".
+//
+// Parameters:
+//   - query: The query criteria to match documents against.
+//   - opts: The sort keys, projection, skip and limit; nil returns every match ordered by "_id".
+//
+// Returns:
+//   - A slice of documents. With a projection, the documents are copies holding the projected fields.
+//   - An error if the options are invalid.
+func (c *Collection) FindWithOptions(query map[string]interface{}, opts *FindOptions) ([]Document, error) {
+	if opts == nil {
+		opts = &FindOptions{}
+	}
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
+	c.mu.RLock() // Lock for reading
+	documents, _ := c.execute(query)
+	c.mu.RUnlock()
+
+	sortDocuments(documents, opts.Sort)
+
+	if opts.Skip >= len(documents) {
+		documents = documents[:0]
+	} else {
+		documents = documents[opts.Skip:]
+	}
+	if opts.Limit > 0 && opts.Limit < len(documents) {
+		documents = documents[:opts.Limit]
+	}
+
+	if len(opts.Projection) > 0 {
+		projected := make([]Document, len(documents))
+		for i, document := range documents {
+			projected[i] = project(document, opts.Projection)
+		}
+		documents = projected
+	}
+	return documents, nil
+}
+
+// validate checks the consistency of the options.
+//
+// Returns:
+//   - An error if a sort order is not 1 or -1, skip or limit is negative,
+//     or the projection mixes included and excluded fields other than "_id".
+func (o *FindOptions) validate() error {
+	for _, key := range o.Sort {
+		if key.Order != 1 && key.Order != -1 {
+			return fmt.Errorf("invalid sort order %d for %s: expected 1 or -1", key.Order, key.Field)
+		}
+	}
+	if o.Skip < 0 {
+		return errors.New("skip must not be negative")
+	}
+	if o.Limit < 0 {
+		return errors.New("limit must not be negative")
+	}
+	include, exclude := false, false
+	for field, flag := range o.Projection {
+		if field == "_id" {
+			continue
+		}
+		if flag == 0 {
+			exclude = true
+		} else {
+			include = true
+		}
+	}
+	if include && exclude {
+		return errors.New("projection cannot mix included and excluded fields")
+	}
+	return nil
+}
+
+// sortDocuments sorts documents by the given keys, then by "_id".
+//
+// Parameters:
+//   - documents: The documents to sort in place.
+//   - keys: The sort keys.
+func sortDocuments(documents []Document, keys []SortField) {
+	sort.SliceStable(documents, func(i, j int) bool {
+		for _, key := range keys {
+			a, _ := lookupPath(documents[i], key.Field)
+			b, _ := lookupPath(documents[j], key.Field)
+			if result := orderValues(a, b); result != 0 {
+				return result*key.Order < 0
+			}
+		}
+		return orderValues(documents[i]["_id"], documents[j]["_id"]) < 0
+	})
+}
+
+// typeRank orders values of different kinds the way MongoDB does:
+// missing and null, numbers, strings, documents, arrays, booleans, then dates.
+//
+// Parameters:
+//   - value: The value to rank.
+//
+// Returns:
+//   - The rank of the kind of the value.
+func typeRank(value interface{}) int {
+	if value == nil {
+		return 0
+	}
+	if _, ok := toFloat(value); ok {
+		return 1
+	}
+	switch value.(type) {
+	case string:
+		return 2
+	case bool:
+		return 5
+	case time.Time:
+		return 6
+	}
+	if _, ok := asMap(value); ok {
+		return 3
+	}
+	if _, ok := toSlice(value); ok {
+		return 4
+	}
+	return 7
+}
+
+// orderValues compares two values for sorting.
+//
+// Parameters:
+//   - a: The first value.
+//   - b: The second value.
+//
+// Returns:
+//   - A negative number, zero or a positive number if a sorts before, with or after b.
+func orderValues(a, b interface{}) int {
+	rankA, rankB := typeRank(a), typeRank(b)
+	if rankA != rankB {
+		return rankA - rankB
+	}
+	if result, ok := compareValues(a, b); ok {
+		return result
+	}
+	if x, ok := a.(bool); ok {
+		y := b.(bool)
+		switch {
+		case x == y:
+			return 0
+		case !x:
+			return -1
+		}
+		return 1
+	}
+	return 0
+}
+
+// project keeps or removes the fields listed in a projection.
+//
+// Parameters:
+//   - document: The document to project.
+//   - projection: The fields to include (1) or exclude (0).
+//
+// Returns:
+//   - A new document holding the projected fields.
+func project(document Document, projection map[string]int) Document {
+	inclusion := false
+	for field, flag := range projection {
+		if field != "_id" && flag != 0 {
+			inclusion = true
+			break
+		}
+	}
+
+	if !inclusion {
+		projected := deepCopy(document).(Document)
+		for field := range projection {
+			unsetPath(projected, field)
+		}
+		return projected
+	}
+
+	projected := make(Document, len(projection)+1)
+	if flag, ok := projection["_id"]; !ok || flag != 0 {
+		if id, ok := document["_id"]; ok {
+			projected["_id"] = id
+		}
+	}
+	for field, flag := range projection {
+		if field == "_id" || flag == 0 {
+			continue
+		}
+		if value, ok := lookupPath(document, field); ok {
+			_ = setPath(projected, field, deepCopy(value))
+		}
+	}
+	return projected
+}
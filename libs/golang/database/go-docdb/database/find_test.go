@@ -0,0 +1,119 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type FindTestSuite struct {
+	suite.Suite
+	collection *Collection
+}
+
+func TestFindTestSuite(t *testing.T) {
+	suite.Run(t, new(FindTestSuite))
+}
+
+func (suite *FindTestSuite) SetupTest() {
+	suite.collection = NewCollection()
+	documents := []Document{
+		{"_id": "d", "provider": "p1", "stage": "received", "attempts": 2, "metadata": map[string]interface{}{"source": "s1", "size": 10}},
+		{"_id": "b", "provider": "p2", "stage": "completed", "attempts": 1, "metadata": map[string]interface{}{"source": "s2", "size": 20}},
+		{"_id": "a", "provider": "p1", "stage": "completed", "attempts": 3},
+		{"_id": "c", "provider": "p2", "stage": "received", "attempts": 2.5},
+	}
+	for _, document := range documents {
+		suite.Require().NoError(suite.collection.InsertOne(document))
+	}
+}
+
+// ids returns the IDs of the documents, in order.
+func ids(documents []Document) []string {
+	result := make([]string, len(documents))
+	for i, document := range documents {
+		result[i] = document["_id"].(string)
+	}
+	return result
+}
+
+func (suite *FindTestSuite) TestDefaultOrderIsByID() {
+	documents, err := suite.collection.FindWithOptions(nil, nil)
+	suite.Require().NoError(err)
+	suite.Equal([]string{"a", "b", "c", "d"}, ids(documents))
+}
+
+func (suite *FindTestSuite) TestSort() {
+	documents, err := suite.collection.FindWithOptions(nil, &FindOptions{
+		Sort: []SortField{{Field: "attempts", Order: -1}},
+	})
+	suite.Require().NoError(err)
+	suite.Equal([]string{"a", "c", "d", "b"}, ids(documents))
+
+	documents, err = suite.collection.FindWithOptions(nil, &FindOptions{
+		Sort: []SortField{{Field: "stage", Order: 1}, {Field: "provider", Order: -1}},
+	})
+	suite.Require().NoError(err)
+	suite.Equal([]string{"b", "a", "c", "d"}, ids(documents))
+}
+
+func (suite *FindTestSuite) TestSortPutsMissingFieldsFirst() {
+	documents, err := suite.collection.FindWithOptions(nil, &FindOptions{
+		Sort: []SortField{{Field: "metadata.size", Order: 1}},
+	})
+	suite.Require().NoError(err)
+	suite.Equal([]string{"a", "c", "d", "b"}, ids(documents))
+}
+
+func (suite *FindTestSuite) TestSkipAndLimit() {
+	documents, err := suite.collection.FindWithOptions(
+		map[string]interface{}{"attempts": map[string]interface{}{"$gte": 2}},
+		&FindOptions{Skip: 1, Limit: 1},
+	)
+	suite.Require().NoError(err)
+	suite.Equal([]string{"c"}, ids(documents))
+
+	documents, err = suite.collection.FindWithOptions(nil, &FindOptions{Skip: 10})
+	suite.Require().NoError(err)
+	suite.Empty(documents)
+}
+
+func (suite *FindTestSuite) TestInclusionProjection() {
+	documents, err := suite.collection.FindWithOptions(
+		map[string]interface{}{"_id": "d"},
+		&FindOptions{Projection: map[string]int{"stage": 1, "metadata.source": 1}},
+	)
+	suite.Require().NoError(err)
+	suite.Equal([]Document{{"_id": "d", "stage": "received", "metadata": map[string]interface{}{"source": "s1"}}}, documents)
+
+	documents, err = suite.collection.FindWithOptions(
+		map[string]interface{}{"_id": "d"},
+		&FindOptions{Projection: map[string]int{"_id": 0, "stage": 1}},
+	)
+	suite.Require().NoError(err)
+	suite.Equal([]Document{{"stage": "received"}}, documents)
+}
+
+func (suite *FindTestSuite) TestExclusionProjection() {
+	documents, err := suite.collection.FindWithOptions(
+		map[string]interface{}{"_id": "d"},
+		&FindOptions{Projection: map[string]int{"metadata.size": 0, "attempts": 0}},
+	)
+	suite.Require().NoError(err)
+	suite.Equal([]Document{{"_id": "d", "provider": "p1", "stage": "received", "metadata": map[string]interface{}{"source": "s1"}}}, documents)
+
+	stored, _ := suite.collection.FindOne("d")
+	suite.Equal(map[string]interface{}{"source": "s1", "size": 10}, stored["metadata"])
+}
+
+func (suite *FindTestSuite) TestInvalidOptions() {
+	for _, opts := range []*FindOptions{
+		{Sort: []SortField{{Field: "stage", Order: 0}}},
+		{Skip: -1},
+		{Limit: -1},
+		{Projection: map[string]int{"stage": 1, "provider": 0}},
+	} {
+		_, err := suite.collection.FindWithOptions(nil, opts)
+		suite.Error(err)
+	}
+}
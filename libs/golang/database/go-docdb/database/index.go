@@ -0,0 +1,512 @@
+package database
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrDuplicateKey is returned when a write would break a unique index.
+	ErrDuplicateKey = errors.New("duplicate key")
+)
+
+const (
+	// ScanIndex is the strategy of a query answered through an index.
+	ScanIndex = "IXSCAN"
+	// ScanCollection is the strategy of a query answered by scanning every document.
+	ScanCollection = "COLLSCAN"
+)
+
+// QueryPlan describes how a query was executed.
+type QueryPlan struct {
+	Strategy          string   // ScanIndex or ScanCollection
+	Index             string   // Name of the index used, empty for a collection scan
+	Fields            []string // Fields of the index used
+	DocumentsExamined int      // Number of documents matched against the query
+	DocumentsReturned int      // Number of documents returned
+}
+
+// index is a secondary index over one or more, possibly dotted, field paths.
+type index struct {
+	name    string
+	fields  []string
+	unique  bool
+	entries map[string]map[string]struct{} // Index key to document IDs
+	arrays  map[string]struct{}            // IDs of documents with an array in an indexed field
+}
+
+// newIndex creates an empty index over the given fields.
+//
+// Parameters:
+//   - fields: The field paths covered by the index, in order.
+//   - unique: Whether two documents may share the same key.
+//
+// Returns:
+//   - A pointer to the newly created index.
+func newIndex(fields []string, unique bool) *index {
+	return &index{
+		name:    strings.Join(fields, "_"),
+		fields:  fields,
+		unique:  unique,
+		entries: make(map[string]map[string]struct{}),
+		arrays:  make(map[string]struct{}),
+	}
+}
+
+// encodeKey builds the index key of a tuple of values.
+//
+// Parameters:
+//   - values: The values of the indexed fields, in index order.
+//
+// Returns:
+//   - The key identifying the tuple.
+func encodeKey(values []interface{}) string {
+	for i, value := range values {
+		if t, ok := value.(time.Time); ok {
+			values[i] = t.UTC()
+		}
+	}
+	data, err := json.Marshal(values)
+	if err != nil {
+		return fmt.Sprintf("%#v", values)
+	}
+	return string(data)
+}
+
+// key computes the index key of a document.
+//
+// Parameters:
+//   - document: The document to index.
+//
+// Returns:
+//   - The index key. Missing fields are indexed as null.
+//   - Whether every indexed field is present in the document.
+//   - Whether an indexed field holds an array.
+func (idx *index) key(document Document) (string, bool, bool) {
+	values := make([]interface{}, len(idx.fields))
+	complete := true
+	array := false
+	for i, field := range idx.fields {
+		value, ok := lookupPath(document, field)
+		if !ok {
+			complete = false
+		}
+		if _, ok := toSlice(value); ok {
+			array = true
+		}
+		values[i] = value
+	}
+	return encodeKey(values), complete, array
+}
+
+// add indexes a document.
+//
+// Parameters:
+//   - id: The ID of the document.
+//   - document: The document to index.
+func (idx *index) add(id string, document Document) {
+	key, _, array := idx.key(document)
+	if array {
+		idx.arrays[id] = struct{}{}
+	}
+	ids, ok := idx.entries[key]
+	if !ok {
+		ids = make(map[string]struct{})
+		idx.entries[key] = ids
+	}
+	ids[id] = struct{}{}
+}
+
+// remove removes a document from the index.
+//
+// Parameters:
+//   - id: The ID of the document.
+//   - document: The indexed version of the document.
+func (idx *index) remove(id string, document Document) {
+	key, _, _ := idx.key(document)
+	delete(idx.arrays, id)
+	ids, ok := idx.entries[key]
+	if !ok {
+		return
+	}
+	delete(ids, id)
+	if len(ids) == 0 {
+		delete(idx.entries, key)
+	}
+}
+
+// conflicts reports whether storing the document would break the uniqueness of the index.
+// Documents missing one of the indexed fields are not subject to the constraint.
+//
+// Parameters:
+//   - id: The ID of the document being written.
+//   - document: The document being written.
+//
+// Returns:
+//   - An error wrapping ErrDuplicateKey if another document has the same key, otherwise nil.
+func (idx *index) conflicts(id string, document Document) error {
+	if !idx.unique {
+		return nil
+	}
+	key, complete, _ := idx.key(document)
+	if !complete {
+		return nil
+	}
+	for other := range idx.entries[key] {
+		if other != id {
+			return fmt.Errorf("%w: index %s already contains %s", ErrDuplicateKey, idx.name, key)
+		}
+	}
+	return nil
+}
+
+// lookupPath resolves a field of a document. The path may be a literal key or a dotted path
+// into nested documents, such as "metadata.provider".
+//
+// Parameters:
+//   - document: The document to read.
+//   - path: The field path.
+//
+// Returns:
+//   - The value of the field.
+//   - Whether the field exists.
+func lookupPath(document map[string]interface{}, path string) (interface{}, bool) {
+	if value, ok := document[path]; ok {
+		return value, true
+	}
+	head, rest, found := strings.Cut(path, ".")
+	if !found {
+		return nil, false
+	}
+	nested, ok := asMap(document[head])
+	if !ok {
+		return nil, false
+	}
+	return lookupPath(nested, rest)
+}
+
+// asMap converts a nested document to a map.
+//
+// Parameters:
+//   - value: The value to convert.
+//
+// Returns:
+//   - The value as a map.
+//   - Whether the value is a nested document.
+func asMap(value interface{}) (map[string]interface{}, bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return v, true
+	case Document:
+		return v, true
+	default:
+		return nil, false
+	}
+}
+
+// equalityPredicates collects the fields a query compares for equality, either with a literal
+// or with $eq, keyed by dotted path. Clauses of $and are included; those of $or are not.
+//
+// Parameters:
+//   - query: The query criteria.
+//   - prefix: The path of the nested document being visited.
+//   - predicates: The map receiving the predicates.
+func equalityPredicates(query map[string]interface{}, prefix string, predicates map[string]interface{}) {
+	for key, value := range query {
+		if key == "$and" {
+			clauses, _ := toSlice(value)
+			for _, clause := range clauses {
+				if clauseQuery, ok := asMap(clause); ok {
+					equalityPredicates(clauseQuery, prefix, predicates)
+				}
+			}
+			continue
+		}
+		if strings.HasPrefix(key, "$") {
+			continue
+		}
+		path := prefix + key
+		if nested, ok := asMap(value); ok {
+			if !hasOperator(nested) {
+				equalityPredicates(nested, path+".", predicates)
+			} else if eq, ok := nested["$eq"]; ok {
+				if _, isMap := asMap(eq); !isMap {
+					predicates[path] = eq
+				}
+			}
+			continue
+		}
+		predicates[path] = value
+	}
+}
+
+// hasOperator reports whether a nested query uses query operators.
+//
+// Parameters:
+//   - query: The nested query.
+//
+// Returns:
+//   - True if any key starts with "$".
+func hasOperator(query map[string]interface{}) bool {
+	for key := range query {
+		if strings.HasPrefix(key, "$") {
+			return true
+		}
+	}
+	return false
+}
+
+// CreateIndex creates an index over the given fields, so queries with equality predicates
+// on all of them avoid scanning the whole collection. Fields may be dotted paths into
+// nested documents. Creating an index that already exists is a no-op.
+//
+// With persistence, the index is logged and kept in the snapshots, so it is rebuilt when the
+// database is opened again.
+//
+// Parameters:
+//   - fields: The fields covered by the index, in order.
+//
+// Returns:
+//   - An error if no field is given or an index with the same fields but another uniqueness exists.
+func (c *Collection) CreateIndex(fields ...string) error {
+	return c.createIndex(fields, false)
+}
+
+// CreateUniqueIndex creates an index over the given fields that rejects two documents with
+// the same values. Documents missing one of the fields are not subject to the constraint.
+//
+// Parameters:
+//   - fields: The fields covered by the index, in order.
+//
+// Returns:
+//   - An error if no field is given, the existing documents already contain duplicates,
+//     or an index with the same fields but another uniqueness exists.
+func (c *Collection) CreateUniqueIndex(fields ...string) error {
+	return c.createIndex(fields, true)
+}
+
+// createIndex creates and populates an index, logging it when the collection is persisted.
+//
+// Parameters:
+//   - fields: The fields covered by the index.
+//   - unique: Whether the index is unique.
+//
+// Returns:
+//   - An error if the index cannot be created or logged.
+func (c *Collection) createIndex(fields []string, unique bool) error {
+	if len(fields) == 0 {
+		return errors.New("at least one field is required")
+	}
+	endWrite := c.store.beginWrite()
+	defer endWrite()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	idx := newIndex(append([]string(nil), fields...), unique)
+	for _, existing := range c.indexes {
+		if existing.name == idx.name {
+			if existing.unique != unique {
+				return fmt.Errorf("index %s already exists with another uniqueness", idx.name)
+			}
+			return nil
+		}
+	}
+	if err := idx.populate(c.data); err != nil {
+		return err
+	}
+	if _, err := c.store.append(walRecord{Op: opCreateIndex, Collection: c.name, Fields: idx.fields, Unique: unique}); err != nil {
+		return err
+	}
+	c.indexes = append(c.indexes, idx)
+	return nil
+}
+
+// addIndex creates and populates an index restored from a snapshot or the write-ahead log.
+// The caller must hold the collection lock, or the collection must not be shared yet.
+//
+// Parameters:
+//   - fields: The fields covered by the index.
+//   - unique: Whether the index is unique.
+//
+// Returns:
+//   - An error if the documents break the unique index.
+func (c *Collection) addIndex(fields []string, unique bool) error {
+	idx := newIndex(append([]string(nil), fields...), unique)
+	for _, existing := range c.indexes {
+		if existing.name == idx.name {
+			return nil
+		}
+	}
+	if err := idx.populate(c.data); err != nil {
+		return err
+	}
+	c.indexes = append(c.indexes, idx)
+	return nil
+}
+
+// populate indexes the documents of a collection.
+//
+// Parameters:
+//   - documents: The documents of the collection, by ID.
+//
+// Returns:
+//   - An error wrapping ErrDuplicateKey if two documents break a unique index.
+func (idx *index) populate(documents map[string]Document) error {
+	for id, document := range documents {
+		if err := idx.conflicts(id, document); err != nil {
+			return err
+		}
+		idx.add(id, document)
+	}
+	return nil
+}
+
+// clearIndexes removes every document from the indexes. The caller must hold the collection lock.
+func (c *Collection) clearIndexes() {
+	for _, idx := range c.indexes {
+		idx.entries = make(map[string]map[string]struct{})
+		idx.arrays = make(map[string]struct{})
+	}
+}
+
+// checkUnique verifies that a document can be stored without breaking a unique index.
+// The caller must hold the collection lock.
+//
+// Parameters:
+//   - id: The ID of the document.
+//   - document: The document to store.
+//
+// Returns:
+//   - An error wrapping ErrDuplicateKey on conflict.
+func (c *Collection) checkUnique(id string, document Document) error {
+	for _, idx := range c.indexes {
+		if err := idx.conflicts(id, document); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reindex replaces the indexed version of a document. The caller must hold the collection lock.
+//
+// Parameters:
+//   - id: The ID of the document.
+//   - previous: The previous version of the document, or nil if it is new.
+//   - current: The new version of the document, or nil if it was deleted.
+func (c *Collection) reindex(id string, previous, current Document) {
+	for _, idx := range c.indexes {
+		if previous != nil {
+			idx.remove(id, previous)
+		}
+		if current != nil {
+			idx.add(id, current)
+		}
+	}
+}
+
+// plan picks the index covering the most equality predicates of a query.
+// The caller must hold the collection lock.
+//
+// Parameters:
+//   - query: The query criteria.
+//
+// Returns:
+//   - The chosen index, or nil if none is usable.
+//   - The IDs of the candidate documents when an index is chosen. Documents holding an array
+//     in an indexed field are always candidates, since an element may match the query.
+func (c *Collection) plan(query map[string]interface{}) (*index, map[string]struct{}) {
+	predicates := make(map[string]interface{})
+	equalityPredicates(query, "", predicates)
+
+	var best *index
+	for _, idx := range c.indexes {
+		if best != nil && len(best.fields) >= len(idx.fields) {
+			continue
+		}
+		covered := true
+		for _, field := range idx.fields {
+			if _, ok := predicates[field]; !ok {
+				covered = false
+				break
+			}
+		}
+		if covered {
+			best = idx
+		}
+	}
+	if best == nil {
+		return nil, nil
+	}
+
+	values := make([]interface{}, len(best.fields))
+	for i, field := range best.fields {
+		values[i] = predicates[field]
+	}
+	matches := best.entries[encodeKey(values)]
+	if len(best.arrays) == 0 {
+		return best, matches
+	}
+	candidates := make(map[string]struct{}, len(matches)+len(best.arrays))
+	for id := range matches {
+		candidates[id] = struct{}{}
+	}
+	for id := range best.arrays {
+		candidates[id] = struct{}{}
+	}
+	return best, candidates
+}
+
+// execute runs a query using the best available index. The caller must hold the collection lock.
+//
+// Parameters:
+//   - query: The query criteria.
+//
+// Returns:
+//   - The matching documents.
+//   - The plan describing the execution.
+func (c *Collection) execute(query map[string]interface{}) ([]Document, QueryPlan) {
+	idx, candidates := c.plan(query)
+	plan := QueryPlan{Strategy: ScanCollection}
+	var documents []Document
+
+	if idx != nil {
+		plan.Strategy = ScanIndex
+		plan.Index = idx.name
+		plan.Fields = append([]string(nil), idx.fields...)
+		documents = make([]Document, 0, len(candidates))
+		for id := range candidates {
+			document := c.data[id]
+			plan.DocumentsExamined++
+			if matchesQuery(document, query) {
+				documents = append(documents, document)
+			}
+		}
+	} else {
+		documents = make([]Document, 0, len(c.data))
+		for _, document := range c.data {
+			plan.DocumentsExamined++
+			if matchesQuery(document, query) {
+				documents = append(documents, document)
+			}
+		}
+	}
+	plan.DocumentsReturned = len(documents)
+	return documents, plan
+}
+
+// Explain runs a query and reports how it was executed.
+//
+// Parameters:
+//   - query: The query criteria.
+//
+// Returns:
+//   - The plan describing which index, if any, was used.
+func (c *Collection) Explain(query map[string]interface{}) QueryPlan {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	_, plan := c.execute(query)
+	return plan
+}
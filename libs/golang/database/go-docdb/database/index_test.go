@@ -0,0 +1,142 @@
+package database
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type IndexTestSuite struct {
+	suite.Suite
+	collection *Collection
+}
+
+func TestIndexTestSuite(t *testing.T) {
+	suite.Run(t, new(IndexTestSuite))
+}
+
+func (suite *IndexTestSuite) SetupTest() {
+	suite.collection = NewCollection()
+	documents := []Document{
+		{"_id": "1", "metadata": map[string]interface{}{"provider": "p1", "service": "s1"}, "status": "ok"},
+		{"_id": "2", "metadata": map[string]interface{}{"provider": "p1", "service": "s2"}, "status": "ok"},
+		{"_id": "3", "metadata": map[string]interface{}{"provider": "p2", "service": "s1"}, "status": "failed"},
+	}
+	for _, document := range documents {
+		suite.Require().NoError(suite.collection.InsertOne(document))
+	}
+}
+
+func (suite *IndexTestSuite) TestFindWithoutIndexScansCollection() {
+	plan := suite.collection.Explain(map[string]interface{}{"metadata.provider": "p1"})
+
+	suite.Equal(ScanCollection, plan.Strategy)
+	suite.Equal(3, plan.DocumentsExamined)
+	suite.Equal(2, plan.DocumentsReturned)
+}
+
+func (suite *IndexTestSuite) TestFindUsesIndexOnDottedPath() {
+	suite.Require().NoError(suite.collection.CreateIndex("metadata.provider"))
+
+	documents := suite.collection.Find(map[string]interface{}{"metadata.provider": "p1"})
+	plan := suite.collection.Explain(map[string]interface{}{"metadata.provider": "p1"})
+
+	suite.Len(documents, 2)
+	suite.Equal(ScanIndex, plan.Strategy)
+	suite.Equal("metadata.provider", plan.Index)
+	suite.Equal(2, plan.DocumentsExamined)
+	suite.Equal(2, plan.DocumentsReturned)
+}
+
+func (suite *IndexTestSuite) TestFindUsesIndexOnNestedQuery() {
+	suite.Require().NoError(suite.collection.CreateIndex("metadata.provider"))
+
+	query := map[string]interface{}{"metadata": map[string]interface{}{"provider": "p2"}}
+	documents := suite.collection.Find(query)
+	plan := suite.collection.Explain(query)
+
+	suite.Len(documents, 1)
+	suite.Equal(ScanIndex, plan.Strategy)
+	suite.Equal(1, plan.DocumentsExamined)
+}
+
+func (suite *IndexTestSuite) TestPlannerPrefersMostSelectiveCompoundIndex() {
+	suite.Require().NoError(suite.collection.CreateIndex("metadata.provider"))
+	suite.Require().NoError(suite.collection.CreateIndex("metadata.provider", "metadata.service"))
+
+	plan := suite.collection.Explain(map[string]interface{}{
+		"metadata.provider": "p1",
+		"metadata.service":  "s2",
+		"status":            "ok",
+	})
+
+	suite.Equal("metadata.provider_metadata.service", plan.Index)
+	suite.Equal([]string{"metadata.provider", "metadata.service"}, plan.Fields)
+	suite.Equal(1, plan.DocumentsExamined)
+	suite.Equal(1, plan.DocumentsReturned)
+}
+
+func (suite *IndexTestSuite) TestPlannerIgnoresPartiallyCoveredIndex() {
+	suite.Require().NoError(suite.collection.CreateIndex("metadata.provider", "metadata.service"))
+
+	plan := suite.collection.Explain(map[string]interface{}{"metadata.provider": "p1"})
+
+	suite.Equal(ScanCollection, plan.Strategy)
+}
+
+func (suite *IndexTestSuite) TestIndexFollowsWrites() {
+	suite.Require().NoError(suite.collection.CreateIndex("status"))
+
+	suite.Require().NoError(suite.collection.UpdateOne("3", Document{"status": "ok"}))
+	suite.Len(suite.collection.Find(map[string]interface{}{"status": "ok"}), 3)
+	suite.Empty(suite.collection.Find(map[string]interface{}{"status": "failed"}))
+
+	suite.Require().NoError(suite.collection.DeleteOne("1"))
+	suite.Len(suite.collection.Find(map[string]interface{}{"status": "ok"}), 2)
+
+	suite.Require().NoError(suite.collection.DeleteAll())
+	suite.Empty(suite.collection.Find(map[string]interface{}{"status": "ok"}))
+	suite.Equal(0, suite.collection.Explain(map[string]interface{}{"status": "ok"}).DocumentsExamined)
+}
+
+func (suite *IndexTestSuite) TestUniqueIndexRejectsDuplicates() {
+	suite.Require().NoError(suite.collection.CreateUniqueIndex("metadata.provider", "metadata.service"))
+
+	err := suite.collection.InsertOne(Document{
+		"_id":      "4",
+		"metadata": map[string]interface{}{"provider": "p1", "service": "s1"},
+	})
+	suite.True(errors.Is(err, ErrDuplicateKey))
+	suite.Len(suite.collection.data, 3)
+
+	err = suite.collection.UpdateOne("2", Document{"metadata": map[string]interface{}{"provider": "p1", "service": "s1"}})
+	suite.True(errors.Is(err, ErrDuplicateKey))
+
+	err = suite.collection.UpdateOne("1", Document{"status": "failed"})
+	suite.NoError(err)
+}
+
+func (suite *IndexTestSuite) TestUniqueIndexIgnoresIncompleteKeys() {
+	suite.Require().NoError(suite.collection.CreateUniqueIndex("code"))
+
+	suite.NoError(suite.collection.InsertOne(Document{"_id": "4"}))
+	suite.NoError(suite.collection.InsertOne(Document{"_id": "5", "code": "a"}))
+	suite.True(errors.Is(suite.collection.InsertOne(Document{"_id": "6", "code": "a"}), ErrDuplicateKey))
+}
+
+func (suite *IndexTestSuite) TestCreateUniqueIndexFailsOnExistingDuplicates() {
+	err := suite.collection.CreateUniqueIndex("metadata.provider")
+
+	suite.True(errors.Is(err, ErrDuplicateKey))
+	suite.Equal(ScanCollection, suite.collection.Explain(map[string]interface{}{"metadata.provider": "p1"}).Strategy)
+}
+
+func (suite *IndexTestSuite) TestCreateIndexIsIdempotent() {
+	suite.NoError(suite.collection.CreateIndex("status"))
+	suite.NoError(suite.collection.CreateIndex("status"))
+	suite.Len(suite.collection.indexes, 1)
+
+	suite.Error(suite.collection.CreateUniqueIndex("status"))
+	suite.Error(suite.collection.CreateIndex())
+}
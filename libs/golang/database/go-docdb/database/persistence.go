@@ -0,0 +1,690 @@
+package database
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var (
+	walFileName              = "wal.log"
+	snapshotFileName         = "snapshot.json"
+	defaultSyncInterval      = time.Second
+	defaultSnapshotThreshold = 1000
+)
+
+// SyncPolicy controls when writes to the write-ahead log are flushed to stable storage.
+type SyncPolicy int
+
+const (
+	// SyncAlways fsyncs the write-ahead log after every write. No acknowledged write is lost on a crash.
+	SyncAlways SyncPolicy = iota
+	// SyncInterval fsyncs the write-ahead log periodically. Writes of the last interval may be lost on a crash.
+	SyncInterval
+	// SyncNever leaves flushing to the operating system.
+	SyncNever
+)
+
+// String returns the name of the sync policy.
+func (p SyncPolicy) String() string {
+	switch p {
+	case SyncAlways:
+		return "always"
+	case SyncInterval:
+		return "interval"
+	case SyncNever:
+		return "never"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseSyncPolicy converts a policy name ("always", "interval" or "never") into a SyncPolicy.
+//
+// Parameters:
+//   - name: The name of the policy.
+//
+// Returns:
+//   - The matching SyncPolicy.
+//   - An error if the name is unknown.
+func ParseSyncPolicy(name string) (SyncPolicy, error) {
+	switch name {
+	case "always", "":
+		return SyncAlways, nil
+	case "interval":
+		return SyncInterval, nil
+	case "never":
+		return SyncNever, nil
+	default:
+		return SyncAlways, fmt.Errorf("unknown sync policy: %s", name)
+	}
+}
+
+// PersistenceConfig configures the file-backed mode of the database.
+type PersistenceConfig struct {
+	Dir               string        // Directory holding one sub-directory per database
+	SyncPolicy        SyncPolicy    // When the write-ahead log is fsynced
+	SyncInterval      time.Duration // Fsync period when SyncPolicy is SyncInterval
+	SnapshotThreshold int           // Number of logged writes that triggers a snapshot
+}
+
+// Option configures an InMemoryDocBD.
+type Option func(*InMemoryDocBD) error
+
+// WithPersistence enables the file-backed mode: every write is appended to a write-ahead log,
+// the log is compacted into periodic snapshots, and both are replayed when the database is opened.
+//
+// Documents are persisted as JSON, so values are restored as JSON types
+// (numbers as float64, objects as map[string]interface{}, arrays as []interface{}).
+// The same conversion is applied when a document is written, so the in-memory state always
+// matches what is replayed after a restart.
+//
+// Parameters:
+//   - config: The persistence configuration.
+//
+// Returns:
+//   - An Option enabling persistence.
+func WithPersistence(config PersistenceConfig) Option {
+	return func(d *InMemoryDocBD) error {
+		if config.Dir == "" {
+			return errors.New("persistence directory is required")
+		}
+		if config.SyncInterval <= 0 {
+			config.SyncInterval = defaultSyncInterval
+		}
+		if config.SnapshotThreshold <= 0 {
+			config.SnapshotThreshold = defaultSnapshotThreshold
+		}
+		s, err := openStore(d, config)
+		if err != nil {
+			return err
+		}
+		d.store = s
+		return nil
+	}
+}
+
+// walOp is the kind of change recorded in the write-ahead log.
+type walOp string
+
+const (
+	opPut              walOp = "put"
+	opDelete           walOp = "delete"
+	opClear            walOp = "clear"
+	opCreateCollection walOp = "create_collection"
+	opDropCollection   walOp = "drop_collection"
+	opTransaction      walOp = "transaction"
+	opCreateIndex      walOp = "create_index"
+)
+
+// walRecord is a single entry of the write-ahead log.
+type walRecord struct {
+	Seq        uint64      `json:"seq"`
+	Op         walOp       `json:"op"`
+	Collection string      `json:"collection"`
+	ID         string      `json:"id,omitempty"`
+	Document   Document    `json:"document,omitempty"`
+	Ops        []walRecord `json:"ops,omitempty"`    // Changes committed together by a transaction
+	Fields     []string    `json:"fields,omitempty"` // Fields of a created index
+	Unique     bool        `json:"unique,omitempty"` // Uniqueness of a created index
+}
+
+// indexDefinition is an index as stored in a snapshot. The index entries are rebuilt from the documents.
+type indexDefinition struct {
+	Fields []string `json:"fields"`
+	Unique bool     `json:"unique,omitempty"`
+}
+
+// snapshot is the content of a snapshot file.
+type snapshot struct {
+	Seq         uint64                         `json:"seq"`
+	Collections map[string]map[string]Document `json:"collections"`
+	Indexes     map[string][]indexDefinition   `json:"indexes,omitempty"` // Indexes of each collection
+}
+
+// store persists the changes of a database into a write-ahead log and snapshots.
+//
+// Lock order: writeMu, then the database lock, then the collection lock, then walMu.
+// Snapshots hold writeMu exclusively, so they never wait on a writer.
+type store struct {
+	db      *InMemoryDocBD
+	config  PersistenceConfig
+	dir     string
+	writeMu sync.RWMutex // Held shared by writers and exclusively while a snapshot is taken
+	walMu   sync.Mutex   // Guards the fields below
+	wal     *os.File
+	size    int64  // Size of the write-ahead log
+	seq     uint64 // Sequence number of the last logged change
+	pending int    // Number of changes logged since the last snapshot
+	dirty   bool   // Whether there are writes not yet fsynced
+	closed  bool
+	done    chan struct{}
+	wg      sync.WaitGroup
+}
+
+// openStore restores the database from disk and opens its write-ahead log for appending.
+//
+// Parameters:
+//   - db: The database to restore.
+//   - config: The persistence configuration.
+//
+// Returns:
+//   - A pointer to the opened store.
+//   - An error if the snapshot or the write-ahead log cannot be read.
+func openStore(db *InMemoryDocBD, config PersistenceConfig) (*store, error) {
+	dir := filepath.Join(config.Dir, db.Name)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create data directory %s: %w", dir, err)
+	}
+	s := &store{
+		db:     db,
+		config: config,
+		dir:    dir,
+		done:   make(chan struct{}),
+	}
+	if err := s.loadSnapshot(); err != nil {
+		return nil, err
+	}
+	if err := s.replay(); err != nil {
+		return nil, err
+	}
+	if config.SyncPolicy == SyncInterval {
+		s.wg.Add(1)
+		go s.syncLoop()
+	}
+	log.Printf("Opened docdb %s from %s at sequence %d", db.Name, dir, s.seq)
+	return s, nil
+}
+
+// loadSnapshot restores the collections stored in the snapshot file, if any, and rebuilds their indexes.
+//
+// Returns:
+//   - An error if the snapshot exists but cannot be read, or an index cannot be rebuilt.
+func (s *store) loadSnapshot() error {
+	data, err := os.ReadFile(filepath.Join(s.dir, snapshotFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read snapshot: %w", err)
+	}
+	var snap snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return fmt.Errorf("failed to decode snapshot: %w", err)
+	}
+	for name, documents := range snap.Collections {
+		collection := newCollection(name, s)
+		for id, document := range documents {
+			collection.data[id] = document
+		}
+		s.db.Collections[name] = collection
+	}
+	for name, definitions := range snap.Indexes {
+		collection, ok := s.db.Collections[name]
+		if !ok {
+			return fmt.Errorf("failed to decode snapshot: index of unknown collection %s", name)
+		}
+		for _, definition := range definitions {
+			if err := collection.addIndex(definition.Fields, definition.Unique); err != nil {
+				return fmt.Errorf("failed to rebuild index of collection %s: %w", name, err)
+			}
+		}
+	}
+	s.seq = snap.Seq
+	return nil
+}
+
+// replay applies the write-ahead log records newer than the snapshot. A torn last line, left by a
+// crash in the middle of a write, is truncated. A corrupted line followed by other lines cannot be
+// left by a crash, so it fails the replay rather than dropping the records after it.
+//
+// Returns:
+//   - An error if the write-ahead log cannot be opened, read or truncated, if a line before the last
+//     one is corrupted, or if a record cannot be applied.
+func (s *store) replay() error {
+	wal, err := os.OpenFile(filepath.Join(s.dir, walFileName), os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open write-ahead log: %w", err)
+	}
+
+	reader := bufio.NewReader(wal)
+	var offset int64
+	for {
+		line, readErr := reader.ReadBytes('\n')
+		if readErr == io.EOF && len(line) == 0 {
+			break
+		}
+		if readErr != nil && readErr != io.EOF {
+			wal.Close()
+			return fmt.Errorf("failed to read write-ahead log: %w", readErr)
+		}
+		record, decodeErr := decodeRecord(line)
+		if decodeErr != nil && readErr == nil {
+			if _, peekErr := reader.Peek(1); peekErr != io.EOF {
+				wal.Close()
+				return fmt.Errorf("corrupted write-ahead log record at offset %d: %w", offset, decodeErr)
+			}
+		}
+		if readErr == io.EOF || decodeErr != nil {
+			log.Printf("Truncating corrupted write-ahead log tail of docdb %s at offset %d: %v", s.db.Name, offset, decodeErr)
+			if err := wal.Truncate(offset); err != nil {
+				wal.Close()
+				return fmt.Errorf("failed to truncate write-ahead log: %w", err)
+			}
+			break
+		}
+		offset += int64(len(line))
+		if record.Seq <= s.seq {
+			continue
+		}
+		if err := s.apply(record); err != nil {
+			wal.Close()
+			return fmt.Errorf("failed to replay write-ahead log record %d: %w", record.Seq, err)
+		}
+		s.seq = record.Seq
+		s.pending++
+	}
+
+	if _, err := wal.Seek(offset, io.SeekStart); err != nil {
+		wal.Close()
+		return fmt.Errorf("failed to seek write-ahead log: %w", err)
+	}
+	s.wal = wal
+	s.size = offset
+	return nil
+}
+
+// apply replays a single record on the database, keeping the indexes up to date.
+//
+// Parameters:
+//   - record: The record to apply.
+//
+// Returns:
+//   - An error if a logged index cannot be rebuilt.
+func (s *store) apply(record walRecord) error {
+	collection, ok := s.db.Collections[record.Collection]
+	switch record.Op {
+	case opCreateCollection:
+		if !ok {
+			s.db.Collections[record.Collection] = newCollection(record.Collection, s)
+		}
+		return nil
+	case opDropCollection:
+		delete(s.db.Collections, record.Collection)
+		return nil
+	case opTransaction:
+		for _, op := range record.Ops {
+			if err := s.apply(op); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if !ok {
+		collection = newCollection(record.Collection, s)
+		s.db.Collections[record.Collection] = collection
+	}
+	switch record.Op {
+	case opPut:
+		collection.reindex(record.ID, collection.data[record.ID], record.Document)
+		collection.data[record.ID] = record.Document
+	case opDelete:
+		collection.reindex(record.ID, collection.data[record.ID], nil)
+		delete(collection.data, record.ID)
+	case opClear:
+		collection.data = make(map[string]Document)
+		collection.clearIndexes()
+	case opCreateIndex:
+		return collection.addIndex(record.Fields, record.Unique)
+	}
+	return nil
+}
+
+// encodeRecord serializes a record as a checksummed line.
+//
+// Parameters:
+//   - record: The record to serialize.
+//
+// Returns:
+//   - The line to append to the write-ahead log.
+//   - An error if the record cannot be serialized.
+func encodeRecord(record walRecord) ([]byte, error) {
+	payload, err := json.Marshal(record)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode write-ahead log record: %w", err)
+	}
+	line := make([]byte, 0, len(payload)+10)
+	line = append(line, fmt.Sprintf("%08x ", crc32.ChecksumIEEE(payload))...)
+	line = append(line, payload...)
+	return append(line, '\n'), nil
+}
+
+// decodeRecord parses and verifies a checksummed line.
+//
+// Parameters:
+//   - line: The line read from the write-ahead log, including the trailing newline.
+//
+// Returns:
+//   - The decoded record.
+//   - An error if the line is incomplete or its checksum does not match.
+func decodeRecord(line []byte) (walRecord, error) {
+	var record walRecord
+	if len(line) < 10 || line[8] != ' ' || line[len(line)-1] != '\n' {
+		return record, errors.New("incomplete record")
+	}
+	checksum, err := strconv.ParseUint(string(line[:8]), 16, 32)
+	if err != nil {
+		return record, fmt.Errorf("invalid checksum: %w", err)
+	}
+	payload := line[9 : len(line)-1]
+	if crc32.ChecksumIEEE(payload) != uint32(checksum) {
+		return record, errors.New("checksum mismatch")
+	}
+	if err := json.Unmarshal(payload, &record); err != nil {
+		return record, fmt.Errorf("invalid record: %w", err)
+	}
+	return record, nil
+}
+
+// beginWrite marks the start of a write. The returned function must be called once the write
+// is applied; it may take a snapshot when enough changes were logged.
+//
+// Returns:
+//   - The function ending the write.
+func (s *store) beginWrite() func() {
+	if s == nil {
+		return func() {}
+	}
+	s.writeMu.RLock()
+	return func() {
+		s.writeMu.RUnlock()
+		s.maybeSnapshot()
+	}
+}
+
+// append logs a change before it is applied in memory.
+//
+// Parameters:
+//   - record: The change to log. Its sequence number is assigned by the store.
+//
+// Returns:
+//   - The document as it will be restored on replay, or the given document when persistence is disabled.
+//   - An error if the change cannot be written; the change must not be applied in that case.
+func (s *store) append(record walRecord) (Document, error) {
+	if s == nil {
+		return record.Document, nil
+	}
+	logged, err := s.write(record)
+	if err != nil {
+		return nil, err
+	}
+	return logged.Document, nil
+}
+
+// appendTransaction logs the changes of a transaction as a single record, so a replay
+// restores either all of them or none.
+//
+// Parameters:
+//   - ops: The put and delete changes of the transaction.
+//
+// Returns:
+//   - The documents as they will be restored on replay, in the order of ops (nil for deletes).
+//   - An error if the changes cannot be written; they must not be applied in that case.
+func (s *store) appendTransaction(ops []walRecord) ([]Document, error) {
+	documents := make([]Document, len(ops))
+	if s == nil {
+		for i, op := range ops {
+			documents[i] = op.Document
+		}
+		return documents, nil
+	}
+	logged, err := s.write(walRecord{Op: opTransaction, Ops: ops})
+	if err != nil {
+		return nil, err
+	}
+	for i, op := range logged.Ops {
+		documents[i] = op.Document
+	}
+	return documents, nil
+}
+
+// write appends a record to the write-ahead log.
+//
+// Parameters:
+//   - record: The record to write. Its sequence number is assigned by the store.
+//
+// Returns:
+//   - The record as it will be decoded on replay.
+//   - An error if the record cannot be written.
+func (s *store) write(record walRecord) (walRecord, error) {
+	s.walMu.Lock()
+	defer s.walMu.Unlock()
+	var logged walRecord
+	if s.closed {
+		return logged, errors.New("database is closed")
+	}
+
+	record.Seq = s.seq + 1
+	line, err := encodeRecord(record)
+	if err != nil {
+		return logged, err
+	}
+	// Decode the logged record back so memory holds exactly what a replay would restore.
+	if err := json.Unmarshal(line[9:len(line)-1], &logged); err != nil {
+		return logged, fmt.Errorf("failed to decode write-ahead log record: %w", err)
+	}
+
+	if _, err := s.wal.Write(line); err != nil {
+		s.rollback()
+		return logged, fmt.Errorf("failed to write to write-ahead log: %w", err)
+	}
+	if s.config.SyncPolicy == SyncAlways {
+		if err := s.wal.Sync(); err != nil {
+			s.rollback()
+			return logged, fmt.Errorf("failed to sync write-ahead log: %w", err)
+		}
+	} else {
+		s.dirty = true
+	}
+	s.seq = record.Seq
+	s.size += int64(len(line))
+	s.pending++
+	return logged, nil
+}
+
+// rollback removes a partially written record from the end of the write-ahead log.
+func (s *store) rollback() {
+	if err := s.wal.Truncate(s.size); err != nil {
+		log.Printf("Failed to roll back write-ahead log of docdb %s: %v", s.db.Name, err)
+		return
+	}
+	if _, err := s.wal.Seek(s.size, io.SeekStart); err != nil {
+		log.Printf("Failed to roll back write-ahead log of docdb %s: %v", s.db.Name, err)
+	}
+}
+
+// maybeSnapshot takes a snapshot once the number of logged changes reaches the threshold.
+func (s *store) maybeSnapshot() {
+	s.walMu.Lock()
+	due := s.pending >= s.config.SnapshotThreshold && !s.closed
+	s.walMu.Unlock()
+	if !due {
+		return
+	}
+	if err := s.snapshot(false); err != nil {
+		log.Printf("Failed to snapshot docdb %s: %v", s.db.Name, err)
+	}
+}
+
+// snapshot writes the whole database into a new snapshot file and truncates the write-ahead log.
+//
+// Parameters:
+//   - force: Whether to snapshot even if the threshold was not reached.
+//
+// Returns:
+//   - An error if the snapshot cannot be written or the write-ahead log cannot be truncated.
+func (s *store) snapshot(force bool) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	// No write can be in flight while writeMu is held exclusively.
+	s.walMu.Lock()
+	defer s.walMu.Unlock()
+	if s.closed {
+		return errors.New("database is closed")
+	}
+	if !force && s.pending < s.config.SnapshotThreshold {
+		return nil
+	}
+
+	snap := snapshot{
+		Seq:         s.seq,
+		Collections: make(map[string]map[string]Document),
+		Indexes:     make(map[string][]indexDefinition),
+	}
+	s.db.mu.RLock()
+	for name, collection := range s.db.Collections {
+		collection.mu.RLock()
+		documents := make(map[string]Document, len(collection.data))
+		for id, document := range collection.data {
+			documents[id] = document
+		}
+		for _, idx := range collection.indexes {
+			snap.Indexes[name] = append(snap.Indexes[name], indexDefinition{Fields: idx.fields, Unique: idx.unique})
+		}
+		collection.mu.RUnlock()
+		snap.Collections[name] = documents
+	}
+	s.db.mu.RUnlock()
+
+	if err := s.writeSnapshot(snap); err != nil {
+		return err
+	}
+
+	// The snapshot covers every logged change, so the log can start over.
+	if err := s.wal.Truncate(0); err != nil {
+		return fmt.Errorf("failed to truncate write-ahead log: %w", err)
+	}
+	if _, err := s.wal.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to seek write-ahead log: %w", err)
+	}
+	if err := s.wal.Sync(); err != nil {
+		return fmt.Errorf("failed to sync write-ahead log: %w", err)
+	}
+	s.size = 0
+	s.pending = 0
+	s.dirty = false
+	log.Printf("Snapshotted docdb %s at sequence %d", s.db.Name, s.seq)
+	return nil
+}
+
+// writeSnapshot atomically replaces the snapshot file.
+//
+// Parameters:
+//   - snap: The snapshot to write.
+//
+// Returns:
+//   - An error if the snapshot cannot be written.
+func (s *store) writeSnapshot(snap snapshot) error {
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+	tmp, err := os.CreateTemp(s.dir, snapshotFileName+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create snapshot: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to sync snapshot: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close snapshot: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(s.dir, snapshotFileName)); err != nil {
+		return fmt.Errorf("failed to replace snapshot: %w", err)
+	}
+	return syncDir(s.dir)
+}
+
+// syncDir fsyncs a directory so a rename inside it survives a crash.
+//
+// Parameters:
+//   - dir: The directory to sync.
+//
+// Returns:
+//   - An error if the directory cannot be synced.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return fmt.Errorf("failed to open data directory: %w", err)
+	}
+	defer d.Close()
+	if err := d.Sync(); err != nil {
+		return fmt.Errorf("failed to sync data directory: %w", err)
+	}
+	return nil
+}
+
+// syncLoop periodically fsyncs the write-ahead log when the SyncInterval policy is used.
+func (s *store) syncLoop() {
+	defer s.wg.Done()
+	ticker := time.NewTicker(s.config.SyncInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			s.walMu.Lock()
+			if s.dirty && !s.closed {
+				if err := s.wal.Sync(); err != nil {
+					log.Printf("Failed to sync write-ahead log of docdb %s: %v", s.db.Name, err)
+				} else {
+					s.dirty = false
+				}
+			}
+			s.walMu.Unlock()
+		}
+	}
+}
+
+// close takes a final snapshot and closes the write-ahead log.
+//
+// Returns:
+//   - An error if the final snapshot cannot be taken or the log cannot be closed.
+func (s *store) close() error {
+	s.walMu.Lock()
+	closed := s.closed
+	s.walMu.Unlock()
+	if closed {
+		return nil
+	}
+
+	snapErr := s.snapshot(true)
+
+	s.walMu.Lock()
+	s.closed = true
+	s.walMu.Unlock()
+
+	close(s.done)
+	s.wg.Wait()
+
+	syncErr := s.wal.Sync()
+	closeErr := s.wal.Close()
+	return errors.Join(snapErr, syncErr, closeErr)
+}
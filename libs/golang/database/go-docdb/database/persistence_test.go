@@ -0,0 +1,305 @@
+package database
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type PersistenceTestSuite struct {
+	suite.Suite
+	dir    string
+	dbName string
+	config PersistenceConfig
+}
+
+func TestPersistenceTestSuite(t *testing.T) {
+	suite.Run(t, new(PersistenceTestSuite))
+}
+
+func (suite *PersistenceTestSuite) SetupTest() {
+	suite.dir = suite.T().TempDir()
+	suite.dbName = "test-db"
+	suite.config = PersistenceConfig{
+		Dir:        suite.dir,
+		SyncPolicy: SyncAlways,
+	}
+}
+
+func (suite *PersistenceTestSuite) open() *InMemoryDocBD {
+	db, err := OpenInMemoryDocBD(suite.dbName, WithPersistence(suite.config))
+	assert.Nil(suite.T(), err)
+	return db
+}
+
+func (suite *PersistenceTestSuite) seed(db *InMemoryDocBD) *Collection {
+	err := db.CreateCollection("users")
+	assert.Nil(suite.T(), err)
+	collection, err := db.GetCollection("users")
+	assert.Nil(suite.T(), err)
+
+	assert.Nil(suite.T(), collection.InsertOne(Document{"_id": "1", "name": "Alice", "age": 30}))
+	assert.Nil(suite.T(), collection.InsertOne(Document{"_id": "2", "name": "Bob", "age": 25}))
+	assert.Nil(suite.T(), collection.InsertOne(Document{"_id": "3", "name": "Carol", "age": 41}))
+	assert.Nil(suite.T(), collection.UpdateOne("1", Document{"age": 31}))
+	assert.Nil(suite.T(), collection.DeleteOne("2"))
+	return collection
+}
+
+func (suite *PersistenceTestSuite) assertSeeded(db *InMemoryDocBD) {
+	collection, err := db.GetCollection("users")
+	assert.Nil(suite.T(), err)
+
+	document, err := collection.FindOne("1")
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), Document{"_id": "1", "name": "Alice", "age": float64(31)}, document)
+
+	_, err = collection.FindOne("2")
+	assert.NotNil(suite.T(), err)
+	assert.Equal(suite.T(), 2, len(collection.FindAll()))
+}
+
+func (suite *PersistenceTestSuite) TestReplayWriteAheadLogAfterCrash() {
+	db := suite.open()
+	suite.seed(db)
+
+	// The first instance is never closed, as if the process crashed.
+	restored := suite.open()
+	suite.assertSeeded(restored)
+	assert.Nil(suite.T(), restored.Close())
+}
+
+func (suite *PersistenceTestSuite) TestRestoreFromSnapshotAfterClose() {
+	db := suite.open()
+	suite.seed(db)
+	assert.Nil(suite.T(), db.Close())
+
+	info, err := os.Stat(filepath.Join(suite.dir, suite.dbName, walFileName))
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), int64(0), info.Size())
+
+	restored := suite.open()
+	suite.assertSeeded(restored)
+	assert.Nil(suite.T(), restored.Close())
+}
+
+func (suite *PersistenceTestSuite) TestInMemoryStateMatchesReplay() {
+	db := suite.open()
+	collection := suite.seed(db)
+
+	document, err := collection.FindOne("3")
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), float64(41), document["age"])
+	assert.Nil(suite.T(), db.Close())
+}
+
+func (suite *PersistenceTestSuite) TestSnapshotWhenThresholdReached() {
+	suite.config.SnapshotThreshold = 3
+	db := suite.open()
+	suite.seed(db)
+
+	_, err := os.Stat(filepath.Join(suite.dir, suite.dbName, snapshotFileName))
+	assert.Nil(suite.T(), err)
+
+	restored := suite.open()
+	suite.assertSeeded(restored)
+	assert.Nil(suite.T(), restored.Close())
+}
+
+func (suite *PersistenceTestSuite) TestTruncateTornWriteAheadLogTail() {
+	db := suite.open()
+	suite.seed(db)
+
+	walPath := filepath.Join(suite.dir, suite.dbName, walFileName)
+	wal, err := os.OpenFile(walPath, os.O_APPEND|os.O_WRONLY, 0o644)
+	assert.Nil(suite.T(), err)
+	_, err = wal.WriteString(`0badc0de {"seq":99,"op":"put","collection":"users","id":"9"`)
+	assert.Nil(suite.T(), err)
+	assert.Nil(suite.T(), wal.Close())
+
+	restored := suite.open()
+	suite.assertSeeded(restored)
+
+	collection, err := restored.GetCollection("users")
+	assert.Nil(suite.T(), err)
+	assert.Nil(suite.T(), collection.InsertOne(Document{"_id": "4", "name": "Dave"}))
+
+	again := suite.open()
+	collection, err = again.GetCollection("users")
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 3, len(collection.FindAll()))
+}
+
+func (suite *PersistenceTestSuite) TestCorruptedWriteAheadLogRecordFailsLoad() {
+	db := suite.open()
+	suite.seed(db)
+
+	walPath := filepath.Join(suite.dir, suite.dbName, walFileName)
+	data, err := os.ReadFile(walPath)
+	assert.Nil(suite.T(), err)
+	lines := strings.SplitAfter(string(data), "\n")
+	lines[1] = strings.Replace(lines[1], "Alice", "Alicf", 1)
+	assert.Nil(suite.T(), os.WriteFile(walPath, []byte(strings.Join(lines, "")), 0o644))
+
+	_, err = OpenInMemoryDocBD(suite.dbName, WithPersistence(suite.config))
+	assert.ErrorContains(suite.T(), err, "corrupted write-ahead log record")
+
+	after, err := os.ReadFile(walPath)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), strings.Join(lines, ""), string(after), "The records after the corrupted one must be kept")
+}
+
+func (suite *PersistenceTestSuite) TestIndexesAreReplayed() {
+	db := suite.open()
+	collection := suite.seed(db)
+	assert.Nil(suite.T(), collection.CreateUniqueIndex("name"))
+	assert.Nil(suite.T(), collection.InsertOne(Document{"_id": "4", "name": "Dave"}))
+	assert.Nil(suite.T(), collection.DeleteOne("4"))
+
+	restored := suite.open()
+	suite.assertIndexed(restored)
+	restoredCollection, err := restored.GetCollection("users")
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 0, restoredCollection.Explain(map[string]interface{}{"name": "Dave"}).DocumentsReturned)
+	assert.Nil(suite.T(), restored.Close())
+}
+
+func (suite *PersistenceTestSuite) TestIndexesAreSnapshotted() {
+	db := suite.open()
+	collection := suite.seed(db)
+	assert.Nil(suite.T(), collection.CreateUniqueIndex("name"))
+	assert.Nil(suite.T(), db.Close())
+
+	restored := suite.open()
+	suite.assertIndexed(restored)
+	assert.Nil(suite.T(), restored.Close())
+}
+
+// assertIndexed checks that the unique index on the name of the seeded users was restored with the documents.
+func (suite *PersistenceTestSuite) assertIndexed(db *InMemoryDocBD) {
+	collection, err := db.GetCollection("users")
+	assert.Nil(suite.T(), err)
+
+	plan := collection.Explain(map[string]interface{}{"name": "Alice"})
+	assert.Equal(suite.T(), ScanIndex, plan.Strategy)
+	assert.Equal(suite.T(), 1, plan.DocumentsReturned)
+	assert.Equal(suite.T(), 0, collection.Explain(map[string]interface{}{"name": "Bob"}).DocumentsReturned)
+	assert.ErrorIs(suite.T(), collection.InsertOne(Document{"_id": "4", "name": "Carol"}), ErrDuplicateKey)
+}
+
+func (suite *PersistenceTestSuite) TestDropCollectionIsPersisted() {
+	db := suite.open()
+	suite.seed(db)
+	assert.Nil(suite.T(), db.DropCollection("users"))
+
+	restored := suite.open()
+	assert.Equal(suite.T(), 0, len(restored.ListCollections()))
+}
+
+func (suite *PersistenceTestSuite) TestUpdateOperatorsArePersisted() {
+	db := suite.open()
+	collection := suite.seed(db)
+	result, err := collection.UpdateMany(
+		map[string]interface{}{"age": map[string]interface{}{"$gt": 30}},
+		Document{"$inc": map[string]interface{}{"age": 1}, "$push": map[string]interface{}{"tags": "senior"}},
+	)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 2, result.ModifiedCount)
+
+	// Setting a value equal to the persisted one is not a modification.
+	result, err = collection.UpdateByID("1", Document{"$set": map[string]interface{}{"age": 32}})
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 0, result.ModifiedCount)
+
+	restored := suite.open()
+	restoredCollection, err := restored.GetCollection("users")
+	assert.Nil(suite.T(), err)
+	document, err := restoredCollection.FindOne("3")
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), Document{"_id": "3", "name": "Carol", "age": float64(42), "tags": []interface{}{"senior"}}, document)
+}
+
+func (suite *PersistenceTestSuite) TestUpdateManyIsPersistedAsOneRecord() {
+	db := suite.open()
+	collection := suite.seed(db)
+	walPath := filepath.Join(suite.dir, suite.dbName, walFileName)
+	before, err := os.ReadFile(walPath)
+	assert.Nil(suite.T(), err)
+
+	result, err := collection.UpdateMany(map[string]interface{}{}, Document{"$set": map[string]interface{}{"active": true}})
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 2, result.ModifiedCount)
+
+	after, err := os.ReadFile(walPath)
+	assert.Nil(suite.T(), err)
+	appended := string(after[len(before):])
+	assert.Equal(suite.T(), 1, strings.Count(appended, "\n"))
+	assert.Contains(suite.T(), appended, `"op":"transaction"`)
+
+	restored := suite.open()
+	restoredCollection, err := restored.GetCollection("users")
+	assert.Nil(suite.T(), err)
+	for _, id := range []string{"1", "3"} {
+		document, err := restoredCollection.FindOne(id)
+		assert.Nil(suite.T(), err)
+		assert.Equal(suite.T(), true, document["active"])
+	}
+}
+
+func (suite *PersistenceTestSuite) TestTransactionIsPersistedAsOneRecord() {
+	db := suite.open()
+	suite.seed(db)
+	assert.Nil(suite.T(), db.CreateCollection("orders"))
+
+	session := db.StartSession()
+	err := session.WithTransaction(func(tx *Transaction) error {
+		if err := tx.InsertOne("orders", Document{"_id": "o1", "user": "1"}); err != nil {
+			return err
+		}
+		return tx.DeleteOne("users", "3")
+	})
+	assert.Nil(suite.T(), err)
+
+	data, err := os.ReadFile(filepath.Join(suite.dir, suite.dbName, walFileName))
+	assert.Nil(suite.T(), err)
+	assert.Contains(suite.T(), string(data), `"op":"transaction"`)
+
+	restored := suite.open()
+	orders, err := restored.GetCollection("orders")
+	assert.Nil(suite.T(), err)
+	order, err := orders.FindOne("o1")
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), Document{"_id": "o1", "user": "1"}, order)
+	users, _ := restored.GetCollection("users")
+	_, err = users.FindOne("3")
+	assert.NotNil(suite.T(), err)
+}
+
+func (suite *PersistenceTestSuite) TestWriteAfterCloseFails() {
+	db := suite.open()
+	collection := suite.seed(db)
+	assert.Nil(suite.T(), db.Close())
+
+	err := collection.InsertOne(Document{"_id": "5", "name": "Eve"})
+	assert.NotNil(suite.T(), err)
+}
+
+func (suite *PersistenceTestSuite) TestWithPersistenceRequiresDir() {
+	_, err := OpenInMemoryDocBD(suite.dbName, WithPersistence(PersistenceConfig{}))
+	assert.NotNil(suite.T(), err)
+}
+
+func (suite *PersistenceTestSuite) TestParseSyncPolicy() {
+	for name, expected := range map[string]SyncPolicy{"always": SyncAlways, "interval": SyncInterval, "never": SyncNever} {
+		policy, err := ParseSyncPolicy(name)
+		assert.Nil(suite.T(), err)
+		assert.Equal(suite.T(), expected, policy)
+		assert.Equal(suite.T(), name, policy.String())
+	}
+	_, err := ParseSyncPolicy("sometimes")
+	assert.NotNil(suite.T(), err)
+}
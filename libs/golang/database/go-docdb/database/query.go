@@ -0,0 +1,423 @@
+package database
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// matchesQuery checks if a document matches the query criteria.
+//
+// The query follows the MongoDB syntax: keys are field names or dotted paths, and values are
+// either literals compared for equality, nested documents matched field by field, or operator
+// documents such as {"$gte": 400}. The logical operators $and and $or are accepted at any level.
+// Like MongoDB, a literal or comparison matches an array field when one of its elements matches.
+//
+// Parameters:
+//   - document: The document to check against the query.
+//   - query: The query criteria to match against the document.
+//
+// Returns:
+//   - A boolean indicating if the document matches the query.
+func matchesQuery(document, query map[string]interface{}) bool {
+	for key, value := range query {
+		switch key {
+		case "$and":
+			if !matchesAll(document, value) {
+				return false
+			}
+			continue
+		case "$or":
+			if !matchesAny(document, value) {
+				return false
+			}
+			continue
+		}
+		if strings.HasPrefix(key, "$") {
+			return false // Unsupported top-level operator
+		}
+
+		docValue, exists := lookupPath(document, key)
+		queryMap, isMap := asMap(value)
+		switch {
+		case isMap && hasOperator(queryMap):
+			if !matchesOperators(docValue, exists, queryMap) {
+				return false
+			}
+		case isMap:
+			// A nested document matches the fields it lists.
+			docMap, ok := asMap(docValue)
+			if !exists || !ok || !matchesQuery(docMap, queryMap) {
+				return false
+			}
+		default:
+			if !exists || !anyValue(docValue, func(v interface{}) bool { return valuesEqual(v, value) }) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// matchesAll implements $and.
+//
+// Parameters:
+//   - document: The document to check.
+//   - clauses: The list of queries.
+//
+// Returns:
+//   - True if the document matches every query of the list.
+func matchesAll(document map[string]interface{}, clauses interface{}) bool {
+	queries, ok := toSlice(clauses)
+	if !ok {
+		return false
+	}
+	for _, clause := range queries {
+		query, ok := asMap(clause)
+		if !ok || !matchesQuery(document, query) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesAny implements $or.
+//
+// Parameters:
+//   - document: The document to check.
+//   - clauses: The list of queries.
+//
+// Returns:
+//   - True if the document matches at least one query of the list.
+func matchesAny(document map[string]interface{}, clauses interface{}) bool {
+	queries, ok := toSlice(clauses)
+	if !ok {
+		return false
+	}
+	for _, clause := range queries {
+		query, ok := asMap(clause)
+		if ok && matchesQuery(document, query) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesOperators evaluates an operator document, such as {"$gte": 400, "$lt": 500}, against a field.
+//
+// Parameters:
+//   - docValue: The value of the field.
+//   - exists: Whether the field exists in the document.
+//   - operators: The operators and their arguments.
+//
+// Returns:
+//   - True if every operator matches.
+func matchesOperators(docValue interface{}, exists bool, operators map[string]interface{}) bool {
+	for operator, argument := range operators {
+		var ok bool
+		switch operator {
+		case "$eq":
+			ok = exists && anyValue(docValue, func(v interface{}) bool { return valuesEqual(v, argument) })
+		case "$ne":
+			ok = !exists || !anyValue(docValue, func(v interface{}) bool { return valuesEqual(v, argument) })
+		case "$gt", "$gte", "$lt", "$lte":
+			ok = exists && anyValue(docValue, func(v interface{}) bool { return compareWith(operator, v, argument) })
+		case "$in":
+			ok = exists && isIn(docValue, argument)
+		case "$nin":
+			ok = !exists || !isIn(docValue, argument)
+		case "$exists":
+			want, isBool := argument.(bool)
+			ok = isBool && exists == want
+		case "$regex":
+			ok = exists && matchesRegex(docValue, argument, operators["$options"])
+		case "$options":
+			ok = true // Consumed by $regex
+		case "$not":
+			ok = !matchesNot(docValue, exists, argument)
+		case "$elemMatch":
+			ok = exists && matchesElement(docValue, argument)
+		default:
+			ok = false // Unsupported operator
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesNot evaluates the expression negated by $not.
+//
+// Parameters:
+//   - docValue: The value of the field.
+//   - exists: Whether the field exists in the document.
+//   - expression: An operator document or a regular expression.
+//
+// Returns:
+//   - True if the expression matches, so $not does not.
+func matchesNot(docValue interface{}, exists bool, expression interface{}) bool {
+	if operators, ok := asMap(expression); ok {
+		return matchesOperators(docValue, exists, operators)
+	}
+	return exists && matchesRegex(docValue, expression, nil)
+}
+
+// matchesElement implements $elemMatch: at least one element of an array must match the condition.
+// The condition is either an operator document applied to the element itself, or a query applied
+// to elements that are nested documents.
+//
+// Parameters:
+//   - docValue: The value of the field, which must be an array.
+//   - condition: The condition on the elements.
+//
+// Returns:
+//   - True if an element matches.
+func matchesElement(docValue interface{}, condition interface{}) bool {
+	elements, ok := toSlice(docValue)
+	if !ok {
+		return false
+	}
+	query, ok := asMap(condition)
+	if !ok {
+		return false
+	}
+	for _, element := range elements {
+		if onlyOperators(query) {
+			if matchesOperators(element, true, query) {
+				return true
+			}
+			continue
+		}
+		if elementMap, ok := asMap(element); ok && matchesQuery(elementMap, query) {
+			return true
+		}
+	}
+	return false
+}
+
+// onlyOperators reports whether every key of a query is a field operator.
+//
+// Parameters:
+//   - query: The query to inspect.
+//
+// Returns:
+//   - True if the query only contains operators other than $and and $or.
+func onlyOperators(query map[string]interface{}) bool {
+	for key := range query {
+		if !strings.HasPrefix(key, "$") || key == "$and" || key == "$or" {
+			return false
+		}
+	}
+	return true
+}
+
+// isIn implements $in: the value, or one of its elements, must equal one of the candidates.
+//
+// Parameters:
+//   - docValue: The value of the field.
+//   - candidates: The list of accepted values.
+//
+// Returns:
+//   - True if the value is one of the candidates.
+func isIn(docValue interface{}, candidates interface{}) bool {
+	list, ok := toSlice(candidates)
+	if !ok {
+		return false
+	}
+	for _, candidate := range list {
+		if anyValue(docValue, func(v interface{}) bool { return valuesEqual(v, candidate) }) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesRegex implements $regex.
+//
+// Parameters:
+//   - docValue: The value of the field. Only strings can match.
+//   - pattern: The pattern, as a string or a *regexp.Regexp.
+//   - options: The optional $options flags, a combination of "i", "m" and "s".
+//
+// Returns:
+//   - True if the value matches the pattern. An invalid pattern never matches.
+func matchesRegex(docValue interface{}, pattern interface{}, options interface{}) bool {
+	var re *regexp.Regexp
+	switch p := pattern.(type) {
+	case *regexp.Regexp:
+		re = p
+	case string:
+		if flags, ok := options.(string); ok && flags != "" {
+			p = "(?" + flags + ")" + p
+		}
+		compiled, err := regexp.Compile(p)
+		if err != nil {
+			return false
+		}
+		re = compiled
+	default:
+		return false
+	}
+	return anyValue(docValue, func(v interface{}) bool {
+		s, ok := v.(string)
+		return ok && re.MatchString(s)
+	})
+}
+
+// compareWith applies a comparison operator.
+//
+// Parameters:
+//   - operator: One of $gt, $gte, $lt and $lte.
+//   - a: The value of the field.
+//   - b: The argument of the operator.
+//
+// Returns:
+//   - The result of the comparison, or false if the values are not comparable.
+func compareWith(operator string, a, b interface{}) bool {
+	result, ok := compareValues(a, b)
+	if !ok {
+		return false
+	}
+	switch operator {
+	case "$gt":
+		return result > 0
+	case "$gte":
+		return result >= 0
+	case "$lt":
+		return result < 0
+	case "$lte":
+		return result <= 0
+	}
+	return false
+}
+
+// anyValue applies a predicate to a value and, when the value is an array, to each of its elements.
+//
+// Parameters:
+//   - value: The value of the field.
+//   - predicate: The condition to check.
+//
+// Returns:
+//   - True if the value or one of its elements satisfies the predicate.
+func anyValue(value interface{}, predicate func(interface{}) bool) bool {
+	if predicate(value) {
+		return true
+	}
+	elements, ok := toSlice(value)
+	if !ok {
+		return false
+	}
+	for _, element := range elements {
+		if predicate(element) {
+			return true
+		}
+	}
+	return false
+}
+
+// valuesEqual compares two values. Numbers are compared by value whatever their type, so an
+// int in a query matches the float64 a persisted document holds.
+//
+// Parameters:
+//   - a: The first value.
+//   - b: The second value.
+//
+// Returns:
+//   - True if the values are equal.
+func valuesEqual(a, b interface{}) bool {
+	if x, ok := toFloat(a); ok {
+		y, ok := toFloat(b)
+		return ok && x == y
+	}
+	if x, ok := a.(time.Time); ok {
+		y, ok := b.(time.Time)
+		return ok && x.Equal(y)
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// compareValues orders two values of the same kind: numbers, strings or times.
+//
+// Parameters:
+//   - a: The first value.
+//   - b: The second value.
+//
+// Returns:
+//   - -1, 0 or 1 if a is lower than, equal to or greater than b.
+//   - Whether the values are comparable.
+func compareValues(a, b interface{}) (int, bool) {
+	if x, ok := toFloat(a); ok {
+		y, ok := toFloat(b)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case x < y:
+			return -1, true
+		case x > y:
+			return 1, true
+		}
+		return 0, true
+	}
+	switch x := a.(type) {
+	case string:
+		y, ok := b.(string)
+		if !ok {
+			return 0, false
+		}
+		return strings.Compare(x, y), true
+	case time.Time:
+		y, ok := b.(time.Time)
+		if !ok {
+			return 0, false
+		}
+		return x.Compare(y), true
+	}
+	return 0, false
+}
+
+// toFloat converts any numeric value to a float64.
+//
+// Parameters:
+//   - value: The value to convert.
+//
+// Returns:
+//   - The value as a float64.
+//   - Whether the value is a number.
+func toFloat(value interface{}) (float64, bool) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	}
+	return 0, false
+}
+
+// toSlice converts any slice or array to a []interface{}.
+//
+// Parameters:
+//   - value: The value to convert.
+//
+// Returns:
+//   - The elements of the value.
+//   - Whether the value is a slice or an array.
+func toSlice(value interface{}) ([]interface{}, bool) {
+	if list, ok := value.([]interface{}); ok {
+		return list, true
+	}
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, false
+	}
+	list := make([]interface{}, v.Len())
+	for i := range list {
+		list[i] = v.Index(i).Interface()
+	}
+	return list, true
+}
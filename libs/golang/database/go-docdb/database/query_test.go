@@ -0,0 +1,180 @@
+package database
+
+import (
+	"regexp"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type QueryTestSuite struct {
+	suite.Suite
+	collection *Collection
+}
+
+func TestQueryTestSuite(t *testing.T) {
+	suite.Run(t, new(QueryTestSuite))
+}
+
+func (suite *QueryTestSuite) SetupTest() {
+	suite.collection = NewCollection()
+	documents := []Document{
+		{
+			"_id":        "1",
+			"provider":   "aws",
+			"statusCode": 200,
+			"tags":       []interface{}{"a", "b"},
+			"metadata":   map[string]interface{}{"source": "s3", "attempts": 1},
+			"createdAt":  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			"items": []interface{}{
+				map[string]interface{}{"sku": "x", "qty": 1},
+				map[string]interface{}{"sku": "y", "qty": 5},
+			},
+		},
+		{
+			"_id":        "2",
+			"provider":   "gcp",
+			"statusCode": 404,
+			"tags":       []string{"b", "c"},
+			"metadata":   map[string]interface{}{"source": "gcs", "attempts": 3},
+			"createdAt":  time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+			"items": []interface{}{
+				map[string]interface{}{"sku": "x", "qty": 10},
+			},
+		},
+		{
+			"_id":        "3",
+			"provider":   "Azure",
+			"statusCode": 500.0,
+			"metadata":   map[string]interface{}{"source": "blob"},
+		},
+	}
+	for _, document := range documents {
+		suite.Require().NoError(suite.collection.InsertOne(document))
+	}
+}
+
+// ids runs the query and returns the sorted IDs of the matching documents.
+func (suite *QueryTestSuite) ids(query map[string]interface{}) []string {
+	documents := suite.collection.Find(query)
+	ids := make([]string, 0, len(documents))
+	for _, document := range documents {
+		ids = append(ids, document["_id"].(string))
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// providers runs the query and returns the sorted providers of the matching documents.
+func (suite *QueryTestSuite) providers(query map[string]interface{}) []string {
+	documents := suite.collection.Find(query)
+	providers := make([]string, 0, len(documents))
+	for _, document := range documents {
+		providers = append(providers, document["provider"].(string))
+	}
+	sort.Strings(providers)
+	return providers
+}
+
+func (suite *QueryTestSuite) TestComparisonOperators() {
+	suite.Equal([]string{"2", "3"}, suite.ids(map[string]interface{}{"statusCode": map[string]interface{}{"$gte": 400}}))
+	suite.Equal([]string{"2"}, suite.ids(map[string]interface{}{"statusCode": map[string]interface{}{"$gt": 200, "$lt": 500}}))
+	suite.Equal([]string{"1", "2"}, suite.ids(map[string]interface{}{"statusCode": map[string]interface{}{"$lte": 404.0}}))
+	suite.Equal([]string{"3"}, suite.ids(map[string]interface{}{"statusCode": map[string]interface{}{"$eq": 500}}))
+	suite.Equal([]string{"1", "3"}, suite.ids(map[string]interface{}{"statusCode": map[string]interface{}{"$ne": 404}}))
+	suite.Equal([]string{"aws", "gcp"}, suite.providers(map[string]interface{}{"provider": map[string]interface{}{"$gt": "B"}}))
+	suite.Equal([]string{"2"}, suite.ids(map[string]interface{}{
+		"createdAt": map[string]interface{}{"$gt": time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+	}))
+	suite.Empty(suite.ids(map[string]interface{}{"statusCode": map[string]interface{}{"$gt": "400"}}))
+}
+
+func (suite *QueryTestSuite) TestSetOperators() {
+	suite.Equal([]string{"1", "2"}, suite.ids(map[string]interface{}{"provider": map[string]interface{}{"$in": []string{"aws", "gcp"}}}))
+	suite.Equal([]string{"3"}, suite.ids(map[string]interface{}{"provider": map[string]interface{}{"$nin": []interface{}{"aws", "gcp"}}}))
+	suite.Equal([]string{"2"}, suite.ids(map[string]interface{}{"tags": map[string]interface{}{"$in": []string{"c"}}}))
+	suite.Equal([]string{"3"}, suite.ids(map[string]interface{}{"tags": map[string]interface{}{"$nin": []string{"b"}}}))
+}
+
+func (suite *QueryTestSuite) TestArrayFieldsMatchElements() {
+	suite.Equal([]string{"1", "2"}, suite.ids(map[string]interface{}{"tags": "b"}))
+	suite.Equal([]string{"1"}, suite.ids(map[string]interface{}{"tags": []interface{}{"a", "b"}}))
+}
+
+func (suite *QueryTestSuite) TestExistsOperator() {
+	suite.Equal([]string{"1", "2"}, suite.ids(map[string]interface{}{"tags": map[string]interface{}{"$exists": true}}))
+	suite.Equal([]string{"3"}, suite.ids(map[string]interface{}{"metadata.attempts": map[string]interface{}{"$exists": false}}))
+}
+
+func (suite *QueryTestSuite) TestRegexOperator() {
+	suite.Equal([]string{"1", "3"}, suite.ids(map[string]interface{}{"provider": map[string]interface{}{"$regex": "^a", "$options": "i"}}))
+	suite.Equal([]string{"1"}, suite.ids(map[string]interface{}{"provider": map[string]interface{}{"$regex": regexp.MustCompile("^a")}}))
+	suite.Equal([]string{"2"}, suite.ids(map[string]interface{}{"metadata.source": map[string]interface{}{"$regex": "^gc"}}))
+	suite.Empty(suite.ids(map[string]interface{}{"provider": map[string]interface{}{"$regex": "("}}))
+}
+
+func (suite *QueryTestSuite) TestLogicalOperators() {
+	suite.Equal([]string{"1", "3"}, suite.ids(map[string]interface{}{
+		"$or": []interface{}{
+			map[string]interface{}{"provider": "aws"},
+			map[string]interface{}{"statusCode": map[string]interface{}{"$gte": 500}},
+		},
+	}))
+	suite.Equal([]string{"2"}, suite.ids(map[string]interface{}{
+		"$and": []map[string]interface{}{
+			{"statusCode": map[string]interface{}{"$gte": 400}},
+			{"metadata.attempts": map[string]interface{}{"$gt": 1}},
+		},
+	}))
+	suite.Equal([]string{"1", "3"}, suite.ids(map[string]interface{}{
+		"statusCode": map[string]interface{}{"$not": map[string]interface{}{"$eq": 404}},
+	}))
+	suite.Equal([]string{"2", "3"}, suite.ids(map[string]interface{}{
+		"provider": map[string]interface{}{"$not": "^a"},
+	}))
+}
+
+func (suite *QueryTestSuite) TestElemMatchOperator() {
+	suite.Equal([]string{"1"}, suite.ids(map[string]interface{}{
+		"items": map[string]interface{}{"$elemMatch": map[string]interface{}{"sku": "y", "qty": map[string]interface{}{"$gte": 5}}},
+	}))
+	suite.Equal([]string{"1", "2"}, suite.ids(map[string]interface{}{
+		"items": map[string]interface{}{"$elemMatch": map[string]interface{}{"sku": "x"}},
+	}))
+	suite.Equal([]string{"2"}, suite.ids(map[string]interface{}{
+		"tags": map[string]interface{}{"$elemMatch": map[string]interface{}{"$gt": "b"}},
+	}))
+	suite.Empty(suite.ids(map[string]interface{}{
+		"provider": map[string]interface{}{"$elemMatch": map[string]interface{}{"$eq": "aws"}},
+	}))
+}
+
+func (suite *QueryTestSuite) TestUnsupportedOperatorMatchesNothing() {
+	suite.Empty(suite.ids(map[string]interface{}{"provider": map[string]interface{}{"$size": 1}}))
+	suite.Empty(suite.ids(map[string]interface{}{"$where": "true"}))
+}
+
+func (suite *QueryTestSuite) TestIndexIsUsedWithOperators() {
+	suite.Require().NoError(suite.collection.CreateIndex("provider"))
+	suite.Require().NoError(suite.collection.CreateIndex("tags"))
+
+	plan := suite.collection.Explain(map[string]interface{}{
+		"$and": []interface{}{
+			map[string]interface{}{"provider": map[string]interface{}{"$eq": "gcp"}},
+			map[string]interface{}{"statusCode": map[string]interface{}{"$gte": 400}},
+		},
+	})
+	suite.Equal(ScanIndex, plan.Strategy)
+	suite.Equal(1, plan.DocumentsExamined)
+	suite.Equal(1, plan.DocumentsReturned)
+
+	plan = suite.collection.Explain(map[string]interface{}{"provider": map[string]interface{}{"$in": []string{"aws"}}})
+	suite.Equal(ScanCollection, plan.Strategy)
+	suite.Equal(1, plan.DocumentsReturned)
+
+	// Documents holding arrays are always examined, so element matches are not missed.
+	suite.Equal([]string{"1", "2"}, suite.ids(map[string]interface{}{"tags": "b"}))
+	suite.Equal(ScanIndex, suite.collection.Explain(map[string]interface{}{"tags": "b"}).Strategy)
+}
@@ -0,0 +1,526 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	// ErrWriteConflict is returned when a transaction touches a document written by a
+	// concurrent writer since the transaction started. The transaction can be retried.
+	ErrWriteConflict = errors.New("write conflict")
+
+	// ErrNoTransaction is returned when a transaction is used after it was committed or aborted.
+	ErrNoTransaction = errors.New("no transaction in progress")
+
+	// maxTransactionAttempts is how many times WithTransaction runs a transaction that hits write conflicts.
+	maxTransactionAttempts = 5
+
+	// clock hands out the versions stamped on every write.
+	clock atomic.Uint64
+)
+
+// nextVersion returns a new write version, greater than every version handed out before.
+//
+// Returns:
+//   - The version.
+func nextVersion() uint64 {
+	return clock.Add(1)
+}
+
+// version returns the version of the last write of a document. The caller must hold the collection lock.
+//
+// Parameters:
+//   - id: The ID of the document, which may not exist.
+//
+// Returns:
+//   - The version of the document.
+func (c *Collection) version(id string) uint64 {
+	if version, ok := c.versions[id]; ok {
+		return version
+	}
+	return c.clearedAt
+}
+
+// stamp records the version of a write. The caller must hold the collection lock.
+//
+// Parameters:
+//   - id: The ID of the written document.
+//   - version: The version of the write.
+//   - deleted: Whether the document was deleted.
+func (c *Collection) stamp(id string, version uint64, deleted bool) {
+	c.versions[id] = version
+	if deleted {
+		c.lastDelete = version
+	}
+}
+
+// Session groups the transactions of a caller, like a MongoDB driver session.
+// A session runs one transaction at a time and must not be shared between goroutines.
+type Session struct {
+	db    *InMemoryDocBD
+	tx    *Transaction
+	ended bool
+}
+
+// StartSession starts a new session on the database.
+//
+// Returns:
+//   - A pointer to the new Session. Call EndSession when done.
+func (d *InMemoryDocBD) StartSession() *Session {
+	return &Session{db: d}
+}
+
+// StartTransaction starts a transaction reading a snapshot of the database taken now.
+//
+// Returns:
+//   - The new transaction.
+//   - An error if the session has ended or a transaction is already in progress.
+func (s *Session) StartTransaction() (*Transaction, error) {
+	if s.ended {
+		return nil, errors.New("session has ended")
+	}
+	if s.tx != nil && !s.tx.isDone() {
+		return nil, errors.New("transaction already in progress")
+	}
+	s.tx = &Transaction{
+		db:     s.db,
+		start:  clock.Load(),
+		writes: make(map[string]map[string]Document),
+	}
+	return s.tx, nil
+}
+
+// CommitTransaction commits the transaction in progress.
+//
+// Returns:
+//   - An error wrapping ErrWriteConflict if a concurrent writer changed a document written by
+//     the transaction, ErrDuplicateKey if a unique index would be broken, or ErrNoTransaction.
+//     Nothing is applied when an error is returned.
+func (s *Session) CommitTransaction() error {
+	if s.tx == nil {
+		return ErrNoTransaction
+	}
+	return s.tx.commit()
+}
+
+// AbortTransaction discards the writes of the transaction in progress.
+//
+// Returns:
+//   - ErrNoTransaction if no transaction is in progress.
+func (s *Session) AbortTransaction() error {
+	if s.tx == nil {
+		return ErrNoTransaction
+	}
+	return s.tx.abort()
+}
+
+// WithTransaction runs fn in a transaction and commits it. If fn returns an error, the
+// transaction is aborted and the error returned. Write conflicts, whether raised by fn or
+// by the commit, restart the transaction up to maxTransactionAttempts times, so fn must
+// be safe to run several times.
+//
+// Parameters:
+//   - fn: The function performing the reads and writes through tx.
+//
+// Returns:
+//   - The error of fn, or of the commit.
+func (s *Session) WithTransaction(fn func(tx *Transaction) error) error {
+	for attempt := 1; ; attempt++ {
+		tx, err := s.StartTransaction()
+		if err != nil {
+			return err
+		}
+		err = fn(tx)
+		if err == nil {
+			err = s.CommitTransaction()
+		} else {
+			_ = s.AbortTransaction()
+		}
+		if err == nil || !errors.Is(err, ErrWriteConflict) || attempt >= maxTransactionAttempts {
+			return err
+		}
+	}
+}
+
+// EndSession aborts the transaction in progress, if any, and ends the session.
+func (s *Session) EndSession() {
+	if s.tx != nil {
+		_ = s.tx.abort()
+	}
+	s.ended = true
+}
+
+// Transaction buffers writes across collections and applies them atomically on commit.
+//
+// Reads see the database as of the start of the transaction plus the transaction's own
+// writes (snapshot isolation). Since the database keeps a single version of each document,
+// reading a document written by someone else after the start fails with ErrWriteConflict
+// instead of returning an older version. Conflicts are detected optimistically: nothing is
+// locked until commit, when the first committer wins.
+type Transaction struct {
+	db     *InMemoryDocBD
+	start  uint64
+	writes map[string]map[string]Document // Collection name to document ID to new version, nil for deletes
+	order  []txWrite                      // Written documents, in first-write order
+	mu     sync.Mutex
+	done   bool
+}
+
+// txWrite identifies a document written by a transaction.
+type txWrite struct {
+	collection string
+	id         string
+}
+
+// isDone reports whether the transaction was committed or aborted.
+//
+// Returns:
+//   - True if the transaction is over.
+func (t *Transaction) isDone() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.done
+}
+
+// abort discards the writes of the transaction.
+//
+// Returns:
+//   - ErrNoTransaction if the transaction is already over.
+func (t *Transaction) abort() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.done {
+		return ErrNoTransaction
+	}
+	t.done = true
+	t.writes = nil
+	t.order = nil
+	return nil
+}
+
+// conflict builds the error returned when a document changed after the transaction started.
+//
+// Parameters:
+//   - collection: The name of the collection.
+//   - id: The ID of the document.
+//
+// Returns:
+//   - An error wrapping ErrWriteConflict.
+func conflict(collection, id string) error {
+	return fmt.Errorf("%w: document %s in collection %s changed since the transaction started", ErrWriteConflict, id, collection)
+}
+
+// read returns a document as seen by the transaction. The caller must hold t.mu.
+//
+// Parameters:
+//   - collectionName: The name of the collection.
+//   - id: The ID of the document.
+//
+// Returns:
+//   - The document, or nil if it does not exist.
+//   - An error if the transaction is over, the collection does not exist, or the document changed since the start.
+func (t *Transaction) read(collectionName, id string) (Document, error) {
+	if t.done {
+		return nil, ErrNoTransaction
+	}
+	if document, ok := t.writes[collectionName][id]; ok {
+		return document, nil
+	}
+	collection, err := t.db.GetCollection(collectionName)
+	if err != nil {
+		return nil, err
+	}
+	collection.mu.RLock()
+	defer collection.mu.RUnlock()
+	if collection.version(id) > t.start {
+		return nil, conflict(collectionName, id)
+	}
+	return collection.data[id], nil
+}
+
+// write buffers a new version of a document. The caller must hold t.mu.
+//
+// Parameters:
+//   - collectionName: The name of the collection.
+//   - id: The ID of the document.
+//   - document: The new version, or nil to delete the document.
+func (t *Transaction) write(collectionName, id string, document Document) {
+	writes, ok := t.writes[collectionName]
+	if !ok {
+		writes = make(map[string]Document)
+		t.writes[collectionName] = writes
+	}
+	if _, written := writes[id]; !written {
+		t.order = append(t.order, txWrite{collection: collectionName, id: id})
+	}
+	writes[id] = document
+}
+
+// InsertOne inserts a document when the transaction commits.
+//
+// Parameters:
+//   - collectionName: The name of the collection.
+//   - document: The document to insert, which must contain a string "_id" field.
+//
+// Returns:
+//   - An error if the document is invalid or already exists in the snapshot.
+func (t *Transaction) InsertOne(collectionName string, document Document) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	id, ok := document["_id"].(string)
+	if !ok {
+		return errors.New("_id field is required and must be a string")
+	}
+	current, err := t.read(collectionName, id)
+	if err != nil {
+		return err
+	}
+	if current != nil {
+		return errors.New("document already exists")
+	}
+	t.write(collectionName, id, document)
+	return nil
+}
+
+// FindOne retrieves a document by its ID from the snapshot of the transaction.
+//
+// Parameters:
+//   - collectionName: The name of the collection.
+//   - id: The ID of the document.
+//
+// Returns:
+//   - The document.
+//   - An error if the document does not exist or changed since the transaction started.
+func (t *Transaction) FindOne(collectionName, id string) (Document, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	document, err := t.read(collectionName, id)
+	if err != nil {
+		return nil, err
+	}
+	if document == nil {
+		return nil, errors.New("document not found")
+	}
+	return document, nil
+}
+
+// Find searches the snapshot of the transaction for documents matching a query.
+//
+// Parameters:
+//   - collectionName: The name of the collection.
+//   - query: The query criteria.
+//
+// Returns:
+//   - The matching documents, ordered by "_id".
+//   - An error wrapping ErrWriteConflict if the collection changed since the transaction started.
+func (t *Transaction) Find(collectionName string, query map[string]interface{}) ([]Document, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.done {
+		return nil, ErrNoTransaction
+	}
+	collection, err := t.db.GetCollection(collectionName)
+	if err != nil {
+		return nil, err
+	}
+	writes := t.writes[collectionName]
+
+	collection.mu.RLock()
+	if collection.lastDelete > t.start {
+		collection.mu.RUnlock()
+		return nil, fmt.Errorf("%w: documents of collection %s were deleted since the transaction started", ErrWriteConflict, collectionName)
+	}
+	documents := make([]Document, 0)
+	for id, document := range collection.data {
+		if collection.version(id) > t.start {
+			collection.mu.RUnlock()
+			return nil, conflict(collectionName, id)
+		}
+		if _, written := writes[id]; !written && matchesQuery(document, query) {
+			documents = append(documents, document)
+		}
+	}
+	collection.mu.RUnlock()
+
+	for _, document := range writes {
+		if document != nil && matchesQuery(document, query) {
+			documents = append(documents, document)
+		}
+	}
+	sortDocuments(documents, nil)
+	return documents, nil
+}
+
+// UpdateByID updates a document when the transaction commits.
+//
+// Parameters:
+//   - collectionName: The name of the collection.
+//   - id: The ID of the document.
+//   - update: The fields to merge into the document, or update operators.
+//   - opts: Optional settings, such as WithUpsert.
+//
+// Returns:
+//   - The number of matched, modified and upserted documents.
+//   - An error if the update is invalid or the document changed since the transaction started.
+func (t *Transaction) UpdateByID(collectionName, id string, update Document, opts ...UpdateOption) (*UpdateResult, error) {
+	options := &updateOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	current, err := t.read(collectionName, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if current == nil {
+		if !options.upsert {
+			return &UpdateResult{}, nil
+		}
+		document, err := applyUpdate(Document{"_id": id}, update)
+		if err != nil {
+			return nil, err
+		}
+		t.write(collectionName, id, document)
+		return &UpdateResult{UpsertedCount: 1, UpsertedID: id}, nil
+	}
+
+	updated, err := applyUpdate(current, update)
+	if err != nil {
+		return nil, err
+	}
+	result := &UpdateResult{MatchedCount: 1}
+	if !sameDocument(current, updated) {
+		t.write(collectionName, id, updated)
+		result.ModifiedCount = 1
+	}
+	return result, nil
+}
+
+// UpdateOne updates a document when the transaction commits.
+//
+// Parameters:
+//   - collectionName: The name of the collection.
+//   - id: The ID of the document.
+//   - update: The fields to merge into the document, or update operators.
+//
+// Returns:
+//   - An error if the document does not exist, the update is invalid, or the document changed since the transaction started.
+func (t *Transaction) UpdateOne(collectionName, id string, update Document) error {
+	result, err := t.UpdateByID(collectionName, id, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("document not found")
+	}
+	return nil
+}
+
+// DeleteOne deletes a document when the transaction commits.
+//
+// Parameters:
+//   - collectionName: The name of the collection.
+//   - id: The ID of the document.
+//
+// Returns:
+//   - An error if the document does not exist or changed since the transaction started.
+func (t *Transaction) DeleteOne(collectionName, id string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	current, err := t.read(collectionName, id)
+	if err != nil {
+		return err
+	}
+	if current == nil {
+		return errors.New("document not found")
+	}
+	t.write(collectionName, id, nil)
+	return nil
+}
+
+// commit validates and applies the writes of the transaction.
+//
+// Returns:
+//   - An error if the transaction is over, conflicts with a concurrent writer, or cannot be logged.
+func (t *Transaction) commit() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.done {
+		return ErrNoTransaction
+	}
+	t.done = true
+	if len(t.order) == 0 {
+		return nil
+	}
+
+	// Lock order: writeMu, then the database lock, then the collection locks by name.
+	endWrite := t.db.store.beginWrite()
+	defer endWrite()
+	t.db.mu.RLock()
+	defer t.db.mu.RUnlock()
+
+	names := make([]string, 0, len(t.writes))
+	for name := range t.writes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	collections := make(map[string]*Collection, len(names))
+	for _, name := range names {
+		collection, ok := t.db.Collections[name]
+		if !ok {
+			return fmt.Errorf("collection %s not found", name)
+		}
+		collection.mu.Lock()
+		defer collection.mu.Unlock()
+		collections[name] = collection
+	}
+
+	for _, name := range names {
+		collection := collections[name]
+		for id := range t.writes[name] {
+			if collection.version(id) > t.start {
+				return conflict(name, id)
+			}
+		}
+		if err := collection.checkUniqueBatch(t.writes[name]); err != nil {
+			return err
+		}
+	}
+
+	ops := make([]walRecord, len(t.order))
+	for i, write := range t.order {
+		document := t.writes[write.collection][write.id]
+		if document == nil {
+			ops[i] = walRecord{Op: opDelete, Collection: write.collection, ID: write.id}
+		} else {
+			ops[i] = walRecord{Op: opPut, Collection: write.collection, ID: write.id, Document: document}
+		}
+	}
+	stored, err := t.db.store.appendTransaction(ops)
+	if err != nil {
+		return err
+	}
+
+	version := nextVersion()
+	for i, write := range t.order {
+		collection := collections[write.collection]
+		previous := collection.data[write.id]
+		if ops[i].Op == opDelete {
+			delete(collection.data, write.id)
+		} else {
+			collection.data[write.id] = stored[i]
+		}
+		collection.reindex(write.id, previous, stored[i])
+		collection.stamp(write.id, version, ops[i].Op == opDelete)
+		if previous != nil || stored[i] != nil {
+			collection.publish(write.id, previous, stored[i])
+		}
+	}
+	return nil
+}
@@ -0,0 +1,237 @@
+package database
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TransactionTestSuite struct {
+	suite.Suite
+	db     *InMemoryDocBD
+	orders *Collection
+	stages *Collection
+}
+
+func TestTransactionTestSuite(t *testing.T) {
+	suite.Run(t, new(TransactionTestSuite))
+}
+
+func (suite *TransactionTestSuite) SetupTest() {
+	suite.db = NewInMemoryDocBD("test-db")
+	suite.Require().NoError(suite.db.CreateCollection("orders"))
+	suite.Require().NoError(suite.db.CreateCollection("stages"))
+	suite.orders, _ = suite.db.GetCollection("orders")
+	suite.stages, _ = suite.db.GetCollection("stages")
+	suite.Require().NoError(suite.stages.InsertOne(Document{"_id": "s1", "stage": "received", "done": false}))
+}
+
+func (suite *TransactionTestSuite) TestCommitAppliesAllWrites() {
+	session := suite.db.StartSession()
+	defer session.EndSession()
+
+	err := session.WithTransaction(func(tx *Transaction) error {
+		if err := tx.InsertOne("orders", Document{"_id": "o1", "stage": "pre-processed"}); err != nil {
+			return err
+		}
+		return tx.UpdateOne("stages", "s1", Document{"$set": map[string]interface{}{"done": true}})
+	})
+	suite.Require().NoError(err)
+
+	order, err := suite.orders.FindOne("o1")
+	suite.NoError(err)
+	suite.Equal("pre-processed", order["stage"])
+	stage, _ := suite.stages.FindOne("s1")
+	suite.Equal(true, stage["done"])
+}
+
+func (suite *TransactionTestSuite) TestErrorRollsBack() {
+	session := suite.db.StartSession()
+	defer session.EndSession()
+	failure := errors.New("boom")
+
+	err := session.WithTransaction(func(tx *Transaction) error {
+		suite.Require().NoError(tx.InsertOne("orders", Document{"_id": "o1"}))
+		suite.Require().NoError(tx.DeleteOne("stages", "s1"))
+		return failure
+	})
+	suite.ErrorIs(err, failure)
+
+	_, err = suite.orders.FindOne("o1")
+	suite.Error(err)
+	_, err = suite.stages.FindOne("s1")
+	suite.NoError(err)
+}
+
+func (suite *TransactionTestSuite) TestReadsSeeOwnWritesOnly() {
+	session := suite.db.StartSession()
+	defer session.EndSession()
+	tx, err := session.StartTransaction()
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(tx.InsertOne("orders", Document{"_id": "o1", "stage": "received"}))
+	suite.Require().NoError(tx.UpdateOne("stages", "s1", Document{"stage": "dispatched"}))
+
+	document, err := tx.FindOne("orders", "o1")
+	suite.NoError(err)
+	suite.Equal("received", document["stage"])
+	documents, err := tx.Find("stages", map[string]interface{}{"stage": "dispatched"})
+	suite.NoError(err)
+	suite.Len(documents, 1)
+
+	_, err = suite.orders.FindOne("o1")
+	suite.Error(err)
+	stage, _ := suite.stages.FindOne("s1")
+	suite.Equal("received", stage["stage"])
+
+	suite.NoError(session.CommitTransaction())
+	suite.ErrorIs(session.CommitTransaction(), ErrNoTransaction)
+	suite.ErrorIs(tx.InsertOne("orders", Document{"_id": "o2"}), ErrNoTransaction)
+}
+
+func (suite *TransactionTestSuite) TestFirstCommitterWins() {
+	first := suite.db.StartSession()
+	second := suite.db.StartSession()
+	tx1, _ := first.StartTransaction()
+	tx2, _ := second.StartTransaction()
+
+	suite.Require().NoError(tx1.UpdateOne("stages", "s1", Document{"stage": "dispatched"}))
+	suite.Require().NoError(tx2.UpdateOne("stages", "s1", Document{"stage": "failed"}))
+
+	suite.NoError(first.CommitTransaction())
+	suite.ErrorIs(second.CommitTransaction(), ErrWriteConflict)
+
+	stage, _ := suite.stages.FindOne("s1")
+	suite.Equal("dispatched", stage["stage"])
+}
+
+func (suite *TransactionTestSuite) TestReadOfConcurrentWriteConflicts() {
+	session := suite.db.StartSession()
+	tx, _ := session.StartTransaction()
+
+	suite.Require().NoError(suite.stages.UpdateOne("s1", Document{"stage": "dispatched"}))
+	suite.Require().NoError(suite.orders.InsertOne(Document{"_id": "o9"}))
+
+	_, err := tx.FindOne("stages", "s1")
+	suite.ErrorIs(err, ErrWriteConflict)
+	_, err = tx.Find("orders", nil)
+	suite.ErrorIs(err, ErrWriteConflict)
+	err = tx.InsertOne("orders", Document{"_id": "o9"})
+	suite.ErrorIs(err, ErrWriteConflict)
+}
+
+func (suite *TransactionTestSuite) TestDeleteAfterStartConflictsWithFind() {
+	suite.Require().NoError(suite.orders.InsertOne(Document{"_id": "o1"}))
+	session := suite.db.StartSession()
+	tx, _ := session.StartTransaction()
+
+	suite.Require().NoError(suite.orders.DeleteOne("o1"))
+
+	_, err := tx.Find("orders", nil)
+	suite.ErrorIs(err, ErrWriteConflict)
+}
+
+func (suite *TransactionTestSuite) TestWithTransactionRetriesConflicts() {
+	session := suite.db.StartSession()
+	attempts := 0
+
+	err := session.WithTransaction(func(tx *Transaction) error {
+		attempts++
+		if _, err := tx.FindOne("stages", "s1"); err != nil {
+			return err
+		}
+		if attempts == 1 {
+			// A concurrent writer changes the document before the commit.
+			suite.Require().NoError(suite.stages.UpdateOne("s1", Document{"$inc": map[string]interface{}{"version": 1}}))
+		}
+		return tx.UpdateOne("stages", "s1", Document{"$set": map[string]interface{}{"done": true}})
+	})
+	suite.NoError(err)
+	suite.Equal(2, attempts)
+
+	stage, _ := suite.stages.FindOne("s1")
+	suite.Equal(Document{"_id": "s1", "stage": "received", "done": true, "version": 1}, stage)
+}
+
+func (suite *TransactionTestSuite) TestConcurrentTransactionsAreSerialized() {
+	suite.Require().NoError(suite.stages.InsertOne(Document{"_id": "counter", "value": 0}))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			session := suite.db.StartSession()
+			defer session.EndSession()
+			for {
+				err := session.WithTransaction(func(tx *Transaction) error {
+					counter, err := tx.FindOne("stages", "counter")
+					if err != nil {
+						return err
+					}
+					return tx.UpdateOne("stages", "counter", Document{"value": counter["value"].(int) + 1})
+				})
+				if !errors.Is(err, ErrWriteConflict) {
+					suite.NoError(err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	counter, _ := suite.stages.FindOne("counter")
+	suite.Equal(5, counter["value"])
+}
+
+func (suite *TransactionTestSuite) TestUniqueIndexIsCheckedOnCommit() {
+	suite.Require().NoError(suite.orders.CreateUniqueIndex("code"))
+	suite.Require().NoError(suite.orders.InsertOne(Document{"_id": "o1", "code": "a"}))
+	session := suite.db.StartSession()
+
+	err := session.WithTransaction(func(tx *Transaction) error {
+		return tx.InsertOne("orders", Document{"_id": "o2", "code": "a"})
+	})
+	suite.ErrorIs(err, ErrDuplicateKey)
+
+	// Freeing the key in the same transaction is allowed.
+	err = session.WithTransaction(func(tx *Transaction) error {
+		if err := tx.DeleteOne("orders", "o1"); err != nil {
+			return err
+		}
+		return tx.InsertOne("orders", Document{"_id": "o2", "code": "a"})
+	})
+	suite.NoError(err)
+	suite.Len(suite.orders.Find(map[string]interface{}{"code": "a"}), 1)
+}
+
+func (suite *TransactionTestSuite) TestUpsertInTransaction() {
+	session := suite.db.StartSession()
+	var result *UpdateResult
+	err := session.WithTransaction(func(tx *Transaction) error {
+		var err error
+		result, err = tx.UpdateByID("orders", "o1", Document{"$set": map[string]interface{}{"stage": "received"}}, WithUpsert())
+		return err
+	})
+	suite.Require().NoError(err)
+	suite.Equal("o1", result.UpsertedID)
+
+	order, _ := suite.orders.FindOne("o1")
+	suite.Equal(Document{"_id": "o1", "stage": "received"}, order)
+}
+
+func (suite *TransactionTestSuite) TestSessionRules() {
+	session := suite.db.StartSession()
+	_, err := session.StartTransaction()
+	suite.NoError(err)
+	_, err = session.StartTransaction()
+	suite.Error(err)
+	suite.NoError(session.AbortTransaction())
+	suite.ErrorIs(session.AbortTransaction(), ErrNoTransaction)
+
+	session.EndSession()
+	_, err = session.StartTransaction()
+	suite.Error(err)
+}
@@ -0,0 +1,603 @@
+package database
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// UpdateResult reports the outcome of an update.
+type UpdateResult struct {
+	MatchedCount  int    // Number of documents matching the filter
+	ModifiedCount int    // Number of matched documents actually changed
+	UpsertedCount int    // 1 if a document was inserted because none matched, otherwise 0
+	UpsertedID    string // ID of the inserted document, if any
+}
+
+// UpdateOption configures an update.
+type UpdateOption func(*updateOptions)
+
+// updateOptions holds the settings of an update.
+type updateOptions struct {
+	upsert bool
+}
+
+// WithUpsert inserts a new document when no document matches the update filter.
+// The new document is built from the equality predicates of the filter, then the update is applied to it.
+//
+// Returns:
+//   - An UpdateOption enabling upserts.
+func WithUpsert() UpdateOption {
+	return func(o *updateOptions) {
+		o.upsert = true
+	}
+}
+
+// isOperatorUpdate reports whether an update uses update operators such as $set.
+//
+// Parameters:
+//   - update: The update document.
+//
+// Returns:
+//   - True if the update uses operators.
+//   - An error if the update mixes operators and plain fields.
+func isOperatorUpdate(update Document) (bool, error) {
+	operators, fields := 0, 0
+	for key := range update {
+		if strings.HasPrefix(key, "$") {
+			operators++
+		} else {
+			fields++
+		}
+	}
+	if operators > 0 && fields > 0 {
+		return false, errors.New("update cannot mix operators and fields")
+	}
+	return operators > 0, nil
+}
+
+// applyUpdate computes the new version of a document. The current version is left untouched.
+//
+// Without operators, the fields of the update are merged into the document. With operators,
+// each of $set, $unset, $inc, $push, $addToSet, $pull and $currentDate is applied in turn.
+//
+// Parameters:
+//   - current: The current version of the document.
+//   - update: The update document.
+//
+// Returns:
+//   - The updated document.
+//   - An error if the update is invalid or changes the "_id" field.
+func applyUpdate(current Document, update Document) (Document, error) {
+	operator, err := isOperatorUpdate(update)
+	if err != nil {
+		return nil, err
+	}
+	updated := deepCopy(current).(Document)
+	if !operator {
+		for key, value := range update {
+			updated[key] = value
+		}
+	} else {
+		for name, arguments := range update {
+			fields, ok := asMap(arguments)
+			if !ok {
+				return nil, fmt.Errorf("%s requires a document of fields", name)
+			}
+			for path, argument := range fields {
+				if err := applyOperator(updated, name, path, argument); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	if id, ok := current["_id"]; ok && !reflect.DeepEqual(updated["_id"], id) {
+		return nil, errors.New("_id field is immutable")
+	}
+	return updated, nil
+}
+
+// applyOperator applies a single update operator to a field.
+//
+// Parameters:
+//   - document: The document being updated, modified in place.
+//   - operator: The update operator.
+//   - path: The field path, possibly dotted.
+//   - argument: The argument of the operator for that field.
+//
+// Returns:
+//   - An error if the operator is unsupported or cannot be applied to the field.
+func applyOperator(document Document, operator, path string, argument interface{}) error {
+	value, exists := lookupPath(document, path)
+	switch operator {
+	case "$set":
+		return setPath(document, path, argument)
+	case "$unset":
+		unsetPath(document, path)
+		return nil
+	case "$inc":
+		sum, err := increment(value, exists, argument)
+		if err != nil {
+			return fmt.Errorf("$inc on %s: %w", path, err)
+		}
+		return setPath(document, path, sum)
+	case "$push", "$addToSet":
+		list, err := arrayField(value, exists, path)
+		if err != nil {
+			return err
+		}
+		for _, element := range eachValues(argument) {
+			if operator == "$addToSet" && containsValue(list, element) {
+				continue
+			}
+			list = append(list, element)
+		}
+		return setPath(document, path, list)
+	case "$pull":
+		if !exists {
+			return nil
+		}
+		list, err := arrayField(value, exists, path)
+		if err != nil {
+			return err
+		}
+		kept := make([]interface{}, 0, len(list))
+		for _, element := range list {
+			if !matchesCondition(element, argument) {
+				kept = append(kept, element)
+			}
+		}
+		return setPath(document, path, kept)
+	case "$currentDate":
+		if err := checkDateType(argument); err != nil {
+			return fmt.Errorf("$currentDate on %s: %w", path, err)
+		}
+		return setPath(document, path, time.Now().UTC())
+	default:
+		return fmt.Errorf("unsupported update operator %s", operator)
+	}
+}
+
+// setPath sets a field, creating the intermediate documents of a dotted path.
+//
+// Parameters:
+//   - document: The document to modify.
+//   - path: The field path.
+//   - value: The new value.
+//
+// Returns:
+//   - An error if an intermediate field exists but is not a document.
+func setPath(document map[string]interface{}, path string, value interface{}) error {
+	head, rest, found := strings.Cut(path, ".")
+	if !found {
+		document[path] = value
+		return nil
+	}
+	next, exists := document[head]
+	if !exists || next == nil {
+		nested := make(map[string]interface{})
+		document[head] = nested
+		return setPath(nested, rest, value)
+	}
+	nested, ok := asMap(next)
+	if !ok {
+		return fmt.Errorf("cannot set %s: %s is not a document", path, head)
+	}
+	return setPath(nested, rest, value)
+}
+
+// unsetPath removes a field. Missing fields are ignored.
+//
+// Parameters:
+//   - document: The document to modify.
+//   - path: The field path.
+func unsetPath(document map[string]interface{}, path string) {
+	head, rest, found := strings.Cut(path, ".")
+	if !found {
+		delete(document, path)
+		return
+	}
+	if nested, ok := asMap(document[head]); ok {
+		unsetPath(nested, rest)
+	}
+}
+
+// increment implements $inc. Integers stay integers of the type already stored.
+//
+// Parameters:
+//   - value: The current value of the field.
+//   - exists: Whether the field exists.
+//   - amount: The amount to add.
+//
+// Returns:
+//   - The new value of the field.
+//   - An error if the field or the amount is not a number.
+func increment(value interface{}, exists bool, amount interface{}) (interface{}, error) {
+	delta, ok := toFloat(amount)
+	if !ok {
+		return nil, errors.New("amount must be a number")
+	}
+	if !exists || value == nil {
+		return amount, nil
+	}
+	current, ok := toFloat(value)
+	if !ok {
+		return nil, errors.New("field is not a number")
+	}
+	v := reflect.ValueOf(value)
+	a := reflect.ValueOf(amount)
+	switch {
+	case v.CanInt() && a.CanInt():
+		sum := reflect.New(v.Type()).Elem()
+		sum.SetInt(v.Int() + a.Int())
+		return sum.Interface(), nil
+	case v.CanUint() && a.CanUint():
+		sum := reflect.New(v.Type()).Elem()
+		sum.SetUint(v.Uint() + a.Uint())
+		return sum.Interface(), nil
+	}
+	return current + delta, nil
+}
+
+// arrayField reads a field used by $push, $addToSet or $pull.
+//
+// Parameters:
+//   - value: The current value of the field.
+//   - exists: Whether the field exists.
+//   - path: The field path, for error messages.
+//
+// Returns:
+//   - A copy of the elements of the field, empty if it does not exist.
+//   - An error if the field is not an array.
+func arrayField(value interface{}, exists bool, path string) ([]interface{}, error) {
+	if !exists || value == nil {
+		return []interface{}{}, nil
+	}
+	list, ok := toSlice(value)
+	if !ok {
+		return nil, fmt.Errorf("%s is not an array", path)
+	}
+	return append([]interface{}(nil), list...), nil
+}
+
+// eachValues returns the values added by $push or $addToSet, expanding the $each modifier.
+//
+// Parameters:
+//   - argument: The argument of the operator.
+//
+// Returns:
+//   - The values to add.
+func eachValues(argument interface{}) []interface{} {
+	if modifier, ok := asMap(argument); ok {
+		if each, ok := toSlice(modifier["$each"]); ok {
+			return each
+		}
+	}
+	return []interface{}{argument}
+}
+
+// containsValue reports whether a list holds a value.
+//
+// Parameters:
+//   - list: The list to search.
+//   - value: The value to look for.
+//
+// Returns:
+//   - True if an element is equal to the value.
+func containsValue(list []interface{}, value interface{}) bool {
+	for _, element := range list {
+		if valuesEqual(element, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesCondition evaluates the condition of $pull against an array element.
+//
+// Parameters:
+//   - element: The array element.
+//   - condition: A value, an operator document or a query on nested documents.
+//
+// Returns:
+//   - True if the element must be removed.
+func matchesCondition(element interface{}, condition interface{}) bool {
+	query, ok := asMap(condition)
+	if !ok {
+		return valuesEqual(element, condition)
+	}
+	if onlyOperators(query) {
+		return matchesOperators(element, true, query)
+	}
+	elementMap, ok := asMap(element)
+	return ok && matchesQuery(elementMap, query)
+}
+
+// checkDateType validates the argument of $currentDate.
+//
+// Parameters:
+//   - argument: true or {"$type": "date"}.
+//
+// Returns:
+//   - An error if the argument is not supported.
+func checkDateType(argument interface{}) error {
+	if enabled, ok := argument.(bool); ok && enabled {
+		return nil
+	}
+	if spec, ok := asMap(argument); ok && spec["$type"] == "date" {
+		return nil
+	}
+	return errors.New(`expected true or {"$type": "date"}`)
+}
+
+// deepCopy copies nested documents and arrays so an update never alters a stored document.
+//
+// Parameters:
+//   - value: The value to copy.
+//
+// Returns:
+//   - The copy.
+func deepCopy(value interface{}) interface{} {
+	switch v := value.(type) {
+	case Document:
+		copied := make(Document, len(v))
+		for key, nested := range v {
+			copied[key] = deepCopy(nested)
+		}
+		return copied
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(v))
+		for key, nested := range v {
+			copied[key] = deepCopy(nested)
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(v))
+		for i, nested := range v {
+			copied[i] = deepCopy(nested)
+		}
+		return copied
+	default:
+		return value
+	}
+}
+
+// sameDocument reports whether two versions of a document hold the same data, ignoring
+// the Go types of numbers so an in-memory int and a persisted float64 compare equal.
+//
+// Parameters:
+//   - a: The first version.
+//   - b: The second version.
+//
+// Returns:
+//   - True if both versions encode to the same JSON.
+func sameDocument(a, b Document) bool {
+	x, errX := json.Marshal(a)
+	y, errY := json.Marshal(b)
+	if errX != nil || errY != nil {
+		return reflect.DeepEqual(a, b)
+	}
+	return string(x) == string(y)
+}
+
+// newDocumentID generates a random ID for an upserted document.
+//
+// Returns:
+//   - A 32-character hexadecimal ID.
+func newDocumentID() string {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return fmt.Sprintf("%x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(buf)
+}
+
+// UpdateByID updates a document by its ID.
+//
+// Parameters:
+//   - id: The ID of the document to update.
+//   - update: Either fields to merge into the document or update operators such as
+//     {"$set": {"stage": "completed"}, "$inc": {"attempts": 1}}.
+//   - opts: Optional settings, such as WithUpsert.
+//
+// Returns:
+//   - The number of matched, modified and upserted documents.
+//   - An error if the update is invalid or breaks a unique index.
+func (c *Collection) UpdateByID(id string, update Document, opts ...UpdateOption) (*UpdateResult, error) {
+	return c.update(map[string]interface{}{"_id": id}, update, true, opts)
+}
+
+// UpdateMany updates every document matching a filter. The update is applied atomically
+// under the collection lock and logged as a single record: either every matching document
+// is updated, or none when the update is invalid, would break a unique index or cannot be
+// written to the log.
+//
+// Parameters:
+//   - filter: The query selecting the documents to update.
+//   - update: Either fields to merge into the documents or update operators.
+//   - opts: Optional settings, such as WithUpsert.
+//
+// Returns:
+//   - The number of matched, modified and upserted documents.
+//   - An error if the update is invalid or breaks a unique index.
+func (c *Collection) UpdateMany(filter map[string]interface{}, update Document, opts ...UpdateOption) (*UpdateResult, error) {
+	return c.update(filter, update, false, opts)
+}
+
+// update applies an update to the documents matching a filter.
+//
+// Parameters:
+//   - filter: The query selecting the documents.
+//   - update: The update document.
+//   - byID: Whether the filter is {"_id": id}, resolved without a query.
+//   - opts: The update options.
+//
+// Returns:
+//   - The update result.
+//   - An error if the update fails; nothing is changed in that case.
+func (c *Collection) update(filter map[string]interface{}, update Document, byID bool, opts []UpdateOption) (*UpdateResult, error) {
+	options := &updateOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	if _, err := isOperatorUpdate(update); err != nil {
+		return nil, err
+	}
+
+	endWrite := c.store.beginWrite()
+	defer endWrite()
+	c.mu.Lock() // Lock for writing
+	defer c.mu.Unlock()
+
+	var matched []Document
+	if byID {
+		if document, ok := c.data[filter["_id"].(string)]; ok {
+			matched = []Document{document}
+		}
+	} else {
+		matched, _ = c.execute(filter)
+	}
+
+	result := &UpdateResult{MatchedCount: len(matched)}
+	if len(matched) == 0 {
+		if !options.upsert {
+			return result, nil
+		}
+		id, err := c.upsert(filter, update)
+		if err != nil {
+			return nil, err
+		}
+		result.UpsertedCount = 1
+		result.UpsertedID = id
+		return result, nil
+	}
+
+	changes := make(map[string]Document, len(matched))
+	for _, current := range matched {
+		updated, err := applyUpdate(current, update)
+		if err != nil {
+			return nil, err
+		}
+		if !sameDocument(current, updated) {
+			changes[current["_id"].(string)] = updated
+		}
+	}
+	if err := c.checkUniqueBatch(changes); err != nil {
+		return nil, err
+	}
+	if len(changes) == 0 {
+		return result, nil
+	}
+
+	ids := make([]string, 0, len(changes))
+	for id := range changes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	ops := make([]walRecord, len(ids))
+	for i, id := range ids {
+		ops[i] = walRecord{Op: opPut, Collection: c.name, ID: id, Document: changes[id]}
+	}
+	// Log every change in one record before touching memory, so a failed write or a
+	// crash leaves either all of the documents updated or none.
+	logged, err := c.store.appendTransaction(ops)
+	if err != nil {
+		return nil, err
+	}
+	for i, id := range ids {
+		stored := logged[i]
+		previous := c.data[id]
+		c.reindex(id, previous, stored)
+		c.data[id] = stored
+		c.stamp(id, nextVersion(), false)
+		c.publish(id, previous, stored)
+		result.ModifiedCount++
+	}
+	return result, nil
+}
+
+// upsert inserts the document built from a filter and an update. The caller must hold the collection lock.
+//
+// Parameters:
+//   - filter: The filter that matched no document.
+//   - update: The update document.
+//
+// Returns:
+//   - The ID of the inserted document.
+//   - An error if the document cannot be built or inserted.
+func (c *Collection) upsert(filter map[string]interface{}, update Document) (string, error) {
+	predicates := make(map[string]interface{})
+	equalityPredicates(filter, "", predicates)
+
+	seed := make(Document, len(predicates)+1)
+	for path, value := range predicates {
+		if err := setPath(seed, path, deepCopy(value)); err != nil {
+			return "", err
+		}
+	}
+	document, err := applyUpdate(seed, update)
+	if err != nil {
+		return "", err
+	}
+	if _, ok := document["_id"]; !ok {
+		document["_id"] = newDocumentID()
+	}
+	id, ok := document["_id"].(string)
+	if !ok {
+		return "", errors.New("_id field must be a string")
+	}
+	if _, exists := c.data[id]; exists {
+		return "", errors.New("document already exists")
+	}
+	if err := c.checkUnique(id, document); err != nil {
+		return "", err
+	}
+	stored, err := c.store.append(walRecord{Op: opPut, Collection: c.name, ID: id, Document: document})
+	if err != nil {
+		return "", err
+	}
+	c.data[id] = stored
+	c.reindex(id, nil, stored)
+	c.stamp(id, nextVersion(), false)
+	c.publish(id, nil, stored)
+	return id, nil
+}
+
+// checkUniqueBatch verifies that a set of updated documents can be stored together without
+// breaking a unique index. The caller must hold the collection lock.
+//
+// Parameters:
+//   - changes: The new versions of the documents, keyed by ID.
+//
+// Returns:
+//   - An error wrapping ErrDuplicateKey on conflict.
+func (c *Collection) checkUniqueBatch(changes map[string]Document) error {
+	for _, idx := range c.indexes {
+		if !idx.unique {
+			continue
+		}
+		seen := make(map[string]string, len(changes))
+		for id, document := range changes {
+			key, complete, _ := idx.key(document)
+			if !complete {
+				continue
+			}
+			if other, ok := seen[key]; ok {
+				return fmt.Errorf("%w: index %s would contain %s twice (documents %s and %s)", ErrDuplicateKey, idx.name, key, other, id)
+			}
+			seen[key] = id
+			for other := range idx.entries[key] {
+				if _, changing := changes[other]; !changing && other != id {
+					return fmt.Errorf("%w: index %s already contains %s", ErrDuplicateKey, idx.name, key)
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,214 @@
+package database
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type UpdateTestSuite struct {
+	suite.Suite
+	collection *Collection
+}
+
+func TestUpdateTestSuite(t *testing.T) {
+	suite.Run(t, new(UpdateTestSuite))
+}
+
+func (suite *UpdateTestSuite) SetupTest() {
+	suite.collection = NewCollection()
+	documents := []Document{
+		{"_id": "1", "stage": "received", "attempts": 1, "tags": []interface{}{"a"}, "metadata": map[string]interface{}{"provider": "p1"}},
+		{"_id": "2", "stage": "received", "attempts": 2, "tags": []interface{}{"a", "b"}, "metadata": map[string]interface{}{"provider": "p2"}},
+		{"_id": "3", "stage": "completed", "attempts": 1},
+	}
+	for _, document := range documents {
+		suite.Require().NoError(suite.collection.InsertOne(document))
+	}
+}
+
+func (suite *UpdateTestSuite) TestSetAndUnset() {
+	result, err := suite.collection.UpdateByID("1", Document{
+		"$set":   map[string]interface{}{"stage": "dispatched", "metadata.service": "s1", "output.path": "/tmp"},
+		"$unset": map[string]interface{}{"tags": ""},
+	})
+	suite.Require().NoError(err)
+	suite.Equal(&UpdateResult{MatchedCount: 1, ModifiedCount: 1}, result)
+
+	document, _ := suite.collection.FindOne("1")
+	suite.Equal("dispatched", document["stage"])
+	suite.Equal(map[string]interface{}{"provider": "p1", "service": "s1"}, document["metadata"])
+	suite.Equal(map[string]interface{}{"path": "/tmp"}, document["output"])
+	suite.NotContains(document, "tags")
+}
+
+func (suite *UpdateTestSuite) TestUpdateDoesNotAlterPreviousVersion() {
+	before, _ := suite.collection.FindOne("1")
+
+	_, err := suite.collection.UpdateByID("1", Document{"$set": map[string]interface{}{"metadata.provider": "p9"}})
+	suite.Require().NoError(err)
+
+	suite.Equal(map[string]interface{}{"provider": "p1"}, before["metadata"])
+}
+
+func (suite *UpdateTestSuite) TestInc() {
+	_, err := suite.collection.UpdateByID("2", Document{"$inc": map[string]interface{}{"attempts": 3, "retries": 1, "score": 0.5}})
+	suite.Require().NoError(err)
+
+	document, _ := suite.collection.FindOne("2")
+	suite.Equal(5, document["attempts"])
+	suite.Equal(1, document["retries"])
+	suite.Equal(0.5, document["score"])
+
+	_, err = suite.collection.UpdateByID("2", Document{"$inc": map[string]interface{}{"stage": 1}})
+	suite.Error(err)
+}
+
+func (suite *UpdateTestSuite) TestArrayOperators() {
+	_, err := suite.collection.UpdateByID("2", Document{
+		"$push":     map[string]interface{}{"history": "received", "tags": map[string]interface{}{"$each": []interface{}{"c", "d"}}},
+		"$addToSet": map[string]interface{}{"labels": map[string]interface{}{"$each": []string{"x", "x", "y"}}},
+	})
+	suite.Require().NoError(err)
+
+	document, _ := suite.collection.FindOne("2")
+	suite.Equal([]interface{}{"received"}, document["history"])
+	suite.Equal([]interface{}{"a", "b", "c", "d"}, document["tags"])
+	suite.Equal([]interface{}{"x", "y"}, document["labels"])
+
+	_, err = suite.collection.UpdateByID("2", Document{
+		"$pull": map[string]interface{}{"tags": map[string]interface{}{"$in": []string{"a", "c"}}, "labels": "y"},
+	})
+	suite.Require().NoError(err)
+
+	document, _ = suite.collection.FindOne("2")
+	suite.Equal([]interface{}{"b", "d"}, document["tags"])
+	suite.Equal([]interface{}{"x"}, document["labels"])
+
+	_, err = suite.collection.UpdateByID("2", Document{"$push": map[string]interface{}{"stage": "x"}})
+	suite.Error(err)
+}
+
+func (suite *UpdateTestSuite) TestCurrentDate() {
+	before := time.Now().UTC()
+	_, err := suite.collection.UpdateByID("1", Document{"$currentDate": map[string]interface{}{"updatedAt": true}})
+	suite.Require().NoError(err)
+
+	document, _ := suite.collection.FindOne("1")
+	updatedAt, ok := document["updatedAt"].(time.Time)
+	suite.True(ok)
+	suite.False(updatedAt.Before(before))
+
+	_, err = suite.collection.UpdateByID("1", Document{"$currentDate": map[string]interface{}{"updatedAt": "now"}})
+	suite.Error(err)
+}
+
+func (suite *UpdateTestSuite) TestInvalidUpdates() {
+	_, err := suite.collection.UpdateByID("1", Document{"$set": map[string]interface{}{"a": 1}, "b": 2})
+	suite.Error(err)
+
+	_, err = suite.collection.UpdateByID("1", Document{"$rename": map[string]interface{}{"a": "b"}})
+	suite.Error(err)
+
+	_, err = suite.collection.UpdateByID("1", Document{"$set": map[string]interface{}{"_id": "9"}})
+	suite.Error(err)
+
+	_, err = suite.collection.UpdateByID("1", Document{"$set": map[string]interface{}{"stage.name": "x"}})
+	suite.Error(err)
+}
+
+func (suite *UpdateTestSuite) TestUpdateManyCounts() {
+	result, err := suite.collection.UpdateMany(
+		map[string]interface{}{"attempts": 1},
+		Document{"$set": map[string]interface{}{"stage": "completed"}},
+	)
+	suite.Require().NoError(err)
+	suite.Equal(&UpdateResult{MatchedCount: 2, ModifiedCount: 1}, result)
+
+	suite.Len(suite.collection.Find(map[string]interface{}{"stage": "completed"}), 2)
+}
+
+func (suite *UpdateTestSuite) TestUpdateManyWithoutMatch() {
+	result, err := suite.collection.UpdateMany(
+		map[string]interface{}{"stage": "failed"},
+		Document{"$set": map[string]interface{}{"stage": "completed"}},
+	)
+	suite.Require().NoError(err)
+	suite.Equal(&UpdateResult{}, result)
+}
+
+func (suite *UpdateTestSuite) TestUpdateManyIsAllOrNothing() {
+	suite.Require().NoError(suite.collection.CreateUniqueIndex("metadata.provider"))
+
+	_, err := suite.collection.UpdateMany(
+		map[string]interface{}{"stage": "received"},
+		Document{"$set": map[string]interface{}{"metadata.provider": "p3"}},
+	)
+	suite.True(errors.Is(err, ErrDuplicateKey))
+
+	suite.Len(suite.collection.Find(map[string]interface{}{"metadata.provider": "p3"}), 0)
+	suite.Len(suite.collection.Find(map[string]interface{}{"metadata.provider": "p1"}), 1)
+
+	// Swapping keys between matched documents is allowed.
+	_, err = suite.collection.UpdateMany(
+		map[string]interface{}{"_id": "1"},
+		Document{"$set": map[string]interface{}{"metadata.provider": "p3"}},
+	)
+	suite.NoError(err)
+}
+
+func (suite *UpdateTestSuite) TestUpsert() {
+	result, err := suite.collection.UpdateMany(
+		map[string]interface{}{"metadata.provider": "p4", "attempts": map[string]interface{}{"$gt": 5}},
+		Document{"$set": map[string]interface{}{"stage": "received"}, "$inc": map[string]interface{}{"count": 1}},
+		WithUpsert(),
+	)
+	suite.Require().NoError(err)
+	suite.Equal(0, result.MatchedCount)
+	suite.Equal(1, result.UpsertedCount)
+	suite.NotEmpty(result.UpsertedID)
+
+	document, err := suite.collection.FindOne(result.UpsertedID)
+	suite.Require().NoError(err)
+	suite.Equal(map[string]interface{}{"provider": "p4"}, document["metadata"])
+	suite.Equal("received", document["stage"])
+	suite.Equal(1, document["count"])
+	suite.NotContains(document, "attempts")
+
+	result, err = suite.collection.UpdateByID("9", Document{"$set": map[string]interface{}{"stage": "received"}}, WithUpsert())
+	suite.Require().NoError(err)
+	suite.Equal("9", result.UpsertedID)
+	document, _ = suite.collection.FindOne("9")
+	suite.Equal(Document{"_id": "9", "stage": "received"}, document)
+}
+
+func (suite *UpdateTestSuite) TestUpdateOneKeepsMergeSemantics() {
+	suite.NoError(suite.collection.UpdateOne("3", Document{"attempts": 2}))
+	document, _ := suite.collection.FindOne("3")
+	suite.Equal(Document{"_id": "3", "stage": "completed", "attempts": 2}, document)
+
+	suite.NoError(suite.collection.UpdateOne("3", Document{"$inc": map[string]interface{}{"attempts": 1}}))
+	document, _ = suite.collection.FindOne("3")
+	suite.Equal(3, document["attempts"])
+
+	suite.Error(suite.collection.UpdateOne("9", Document{"attempts": 2}))
+}
+
+func (suite *UpdateTestSuite) TestConcurrentIncrementsAreAtomic() {
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := suite.collection.UpdateByID("3", Document{"$inc": map[string]interface{}{"attempts": 1}})
+			suite.NoError(err)
+		}()
+	}
+	wg.Wait()
+
+	document, _ := suite.collection.FindOne("3")
+	suite.Equal(51, document["attempts"])
+}
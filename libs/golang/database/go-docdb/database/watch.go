@@ -0,0 +1,269 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrChangeStreamHistoryLost is returned when a change stream cannot resume because the
+	// changes after the resume token are no longer retained.
+	ErrChangeStreamHistoryLost = errors.New("change stream history lost")
+
+	// ErrChangeStreamLagging closes a change stream whose consumer does not keep up.
+	// The consumer can resume from the token of the last event it handled.
+	ErrChangeStreamLagging = errors.New("change stream consumer is too slow")
+
+	// ErrChangeStreamInvalidated closes the change streams of a dropped collection.
+	ErrChangeStreamInvalidated = errors.New("collection was dropped")
+
+	// changeLogSize is the minimum number of changes a collection retains for resuming change streams.
+	changeLogSize = 1024
+
+	// watchBufferSize is the number of events buffered for a change stream consumer.
+	watchBufferSize = 256
+)
+
+const (
+	// OperationInsert is the operation type of a change inserting a document.
+	OperationInsert = "insert"
+	// OperationUpdate is the operation type of a change updating a document.
+	OperationUpdate = "update"
+	// OperationDelete is the operation type of a change deleting a document.
+	OperationDelete = "delete"
+)
+
+// ResumeToken identifies a change in a collection. Tokens increase with every change.
+type ResumeToken uint64
+
+// ChangeEvent describes a write to a collection. The documents are shared with the
+// collection and must not be modified.
+type ChangeEvent struct {
+	Token                    ResumeToken // Token to resume after this event
+	OperationType            string      // OperationInsert, OperationUpdate or OperationDelete
+	Collection               string      // Name of the collection
+	DocumentKey              string      // ID of the document
+	FullDocument             Document    // Document after the change, nil for deletes
+	FullDocumentBeforeChange Document    // Document before the change, nil for inserts
+	ClusterTime              time.Time   // Time of the change
+}
+
+// toMap exposes the event with the field names of MongoDB change events, so Watch filters
+// look like a $match stage, for instance {"operationType": "update", "fullDocument.stage": "completed"}.
+//
+// Returns:
+//   - The event as a document.
+func (e ChangeEvent) toMap() map[string]interface{} {
+	event := map[string]interface{}{
+		"operationType": e.OperationType,
+		"ns":            map[string]interface{}{"coll": e.Collection},
+		"documentKey":   map[string]interface{}{"_id": e.DocumentKey},
+		"clusterTime":   e.ClusterTime,
+	}
+	if e.FullDocument != nil {
+		event["fullDocument"] = map[string]interface{}(e.FullDocument)
+	}
+	if e.FullDocumentBeforeChange != nil {
+		event["fullDocumentBeforeChange"] = map[string]interface{}(e.FullDocumentBeforeChange)
+	}
+	return event
+}
+
+// WatchOption configures a change stream.
+type WatchOption func(*watchOptions)
+
+// watchOptions holds the settings of a change stream.
+type watchOptions struct {
+	resumeAfter *ResumeToken
+}
+
+// WithResumeAfter starts the change stream right after the given token, replaying the
+// retained changes the consumer missed.
+//
+// Parameters:
+//   - token: The token of the last event handled.
+//
+// Returns:
+//   - A WatchOption resuming the stream.
+func WithResumeAfter(token ResumeToken) WatchOption {
+	return func(o *watchOptions) {
+		o.resumeAfter = &token
+	}
+}
+
+// ChangeStream delivers the changes of a collection matching a filter.
+type ChangeStream struct {
+	collection *Collection
+	filter     map[string]interface{}
+	events     chan ChangeEvent
+	token      ResumeToken
+	err        error
+	closed     bool
+}
+
+// Events returns the channel of change events. It is closed when the stream is closed,
+// the consumer falls behind, or the collection is dropped; Err tells which.
+//
+// Returns:
+//   - The channel of events.
+func (s *ChangeStream) Events() <-chan ChangeEvent {
+	return s.events
+}
+
+// ResumeToken returns the token of the last change the stream delivered to its buffer,
+// or the token it was started from.
+//
+// Returns:
+//   - The token.
+func (s *ChangeStream) ResumeToken() ResumeToken {
+	s.collection.mu.RLock()
+	defer s.collection.mu.RUnlock()
+	return s.token
+}
+
+// Err returns the reason the stream stopped, if it was not closed by Close.
+//
+// Returns:
+//   - ErrChangeStreamLagging, ErrChangeStreamInvalidated, or nil.
+func (s *ChangeStream) Err() error {
+	s.collection.mu.RLock()
+	defer s.collection.mu.RUnlock()
+	return s.err
+}
+
+// Close stops the stream and closes its channel. Closing twice is a no-op.
+func (s *ChangeStream) Close() {
+	s.collection.mu.Lock()
+	defer s.collection.mu.Unlock()
+	s.collection.unsubscribe(s, nil)
+}
+
+// Watch opens a change stream on the collection. Every insert, update and delete matching
+// the filter is delivered, with the document after the change and the pre-image before it.
+// The filter applies to the event, with the field names of MongoDB change events:
+// operationType, documentKey._id, fullDocument and fullDocumentBeforeChange.
+//
+// Parameters:
+//   - filter: The query selecting the events, or nil for all of them.
+//   - opts: Optional settings, such as WithResumeAfter.
+//
+// Returns:
+//   - The change stream. Close it when done.
+//   - An error wrapping ErrChangeStreamHistoryLost if the stream cannot resume from the given token.
+func (c *Collection) Watch(filter map[string]interface{}, opts ...WatchOption) (*ChangeStream, error) {
+	options := &watchOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stream := &ChangeStream{collection: c, filter: filter, token: c.changeSeq}
+	var backlog []ChangeEvent
+	if options.resumeAfter != nil {
+		token := *options.resumeAfter
+		oldest := c.changeSeq - ResumeToken(len(c.changes))
+		if token > c.changeSeq || token < oldest {
+			return nil, fmt.Errorf("%w: cannot resume collection %s after token %d", ErrChangeStreamHistoryLost, c.name, token)
+		}
+		for _, event := range c.changes[len(c.changes)-int(c.changeSeq-token):] {
+			if stream.matches(event) {
+				backlog = append(backlog, event)
+			}
+		}
+		stream.token = token
+	}
+
+	stream.events = make(chan ChangeEvent, watchBufferSize+len(backlog))
+	for _, event := range backlog {
+		stream.events <- event
+		stream.token = event.Token
+	}
+	c.watchers = append(c.watchers, stream)
+	return stream, nil
+}
+
+// matches reports whether an event passes the filter of the stream.
+//
+// Parameters:
+//   - event: The change event.
+//
+// Returns:
+//   - True if the event must be delivered.
+func (s *ChangeStream) matches(event ChangeEvent) bool {
+	return len(s.filter) == 0 || matchesQuery(event.toMap(), s.filter)
+}
+
+// publish records a change and delivers it to the change streams. The caller must hold the collection lock.
+//
+// Parameters:
+//   - id: The ID of the document.
+//   - previous: The document before the change, or nil if it was inserted.
+//   - current: The document after the change, or nil if it was deleted.
+func (c *Collection) publish(id string, previous, current Document) {
+	operation := OperationUpdate
+	switch {
+	case previous == nil:
+		operation = OperationInsert
+	case current == nil:
+		operation = OperationDelete
+	}
+	c.changeSeq++
+	event := ChangeEvent{
+		Token:                    c.changeSeq,
+		OperationType:            operation,
+		Collection:               c.name,
+		DocumentKey:              id,
+		FullDocument:             current,
+		FullDocumentBeforeChange: previous,
+		ClusterTime:              time.Now().UTC(),
+	}
+
+	c.changes = append(c.changes, event)
+	if len(c.changes) >= 2*changeLogSize { // Trim in batches to keep appends cheap
+		c.changes = append(c.changes[:0:0], c.changes[len(c.changes)-changeLogSize:]...)
+	}
+
+	for _, stream := range append([]*ChangeStream(nil), c.watchers...) {
+		if !stream.matches(event) {
+			continue
+		}
+		select {
+		case stream.events <- event:
+			stream.token = event.Token
+		default:
+			c.unsubscribe(stream, ErrChangeStreamLagging)
+		}
+	}
+}
+
+// unsubscribe removes a change stream and closes its channel. The caller must hold the collection lock.
+//
+// Parameters:
+//   - stream: The stream to remove.
+//   - err: The reason, or nil when the consumer closed the stream.
+func (c *Collection) unsubscribe(stream *ChangeStream, err error) {
+	if stream.closed {
+		return
+	}
+	stream.closed = true
+	stream.err = err
+	close(stream.events)
+	for i, watcher := range c.watchers {
+		if watcher == stream {
+			c.watchers = append(c.watchers[:i], c.watchers[i+1:]...)
+			break
+		}
+	}
+}
+
+// invalidate closes every change stream of a dropped collection.
+func (c *Collection) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for len(c.watchers) > 0 {
+		c.unsubscribe(c.watchers[0], ErrChangeStreamInvalidated)
+	}
+}
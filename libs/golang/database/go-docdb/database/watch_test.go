@@ -0,0 +1,196 @@
+package database
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type WatchTestSuite struct {
+	suite.Suite
+	db         *InMemoryDocBD
+	collection *Collection
+}
+
+func TestWatchTestSuite(t *testing.T) {
+	suite.Run(t, new(WatchTestSuite))
+}
+
+func (suite *WatchTestSuite) SetupTest() {
+	suite.db = NewInMemoryDocBD("test-db")
+	suite.Require().NoError(suite.db.CreateCollection("orders"))
+	suite.collection, _ = suite.db.GetCollection("orders")
+}
+
+// next waits for the next event of a stream.
+func (suite *WatchTestSuite) next(stream *ChangeStream) ChangeEvent {
+	select {
+	case event, ok := <-stream.Events():
+		suite.Require().True(ok, "change stream closed")
+		return event
+	case <-time.After(time.Second):
+		suite.FailNow("no change event received")
+		return ChangeEvent{}
+	}
+}
+
+func (suite *WatchTestSuite) TestInsertUpdateDelete() {
+	stream, err := suite.collection.Watch(nil)
+	suite.Require().NoError(err)
+	defer stream.Close()
+
+	suite.Require().NoError(suite.collection.InsertOne(Document{"_id": "o1", "stage": "received"}))
+	suite.Require().NoError(suite.collection.UpdateOne("o1", Document{"$set": map[string]interface{}{"stage": "dispatched"}}))
+	suite.Require().NoError(suite.collection.DeleteOne("o1"))
+
+	insert := suite.next(stream)
+	suite.Equal(OperationInsert, insert.OperationType)
+	suite.Equal("orders", insert.Collection)
+	suite.Equal("o1", insert.DocumentKey)
+	suite.Equal(Document{"_id": "o1", "stage": "received"}, insert.FullDocument)
+	suite.Nil(insert.FullDocumentBeforeChange)
+
+	update := suite.next(stream)
+	suite.Equal(OperationUpdate, update.OperationType)
+	suite.Equal("dispatched", update.FullDocument["stage"])
+	suite.Equal("received", update.FullDocumentBeforeChange["stage"])
+
+	deletion := suite.next(stream)
+	suite.Equal(OperationDelete, deletion.OperationType)
+	suite.Nil(deletion.FullDocument)
+	suite.Equal("dispatched", deletion.FullDocumentBeforeChange["stage"])
+
+	suite.True(insert.Token < update.Token && update.Token < deletion.Token)
+	suite.Equal(deletion.Token, stream.ResumeToken())
+}
+
+func (suite *WatchTestSuite) TestFilter() {
+	stream, err := suite.collection.Watch(map[string]interface{}{
+		"operationType":      OperationUpdate,
+		"fullDocument.stage": "completed",
+	})
+	suite.Require().NoError(err)
+	defer stream.Close()
+
+	suite.Require().NoError(suite.collection.InsertOne(Document{"_id": "o1", "stage": "completed"}))
+	suite.Require().NoError(suite.collection.InsertOne(Document{"_id": "o2", "stage": "received"}))
+	suite.Require().NoError(suite.collection.UpdateOne("o2", Document{"stage": "processing"}))
+	suite.Require().NoError(suite.collection.UpdateOne("o2", Document{"stage": "completed"}))
+
+	event := suite.next(stream)
+	suite.Equal("o2", event.DocumentKey)
+	suite.Equal("processing", event.FullDocumentBeforeChange["stage"])
+	suite.Empty(stream.Events())
+}
+
+func (suite *WatchTestSuite) TestResumeAfterToken() {
+	stream, err := suite.collection.Watch(nil)
+	suite.Require().NoError(err)
+	suite.Require().NoError(suite.collection.InsertOne(Document{"_id": "o1"}))
+	token := suite.next(stream).Token
+	stream.Close()
+
+	suite.Require().NoError(suite.collection.InsertOne(Document{"_id": "o2"}))
+	suite.Require().NoError(suite.collection.InsertOne(Document{"_id": "o3"}))
+
+	resumed, err := suite.collection.Watch(nil, WithResumeAfter(token))
+	suite.Require().NoError(err)
+	defer resumed.Close()
+	suite.Equal("o2", suite.next(resumed).DocumentKey)
+	suite.Equal("o3", suite.next(resumed).DocumentKey)
+
+	suite.Require().NoError(suite.collection.InsertOne(Document{"_id": "o4"}))
+	suite.Equal("o4", suite.next(resumed).DocumentKey)
+}
+
+func (suite *WatchTestSuite) TestResumeFailsWhenHistoryIsLost() {
+	previous := changeLogSize
+	changeLogSize = 2
+	defer func() { changeLogSize = previous }()
+
+	for _, id := range []string{"o1", "o2", "o3", "o4", "o5"} {
+		suite.Require().NoError(suite.collection.InsertOne(Document{"_id": id}))
+	}
+
+	_, err := suite.collection.Watch(nil, WithResumeAfter(0))
+	suite.True(errors.Is(err, ErrChangeStreamHistoryLost))
+	_, err = suite.collection.Watch(nil, WithResumeAfter(100))
+	suite.True(errors.Is(err, ErrChangeStreamHistoryLost))
+
+	stream, err := suite.collection.Watch(nil, WithResumeAfter(4))
+	suite.Require().NoError(err)
+	suite.Equal("o5", suite.next(stream).DocumentKey)
+}
+
+func (suite *WatchTestSuite) TestSlowConsumerIsDisconnected() {
+	previous := watchBufferSize
+	watchBufferSize = 1
+	defer func() { watchBufferSize = previous }()
+
+	stream, err := suite.collection.Watch(nil)
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(suite.collection.InsertOne(Document{"_id": "o1"}))
+	suite.Require().NoError(suite.collection.InsertOne(Document{"_id": "o2"}))
+
+	event := suite.next(stream)
+	_, open := <-stream.Events()
+	suite.False(open)
+	suite.ErrorIs(stream.Err(), ErrChangeStreamLagging)
+
+	resumed, err := suite.collection.Watch(nil, WithResumeAfter(event.Token))
+	suite.Require().NoError(err)
+	suite.Equal("o2", suite.next(resumed).DocumentKey)
+}
+
+func (suite *WatchTestSuite) TestUpdateManyDeleteAllAndTransactions() {
+	suite.Require().NoError(suite.collection.InsertOne(Document{"_id": "o1", "stage": "received"}))
+	stream, err := suite.collection.Watch(nil)
+	suite.Require().NoError(err)
+	defer stream.Close()
+
+	_, err = suite.collection.UpdateMany(nil, Document{"$set": map[string]interface{}{"stage": "dispatched"}})
+	suite.Require().NoError(err)
+	suite.Equal(OperationUpdate, suite.next(stream).OperationType)
+
+	err = suite.db.StartSession().WithTransaction(func(tx *Transaction) error {
+		return tx.InsertOne("orders", Document{"_id": "o2"})
+	})
+	suite.Require().NoError(err)
+	suite.Equal("o2", suite.next(stream).DocumentKey)
+
+	suite.Require().NoError(suite.collection.DeleteAll())
+	deleted := map[string]bool{}
+	for i := 0; i < 2; i++ {
+		event := suite.next(stream)
+		suite.Equal(OperationDelete, event.OperationType)
+		deleted[event.DocumentKey] = true
+	}
+	suite.Equal(map[string]bool{"o1": true, "o2": true}, deleted)
+}
+
+func (suite *WatchTestSuite) TestDropCollectionInvalidatesStreams() {
+	stream, err := suite.collection.Watch(nil)
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(suite.db.DropCollection("orders"))
+
+	_, open := <-stream.Events()
+	suite.False(open)
+	suite.ErrorIs(stream.Err(), ErrChangeStreamInvalidated)
+	stream.Close()
+}
+
+func (suite *WatchTestSuite) TestCloseStopsDelivery() {
+	stream, err := suite.collection.Watch(nil)
+	suite.Require().NoError(err)
+	stream.Close()
+	stream.Close()
+
+	suite.Require().NoError(suite.collection.InsertOne(Document{"_id": "o1"}))
+	_, open := <-stream.Events()
+	suite.False(open)
+	suite.NoError(stream.Err())
+}
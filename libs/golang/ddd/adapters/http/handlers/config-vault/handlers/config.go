@@ -5,13 +5,16 @@ import (
 	"libs/golang/ddd/domain/entities/config-vault/entity"
 	inputdto "libs/golang/ddd/dtos/config-vault/input"
 	"libs/golang/ddd/usecases/config-vault/usecase"
+	"libs/golang/shared/go-problem/problem"
+	"libs/golang/shared/go-validator/validator"
 	typetools "libs/golang/shared/type-tools"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
 )
 
-// WebConfigHandler handles HTTP requests for configuration operations.
+// WebConfigHandler handles HTTP requests for configuration operations. Failed requests are answered
+// with RFC 7807 problem details, whose status and code are chosen by problems.
 type WebConfigHandler struct {
 	ConfigRepository entity.ConfigRepositoryInterface
 }
@@ -45,26 +48,28 @@ func NewWebConfigHandler(
 //
 //	None.
 //
-// If the request body cannot be decoded, it responds with HTTP status 400 (Bad Request).
-// If an error occurs during the creation process, it responds with HTTP status 500 (Internal Server Error).
+// If the request body cannot be decoded, it responds with HTTP status 400 (Bad Request), and if fields of the body are
+// missing or invalid, with HTTP status 422 (Unprocessable Entity) listing every invalid field.
+// If the configuration breaks a rule of the domain, such as dependencies forming a cycle, it responds with HTTP status
+// 422 (Unprocessable Entity), if it already exists, with HTTP status 409 (Conflict), and if another error occurs during
+// the creation process, with HTTP status 500 (Internal Server Error).
 func (h *WebConfigHandler) CreateConfig(w http.ResponseWriter, r *http.Request) {
 	var dto inputdto.ConfigDTO
-	err := json.NewDecoder(r.Body).Decode(&dto)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	if err := validator.Decode(w, r, &dto); err != nil {
+		problems.Write(w, r, err)
 		return
 	}
 
 	createConfigUseCase := usecase.NewCreateConfigUseCase(h.ConfigRepository)
 	configCreated, err := createConfigUseCase.Execute(dto)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problems.Write(w, r, err)
 		return
 	}
 
 	err = json.NewEncoder(w).Encode(configCreated)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problems.Write(w, r, err)
 		return
 	}
 }
@@ -81,26 +86,28 @@ func (h *WebConfigHandler) CreateConfig(w http.ResponseWriter, r *http.Request)
 //
 //	None.
 //
-// If the request body cannot be decoded, it responds with HTTP status 400 (Bad Request).
-// If an error occurs during the update process, it responds with HTTP status 500 (Internal Server Error).
+// If the request body cannot be decoded, it responds with HTTP status 400 (Bad Request), and if fields of the body are
+// missing or invalid, with HTTP status 422 (Unprocessable Entity) listing every invalid field.
+// If the configuration breaks a rule of the domain, such as dependencies forming a cycle, it responds with HTTP status
+// 422 (Unprocessable Entity), if it does not exist, with HTTP status 404 (Not Found), and if another error occurs during
+// the update process, with HTTP status 500 (Internal Server Error).
 func (h *WebConfigHandler) UpdateConfig(w http.ResponseWriter, r *http.Request) {
 	var dto inputdto.ConfigDTO
-	err := json.NewDecoder(r.Body).Decode(&dto)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	if err := validator.Decode(w, r, &dto); err != nil {
+		problems.Write(w, r, err)
 		return
 	}
 
 	updateConfigUseCase := usecase.NewUpdateConfigUseCase(h.ConfigRepository)
 	configUpdated, err := updateConfigUseCase.Execute(dto)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problems.Write(w, r, err)
 		return
 	}
 
 	err = json.NewEncoder(w).Encode(configUpdated)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problems.Write(w, r, err)
 		return
 	}
 }
@@ -117,18 +124,19 @@ func (h *WebConfigHandler) UpdateConfig(w http.ResponseWriter, r *http.Request)
 //
 //	None.
 //
-// If the ID is not provided or an error occurs during the deletion process, it responds with the appropriate HTTP status code.
+// If the ID is not provided or an error occurs during the deletion process, it responds with the appropriate HTTP status code,
+// 404 (Not Found) when the configuration does not exist.
 func (h *WebConfigHandler) DeleteConfig(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 	if id == "" {
-		http.Error(w, "ID is required", http.StatusBadRequest)
+		problem.Write(w, r, problem.ErrInvalidRequest.WithDetail("ID is required"))
 		return
 	}
 
 	deleteConfigUseCase := usecase.NewDeleteConfigUseCase(h.ConfigRepository)
 	err := deleteConfigUseCase.Execute(id)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problems.Write(w, r, err)
 		return
 	}
 
@@ -136,8 +144,10 @@ func (h *WebConfigHandler) DeleteConfig(w http.ResponseWriter, r *http.Request)
 	w.Write([]byte("Config deleted successfully"))
 }
 
-// ListAllConfigs handles HTTP GET requests to list all configurations. It executes the ListAllConfigUseCase and writes
-// the list of configurations as a JSON response.
+// ListAllConfigs handles HTTP GET requests to list the configs selected by the query parameters, such as
+// `GET /config?provider=acme&active=true`. Every parameter is optional: provider, service, source, active,
+// created_after and created_before, the times being RFC 3339 timestamps. The limit, cursor and sort parameters
+// select the page, and the page is written as a JSON response with the cursor of the next page.
 //
 // Parameters:
 //
@@ -148,22 +158,56 @@ func (h *WebConfigHandler) DeleteConfig(w http.ResponseWriter, r *http.Request)
 //
 //	None.
 //
-// If an error occurs during the listing process, it responds with HTTP status 500 (Internal Server Error).
+// If a query parameter is invalid, it responds with HTTP status 400 (Bad Request), and if an error occurs during the
+// listing process, with HTTP status 500 (Internal Server Error).
 func (h *WebConfigHandler) ListAllConfigs(w http.ResponseWriter, r *http.Request) {
-	listConfigsUseCase := usecase.NewListAllConfigUseCase(h.ConfigRepository)
-	configs, err := listConfigsUseCase.Execute()
+	filterDTO, err := inputdto.NewConfigFilterDTO(r.URL.Query())
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problem.Write(w, r, problem.ErrInvalidRequest.WithDetail(err.Error()))
 		return
 	}
+	h.listConfigs(w, r, filterDTO)
+}
 
-	err = json.NewEncoder(w).Encode(configs)
+// listConfigs lists a page of the configs selected by the filter and writes it as a JSON response.
+//
+// Parameters:
+//
+//	w: The HTTP response writer.
+//	filterDTO: The filter selecting the configs and the page.
+func (h *WebConfigHandler) listConfigs(w http.ResponseWriter, r *http.Request, filterDTO inputdto.ConfigFilterDTO) {
+	listPageByFilterConfigUseCase := usecase.NewListPageByFilterConfigUseCase(h.ConfigRepository)
+	page, err := listPageByFilterConfigUseCase.Execute(filterDTO)
+	if err != nil {
+		problems.Write(w, r, err)
+		return
+	}
+
+	err = json.NewEncoder(w).Encode(page)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problems.Write(w, r, err)
 		return
 	}
 }
 
+// listConfigsByPath lists the configs of the routes filtering on path parameters, which are aliases of ListAllConfigs. The
+// filter built from the path replaces the filter of the query parameters, which still select the page.
+//
+// Parameters:
+//
+//	w: The HTTP response writer.
+//	r: The HTTP request.
+//	pathFilterDTO: The filter built from the path parameters.
+func (h *WebConfigHandler) listConfigsByPath(w http.ResponseWriter, r *http.Request, pathFilterDTO inputdto.ConfigFilterDTO) {
+	filterDTO, err := inputdto.NewConfigFilterDTO(r.URL.Query())
+	if err != nil {
+		problem.Write(w, r, problem.ErrInvalidRequest.WithDetail(err.Error()))
+		return
+	}
+	pathFilterDTO.Limit, pathFilterDTO.Cursor, pathFilterDTO.Sort = filterDTO.Limit, filterDTO.Cursor, filterDTO.Sort
+	h.listConfigs(w, r, pathFilterDTO)
+}
+
 // ListConfigByID handles HTTP GET requests to list a configuration by its ID. It extracts the ID from the query parameters,
 // executes the ListOneByIDConfigUseCase, and writes the configuration as a JSON response.
 //
@@ -176,24 +220,25 @@ func (h *WebConfigHandler) ListAllConfigs(w http.ResponseWriter, r *http.Request
 //
 //	None.
 //
-// If the ID is not provided or an error occurs during the listing process, it responds with the appropriate HTTP status code.
+// If the ID is not provided or an error occurs during the listing process, it responds with the appropriate HTTP status code,
+// 404 (Not Found) when the configuration does not exist.
 func (h *WebConfigHandler) ListConfigByID(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 	if id == "" {
-		http.Error(w, "ID is required", http.StatusBadRequest)
+		problem.Write(w, r, problem.ErrInvalidRequest.WithDetail("ID is required"))
 		return
 	}
 
 	getConfigUseCase := usecase.NewListOneByIDConfigUseCase(h.ConfigRepository)
 	config, err := getConfigUseCase.Execute(id)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problems.Write(w, r, err)
 		return
 	}
 
 	err = json.NewEncoder(w).Encode(config)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problems.Write(w, r, err)
 		return
 	}
 }
@@ -216,22 +261,11 @@ func (h *WebConfigHandler) ListConfigsByServiceAndProvider(w http.ResponseWriter
 	provider := chi.URLParam(r, "provider")
 	service := chi.URLParam(r, "service")
 	if service == "" || provider == "" {
-		http.Error(w, "Service and provider are required", http.StatusBadRequest)
-		return
-	}
-
-	listConfigsUseCase := usecase.NewListAllByServiceAndProviderConfigUseCase(h.ConfigRepository)
-	configs, err := listConfigsUseCase.Execute(provider, service)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problem.Write(w, r, problem.ErrInvalidRequest.WithDetail("Service and provider are required"))
 		return
 	}
 
-	err = json.NewEncoder(w).Encode(configs)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
+	h.listConfigsByPath(w, r, inputdto.ConfigFilterDTO{Provider: provider, Service: service})
 }
 
 // ListConfigsBySourceAndProvider handles HTTP GET requests to list configurations by source and provider.
@@ -252,22 +286,11 @@ func (h *WebConfigHandler) ListConfigsBySourceAndProvider(w http.ResponseWriter,
 	provider := chi.URLParam(r, "provider")
 	source := chi.URLParam(r, "source")
 	if source == "" || provider == "" {
-		http.Error(w, "Source and provider are required", http.StatusBadRequest)
+		problem.Write(w, r, problem.ErrInvalidRequest.WithDetail("Source and provider are required"))
 		return
 	}
 
-	listConfigsUseCase := usecase.NewListAllBySourceAndProviderConfigUseCase(h.ConfigRepository)
-	configs, err := listConfigsUseCase.Execute(provider, source)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-
-	err = json.NewEncoder(w).Encode(configs)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
+	h.listConfigsByPath(w, r, inputdto.ConfigFilterDTO{Provider: provider, Source: source})
 }
 
 // ListConfigsByServiceAndSourceAndProvider handles HTTP GET requests to list configurations by service, source, and provider.
@@ -290,22 +313,11 @@ func (h *WebConfigHandler) ListConfigsByServiceAndSourceAndProvider(w http.Respo
 	source := chi.URLParam(r, "source")
 
 	if service == "" || source == "" || provider == "" {
-		http.Error(w, "Service, source, and provider are required", http.StatusBadRequest)
+		problem.Write(w, r, problem.ErrInvalidRequest.WithDetail("Service, source, and provider are required"))
 		return
 	}
 
-	listConfigsUseCase := usecase.NewListAllByServiceAndSourceAndProviderConfigUseCase(h.ConfigRepository)
-	configs, err := listConfigsUseCase.Execute(service, source, provider)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-
-	err = json.NewEncoder(w).Encode(configs)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
+	h.listConfigsByPath(w, r, inputdto.ConfigFilterDTO{Provider: provider, Service: service, Source: source})
 }
 
 // ListConfigsByServiceAndProviderAndActive handles HTTP GET requests to list configurations by service, provider, and active status.
@@ -328,28 +340,17 @@ func (h *WebConfigHandler) ListConfigsByServiceAndProviderAndActive(w http.Respo
 	active := chi.URLParam(r, "active")
 
 	if service == "" || provider == "" || active == "" {
-		http.Error(w, "Service, provider, and active status are required", http.StatusBadRequest)
+		problem.Write(w, r, problem.ErrInvalidRequest.WithDetail("Service, provider, and active status are required"))
 		return
 	}
 
 	activeBool, err := typetools.ParseBool(active)
 	if err != nil {
-		http.Error(w, "Invalid active status value", http.StatusBadRequest)
+		problem.Write(w, r, problem.ErrInvalidRequest.WithDetail("Invalid active status value"))
 		return
 	}
 
-	listConfigsUseCase := usecase.NewListAllByServiceAndProviderAndActiveConfigUseCase(h.ConfigRepository)
-	configs, err := listConfigsUseCase.Execute(service, provider, activeBool)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-
-	err = json.NewEncoder(w).Encode(configs)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
+	h.listConfigsByPath(w, r, inputdto.ConfigFilterDTO{Provider: provider, Service: service, Active: &activeBool})
 }
 
 // ListConfigsByProviderAndDependencies handles HTTP GET requests to list configurations by their dependencies.
@@ -372,20 +373,54 @@ func (h *WebConfigHandler) ListConfigsByProviderAndDependencies(w http.ResponseW
 	source := chi.URLParam(r, "source")
 
 	if provider == "" && service == "" && source == "" {
-		http.Error(w, "At least one dependency is required", http.StatusBadRequest)
+		problem.Write(w, r, problem.ErrInvalidRequest.WithDetail("At least one dependency is required"))
 		return
 	}
 
 	listConfigsUseCase := usecase.NewListAllByProviderAndDependsOnConfigUseCase(h.ConfigRepository)
 	configs, err := listConfigsUseCase.Execute(provider, service, source)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problems.Write(w, r, err)
 		return
 	}
 
 	err = json.NewEncoder(w).Encode(configs)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problems.Write(w, r, err)
+		return
+	}
+}
+
+// GetConfigGraph handles HTTP GET requests to get the dependency graph of the configurations of a provider.
+// It extracts the provider from the URL parameters, executes the GetConfigGraphUseCase, and writes the graph as a JSON response.
+//
+// Parameters:
+//
+//	w: The HTTP response writer.
+//	r: The HTTP request.
+//
+// Returns:
+//
+//	None.
+//
+// If the provider is not provided or an error occurs while building the graph, it responds with the appropriate HTTP status code.
+func (h *WebConfigHandler) GetConfigGraph(w http.ResponseWriter, r *http.Request) {
+	provider := chi.URLParam(r, "provider")
+	if provider == "" {
+		problem.Write(w, r, problem.ErrInvalidRequest.WithDetail("Provider is required"))
+		return
+	}
+
+	getConfigGraphUseCase := usecase.NewGetConfigGraphUseCase(h.ConfigRepository)
+	graph, err := getConfigGraphUseCase.Execute(provider)
+	if err != nil {
+		problems.Write(w, r, err)
+		return
+	}
+
+	err = json.NewEncoder(w).Encode(graph)
+	if err != nil {
+		problems.Write(w, r, err)
 		return
 	}
 }
@@ -5,11 +5,15 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"libs/golang/ddd/domain/entities/config-vault/entity"
 	mockrepository "libs/golang/ddd/domain/repositories/database/mock/config-vault/repository"
 	inputdto "libs/golang/ddd/dtos/config-vault/input"
 	outputdto "libs/golang/ddd/dtos/config-vault/output"
 	shareddto "libs/golang/ddd/dtos/config-vault/shared"
+	"libs/golang/server/http/openapi/openapitest"
+	"libs/golang/shared/go-criteria/criteria"
+	"libs/golang/shared/go-problem/problem"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -20,6 +24,17 @@ import (
 	"github.com/stretchr/testify/suite"
 )
 
+// firstPage is the page read when the request does not set the limit, cursor and sort parameters.
+var firstPage = criteria.Page{Sort: criteria.NewSort("created_at")}
+
+// decodeProblem reads the problem details written in the response.
+func decodeProblem(t *testing.T, rr *httptest.ResponseRecorder) problem.Problem {
+	assert.Equal(t, problem.ContentType, rr.Header().Get("Content-Type"))
+	var p problem.Problem
+	assert.NoError(t, json.NewDecoder(rr.Body).Decode(&p))
+	return p
+}
+
 type WebConfigHandlerSuite struct {
 	suite.Suite
 	handler  *WebConfigHandler
@@ -33,6 +48,7 @@ func TestWebConfigHandlerSuite(t *testing.T) {
 func (suite *WebConfigHandlerSuite) SetupTest() {
 	suite.repoMock = new(mockrepository.ConfigRepositoryMock)
 	suite.handler = NewWebConfigHandler(suite.repoMock)
+	suite.repoMock.On("FindAllByFilter", entity.ConfigFilter{Provider: "test_provider"}).Return([]*entity.Config{}, nil).Maybe()
 }
 
 // Tests for CreateConfig handler
@@ -97,6 +113,23 @@ func (suite *WebConfigHandlerSuite) TestCreateConfigWhenDecodingFails() {
 	assert.Contains(suite.T(), rr.Body.String(), "invalid character")
 }
 
+func (suite *WebConfigHandlerSuite) TestCreateConfigWhenInvalid() {
+	req := httptest.NewRequest(http.MethodPost, "/configs", bytes.NewBuffer([]byte(`{"provider": "test_provider", "source": "test source", "depends_on": [{"service": "dep_service"}]}`)))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+
+	suite.handler.CreateConfig(rr, req)
+
+	assert.Equal(suite.T(), http.StatusUnprocessableEntity, rr.Code)
+	problemDetails := decodeProblem(suite.T(), rr)
+	assert.Equal(suite.T(), problem.CodeValidationFailed, problemDetails.Code)
+	assert.Equal(suite.T(), []problem.FieldError{
+		{Field: "service", Message: "is required"},
+		{Field: "source", Message: "must only contain letters, digits, '_' and '-'"},
+		{Field: "depends_on[0].source", Message: "is required"},
+	}, problemDetails.Errors)
+}
+
 func (suite *WebConfigHandlerSuite) TestCreateConfigWhenRepositoryFails() {
 	inputDTO := inputdto.ConfigDTO{
 		Active:   true,
@@ -122,6 +155,57 @@ func (suite *WebConfigHandlerSuite) TestCreateConfigWhenRepositoryFails() {
 	suite.repoMock.AssertExpectations(suite.T())
 }
 
+func (suite *WebConfigHandlerSuite) TestCreateConfigWhenAlreadyExists() {
+	inputDTO := inputdto.ConfigDTO{
+		Active:   true,
+		Service:  "test_service",
+		Source:   "test_source",
+		Provider: "test_provider",
+		DependsOn: []shareddto.JobDependenciesDTO{
+			{Service: "dep_service", Source: "dep_source"},
+		},
+	}
+
+	suite.repoMock.On("Create", mock.AnythingOfType("*entity.Config")).Return(fmt.Errorf("%w: ID 1", entity.ErrAlreadyExists))
+
+	jsonBody, _ := json.Marshal(inputDTO)
+	req := httptest.NewRequest(http.MethodPost, "/configs", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+
+	suite.handler.CreateConfig(rr, req)
+
+	assert.Equal(suite.T(), http.StatusConflict, rr.Code)
+	assert.Equal(suite.T(), problem.CodeAlreadyExists, decodeProblem(suite.T(), rr).Code)
+	suite.repoMock.AssertExpectations(suite.T())
+}
+
+func (suite *WebConfigHandlerSuite) TestCreateConfigWhenDependencyCycle() {
+	inputDTO := inputdto.ConfigDTO{
+		Active:   true,
+		Service:  "test_service",
+		Source:   "test_source",
+		Provider: "test_provider",
+		JobParameters: shareddto.JobParametersDTO{
+			ParserModule: "test_parser_module",
+		},
+		DependsOn: []shareddto.JobDependenciesDTO{
+			{Service: "test_service", Source: "test_source"},
+		},
+	}
+
+	jsonBody, _ := json.Marshal(inputDTO)
+	req := httptest.NewRequest(http.MethodPost, "/configs", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+
+	suite.handler.CreateConfig(rr, req)
+
+	assert.Equal(suite.T(), http.StatusUnprocessableEntity, rr.Code)
+	assert.Contains(suite.T(), rr.Body.String(), "dependency cycle")
+	suite.repoMock.AssertNotCalled(suite.T(), "Create", mock.Anything)
+}
+
 // Tests for UpdateConfig handler
 func (suite *WebConfigHandlerSuite) TestUpdateConfigWhenSuccess() {
 	inputDTO := inputdto.ConfigDTO{
@@ -254,6 +338,22 @@ func (suite *WebConfigHandlerSuite) TestDeleteConfigWhenRepositoryFails() {
 	suite.repoMock.AssertExpectations(suite.T())
 }
 
+func (suite *WebConfigHandlerSuite) TestDeleteConfigWhenNotFound() {
+	suite.repoMock.On("Delete", "1").Return(fmt.Errorf("%w: ID 1", entity.ErrNotFound))
+
+	req := httptest.NewRequest("DELETE", "/configs/1", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "1")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	rr := httptest.NewRecorder()
+
+	suite.handler.DeleteConfig(rr, req)
+
+	assert.Equal(suite.T(), http.StatusNotFound, rr.Code)
+	assert.Equal(suite.T(), problem.CodeNotFound, decodeProblem(suite.T(), rr).Code)
+	suite.repoMock.AssertExpectations(suite.T())
+}
+
 // Tests for ListAllConfigs handler
 func (suite *WebConfigHandlerSuite) TestListAllConfigsWhenSuccess() {
 	expectedOutput := []outputdto.ConfigDTO{
@@ -281,7 +381,7 @@ func (suite *WebConfigHandlerSuite) TestListAllConfigsWhenSuccess() {
 		},
 	}
 
-	suite.repoMock.On("FindAll").Return([]*entity.Config{
+	suite.repoMock.On("FindPageByFilter", entity.ConfigFilter{}, firstPage).Return([]*entity.Config{
 		{
 			ID:              "1",
 			Active:          true,
@@ -302,7 +402,7 @@ func (suite *WebConfigHandlerSuite) TestListAllConfigsWhenSuccess() {
 			CreatedAt:       "2023-06-02T00:00:00Z",
 			UpdatedAt:       "2023-06-02T00:00:00Z",
 		},
-	}, nil)
+	}, "", nil)
 
 	req := httptest.NewRequest("GET", "/configs", nil)
 	rr := httptest.NewRecorder()
@@ -311,16 +411,16 @@ func (suite *WebConfigHandlerSuite) TestListAllConfigsWhenSuccess() {
 
 	assert.Equal(suite.T(), http.StatusOK, rr.Code)
 
-	var actualOutput []outputdto.ConfigDTO
+	var actualOutput outputdto.ConfigPageDTO
 	err := json.NewDecoder(rr.Body).Decode(&actualOutput)
 	assert.NoError(suite.T(), err)
 
-	assert.Equal(suite.T(), expectedOutput, actualOutput)
+	assert.Equal(suite.T(), expectedOutput, actualOutput.Items)
 	suite.repoMock.AssertExpectations(suite.T())
 }
 
 func (suite *WebConfigHandlerSuite) TestListAllConfigsWhenRepositoryFails() {
-	suite.repoMock.On("FindAll").Return(nil, errors.New("repository error"))
+	suite.repoMock.On("FindPageByFilter", entity.ConfigFilter{}, firstPage).Return(nil, "", errors.New("repository error"))
 
 	req := httptest.NewRequest("GET", "/configs", nil)
 	rr := httptest.NewRecorder()
@@ -332,6 +432,69 @@ func (suite *WebConfigHandlerSuite) TestListAllConfigsWhenRepositoryFails() {
 	suite.repoMock.AssertExpectations(suite.T())
 }
 
+func (suite *WebConfigHandlerSuite) TestListAllConfigsWithPage() {
+	page := criteria.Page{Limit: 2, Cursor: "cursor", Sort: criteria.NewSort("-updated_at")}
+	suite.repoMock.On("FindPageByFilter", entity.ConfigFilter{}, page).Return([]*entity.Config{}, "next", nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/config?limit=2&cursor=cursor&sort=-updated_at", nil)
+	rr := httptest.NewRecorder()
+
+	suite.handler.ListAllConfigs(rr, req)
+
+	assert.Equal(suite.T(), http.StatusOK, rr.Code)
+	var actualPage outputdto.ConfigPageDTO
+	assert.NoError(suite.T(), json.NewDecoder(rr.Body).Decode(&actualPage))
+	assert.Equal(suite.T(), outputdto.ConfigPageDTO{Items: []outputdto.ConfigDTO{}, NextCursor: "next"}, actualPage)
+	suite.repoMock.AssertExpectations(suite.T())
+}
+
+func (suite *WebConfigHandlerSuite) TestListAllConfigsWhenCursorIsInvalid() {
+	suite.repoMock.On("FindPageByFilter", entity.ConfigFilter{}, criteria.Page{Cursor: "invalid", Sort: criteria.NewSort("created_at")}).
+		Return(nil, "", criteria.ErrInvalidCursor)
+
+	req := httptest.NewRequest(http.MethodGet, "/config?cursor=invalid", nil)
+	rr := httptest.NewRecorder()
+
+	suite.handler.ListAllConfigs(rr, req)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, rr.Code)
+	assert.Equal(suite.T(), problem.CodeInvalidCursor, decodeProblem(suite.T(), rr).Code)
+	suite.repoMock.AssertExpectations(suite.T())
+}
+
+func (suite *WebConfigHandlerSuite) TestListAllConfigsWhenLimitIsInvalid() {
+	req := httptest.NewRequest(http.MethodGet, "/config?limit=0", nil)
+	rr := httptest.NewRecorder()
+
+	suite.handler.ListAllConfigs(rr, req)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, rr.Code)
+	suite.repoMock.AssertNotCalled(suite.T(), "FindPageByFilter", mock.Anything, mock.Anything)
+}
+
+func (suite *WebConfigHandlerSuite) TestListAllConfigsWithQuery() {
+	active := false
+	suite.repoMock.On("FindPageByFilter", entity.ConfigFilter{Provider: "test_provider", Active: &active}, firstPage).Return([]*entity.Config{}, "", nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/config?provider=test_provider&active=false", nil)
+	rr := httptest.NewRecorder()
+
+	suite.handler.ListAllConfigs(rr, req)
+
+	assert.Equal(suite.T(), http.StatusOK, rr.Code)
+	suite.repoMock.AssertExpectations(suite.T())
+}
+
+func (suite *WebConfigHandlerSuite) TestListAllConfigsWhenQueryIsInvalid() {
+	req := httptest.NewRequest(http.MethodGet, "/config?active=maybe", nil)
+	rr := httptest.NewRecorder()
+
+	suite.handler.ListAllConfigs(rr, req)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, rr.Code)
+	suite.repoMock.AssertNotCalled(suite.T(), "FindPageByFilter", mock.Anything, mock.Anything)
+}
+
 // Tests for ListConfigByID handler
 func (suite *WebConfigHandlerSuite) TestListConfigByIDWhenSuccess() {
 	expectedOutput := outputdto.ConfigDTO{
@@ -403,6 +566,22 @@ func (suite *WebConfigHandlerSuite) TestListConfigByIDWhenRepositoryFails() {
 	suite.repoMock.AssertExpectations(suite.T())
 }
 
+func (suite *WebConfigHandlerSuite) TestListConfigByIDWhenNotFound() {
+	suite.repoMock.On("FindByID", "1").Return(nil, fmt.Errorf("%w: ID 1", entity.ErrNotFound))
+
+	req := httptest.NewRequest("GET", "/config/1", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "1")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	rr := httptest.NewRecorder()
+
+	suite.handler.ListConfigByID(rr, req)
+
+	assert.Equal(suite.T(), http.StatusNotFound, rr.Code)
+	assert.Equal(suite.T(), problem.CodeNotFound, decodeProblem(suite.T(), rr).Code)
+	suite.repoMock.AssertExpectations(suite.T())
+}
+
 // Tests for ListConfigsByServiceAndProvider handler
 func (suite *WebConfigHandlerSuite) TestListConfigsByServiceAndProviderWhenSuccess() {
 	expectedOutput := []outputdto.ConfigDTO{
@@ -419,7 +598,7 @@ func (suite *WebConfigHandlerSuite) TestListConfigsByServiceAndProviderWhenSucce
 		},
 	}
 
-	suite.repoMock.On("FindAllByServiceAndProvider", "test_provider", "test_service").Return([]*entity.Config{
+	suite.repoMock.On("FindPageByFilter", entity.ConfigFilter{Provider: "test_provider", Service: "test_service"}, firstPage).Return([]*entity.Config{
 		{
 			ID:              "1",
 			Active:          true,
@@ -430,7 +609,7 @@ func (suite *WebConfigHandlerSuite) TestListConfigsByServiceAndProviderWhenSucce
 			CreatedAt:       "2023-06-01T00:00:00Z",
 			UpdatedAt:       "2023-06-01T00:00:00Z",
 		},
-	}, nil)
+	}, "", nil)
 
 	req := httptest.NewRequest("GET", "/configs/service/test_service/provider/test_provider", nil)
 	rctx := chi.NewRouteContext()
@@ -443,11 +622,11 @@ func (suite *WebConfigHandlerSuite) TestListConfigsByServiceAndProviderWhenSucce
 
 	assert.Equal(suite.T(), http.StatusOK, rr.Code)
 
-	var actualOutput []outputdto.ConfigDTO
+	var actualOutput outputdto.ConfigPageDTO
 	err := json.NewDecoder(rr.Body).Decode(&actualOutput)
 	assert.NoError(suite.T(), err)
 
-	assert.Equal(suite.T(), expectedOutput, actualOutput)
+	assert.Equal(suite.T(), expectedOutput, actualOutput.Items)
 	suite.repoMock.AssertExpectations(suite.T())
 }
 
@@ -465,7 +644,7 @@ func (suite *WebConfigHandlerSuite) TestListConfigsByServiceAndProviderWhenServi
 }
 
 func (suite *WebConfigHandlerSuite) TestListConfigsByServiceAndProviderWhenRepositoryFails() {
-	suite.repoMock.On("FindAllByServiceAndProvider", "test_provider", "test_service").Return(nil, errors.New("repository error"))
+	suite.repoMock.On("FindPageByFilter", entity.ConfigFilter{Provider: "test_provider", Service: "test_service"}, firstPage).Return(nil, "", errors.New("repository error"))
 
 	req := httptest.NewRequest("GET", "/configs/service/test_service/provider/test_provider", nil)
 	rctx := chi.NewRouteContext()
@@ -497,7 +676,7 @@ func (suite *WebConfigHandlerSuite) TestListConfigsBySourceAndProviderWhenSucces
 		},
 	}
 
-	suite.repoMock.On("FindAllBySourceAndProvider", "test_provider", "test_source").Return([]*entity.Config{
+	suite.repoMock.On("FindPageByFilter", entity.ConfigFilter{Provider: "test_provider", Source: "test_source"}, firstPage).Return([]*entity.Config{
 		{
 			ID:              "1",
 			Active:          true,
@@ -508,7 +687,7 @@ func (suite *WebConfigHandlerSuite) TestListConfigsBySourceAndProviderWhenSucces
 			CreatedAt:       "2023-06-01T00:00:00Z",
 			UpdatedAt:       "2023-06-01T00:00:00Z",
 		},
-	}, nil)
+	}, "", nil)
 
 	req := httptest.NewRequest("GET", "/configs/source/test_source/provider/test_provider", nil)
 	rctx := chi.NewRouteContext()
@@ -521,11 +700,11 @@ func (suite *WebConfigHandlerSuite) TestListConfigsBySourceAndProviderWhenSucces
 
 	assert.Equal(suite.T(), http.StatusOK, rr.Code)
 
-	var actualOutput []outputdto.ConfigDTO
+	var actualOutput outputdto.ConfigPageDTO
 	err := json.NewDecoder(rr.Body).Decode(&actualOutput)
 	assert.NoError(suite.T(), err)
 
-	assert.Equal(suite.T(), expectedOutput, actualOutput)
+	assert.Equal(suite.T(), expectedOutput, actualOutput.Items)
 	suite.repoMock.AssertExpectations(suite.T())
 }
 
@@ -543,7 +722,7 @@ func (suite *WebConfigHandlerSuite) TestListConfigsBySourceAndProviderWhenSource
 }
 
 func (suite *WebConfigHandlerSuite) TestListConfigsBySourceAndProviderWhenRepositoryFails() {
-	suite.repoMock.On("FindAllBySourceAndProvider", "test_provider", "test_source").Return(nil, errors.New("repository error"))
+	suite.repoMock.On("FindPageByFilter", entity.ConfigFilter{Provider: "test_provider", Source: "test_source"}, firstPage).Return(nil, "", errors.New("repository error"))
 
 	req := httptest.NewRequest("GET", "/configs/source/test_source/provider/test_provider", nil)
 	rctx := chi.NewRouteContext()
@@ -575,7 +754,7 @@ func (suite *WebConfigHandlerSuite) TestListConfigsByServiceAndSourceAndProvider
 		},
 	}
 
-	suite.repoMock.On("FindAllByServiceAndSourceAndProvider", "test_service", "test_source", "test_provider").Return([]*entity.Config{
+	suite.repoMock.On("FindPageByFilter", entity.ConfigFilter{Provider: "test_provider", Service: "test_service", Source: "test_source"}, firstPage).Return([]*entity.Config{
 		{
 			ID:              "1",
 			Active:          true,
@@ -586,7 +765,7 @@ func (suite *WebConfigHandlerSuite) TestListConfigsByServiceAndSourceAndProvider
 			CreatedAt:       "2023-06-01T00:00:00Z",
 			UpdatedAt:       "2023-06-01T00:00:00Z",
 		},
-	}, nil)
+	}, "", nil)
 
 	req := httptest.NewRequest("GET", "/configs/service/test_service/source/test_source/provider/test_provider", nil)
 	rctx := chi.NewRouteContext()
@@ -600,11 +779,11 @@ func (suite *WebConfigHandlerSuite) TestListConfigsByServiceAndSourceAndProvider
 
 	assert.Equal(suite.T(), http.StatusOK, rr.Code)
 
-	var actualOutput []outputdto.ConfigDTO
+	var actualOutput outputdto.ConfigPageDTO
 	err := json.NewDecoder(rr.Body).Decode(&actualOutput)
 	assert.NoError(suite.T(), err)
 
-	assert.Equal(suite.T(), expectedOutput, actualOutput)
+	assert.Equal(suite.T(), expectedOutput, actualOutput.Items)
 	suite.repoMock.AssertExpectations(suite.T())
 }
 
@@ -623,7 +802,7 @@ func (suite *WebConfigHandlerSuite) TestListConfigsByServiceAndSourceAndProvider
 }
 
 func (suite *WebConfigHandlerSuite) TestListConfigsByServiceAndSourceAndProviderWhenRepositoryFails() {
-	suite.repoMock.On("FindAllByServiceAndSourceAndProvider", "test_service", "test_source", "test_provider").Return(nil, errors.New("repository error"))
+	suite.repoMock.On("FindPageByFilter", entity.ConfigFilter{Provider: "test_provider", Service: "test_service", Source: "test_source"}, firstPage).Return(nil, "", errors.New("repository error"))
 
 	req := httptest.NewRequest("GET", "/configs/service/test_service/source/test_source/provider/test_provider", nil)
 	rctx := chi.NewRouteContext()
@@ -656,7 +835,8 @@ func (suite *WebConfigHandlerSuite) TestListConfigsByServiceAndProviderAndActive
 		},
 	}
 
-	suite.repoMock.On("FindAllByServiceAndProviderAndActive", "test_service", "test_provider", true).Return([]*entity.Config{
+	active := true
+	suite.repoMock.On("FindPageByFilter", entity.ConfigFilter{Provider: "test_provider", Service: "test_service", Active: &active}, firstPage).Return([]*entity.Config{
 		{
 			ID:              "1",
 			Active:          true,
@@ -667,7 +847,7 @@ func (suite *WebConfigHandlerSuite) TestListConfigsByServiceAndProviderAndActive
 			CreatedAt:       "2023-06-01T00:00:00Z",
 			UpdatedAt:       "2023-06-01T00:00:00Z",
 		},
-	}, nil)
+	}, "", nil)
 
 	req := httptest.NewRequest("GET", "/configs/service/test_service/provider/test_provider/active/true", nil)
 	rctx := chi.NewRouteContext()
@@ -681,11 +861,11 @@ func (suite *WebConfigHandlerSuite) TestListConfigsByServiceAndProviderAndActive
 
 	assert.Equal(suite.T(), http.StatusOK, rr.Code)
 
-	var actualOutput []outputdto.ConfigDTO
+	var actualOutput outputdto.ConfigPageDTO
 	err := json.NewDecoder(rr.Body).Decode(&actualOutput)
 	assert.NoError(suite.T(), err)
 
-	assert.Equal(suite.T(), expectedOutput, actualOutput)
+	assert.Equal(suite.T(), expectedOutput, actualOutput.Items)
 	suite.repoMock.AssertExpectations(suite.T())
 }
 
@@ -719,7 +899,8 @@ func (suite *WebConfigHandlerSuite) TestListConfigsByServiceAndProviderAndActive
 }
 
 func (suite *WebConfigHandlerSuite) TestListConfigsByServiceAndProviderAndActiveWhenRepositoryFails() {
-	suite.repoMock.On("FindAllByServiceAndProviderAndActive", "test_service", "test_provider", true).Return(nil, errors.New("repository error"))
+	active := true
+	suite.repoMock.On("FindPageByFilter", entity.ConfigFilter{Provider: "test_provider", Service: "test_service", Active: &active}, firstPage).Return(nil, "", errors.New("repository error"))
 
 	req := httptest.NewRequest("GET", "/configs/service/test_service/provider/test_provider/active/true", nil)
 	rctx := chi.NewRouteContext()
@@ -832,3 +1013,58 @@ func (suite *WebConfigHandlerSuite) TestListConfigsByProviderAndDependenciesWhen
 	assert.Contains(suite.T(), rr.Body.String(), "repository error")
 	suite.repoMock.AssertExpectations(suite.T())
 }
+
+// Tests for GetConfigGraph handler
+func (suite *WebConfigHandlerSuite) TestGetConfigGraphWhenSuccess() {
+	suite.repoMock.ExpectedCalls = nil
+	suite.repoMock.On("FindAllByFilter", entity.ConfigFilter{Provider: "test_provider"}).Return([]*entity.Config{
+		{
+			ID:        "1",
+			Active:    true,
+			Service:   "test_service",
+			Source:    "test_source",
+			Provider:  "test_provider",
+			DependsOn: []entity.JobDependencies{{Service: "dep_service", Source: "dep_source"}},
+		},
+	}, nil)
+
+	req := httptest.NewRequest("GET", "/config/provider/test_provider/graph", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("provider", "test_provider")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	rr := httptest.NewRecorder()
+
+	suite.handler.GetConfigGraph(rr, req)
+
+	assert.Equal(suite.T(), http.StatusOK, rr.Code)
+
+	var actualOutput outputdto.ConfigGraphDTO
+	err := json.NewDecoder(rr.Body).Decode(&actualOutput)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 2, len(actualOutput.Nodes))
+	assert.Equal(suite.T(), []outputdto.ConfigGraphEdgeDTO{{
+		From: shareddto.JobDependenciesDTO{Service: "dep_service", Source: "dep_source"},
+		To:   shareddto.JobDependenciesDTO{Service: "test_service", Source: "test_source"},
+	}}, actualOutput.Edges)
+	suite.repoMock.AssertExpectations(suite.T())
+}
+
+func (suite *WebConfigHandlerSuite) TestGetConfigGraphWhenRepositoryFails() {
+	suite.repoMock.ExpectedCalls = nil
+	suite.repoMock.On("FindAllByFilter", entity.ConfigFilter{Provider: "test_provider"}).Return(nil, errors.New("repository error"))
+
+	req := httptest.NewRequest("GET", "/config/provider/test_provider/graph", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("provider", "test_provider")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	rr := httptest.NewRecorder()
+
+	suite.handler.GetConfigGraph(rr, req)
+
+	assert.Equal(suite.T(), http.StatusInternalServerError, rr.Code)
+	assert.Contains(suite.T(), rr.Body.String(), "repository error")
+}
+
+func (suite *WebConfigHandlerSuite) TestOperationsDocumentEveryHandler() {
+	openapitest.AssertDocumentsHandlers(suite.T(), &WebConfigHandler{}, Operations)
+}
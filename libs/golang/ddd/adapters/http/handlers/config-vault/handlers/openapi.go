@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	inputdto "libs/golang/ddd/dtos/config-vault/input"
+	outputdto "libs/golang/ddd/dtos/config-vault/output"
+	"libs/golang/server/http/openapi/openapi"
+	"net/http"
+)
+
+// Operations documents the methods of WebConfigHandler in the OpenAPI document of the service registering them, with
+// the statuses of the problems each method may answer.
+var Operations = openapi.Operations{
+	"CreateConfig": {
+		Summary:  "Create a config",
+		Request:  inputdto.ConfigDTO{},
+		Response: outputdto.ConfigDTO{},
+		Errors:   []int{http.StatusBadRequest, http.StatusConflict, http.StatusUnprocessableEntity, http.StatusInternalServerError},
+	},
+	"UpdateConfig": {
+		Summary:  "Update a config",
+		Request:  inputdto.ConfigDTO{},
+		Response: outputdto.ConfigDTO{},
+		Errors:   []int{http.StatusBadRequest, http.StatusNotFound, http.StatusUnprocessableEntity, http.StatusInternalServerError},
+	},
+	"DeleteConfig": {
+		Summary:  "Delete a config",
+		Response: "Config deleted successfully",
+		Errors:   []int{http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+	},
+	"ListAllConfigs": {
+		Summary:     "List the configs selected by the query",
+		Description: "Every filter is optional, and the times are RFC 3339 timestamps. The limit, cursor and sort parameters select the page.",
+		Query:       inputdto.ConfigFilterDTO{},
+		Response:    outputdto.ConfigPageDTO{},
+		Errors:      []int{http.StatusBadRequest, http.StatusInternalServerError},
+	},
+	"ListConfigByID": {
+		Summary:  "Get a config by ID",
+		Response: outputdto.ConfigDTO{},
+		Errors:   []int{http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+	},
+	"ListConfigsByServiceAndProvider": {
+		Summary:  "List the configs of a service",
+		Query:    openapi.PageQuery{},
+		Response: outputdto.ConfigPageDTO{},
+		Errors:   []int{http.StatusBadRequest, http.StatusInternalServerError},
+	},
+	"ListConfigsBySourceAndProvider": {
+		Summary:  "List the configs of a source",
+		Query:    openapi.PageQuery{},
+		Response: outputdto.ConfigPageDTO{},
+		Errors:   []int{http.StatusBadRequest, http.StatusInternalServerError},
+	},
+	"ListConfigsByServiceAndSourceAndProvider": {
+		Summary:  "List the configs of a service and source",
+		Query:    openapi.PageQuery{},
+		Response: outputdto.ConfigPageDTO{},
+		Errors:   []int{http.StatusBadRequest, http.StatusInternalServerError},
+	},
+	"ListConfigsByServiceAndProviderAndActive": {
+		Summary:  "List the active or inactive configs of a service",
+		Query:    openapi.PageQuery{},
+		Response: outputdto.ConfigPageDTO{},
+		Errors:   []int{http.StatusBadRequest, http.StatusInternalServerError},
+	},
+	"ListConfigsByProviderAndDependencies": {
+		Summary:  "List the configs depending on a job",
+		Response: []outputdto.ConfigDTO{},
+		Errors:   []int{http.StatusBadRequest, http.StatusInternalServerError},
+	},
+	"GetConfigGraph": {
+		Summary:     "Get the dependency graph of the configs of a provider",
+		Description: "The graph lists the order the jobs run in, or the cycle preventing it.",
+		Response:    outputdto.ConfigGraphDTO{},
+		Errors:      []int{http.StatusBadRequest, http.StatusInternalServerError},
+	},
+}
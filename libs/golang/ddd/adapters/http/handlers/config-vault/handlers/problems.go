@@ -0,0 +1,24 @@
+package handlers
+
+import (
+	"libs/golang/ddd/domain/entities/config-vault/entity"
+	"libs/golang/shared/go-criteria/criteria"
+	"libs/golang/shared/go-problem/problem"
+)
+
+// problems maps the errors of the config use cases to the problems written in the responses: missing configs are 404
+// (Not Found), duplicated configs 409 (Conflict), configs breaking a rule of the domain 422 (Unprocessable Entity)
+// and invalid cursors and sorts 400 (Bad Request). Any other error is a 500 (Internal Server Error).
+var problems = problem.Mapper{
+	{Err: entity.ErrNotFound, Problem: problem.ErrNotFound},
+	{Err: entity.ErrAlreadyExists, Problem: problem.ErrAlreadyExists},
+	{Err: entity.ErrInvalidID, Problem: problem.ErrInvalidEntity},
+	{Err: entity.ErrInvalidService, Problem: problem.ErrInvalidEntity},
+	{Err: entity.ErrInvalidSource, Problem: problem.ErrInvalidEntity},
+	{Err: entity.ErrInvalidProvider, Problem: problem.ErrInvalidEntity},
+	{Err: entity.ErrInvalidConfigVersionID, Problem: problem.ErrInvalidEntity},
+	{Err: entity.ErrInvalidCreatedAt, Problem: problem.ErrInvalidEntity},
+	{Err: entity.ErrDependencyCycle, Problem: problem.ErrInvalidEntity},
+	{Err: criteria.ErrInvalidCursor, Problem: problem.ErrInvalidCursor},
+	{Err: criteria.ErrInvalidSort, Problem: problem.ErrInvalidRequest},
+}
@@ -3,15 +3,28 @@ package healthz
 import (
 	"fmt"
 	"net/http"
+	"sync"
 	"time"
 )
 
+// DependencyCheck reports whether a dependency of the server is available.
+// It returns a non-nil error when the dependency is degraded.
+type DependencyCheck func() error
+
+// dependency associates a DependencyCheck with the name reported when it fails.
+type dependency struct {
+	name  string
+	check DependencyCheck
+}
+
 // WebHealthzHandler handles HTTP requests for health checks,
 // providing information about the server's uptime and readiness.
 type WebHealthzHandler struct {
 	startedAt    time.Time     // The time the server started.
 	timeProvider TimeProvider  // The TimeProvider implementation for time-related functions.
 	minUptime    time.Duration // The minimum uptime required for the server to be considered healthy.
+	mu           sync.RWMutex  // Guards dependencies.
+	dependencies []dependency  // The dependencies checked on every request.
 }
 
 // NewWebHealthzHandler creates and returns a new WebHealthzHandler instance
@@ -31,8 +44,20 @@ func NewWebHealthzHandler(timeProvider TimeProvider, minUptime time.Duration) *W
 	}
 }
 
+// AddDependencyCheck registers a dependency that must be available for the server to be considered healthy.
+//
+// Parameters:
+//   - name: The name of the dependency, reported when the check fails.
+//   - check: The function that reports whether the dependency is available.
+func (h *WebHealthzHandler) AddDependencyCheck(name string, check DependencyCheck) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.dependencies = append(h.dependencies, dependency{name: name, check: check})
+}
+
 // Healthz is an HTTP handler function that checks the health status of the server.
 // If the server has been running for less than the minimum uptime required, it responds with a 500 Internal Server Error status.
+// If any registered dependency is degraded, it responds with a 503 Service Unavailable status.
 // Otherwise, it responds with a 200 OK status.
 //
 // Parameters:
@@ -50,8 +75,26 @@ func (h *WebHealthzHandler) Healthz(w http.ResponseWriter, r *http.Request) {
 	if duration < h.minUptime {
 		w.WriteHeader(http.StatusInternalServerError)
 		w.Write([]byte(fmt.Sprintf("Healthz check failed after %v seconds", duration.Seconds())))
+	} else if err := h.checkDependencies(); err != nil {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(fmt.Sprintf("Healthz check degraded: %v", err)))
 	} else {
 		w.WriteHeader(http.StatusOK)
 		w.Write([]byte("Healthz check passed"))
 	}
 }
+
+// checkDependencies runs every registered dependency check.
+//
+// Returns:
+//   - An error naming the first degraded dependency, or nil if all dependencies are available.
+func (h *WebHealthzHandler) checkDependencies() error {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for _, dep := range h.dependencies {
+		if err := dep.check(); err != nil {
+			return fmt.Errorf("%s: %w", dep.name, err)
+		}
+	}
+	return nil
+}
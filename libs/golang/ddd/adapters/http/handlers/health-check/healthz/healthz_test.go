@@ -1,6 +1,8 @@
 package healthz
 
 import (
+	"errors"
+	"libs/golang/server/http/openapi/openapitest"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -55,3 +57,42 @@ func (suite *WebHealthzHandlerSuite) TestHealthzHandlerAfterFiveSeconds() {
 	assert.Equal(suite.T(), http.StatusOK, rr.Code)
 	assert.Equal(suite.T(), "Healthz check passed", rr.Body.String())
 }
+
+func (suite *WebHealthzHandlerSuite) TestHealthzHandlerDegradedDependency() {
+	suite.mockTimeProvider.Advance(5 * time.Second)
+	suite.handler.AddDependencyCheck("rabbitmq", func() error {
+		return errors.New("reconnecting")
+	})
+
+	req, err := http.NewRequest("GET", "/healthz", nil)
+	assert.NoError(suite.T(), err)
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(suite.handler.Healthz)
+
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(suite.T(), http.StatusServiceUnavailable, rr.Code)
+	assert.Equal(suite.T(), "Healthz check degraded: rabbitmq: reconnecting", rr.Body.String())
+}
+
+func (suite *WebHealthzHandlerSuite) TestHealthzHandlerHealthyDependency() {
+	suite.mockTimeProvider.Advance(5 * time.Second)
+	suite.handler.AddDependencyCheck("rabbitmq", func() error {
+		return nil
+	})
+
+	req, err := http.NewRequest("GET", "/healthz", nil)
+	assert.NoError(suite.T(), err)
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(suite.handler.Healthz)
+
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(suite.T(), http.StatusOK, rr.Code)
+}
+
+func (suite *WebHealthzHandlerSuite) TestOperationsDocumentEveryHandler() {
+	openapitest.AssertDocumentsHandlers(suite.T(), &WebHealthzHandler{}, Operations)
+}
@@ -0,0 +1,17 @@
+package healthz
+
+import (
+	"libs/golang/server/http/openapi/openapi"
+	"net/http"
+)
+
+// Operations documents the methods of WebHealthzHandler in the OpenAPI document of the service registering them.
+var Operations = openapi.Operations{
+	"Healthz": {
+		Summary:     "Check the health of the service",
+		Description: "The service is unhealthy until its minimum uptime is reached, and degraded while a dependency is unavailable.",
+		Response:    "Healthz check passed",
+		Errors:      []int{http.StatusInternalServerError, http.StatusServiceUnavailable},
+		TextErrors:  true,
+	},
+}
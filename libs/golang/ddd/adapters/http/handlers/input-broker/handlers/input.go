@@ -7,37 +7,44 @@ import (
 	shareddto "libs/golang/ddd/dtos/input-broker/shared"
 	"libs/golang/ddd/usecases/input-broker/usecase"
 	events "libs/golang/shared/go-events/amqp_events"
+	"libs/golang/shared/go-problem/problem"
+	"libs/golang/shared/go-validator/validator"
 	typetools "libs/golang/shared/type-tools"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
 )
 
-// WebInputHandler handles HTTP requests for input-related operations.
+var (
+	// idempotencyKeyHeader is the request header identifying a create request across its retries.
+	idempotencyKeyHeader = "Idempotency-Key"
+
+	// idempotentReplayedHeader is the response header set when a stored input is returned for a retried request.
+	idempotentReplayedHeader = "Idempotent-Replayed"
+)
+
+// WebInputHandler handles HTTP requests for input-related operations. Failed requests are answered
+// with RFC 7807 problem details, whose status and code are chosen by problems.
 type WebInputHandler struct {
-	InputRepository   entity.InputRepositoryInterface // Interface for input repository operations.
-	EventDispatcher   events.EventDispatcherInterface // Interface for event dispatching.
-	InputCreatedEvent events.EventInterface           // Event interface for input creation event.
+	InputRepository      entity.InputRepositoryInterface // Interface for input repository operations.
+	NewInputCreatedEvent func() events.EventInterface    // Builds the input creation event of each created input.
 }
 
 // NewWebInputHandler creates a new instance of WebInputHandler with the provided dependencies.
 //
 // Parameters:
 //   - inputRepository: Interface for input repository operations.
-//   - eventDispatcher: Interface for event dispatching.
-//   - inputCreatedEvent: Event interface for input creation event.
+//   - newInputCreatedEvent: Builds the input creation event of each created input.
 //
 // Returns:
 //   - A new instance of WebInputHandler.
 func NewWebInputHandler(
 	inputRepository entity.InputRepositoryInterface,
-	eventDispatcher events.EventDispatcherInterface,
-	inputCreatedEvent events.EventInterface,
+	newInputCreatedEvent func() events.EventInterface,
 ) *WebInputHandler {
 	return &WebInputHandler{
-		InputRepository:   inputRepository,
-		EventDispatcher:   eventDispatcher,
-		InputCreatedEvent: inputCreatedEvent,
+		InputRepository:      inputRepository,
+		NewInputCreatedEvent: newInputCreatedEvent,
 	}
 }
 
@@ -48,6 +55,9 @@ func NewWebInputHandler(
 // it responds with the created input entity as JSON. If there are errors,
 // appropriate HTTP error responses are returned.
 //
+// A request with an Idempotency-Key header can be retried safely: once an input was created with the key,
+// the stored input is returned with the Idempotent-Replayed header instead of being created again.
+//
 // Parameters:
 //   - w: HTTP Response Writer to write the response.
 //   - r: HTTP Request containing the input data.
@@ -55,25 +65,30 @@ func NewWebInputHandler(
 // Responses:
 //   - 200 OK: If the input entity is created successfully, the response contains the created input entity as JSON.
 //   - 400 Bad Request: If there is an error decoding the request body.
+//   - 409 Conflict: If the input entity already exists.
+//   - 422 Unprocessable Entity: If fields of the request body are missing or invalid, all listed in the response, if the
+//     input entity breaks a rule of the domain or if the idempotency key was already used for a different input.
 //   - 500 Internal Server Error: If there is an error creating the input entity or encoding the response.
 func (h *WebInputHandler) CreateInput(w http.ResponseWriter, r *http.Request) {
 	var dto inputdto.InputDTO
-	err := json.NewDecoder(r.Body).Decode(&dto)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	if err := validator.Decode(w, r, &dto); err != nil {
+		problems.Write(w, r, err)
 		return
 	}
 
-	createInputUseCase := usecase.NewCreateInputUseCase(h.InputRepository, h.InputCreatedEvent, h.EventDispatcher)
-	inputCreated, err := createInputUseCase.Execute(dto)
+	createInputUseCase := usecase.NewCreateInputUseCase(h.InputRepository, h.NewInputCreatedEvent)
+	inputCreated, replayed, err := createInputUseCase.ExecuteWithIdempotencyKey(dto, r.Header.Get(idempotencyKeyHeader))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problems.Write(w, r, err)
 		return
 	}
+	if replayed {
+		w.Header().Set(idempotentReplayedHeader, "true")
+	}
 
 	err = json.NewEncoder(w).Encode(inputCreated)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problems.Write(w, r, err)
 		return
 	}
 }
@@ -92,25 +107,27 @@ func (h *WebInputHandler) CreateInput(w http.ResponseWriter, r *http.Request) {
 // Responses:
 //   - 200 OK: If the input entity is updated successfully, the response contains the updated input entity as JSON.
 //   - 400 Bad Request: If there is an error decoding the request body.
+//   - 404 Not Found: If the input entity does not exist.
+//   - 422 Unprocessable Entity: If fields of the request body are missing or invalid, all listed in the response, or if
+//     the input entity breaks a rule of the domain.
 //   - 500 Internal Server Error: If there is an error updating the input entity or encoding the response.
 func (h *WebInputHandler) UpdateInput(w http.ResponseWriter, r *http.Request) {
 	var dto inputdto.InputDTO
-	err := json.NewDecoder(r.Body).Decode(&dto)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	if err := validator.Decode(w, r, &dto); err != nil {
+		problems.Write(w, r, err)
 		return
 	}
 
 	updateInputUseCase := usecase.NewUpdateInputUseCase(h.InputRepository)
 	inputUpdated, err := updateInputUseCase.Execute(dto)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problems.Write(w, r, err)
 		return
 	}
 
 	err = json.NewEncoder(w).Encode(inputUpdated)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problems.Write(w, r, err)
 		return
 	}
 
@@ -129,18 +146,19 @@ func (h *WebInputHandler) UpdateInput(w http.ResponseWriter, r *http.Request) {
 //
 // Responses:
 //   - 200 OK: If the input entity is deleted successfully, the response contains a success message.
+//   - 404 Not Found: If the input entity does not exist.
 //   - 500 Internal Server Error: If there is an error deleting the input entity.
 func (h *WebInputHandler) DeleteInput(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 	if id == "" {
-		http.Error(w, "ID is required", http.StatusBadRequest)
+		problem.Write(w, r, problem.ErrInvalidRequest.WithDetail("ID is required"))
 		return
 	}
 
 	deleteInputUseCase := usecase.NewDeleteInputUseCase(h.InputRepository)
 	err := deleteInputUseCase.Execute(id)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problems.Write(w, r, err)
 		return
 	}
 
@@ -148,34 +166,70 @@ func (h *WebInputHandler) DeleteInput(w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte("Input deleted successfully"))
 }
 
-// ListAllInputs handles the retrieval of all input entities.
-//
-// This function retrieves all input entities using the use case,
-// and then responds with the list of input entities as JSON. If there are errors,
-// appropriate HTTP error responses are returned.
+// ListAllInputs handles HTTP GET requests to list the inputs selected by the query parameters, such as
+// `GET /input?provider=acme&status=0`. Every parameter is optional: provider, service, source, status,
+// created_after and created_before, the times being RFC 3339 timestamps. The limit, cursor and sort parameters
+// select the page, and the page is written as a JSON response with the cursor of the next page.
 //
 // Parameters:
-//   - w: HTTP Response Writer to write the response.
-//   - r: HTTP Request.
 //
-// Responses:
-//   - 200 OK: If the input entities are retrieved successfully, the response contains the list of input entities as JSON.
-//   - 500 Internal Server Error: If there is an error retrieving the input entities or encoding the response.
+//	w: The HTTP response writer.
+//	r: The HTTP request.
+//
+// Returns:
+//
+//	None.
+//
+// If a query parameter is invalid, it responds with HTTP status 400 (Bad Request), and if an error occurs during the
+// listing process, with HTTP status 500 (Internal Server Error).
 func (h *WebInputHandler) ListAllInputs(w http.ResponseWriter, r *http.Request) {
-	listInputsUseCase := usecase.NewListAllInputUseCase(h.InputRepository)
-	inputs, err := listInputsUseCase.Execute()
+	filterDTO, err := inputdto.NewInputFilterDTO(r.URL.Query())
+	if err != nil {
+		problem.Write(w, r, problem.ErrInvalidRequest.WithDetail(err.Error()))
+		return
+	}
+	h.listInputs(w, r, filterDTO)
+}
+
+// listInputs lists a page of the inputs selected by the filter and writes it as a JSON response.
+//
+// Parameters:
+//
+//	w: The HTTP response writer.
+//	filterDTO: The filter selecting the inputs and the page.
+func (h *WebInputHandler) listInputs(w http.ResponseWriter, r *http.Request, filterDTO inputdto.InputFilterDTO) {
+	listPageByFilterInputUseCase := usecase.NewListPageByFilterInputUseCase(h.InputRepository)
+	page, err := listPageByFilterInputUseCase.Execute(filterDTO)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problems.Write(w, r, err)
 		return
 	}
 
-	err = json.NewEncoder(w).Encode(inputs)
+	err = json.NewEncoder(w).Encode(page)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problems.Write(w, r, err)
 		return
 	}
 }
 
+// listInputsByPath lists the inputs of the routes filtering on path parameters, which are aliases of ListAllInputs. The
+// filter built from the path replaces the filter of the query parameters, which still select the page.
+//
+// Parameters:
+//
+//	w: The HTTP response writer.
+//	r: The HTTP request.
+//	pathFilterDTO: The filter built from the path parameters.
+func (h *WebInputHandler) listInputsByPath(w http.ResponseWriter, r *http.Request, pathFilterDTO inputdto.InputFilterDTO) {
+	filterDTO, err := inputdto.NewInputFilterDTO(r.URL.Query())
+	if err != nil {
+		problem.Write(w, r, problem.ErrInvalidRequest.WithDetail(err.Error()))
+		return
+	}
+	pathFilterDTO.Limit, pathFilterDTO.Cursor, pathFilterDTO.Sort = filterDTO.Limit, filterDTO.Cursor, filterDTO.Sort
+	h.listInputs(w, r, pathFilterDTO)
+}
+
 // ListInputByID handles the retrieval of an input entity by ID.
 //
 // This function extracts the input ID from the request URL,
@@ -189,24 +243,25 @@ func (h *WebInputHandler) ListAllInputs(w http.ResponseWriter, r *http.Request)
 //
 // Responses:
 //   - 200 OK: If the input entity is retrieved successfully, the response contains the input entity as JSON.
+//   - 404 Not Found: If the input entity does not exist.
 //   - 500 Internal Server Error: If there is an error retrieving the input entity or encoding the response.
 func (h *WebInputHandler) ListInputByID(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 	if id == "" {
-		http.Error(w, "ID is required", http.StatusBadRequest)
+		problem.Write(w, r, problem.ErrInvalidRequest.WithDetail("ID is required"))
 		return
 	}
 
 	getInputUseCase := usecase.NewListOneByIDInputUseCase(h.InputRepository)
 	input, err := getInputUseCase.Execute(id)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problems.Write(w, r, err)
 		return
 	}
 
 	err = json.NewEncoder(w).Encode(input)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problems.Write(w, r, err)
 		return
 	}
 }
@@ -229,22 +284,11 @@ func (h *WebInputHandler) ListInputsByServiceAndProvider(w http.ResponseWriter,
 	provider := chi.URLParam(r, "provider")
 	service := chi.URLParam(r, "service")
 	if service == "" || provider == "" {
-		http.Error(w, "Service and provider are required", http.StatusBadRequest)
-		return
-	}
-
-	listInputsUseCase := usecase.NewListAllByServiceAndProviderInputUseCase(h.InputRepository)
-	inputs, err := listInputsUseCase.Execute(provider, service)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problem.Write(w, r, problem.ErrInvalidRequest.WithDetail("Service and provider are required"))
 		return
 	}
 
-	err = json.NewEncoder(w).Encode(inputs)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
+	h.listInputsByPath(w, r, inputdto.InputFilterDTO{Provider: provider, Service: service})
 }
 
 // ListInputsBySourceAndProvider handles the retrieval of input entities by source and provider.
@@ -266,22 +310,11 @@ func (h *WebInputHandler) ListInputsBySourceAndProvider(w http.ResponseWriter, r
 	provider := chi.URLParam(r, "provider")
 	source := chi.URLParam(r, "source")
 	if source == "" || provider == "" {
-		http.Error(w, "Source and provider are required", http.StatusBadRequest)
+		problem.Write(w, r, problem.ErrInvalidRequest.WithDetail("Source and provider are required"))
 		return
 	}
 
-	listInputsUseCase := usecase.NewListAllBySourceAndProviderInputUseCase(h.InputRepository)
-	inputs, err := listInputsUseCase.Execute(provider, source)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-
-	err = json.NewEncoder(w).Encode(inputs)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
+	h.listInputsByPath(w, r, inputdto.InputFilterDTO{Provider: provider, Source: source})
 }
 
 // ListInputsByServiceAndSourceAndProvider handles the retrieval of input entities by service, source, and provider.
@@ -305,22 +338,11 @@ func (h *WebInputHandler) ListInputsByServiceAndSourceAndProvider(w http.Respons
 	source := chi.URLParam(r, "source")
 
 	if service == "" || source == "" || provider == "" {
-		http.Error(w, "Service, source, and provider are required", http.StatusBadRequest)
+		problem.Write(w, r, problem.ErrInvalidRequest.WithDetail("Service, source, and provider are required"))
 		return
 	}
 
-	listInputsUseCase := usecase.NewListAllByServiceAndSourceAndProviderInputUseCase(h.InputRepository)
-	inputs, err := listInputsUseCase.Execute(provider, service, source)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-
-	err = json.NewEncoder(w).Encode(inputs)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
+	h.listInputsByPath(w, r, inputdto.InputFilterDTO{Provider: provider, Service: service, Source: source})
 }
 
 // ListInputsByStatusAndProvider handles the retrieval of input entities by status and provider.
@@ -342,27 +364,16 @@ func (h *WebInputHandler) ListInputsByStatusAndProvider(w http.ResponseWriter, r
 	provider := chi.URLParam(r, "provider")
 	statusStr := chi.URLParam(r, "status")
 	if statusStr == "" || provider == "" {
-		http.Error(w, "Status and provider are required", http.StatusBadRequest)
+		problem.Write(w, r, problem.ErrInvalidRequest.WithDetail("Status and provider are required"))
 		return
 	}
 	status, err := typetools.ParseInt(statusStr)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
-	}
-
-	listInputsUseCase := usecase.NewListAllByStatusAndProviderInputUseCase(h.InputRepository)
-	inputs, err := listInputsUseCase.Execute(provider, status)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problem.Write(w, r, problem.ErrInvalidRequest.WithDetail(err.Error()))
 		return
 	}
 
-	err = json.NewEncoder(w).Encode(inputs)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
+	h.listInputsByPath(w, r, inputdto.InputFilterDTO{Provider: provider, Status: &status})
 }
 
 // ListInputsByStatusAndServiceAndProvider handles the retrieval of input entities by status, service, and provider.
@@ -385,27 +396,16 @@ func (h *WebInputHandler) ListInputsByStatusAndServiceAndProvider(w http.Respons
 	service := chi.URLParam(r, "service")
 	statusStr := chi.URLParam(r, "status")
 	if statusStr == "" || provider == "" || service == "" {
-		http.Error(w, "Status, provider and service are required", http.StatusBadRequest)
+		problem.Write(w, r, problem.ErrInvalidRequest.WithDetail("Status, provider and service are required"))
 		return
 	}
 	status, err := typetools.ParseInt(statusStr)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		problem.Write(w, r, problem.ErrInvalidRequest.WithDetail(err.Error()))
 		return
 	}
 
-	listInputsUseCase := usecase.NewListAllByStatusAndServiceAndProviderInputUseCase(h.InputRepository)
-	inputs, err := listInputsUseCase.Execute(provider, service, status)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-
-	err = json.NewEncoder(w).Encode(inputs)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
+	h.listInputsByPath(w, r, inputdto.InputFilterDTO{Provider: provider, Service: service, Status: &status})
 }
 
 // ListInputsByStatusAndSourceAndProvider handles the retrieval of input entities by status, source, and provider.
@@ -428,27 +428,16 @@ func (h *WebInputHandler) ListInputsByStatusAndSourceAndProvider(w http.Response
 	source := chi.URLParam(r, "source")
 	statusStr := chi.URLParam(r, "status")
 	if statusStr == "" || provider == "" || source == "" {
-		http.Error(w, "Status, provider and source are required", http.StatusBadRequest)
+		problem.Write(w, r, problem.ErrInvalidRequest.WithDetail("Status, provider and source are required"))
 		return
 	}
 	status, err := typetools.ParseInt(statusStr)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		problem.Write(w, r, problem.ErrInvalidRequest.WithDetail(err.Error()))
 		return
 	}
 
-	listInputsUseCase := usecase.NewListAllByStatusAndSourceAndProviderInputUseCase(h.InputRepository)
-	inputs, err := listInputsUseCase.Execute(provider, source, status)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-
-	err = json.NewEncoder(w).Encode(inputs)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
+	h.listInputsByPath(w, r, inputdto.InputFilterDTO{Provider: provider, Source: source, Status: &status})
 }
 
 // ListInputsByStatusAndServiceAndSourceAndProvider handles the retrieval of input entities by status, service, source, and provider.
@@ -472,27 +461,16 @@ func (h *WebInputHandler) ListInputsByStatusAndServiceAndSourceAndProvider(w htt
 	source := chi.URLParam(r, "source")
 	statusStr := chi.URLParam(r, "status")
 	if statusStr == "" || provider == "" || service == "" || source == "" {
-		http.Error(w, "Status, provider, service and source are required", http.StatusBadRequest)
+		problem.Write(w, r, problem.ErrInvalidRequest.WithDetail("Status, provider, service and source are required"))
 		return
 	}
 	status, err := typetools.ParseInt(statusStr)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
-	}
-
-	listInputsUseCase := usecase.NewListAllByStatusAndServiceAndSourceAndProviderInputUseCaseSuite(h.InputRepository)
-	inputs, err := listInputsUseCase.Execute(provider, service, source, status)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problem.Write(w, r, problem.ErrInvalidRequest.WithDetail(err.Error()))
 		return
 	}
 
-	err = json.NewEncoder(w).Encode(inputs)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
+	h.listInputsByPath(w, r, inputdto.InputFilterDTO{Provider: provider, Service: service, Source: source, Status: &status})
 }
 
 // UpdateInputStatus handles the update of an existing input entity's status.
@@ -509,30 +487,30 @@ func (h *WebInputHandler) ListInputsByStatusAndServiceAndSourceAndProvider(w htt
 // Responses:
 //   - 200 OK: If the input entity status is updated successfully, the response contains the updated input entity as JSON.
 //   - 400 Bad Request: If there is an error decoding the request body.
+//   - 404 Not Found: If the input entity does not exist.
 //   - 500 Internal Server Error: If there is an error updating the input entity status or encoding the response.
 func (h *WebInputHandler) UpdateInputStatus(w http.ResponseWriter, r *http.Request) {
 	var dto shareddto.StatusDTO
-	err := json.NewDecoder(r.Body).Decode(&dto)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	if err := validator.Decode(w, r, &dto); err != nil {
+		problems.Write(w, r, err)
 		return
 	}
 
 	id := chi.URLParam(r, "id")
 	if id == "" {
-		http.Error(w, "missing input ID", http.StatusBadRequest)
+		problem.Write(w, r, problem.ErrInvalidRequest.WithDetail("missing input ID"))
 		return
 	}
 	updateStatusInputUseCase := usecase.NewUpdateStatusInputUseCase(h.InputRepository)
 	inputUpdated, err := updateStatusInputUseCase.Execute(id, dto)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problems.Write(w, r, err)
 		return
 	}
 
 	err = json.NewEncoder(w).Encode(inputUpdated)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problems.Write(w, r, err)
 		return
 	}
 }
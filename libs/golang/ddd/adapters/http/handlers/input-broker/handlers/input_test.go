@@ -8,6 +8,10 @@ import (
 	"libs/golang/ddd/domain/entities/input-broker/entity"
 	mockrepository "libs/golang/ddd/domain/repositories/database/mock/input-broker/repository"
 	mockevent "libs/golang/ddd/events/event-mock/mock"
+	"libs/golang/server/http/openapi/openapitest"
+	"libs/golang/shared/go-criteria/criteria"
+	events "libs/golang/shared/go-events/amqp_events"
+	"libs/golang/shared/go-problem/problem"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -23,12 +27,22 @@ import (
 	"github.com/stretchr/testify/suite"
 )
 
+// firstPage is the page read when the request does not set the limit, cursor and sort parameters.
+var firstPage = criteria.Page{Sort: criteria.NewSort("created_at")}
+
+// decodeProblem reads the problem details written in the response.
+func decodeProblem(t *testing.T, rr *httptest.ResponseRecorder) problem.Problem {
+	assert.Equal(t, problem.ContentType, rr.Header().Get("Content-Type"))
+	var p problem.Problem
+	assert.NoError(t, json.NewDecoder(rr.Body).Decode(&p))
+	return p
+}
+
 type WebInputHandlerSuite struct {
 	suite.Suite
-	handler        *WebInputHandler
-	repoMock       *mockrepository.InputRepositoryMock
-	eventMock      *mockevent.MockEvent
-	dispatcherMock *mockevent.MockEventDispatcher
+	handler   *WebInputHandler
+	repoMock  *mockrepository.InputRepositoryMock
+	eventMock *mockevent.MockEvent
 }
 
 func TestWebInputHandlerSuite(t *testing.T) {
@@ -38,8 +52,7 @@ func TestWebInputHandlerSuite(t *testing.T) {
 func (suite *WebInputHandlerSuite) SetupTest() {
 	suite.repoMock = new(mockrepository.InputRepositoryMock)
 	suite.eventMock = new(mockevent.MockEvent)
-	suite.dispatcherMock = new(mockevent.MockEventDispatcher)
-	suite.handler = NewWebInputHandler(suite.repoMock, suite.dispatcherMock, suite.eventMock)
+	suite.handler = NewWebInputHandler(suite.repoMock, func() events.EventInterface { return suite.eventMock })
 }
 
 func (suite *WebInputHandlerSuite) TestCreateInput() {
@@ -50,39 +63,14 @@ func (suite *WebInputHandlerSuite) TestCreateInput() {
 		Data:     map[string]interface{}{"key": "value"},
 	}
 
-	expectedOutput := outputdto.InputDTO{
-		ID: "test_id",
-		Metadata: shareddto.MetadataDTO{
-			Service:             "test_service",
-			Source:              "test_source",
-			Provider:            "test_provider",
-			ProcessingID:        "test_processing_id",
-			ProcessingTimestamp: "2023-06-01 00:00:00",
-		},
-		Status: shareddto.StatusDTO{
-			Code:   0,
-			Detail: "test_detail",
-		},
-		Data: map[string]interface{}{
-			"key": "value",
-		},
-		CreatedAt: "2023-06-01 00:00:00",
-		UpdatedAt: "2023-06-01 00:00:00",
-	}
-
-	suite.repoMock.On("Create", mock.AnythingOfType("*entity.Input")).Return(nil).Run(func(args mock.Arguments) {
-		arg := args.Get(0).(*entity.Input)
-		arg.ID = "test_id"
-		arg.Metadata.ProcessingID = "test_processing_id"
-		arg.Metadata.ProcessingTimestamp = "2023-06-01 00:00:00"
-		arg.Metadata.Service = inputDTO.Service
-		arg.Metadata.Source = inputDTO.Source
-		arg.Metadata.Provider = inputDTO.Provider
-		arg.Status.Code = 0
-		arg.Status.Detail = "test_detail"
-		arg.Data = inputDTO.Data
-		arg.CreatedAt = "2023-06-01 00:00:00"
-		arg.UpdatedAt = "2023-06-01 00:00:00"
+	var storedInput *entity.Input
+	suite.repoMock.On(
+		"CreateWithEvent",
+		mock.AnythingOfType("*entity.Input"),
+		suite.eventMock,
+		fmt.Sprintf("input.created.%s.%s.%s", inputDTO.Provider, inputDTO.Service, inputDTO.Source),
+	).Return(nil).Run(func(args mock.Arguments) {
+		storedInput = args.Get(0).(*entity.Input)
 	})
 
 	jsonBody, _ := json.Marshal(inputDTO)
@@ -91,7 +79,6 @@ func (suite *WebInputHandlerSuite) TestCreateInput() {
 	rr := httptest.NewRecorder()
 
 	suite.eventMock.On("SetPayload", mock.AnythingOfType("outputdto.InputDTO")).Return(nil)
-	suite.dispatcherMock.On("Dispatch", suite.eventMock, fmt.Sprintf("input.created.%s.%s.%s", inputDTO.Provider, inputDTO.Service, inputDTO.Source)).Return(nil)
 
 	suite.handler.CreateInput(rr, req)
 
@@ -101,10 +88,107 @@ func (suite *WebInputHandlerSuite) TestCreateInput() {
 	err := json.NewDecoder(rr.Body).Decode(&actualOutput)
 	assert.NoError(suite.T(), err)
 
-	assert.Equal(suite.T(), expectedOutput, actualOutput)
+	assert.Equal(suite.T(), string(storedInput.ID), actualOutput.ID)
+	assert.Equal(suite.T(), shareddto.MetadataDTO{
+		Service:             "test_service",
+		Source:              "test_source",
+		Provider:            "test_provider",
+		ProcessingID:        string(storedInput.Metadata.ProcessingID),
+		ProcessingTimestamp: storedInput.Metadata.ProcessingTimestamp,
+	}, actualOutput.Metadata)
+	assert.Equal(suite.T(), inputDTO.Data, actualOutput.Data)
+	assert.Equal(suite.T(), storedInput.CreatedAt, actualOutput.CreatedAt)
 	suite.repoMock.AssertExpectations(suite.T())
 	suite.eventMock.AssertExpectations(suite.T())
-	suite.dispatcherMock.AssertExpectations(suite.T())
+}
+
+func (suite *WebInputHandlerSuite) TestCreateInputWithIdempotencyKey() {
+	inputDTO := inputdto.InputDTO{
+		Provider: "test_provider",
+		Service:  "test_service",
+		Source:   "test_source",
+		Data:     map[string]interface{}{"key": "value"},
+	}
+	storedInput, _ := entity.NewInput(entity.InputProps{
+		Provider: inputDTO.Provider,
+		Service:  inputDTO.Service,
+		Source:   inputDTO.Source,
+		Data:     inputDTO.Data,
+	})
+	otherInput, _ := entity.NewInput(entity.InputProps{
+		Provider: inputDTO.Provider,
+		Service:  inputDTO.Service,
+		Source:   inputDTO.Source,
+		Data:     map[string]interface{}{"key": "other"},
+	})
+	suite.repoMock.On("FindByIdempotencyKey", "request-1").Return(storedInput, nil)
+	suite.repoMock.On("FindByIdempotencyKey", "request-2").Return(otherInput, nil)
+	jsonBody, _ := json.Marshal(inputDTO)
+
+	req := httptest.NewRequest(http.MethodPost, "/input", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Idempotency-Key", "request-1")
+	rr := httptest.NewRecorder()
+	suite.handler.CreateInput(rr, req)
+
+	assert.Equal(suite.T(), http.StatusOK, rr.Code)
+	assert.Equal(suite.T(), "true", rr.Header().Get("Idempotent-Replayed"))
+	var actualOutput outputdto.InputDTO
+	assert.NoError(suite.T(), json.NewDecoder(rr.Body).Decode(&actualOutput))
+	assert.Equal(suite.T(), string(storedInput.ID), actualOutput.ID)
+
+	req = httptest.NewRequest(http.MethodPost, "/input", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Idempotency-Key", "request-2")
+	rr = httptest.NewRecorder()
+	suite.handler.CreateInput(rr, req)
+
+	assert.Equal(suite.T(), http.StatusUnprocessableEntity, rr.Code)
+	assert.Equal(suite.T(), problem.CodeIdempotencyKeyReused, decodeProblem(suite.T(), rr).Code)
+	suite.repoMock.AssertNotCalled(suite.T(), "CreateWithEvent", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *WebInputHandlerSuite) TestCreateInputInvalid() {
+	req := httptest.NewRequest(http.MethodPost, "/input", bytes.NewBuffer([]byte(`{"provider": "test_provider", "source": "test_source"}`)))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+
+	suite.handler.CreateInput(rr, req)
+
+	assert.Equal(suite.T(), http.StatusUnprocessableEntity, rr.Code)
+	problemDetails := decodeProblem(suite.T(), rr)
+	assert.Equal(suite.T(), problem.CodeValidationFailed, problemDetails.Code)
+	assert.Equal(suite.T(), []problem.FieldError{
+		{Field: "service", Message: "is required"},
+		{Field: "data", Message: "is required"},
+	}, problemDetails.Errors)
+	suite.repoMock.AssertNotCalled(suite.T(), "CreateWithEvent", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *WebInputHandlerSuite) TestCreateInputAlreadyExists() {
+	inputDTO := inputdto.InputDTO{
+		Provider: "test_provider",
+		Service:  "test_service",
+		Source:   "test_source",
+		Data:     map[string]interface{}{"key": "value"},
+	}
+
+	suite.repoMock.On(
+		"CreateWithEvent",
+		mock.AnythingOfType("*entity.Input"),
+		suite.eventMock,
+		fmt.Sprintf("input.created.%s.%s.%s", inputDTO.Provider, inputDTO.Service, inputDTO.Source),
+	).Return(fmt.Errorf("%w: ID 1", entity.ErrAlreadyExists))
+	suite.eventMock.On("SetPayload", mock.AnythingOfType("outputdto.InputDTO")).Return(nil)
+
+	jsonBody, _ := json.Marshal(inputDTO)
+	req := httptest.NewRequest(http.MethodPost, "/input", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+
+	suite.handler.CreateInput(rr, req)
+
+	assert.Equal(suite.T(), http.StatusConflict, rr.Code)
+	assert.Equal(suite.T(), problem.CodeAlreadyExists, decodeProblem(suite.T(), rr).Code)
+	suite.repoMock.AssertExpectations(suite.T())
 }
 
 func (suite *WebInputHandlerSuite) TestUpdateInput() {
@@ -183,6 +267,22 @@ func (suite *WebInputHandlerSuite) TestDeleteInput() {
 	suite.repoMock.AssertExpectations(suite.T())
 }
 
+func (suite *WebInputHandlerSuite) TestDeleteInputNotFound() {
+	suite.repoMock.On("Delete", "1").Return(fmt.Errorf("%w: ID 1", entity.ErrNotFound))
+
+	req := httptest.NewRequest(http.MethodDelete, "/input/1", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "1")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	rr := httptest.NewRecorder()
+
+	suite.handler.DeleteInput(rr, req)
+
+	assert.Equal(suite.T(), http.StatusNotFound, rr.Code)
+	assert.Equal(suite.T(), problem.CodeNotFound, decodeProblem(suite.T(), rr).Code)
+	suite.repoMock.AssertExpectations(suite.T())
+}
+
 func (suite *WebInputHandlerSuite) TestListInputByID() {
 	expectedInput := outputdto.InputDTO{
 		ID: "test_id",
@@ -243,6 +343,22 @@ func (suite *WebInputHandlerSuite) TestListInputByID() {
 	suite.repoMock.AssertExpectations(suite.T())
 }
 
+func (suite *WebInputHandlerSuite) TestListInputByIDNotFound() {
+	suite.repoMock.On("FindByID", "test_id").Return(nil, fmt.Errorf("%w: ID test_id", entity.ErrNotFound))
+
+	req := httptest.NewRequest(http.MethodGet, "/input/test_id", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "test_id")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	rr := httptest.NewRecorder()
+
+	suite.handler.ListInputByID(rr, req)
+
+	assert.Equal(suite.T(), http.StatusNotFound, rr.Code)
+	assert.Equal(suite.T(), problem.CodeNotFound, decodeProblem(suite.T(), rr).Code)
+	suite.repoMock.AssertExpectations(suite.T())
+}
+
 func (suite *WebInputHandlerSuite) TestListAllInputs() {
 	expectedInputs := []outputdto.InputDTO{
 		{
@@ -266,7 +382,7 @@ func (suite *WebInputHandlerSuite) TestListAllInputs() {
 		},
 	}
 
-	suite.repoMock.On("FindAll").Return([]*entity.Input{
+	suite.repoMock.On("FindPageByFilter", entity.InputFilter{}, firstPage).Return([]*entity.Input{
 		{
 			ID: "test_id_1",
 			Metadata: entity.Metadata{
@@ -286,7 +402,7 @@ func (suite *WebInputHandlerSuite) TestListAllInputs() {
 			CreatedAt: "2023-06-01 00:00:00",
 			UpdatedAt: "2023-06-01 00:00:00",
 		},
-	}, nil)
+	}, "", nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/inputs", nil)
 	rr := httptest.NewRecorder()
@@ -295,14 +411,85 @@ func (suite *WebInputHandlerSuite) TestListAllInputs() {
 
 	assert.Equal(suite.T(), http.StatusOK, rr.Code)
 
-	var actualOutputs []outputdto.InputDTO
+	var actualOutputs outputdto.InputPageDTO
 	err := json.NewDecoder(rr.Body).Decode(&actualOutputs)
 	assert.NoError(suite.T(), err)
 
-	assert.Equal(suite.T(), expectedInputs, actualOutputs)
+	assert.Equal(suite.T(), expectedInputs, actualOutputs.Items)
+	suite.repoMock.AssertExpectations(suite.T())
+}
+
+func (suite *WebInputHandlerSuite) TestListAllInputsWithQuery() {
+	status := 0
+	createdAfter := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	suite.repoMock.On("FindPageByFilter", entity.InputFilter{
+		Provider:     "test_provider",
+		Source:       "test_source",
+		Status:       &status,
+		CreatedAfter: createdAfter,
+	}, firstPage).Return([]*entity.Input{}, "", nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/input?provider=test_provider&source=test_source&status=0&created_after=2024-01-01T00:00:00Z", nil)
+	rr := httptest.NewRecorder()
+
+	suite.handler.ListAllInputs(rr, req)
+
+	assert.Equal(suite.T(), http.StatusOK, rr.Code)
+	suite.repoMock.AssertExpectations(suite.T())
+}
+
+func (suite *WebInputHandlerSuite) TestListAllInputsWithPage() {
+	page := criteria.Page{Limit: 2, Cursor: "cursor", Sort: criteria.NewSort("-updated_at")}
+	suite.repoMock.On("FindPageByFilter", entity.InputFilter{}, page).Return([]*entity.Input{}, "next", nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/input?limit=2&cursor=cursor&sort=-updated_at", nil)
+	rr := httptest.NewRecorder()
+
+	suite.handler.ListAllInputs(rr, req)
+
+	assert.Equal(suite.T(), http.StatusOK, rr.Code)
+	var actualPage outputdto.InputPageDTO
+	assert.NoError(suite.T(), json.NewDecoder(rr.Body).Decode(&actualPage))
+	assert.Equal(suite.T(), outputdto.InputPageDTO{Items: []outputdto.InputDTO{}, NextCursor: "next"}, actualPage)
 	suite.repoMock.AssertExpectations(suite.T())
 }
 
+func (suite *WebInputHandlerSuite) TestListAllInputsWhenCursorIsInvalid() {
+	suite.repoMock.On("FindPageByFilter", entity.InputFilter{}, criteria.Page{Cursor: "invalid", Sort: criteria.NewSort("created_at")}).
+		Return(nil, "", criteria.ErrInvalidCursor)
+
+	req := httptest.NewRequest(http.MethodGet, "/input?cursor=invalid", nil)
+	rr := httptest.NewRecorder()
+
+	suite.handler.ListAllInputs(rr, req)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, rr.Code)
+	assert.Equal(suite.T(), problem.CodeInvalidCursor, decodeProblem(suite.T(), rr).Code)
+	suite.repoMock.AssertExpectations(suite.T())
+}
+
+func (suite *WebInputHandlerSuite) TestListAllInputsWhenLimitIsInvalid() {
+	req := httptest.NewRequest(http.MethodGet, "/input?limit=0", nil)
+	rr := httptest.NewRecorder()
+
+	suite.handler.ListAllInputs(rr, req)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, rr.Code)
+	suite.repoMock.AssertNotCalled(suite.T(), "FindPageByFilter", mock.Anything, mock.Anything)
+}
+
+func (suite *WebInputHandlerSuite) TestListAllInputsWhenQueryIsInvalid() {
+	for _, query := range []string{"status=done", "created_after=yesterday"} {
+		req := httptest.NewRequest(http.MethodGet, "/input?"+query, nil)
+		rr := httptest.NewRecorder()
+
+		suite.handler.ListAllInputs(rr, req)
+
+		assert.Equal(suite.T(), http.StatusBadRequest, rr.Code, query)
+	}
+	suite.repoMock.AssertNotCalled(suite.T(), "FindPageByFilter", mock.Anything, mock.Anything)
+}
+
 func (suite *WebInputHandlerSuite) TestListInputsByServiceAndProvider() {
 	expectedInputs := []outputdto.InputDTO{
 		{
@@ -326,7 +513,7 @@ func (suite *WebInputHandlerSuite) TestListInputsByServiceAndProvider() {
 		},
 	}
 
-	suite.repoMock.On("FindAllByServiceAndProvider", "test_provider", "test_service").Return([]*entity.Input{
+	suite.repoMock.On("FindPageByFilter", entity.InputFilter{Provider: "test_provider", Service: "test_service"}, firstPage).Return([]*entity.Input{
 		{
 			ID: "test_id_1",
 			Metadata: entity.Metadata{
@@ -346,7 +533,7 @@ func (suite *WebInputHandlerSuite) TestListInputsByServiceAndProvider() {
 			CreatedAt: "2023-06-01 00:00:00",
 			UpdatedAt: "2023-06-01 00:00:00",
 		},
-	}, nil)
+	}, "", nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/inputs/provider/test_provider/service/test_service", nil)
 	rctx := chi.NewRouteContext()
@@ -359,11 +546,11 @@ func (suite *WebInputHandlerSuite) TestListInputsByServiceAndProvider() {
 
 	assert.Equal(suite.T(), http.StatusOK, rr.Code)
 
-	var actualOutputs []outputdto.InputDTO
+	var actualOutputs outputdto.InputPageDTO
 	err := json.NewDecoder(rr.Body).Decode(&actualOutputs)
 	assert.NoError(suite.T(), err)
 
-	assert.Equal(suite.T(), expectedInputs, actualOutputs)
+	assert.Equal(suite.T(), expectedInputs, actualOutputs.Items)
 	suite.repoMock.AssertExpectations(suite.T())
 }
 
@@ -390,7 +577,7 @@ func (suite *WebInputHandlerSuite) TestListInputsBySourceAndProvider() {
 		},
 	}
 
-	suite.repoMock.On("FindAllBySourceAndProvider", "test_provider", "test_source").Return([]*entity.Input{
+	suite.repoMock.On("FindPageByFilter", entity.InputFilter{Provider: "test_provider", Source: "test_source"}, firstPage).Return([]*entity.Input{
 		{
 			ID: "test_id_1",
 			Metadata: entity.Metadata{
@@ -410,7 +597,7 @@ func (suite *WebInputHandlerSuite) TestListInputsBySourceAndProvider() {
 			CreatedAt: "2023-06-01 00:00:00",
 			UpdatedAt: "2023-06-01 00:00:00",
 		},
-	}, nil)
+	}, "", nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/inputs/provider/test_provider/source/test_source", nil)
 	rctx := chi.NewRouteContext()
@@ -423,11 +610,11 @@ func (suite *WebInputHandlerSuite) TestListInputsBySourceAndProvider() {
 
 	assert.Equal(suite.T(), http.StatusOK, rr.Code)
 
-	var actualOutputs []outputdto.InputDTO
+	var actualOutputs outputdto.InputPageDTO
 	err := json.NewDecoder(rr.Body).Decode(&actualOutputs)
 	assert.NoError(suite.T(), err)
 
-	assert.Equal(suite.T(), expectedInputs, actualOutputs)
+	assert.Equal(suite.T(), expectedInputs, actualOutputs.Items)
 	suite.repoMock.AssertExpectations(suite.T())
 }
 
@@ -454,7 +641,7 @@ func (suite *WebInputHandlerSuite) TestListInputsByServiceAndSourceAndProvider()
 		},
 	}
 
-	suite.repoMock.On("FindAllByServiceAndSourceAndProvider", "test_provider", "test_service", "test_source").Return([]*entity.Input{
+	suite.repoMock.On("FindPageByFilter", entity.InputFilter{Provider: "test_provider", Service: "test_service", Source: "test_source"}, firstPage).Return([]*entity.Input{
 		{
 			ID: "test_id_1",
 			Metadata: entity.Metadata{
@@ -474,7 +661,7 @@ func (suite *WebInputHandlerSuite) TestListInputsByServiceAndSourceAndProvider()
 			CreatedAt: "2023-06-01 00:00:00",
 			UpdatedAt: "2023-06-01 00:00:00",
 		},
-	}, nil)
+	}, "", nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/inputs/provider/test_provider/service/test_service/source/test_source", nil)
 	rctx := chi.NewRouteContext()
@@ -488,11 +675,11 @@ func (suite *WebInputHandlerSuite) TestListInputsByServiceAndSourceAndProvider()
 
 	assert.Equal(suite.T(), http.StatusOK, rr.Code)
 
-	var actualOutputs []outputdto.InputDTO
+	var actualOutputs outputdto.InputPageDTO
 	err := json.NewDecoder(rr.Body).Decode(&actualOutputs)
 	assert.NoError(suite.T(), err)
 
-	assert.Equal(suite.T(), expectedInputs, actualOutputs)
+	assert.Equal(suite.T(), expectedInputs, actualOutputs.Items)
 	suite.repoMock.AssertExpectations(suite.T())
 }
 
@@ -519,7 +706,8 @@ func (suite *WebInputHandlerSuite) TestListInputsByStatusAndProvider() {
 		},
 	}
 
-	suite.repoMock.On("FindAllByStatusAndProvider", "test_provider", 0).Return([]*entity.Input{
+	status := 0
+	suite.repoMock.On("FindPageByFilter", entity.InputFilter{Provider: "test_provider", Status: &status}, firstPage).Return([]*entity.Input{
 		{
 			ID: "test_id_1",
 			Metadata: entity.Metadata{
@@ -539,7 +727,7 @@ func (suite *WebInputHandlerSuite) TestListInputsByStatusAndProvider() {
 			CreatedAt: "2023-06-01 00:00:00",
 			UpdatedAt: "2023-06-01 00:00:00",
 		},
-	}, nil)
+	}, "", nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/inputs/provider/test_provider/status/0", nil)
 	rctx := chi.NewRouteContext()
@@ -552,11 +740,11 @@ func (suite *WebInputHandlerSuite) TestListInputsByStatusAndProvider() {
 
 	assert.Equal(suite.T(), http.StatusOK, rr.Code)
 
-	var actualOutputs []outputdto.InputDTO
+	var actualOutputs outputdto.InputPageDTO
 	err := json.NewDecoder(rr.Body).Decode(&actualOutputs)
 	assert.NoError(suite.T(), err)
 
-	assert.Equal(suite.T(), expectedInputs, actualOutputs)
+	assert.Equal(suite.T(), expectedInputs, actualOutputs.Items)
 	suite.repoMock.AssertExpectations(suite.T())
 }
 
@@ -583,7 +771,8 @@ func (suite *WebInputHandlerSuite) TestListInputsByStatusAndServiceAndProvider()
 		},
 	}
 
-	suite.repoMock.On("FindAllByStatusAndServiceAndProvider", "test_service", "test_provider", 0).Return([]*entity.Input{
+	status := 0
+	suite.repoMock.On("FindPageByFilter", entity.InputFilter{Provider: "test_provider", Service: "test_service", Status: &status}, firstPage).Return([]*entity.Input{
 		{
 			ID: "test_id_1",
 			Metadata: entity.Metadata{
@@ -603,7 +792,7 @@ func (suite *WebInputHandlerSuite) TestListInputsByStatusAndServiceAndProvider()
 			CreatedAt: "2023-06-01 00:00:00",
 			UpdatedAt: "2023-06-01 00:00:00",
 		},
-	}, nil)
+	}, "", nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/inputs/provider/test_provider/service/test_service/status/0", nil)
 	rctx := chi.NewRouteContext()
@@ -617,11 +806,11 @@ func (suite *WebInputHandlerSuite) TestListInputsByStatusAndServiceAndProvider()
 
 	assert.Equal(suite.T(), http.StatusOK, rr.Code)
 
-	var actualOutputs []outputdto.InputDTO
+	var actualOutputs outputdto.InputPageDTO
 	err := json.NewDecoder(rr.Body).Decode(&actualOutputs)
 	assert.NoError(suite.T(), err)
 
-	assert.Equal(suite.T(), expectedInputs, actualOutputs)
+	assert.Equal(suite.T(), expectedInputs, actualOutputs.Items)
 	suite.repoMock.AssertExpectations(suite.T())
 }
 
@@ -648,7 +837,8 @@ func (suite *WebInputHandlerSuite) TestListInputsByStatusAndSourceAndProvider()
 		},
 	}
 
-	suite.repoMock.On("FindAllByStatusAndSourceAndProvider", "test_source", "test_provider", 0).Return([]*entity.Input{
+	status := 0
+	suite.repoMock.On("FindPageByFilter", entity.InputFilter{Provider: "test_provider", Source: "test_source", Status: &status}, firstPage).Return([]*entity.Input{
 		{
 			ID: "test_id_1",
 			Metadata: entity.Metadata{
@@ -668,7 +858,7 @@ func (suite *WebInputHandlerSuite) TestListInputsByStatusAndSourceAndProvider()
 			CreatedAt: "2023-06-01 00:00:00",
 			UpdatedAt: "2023-06-01 00:00:00",
 		},
-	}, nil)
+	}, "", nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/inputs/provider/test_provider/source/test_source/status/0", nil)
 	rctx := chi.NewRouteContext()
@@ -682,11 +872,11 @@ func (suite *WebInputHandlerSuite) TestListInputsByStatusAndSourceAndProvider()
 
 	assert.Equal(suite.T(), http.StatusOK, rr.Code)
 
-	var actualOutputs []outputdto.InputDTO
+	var actualOutputs outputdto.InputPageDTO
 	err := json.NewDecoder(rr.Body).Decode(&actualOutputs)
 	assert.NoError(suite.T(), err)
 
-	assert.Equal(suite.T(), expectedInputs, actualOutputs)
+	assert.Equal(suite.T(), expectedInputs, actualOutputs.Items)
 	suite.repoMock.AssertExpectations(suite.T())
 }
 
@@ -713,7 +903,8 @@ func (suite *WebInputHandlerSuite) TestListInputsByStatusAndServiceAndSourceAndP
 		},
 	}
 
-	suite.repoMock.On("FindAllByStatusAndServiceAndSourceAndProvider", "test_service", "test_source", "test_provider", 0).Return([]*entity.Input{
+	status := 0
+	suite.repoMock.On("FindPageByFilter", entity.InputFilter{Provider: "test_provider", Service: "test_service", Source: "test_source", Status: &status}, firstPage).Return([]*entity.Input{
 		{
 			ID: "test_id_1",
 			Metadata: entity.Metadata{
@@ -733,7 +924,7 @@ func (suite *WebInputHandlerSuite) TestListInputsByStatusAndServiceAndSourceAndP
 			CreatedAt: "2023-06-01 00:00:00",
 			UpdatedAt: "2023-06-01 00:00:00",
 		},
-	}, nil)
+	}, "", nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/inputs/provider/test_provider/service/test_service/source/test_source/status/0", nil)
 	rctx := chi.NewRouteContext()
@@ -748,11 +939,11 @@ func (suite *WebInputHandlerSuite) TestListInputsByStatusAndServiceAndSourceAndP
 
 	assert.Equal(suite.T(), http.StatusOK, rr.Code)
 
-	var actualOutputs []outputdto.InputDTO
+	var actualOutputs outputdto.InputPageDTO
 	err := json.NewDecoder(rr.Body).Decode(&actualOutputs)
 	assert.NoError(suite.T(), err)
 
-	assert.Equal(suite.T(), expectedInputs, actualOutputs)
+	assert.Equal(suite.T(), expectedInputs, actualOutputs.Items)
 	suite.repoMock.AssertExpectations(suite.T())
 }
 
@@ -831,3 +1022,7 @@ func (suite *WebInputHandlerSuite) TestUpdateInputStatus() {
 	assert.Equal(suite.T(), expectedOutput, actualOutput)
 	suite.repoMock.AssertExpectations(suite.T())
 }
+
+func (suite *WebInputHandlerSuite) TestOperationsDocumentEveryHandler() {
+	openapitest.AssertDocumentsHandlers(suite.T(), &WebInputHandler{}, Operations)
+}
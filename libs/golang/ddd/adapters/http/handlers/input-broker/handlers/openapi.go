@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	inputdto "libs/golang/ddd/dtos/input-broker/input"
+	outputdto "libs/golang/ddd/dtos/input-broker/output"
+	shareddto "libs/golang/ddd/dtos/input-broker/shared"
+	"libs/golang/server/http/openapi/openapi"
+	"net/http"
+)
+
+// Operations documents the methods of WebInputHandler in the OpenAPI document of the service registering them, with
+// the statuses of the problems each method may answer.
+var Operations = openapi.Operations{
+	"CreateInput": {
+		Summary:         "Create an input",
+		Description:     "A request with an Idempotency-Key header can be retried safely: the input created with the key is returned instead of being created again.",
+		Headers:         map[string]string{idempotencyKeyHeader: "Key identifying the request across its retries"},
+		Request:         inputdto.InputDTO{},
+		Response:        outputdto.InputDTO{},
+		ResponseHeaders: map[string]string{idempotentReplayedHeader: "Set to true when the input was created by a previous request with the same key"},
+		Errors:          []int{http.StatusBadRequest, http.StatusConflict, http.StatusUnprocessableEntity, http.StatusInternalServerError},
+	},
+	"UpdateInput": {
+		Summary:  "Update an input",
+		Request:  inputdto.InputDTO{},
+		Response: outputdto.InputDTO{},
+		Errors:   []int{http.StatusBadRequest, http.StatusNotFound, http.StatusUnprocessableEntity, http.StatusInternalServerError},
+	},
+	"UpdateInputStatus": {
+		Summary:  "Update the status of an input",
+		Request:  shareddto.StatusDTO{},
+		Response: outputdto.InputDTO{},
+		Errors:   []int{http.StatusBadRequest, http.StatusNotFound, http.StatusUnprocessableEntity, http.StatusInternalServerError},
+	},
+	"DeleteInput": {
+		Summary:  "Delete an input",
+		Response: "Input deleted successfully",
+		Errors:   []int{http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+	},
+	"ListAllInputs": {
+		Summary:     "List the inputs selected by the query",
+		Description: "Every filter is optional, and the times are RFC 3339 timestamps. The limit, cursor and sort parameters select the page.",
+		Query:       inputdto.InputFilterDTO{},
+		Response:    outputdto.InputPageDTO{},
+		Errors:      []int{http.StatusBadRequest, http.StatusInternalServerError},
+	},
+	"ListInputByID": {
+		Summary:  "Get an input by ID",
+		Response: outputdto.InputDTO{},
+		Errors:   []int{http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+	},
+	"ListInputsByServiceAndProvider": {
+		Summary:  "List the inputs of a service",
+		Query:    openapi.PageQuery{},
+		Response: outputdto.InputPageDTO{},
+		Errors:   []int{http.StatusBadRequest, http.StatusInternalServerError},
+	},
+	"ListInputsBySourceAndProvider": {
+		Summary:  "List the inputs of a source",
+		Query:    openapi.PageQuery{},
+		Response: outputdto.InputPageDTO{},
+		Errors:   []int{http.StatusBadRequest, http.StatusInternalServerError},
+	},
+	"ListInputsByServiceAndSourceAndProvider": {
+		Summary:  "List the inputs of a service and source",
+		Query:    openapi.PageQuery{},
+		Response: outputdto.InputPageDTO{},
+		Errors:   []int{http.StatusBadRequest, http.StatusInternalServerError},
+	},
+	"ListInputsByStatusAndProvider": {
+		Summary:  "List the inputs of a provider by status code",
+		Query:    openapi.PageQuery{},
+		Response: outputdto.InputPageDTO{},
+		Errors:   []int{http.StatusBadRequest, http.StatusInternalServerError},
+	},
+	"ListInputsByStatusAndServiceAndProvider": {
+		Summary:  "List the inputs of a service by status code",
+		Query:    openapi.PageQuery{},
+		Response: outputdto.InputPageDTO{},
+		Errors:   []int{http.StatusBadRequest, http.StatusInternalServerError},
+	},
+	"ListInputsByStatusAndSourceAndProvider": {
+		Summary:  "List the inputs of a source by status code",
+		Query:    openapi.PageQuery{},
+		Response: outputdto.InputPageDTO{},
+		Errors:   []int{http.StatusBadRequest, http.StatusInternalServerError},
+	},
+	"ListInputsByStatusAndServiceAndSourceAndProvider": {
+		Summary:  "List the inputs of a service and source by status code",
+		Query:    openapi.PageQuery{},
+		Response: outputdto.InputPageDTO{},
+		Errors:   []int{http.StatusBadRequest, http.StatusInternalServerError},
+	},
+}
@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"libs/golang/ddd/domain/entities/input-broker/entity"
+	"libs/golang/ddd/usecases/input-broker/usecase"
+	"libs/golang/shared/go-criteria/criteria"
+	"libs/golang/shared/go-problem/problem"
+)
+
+// problems maps the errors of the input use cases to the problems written in the responses: missing inputs are 404
+// (Not Found), duplicated inputs 409 (Conflict), inputs breaking a rule of the domain and reused idempotency keys 422
+// (Unprocessable Entity), and invalid cursors and sorts 400 (Bad Request). Any other error is a 500 (Internal Server
+// Error).
+var problems = problem.Mapper{
+	{Err: entity.ErrNotFound, Problem: problem.ErrNotFound},
+	{Err: entity.ErrAlreadyExists, Problem: problem.ErrAlreadyExists},
+	{Err: entity.ErrInvalidID, Problem: problem.ErrInvalidEntity},
+	{Err: entity.ErrInvalidService, Problem: problem.ErrInvalidEntity},
+	{Err: entity.ErrInvalidSource, Problem: problem.ErrInvalidEntity},
+	{Err: entity.ErrInvalidProvider, Problem: problem.ErrInvalidEntity},
+	{Err: entity.ErrInvalidInputID, Problem: problem.ErrInvalidEntity},
+	{Err: entity.ErrInvalidProcessingID, Problem: problem.ErrInvalidEntity},
+	{Err: entity.ErrInvalidProcessingTimestamp, Problem: problem.ErrInvalidEntity},
+	{Err: entity.ErrInvalidData, Problem: problem.ErrInvalidEntity},
+	{Err: entity.ErrInvalidInputData, Problem: problem.ErrInvalidEntity},
+	{Err: entity.ErrInvalidStatusCode, Problem: problem.ErrInvalidEntity},
+	{Err: entity.ErrInvalidStatusDetail, Problem: problem.ErrInvalidEntity},
+	{Err: usecase.ErrIdempotencyKeyReused, Problem: problem.ErrIdempotencyKeyReused},
+	{Err: criteria.ErrInvalidCursor, Problem: problem.ErrInvalidCursor},
+	{Err: criteria.ErrInvalidSort, Problem: problem.ErrInvalidRequest},
+}
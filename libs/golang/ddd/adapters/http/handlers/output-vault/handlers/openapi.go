@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	inputdto "libs/golang/ddd/dtos/output-vault/input"
+	outputdto "libs/golang/ddd/dtos/output-vault/output"
+	"libs/golang/server/http/openapi/openapi"
+	"net/http"
+)
+
+// Operations documents the methods of WebOutputHandler in the OpenAPI document of the service registering them, with
+// the statuses of the problems each method may answer.
+var Operations = openapi.Operations{
+	"CreateOutput": {
+		Summary:  "Create an output",
+		Request:  inputdto.OutputDTO{},
+		Response: outputdto.OutputDTO{},
+		Errors:   []int{http.StatusBadRequest, http.StatusConflict, http.StatusUnprocessableEntity, http.StatusInternalServerError},
+	},
+	"UpdateOutput": {
+		Summary:  "Update an output",
+		Request:  inputdto.OutputDTO{},
+		Response: outputdto.OutputDTO{},
+		Errors:   []int{http.StatusBadRequest, http.StatusNotFound, http.StatusUnprocessableEntity, http.StatusInternalServerError},
+	},
+	"DeleteOutput": {
+		Summary:  "Delete an output",
+		Response: "Output deleted successfully",
+		Errors:   []int{http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+	},
+	"ListAllOutputs": {
+		Summary:     "List the outputs selected by the query",
+		Description: "Every filter is optional, and the times are RFC 3339 timestamps. The limit, cursor and sort parameters select the page.",
+		Query:       inputdto.OutputFilterDTO{},
+		Response:    outputdto.OutputPageDTO{},
+		Errors:      []int{http.StatusBadRequest, http.StatusInternalServerError},
+	},
+	"ListOutputByID": {
+		Summary:  "Get an output by ID",
+		Response: outputdto.OutputDTO{},
+		Errors:   []int{http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+	},
+	"ListOutputsByServiceAndProvider": {
+		Summary:  "List the outputs of a service",
+		Query:    openapi.PageQuery{},
+		Response: outputdto.OutputPageDTO{},
+		Errors:   []int{http.StatusBadRequest, http.StatusInternalServerError},
+	},
+	"ListOutputsBySourceAndProvider": {
+		Summary:  "List the outputs of a source",
+		Query:    openapi.PageQuery{},
+		Response: outputdto.OutputPageDTO{},
+		Errors:   []int{http.StatusBadRequest, http.StatusInternalServerError},
+	},
+	"ListOutputsByServiceAndSourceAndProvider": {
+		Summary:  "List the outputs of a service and source",
+		Query:    openapi.PageQuery{},
+		Response: outputdto.OutputPageDTO{},
+		Errors:   []int{http.StatusBadRequest, http.StatusInternalServerError},
+	},
+}
@@ -5,14 +5,19 @@ import (
 	"libs/golang/ddd/domain/entities/output-vault/entity"
 	inputdto "libs/golang/ddd/dtos/output-vault/input"
 	"libs/golang/ddd/usecases/output-vault/usecase"
+	events "libs/golang/shared/go-events/amqp_events"
+	"libs/golang/shared/go-problem/problem"
+	"libs/golang/shared/go-validator/validator"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
 )
 
-// WebOutputHandler represents the handler for the output vault.
+// WebOutputHandler represents the handler for the output vault. Failed requests are answered
+// with RFC 7807 problem details, whose status and code are chosen by problems.
 type WebOutputHandler struct {
-	OutputRepository entity.OutputRepositoryInterface
+	OutputRepository      entity.OutputRepositoryInterface
+	NewOutputCreatedEvent func() events.EventInterface
 }
 
 // NewWebOutputHandler initializes a new instance of WebOutputHandler with the provided OutputRepositoryInterface.
@@ -20,15 +25,18 @@ type WebOutputHandler struct {
 // Parameters:
 //
 //	outputRepository: The repository interface for managing Output entities.
+//	newOutputCreatedEvent: Builds the event stored in the outbox for each created output.
 //
 // Returns:
 //
 //	A pointer to an instance of WebOutputHandler.
 func NewWebOutputHandler(
 	outputRepository entity.OutputRepositoryInterface,
+	newOutputCreatedEvent func() events.EventInterface,
 ) *WebOutputHandler {
 	return &WebOutputHandler{
-		OutputRepository: outputRepository,
+		OutputRepository:      outputRepository,
+		NewOutputCreatedEvent: newOutputCreatedEvent,
 	}
 }
 
@@ -44,26 +52,28 @@ func NewWebOutputHandler(
 //
 //	None.
 //
-// If the request body cannot be decoded, it responds with HTTP status 400 (Bad Request).
-// If an error occurs during the creation process, it responds with HTTP status 500 (Internal Server Error).
+// If the request body cannot be decoded, it responds with HTTP status 400 (Bad Request), and if fields of the body are
+// missing or invalid, with HTTP status 422 (Unprocessable Entity) listing every invalid field.
+// If the output breaks a rule of the domain, it responds with HTTP status 422 (Unprocessable Entity), if it already
+// exists, with HTTP status 409 (Conflict), and if another error occurs during the creation process, with HTTP status
+// 500 (Internal Server Error).
 func (h *WebOutputHandler) CreateOutput(w http.ResponseWriter, r *http.Request) {
 	var dto inputdto.OutputDTO
-	err := json.NewDecoder(r.Body).Decode(&dto)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	if err := validator.Decode(w, r, &dto); err != nil {
+		problems.Write(w, r, err)
 		return
 	}
 
-	createOutputUseCase := usecase.NewCreateOutputUseCase(h.OutputRepository)
+	createOutputUseCase := usecase.NewCreateOutputUseCase(h.OutputRepository, h.NewOutputCreatedEvent)
 	outputCreated, err := createOutputUseCase.Execute(dto)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problems.Write(w, r, err)
 		return
 	}
 
 	err = json.NewEncoder(w).Encode(outputCreated)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problems.Write(w, r, err)
 		return
 	}
 }
@@ -80,26 +90,28 @@ func (h *WebOutputHandler) CreateOutput(w http.ResponseWriter, r *http.Request)
 //
 //	None.
 //
-// If the request body cannot be decoded, it responds with HTTP status 400 (Bad Request).
-// If an error occurs during the update process, it responds with HTTP status 500 (Internal Server Error).
+// If the request body cannot be decoded, it responds with HTTP status 400 (Bad Request), and if fields of the body are
+// missing or invalid, with HTTP status 422 (Unprocessable Entity) listing every invalid field.
+// If the output breaks a rule of the domain, it responds with HTTP status 422 (Unprocessable Entity), if it does not
+// exist, with HTTP status 404 (Not Found), and if another error occurs during the update process, with HTTP status
+// 500 (Internal Server Error).
 func (h *WebOutputHandler) UpdateOutput(w http.ResponseWriter, r *http.Request) {
 	var dto inputdto.OutputDTO
-	err := json.NewDecoder(r.Body).Decode(&dto)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	if err := validator.Decode(w, r, &dto); err != nil {
+		problems.Write(w, r, err)
 		return
 	}
 
 	updateOutputUseCase := usecase.NewUpdateOutputUseCase(h.OutputRepository)
 	outputUpdated, err := updateOutputUseCase.Execute(dto)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problems.Write(w, r, err)
 		return
 	}
 
 	err = json.NewEncoder(w).Encode(outputUpdated)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problems.Write(w, r, err)
 		return
 	}
 }
@@ -116,18 +128,19 @@ func (h *WebOutputHandler) UpdateOutput(w http.ResponseWriter, r *http.Request)
 //
 //	None.
 //
-// If an error occurs during the deletion process, it responds with HTTP status 500 (Internal Server Error).
+// If the output does not exist, it responds with HTTP status 404 (Not Found), and if another error occurs during the
+// deletion process, with HTTP status 500 (Internal Server Error).
 func (h *WebOutputHandler) DeleteOutput(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 	if id == "" {
-		http.Error(w, "ID is required", http.StatusBadRequest)
+		problem.Write(w, r, problem.ErrInvalidRequest.WithDetail("ID is required"))
 		return
 	}
 
 	deleteOutputUseCase := usecase.NewDeleteOutputUseCase(h.OutputRepository)
 	err := deleteOutputUseCase.Execute(id)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problems.Write(w, r, err)
 		return
 	}
 
@@ -135,8 +148,10 @@ func (h *WebOutputHandler) DeleteOutput(w http.ResponseWriter, r *http.Request)
 	w.Write([]byte(`Output deleted successfully`))
 }
 
-// ListAllOutputs handles HTTP GET requests to list all outputs. It executes the ListAllOutputUseCase and writes the
-// outputs as a JSON response.
+// ListAllOutputs handles HTTP GET requests to list the outputs selected by the query parameters, such as
+// `GET /output?provider=acme&service=billing`. Every parameter is optional: provider, service, source,
+// created_after and created_before, the times being RFC 3339 timestamps. The limit, cursor and sort parameters
+// select the page, and the page is written as a JSON response with the cursor of the next page.
 //
 // Parameters:
 //
@@ -147,20 +162,54 @@ func (h *WebOutputHandler) DeleteOutput(w http.ResponseWriter, r *http.Request)
 //
 //	None.
 //
-// If an error occurs during the listing process, it responds with HTTP status 500 (Internal Server Error).
+// If a query parameter is invalid, it responds with HTTP status 400 (Bad Request), and if an error occurs during the
+// listing process, with HTTP status 500 (Internal Server Error).
 func (h *WebOutputHandler) ListAllOutputs(w http.ResponseWriter, r *http.Request) {
-	listAllOutputUseCase := usecase.NewListAllOutputUseCase(h.OutputRepository)
-	outputs, err := listAllOutputUseCase.Execute()
+	filterDTO, err := inputdto.NewOutputFilterDTO(r.URL.Query())
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problem.Write(w, r, problem.ErrInvalidRequest.WithDetail(err.Error()))
 		return
 	}
+	h.listOutputs(w, r, filterDTO)
+}
 
-	err = json.NewEncoder(w).Encode(outputs)
+// listOutputs lists a page of the outputs selected by the filter and writes it as a JSON response.
+//
+// Parameters:
+//
+//	w: The HTTP response writer.
+//	filterDTO: The filter selecting the outputs and the page.
+func (h *WebOutputHandler) listOutputs(w http.ResponseWriter, r *http.Request, filterDTO inputdto.OutputFilterDTO) {
+	listPageByFilterOutputUseCase := usecase.NewListPageByFilterOutputUseCase(h.OutputRepository)
+	page, err := listPageByFilterOutputUseCase.Execute(filterDTO)
+	if err != nil {
+		problems.Write(w, r, err)
+		return
+	}
+
+	err = json.NewEncoder(w).Encode(page)
+	if err != nil {
+		problems.Write(w, r, err)
+		return
+	}
+}
+
+// listOutputsByPath lists the outputs of the routes filtering on path parameters, which are aliases of ListAllOutputs. The
+// filter built from the path replaces the filter of the query parameters, which still select the page.
+//
+// Parameters:
+//
+//	w: The HTTP response writer.
+//	r: The HTTP request.
+//	pathFilterDTO: The filter built from the path parameters.
+func (h *WebOutputHandler) listOutputsByPath(w http.ResponseWriter, r *http.Request, pathFilterDTO inputdto.OutputFilterDTO) {
+	filterDTO, err := inputdto.NewOutputFilterDTO(r.URL.Query())
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problem.Write(w, r, problem.ErrInvalidRequest.WithDetail(err.Error()))
 		return
 	}
+	pathFilterDTO.Limit, pathFilterDTO.Cursor, pathFilterDTO.Sort = filterDTO.Limit, filterDTO.Cursor, filterDTO.Sort
+	h.listOutputs(w, r, pathFilterDTO)
 }
 
 // ListOutputByID handles HTTP GET requests to list a output by its ID. It extracts the output ID from the request URL,
@@ -175,24 +224,25 @@ func (h *WebOutputHandler) ListAllOutputs(w http.ResponseWriter, r *http.Request
 //
 //	None.
 //
-// If an error occurs during the listing process, it responds with HTTP status 500 (Internal Server Error).
+// If the output does not exist, it responds with HTTP status 404 (Not Found), and if another error occurs during the
+// listing process, with HTTP status 500 (Internal Server Error).
 func (h *WebOutputHandler) ListOutputByID(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 	if id == "" {
-		http.Error(w, "ID is required", http.StatusBadRequest)
+		problem.Write(w, r, problem.ErrInvalidRequest.WithDetail("ID is required"))
 		return
 	}
 
 	listOneByIDOutputUseCase := usecase.NewListOneByIDOutputUseCase(h.OutputRepository)
 	output, err := listOneByIDOutputUseCase.Execute(id)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problems.Write(w, r, err)
 		return
 	}
 
 	err = json.NewEncoder(w).Encode(output)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problems.Write(w, r, err)
 		return
 	}
 }
@@ -215,22 +265,11 @@ func (h *WebOutputHandler) ListOutputsByServiceAndProvider(w http.ResponseWriter
 	provider := chi.URLParam(r, "provider")
 	service := chi.URLParam(r, "service")
 	if service == "" || provider == "" {
-		http.Error(w, "Service and provider are required", http.StatusBadRequest)
-		return
-	}
-
-	listAllByServiceAndProviderOutputUseCase := usecase.NewListAllByServiceAndProviderOutputUseCase(h.OutputRepository)
-	outputs, err := listAllByServiceAndProviderOutputUseCase.Execute(provider, service)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problem.Write(w, r, problem.ErrInvalidRequest.WithDetail("Service and provider are required"))
 		return
 	}
 
-	err = json.NewEncoder(w).Encode(outputs)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
+	h.listOutputsByPath(w, r, inputdto.OutputFilterDTO{Provider: provider, Service: service})
 }
 
 // ListOutputsBySourceAndProvider handles HTTP GET requests to list all outputs by source and provider. It extracts the
@@ -251,22 +290,11 @@ func (h *WebOutputHandler) ListOutputsBySourceAndProvider(w http.ResponseWriter,
 	provider := chi.URLParam(r, "provider")
 	source := chi.URLParam(r, "source")
 	if source == "" || provider == "" {
-		http.Error(w, "Source and provider are required", http.StatusBadRequest)
-		return
-	}
-
-	listAllBySourceAndProviderOutputUseCase := usecase.NewListAllBySourceAndProviderOutputUseCase(h.OutputRepository)
-	outputs, err := listAllBySourceAndProviderOutputUseCase.Execute(provider, source)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problem.Write(w, r, problem.ErrInvalidRequest.WithDetail("Source and provider are required"))
 		return
 	}
 
-	err = json.NewEncoder(w).Encode(outputs)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
+	h.listOutputsByPath(w, r, inputdto.OutputFilterDTO{Provider: provider, Source: source})
 }
 
 // ListOutputsByServiceAndSourceAndProvider handles HTTP GET requests to list all outputs by service, source, and provider.
@@ -288,20 +316,9 @@ func (h *WebOutputHandler) ListOutputsByServiceAndSourceAndProvider(w http.Respo
 	service := chi.URLParam(r, "service")
 	source := chi.URLParam(r, "source")
 	if service == "" || source == "" || provider == "" {
-		http.Error(w, "Service, source and provider are required", http.StatusBadRequest)
+		problem.Write(w, r, problem.ErrInvalidRequest.WithDetail("Service, source and provider are required"))
 		return
 	}
 
-	listAllByServiceAndSourceAndProviderOutputUseCase := usecase.NewListAllByServiceAndSourceAndProviderOutputUseCase(h.OutputRepository)
-	outputs, err := listAllByServiceAndSourceAndProviderOutputUseCase.Execute(provider, service, source)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-
-	err = json.NewEncoder(w).Encode(outputs)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
+	h.listOutputsByPath(w, r, inputdto.OutputFilterDTO{Provider: provider, Service: service, Source: source})
 }
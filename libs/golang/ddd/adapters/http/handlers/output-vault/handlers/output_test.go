@@ -5,11 +5,17 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"libs/golang/ddd/domain/entities/output-vault/entity"
 	mockrepository "libs/golang/ddd/domain/repositories/database/mock/output-vault/repository"
 	inputdto "libs/golang/ddd/dtos/output-vault/input"
 	outputdto "libs/golang/ddd/dtos/output-vault/output"
 	shareddto "libs/golang/ddd/dtos/output-vault/shared"
+	mockevent "libs/golang/ddd/events/event-mock/mock"
+	"libs/golang/server/http/openapi/openapitest"
+	"libs/golang/shared/go-criteria/criteria"
+	events "libs/golang/shared/go-events/amqp_events"
+	"libs/golang/shared/go-problem/problem"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -20,10 +26,22 @@ import (
 	"github.com/stretchr/testify/suite"
 )
 
+// firstPage is the page read when the request does not set the limit, cursor and sort parameters.
+var firstPage = criteria.Page{Sort: criteria.NewSort("created_at")}
+
+// decodeProblem reads the problem details written in the response.
+func decodeProblem(t *testing.T, rr *httptest.ResponseRecorder) problem.Problem {
+	assert.Equal(t, problem.ContentType, rr.Header().Get("Content-Type"))
+	var p problem.Problem
+	assert.NoError(t, json.NewDecoder(rr.Body).Decode(&p))
+	return p
+}
+
 type WebOutputHandlerSuite struct {
 	suite.Suite
-	handler  *WebOutputHandler
-	repoMock *mockrepository.OutputRepositoryMock
+	handler   *WebOutputHandler
+	repoMock  *mockrepository.OutputRepositoryMock
+	eventMock *mockevent.MockEvent
 }
 
 func TestWebOutputHandlerSuite(t *testing.T) {
@@ -32,7 +50,8 @@ func TestWebOutputHandlerSuite(t *testing.T) {
 
 func (suite *WebOutputHandlerSuite) SetupTest() {
 	suite.repoMock = new(mockrepository.OutputRepositoryMock)
-	suite.handler = NewWebOutputHandler(suite.repoMock)
+	suite.eventMock = new(mockevent.MockEvent)
+	suite.handler = NewWebOutputHandler(suite.repoMock, func() events.EventInterface { return suite.eventMock })
 }
 
 // Tests for CreateOutput handler
@@ -55,48 +74,16 @@ func (suite *WebOutputHandlerSuite) TestCreateOutputWhenSuccess() {
 		},
 	}
 
-	expectedOutput := outputdto.OutputDTO{
-		ID:       "1",
-		Service:  "test_service",
-		Source:   "test_source",
-		Provider: "test_provider",
-		Data: map[string]interface{}{
-			"field1": "value1",
-			"field2": "value2",
-		},
-		Metadata: shareddto.MetadataDTO{
-			InputID: "input1",
-			Input: shareddto.InputDTO{
-				Data:                map[string]interface{}{"key": "value"},
-				ProcessingID:        "processing1",
-				ProcessingTimestamp: "2021-06-01 00:00:00",
-			},
-		},
-		CreatedAt: "2023-06-01 00:00:00",
-		UpdatedAt: "2023-06-01 00:00:00",
-	}
-
-	suite.repoMock.On("Create", mock.AnythingOfType("*entity.Output")).Return(nil).Run(func(args mock.Arguments) {
-		arg := args.Get(0).(*entity.Output)
-		arg.ID = "1"
-		arg.Service = "test_service"
-		arg.Source = "test_source"
-		arg.Provider = "test_provider"
-		arg.Data = map[string]interface{}{
-			"field1": "value1",
-			"field2": "value2",
-		}
-		arg.Metadata = entity.Metadata{
-			InputID: "input1",
-			Input: entity.Input{
-				Data:                map[string]interface{}{"key": "value"},
-				ProcessingID:        "processing1",
-				ProcessingTimestamp: "2021-06-01 00:00:00",
-			},
-		}
-		arg.CreatedAt = "2023-06-01 00:00:00"
-		arg.UpdatedAt = "2023-06-01 00:00:00"
+	var storedOutput *entity.Output
+	suite.repoMock.On(
+		"CreateWithEvent",
+		mock.AnythingOfType("*entity.Output"),
+		suite.eventMock,
+		"output.created.test_provider.test_service.test_source",
+	).Return(nil).Run(func(args mock.Arguments) {
+		storedOutput = args.Get(0).(*entity.Output)
 	})
+	suite.eventMock.On("SetPayload", mock.AnythingOfType("outputdto.OutputDTO")).Return()
 
 	jsonBody, _ := json.Marshal(inputDTO)
 	req := httptest.NewRequest(http.MethodPost, "/outputs", bytes.NewBuffer(jsonBody))
@@ -111,8 +98,18 @@ func (suite *WebOutputHandlerSuite) TestCreateOutputWhenSuccess() {
 	err := json.NewDecoder(rr.Body).Decode(&actualOutput)
 	assert.NoError(suite.T(), err)
 
-	assert.Equal(suite.T(), expectedOutput, actualOutput)
+	assert.Equal(suite.T(), outputdto.OutputDTO{
+		ID:        string(storedOutput.ID),
+		Service:   "test_service",
+		Source:    "test_source",
+		Provider:  "test_provider",
+		Data:      inputDTO.Data,
+		Metadata:  inputDTO.Metadata,
+		CreatedAt: storedOutput.CreatedAt,
+		UpdatedAt: storedOutput.UpdatedAt,
+	}, actualOutput)
 	suite.repoMock.AssertExpectations(suite.T())
+	suite.eventMock.AssertExpectations(suite.T())
 }
 
 func (suite *WebOutputHandlerSuite) TestCreateOutputWhenDecodingFails() {
@@ -126,6 +123,26 @@ func (suite *WebOutputHandlerSuite) TestCreateOutputWhenDecodingFails() {
 	assert.Contains(suite.T(), rr.Body.String(), "invalid character")
 }
 
+func (suite *WebOutputHandlerSuite) TestCreateOutputWhenInvalid() {
+	req := httptest.NewRequest(http.MethodPost, "/outputs", bytes.NewBuffer([]byte(`{"provider": "test_provider", "source": "test_source"}`)))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+
+	suite.handler.CreateOutput(rr, req)
+
+	assert.Equal(suite.T(), http.StatusUnprocessableEntity, rr.Code)
+	problemDetails := decodeProblem(suite.T(), rr)
+	assert.Equal(suite.T(), problem.CodeValidationFailed, problemDetails.Code)
+	assert.Equal(suite.T(), []problem.FieldError{
+		{Field: "data", Message: "is required"},
+		{Field: "service", Message: "is required"},
+		{Field: "metadata.input_id", Message: "is required"},
+		{Field: "metadata.input.data", Message: "is required"},
+		{Field: "metadata.input.processing_id", Message: "is required"},
+		{Field: "metadata.input.processing_timestamp", Message: "is required"},
+	}, problemDetails.Errors)
+}
+
 func (suite *WebOutputHandlerSuite) TestCreateOutputWhenRepositoryFails() {
 	inputDTO := inputdto.OutputDTO{
 		Service:  "test_service",
@@ -145,7 +162,8 @@ func (suite *WebOutputHandlerSuite) TestCreateOutputWhenRepositoryFails() {
 		},
 	}
 
-	suite.repoMock.On("Create", mock.AnythingOfType("*entity.Output")).Return(errors.New("repository error"))
+	suite.eventMock.On("SetPayload", mock.Anything).Return()
+	suite.repoMock.On("CreateWithEvent", mock.AnythingOfType("*entity.Output"), suite.eventMock, mock.Anything).Return(errors.New("repository error"))
 
 	jsonBody, _ := json.Marshal(inputDTO)
 	req := httptest.NewRequest(http.MethodPost, "/outputs", bytes.NewBuffer(jsonBody))
@@ -159,6 +177,40 @@ func (suite *WebOutputHandlerSuite) TestCreateOutputWhenRepositoryFails() {
 	suite.repoMock.AssertExpectations(suite.T())
 }
 
+func (suite *WebOutputHandlerSuite) TestCreateOutputWhenAlreadyExists() {
+	inputDTO := inputdto.OutputDTO{
+		Service:  "test_service",
+		Source:   "test_source",
+		Provider: "test_provider",
+		Data: map[string]interface{}{
+			"field1": "value1",
+			"field2": "value2",
+		},
+		Metadata: shareddto.MetadataDTO{
+			InputID: "input1",
+			Input: shareddto.InputDTO{
+				Data:                map[string]interface{}{"key": "value"},
+				ProcessingID:        "processing1",
+				ProcessingTimestamp: "2021-06-01 00:00:00",
+			},
+		},
+	}
+
+	suite.eventMock.On("SetPayload", mock.Anything).Return()
+	suite.repoMock.On("CreateWithEvent", mock.AnythingOfType("*entity.Output"), suite.eventMock, mock.Anything).Return(fmt.Errorf("%w: ID 1", entity.ErrAlreadyExists))
+
+	jsonBody, _ := json.Marshal(inputDTO)
+	req := httptest.NewRequest(http.MethodPost, "/outputs", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+
+	suite.handler.CreateOutput(rr, req)
+
+	assert.Equal(suite.T(), http.StatusConflict, rr.Code)
+	assert.Equal(suite.T(), problem.CodeAlreadyExists, decodeProblem(suite.T(), rr).Code)
+	suite.repoMock.AssertExpectations(suite.T())
+}
+
 // Tests for UpdateOutput handler
 func (suite *WebOutputHandlerSuite) TestUpdateOutputWhenSuccess() {
 	inputDTO := inputdto.OutputDTO{
@@ -328,6 +380,22 @@ func (suite *WebOutputHandlerSuite) TestDeleteOutputWhenRepositoryFails() {
 	suite.repoMock.AssertExpectations(suite.T())
 }
 
+func (suite *WebOutputHandlerSuite) TestDeleteOutputWhenNotFound() {
+	suite.repoMock.On("Delete", "1").Return(fmt.Errorf("%w: ID 1", entity.ErrNotFound))
+
+	req := httptest.NewRequest("DELETE", "/outputs/1", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "1")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	rr := httptest.NewRecorder()
+
+	suite.handler.DeleteOutput(rr, req)
+
+	assert.Equal(suite.T(), http.StatusNotFound, rr.Code)
+	assert.Equal(suite.T(), problem.CodeNotFound, decodeProblem(suite.T(), rr).Code)
+	suite.repoMock.AssertExpectations(suite.T())
+}
+
 // Tests for ListAllOutputs handler
 func (suite *WebOutputHandlerSuite) TestListAllOutputsWhenSuccess() {
 	entityOutputs := []*entity.Output{
@@ -373,7 +441,7 @@ func (suite *WebOutputHandlerSuite) TestListAllOutputsWhenSuccess() {
 		},
 	}
 
-	suite.repoMock.On("FindAll").Return(entityOutputs, nil)
+	suite.repoMock.On("FindPageByFilter", entity.OutputFilter{}, firstPage).Return(entityOutputs, "", nil)
 
 	expectedOutput := []outputdto.OutputDTO{
 		{
@@ -425,16 +493,16 @@ func (suite *WebOutputHandlerSuite) TestListAllOutputsWhenSuccess() {
 
 	assert.Equal(suite.T(), http.StatusOK, rr.Code)
 
-	var actualOutput []outputdto.OutputDTO
+	var actualOutput outputdto.OutputPageDTO
 	err := json.NewDecoder(rr.Body).Decode(&actualOutput)
 	assert.NoError(suite.T(), err)
 
-	assert.Equal(suite.T(), expectedOutput, actualOutput)
+	assert.Equal(suite.T(), expectedOutput, actualOutput.Items)
 	suite.repoMock.AssertExpectations(suite.T())
 }
 
 func (suite *WebOutputHandlerSuite) TestListAllOutputsWhenRepositoryFails() {
-	suite.repoMock.On("FindAll").Return(nil, errors.New("repository error"))
+	suite.repoMock.On("FindPageByFilter", entity.OutputFilter{}, firstPage).Return(nil, "", errors.New("repository error"))
 
 	req := httptest.NewRequest(http.MethodGet, "/outputs", nil)
 	rr := httptest.NewRecorder()
@@ -446,6 +514,46 @@ func (suite *WebOutputHandlerSuite) TestListAllOutputsWhenRepositoryFails() {
 	suite.repoMock.AssertExpectations(suite.T())
 }
 
+func (suite *WebOutputHandlerSuite) TestListAllOutputsWithPage() {
+	page := criteria.Page{Limit: 2, Cursor: "cursor", Sort: criteria.NewSort("-updated_at")}
+	suite.repoMock.On("FindPageByFilter", entity.OutputFilter{}, page).Return([]*entity.Output{}, "next", nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/output?limit=2&cursor=cursor&sort=-updated_at", nil)
+	rr := httptest.NewRecorder()
+
+	suite.handler.ListAllOutputs(rr, req)
+
+	assert.Equal(suite.T(), http.StatusOK, rr.Code)
+	var actualPage outputdto.OutputPageDTO
+	assert.NoError(suite.T(), json.NewDecoder(rr.Body).Decode(&actualPage))
+	assert.Equal(suite.T(), outputdto.OutputPageDTO{Items: []outputdto.OutputDTO{}, NextCursor: "next"}, actualPage)
+	suite.repoMock.AssertExpectations(suite.T())
+}
+
+func (suite *WebOutputHandlerSuite) TestListAllOutputsWhenCursorIsInvalid() {
+	suite.repoMock.On("FindPageByFilter", entity.OutputFilter{}, criteria.Page{Cursor: "invalid", Sort: criteria.NewSort("created_at")}).
+		Return(nil, "", criteria.ErrInvalidCursor)
+
+	req := httptest.NewRequest(http.MethodGet, "/output?cursor=invalid", nil)
+	rr := httptest.NewRecorder()
+
+	suite.handler.ListAllOutputs(rr, req)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, rr.Code)
+	assert.Equal(suite.T(), problem.CodeInvalidCursor, decodeProblem(suite.T(), rr).Code)
+	suite.repoMock.AssertExpectations(suite.T())
+}
+
+func (suite *WebOutputHandlerSuite) TestListAllOutputsWhenLimitIsInvalid() {
+	req := httptest.NewRequest(http.MethodGet, "/output?limit=0", nil)
+	rr := httptest.NewRecorder()
+
+	suite.handler.ListAllOutputs(rr, req)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, rr.Code)
+	suite.repoMock.AssertNotCalled(suite.T(), "FindPageByFilter", mock.Anything, mock.Anything)
+}
+
 // Tests for ListOutputByID handler
 func (suite *WebOutputHandlerSuite) TestListOutputByIDWhenSuccess() {
 	entityOutputs := []*entity.Output{
@@ -560,6 +668,22 @@ func (suite *WebOutputHandlerSuite) TestListOutputByIDWhenRepositoryFails() {
 	suite.repoMock.AssertExpectations(suite.T())
 }
 
+func (suite *WebOutputHandlerSuite) TestListOutputByIDWhenNotFound() {
+	suite.repoMock.On("FindByID", "1").Return(nil, fmt.Errorf("%w: ID 1", entity.ErrNotFound))
+
+	req := httptest.NewRequest(http.MethodGet, "/outputs/1", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "1")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	rr := httptest.NewRecorder()
+
+	suite.handler.ListOutputByID(rr, req)
+
+	assert.Equal(suite.T(), http.StatusNotFound, rr.Code)
+	assert.Equal(suite.T(), problem.CodeNotFound, decodeProblem(suite.T(), rr).Code)
+	suite.repoMock.AssertExpectations(suite.T())
+}
+
 // Tests for ListOutputsByServiceAndProvider handler
 func (suite *WebOutputHandlerSuite) TestListOutputsByServiceAndProviderWhenSuccess() {
 	entityOutputs := []*entity.Output{
@@ -585,7 +709,7 @@ func (suite *WebOutputHandlerSuite) TestListOutputsByServiceAndProviderWhenSucce
 		},
 	}
 
-	suite.repoMock.On("FindAllByServiceAndProvider", "test_provider", "test_service").Return(entityOutputs, nil)
+	suite.repoMock.On("FindPageByFilter", entity.OutputFilter{Provider: "test_provider", Service: "test_service"}, firstPage).Return(entityOutputs, "", nil)
 
 	expectedOutput := []outputdto.OutputDTO{
 		{
@@ -621,11 +745,11 @@ func (suite *WebOutputHandlerSuite) TestListOutputsByServiceAndProviderWhenSucce
 
 	assert.Equal(suite.T(), http.StatusOK, rr.Code)
 
-	var actualOutput []outputdto.OutputDTO
+	var actualOutput outputdto.OutputPageDTO
 	err := json.NewDecoder(rr.Body).Decode(&actualOutput)
 	assert.NoError(suite.T(), err)
 
-	assert.Equal(suite.T(), expectedOutput, actualOutput)
+	assert.Equal(suite.T(), expectedOutput, actualOutput.Items)
 	suite.repoMock.AssertExpectations(suite.T())
 }
 
@@ -643,7 +767,7 @@ func (suite *WebOutputHandlerSuite) TestListOutputsByServiceAndProviderWhenServi
 }
 
 func (suite *WebOutputHandlerSuite) TestListOutputsByServiceAndProviderWhenRepositoryFails() {
-	suite.repoMock.On("FindAllByServiceAndProvider", "test_provider", "test_service").Return(nil, errors.New("repository error"))
+	suite.repoMock.On("FindPageByFilter", entity.OutputFilter{Provider: "test_provider", Service: "test_service"}, firstPage).Return(nil, "", errors.New("repository error"))
 
 	req := httptest.NewRequest("GET", "/outputs/service/test_service/provider/test_provider", nil)
 	rctx := chi.NewRouteContext()
@@ -684,7 +808,7 @@ func (suite *WebOutputHandlerSuite) TestListOutputsBySourceAndProviderWhenSucces
 		},
 	}
 
-	suite.repoMock.On("FindAllBySourceAndProvider", "test_provider", "test_source").Return(entityOutputs, nil)
+	suite.repoMock.On("FindPageByFilter", entity.OutputFilter{Provider: "test_provider", Source: "test_source"}, firstPage).Return(entityOutputs, "", nil)
 
 	expectedOutput := []outputdto.OutputDTO{
 		{
@@ -720,16 +844,16 @@ func (suite *WebOutputHandlerSuite) TestListOutputsBySourceAndProviderWhenSucces
 
 	assert.Equal(suite.T(), http.StatusOK, rr.Code)
 
-	var actualOutput []outputdto.OutputDTO
+	var actualOutput outputdto.OutputPageDTO
 	err := json.NewDecoder(rr.Body).Decode(&actualOutput)
 	assert.NoError(suite.T(), err)
 
-	assert.Equal(suite.T(), expectedOutput, actualOutput)
+	assert.Equal(suite.T(), expectedOutput, actualOutput.Items)
 	suite.repoMock.AssertExpectations(suite.T())
 }
 
 func (suite *WebOutputHandlerSuite) TestListOutputsBySourceAndProviderWhenRepositoryFails() {
-	suite.repoMock.On("FindAllBySourceAndProvider", "test_provider", "test_source").Return(nil, errors.New("repository error"))
+	suite.repoMock.On("FindPageByFilter", entity.OutputFilter{Provider: "test_provider", Source: "test_source"}, firstPage).Return(nil, "", errors.New("repository error"))
 
 	req := httptest.NewRequest("GET", "/outputs/source/test_source/provider/test_provider", nil)
 	rctx := chi.NewRouteContext()
@@ -745,6 +869,23 @@ func (suite *WebOutputHandlerSuite) TestListOutputsBySourceAndProviderWhenReposi
 	suite.repoMock.AssertExpectations(suite.T())
 }
 
+func (suite *WebOutputHandlerSuite) TestListOutputsBySourceAndProviderWithPage() {
+	page := criteria.Page{Limit: 10, Cursor: "cursor", Sort: criteria.NewSort("created_at")}
+	suite.repoMock.On("FindPageByFilter", entity.OutputFilter{Provider: "test_provider", Source: "test_source"}, page).Return([]*entity.Output{}, "", nil)
+
+	req := httptest.NewRequest("GET", "/outputs/source/test_source/provider/test_provider?limit=10&cursor=cursor&source=ignored", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("source", "test_source")
+	rctx.URLParams.Add("provider", "test_provider")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	rr := httptest.NewRecorder()
+
+	suite.handler.ListOutputsBySourceAndProvider(rr, req)
+
+	assert.Equal(suite.T(), http.StatusOK, rr.Code)
+	suite.repoMock.AssertExpectations(suite.T())
+}
+
 // Tests for ListOutputsByServiceAndSourceAndProvider handler
 func (suite *WebOutputHandlerSuite) TestListOutputsByServiceAndSourceAndProviderWhenSuccess() {
 	entityOutputs := []*entity.Output{
@@ -770,7 +911,7 @@ func (suite *WebOutputHandlerSuite) TestListOutputsByServiceAndSourceAndProvider
 		},
 	}
 
-	suite.repoMock.On("FindAllByServiceAndSourceAndProvider", "test_service", "test_source", "test_provider").Return(entityOutputs, nil)
+	suite.repoMock.On("FindPageByFilter", entity.OutputFilter{Provider: "test_provider", Service: "test_service", Source: "test_source"}, firstPage).Return(entityOutputs, "", nil)
 
 	expectedOutput := []outputdto.OutputDTO{
 		{
@@ -807,11 +948,11 @@ func (suite *WebOutputHandlerSuite) TestListOutputsByServiceAndSourceAndProvider
 
 	assert.Equal(suite.T(), http.StatusOK, rr.Code)
 
-	var actualOutput []outputdto.OutputDTO
+	var actualOutput outputdto.OutputPageDTO
 	err := json.NewDecoder(rr.Body).Decode(&actualOutput)
 	assert.NoError(suite.T(), err)
 
-	assert.Equal(suite.T(), expectedOutput, actualOutput)
+	assert.Equal(suite.T(), expectedOutput, actualOutput.Items)
 	suite.repoMock.AssertExpectations(suite.T())
 }
 
@@ -830,7 +971,7 @@ func (suite *WebOutputHandlerSuite) TestListOutputsByServiceAndSourceAndProvider
 }
 
 func (suite *WebOutputHandlerSuite) TestListOutputsByServiceAndSourceAndProviderWhenRepositoryFails() {
-	suite.repoMock.On("FindAllByServiceAndSourceAndProvider", "test_service", "test_source", "test_provider").Return(nil, errors.New("repository error"))
+	suite.repoMock.On("FindPageByFilter", entity.OutputFilter{Provider: "test_provider", Service: "test_service", Source: "test_source"}, firstPage).Return(nil, "", errors.New("repository error"))
 
 	req := httptest.NewRequest("GET", "/outputs/service/test_service/source/test_source/provider/test_provider", nil)
 	rctx := chi.NewRouteContext()
@@ -846,3 +987,7 @@ func (suite *WebOutputHandlerSuite) TestListOutputsByServiceAndSourceAndProvider
 	assert.Contains(suite.T(), rr.Body.String(), "repository error")
 	suite.repoMock.AssertExpectations(suite.T())
 }
+
+func (suite *WebOutputHandlerSuite) TestOperationsDocumentEveryHandler() {
+	openapitest.AssertDocumentsHandlers(suite.T(), &WebOutputHandler{}, Operations)
+}
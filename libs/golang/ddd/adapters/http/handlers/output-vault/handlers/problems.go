@@ -0,0 +1,26 @@
+package handlers
+
+import (
+	"libs/golang/ddd/domain/entities/output-vault/entity"
+	"libs/golang/shared/go-criteria/criteria"
+	"libs/golang/shared/go-problem/problem"
+)
+
+// problems maps the errors of the output use cases to the problems written in the responses: missing outputs are 404
+// (Not Found), duplicated outputs 409 (Conflict), outputs breaking a rule of the domain 422 (Unprocessable Entity)
+// and invalid cursors and sorts 400 (Bad Request). Any other error is a 500 (Internal Server Error).
+var problems = problem.Mapper{
+	{Err: entity.ErrNotFound, Problem: problem.ErrNotFound},
+	{Err: entity.ErrAlreadyExists, Problem: problem.ErrAlreadyExists},
+	{Err: entity.ErrInvalidID, Problem: problem.ErrInvalidEntity},
+	{Err: entity.ErrInvalidService, Problem: problem.ErrInvalidEntity},
+	{Err: entity.ErrInvalidSource, Problem: problem.ErrInvalidEntity},
+	{Err: entity.ErrInvalidProvider, Problem: problem.ErrInvalidEntity},
+	{Err: entity.ErrInvalidInputID, Problem: problem.ErrInvalidEntity},
+	{Err: entity.ErrInvalidProcessingID, Problem: problem.ErrInvalidEntity},
+	{Err: entity.ErrInvalidProcessingTimestamp, Problem: problem.ErrInvalidEntity},
+	{Err: entity.ErrInvalidData, Problem: problem.ErrInvalidEntity},
+	{Err: entity.ErrInvalidInputData, Problem: problem.ErrInvalidEntity},
+	{Err: criteria.ErrInvalidCursor, Problem: problem.ErrInvalidCursor},
+	{Err: criteria.ErrInvalidSort, Problem: problem.ErrInvalidRequest},
+}
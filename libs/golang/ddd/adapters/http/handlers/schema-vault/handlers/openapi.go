@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	inputdto "libs/golang/ddd/dtos/schema-vault/input"
+	outputdto "libs/golang/ddd/dtos/schema-vault/output"
+	"libs/golang/server/http/openapi/openapi"
+	"net/http"
+)
+
+// Operations documents the methods of WebSchemaHandler in the OpenAPI document of the service registering them, with
+// the statuses of the problems each method may answer.
+var Operations = openapi.Operations{
+	"CreateSchema": {
+		Summary:  "Create a schema",
+		Request:  inputdto.SchemaDTO{},
+		Response: outputdto.SchemaDTO{},
+		Errors:   []int{http.StatusBadRequest, http.StatusConflict, http.StatusUnprocessableEntity, http.StatusInternalServerError},
+	},
+	"UpdateSchema": {
+		Summary:  "Update a schema",
+		Request:  inputdto.SchemaDTO{},
+		Response: outputdto.SchemaDTO{},
+		Errors:   []int{http.StatusBadRequest, http.StatusNotFound, http.StatusUnprocessableEntity, http.StatusInternalServerError},
+	},
+	"DeleteSchema": {
+		Summary:  "Delete a schema",
+		Response: "Schema deleted successfully",
+		Errors:   []int{http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+	},
+	"ListAllSchemas": {
+		Summary:     "List the schemas selected by the query",
+		Description: "Every filter is optional, and the times are RFC 3339 timestamps. The limit, cursor and sort parameters select the page.",
+		Query:       inputdto.SchemaFilterDTO{},
+		Response:    outputdto.SchemaPageDTO{},
+		Errors:      []int{http.StatusBadRequest, http.StatusInternalServerError},
+	},
+	"ListSchemaByID": {
+		Summary:  "Get a schema by ID",
+		Response: outputdto.SchemaDTO{},
+		Errors:   []int{http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+	},
+	"ListSchemasByServiceAndProvider": {
+		Summary:  "List the schemas of a service",
+		Query:    openapi.PageQuery{},
+		Response: outputdto.SchemaPageDTO{},
+		Errors:   []int{http.StatusBadRequest, http.StatusInternalServerError},
+	},
+	"ListSchemasBySourceAndProvider": {
+		Summary:  "List the schemas of a source",
+		Query:    openapi.PageQuery{},
+		Response: outputdto.SchemaPageDTO{},
+		Errors:   []int{http.StatusBadRequest, http.StatusInternalServerError},
+	},
+	"ListSchemasByServiceAndSourceAndProvider": {
+		Summary:  "List the schemas of a service and source",
+		Query:    openapi.PageQuery{},
+		Response: outputdto.SchemaPageDTO{},
+		Errors:   []int{http.StatusBadRequest, http.StatusInternalServerError},
+	},
+	"ListSchemasByServiceAndSourceAndProviderAndSchemaType": {
+		Summary:  "Get the schema of a service and source by type",
+		Response: outputdto.SchemaDTO{},
+		Errors:   []int{http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+	},
+	"ValidateSchema": {
+		Summary:     "Validate data against a schema",
+		Description: "The data is valid when it matches the JSON schema of the service, source, provider and schema type of the request.",
+		Request:     inputdto.SchemaDataDTO{},
+		Response:    outputdto.SchemaValidationDTO{},
+		Errors:      []int{http.StatusBadRequest, http.StatusNotFound, http.StatusUnprocessableEntity, http.StatusInternalServerError},
+	},
+}
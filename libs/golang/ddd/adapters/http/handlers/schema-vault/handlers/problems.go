@@ -0,0 +1,27 @@
+package handlers
+
+import (
+	"libs/golang/ddd/domain/entities/schema-vault/entity"
+	"libs/golang/shared/go-criteria/criteria"
+	"libs/golang/shared/go-problem/problem"
+	schematools "libs/golang/shared/json-schema/schema-tools"
+)
+
+// problems maps the errors of the schema use cases to the problems written in the responses: missing schemas are 404
+// (Not Found), duplicated schemas 409 (Conflict), schemas breaking a rule of the domain and data not matching its
+// schema 422 (Unprocessable Entity), and invalid cursors and sorts 400 (Bad Request). Any other error is a 500
+// (Internal Server Error).
+var problems = problem.Mapper{
+	{Err: entity.ErrNotFound, Problem: problem.ErrNotFound},
+	{Err: entity.ErrAlreadyExists, Problem: problem.ErrAlreadyExists},
+	{Err: entity.ErrMissingID, Problem: problem.ErrInvalidEntity},
+	{Err: entity.ErrMissingService, Problem: problem.ErrInvalidEntity},
+	{Err: entity.ErrMissingSource, Problem: problem.ErrInvalidEntity},
+	{Err: entity.ErrMissingProvider, Problem: problem.ErrInvalidEntity},
+	{Err: entity.ErrMissingSchemaType, Problem: problem.ErrInvalidEntity},
+	{Err: entity.ErrJsonSchemaInvalid, Problem: problem.ErrInvalidEntity},
+	{Err: entity.ErrTransformationInvalid, Problem: problem.ErrInvalidEntity},
+	{Err: schematools.ErrDataInvalid, Problem: problem.ErrValidationFailed},
+	{Err: criteria.ErrInvalidCursor, Problem: problem.ErrInvalidCursor},
+	{Err: criteria.ErrInvalidSort, Problem: problem.ErrInvalidRequest},
+}
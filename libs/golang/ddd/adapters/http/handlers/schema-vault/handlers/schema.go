@@ -5,12 +5,15 @@ import (
 	"libs/golang/ddd/domain/entities/schema-vault/entity"
 	inputdto "libs/golang/ddd/dtos/schema-vault/input"
 	"libs/golang/ddd/usecases/schema-vault/usecase"
+	"libs/golang/shared/go-problem/problem"
+	"libs/golang/shared/go-validator/validator"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
 )
 
-// WebSchemaHandler represents the handler for the schema vault.
+// WebSchemaHandler represents the handler for the schema vault. Failed requests are answered
+// with RFC 7807 problem details, whose status and code are chosen by problems.
 type WebSchemaHandler struct {
 	SchemaRepository entity.SchemaRepositoryInterface
 }
@@ -44,26 +47,28 @@ func NewWebSchemaHandler(
 //
 //	None.
 //
-// If the request body cannot be decoded, it responds with HTTP status 400 (Bad Request).
-// If an error occurs during the creation process, it responds with HTTP status 500 (Internal Server Error).
+// If the request body cannot be decoded, it responds with HTTP status 400 (Bad Request), and if fields of the body are
+// missing or invalid, with HTTP status 422 (Unprocessable Entity) listing every invalid field.
+// If the schema breaks a rule of the domain, it responds with HTTP status 422 (Unprocessable Entity), if it already
+// exists, with HTTP status 409 (Conflict), and if another error occurs during the creation process, with HTTP status
+// 500 (Internal Server Error).
 func (h *WebSchemaHandler) CreateSchema(w http.ResponseWriter, r *http.Request) {
 	var dto inputdto.SchemaDTO
-	err := json.NewDecoder(r.Body).Decode(&dto)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	if err := validator.Decode(w, r, &dto); err != nil {
+		problems.Write(w, r, err)
 		return
 	}
 
 	createSchemaUseCase := usecase.NewCreateSchemaUseCase(h.SchemaRepository)
 	schemaCreated, err := createSchemaUseCase.Execute(dto)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problems.Write(w, r, err)
 		return
 	}
 
 	err = json.NewEncoder(w).Encode(schemaCreated)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problems.Write(w, r, err)
 		return
 	}
 }
@@ -80,26 +85,28 @@ func (h *WebSchemaHandler) CreateSchema(w http.ResponseWriter, r *http.Request)
 //
 //	None.
 //
-// If the request body cannot be decoded, it responds with HTTP status 400 (Bad Request).
-// If an error occurs during the update process, it responds with HTTP status 500 (Internal Server Error).
+// If the request body cannot be decoded, it responds with HTTP status 400 (Bad Request), and if fields of the body are
+// missing or invalid, with HTTP status 422 (Unprocessable Entity) listing every invalid field.
+// If the schema breaks a rule of the domain, it responds with HTTP status 422 (Unprocessable Entity), if it does not
+// exist, with HTTP status 404 (Not Found), and if another error occurs during the update process, with HTTP status
+// 500 (Internal Server Error).
 func (h *WebSchemaHandler) UpdateSchema(w http.ResponseWriter, r *http.Request) {
 	var dto inputdto.SchemaDTO
-	err := json.NewDecoder(r.Body).Decode(&dto)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	if err := validator.Decode(w, r, &dto); err != nil {
+		problems.Write(w, r, err)
 		return
 	}
 
 	updateSchemaUseCase := usecase.NewUpdateSchemaUseCase(h.SchemaRepository)
 	schemaUpdated, err := updateSchemaUseCase.Execute(dto)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problems.Write(w, r, err)
 		return
 	}
 
 	err = json.NewEncoder(w).Encode(schemaUpdated)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problems.Write(w, r, err)
 		return
 	}
 }
@@ -116,18 +123,19 @@ func (h *WebSchemaHandler) UpdateSchema(w http.ResponseWriter, r *http.Request)
 //
 //	None.
 //
-// If an error occurs during the deletion process, it responds with HTTP status 500 (Internal Server Error).
+// If the schema does not exist, it responds with HTTP status 404 (Not Found), and if another error occurs during the
+// deletion process, with HTTP status 500 (Internal Server Error).
 func (h *WebSchemaHandler) DeleteSchema(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 	if id == "" {
-		http.Error(w, "ID is required", http.StatusBadRequest)
+		problem.Write(w, r, problem.ErrInvalidRequest.WithDetail("ID is required"))
 		return
 	}
 
 	deleteSchemaUseCase := usecase.NewDeleteSchemaUseCase(h.SchemaRepository)
 	err := deleteSchemaUseCase.Execute(id)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problems.Write(w, r, err)
 		return
 	}
 
@@ -135,8 +143,10 @@ func (h *WebSchemaHandler) DeleteSchema(w http.ResponseWriter, r *http.Request)
 	w.Write([]byte(`Schema deleted successfully`))
 }
 
-// ListAllSchemas handles HTTP GET requests to list all schemas. It executes the ListAllSchemaUseCase and writes the
-// schemas as a JSON response.
+// ListAllSchemas handles HTTP GET requests to list the schemas selected by the query parameters, such as
+// `GET /schema?provider=acme&schema_type=input`. Every parameter is optional: provider, service, source, schema_type,
+// created_after and created_before, the times being RFC 3339 timestamps. The limit, cursor and sort parameters
+// select the page, and the page is written as a JSON response with the cursor of the next page.
 //
 // Parameters:
 //
@@ -147,20 +157,54 @@ func (h *WebSchemaHandler) DeleteSchema(w http.ResponseWriter, r *http.Request)
 //
 //	None.
 //
-// If an error occurs during the listing process, it responds with HTTP status 500 (Internal Server Error).
+// If a query parameter is invalid, it responds with HTTP status 400 (Bad Request), and if an error occurs during the
+// listing process, with HTTP status 500 (Internal Server Error).
 func (h *WebSchemaHandler) ListAllSchemas(w http.ResponseWriter, r *http.Request) {
-	listAllSchemaUseCase := usecase.NewListAllSchemaUseCase(h.SchemaRepository)
-	schemas, err := listAllSchemaUseCase.Execute()
+	filterDTO, err := inputdto.NewSchemaFilterDTO(r.URL.Query())
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problem.Write(w, r, problem.ErrInvalidRequest.WithDetail(err.Error()))
 		return
 	}
+	h.listSchemas(w, r, filterDTO)
+}
 
-	err = json.NewEncoder(w).Encode(schemas)
+// listSchemas lists a page of the schemas selected by the filter and writes it as a JSON response.
+//
+// Parameters:
+//
+//	w: The HTTP response writer.
+//	filterDTO: The filter selecting the schemas and the page.
+func (h *WebSchemaHandler) listSchemas(w http.ResponseWriter, r *http.Request, filterDTO inputdto.SchemaFilterDTO) {
+	listPageByFilterSchemaUseCase := usecase.NewListPageByFilterSchemaUseCase(h.SchemaRepository)
+	page, err := listPageByFilterSchemaUseCase.Execute(filterDTO)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problems.Write(w, r, err)
 		return
 	}
+
+	err = json.NewEncoder(w).Encode(page)
+	if err != nil {
+		problems.Write(w, r, err)
+		return
+	}
+}
+
+// listSchemasByPath lists the schemas of the routes filtering on path parameters, which are aliases of ListAllSchemas. The
+// filter built from the path replaces the filter of the query parameters, which still select the page.
+//
+// Parameters:
+//
+//	w: The HTTP response writer.
+//	r: The HTTP request.
+//	pathFilterDTO: The filter built from the path parameters.
+func (h *WebSchemaHandler) listSchemasByPath(w http.ResponseWriter, r *http.Request, pathFilterDTO inputdto.SchemaFilterDTO) {
+	filterDTO, err := inputdto.NewSchemaFilterDTO(r.URL.Query())
+	if err != nil {
+		problem.Write(w, r, problem.ErrInvalidRequest.WithDetail(err.Error()))
+		return
+	}
+	pathFilterDTO.Limit, pathFilterDTO.Cursor, pathFilterDTO.Sort = filterDTO.Limit, filterDTO.Cursor, filterDTO.Sort
+	h.listSchemas(w, r, pathFilterDTO)
 }
 
 // ListSchemaByID handles HTTP GET requests to list a schema by its ID. It extracts the schema ID from the request URL,
@@ -175,24 +219,25 @@ func (h *WebSchemaHandler) ListAllSchemas(w http.ResponseWriter, r *http.Request
 //
 //	None.
 //
-// If an error occurs during the listing process, it responds with HTTP status 500 (Internal Server Error).
+// If the schema does not exist, it responds with HTTP status 404 (Not Found), and if another error occurs during the
+// listing process, with HTTP status 500 (Internal Server Error).
 func (h *WebSchemaHandler) ListSchemaByID(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 	if id == "" {
-		http.Error(w, "ID is required", http.StatusBadRequest)
+		problem.Write(w, r, problem.ErrInvalidRequest.WithDetail("ID is required"))
 		return
 	}
 
 	listOneByIDSchemaUseCase := usecase.NewListOneByIDSchemaUseCase(h.SchemaRepository)
 	schema, err := listOneByIDSchemaUseCase.Execute(id)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problems.Write(w, r, err)
 		return
 	}
 
 	err = json.NewEncoder(w).Encode(schema)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problems.Write(w, r, err)
 		return
 	}
 }
@@ -215,22 +260,11 @@ func (h *WebSchemaHandler) ListSchemasByServiceAndProvider(w http.ResponseWriter
 	provider := chi.URLParam(r, "provider")
 	service := chi.URLParam(r, "service")
 	if service == "" || provider == "" {
-		http.Error(w, "Service and provider are required", http.StatusBadRequest)
+		problem.Write(w, r, problem.ErrInvalidRequest.WithDetail("Service and provider are required"))
 		return
 	}
 
-	listAllByServiceAndProviderSchemaUseCase := usecase.NewListAllByServiceAndProviderSchemaUseCase(h.SchemaRepository)
-	schemas, err := listAllByServiceAndProviderSchemaUseCase.Execute(provider, service)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-
-	err = json.NewEncoder(w).Encode(schemas)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
+	h.listSchemasByPath(w, r, inputdto.SchemaFilterDTO{Provider: provider, Service: service})
 }
 
 // ListSchemasBySourceAndProvider handles HTTP GET requests to list all schemas by source and provider. It extracts the
@@ -251,22 +285,11 @@ func (h *WebSchemaHandler) ListSchemasBySourceAndProvider(w http.ResponseWriter,
 	provider := chi.URLParam(r, "provider")
 	source := chi.URLParam(r, "source")
 	if source == "" || provider == "" {
-		http.Error(w, "Source and provider are required", http.StatusBadRequest)
-		return
-	}
-
-	listAllBySourceAndProviderSchemaUseCase := usecase.NewListAllBySourceAndProviderSchemaUseCase(h.SchemaRepository)
-	schemas, err := listAllBySourceAndProviderSchemaUseCase.Execute(provider, source)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problem.Write(w, r, problem.ErrInvalidRequest.WithDetail("Source and provider are required"))
 		return
 	}
 
-	err = json.NewEncoder(w).Encode(schemas)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
+	h.listSchemasByPath(w, r, inputdto.SchemaFilterDTO{Provider: provider, Source: source})
 }
 
 // ListSchemasByServiceAndSourceAndProvider handles HTTP GET requests to list all schemas by service, source, and provider.
@@ -288,22 +311,11 @@ func (h *WebSchemaHandler) ListSchemasByServiceAndSourceAndProvider(w http.Respo
 	service := chi.URLParam(r, "service")
 	source := chi.URLParam(r, "source")
 	if service == "" || source == "" || provider == "" {
-		http.Error(w, "Service, source and provider are required", http.StatusBadRequest)
-		return
-	}
-
-	listAllByServiceAndSourceAndProviderSchemaUseCase := usecase.NewListAllByServiceAndSourceAndProviderSchemaUseCase(h.SchemaRepository)
-	schemas, err := listAllByServiceAndSourceAndProviderSchemaUseCase.Execute(provider, service, source)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problem.Write(w, r, problem.ErrInvalidRequest.WithDetail("Service, source and provider are required"))
 		return
 	}
 
-	err = json.NewEncoder(w).Encode(schemas)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
+	h.listSchemasByPath(w, r, inputdto.SchemaFilterDTO{Provider: provider, Service: service, Source: source})
 }
 
 func (h *WebSchemaHandler) ListSchemasByServiceAndSourceAndProviderAndSchemaType(w http.ResponseWriter, r *http.Request) {
@@ -312,42 +324,41 @@ func (h *WebSchemaHandler) ListSchemasByServiceAndSourceAndProviderAndSchemaType
 	source := chi.URLParam(r, "source")
 	schemaType := chi.URLParam(r, "schemaType")
 	if service == "" || source == "" || provider == "" || schemaType == "" {
-		http.Error(w, "Service, source, provider and schema type are required", http.StatusBadRequest)
+		problem.Write(w, r, problem.ErrInvalidRequest.WithDetail("Service, source, provider and schema type are required"))
 		return
 	}
 
 	listAllByServiceAndSourceAndProviderAndSchemaTypeSchemaUseCase := usecase.NewListOneByServiceAndSourceAndProviderAndSchemaTypeSchemaUseCase(h.SchemaRepository)
 	schemas, err := listAllByServiceAndSourceAndProviderAndSchemaTypeSchemaUseCase.Execute(provider, service, source, schemaType)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problems.Write(w, r, err)
 		return
 	}
 
 	err = json.NewEncoder(w).Encode(schemas)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problems.Write(w, r, err)
 		return
 	}
 }
 
 func (h *WebSchemaHandler) ValidateSchema(w http.ResponseWriter, r *http.Request) {
 	var dto inputdto.SchemaDataDTO
-	err := json.NewDecoder(r.Body).Decode(&dto)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	if err := validator.Decode(w, r, &dto); err != nil {
+		problems.Write(w, r, err)
 		return
 	}
 
 	validateSchemaUseCase := usecase.NewValidateSchemaUseCase(h.SchemaRepository)
 	valid, err := validateSchemaUseCase.Execute(dto)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problems.Write(w, r, err)
 		return
 	}
 
 	err = json.NewEncoder(w).Encode(valid)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problems.Write(w, r, err)
 		return
 	}
 }
@@ -5,11 +5,15 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"libs/golang/ddd/domain/entities/schema-vault/entity"
 	mockrepository "libs/golang/ddd/domain/repositories/database/mock/schema-vault/repository"
 	inputdto "libs/golang/ddd/dtos/schema-vault/input"
 	outputdto "libs/golang/ddd/dtos/schema-vault/output"
 	shareddto "libs/golang/ddd/dtos/schema-vault/shared"
+	"libs/golang/server/http/openapi/openapitest"
+	"libs/golang/shared/go-criteria/criteria"
+	"libs/golang/shared/go-problem/problem"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -20,6 +24,17 @@ import (
 	"github.com/stretchr/testify/suite"
 )
 
+// firstPage is the page read when the request does not set the limit, cursor and sort parameters.
+var firstPage = criteria.Page{Sort: criteria.NewSort("created_at")}
+
+// decodeProblem reads the problem details written in the response.
+func decodeProblem(t *testing.T, rr *httptest.ResponseRecorder) problem.Problem {
+	assert.Equal(t, problem.ContentType, rr.Header().Get("Content-Type"))
+	var p problem.Problem
+	assert.NoError(t, json.NewDecoder(rr.Body).Decode(&p))
+	return p
+}
+
 type WebSchemaHandlerSuite struct {
 	suite.Suite
 	handler  *WebSchemaHandler
@@ -119,6 +134,22 @@ func (suite *WebSchemaHandlerSuite) TestCreateSchemaWhenDecodingFails() {
 	assert.Contains(suite.T(), rr.Body.String(), "invalid character")
 }
 
+func (suite *WebSchemaHandlerSuite) TestCreateSchemaWhenInvalid() {
+	req := httptest.NewRequest(http.MethodPost, "/schemas", bytes.NewBuffer([]byte(`{"provider": "test_provider", "source": "test_source", "service": "test_service", "schema_type": "input", "json_schema": {"required": [], "type": "object"}, "transformation": [{"from": "id", "type": "date"}]}`)))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+
+	suite.handler.CreateSchema(rr, req)
+
+	assert.Equal(suite.T(), http.StatusUnprocessableEntity, rr.Code)
+	problemDetails := decodeProblem(suite.T(), rr)
+	assert.Equal(suite.T(), problem.CodeValidationFailed, problemDetails.Code)
+	assert.Equal(suite.T(), []problem.FieldError{
+		{Field: "transformation[0].to", Message: "is required"},
+		{Field: "transformation[0].type", Message: "must be one of string, integer, number, boolean"},
+	}, problemDetails.Errors)
+}
+
 func (suite *WebSchemaHandlerSuite) TestCreateSchemaWhenRepositoryFails() {
 	inputDTO := inputdto.SchemaDTO{
 		Service:    "test_service",
@@ -155,6 +186,42 @@ func (suite *WebSchemaHandlerSuite) TestCreateSchemaWhenRepositoryFails() {
 	suite.repoMock.AssertExpectations(suite.T())
 }
 
+func (suite *WebSchemaHandlerSuite) TestCreateSchemaWhenAlreadyExists() {
+	inputDTO := inputdto.SchemaDTO{
+		Service:    "test_service",
+		Source:     "test_source",
+		Provider:   "test_provider",
+		SchemaType: "test_schema_type",
+		JsonSchema: shareddto.JsonSchemaDTO{
+			JsonType: "object",
+			Properties: map[string]interface{}{
+				"field1": map[string]interface{}{
+					"type": "string",
+				},
+				"field2": map[string]interface{}{
+					"type": "string",
+				},
+			},
+			Required: []string{
+				"field1",
+			},
+		},
+	}
+
+	suite.repoMock.On("Create", mock.AnythingOfType("*entity.Schema")).Return(fmt.Errorf("%w: ID 1", entity.ErrAlreadyExists))
+
+	jsonBody, _ := json.Marshal(inputDTO)
+	req := httptest.NewRequest(http.MethodPost, "/schemas", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+
+	suite.handler.CreateSchema(rr, req)
+
+	assert.Equal(suite.T(), http.StatusConflict, rr.Code)
+	assert.Equal(suite.T(), problem.CodeAlreadyExists, decodeProblem(suite.T(), rr).Code)
+	suite.repoMock.AssertExpectations(suite.T())
+}
+
 // Tests for UpdateSchema handler
 func (suite *WebSchemaHandlerSuite) TestUpdateSchemaWhenSuccess() {
 	inputDTO := inputdto.SchemaDTO{
@@ -320,6 +387,22 @@ func (suite *WebSchemaHandlerSuite) TestDeleteSchemaWhenRepositoryFails() {
 	suite.repoMock.AssertExpectations(suite.T())
 }
 
+func (suite *WebSchemaHandlerSuite) TestDeleteSchemaWhenNotFound() {
+	suite.repoMock.On("Delete", "1").Return(fmt.Errorf("%w: ID 1", entity.ErrNotFound))
+
+	req := httptest.NewRequest("DELETE", "/schemas/1", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "1")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	rr := httptest.NewRecorder()
+
+	suite.handler.DeleteSchema(rr, req)
+
+	assert.Equal(suite.T(), http.StatusNotFound, rr.Code)
+	assert.Equal(suite.T(), problem.CodeNotFound, decodeProblem(suite.T(), rr).Code)
+	suite.repoMock.AssertExpectations(suite.T())
+}
+
 // Tests for ListAllSchemas handler
 func (suite *WebSchemaHandlerSuite) TestListAllSchemasWhenSuccess() {
 	entitySchemas := []*entity.Schema{
@@ -367,7 +450,7 @@ func (suite *WebSchemaHandlerSuite) TestListAllSchemasWhenSuccess() {
 		},
 	}
 
-	suite.repoMock.On("FindAll").Return(entitySchemas, nil)
+	suite.repoMock.On("FindPageByFilter", entity.SchemaFilter{}, firstPage).Return(entitySchemas, "", nil)
 
 	expectedOutput := []outputdto.SchemaDTO{
 		{
@@ -421,16 +504,16 @@ func (suite *WebSchemaHandlerSuite) TestListAllSchemasWhenSuccess() {
 
 	assert.Equal(suite.T(), http.StatusOK, rr.Code)
 
-	var actualOutput []outputdto.SchemaDTO
+	var actualOutput outputdto.SchemaPageDTO
 	err := json.NewDecoder(rr.Body).Decode(&actualOutput)
 	assert.NoError(suite.T(), err)
 
-	assert.Equal(suite.T(), expectedOutput, actualOutput)
+	assert.Equal(suite.T(), expectedOutput, actualOutput.Items)
 	suite.repoMock.AssertExpectations(suite.T())
 }
 
 func (suite *WebSchemaHandlerSuite) TestListAllSchemasWhenRepositoryFails() {
-	suite.repoMock.On("FindAll").Return(nil, errors.New("repository error"))
+	suite.repoMock.On("FindPageByFilter", entity.SchemaFilter{}, firstPage).Return(nil, "", errors.New("repository error"))
 
 	req := httptest.NewRequest(http.MethodGet, "/schemas", nil)
 	rr := httptest.NewRecorder()
@@ -442,6 +525,46 @@ func (suite *WebSchemaHandlerSuite) TestListAllSchemasWhenRepositoryFails() {
 	suite.repoMock.AssertExpectations(suite.T())
 }
 
+func (suite *WebSchemaHandlerSuite) TestListAllSchemasWithPage() {
+	page := criteria.Page{Limit: 2, Cursor: "cursor", Sort: criteria.NewSort("-updated_at")}
+	suite.repoMock.On("FindPageByFilter", entity.SchemaFilter{}, page).Return([]*entity.Schema{}, "next", nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/schema?limit=2&cursor=cursor&sort=-updated_at", nil)
+	rr := httptest.NewRecorder()
+
+	suite.handler.ListAllSchemas(rr, req)
+
+	assert.Equal(suite.T(), http.StatusOK, rr.Code)
+	var actualPage outputdto.SchemaPageDTO
+	assert.NoError(suite.T(), json.NewDecoder(rr.Body).Decode(&actualPage))
+	assert.Equal(suite.T(), outputdto.SchemaPageDTO{Items: []outputdto.SchemaDTO{}, NextCursor: "next"}, actualPage)
+	suite.repoMock.AssertExpectations(suite.T())
+}
+
+func (suite *WebSchemaHandlerSuite) TestListAllSchemasWhenCursorIsInvalid() {
+	suite.repoMock.On("FindPageByFilter", entity.SchemaFilter{}, criteria.Page{Cursor: "invalid", Sort: criteria.NewSort("created_at")}).
+		Return(nil, "", criteria.ErrInvalidCursor)
+
+	req := httptest.NewRequest(http.MethodGet, "/schema?cursor=invalid", nil)
+	rr := httptest.NewRecorder()
+
+	suite.handler.ListAllSchemas(rr, req)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, rr.Code)
+	assert.Equal(suite.T(), problem.CodeInvalidCursor, decodeProblem(suite.T(), rr).Code)
+	suite.repoMock.AssertExpectations(suite.T())
+}
+
+func (suite *WebSchemaHandlerSuite) TestListAllSchemasWhenLimitIsInvalid() {
+	req := httptest.NewRequest(http.MethodGet, "/schema?limit=0", nil)
+	rr := httptest.NewRecorder()
+
+	suite.handler.ListAllSchemas(rr, req)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, rr.Code)
+	suite.repoMock.AssertNotCalled(suite.T(), "FindPageByFilter", mock.Anything, mock.Anything)
+}
+
 // Tests for ListSchemaByID handler
 func (suite *WebSchemaHandlerSuite) TestListSchemaByIDWhenSuccess() {
 	entitySchemas := []*entity.Schema{
@@ -559,6 +682,22 @@ func (suite *WebSchemaHandlerSuite) TestListSchemaByIDWhenRepositoryFails() {
 	suite.repoMock.AssertExpectations(suite.T())
 }
 
+func (suite *WebSchemaHandlerSuite) TestListSchemaByIDWhenNotFound() {
+	suite.repoMock.On("FindByID", "1").Return(nil, fmt.Errorf("%w: ID 1", entity.ErrNotFound))
+
+	req := httptest.NewRequest(http.MethodGet, "/schemas/1", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "1")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	rr := httptest.NewRecorder()
+
+	suite.handler.ListSchemaByID(rr, req)
+
+	assert.Equal(suite.T(), http.StatusNotFound, rr.Code)
+	assert.Equal(suite.T(), problem.CodeNotFound, decodeProblem(suite.T(), rr).Code)
+	suite.repoMock.AssertExpectations(suite.T())
+}
+
 // Tests for ListSchemasByServiceAndProvider handler
 func (suite *WebSchemaHandlerSuite) TestListSchemasByServiceAndProviderWhenSuccess() {
 	entitySchemas := []*entity.Schema{
@@ -586,7 +725,7 @@ func (suite *WebSchemaHandlerSuite) TestListSchemasByServiceAndProviderWhenSucce
 		},
 	}
 
-	suite.repoMock.On("FindAllByServiceAndProvider", "test_provider", "test_service").Return(entitySchemas, nil)
+	suite.repoMock.On("FindPageByFilter", entity.SchemaFilter{Provider: "test_provider", Service: "test_service"}, firstPage).Return(entitySchemas, "", nil)
 
 	expectedOutput := []outputdto.SchemaDTO{
 		{
@@ -624,11 +763,11 @@ func (suite *WebSchemaHandlerSuite) TestListSchemasByServiceAndProviderWhenSucce
 
 	assert.Equal(suite.T(), http.StatusOK, rr.Code)
 
-	var actualOutput []outputdto.SchemaDTO
+	var actualOutput outputdto.SchemaPageDTO
 	err := json.NewDecoder(rr.Body).Decode(&actualOutput)
 	assert.NoError(suite.T(), err)
 
-	assert.Equal(suite.T(), expectedOutput, actualOutput)
+	assert.Equal(suite.T(), expectedOutput, actualOutput.Items)
 	suite.repoMock.AssertExpectations(suite.T())
 }
 
@@ -646,7 +785,7 @@ func (suite *WebSchemaHandlerSuite) TestListSchemasByServiceAndProviderWhenServi
 }
 
 func (suite *WebSchemaHandlerSuite) TestListSchemasByServiceAndProviderWhenRepositoryFails() {
-	suite.repoMock.On("FindAllByServiceAndProvider", "test_provider", "test_service").Return(nil, errors.New("repository error"))
+	suite.repoMock.On("FindPageByFilter", entity.SchemaFilter{Provider: "test_provider", Service: "test_service"}, firstPage).Return(nil, "", errors.New("repository error"))
 
 	req := httptest.NewRequest("GET", "/schemas/service/test_service/provider/test_provider", nil)
 	rctx := chi.NewRouteContext()
@@ -689,7 +828,7 @@ func (suite *WebSchemaHandlerSuite) TestListSchemasBySourceAndProviderWhenSucces
 		},
 	}
 
-	suite.repoMock.On("FindAllBySourceAndProvider", "test_provider", "test_source").Return(entitySchemas, nil)
+	suite.repoMock.On("FindPageByFilter", entity.SchemaFilter{Provider: "test_provider", Source: "test_source"}, firstPage).Return(entitySchemas, "", nil)
 
 	expectedOutput := []outputdto.SchemaDTO{
 		{
@@ -727,16 +866,16 @@ func (suite *WebSchemaHandlerSuite) TestListSchemasBySourceAndProviderWhenSucces
 
 	assert.Equal(suite.T(), http.StatusOK, rr.Code)
 
-	var actualOutput []outputdto.SchemaDTO
+	var actualOutput outputdto.SchemaPageDTO
 	err := json.NewDecoder(rr.Body).Decode(&actualOutput)
 	assert.NoError(suite.T(), err)
 
-	assert.Equal(suite.T(), expectedOutput, actualOutput)
+	assert.Equal(suite.T(), expectedOutput, actualOutput.Items)
 	suite.repoMock.AssertExpectations(suite.T())
 }
 
 func (suite *WebSchemaHandlerSuite) TestListSchemasBySourceAndProviderWhenRepositoryFails() {
-	suite.repoMock.On("FindAllBySourceAndProvider", "test_provider", "test_source").Return(nil, errors.New("repository error"))
+	suite.repoMock.On("FindPageByFilter", entity.SchemaFilter{Provider: "test_provider", Source: "test_source"}, firstPage).Return(nil, "", errors.New("repository error"))
 
 	req := httptest.NewRequest("GET", "/schemas/source/test_source/provider/test_provider", nil)
 	rctx := chi.NewRouteContext()
@@ -779,7 +918,7 @@ func (suite *WebSchemaHandlerSuite) TestListSchemasByServiceAndSourceAndProvider
 		},
 	}
 
-	suite.repoMock.On("FindAllByServiceAndSourceAndProvider", "test_service", "test_source", "test_provider").Return(entitySchemas, nil)
+	suite.repoMock.On("FindPageByFilter", entity.SchemaFilter{Provider: "test_provider", Service: "test_service", Source: "test_source"}, firstPage).Return(entitySchemas, "", nil)
 
 	expectedOutput := []outputdto.SchemaDTO{
 		{
@@ -818,11 +957,11 @@ func (suite *WebSchemaHandlerSuite) TestListSchemasByServiceAndSourceAndProvider
 
 	assert.Equal(suite.T(), http.StatusOK, rr.Code)
 
-	var actualOutput []outputdto.SchemaDTO
+	var actualOutput outputdto.SchemaPageDTO
 	err := json.NewDecoder(rr.Body).Decode(&actualOutput)
 	assert.NoError(suite.T(), err)
 
-	assert.Equal(suite.T(), expectedOutput, actualOutput)
+	assert.Equal(suite.T(), expectedOutput, actualOutput.Items)
 	suite.repoMock.AssertExpectations(suite.T())
 }
 
@@ -841,7 +980,7 @@ func (suite *WebSchemaHandlerSuite) TestListSchemasByServiceAndSourceAndProvider
 }
 
 func (suite *WebSchemaHandlerSuite) TestListSchemasByServiceAndSourceAndProviderWhenRepositoryFails() {
-	suite.repoMock.On("FindAllByServiceAndSourceAndProvider", "test_service", "test_source", "test_provider").Return(nil, errors.New("repository error"))
+	suite.repoMock.On("FindPageByFilter", entity.SchemaFilter{Provider: "test_provider", Service: "test_service", Source: "test_source"}, firstPage).Return(nil, "", errors.New("repository error"))
 
 	req := httptest.NewRequest("GET", "/schemas/service/test_service/source/test_source/provider/test_provider", nil)
 	rctx := chi.NewRouteContext()
@@ -918,7 +1057,7 @@ func (suite *WebSchemaHandlerSuite) TestValidateSchemaWhenDecodingFails() {
 }
 
 func (suite *WebSchemaHandlerSuite) TestValidateSchemaWhenSchemaNotFound() {
-	suite.repoMock.On("FindOneByServiceAndSourceAndProviderAndSchemaType", "service1", "source1", "provider", "input").Return(nil, errors.New("schema not found"))
+	suite.repoMock.On("FindOneByServiceAndSourceAndProviderAndSchemaType", "service1", "source1", "provider", "input").Return(nil, fmt.Errorf("%w: provider provider", entity.ErrNotFound))
 
 	dto := inputdto.SchemaDataDTO{
 		Service:    "service1",
@@ -937,8 +1076,8 @@ func (suite *WebSchemaHandlerSuite) TestValidateSchemaWhenSchemaNotFound() {
 
 	suite.handler.ValidateSchema(rr, req)
 
-	assert.Equal(suite.T(), http.StatusInternalServerError, rr.Code)
-	assert.Contains(suite.T(), rr.Body.String(), "schema not found")
+	assert.Equal(suite.T(), http.StatusNotFound, rr.Code)
+	assert.Equal(suite.T(), problem.CodeNotFound, decodeProblem(suite.T(), rr).Code)
 	suite.repoMock.AssertExpectations(suite.T())
 }
 
@@ -981,7 +1120,12 @@ func (suite *WebSchemaHandlerSuite) TestValidateSchemaWhenValidationFails() {
 
 	suite.handler.ValidateSchema(rr, req)
 
-	assert.Equal(suite.T(), http.StatusInternalServerError, rr.Code)
+	assert.Equal(suite.T(), http.StatusUnprocessableEntity, rr.Code)
 	assert.Contains(suite.T(), rr.Body.String(), "failed to validate JSON data:")
+	assert.Equal(suite.T(), problem.CodeValidationFailed, decodeProblem(suite.T(), rr).Code)
 	suite.repoMock.AssertExpectations(suite.T())
 }
+
+func (suite *WebSchemaHandlerSuite) TestOperationsDocumentEveryHandler() {
+	openapitest.AssertDocumentsHandlers(suite.T(), &WebSchemaHandler{}, Operations)
+}
@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"libs/golang/shared/go-criteria/criteria"
+	"time"
+)
+
+// ConfigFilter selects the Config entities to list. Its zero value selects every config, and each set
+// field narrows the selection.
+type ConfigFilter struct {
+	Provider      string    // Provider of the configs, any when empty
+	Service       string    // Service of the configs, any when empty
+	Source        string    // Source of the configs, any when empty
+	Active        *bool     // Whether the configs are active, either when nil
+	CreatedAfter  time.Time // Configs created strictly after this time, no lower bound when zero
+	CreatedBefore time.Time // Configs created strictly before this time, no upper bound when zero
+}
+
+// Criteria translates the filter into query criteria on the Config documents.
+//
+// Returns:
+//   - The criteria matching the configs selected by the filter.
+func (f ConfigFilter) Criteria() criteria.Criteria {
+	c := criteria.Criteria{}.
+		WhereNotEmpty("provider", f.Provider).
+		WhereNotEmpty("service", f.Service).
+		WhereNotEmpty("source", f.Source).
+		WhereTime("created_at", criteria.Gt, f.CreatedAfter, dateLayout).
+		WhereTime("created_at", criteria.Lt, f.CreatedBefore, dateLayout)
+	if f.Active != nil {
+		c = c.Where("active", criteria.Eq, *f.Active)
+	}
+	return c
+}
@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type ConfigVaultFilterSuite struct {
+	suite.Suite
+}
+
+func TestConfigVaultFilterSuite(t *testing.T) {
+	suite.Run(t, new(ConfigVaultFilterSuite))
+}
+
+func (suite *ConfigVaultFilterSuite) TestCriteria() {
+	active := false
+	filter := ConfigFilter{Provider: "test_provider", Service: "test_service", Active: &active}
+
+	assert.Equal(suite.T(), map[string]interface{}{
+		"provider": "test_provider",
+		"service":  "test_service",
+		"active":   false,
+	}, filter.Criteria().Query())
+}
+
+func (suite *ConfigVaultFilterSuite) TestEmptyFilterMatchesEverything() {
+	assert.Empty(suite.T(), ConfigFilter{}.Criteria().Query())
+}
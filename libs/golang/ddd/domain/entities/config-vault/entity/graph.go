@@ -0,0 +1,220 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+var (
+	// ErrDependencyCycle is returned when the dependencies of the configs of a provider form a cycle.
+	ErrDependencyCycle = errors.New("dependency cycle")
+)
+
+// JobKey identifies the job of a provider by its service and source.
+type JobKey struct {
+	Service string
+	Source  string
+}
+
+// String returns the key as service/source.
+func (k JobKey) String() string {
+	return fmt.Sprintf("%s/%s", k.Service, k.Source)
+}
+
+// ConfigGraph is the job DAG of a provider. Each config is a node, and each entry of its DependsOn
+// is an edge from the upstream job to the config. Upstream jobs without a config are nodes too.
+type ConfigGraph struct {
+	Provider   string
+	configs    map[JobKey]*Config
+	dependents map[JobKey][]JobKey
+	nodes      []JobKey
+}
+
+// NewConfigGraph builds the job DAG of a provider from its configs. Configs of other providers are ignored.
+//
+// Parameters:
+//   - provider: The provider of the graph.
+//   - configs: The configs to add to the graph.
+//
+// Returns:
+//   - A pointer to the graph.
+func NewConfigGraph(provider string, configs []*Config) *ConfigGraph {
+	g := &ConfigGraph{
+		Provider:   provider,
+		configs:    make(map[JobKey]*Config),
+		dependents: make(map[JobKey][]JobKey),
+	}
+	seen := make(map[JobKey]bool)
+	addNode := func(key JobKey) {
+		if !seen[key] {
+			seen[key] = true
+			g.nodes = append(g.nodes, key)
+		}
+	}
+
+	for _, config := range configs {
+		if config.Provider != provider {
+			continue
+		}
+		key := JobKey{Service: config.Service, Source: config.Source}
+		g.configs[key] = config
+		addNode(key)
+		for _, dep := range config.DependsOn {
+			upstream := JobKey{Service: dep.Service, Source: dep.Source}
+			addNode(upstream)
+			g.dependents[upstream] = append(g.dependents[upstream], key)
+		}
+	}
+
+	sort.Slice(g.nodes, func(i, j int) bool { return lessJobKey(g.nodes[i], g.nodes[j]) })
+	for upstream := range g.dependents {
+		sort.Slice(g.dependents[upstream], func(i, j int) bool {
+			return lessJobKey(g.dependents[upstream][i], g.dependents[upstream][j])
+		})
+	}
+	return g
+}
+
+// Nodes returns the jobs of the graph, sorted by service and source.
+//
+// Returns:
+//   - The keys of the jobs.
+func (g *ConfigGraph) Nodes() []JobKey {
+	return g.nodes
+}
+
+// Config returns the config of a job.
+//
+// Parameters:
+//   - key: The key of the job.
+//
+// Returns:
+//   - The config, or nil if the job is only known as a dependency.
+func (g *ConfigGraph) Config(key JobKey) *Config {
+	return g.configs[key]
+}
+
+// Dependents returns the jobs depending directly on a job.
+//
+// Parameters:
+//   - key: The key of the upstream job.
+//
+// Returns:
+//   - The keys of the dependent jobs, sorted by service and source.
+func (g *ConfigGraph) Dependents(key JobKey) []JobKey {
+	return g.dependents[key]
+}
+
+// TopologicalOrder sorts the jobs so that every job comes after the jobs it depends on.
+//
+// Returns:
+//   - The keys of the jobs in dependency order.
+//   - An error wrapping ErrDependencyCycle, naming the jobs of a cycle, if the graph is not acyclic.
+func (g *ConfigGraph) TopologicalOrder() ([]JobKey, error) {
+	if cycle := g.FindCycle(); cycle != nil {
+		names := make([]string, len(cycle))
+		for i, key := range cycle {
+			names[i] = key.String()
+		}
+		return nil, fmt.Errorf("%w for provider %s: %s", ErrDependencyCycle, g.Provider, strings.Join(names, " -> "))
+	}
+
+	inDegree := make(map[JobKey]int)
+	for _, dependents := range g.dependents {
+		for _, dependent := range dependents {
+			inDegree[dependent]++
+		}
+	}
+	order := make([]JobKey, 0, len(g.nodes))
+	for _, key := range g.nodes {
+		if inDegree[key] == 0 {
+			order = append(order, key)
+		}
+	}
+	for i := 0; i < len(order); i++ {
+		for _, dependent := range g.dependents[order[i]] {
+			inDegree[dependent]--
+			if inDegree[dependent] == 0 {
+				order = append(order, dependent)
+			}
+		}
+	}
+	return order, nil
+}
+
+// FindCycle looks for a cycle in the graph.
+//
+// Returns:
+//   - The jobs of a cycle, starting and ending with the same job, or nil if the graph is acyclic.
+func (g *ConfigGraph) FindCycle() []JobKey {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[JobKey]int)
+	var path []JobKey
+
+	var visit func(key JobKey) []JobKey
+	visit = func(key JobKey) []JobKey {
+		state[key] = visiting
+		path = append(path, key)
+		for _, dependent := range g.dependents[key] {
+			switch state[dependent] {
+			case visiting:
+				for i, node := range path {
+					if node == dependent {
+						return append(append([]JobKey{}, path[i:]...), dependent)
+					}
+				}
+			case unvisited:
+				if cycle := visit(dependent); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[key] = visited
+		return nil
+	}
+
+	for _, key := range g.nodes {
+		if state[key] == unvisited {
+			if cycle := visit(key); cycle != nil {
+				return cycle
+			}
+		}
+	}
+	return nil
+}
+
+// CheckDependencyCycle checks that saving a config keeps the job DAG of its provider acyclic.
+//
+// Parameters:
+//   - config: The config to create or update.
+//   - configs: The configs already stored for the provider. A stored config with the same ID is replaced.
+//
+// Returns:
+//   - An error wrapping ErrDependencyCycle if the config would close a cycle, otherwise nil.
+func CheckDependencyCycle(config *Config, configs []*Config) error {
+	candidates := make([]*Config, 0, len(configs)+1)
+	for _, existing := range configs {
+		if existing.ID != config.ID {
+			candidates = append(candidates, existing)
+		}
+	}
+	candidates = append(candidates, config)
+
+	_, err := NewConfigGraph(config.Provider, candidates).TopologicalOrder()
+	return err
+}
+
+// lessJobKey orders job keys by service and source.
+func lessJobKey(a, b JobKey) bool {
+	if a.Service != b.Service {
+		return a.Service < b.Service
+	}
+	return a.Source < b.Source
+}
@@ -0,0 +1,85 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+
+	md5id "libs/golang/shared/id/go-md5"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type ConfigGraphSuite struct {
+	suite.Suite
+}
+
+func TestConfigGraphSuite(t *testing.T) {
+	suite.Run(t, new(ConfigGraphSuite))
+}
+
+// newGraphConfig creates a config of test_provider depending on the given service/source pairs.
+func newGraphConfig(service, source string, dependsOn ...JobKey) *Config {
+	deps := make([]JobDependencies, len(dependsOn))
+	for i, dep := range dependsOn {
+		deps[i] = JobDependencies{Service: dep.Service, Source: dep.Source}
+	}
+	return &Config{
+		ID:        md5id.NewID(getIDData(service, source, "test_provider")),
+		Service:   service,
+		Source:    source,
+		Provider:  "test_provider",
+		DependsOn: deps,
+	}
+}
+
+func (suite *ConfigGraphSuite) TestTopologicalOrder() {
+	raw := JobKey{Service: "crawler", Source: "raw"}
+	clean := JobKey{Service: "cleaner", Source: "clean"}
+	report := JobKey{Service: "reporter", Source: "report"}
+	configs := []*Config{
+		newGraphConfig("reporter", "report", clean, raw),
+		newGraphConfig("cleaner", "clean", raw),
+		{Service: "other", Source: "other", Provider: "other_provider"},
+	}
+
+	graph := NewConfigGraph("test_provider", configs)
+
+	assert.Equal(suite.T(), []JobKey{clean, raw, report}, graph.Nodes())
+	assert.Nil(suite.T(), graph.Config(raw))
+	assert.Equal(suite.T(), "cleaner", graph.Config(clean).Service)
+	assert.Equal(suite.T(), []JobKey{clean, report}, graph.Dependents(raw))
+
+	order, err := graph.TopologicalOrder()
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), []JobKey{raw, clean, report}, order)
+}
+
+func (suite *ConfigGraphSuite) TestFindCycle() {
+	a := JobKey{Service: "a", Source: "a"}
+	b := JobKey{Service: "b", Source: "b"}
+	configs := []*Config{
+		newGraphConfig("a", "a", b),
+		newGraphConfig("b", "b", a),
+	}
+
+	graph := NewConfigGraph("test_provider", configs)
+
+	assert.Equal(suite.T(), []JobKey{a, b, a}, graph.FindCycle())
+	_, err := graph.TopologicalOrder()
+	assert.True(suite.T(), errors.Is(err, ErrDependencyCycle))
+	assert.Contains(suite.T(), err.Error(), "a/a -> b/b -> a/a")
+}
+
+func (suite *ConfigGraphSuite) TestCheckDependencyCycle() {
+	a := JobKey{Service: "a", Source: "a"}
+	b := JobKey{Service: "b", Source: "b"}
+	stored := []*Config{newGraphConfig("b", "b", a)}
+
+	assert.Nil(suite.T(), CheckDependencyCycle(newGraphConfig("c", "c", b), stored))
+	assert.ErrorIs(suite.T(), CheckDependencyCycle(newGraphConfig("a", "a", b), stored), ErrDependencyCycle)
+	assert.ErrorIs(suite.T(), CheckDependencyCycle(newGraphConfig("a", "a", a), nil), ErrDependencyCycle)
+
+	// Updating b to drop its dependency removes the cycle.
+	assert.Nil(suite.T(), CheckDependencyCycle(newGraphConfig("b", "b"), append(stored, newGraphConfig("a", "a", b))))
+}
@@ -1,14 +1,26 @@
 package entity
 
+import (
+	"errors"
+
+	"libs/golang/shared/go-criteria/criteria"
+)
+
+var (
+	// ErrNotFound is returned by the repositories when no Config has the ID or the fields looked up.
+	ErrNotFound = errors.New("config not found")
+
+	// ErrAlreadyExists is returned by the repositories when creating a Config whose ID is already stored.
+	ErrAlreadyExists = errors.New("config already exists")
+)
+
 type ConfigRepositoryInterface interface {
 	Create(config *Config) error
 	FindByID(id string) (*Config, error)
 	FindAll() ([]*Config, error)
 	Update(config *Config) error
 	Delete(id string) error
-	FindAllByServiceAndProvider(provider, service string) ([]*Config, error)
-	FindAllBySourceAndProvider(provider, source string) ([]*Config, error)
-	FindAllByServiceAndSourceAndProvider(service, source, provider string) ([]*Config, error)
-	FindAllByServiceAndProviderAndActive(service, provider string, active bool) ([]*Config, error)
+	FindAllByFilter(filter ConfigFilter) ([]*Config, error)
+	FindPageByFilter(filter ConfigFilter, page criteria.Page) ([]*Config, string, error)
 	FindAllByProviderAndDependsOn(provider, service, source string) ([]*Config, error)
 }
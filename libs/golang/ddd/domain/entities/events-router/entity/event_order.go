@@ -6,6 +6,7 @@ import (
 	md5id "libs/golang/shared/id/go-md5"
 	uuid "libs/golang/shared/id/go-uuid"
 	"reflect"
+	"time"
 )
 
 var (
@@ -30,14 +31,16 @@ var (
 
 // EventOrder represents an event order entity with various attributes.
 type EventOrder struct {
-	ID           md5id.ID               `bson:"_id"`
-	Service      string                 `bson:"service"`
-	Source       string                 `bson:"source"`
-	Provider     string                 `bson:"provider"`
-	Stage        string                 `bson:"stage"`
-	ProcessingID uuid.ID                `bson:"processing_id"`
-	InputID      string                 `bson:"input_id"`
-	Data         map[string]interface{} `bson:"data"`
+	ID             md5id.ID               `bson:"_id"`
+	Service        string                 `bson:"service"`
+	Source         string                 `bson:"source"`
+	Provider       string                 `bson:"provider"`
+	Stage          string                 `bson:"stage"`
+	ProcessingID   uuid.ID                `bson:"processing_id"`
+	InputID        string                 `bson:"input_id"`
+	Data           map[string]interface{} `bson:"data"`
+	DispatchedData map[string]interface{} `bson:"dispatched_data"`
+	History        []StageTransition      `bson:"history"`
 }
 
 // EventOrderProps holds the properties required to create a new EventOrder.
@@ -47,21 +50,23 @@ type EventOrderProps struct {
 	Provider     string
 	ProcessingID string
 	InputID      string
-	Stage        string
+	Stage        string // Initial stage, StageReceived when empty
 	Data         map[string]interface{}
 }
 
-// getIDData constructs a map with the service, source, provider, and data information.
-func getIDData(service, source, provider string, data map[string]interface{}) map[string]interface{} {
+// getIDData constructs a map with the service, source, provider, processing ID and data information.
+// Each processing of an input gets its own order.
+func getIDData(service, source, provider, processingID string, data map[string]interface{}) map[string]interface{} {
 	return map[string]interface{}{
-		"service":  service,
-		"source":   source,
-		"provider": provider,
-		"data":     data,
+		"service":       service,
+		"source":        source,
+		"provider":      provider,
+		"processing_id": processingID,
+		"data":          data,
 	}
 }
 
-// NewEventOrder creates a new EventOrder with the provided properties.
+// NewEventOrder creates a new EventOrder with the provided properties. Its history starts with the initial stage.
 //
 // Parameters:
 //   - props: The properties required to create a new EventOrder.
@@ -70,7 +75,10 @@ func getIDData(service, source, provider string, data map[string]interface{}) ma
 //   - A pointer to the created EventOrder.
 //   - An error if the validation of the EventOrder fails.
 func NewEventOrder(props EventOrderProps) (*EventOrder, error) {
-	idData := getIDData(props.Service, props.Source, props.Provider, props.Data)
+	idData := getIDData(props.Service, props.Source, props.Provider, props.ProcessingID, props.Data)
+	if props.Stage == "" {
+		props.Stage = StageReceived
+	}
 	eventOrder := &EventOrder{
 		ID:           md5id.NewID(idData),
 		Service:      props.Service,
@@ -80,6 +88,10 @@ func NewEventOrder(props EventOrderProps) (*EventOrder, error) {
 		ProcessingID: uuid.ID(props.ProcessingID),
 		InputID:      props.InputID,
 		Data:         props.Data,
+		History: []StageTransition{{
+			To: props.Stage,
+			At: time.Now().Format(DateLayout),
+		}},
 	}
 
 	if err := eventOrder.isValid(); err != nil {
@@ -136,6 +148,15 @@ func (i *EventOrder) MapToEntity(doc map[string]interface{}) (*EventOrder, error
 		return nil, errors.New("field processing_id has invalid type")
 	}
 
+	// Orders stored before stages were tracked have no history.
+	if _, ok := doc["history"]; !ok {
+		doc["history"] = []interface{}{}
+	}
+	// Orders not dispatched yet, or dispatched before their data was stored, have no dispatched data.
+	if doc["dispatched_data"] == nil {
+		doc["dispatched_data"] = map[string]interface{}(nil)
+	}
+
 	eventOrderEntity, err := regularTypesConversion.ConvertFromMapStringToEntity(reflect.TypeOf(EventOrder{}), doc)
 	if err != nil {
 		return nil, err
@@ -171,7 +192,7 @@ func (i *EventOrder) isValid() error {
 		return ErrInvalidProcessingID
 	}
 
-	if i.Stage == "" {
+	if !IsValidStage(i.Stage) {
 		return ErrInvalidStage
 	}
 
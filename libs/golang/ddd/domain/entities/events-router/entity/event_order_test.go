@@ -23,7 +23,7 @@ func (suite *EventsRouterEventOrderSuite) TestNewEventOrderWhenSuccess() {
 		Source:       "test_source",
 		Provider:     "test_provider",
 		ProcessingID: "xyz789",
-		Stage:        "test_stage",
+		Stage:        StageReceived,
 		InputID:      "input-id",
 		Data:         map[string]interface{}{"key": "value"},
 	}
@@ -44,7 +44,7 @@ func (suite *EventsRouterEventOrderSuite) TestNewEventOrderWhenInvalidService()
 		Source:       "test_source",
 		Provider:     "test_provider",
 		ProcessingID: "xyz789",
-		Stage:        "test_stage",
+		Stage:        StageReceived,
 		InputID:      "input-id",
 		Data:         map[string]interface{}{"key": "value"},
 	}
@@ -61,7 +61,7 @@ func (suite *EventsRouterEventOrderSuite) TestNewEventOrderWhenInvalidSource() {
 		Source:       "",
 		Provider:     "test_provider",
 		ProcessingID: "xyz789",
-		Stage:        "test_stage",
+		Stage:        StageReceived,
 		InputID:      "input-id",
 		Data:         map[string]interface{}{"key": "value"},
 	}
@@ -78,7 +78,7 @@ func (suite *EventsRouterEventOrderSuite) TestNewEventOrderWhenInvalidProvider()
 		Source:       "test_source",
 		Provider:     "",
 		ProcessingID: "xyz789",
-		Stage:        "test_stage",
+		Stage:        StageReceived,
 		InputID:      "input-id",
 		Data:         map[string]interface{}{"key": "value"},
 	}
@@ -95,7 +95,7 @@ func (suite *EventsRouterEventOrderSuite) TestNewEventOrderWhenInvalidProcessing
 		Source:       "test_source",
 		Provider:     "test_provider",
 		ProcessingID: "",
-		Stage:        "test_stage",
+		Stage:        StageReceived,
 		InputID:      "input-id",
 		Data:         map[string]interface{}{"key": "value"},
 	}
@@ -112,7 +112,7 @@ func (suite *EventsRouterEventOrderSuite) TestNewEventOrderWhenInvalidStage() {
 		Source:       "test_source",
 		Provider:     "test_provider",
 		ProcessingID: "xyz789",
-		Stage:        "",
+		Stage:        "unknown_stage",
 		InputID:      "input-id",
 		Data:         map[string]interface{}{"key": "value"},
 	}
@@ -123,13 +123,32 @@ func (suite *EventsRouterEventOrderSuite) TestNewEventOrderWhenInvalidStage() {
 	assert.Equal(suite.T(), ErrInvalidStage, err)
 }
 
+func (suite *EventsRouterEventOrderSuite) TestNewEventOrderDefaultsToReceived() {
+	props := EventOrderProps{
+		Service:      "test_service",
+		Source:       "test_source",
+		Provider:     "test_provider",
+		ProcessingID: "xyz789",
+		InputID:      "input-id",
+		Data:         map[string]interface{}{"key": "value"},
+	}
+
+	eventOrder, err := NewEventOrder(props)
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), StageReceived, eventOrder.Stage)
+	assert.Equal(suite.T(), 1, len(eventOrder.History))
+	assert.Equal(suite.T(), "", eventOrder.History[0].From)
+	assert.Equal(suite.T(), StageReceived, eventOrder.History[0].To)
+}
+
 func (suite *EventsRouterEventOrderSuite) TestGetEntityID() {
 	props := EventOrderProps{
 		Service:      "test_service",
 		Source:       "test_source",
 		Provider:     "test_provider",
 		ProcessingID: "xyz789",
-		Stage:        "test_stage",
+		Stage:        StageReceived,
 		InputID:      "input-id",
 		Data:         map[string]interface{}{"key": "value"},
 	}
@@ -146,7 +165,7 @@ func (suite *EventsRouterEventOrderSuite) TestToMap() {
 		Source:       "test_source",
 		Provider:     "test_provider",
 		ProcessingID: "xyz789",
-		Stage:        "test_stage",
+		Stage:        StageReceived,
 		InputID:      "input-id",
 		Data:         map[string]interface{}{"key": "value"},
 	}
@@ -174,7 +193,7 @@ func (suite *EventsRouterEventOrderSuite) TestMapToEntity() {
 		Source:       "test_source",
 		Provider:     "test_provider",
 		ProcessingID: "xyz789",
-		Stage:        "test_stage",
+		Stage:        StageReceived,
 		InputID:      "input-id",
 		Data:         map[string]interface{}{"key": "value"},
 	}
@@ -199,4 +218,5 @@ func (suite *EventsRouterEventOrderSuite) TestMapToEntity() {
 	assert.Equal(suite.T(), eventOrder.Provider, newEventOrder.Provider)
 	assert.Equal(suite.T(), eventOrder.ProcessingID, newEventOrder.ProcessingID)
 	assert.Equal(suite.T(), eventOrder.Data, newEventOrder.Data)
+	assert.Equal(suite.T(), eventOrder.History, newEventOrder.History)
 }
@@ -0,0 +1,76 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrInvalidRetention is returned when the retention of a ProcessedMessage is not positive.
+	ErrInvalidRetention = errors.New("invalid retention")
+)
+
+// ProcessedMessage records that the input of a processing went through the pre-processing, so redeliveries
+// of the same processing can be recognized until the record expires. Its timestamps are stored in UTC with
+// DateLayout, so they order as strings.
+type ProcessedMessage struct {
+	ID           string `bson:"_id"`            // ID is the processing ID of the message.
+	EventOrderID string `bson:"event_order_id"` // EventOrderID is the ID of the event order of the processing.
+	ProcessedAt  string `bson:"processed_at"`   // ProcessedAt is the time the message was processed.
+	ExpiresAt    string `bson:"expires_at"`     // ExpiresAt is the time after which the message is no longer a duplicate.
+}
+
+// NewProcessedMessage creates a new ProcessedMessage.
+//
+// Parameters:
+//   - processingID: The processing ID of the message.
+//   - eventOrderID: The ID of the event order of the processing.
+//   - processedAt: The time the message was processed.
+//   - retention: How long redeliveries of the message are recognized as duplicates.
+//
+// Returns:
+//   - A pointer to the created ProcessedMessage.
+//   - An error if the processing ID is empty or the retention is not positive.
+func NewProcessedMessage(processingID, eventOrderID string, processedAt time.Time, retention time.Duration) (*ProcessedMessage, error) {
+	if processingID == "" {
+		return nil, ErrInvalidProcessingID
+	}
+	if retention <= 0 {
+		return nil, ErrInvalidRetention
+	}
+	return &ProcessedMessage{
+		ID:           processingID,
+		EventOrderID: eventOrderID,
+		ProcessedAt:  FormatUTC(processedAt),
+		ExpiresAt:    FormatUTC(processedAt.Add(retention)),
+	}, nil
+}
+
+// GetEntityID returns the unique identifier of the ProcessedMessage entity.
+func (m *ProcessedMessage) GetEntityID() string {
+	return m.ID
+}
+
+// ToMap converts the ProcessedMessage entity to a map.
+//
+// Returns:
+//   - A map representation of the ProcessedMessage entity.
+func (m *ProcessedMessage) ToMap() map[string]interface{} {
+	return map[string]interface{}{
+		"_id":            m.ID,
+		"event_order_id": m.EventOrderID,
+		"processed_at":   m.ProcessedAt,
+		"expires_at":     m.ExpiresAt,
+	}
+}
+
+// FormatUTC formats a time as a ProcessedMessage timestamp.
+//
+// Parameters:
+//   - t: The time to format.
+//
+// Returns:
+//   - The time in UTC, formatted with DateLayout.
+func FormatUTC(t time.Time) string {
+	return t.UTC().Format(DateLayout)
+}
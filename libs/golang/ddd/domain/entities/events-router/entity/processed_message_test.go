@@ -0,0 +1,45 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type EventsRouterProcessedMessageSuite struct {
+	suite.Suite
+	processedAt time.Time
+}
+
+func TestEventsRouterProcessedMessageSuite(t *testing.T) {
+	suite.Run(t, new(EventsRouterProcessedMessageSuite))
+}
+
+func (suite *EventsRouterProcessedMessageSuite) SetupTest() {
+	suite.processedAt = time.Date(2024, 6, 1, 12, 0, 0, 0, time.FixedZone("BRT", -3*60*60))
+}
+
+func (suite *EventsRouterProcessedMessageSuite) TestNewProcessedMessage() {
+	message, err := NewProcessedMessage("proc-1", "order-1", suite.processedAt, 24*time.Hour)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "proc-1", message.GetEntityID())
+	assert.Equal(suite.T(), "2024-06-01 15:00:00", message.ProcessedAt)
+	assert.Equal(suite.T(), "2024-06-02 15:00:00", message.ExpiresAt)
+	assert.Equal(suite.T(), map[string]interface{}{
+		"_id":            "proc-1",
+		"event_order_id": "order-1",
+		"processed_at":   "2024-06-01 15:00:00",
+		"expires_at":     "2024-06-02 15:00:00",
+	}, message.ToMap())
+}
+
+func (suite *EventsRouterProcessedMessageSuite) TestNewProcessedMessageWhenInvalid() {
+	_, err := NewProcessedMessage("", "order-1", suite.processedAt, time.Hour)
+	assert.ErrorIs(suite.T(), err, ErrInvalidProcessingID)
+
+	_, err = NewProcessedMessage("proc-1", "order-1", suite.processedAt, 0)
+	assert.ErrorIs(suite.T(), err, ErrInvalidRetention)
+}
@@ -0,0 +1,166 @@
+package entity
+
+import (
+	"errors"
+	regularTypesConversion "libs/golang/ddd/shared/type-tools/regular-types-converter/conversion"
+	md5id "libs/golang/shared/id/go-md5"
+	"reflect"
+)
+
+var (
+	// ErrInvalidWindow is returned when the window of a ProcessingWindow is invalid.
+	ErrInvalidWindow = errors.New("invalid window")
+
+	// ErrInvalidDependsOn is returned when a ProcessingWindow has no dependencies.
+	ErrInvalidDependsOn = errors.New("invalid depends on")
+)
+
+// JobDependency identifies an upstream job of a provider by its service and source.
+type JobDependency struct {
+	Service string `bson:"service"`
+	Source  string `bson:"source"`
+}
+
+// ProcessingWindow tracks, for a job with dependencies, which upstream jobs completed within a processing window.
+// The job is dispatched once all of its dependencies completed.
+type ProcessingWindow struct {
+	ID         md5id.ID        `bson:"_id"`
+	Service    string          `bson:"service"`
+	Source     string          `bson:"source"`
+	Provider   string          `bson:"provider"`
+	Window     string          `bson:"window"`
+	DependsOn  []JobDependency `bson:"depends_on"`
+	Completed  []JobDependency `bson:"completed"`
+	Dispatched bool            `bson:"dispatched"`
+}
+
+// ProcessingWindowProps holds the properties required to create a new ProcessingWindow.
+type ProcessingWindowProps struct {
+	Service   string
+	Source    string
+	Provider  string
+	Window    string
+	DependsOn []JobDependency
+}
+
+// NewProcessingWindow creates a new ProcessingWindow, with no completed dependency, for the provided properties.
+//
+// Parameters:
+//   - props: The properties required to create a new ProcessingWindow.
+//
+// Returns:
+//   - A pointer to the created ProcessingWindow.
+//   - An error if the validation of the ProcessingWindow fails.
+func NewProcessingWindow(props ProcessingWindowProps) (*ProcessingWindow, error) {
+	window := &ProcessingWindow{
+		ID: md5id.NewID(map[string]interface{}{
+			"service":  props.Service,
+			"source":   props.Source,
+			"provider": props.Provider,
+			"window":   props.Window,
+		}),
+		Service:   props.Service,
+		Source:    props.Source,
+		Provider:  props.Provider,
+		Window:    props.Window,
+		DependsOn: props.DependsOn,
+		Completed: []JobDependency{},
+	}
+
+	if err := window.isValid(); err != nil {
+		return nil, err
+	}
+
+	return window, nil
+}
+
+// GetEntityID returns the unique identifier of the ProcessingWindow entity.
+func (w *ProcessingWindow) GetEntityID() string {
+	return string(w.ID)
+}
+
+// IsSatisfied reports whether every dependency of the job completed within the window.
+//
+// Returns:
+//   - True if the job can be dispatched.
+func (w *ProcessingWindow) IsSatisfied() bool {
+	completed := make(map[JobDependency]bool, len(w.Completed))
+	for _, dep := range w.Completed {
+		completed[dep] = true
+	}
+	for _, dep := range w.DependsOn {
+		if !completed[dep] {
+			return false
+		}
+	}
+	return true
+}
+
+// ToMap converts the ProcessingWindow entity to a map.
+//
+// Returns:
+//   - A map representation of the ProcessingWindow entity.
+//   - An error if the conversion fails.
+func (w *ProcessingWindow) ToMap() (map[string]interface{}, error) {
+	doc, err := regularTypesConversion.ConvertFromEntityToMapString(w)
+	if err != nil {
+		return nil, err
+	}
+
+	if id, ok := doc["_id"].(md5id.ID); ok {
+		doc["_id"] = string(id)
+	}
+
+	return doc, nil
+}
+
+// MapToEntity converts a map to a ProcessingWindow entity.
+//
+// Parameters:
+//   - doc: The map representation of a ProcessingWindow.
+//
+// Returns:
+//   - A pointer to the ProcessingWindow entity.
+//   - An error if the conversion fails.
+func (w *ProcessingWindow) MapToEntity(doc map[string]interface{}) (*ProcessingWindow, error) {
+	if id, ok := doc["_id"].(string); ok {
+		doc["_id"] = md5id.ID(id)
+	} else {
+		return nil, errors.New("field _id has invalid type")
+	}
+
+	windowEntity, err := regularTypesConversion.ConvertFromMapStringToEntity(reflect.TypeOf(ProcessingWindow{}), doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return windowEntity.(*ProcessingWindow), nil
+}
+
+// isValid validates the ProcessingWindow entity.
+//
+// Returns:
+//   - An error if any of the required fields are invalid.
+func (w *ProcessingWindow) isValid() error {
+	if w.Service == "" {
+		return ErrInvalidService
+	}
+
+	if w.Source == "" {
+		return ErrInvalidSource
+	}
+
+	if w.Provider == "" {
+		return ErrInvalidProvider
+	}
+
+	if w.Window == "" {
+		return ErrInvalidWindow
+	}
+
+	if len(w.DependsOn) == 0 {
+		return ErrInvalidDependsOn
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type EventsRouterProcessingWindowSuite struct {
+	suite.Suite
+	props ProcessingWindowProps
+}
+
+func TestEventsRouterProcessingWindowSuite(t *testing.T) {
+	suite.Run(t, new(EventsRouterProcessingWindowSuite))
+}
+
+func (suite *EventsRouterProcessingWindowSuite) SetupTest() {
+	suite.props = ProcessingWindowProps{
+		Service:  "test_service",
+		Source:   "test_source",
+		Provider: "test_provider",
+		Window:   "2024-06-01 00:00:00",
+		DependsOn: []JobDependency{
+			{Service: "dep_service1", Source: "dep_source1"},
+			{Service: "dep_service2", Source: "dep_source2"},
+		},
+	}
+}
+
+func (suite *EventsRouterProcessingWindowSuite) TestNewProcessingWindowWhenSuccess() {
+	window, err := NewProcessingWindow(suite.props)
+
+	assert.Nil(suite.T(), err)
+	assert.NotEmpty(suite.T(), window.ID)
+	assert.Equal(suite.T(), []JobDependency{}, window.Completed)
+	assert.False(suite.T(), window.Dispatched)
+
+	other := suite.props
+	other.Window = "2024-06-02 00:00:00"
+	otherWindow, _ := NewProcessingWindow(other)
+	assert.NotEqual(suite.T(), window.ID, otherWindow.ID)
+}
+
+func (suite *EventsRouterProcessingWindowSuite) TestNewProcessingWindowWhenInvalid() {
+	props := suite.props
+	props.Window = ""
+	_, err := NewProcessingWindow(props)
+	assert.Equal(suite.T(), ErrInvalidWindow, err)
+
+	props = suite.props
+	props.DependsOn = nil
+	_, err = NewProcessingWindow(props)
+	assert.Equal(suite.T(), ErrInvalidDependsOn, err)
+}
+
+func (suite *EventsRouterProcessingWindowSuite) TestIsSatisfied() {
+	window, _ := NewProcessingWindow(suite.props)
+	assert.False(suite.T(), window.IsSatisfied())
+
+	window.Completed = append(window.Completed, JobDependency{Service: "dep_service2", Source: "dep_source2"})
+	assert.False(suite.T(), window.IsSatisfied())
+
+	window.Completed = append(window.Completed, JobDependency{Service: "dep_service1", Source: "dep_source1"})
+	assert.True(suite.T(), window.IsSatisfied())
+}
+
+func (suite *EventsRouterProcessingWindowSuite) TestMapRoundTrip() {
+	window, _ := NewProcessingWindow(suite.props)
+	window.Completed = []JobDependency{{Service: "dep_service1", Source: "dep_source1"}}
+
+	doc, err := window.ToMap()
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), string(window.ID), doc["_id"])
+
+	result, err := (&ProcessingWindow{}).MapToEntity(doc)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), window, result)
+}
@@ -1,8 +1,28 @@
 package entity
 
+import "time"
+
 type EventOrderRepositoryInterface interface {
 	Create(output *EventOrder) error
 	FindByID(id string) (*EventOrder, error)
+	FindByProcessingID(processingID string) (*EventOrder, error)
 	FindAll() ([]*EventOrder, error)
+	FindByStages(stages ...string) ([]*EventOrder, error)
+	UpdateStage(id, stage, detail string) (*EventOrder, error)
+	Dispatch(id string, data map[string]interface{}) (*EventOrder, error)
+	Redispatch(id, detail string) (*EventOrder, error)
 	Delete(id string) error
 }
+
+type ProcessingWindowRepositoryInterface interface {
+	AddCompletion(window *ProcessingWindow, completed JobDependency) (*ProcessingWindow, error)
+	MarkDispatched(id string) (bool, error)
+	UnmarkDispatched(id string) error
+	FindByID(id string) (*ProcessingWindow, error)
+}
+
+type ProcessedMessageRepositoryInterface interface {
+	Save(message *ProcessedMessage) error
+	IsProcessed(processingID string, at time.Time) (bool, error)
+	DeleteExpired(at time.Time) (int, error)
+}
@@ -0,0 +1,179 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Stages of an EventOrder, in pipeline order. Completed, skipped and failed are final.
+const (
+	StageReceived     = "received"      // The input was received by the events-router
+	StagePreProcessed = "pre-processed" // The input passed pre-processing
+	StageDispatched   = "dispatched"    // The process order was dispatched to the job
+	StageProcessing   = "processing"    // The job reported that it is processing the order
+	StageOutputStored = "output-stored" // The output of the job was stored
+	StageCompleted    = "completed"     // The input was marked as processed
+	StageSkipped      = "skipped"       // A pre-processing action stopped the order before it was dispatched
+	StageFailed       = "failed"        // The order cannot progress anymore
+)
+
+var (
+	// ErrIllegalStageTransition is returned when an EventOrder cannot move from its stage to the requested one.
+	ErrIllegalStageTransition = errors.New("illegal stage transition")
+
+	// DateLayout defines the layout of the timestamps of the stage history.
+	DateLayout = "2006-01-02 15:04:05"
+
+	// stageTransitions lists, for each stage, the stages an EventOrder can move to.
+	// An order can skip processing when the job does not report it.
+	stageTransitions = map[string][]string{
+		StageReceived:     {StagePreProcessed, StageSkipped, StageFailed},
+		StagePreProcessed: {StageDispatched, StageFailed},
+		StageDispatched:   {StageProcessing, StageOutputStored, StageFailed},
+		StageProcessing:   {StageOutputStored, StageFailed},
+		StageOutputStored: {StageCompleted, StageFailed},
+		StageCompleted:    {},
+		StageSkipped:      {},
+		StageFailed:       {},
+	}
+)
+
+// StageTransition records a change of stage of an EventOrder.
+type StageTransition struct {
+	From   string `bson:"from"`   // Stage before the transition, empty for the initial stage
+	To     string `bson:"to"`     // Stage after the transition
+	At     string `bson:"at"`     // Time of the transition, in DateLayout
+	Detail string `bson:"detail"` // Optional reason of the transition
+}
+
+// IsValidStage reports whether a stage is one of the stages of an EventOrder.
+//
+// Parameters:
+//   - stage: The stage to check.
+//
+// Returns:
+//   - True if the stage is known.
+func IsValidStage(stage string) bool {
+	_, ok := stageTransitions[stage]
+	return ok
+}
+
+// IsFinalStage reports whether an EventOrder in a stage can no longer move.
+//
+// Parameters:
+//   - stage: The stage to check.
+//
+// Returns:
+//   - True if the stage is completed, skipped or failed.
+func IsFinalStage(stage string) bool {
+	return stage == StageCompleted || stage == StageSkipped || stage == StageFailed
+}
+
+// CanTransition reports whether an EventOrder can move from a stage to another.
+//
+// Parameters:
+//   - from: The current stage.
+//   - to: The requested stage.
+//
+// Returns:
+//   - True if the transition is allowed.
+func CanTransition(from, to string) bool {
+	for _, allowed := range stageTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Transition moves the EventOrder to a new stage and records the change in its history.
+// Moving to the current stage is a no-op, so redelivered events are harmless.
+//
+// Parameters:
+//   - to: The requested stage.
+//   - detail: An optional reason, recorded in the history.
+//
+// Returns:
+//   - An error wrapping ErrIllegalStageTransition if the transition is not allowed.
+func (i *EventOrder) Transition(to, detail string) error {
+	if to == i.Stage {
+		return nil
+	}
+	if !CanTransition(i.Stage, to) {
+		return fmt.Errorf("%w: from %q to %q for order %s", ErrIllegalStageTransition, i.Stage, to, i.ID)
+	}
+	i.History = append(i.History, StageTransition{
+		From:   i.Stage,
+		To:     to,
+		At:     time.Now().Format(DateLayout),
+		Detail: detail,
+	})
+	i.Stage = to
+	return nil
+}
+
+// Dispatch moves the EventOrder to the dispatched stage and stores the data of its process order as it was
+// dispatched, after the pre-processing actions enriched or transformed it, so the order can be dispatched
+// again with the same data.
+//
+// Parameters:
+//   - data: The data of the dispatched process order.
+//
+// Returns:
+//   - An error wrapping ErrIllegalStageTransition if the order cannot move to the dispatched stage.
+func (i *EventOrder) Dispatch(data map[string]interface{}) error {
+	if err := i.Transition(StageDispatched, ""); err != nil {
+		return err
+	}
+	i.DispatchedData = data
+	return nil
+}
+
+// Redispatch records that the process order of the EventOrder was dispatched again, after its job did not
+// report back. It is allowed while the order waits for its job, in the dispatched or processing stage.
+//
+// Parameters:
+//   - detail: An optional reason, recorded in the history.
+//
+// Returns:
+//   - An error wrapping ErrIllegalStageTransition if the order is not waiting for its job.
+func (i *EventOrder) Redispatch(detail string) error {
+	if i.Stage != StageDispatched && i.Stage != StageProcessing {
+		return fmt.Errorf("%w: cannot re-dispatch order %s from %q", ErrIllegalStageTransition, i.ID, i.Stage)
+	}
+	i.History = append(i.History, StageTransition{
+		From:   i.Stage,
+		To:     StageDispatched,
+		At:     time.Now().Format(DateLayout),
+		Detail: detail,
+	})
+	i.Stage = StageDispatched
+	return nil
+}
+
+// DispatchCount returns how many times the process order of the EventOrder was dispatched.
+//
+// Returns:
+//   - The number of transitions to the dispatched stage.
+func (i *EventOrder) DispatchCount() int {
+	count := 0
+	for _, transition := range i.History {
+		if transition.To == StageDispatched {
+			count++
+		}
+	}
+	return count
+}
+
+// StageEnteredAt returns when the EventOrder entered its current stage.
+//
+// Returns:
+//   - The time of the last transition.
+//   - An error if the history is empty or its timestamp cannot be parsed.
+func (i *EventOrder) StageEnteredAt() (time.Time, error) {
+	if len(i.History) == 0 {
+		return time.Time{}, fmt.Errorf("order %s has no stage history", i.ID)
+	}
+	return time.ParseInLocation(DateLayout, i.History[len(i.History)-1].At, time.Local)
+}
@@ -0,0 +1,113 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type EventOrderStageSuite struct {
+	suite.Suite
+	eventOrder *EventOrder
+}
+
+func TestEventOrderStageSuite(t *testing.T) {
+	suite.Run(t, new(EventOrderStageSuite))
+}
+
+func (suite *EventOrderStageSuite) SetupTest() {
+	suite.eventOrder, _ = NewEventOrder(EventOrderProps{
+		Service:      "test_service",
+		Source:       "test_source",
+		Provider:     "test_provider",
+		ProcessingID: "xyz789",
+		InputID:      "input-id",
+		Data:         map[string]interface{}{"key": "value"},
+	})
+}
+
+func (suite *EventOrderStageSuite) TestTransitionThroughPipeline() {
+	for _, stage := range []string{StagePreProcessed, StageDispatched, StageProcessing, StageOutputStored, StageCompleted} {
+		assert.Nil(suite.T(), suite.eventOrder.Transition(stage, ""))
+	}
+
+	assert.Equal(suite.T(), StageCompleted, suite.eventOrder.Stage)
+	assert.Equal(suite.T(), 6, len(suite.eventOrder.History))
+	last := suite.eventOrder.History[5]
+	assert.Equal(suite.T(), StageOutputStored, last.From)
+	assert.Equal(suite.T(), StageCompleted, last.To)
+	assert.NotEmpty(suite.T(), last.At)
+}
+
+func (suite *EventOrderStageSuite) TestTransitionWhenIllegal() {
+	err := suite.eventOrder.Transition(StageOutputStored, "")
+
+	assert.ErrorIs(suite.T(), err, ErrIllegalStageTransition)
+	assert.Contains(suite.T(), err.Error(), `from "received" to "output-stored"`)
+	assert.Equal(suite.T(), StageReceived, suite.eventOrder.Stage)
+	assert.Equal(suite.T(), 1, len(suite.eventOrder.History))
+}
+
+func (suite *EventOrderStageSuite) TestTransitionFromFinalStage() {
+	assert.Nil(suite.T(), suite.eventOrder.Transition(StageFailed, "timed out"))
+	assert.Equal(suite.T(), "timed out", suite.eventOrder.History[1].Detail)
+
+	assert.ErrorIs(suite.T(), suite.eventOrder.Transition(StagePreProcessed, ""), ErrIllegalStageTransition)
+	assert.True(suite.T(), IsFinalStage(suite.eventOrder.Stage))
+}
+
+func (suite *EventOrderStageSuite) TestTransitionToCurrentStage() {
+	assert.Nil(suite.T(), suite.eventOrder.Transition(StageReceived, ""))
+	assert.Equal(suite.T(), 1, len(suite.eventOrder.History))
+}
+
+func (suite *EventOrderStageSuite) TestCanTransition() {
+	assert.True(suite.T(), CanTransition(StageDispatched, StageOutputStored))
+	assert.False(suite.T(), CanTransition(StageCompleted, StageFailed))
+	assert.True(suite.T(), CanTransition(StageReceived, StageSkipped))
+	assert.False(suite.T(), CanTransition(StageDispatched, StageSkipped))
+	assert.True(suite.T(), IsFinalStage(StageSkipped))
+	assert.False(suite.T(), CanTransition("unknown_stage", StageReceived))
+	assert.False(suite.T(), IsValidStage("unknown_stage"))
+}
+
+func (suite *EventOrderStageSuite) TestStageEnteredAt() {
+	enteredAt, err := suite.eventOrder.StageEnteredAt()
+
+	assert.Nil(suite.T(), err)
+	assert.WithinDuration(suite.T(), time.Now(), enteredAt, 2*time.Second)
+
+	_, err = (&EventOrder{}).StageEnteredAt()
+	assert.NotNil(suite.T(), err)
+}
+
+func (suite *EventOrderStageSuite) TestRedispatch() {
+	assert.ErrorIs(suite.T(), suite.eventOrder.Redispatch(""), ErrIllegalStageTransition)
+
+	suite.eventOrder.Transition(StagePreProcessed, "")
+	suite.eventOrder.Transition(StageDispatched, "")
+	suite.eventOrder.Transition(StageProcessing, "")
+	assert.Equal(suite.T(), 1, suite.eventOrder.DispatchCount())
+
+	assert.Nil(suite.T(), suite.eventOrder.Redispatch("timed out"))
+	assert.Equal(suite.T(), StageDispatched, suite.eventOrder.Stage)
+	assert.Equal(suite.T(), 2, suite.eventOrder.DispatchCount())
+	assert.Equal(suite.T(), StageProcessing, suite.eventOrder.History[4].From)
+}
+
+func (suite *EventOrderStageSuite) TestDispatch() {
+	data := map[string]interface{}{"label": "value"}
+	assert.ErrorIs(suite.T(), suite.eventOrder.Dispatch(data), ErrIllegalStageTransition)
+	assert.Nil(suite.T(), suite.eventOrder.DispatchedData)
+
+	suite.eventOrder.Transition(StagePreProcessed, "")
+	assert.Nil(suite.T(), suite.eventOrder.Dispatch(data))
+	assert.Equal(suite.T(), StageDispatched, suite.eventOrder.Stage)
+	assert.Equal(suite.T(), data, suite.eventOrder.DispatchedData)
+	assert.Equal(suite.T(), 1, suite.eventOrder.DispatchCount())
+
+	assert.Nil(suite.T(), suite.eventOrder.Redispatch("timed out"))
+	assert.Equal(suite.T(), data, suite.eventOrder.DispatchedData)
+}
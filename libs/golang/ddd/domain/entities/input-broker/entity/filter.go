@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"libs/golang/shared/go-criteria/criteria"
+	"time"
+)
+
+// InputFilter selects the Input entities to list. Its zero value selects every input, and each set field
+// narrows the selection.
+type InputFilter struct {
+	Provider      string    // Provider of the inputs, any when empty
+	Service       string    // Service of the inputs, any when empty
+	Source        string    // Source of the inputs, any when empty
+	Status        *int      // Status code of the inputs, any when nil
+	CreatedAfter  time.Time // Inputs created strictly after this time, no lower bound when zero
+	CreatedBefore time.Time // Inputs created strictly before this time, no upper bound when zero
+}
+
+// Criteria translates the filter into query criteria on the Input documents.
+//
+// Returns:
+//   - The criteria matching the inputs selected by the filter.
+func (f InputFilter) Criteria() criteria.Criteria {
+	c := criteria.Criteria{}.
+		WhereNotEmpty("metadata.provider", f.Provider).
+		WhereNotEmpty("metadata.service", f.Service).
+		WhereNotEmpty("metadata.source", f.Source).
+		WhereTime("created_at", criteria.Gt, f.CreatedAfter, DateLayout).
+		WhereTime("created_at", criteria.Lt, f.CreatedBefore, DateLayout)
+	if f.Status != nil {
+		c = c.Where("status.code", criteria.Eq, *f.Status)
+	}
+	return c
+}
@@ -0,0 +1,38 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type InputBrokerFilterSuite struct {
+	suite.Suite
+}
+
+func TestInputBrokerFilterSuite(t *testing.T) {
+	suite.Run(t, new(InputBrokerFilterSuite))
+}
+
+func (suite *InputBrokerFilterSuite) TestCriteria() {
+	status := 0
+	filter := InputFilter{
+		Provider:     "test_provider",
+		Source:       "test_source",
+		Status:       &status,
+		CreatedAfter: time.Date(2024, 6, 1, 0, 0, 0, 0, time.Local),
+	}
+
+	assert.Equal(suite.T(), map[string]interface{}{
+		"metadata.provider": "test_provider",
+		"metadata.source":   "test_source",
+		"status.code":       0,
+		"created_at":        map[string]interface{}{"$gt": "2024-06-01 00:00:00"},
+	}, filter.Criteria().Query())
+}
+
+func (suite *InputBrokerFilterSuite) TestEmptyFilterMatchesEverything() {
+	assert.Empty(suite.T(), InputFilter{}.Criteria().Query())
+}
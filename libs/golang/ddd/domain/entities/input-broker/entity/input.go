@@ -67,12 +67,13 @@ type Metadata struct {
 }
 
 type Input struct {
-	ID        md5id.ID               `bson:"_id"`        // ID is the unique identifier of the Input entity.
-	Data      map[string]interface{} `bson:"data"`       // Data represents the input data.
-	Metadata  Metadata               `bson:"metadata"`   // Metadata represents the metadata of the input data.
-	Status    Status                 `bson:"status"`     // Status represents the status of the input data.
-	CreatedAt string                 `bson:"created_at"` // CreatedAt is the timestamp when the Input entity was created.
-	UpdatedAt string                 `bson:"updated_at"` // UpdatedAt is the timestamp when the Input entity was last updated.
+	ID             md5id.ID               `bson:"_id"`             // ID is the unique identifier of the Input entity.
+	Data           map[string]interface{} `bson:"data"`            // Data represents the input data.
+	Metadata       Metadata               `bson:"metadata"`        // Metadata represents the metadata of the input data.
+	Status         Status                 `bson:"status"`          // Status represents the status of the input data.
+	IdempotencyKey string                 `bson:"idempotency_key"` // IdempotencyKey is the key of the request that created the Input entity, if any.
+	CreatedAt      string                 `bson:"created_at"`      // CreatedAt is the timestamp when the Input entity was created.
+	UpdatedAt      string                 `bson:"updated_at"`      // UpdatedAt is the timestamp when the Input entity was last updated.
 }
 
 // getIDData constructs a map with the service, source, provider and data information.
@@ -142,6 +143,11 @@ func (i *Input) SetProcessingTimestamp(timestamp time.Time) {
 	i.Metadata.ProcessingTimestamp = timestamp.Format(DateLayout)
 }
 
+// SetIdempotencyKey sets the key of the request that created the Input entity.
+func (i *Input) SetIdempotencyKey(key string) {
+	i.IdempotencyKey = key
+}
+
 // SetCreatedAt sets the created at timestamp of the Input entity.
 func (i *Input) SetCreatedAt(createdAt string) {
 	i.CreatedAt = createdAt
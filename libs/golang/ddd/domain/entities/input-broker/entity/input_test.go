@@ -127,6 +127,7 @@ func (suite *InputVaultConfigSuite) TestMapToEntity() {
 	input, err := NewInput(inputProps)
 	assert.Nil(suite.T(), err)
 	assert.NotNil(suite.T(), input)
+	input.SetIdempotencyKey("request-1")
 
 	doc, err := input.ToMap()
 	assert.Nil(suite.T(), err)
@@ -146,4 +147,5 @@ func (suite *InputVaultConfigSuite) TestMapToEntity() {
 	assert.Equal(suite.T(), input.Status, newInput.Status)
 	assert.Equal(suite.T(), input.CreatedAt, newInput.CreatedAt)
 	assert.Equal(suite.T(), input.UpdatedAt, newInput.UpdatedAt)
+	assert.Equal(suite.T(), "request-1", newInput.IdempotencyKey)
 }
@@ -1,16 +1,28 @@
 package entity
 
+import (
+	"errors"
+
+	"libs/golang/shared/go-criteria/criteria"
+	events "libs/golang/shared/go-events/amqp_events"
+)
+
+var (
+	// ErrNotFound is returned by the repositories when no Input has the ID or the fields looked up.
+	ErrNotFound = errors.New("input not found")
+
+	// ErrAlreadyExists is returned by the repositories when creating an Input whose ID is already stored.
+	ErrAlreadyExists = errors.New("input already exists")
+)
+
 type InputRepositoryInterface interface {
 	Create(output *Input) error
+	CreateWithEvent(output *Input, event events.EventInterface, routingKey string) error
 	FindByID(id string) (*Input, error)
+	FindByIdempotencyKey(key string) (*Input, error)
 	FindAll() ([]*Input, error)
 	Update(output *Input) error
 	Delete(id string) error
-	FindAllByStatusAndProvider(provider string, status int) ([]*Input, error)
-	FindAllByServiceAndProvider(provider, service string) ([]*Input, error)
-	FindAllBySourceAndProvider(provider, source string) ([]*Input, error)
-	FindAllByServiceAndSourceAndProvider(provider, service, source string) ([]*Input, error)
-	FindAllByStatusAndServiceAndProvider(service, provider string, status int) ([]*Input, error)
-	FindAllByStatusAndSourceAndProvider(source, provider string, status int) ([]*Input, error)
-	FindAllByStatusAndServiceAndSourceAndProvider(service, source, provider string, status int) ([]*Input, error)
+	FindAllByFilter(filter InputFilter) ([]*Input, error)
+	FindPageByFilter(filter InputFilter, page criteria.Page) ([]*Input, string, error)
 }
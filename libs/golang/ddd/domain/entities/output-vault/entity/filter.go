@@ -0,0 +1,29 @@
+package entity
+
+import (
+	"libs/golang/shared/go-criteria/criteria"
+	"time"
+)
+
+// OutputFilter selects the Output entities to list. Its zero value selects every output, and each set
+// field narrows the selection.
+type OutputFilter struct {
+	Provider      string    // Provider of the outputs, any when empty
+	Service       string    // Service of the outputs, any when empty
+	Source        string    // Source of the outputs, any when empty
+	CreatedAfter  time.Time // Outputs created strictly after this time, no lower bound when zero
+	CreatedBefore time.Time // Outputs created strictly before this time, no upper bound when zero
+}
+
+// Criteria translates the filter into query criteria on the Output documents.
+//
+// Returns:
+//   - The criteria matching the outputs selected by the filter.
+func (f OutputFilter) Criteria() criteria.Criteria {
+	return criteria.Criteria{}.
+		WhereNotEmpty("provider", f.Provider).
+		WhereNotEmpty("service", f.Service).
+		WhereNotEmpty("source", f.Source).
+		WhereTime("created_at", criteria.Gt, f.CreatedAfter, dateLayout).
+		WhereTime("created_at", criteria.Lt, f.CreatedBefore, dateLayout)
+}
@@ -0,0 +1,35 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type OutputVaultFilterSuite struct {
+	suite.Suite
+}
+
+func TestOutputVaultFilterSuite(t *testing.T) {
+	suite.Run(t, new(OutputVaultFilterSuite))
+}
+
+func (suite *OutputVaultFilterSuite) TestCriteria() {
+	filter := OutputFilter{
+		Provider:      "test_provider",
+		Service:       "test_service",
+		CreatedBefore: time.Date(2024, 6, 1, 0, 0, 0, 0, time.Local),
+	}
+
+	assert.Equal(suite.T(), map[string]interface{}{
+		"provider":   "test_provider",
+		"service":    "test_service",
+		"created_at": map[string]interface{}{"$lt": "2024-06-01 00:00:00"},
+	}, filter.Criteria().Query())
+}
+
+func (suite *OutputVaultFilterSuite) TestEmptyFilterMatchesEverything() {
+	assert.Empty(suite.T(), OutputFilter{}.Criteria().Query())
+}
@@ -1,12 +1,27 @@
 package entity
 
+import (
+	"errors"
+
+	"libs/golang/shared/go-criteria/criteria"
+	events "libs/golang/shared/go-events/amqp_events"
+)
+
+var (
+	// ErrNotFound is returned by the repositories when no Output has the ID or the fields looked up.
+	ErrNotFound = errors.New("output not found")
+
+	// ErrAlreadyExists is returned by the repositories when creating an Output whose ID is already stored.
+	ErrAlreadyExists = errors.New("output already exists")
+)
+
 type OutputRepositoryInterface interface {
 	Create(output *Output) error
+	CreateWithEvent(output *Output, event events.EventInterface, routingKey string) error
 	FindByID(id string) (*Output, error)
 	FindAll() ([]*Output, error)
 	Update(output *Output) error
 	Delete(id string) error
-	FindAllByServiceAndProvider(provider, service string) ([]*Output, error)
-	FindAllBySourceAndProvider(provider, source string) ([]*Output, error)
-	FindAllByServiceAndSourceAndProvider(service, source, provider string) ([]*Output, error)
+	FindAllByFilter(filter OutputFilter) ([]*Output, error)
+	FindPageByFilter(filter OutputFilter, page criteria.Page) ([]*Output, string, error)
 }
@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"libs/golang/shared/go-criteria/criteria"
+	"time"
+)
+
+// SchemaFilter selects the Schema entities to list. Its zero value selects every schema, and each set
+// field narrows the selection.
+type SchemaFilter struct {
+	Provider      string    // Provider of the schemas, any when empty
+	Service       string    // Service of the schemas, any when empty
+	Source        string    // Source of the schemas, any when empty
+	SchemaType    string    // Type of the schemas, such as "input" or "output", any when empty
+	CreatedAfter  time.Time // Schemas created strictly after this time, no lower bound when zero
+	CreatedBefore time.Time // Schemas created strictly before this time, no upper bound when zero
+}
+
+// Criteria translates the filter into query criteria on the Schema documents.
+//
+// Returns:
+//   - The criteria matching the schemas selected by the filter.
+func (f SchemaFilter) Criteria() criteria.Criteria {
+	return criteria.Criteria{}.
+		WhereNotEmpty("provider", f.Provider).
+		WhereNotEmpty("service", f.Service).
+		WhereNotEmpty("source", f.Source).
+		WhereNotEmpty("schema_type", f.SchemaType).
+		WhereTime("created_at", criteria.Gt, f.CreatedAfter, dateLayout).
+		WhereTime("created_at", criteria.Lt, f.CreatedBefore, dateLayout)
+}
@@ -0,0 +1,30 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type SchemaVaultFilterSuite struct {
+	suite.Suite
+}
+
+func TestSchemaVaultFilterSuite(t *testing.T) {
+	suite.Run(t, new(SchemaVaultFilterSuite))
+}
+
+func (suite *SchemaVaultFilterSuite) TestCriteria() {
+	filter := SchemaFilter{Provider: "test_provider", Source: "test_source", SchemaType: "output"}
+
+	assert.Equal(suite.T(), map[string]interface{}{
+		"provider":    "test_provider",
+		"source":      "test_source",
+		"schema_type": "output",
+	}, filter.Criteria().Query())
+}
+
+func (suite *SchemaVaultFilterSuite) TestEmptyFilterMatchesEverything() {
+	assert.Empty(suite.T(), SchemaFilter{}.Criteria().Query())
+}
@@ -1,13 +1,26 @@
 package entity
 
+import (
+	"errors"
+
+	"libs/golang/shared/go-criteria/criteria"
+)
+
+var (
+	// ErrNotFound is returned by the repositories when no Schema has the ID or the fields looked up.
+	ErrNotFound = errors.New("schema not found")
+
+	// ErrAlreadyExists is returned by the repositories when creating a Schema whose ID is already stored.
+	ErrAlreadyExists = errors.New("schema already exists")
+)
+
 type SchemaRepositoryInterface interface {
 	Create(schema *Schema) error
 	FindByID(id string) (*Schema, error)
 	FindAll() ([]*Schema, error)
 	Update(schema *Schema) error
 	Delete(id string) error
-	FindAllByServiceAndProvider(provider, service string) ([]*Schema, error)
-	FindAllBySourceAndProvider(provider, source string) ([]*Schema, error)
-	FindAllByServiceAndSourceAndProvider(service, source, provider string) ([]*Schema, error)
+	FindAllByFilter(filter SchemaFilter) ([]*Schema, error)
+	FindPageByFilter(filter SchemaFilter, page criteria.Page) ([]*Schema, string, error)
 	FindOneByServiceAndSourceAndProviderAndSchemaType(provider, service, source, schemaType string) (*Schema, error)
 }
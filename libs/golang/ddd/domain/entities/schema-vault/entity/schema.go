@@ -2,6 +2,7 @@ package entity
 
 import (
 	"errors"
+	"fmt"
 	"reflect"
 	"time"
 
@@ -30,6 +31,9 @@ var (
 	// ErrJsonSchemaInvalid is returned when the JSON schema of a Schema is invalid.
 	ErrJsonSchemaInvalid = errors.New("invalid JSON schema")
 
+	// ErrTransformationInvalid is returned when the transformation of a Schema is invalid.
+	ErrTransformationInvalid = errors.New("invalid transformation")
+
 	// dateLayout defines the layout for parsing and formatting dates.
 	dateLayout = "2006-01-02 15:04:05"
 )
@@ -40,17 +44,26 @@ type JsonSchema struct {
 	JsonType   string                 `bson:"type"`       // JsonType specifies the type of JSON schema.
 }
 
+// FieldMapping describes how a field of the data is reshaped to the schema.
+type FieldMapping struct {
+	From    string      `bson:"from"`    // From is the dot-separated path of the field in the source data, To when empty.
+	To      string      `bson:"to"`      // To is the dot-separated path of the field in the transformed data.
+	Type    string      `bson:"type"`    // Type is the JSON type the value is cast to: string, integer, number or boolean.
+	Default interface{} `bson:"default"` // Default is the value used when the source field is missing.
+}
+
 // Schema represents a schema entity with various attributes such as service, source, provider, and schema type.
 type Schema struct {
-	ID              md5id.ID   `bson:"_id"`               // ID is the unique identifier of the Schema entity.
-	Service         string     `bson:"service"`           // Service is the service name of the Schema entity.
-	Source          string     `bson:"source"`            // Source is the source name of the Schema entity.
-	Provider        string     `bson:"provider"`          // Provider is the provider name of the Schema entity.
-	SchemaType      string     `bson:"schema_type"`       // SchemaType is the type of the schema entity.
-	JsonSchema      JsonSchema `bson:"json_schema"`       // JsonSchema is the JSON schema of the Schema entity.
-	SchemaVersionID uuid.ID    `bson:"schema_version_id"` // SchemaVersionID is the unique identifier of the schema version.
-	CreatedAt       string     `bson:"created_at"`        // CreatedAt is the timestamp when the Schema entity was created.
-	UpdatedAt       string     `bson:"updated_at"`        // UpdatedAt is the timestamp when the Schema entity was last updated.
+	ID              md5id.ID       `bson:"_id"`               // ID is the unique identifier of the Schema entity.
+	Service         string         `bson:"service"`           // Service is the service name of the Schema entity.
+	Source          string         `bson:"source"`            // Source is the source name of the Schema entity.
+	Provider        string         `bson:"provider"`          // Provider is the provider name of the Schema entity.
+	SchemaType      string         `bson:"schema_type"`       // SchemaType is the type of the schema entity.
+	JsonSchema      JsonSchema     `bson:"json_schema"`       // JsonSchema is the JSON schema of the Schema entity.
+	Transformation  []FieldMapping `bson:"transformation"`    // Transformation reshapes the data to the JSON schema.
+	SchemaVersionID uuid.ID        `bson:"schema_version_id"` // SchemaVersionID is the unique identifier of the schema version.
+	CreatedAt       string         `bson:"created_at"`        // CreatedAt is the timestamp when the Schema entity was created.
+	UpdatedAt       string         `bson:"updated_at"`        // UpdatedAt is the timestamp when the Schema entity was last updated.
 }
 
 // SchemaProps represents the properties needed to create a new Schema entity.
@@ -60,6 +73,8 @@ type SchemaProps struct {
 	Provider   string
 	SchemaType string
 	JsonSchema map[string]interface{}
+	// Transformation reshapes the data to the JSON schema, typically for the "output" schema type.
+	Transformation []FieldMapping
 }
 
 // getIDData constructs a map with the service, source, and provider information.
@@ -122,14 +137,15 @@ func NewSchema(schemaProps SchemaProps) (*Schema, error) {
 	jsonSchema := transformJsonSchema(schemaProps.JsonSchema)
 
 	schema := &Schema{
-		ID:         md5id.NewID(idData),
-		Service:    schemaProps.Service,
-		Source:     schemaProps.Source,
-		Provider:   schemaProps.Provider,
-		SchemaType: schemaProps.SchemaType,
-		JsonSchema: jsonSchema,
-		UpdatedAt:  time.Now().Format(dateLayout),
-		CreatedAt:  time.Now().Format(dateLayout),
+		ID:             md5id.NewID(idData),
+		Service:        schemaProps.Service,
+		Source:         schemaProps.Source,
+		Provider:       schemaProps.Provider,
+		SchemaType:     schemaProps.SchemaType,
+		JsonSchema:     jsonSchema,
+		Transformation: schemaProps.Transformation,
+		UpdatedAt:      time.Now().Format(dateLayout),
+		CreatedAt:      time.Now().Format(dateLayout),
 	}
 
 	versionID, err := uuid.GenerateUUIDFromMap(schema.GetVersionIDData())
@@ -145,14 +161,19 @@ func NewSchema(schemaProps SchemaProps) (*Schema, error) {
 }
 
 // GetVersionIDData constructs a map with the service, source, provider, and schema type information.
+// The transformation is only part of the version of the schemas that have one.
 func (s *Schema) GetVersionIDData() map[string]interface{} {
-	return map[string]interface{}{
+	data := map[string]interface{}{
 		"service":     s.Service,
 		"source":      s.Source,
 		"provider":    s.Provider,
 		"schema_type": s.SchemaType,
 		"json_schema": s.JsonSchema,
 	}
+	if len(s.Transformation) > 0 {
+		data["transformation"] = s.Transformation
+	}
+	return data
 }
 
 // SetSchemaVersionID sets the schema version ID.
@@ -170,6 +191,20 @@ func (s *Schema) SetJsonSchema(jsonSchema map[string]interface{}) {
 	s.JsonSchema = transformJsonSchema(jsonSchema)
 }
 
+// SetTransformation sets the transformation of the Schema entity.
+func (s *Schema) SetTransformation(transformation []FieldMapping) {
+	s.Transformation = transformation
+}
+
+// FieldMappings returns the transformation of the Schema entity as field mappings of schema-tools.
+func (s *Schema) FieldMappings() []schematools.FieldMapping {
+	mappings := make([]schematools.FieldMapping, len(s.Transformation))
+	for i, mapping := range s.Transformation {
+		mappings[i] = schematools.FieldMapping(mapping)
+	}
+	return mappings
+}
+
 // GetEntityID returns the ID of the Schema entity.
 func (s *Schema) GetEntityID() string {
 	return string(s.ID)
@@ -224,14 +259,45 @@ func (s *Schema) MapToEntity(doc map[string]interface{}) (*Schema, error) {
 		return nil, errors.New("field schema_version_id has invalid type")
 	}
 
+	// Defaults may be null, which the conversion rejects, so the transformation is converted apart.
+	transformation, err := mapToFieldMappings(doc["transformation"])
+	if err != nil {
+		return nil, err
+	}
+	doc["transformation"] = []interface{}{}
+
 	schemaEntity, err := regularTypesConversion.ConvertFromMapStringToEntity(reflect.TypeOf(Schema{}), doc)
 	if err != nil {
 		return nil, err
 	}
 	schema := schemaEntity.(*Schema)
+	schema.SetTransformation(transformation)
 	return schema, nil
 }
 
+// mapToFieldMappings converts the transformation of a schema document to field mappings.
+func mapToFieldMappings(value interface{}) ([]FieldMapping, error) {
+	if value == nil {
+		return nil, nil
+	}
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil, errors.New("field transformation has invalid type")
+	}
+	mappings := make([]FieldMapping, len(items))
+	for i, item := range items {
+		doc, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, errors.New("field transformation has invalid type")
+		}
+		from, _ := doc["from"].(string)
+		to, _ := doc["to"].(string)
+		jsonType, _ := doc["type"].(string)
+		mappings[i] = FieldMapping{From: from, To: to, Type: jsonType, Default: doc["default"]}
+	}
+	return mappings, nil
+}
+
 // isValid checks if the Schema entity is valid.
 func (s *Schema) isValid() error {
 	if s.ID == "" {
@@ -254,5 +320,8 @@ func (s *Schema) isValid() error {
 	if err := schematools.ValidateJSONSchema(jsonSchema); err != nil {
 		return ErrJsonSchemaInvalid
 	}
+	if err := schematools.ValidateFieldMappings(s.FieldMappings()); err != nil {
+		return fmt.Errorf("%w: %w", ErrTransformationInvalid, err)
+	}
 	return nil
 }
@@ -546,3 +546,62 @@ func (suite *SchemaVaultConfigSuite) TestJsonSchemaToMap() {
 
 	assert.Equal(suite.T(), expectedJsonSchemaMap, jsonSchemaMap)
 }
+
+func (suite *SchemaVaultConfigSuite) outputSchemaProps(transformation []FieldMapping) SchemaProps {
+	return SchemaProps{
+		Service:    "test-service",
+		Source:     "test-source",
+		Provider:   "test-provider",
+		SchemaType: "output",
+		JsonSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"customer_id": map[string]interface{}{
+					"type": "integer",
+				},
+			},
+			"required": []interface{}{
+				"customer_id",
+			},
+		},
+		Transformation: transformation,
+	}
+}
+
+func (suite *SchemaVaultConfigSuite) TestNewSchemaWithTransformation() {
+	transformation := []FieldMapping{{From: "customer.id", To: "customer_id", Type: "integer"}}
+
+	schema, err := NewSchema(suite.outputSchemaProps(transformation))
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), transformation, schema.Transformation)
+
+	plain, err := NewSchema(suite.outputSchemaProps(nil))
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), plain.ID, schema.ID)
+	assert.NotEqual(suite.T(), plain.SchemaVersionID, schema.SchemaVersionID)
+}
+
+func (suite *SchemaVaultConfigSuite) TestIsSchemaValidWhenInvalidTransformation() {
+	schema, err := NewSchema(suite.outputSchemaProps([]FieldMapping{{From: "customer.id", To: "customer_id", Type: "date"}}))
+
+	assert.ErrorIs(suite.T(), err, ErrTransformationInvalid)
+	assert.Nil(suite.T(), schema)
+}
+
+func (suite *SchemaVaultConfigSuite) TestMapToEntityWithTransformation() {
+	transformation := []FieldMapping{
+		{From: "customer.id", To: "customer_id", Type: "integer"},
+		{To: "country", Default: "BR"},
+		{To: "note"},
+	}
+	schema, err := NewSchema(suite.outputSchemaProps(transformation))
+	assert.NoError(suite.T(), err)
+
+	schemaMap, err := schema.ToMap()
+	assert.NoError(suite.T(), err)
+
+	restored, err := schema.MapToEntity(schemaMap)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), transformation, restored.Transformation)
+	assert.Equal(suite.T(), schema.SchemaVersionID, restored.SchemaVersionID)
+}
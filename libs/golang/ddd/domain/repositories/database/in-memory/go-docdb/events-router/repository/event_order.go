@@ -1,9 +1,12 @@
 package repository
 
 import (
+	"errors"
 	"fmt"
 	"libs/golang/clients/resources/go-docdb/client"
+	"libs/golang/database/go-docdb/database"
 	"libs/golang/ddd/domain/entities/events-router/entity"
+	"libs/golang/shared/go-criteria/criteria"
 	"log"
 )
 
@@ -55,7 +58,7 @@ func (r *EventOrderRepository) getOneByID(id string) (*entity.EventOrder, error)
 		return nil, err
 	}
 	result := &entity.EventOrder{}
-	result, err = result.MapToEntity(document)
+	result, err = result.MapToEntity(copyDocument(document))
 	if err != nil {
 		return nil, err
 	}
@@ -104,6 +107,149 @@ func (r *EventOrderRepository) FindByID(id string) (*entity.EventOrder, error) {
 	return r.getOneByID(id)
 }
 
+// FindByProcessingID retrieves the EventOrder of a processing.
+//
+// Parameters:
+//   - processingID: The processing ID of the EventOrder to retrieve.
+//
+// Returns:
+//   - A pointer to the EventOrder if found, otherwise nil.
+//   - An error if no EventOrder has the processing ID or it cannot be mapped to an EventOrder entity.
+func (r *EventOrderRepository) FindByProcessingID(processingID string) (*entity.EventOrder, error) {
+	documents, err := r.client.Find(r.collectionName, map[string]interface{}{"processing_id": processingID})
+	if err != nil {
+		return nil, err
+	}
+	if len(documents) == 0 {
+		return nil, fmt.Errorf("event order with processing ID %s not found", processingID)
+	}
+	return (&entity.EventOrder{}).MapToEntity(copyDocument(documents[0]))
+}
+
+// UpdateStage moves an EventOrder to a new stage and records the transition in its history.
+// The order is read and written in one transaction, so concurrent consumers cannot lose a transition.
+//
+// Parameters:
+//   - id: The ID of the EventOrder.
+//   - stage: The requested stage.
+//   - detail: An optional reason, recorded in the history.
+//
+// Returns:
+//   - A pointer to the EventOrder after the transition.
+//   - An error wrapping entity.ErrIllegalStageTransition if the transition is not allowed,
+//     or an error if the EventOrder cannot be found or saved.
+func (r *EventOrderRepository) UpdateStage(id, stage, detail string) (*entity.EventOrder, error) {
+	return r.updateStage(id, stage, func(eventOrder *entity.EventOrder) error {
+		return eventOrder.Transition(stage, detail)
+	})
+}
+
+// Dispatch moves an EventOrder to the dispatched stage and stores the data of its process order, in one transaction.
+//
+// Parameters:
+//   - id: The ID of the EventOrder.
+//   - data: The data of the dispatched process order.
+//
+// Returns:
+//   - A pointer to the EventOrder after the transition.
+//   - An error wrapping entity.ErrIllegalStageTransition if the order cannot be dispatched,
+//     or an error if the EventOrder cannot be found or saved.
+func (r *EventOrderRepository) Dispatch(id string, data map[string]interface{}) (*entity.EventOrder, error) {
+	return r.updateStage(id, entity.StageDispatched, func(eventOrder *entity.EventOrder) error {
+		return eventOrder.Dispatch(data)
+	})
+}
+
+// Redispatch records that the process order of an EventOrder was dispatched again, in one transaction.
+//
+// Parameters:
+//   - id: The ID of the EventOrder.
+//   - detail: An optional reason, recorded in the history.
+//
+// Returns:
+//   - A pointer to the EventOrder after the transition.
+//   - An error wrapping entity.ErrIllegalStageTransition if the order is not waiting for its job,
+//     or an error if the EventOrder cannot be found or saved.
+func (r *EventOrderRepository) Redispatch(id, detail string) (*entity.EventOrder, error) {
+	return r.updateStage(id, entity.StageDispatched, func(eventOrder *entity.EventOrder) error {
+		return eventOrder.Redispatch(detail)
+	})
+}
+
+// updateStage applies a stage change to an EventOrder and saves its stage, history and dispatched data in one
+// transaction.
+//
+// Parameters:
+//   - id: The ID of the EventOrder.
+//   - stage: The requested stage, for logging.
+//   - change: The change to apply to the stored EventOrder.
+//
+// Returns:
+//   - A pointer to the EventOrder after the change.
+//   - The error of the change, or an error if the EventOrder cannot be found or saved.
+func (r *EventOrderRepository) updateStage(id, stage string, change func(eventOrder *entity.EventOrder) error) (*entity.EventOrder, error) {
+	var eventOrder *entity.EventOrder
+	err := r.client.WithTransaction(func(tx *database.Transaction) error {
+		document, err := tx.FindOne(r.collectionName, id)
+		if err != nil {
+			return err
+		}
+		eventOrder, err = (&entity.EventOrder{}).MapToEntity(copyDocument(document))
+		if err != nil {
+			return err
+		}
+		if err := change(eventOrder); err != nil {
+			return err
+		}
+		eventOrderMap, err := eventOrder.ToMap()
+		if err != nil {
+			return err
+		}
+		return tx.UpdateOne(r.collectionName, id, map[string]interface{}{
+			"$set": map[string]interface{}{
+				"stage":           eventOrderMap["stage"],
+				"history":         eventOrderMap["history"],
+				"dispatched_data": eventOrderMap["dispatched_data"],
+			},
+		})
+	})
+	if err != nil {
+		if !errors.Is(err, entity.ErrIllegalStageTransition) {
+			r.log.Printf("Failed to move event order %s to stage %s: %v\n", id, stage, err)
+		}
+		return nil, err
+	}
+
+	r.log.Printf("Event order %s moved to stage %s\n", id, eventOrder.Stage)
+	return eventOrder, nil
+}
+
+// FindByStages retrieves the EventOrders in any of the given stages.
+//
+// Parameters:
+//   - stages: The stages to match.
+//
+// Returns:
+//   - A slice of pointers to EventOrder entities.
+//   - An error if the documents cannot be retrieved or mapped to EventOrder entities.
+func (r *EventOrderRepository) FindByStages(stages ...string) ([]*entity.EventOrder, error) {
+	query := criteria.Criteria{}.Where("stage", criteria.In, stages).Query()
+	documents, err := r.client.Find(r.collectionName, query)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*entity.EventOrder, 0, len(documents))
+	for _, document := range documents {
+		eventOrder, err := (&entity.EventOrder{}).MapToEntity(copyDocument(document))
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, eventOrder)
+	}
+	return result, nil
+}
+
 // FindAll retrieves all EventOrders from the repository.
 //
 // Returns:
@@ -118,7 +264,7 @@ func (r *EventOrderRepository) FindAll() ([]*entity.EventOrder, error) {
 	var result []*entity.EventOrder
 	for _, document := range documents {
 		eventOrder := &entity.EventOrder{}
-		eventOrder, err = eventOrder.MapToEntity(document)
+		eventOrder, err = eventOrder.MapToEntity(copyDocument(document))
 		if err != nil {
 			return nil, err
 		}
@@ -142,3 +288,19 @@ func (r *EventOrderRepository) Delete(id string) error {
 	}
 	return nil
 }
+
+// copyDocument returns a shallow copy of a document. The client returns the documents stored in the
+// collection, which MapToEntity would otherwise modify.
+//
+// Parameters:
+//   - document: The document to copy.
+//
+// Returns:
+//   - The copy of the document.
+func copyDocument(document map[string]interface{}) map[string]interface{} {
+	doc := make(map[string]interface{}, len(document))
+	for key, value := range document {
+		doc[key] = value
+	}
+	return doc
+}
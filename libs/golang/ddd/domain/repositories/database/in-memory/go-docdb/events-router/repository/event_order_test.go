@@ -110,7 +110,7 @@ func (suite *EventOrderRepositorySuite) TestCreateSuccess() {
 		Service:      "test_service",
 		Source:       "test_source",
 		Provider:     "test_provider",
-		Stage:        "test_stage",
+		Stage:        entity.StageReceived,
 		ProcessingID: "xyz789",
 		InputID:      "input-id",
 		Data: map[string]interface{}{
@@ -142,7 +142,7 @@ func (suite *EventOrderRepositorySuite) TestCreateDuplicate() {
 		Service:      "test_service",
 		Source:       "test_source",
 		Provider:     "test_provider",
-		Stage:        "test_stage",
+		Stage:        entity.StageReceived,
 		ProcessingID: "xyz789",
 		InputID:      "input-id",
 		Data: map[string]interface{}{
@@ -168,7 +168,7 @@ func (suite *EventOrderRepositorySuite) TestCreateInvalid() {
 	props := entity.EventOrderProps{
 		Source:       "test_source",
 		Provider:     "test_provider",
-		Stage:        "test_stage",
+		Stage:        entity.StageReceived,
 		ProcessingID: "xyz789",
 		InputID:      "input-id",
 		Data: map[string]interface{}{
@@ -190,7 +190,7 @@ func (suite *EventOrderRepositorySuite) TestCreateWithoutData() {
 		Service:      "test_service",
 		Source:       "test_source",
 		Provider:     "test_provider",
-		Stage:        "test_stage",
+		Stage:        entity.StageReceived,
 		ProcessingID: "xyz789",
 		InputID:      "input-id",
 		Data:         nil,
@@ -305,3 +305,116 @@ func (suite *EventOrderRepositorySuite) TestDeleteNonExisting() {
 	assert.NotNil(suite.T(), err)
 	assert.Equal(suite.T(), "document not found", err.Error())
 }
+
+// createEventOrder creates and saves an event order in the received stage.
+func (suite *EventOrderRepositorySuite) createEventOrder() *entity.EventOrder {
+	eventOrder, err := entity.NewEventOrder(entity.EventOrderProps{
+		Service:      "test_service",
+		Source:       "test_source",
+		Provider:     "test_provider",
+		ProcessingID: "xyz789",
+		InputID:      "input-id",
+		Data:         map[string]interface{}{"key": "value"},
+	})
+	assert.Nil(suite.T(), err)
+	assert.Nil(suite.T(), suite.repo.Create(eventOrder))
+	return eventOrder
+}
+
+func (suite *EventOrderRepositorySuite) TestFindByProcessingID() {
+	eventOrder := suite.createEventOrder()
+
+	result, err := suite.repo.FindByProcessingID("xyz789")
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), eventOrder.ID, result.ID)
+
+	_, err = suite.repo.FindByProcessingID("unknown")
+	assert.NotNil(suite.T(), err)
+}
+
+func (suite *EventOrderRepositorySuite) TestUpdateStage() {
+	eventOrder := suite.createEventOrder()
+
+	result, err := suite.repo.UpdateStage(eventOrder.GetEntityID(), entity.StagePreProcessed, "")
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), entity.StagePreProcessed, result.Stage)
+
+	result, err = suite.repo.UpdateStage(eventOrder.GetEntityID(), entity.StageDispatched, "sent")
+	assert.Nil(suite.T(), err)
+
+	stored, err := suite.repo.FindByID(eventOrder.GetEntityID())
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), entity.StageDispatched, stored.Stage)
+	assert.Equal(suite.T(), result.History, stored.History)
+	assert.Equal(suite.T(), 3, len(stored.History))
+	assert.Equal(suite.T(), "sent", stored.History[2].Detail)
+}
+
+func (suite *EventOrderRepositorySuite) TestUpdateStageWhenIllegal() {
+	eventOrder := suite.createEventOrder()
+
+	_, err := suite.repo.UpdateStage(eventOrder.GetEntityID(), entity.StageCompleted, "")
+	assert.ErrorIs(suite.T(), err, entity.ErrIllegalStageTransition)
+
+	stored, err := suite.repo.FindByID(eventOrder.GetEntityID())
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), entity.StageReceived, stored.Stage)
+	assert.Equal(suite.T(), 1, len(stored.History))
+}
+
+func (suite *EventOrderRepositorySuite) TestUpdateStageWhenNotFound() {
+	_, err := suite.repo.UpdateStage("unknown", entity.StagePreProcessed, "")
+	assert.NotNil(suite.T(), err)
+}
+
+func (suite *EventOrderRepositorySuite) TestDispatch() {
+	eventOrder := suite.createEventOrder()
+	data := map[string]interface{}{"label": "value"}
+
+	_, err := suite.repo.Dispatch(eventOrder.GetEntityID(), data)
+	assert.ErrorIs(suite.T(), err, entity.ErrIllegalStageTransition)
+
+	suite.repo.UpdateStage(eventOrder.GetEntityID(), entity.StagePreProcessed, "")
+	result, err := suite.repo.Dispatch(eventOrder.GetEntityID(), data)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), entity.StageDispatched, result.Stage)
+
+	_, err = suite.repo.Redispatch(eventOrder.GetEntityID(), "timed out")
+	assert.Nil(suite.T(), err)
+	stored, err := suite.repo.FindByID(eventOrder.GetEntityID())
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), data, stored.DispatchedData)
+	assert.Equal(suite.T(), 2, stored.DispatchCount())
+}
+
+func (suite *EventOrderRepositorySuite) TestRedispatch() {
+	eventOrder := suite.createEventOrder()
+
+	_, err := suite.repo.Redispatch(eventOrder.GetEntityID(), "")
+	assert.ErrorIs(suite.T(), err, entity.ErrIllegalStageTransition)
+
+	suite.repo.UpdateStage(eventOrder.GetEntityID(), entity.StagePreProcessed, "")
+	suite.repo.UpdateStage(eventOrder.GetEntityID(), entity.StageDispatched, "")
+	result, err := suite.repo.Redispatch(eventOrder.GetEntityID(), "timed out")
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 2, result.DispatchCount())
+
+	stored, err := suite.repo.FindByID(eventOrder.GetEntityID())
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 2, stored.DispatchCount())
+}
+
+func (suite *EventOrderRepositorySuite) TestFindByStages() {
+	eventOrder := suite.createEventOrder()
+
+	result, err := suite.repo.FindByStages(entity.StageDispatched, entity.StageProcessing)
+	assert.Nil(suite.T(), err)
+	assert.Empty(suite.T(), result)
+
+	suite.repo.UpdateStage(eventOrder.GetEntityID(), entity.StagePreProcessed, "")
+	suite.repo.UpdateStage(eventOrder.GetEntityID(), entity.StageDispatched, "")
+	result, err = suite.repo.FindByStages(entity.StageDispatched, entity.StageProcessing)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 1, len(result))
+	assert.Equal(suite.T(), eventOrder.ID, result[0].ID)
+}
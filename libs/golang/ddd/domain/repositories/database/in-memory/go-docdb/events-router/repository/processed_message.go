@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"fmt"
+	"libs/golang/clients/resources/go-docdb/client"
+	"libs/golang/database/go-docdb/database"
+	"libs/golang/ddd/domain/entities/events-router/entity"
+	"log"
+	"time"
+)
+
+var (
+	processedMessageCollection = "processed-messages"
+)
+
+// ProcessedMessageRepository is a repository for ProcessedMessage entities, the dedup store of the
+// pre-processing.
+type ProcessedMessageRepository struct {
+	log            *log.Logger
+	client         *client.Client
+	database       string
+	collectionName string
+}
+
+// NewProcessedMessageRepository creates a new instance of ProcessedMessageRepository.
+//
+// Parameters:
+//   - client: The client instance to interact with the document-based database.
+//   - database: The name of the database.
+//
+// Returns:
+//   - A pointer to the newly created ProcessedMessageRepository instance.
+func NewProcessedMessageRepository(
+	client *client.Client,
+	database string,
+) *ProcessedMessageRepository {
+	inMemoryRepository := &ProcessedMessageRepository{
+		log:            log.New(log.Writer(), "[PROCESSED-MESSAGE-REPOSITORY] ", log.LstdFlags),
+		client:         client,
+		database:       database,
+		collectionName: processedMessageCollection,
+	}
+	inMemoryRepository.client.CreateCollection(inMemoryRepository.collectionName)
+	inMemoryRepository.client.CreateIndex(inMemoryRepository.collectionName, "expires_at")
+	return inMemoryRepository
+}
+
+// Save records a processed message, replacing the record of an earlier processing with the same ID.
+//
+// Parameters:
+//   - message: The processed message.
+//
+// Returns:
+//   - An error if the message cannot be saved.
+func (r *ProcessedMessageRepository) Save(message *entity.ProcessedMessage) error {
+	document := message.ToMap()
+	delete(document, "_id")
+	_, err := r.client.UpdateByID(r.collectionName, message.GetEntityID(), map[string]interface{}{"$set": document}, database.WithUpsert())
+	if err != nil {
+		return fmt.Errorf("failed to save processed message %s: %w", message.GetEntityID(), err)
+	}
+	return nil
+}
+
+// IsProcessed reports whether a processing has a record that has not expired.
+//
+// Parameters:
+//   - processingID: The processing ID of the message.
+//   - at: The time the expiration is checked against.
+//
+// Returns:
+//   - True if the message was already processed within its retention.
+//   - An error if the records cannot be read.
+func (r *ProcessedMessageRepository) IsProcessed(processingID string, at time.Time) (bool, error) {
+	documents, err := r.client.Find(r.collectionName, map[string]interface{}{
+		"_id":        processingID,
+		"expires_at": map[string]interface{}{"$gt": entity.FormatUTC(at)},
+	})
+	if err != nil {
+		return false, err
+	}
+	return len(documents) > 0, nil
+}
+
+// DeleteExpired removes the records that expired.
+//
+// Parameters:
+//   - at: The time the expiration is checked against.
+//
+// Returns:
+//   - The number of removed records.
+//   - An error if a record cannot be removed.
+func (r *ProcessedMessageRepository) DeleteExpired(at time.Time) (int, error) {
+	documents, err := r.client.Find(r.collectionName, map[string]interface{}{
+		"expires_at": map[string]interface{}{"$lte": entity.FormatUTC(at)},
+	})
+	if err != nil {
+		return 0, err
+	}
+	for i, document := range documents {
+		id, _ := document["_id"].(string)
+		if err := r.client.DeleteOne(r.collectionName, id); err != nil {
+			return i, fmt.Errorf("failed to delete processed message %s: %w", id, err)
+		}
+	}
+	if len(documents) > 0 {
+		r.log.Printf("Deleted %d expired processed messages\n", len(documents))
+	}
+	return len(documents), nil
+}
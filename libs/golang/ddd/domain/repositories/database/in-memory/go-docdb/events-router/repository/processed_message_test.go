@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"libs/golang/clients/resources/go-docdb/client"
+	"libs/golang/database/go-docdb/database"
+	"libs/golang/ddd/domain/entities/events-router/entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+// ProcessedMessageRepositorySuite is a test suite for the ProcessedMessageRepository.
+type ProcessedMessageRepositorySuite struct {
+	suite.Suite
+	repo *ProcessedMessageRepository
+	now  time.Time
+}
+
+func TestProcessedMessageRepositorySuite(t *testing.T) {
+	suite.Run(t, new(ProcessedMessageRepositorySuite))
+}
+
+func (suite *ProcessedMessageRepositorySuite) SetupTest() {
+	db := database.NewInMemoryDocBD("test_database")
+	suite.repo = NewProcessedMessageRepository(client.NewClient(db), "test_database")
+	suite.now = time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+}
+
+func (suite *ProcessedMessageRepositorySuite) save(processingID string, retention time.Duration) {
+	message, err := entity.NewProcessedMessage(processingID, "order-"+processingID, suite.now, retention)
+	assert.Nil(suite.T(), err)
+	assert.Nil(suite.T(), suite.repo.Save(message))
+}
+
+func (suite *ProcessedMessageRepositorySuite) TestIsProcessedWithinRetention() {
+	suite.save("proc-1", time.Hour)
+
+	processed, err := suite.repo.IsProcessed("proc-1", suite.now.Add(59*time.Minute))
+	assert.Nil(suite.T(), err)
+	assert.True(suite.T(), processed)
+
+	processed, err = suite.repo.IsProcessed("proc-1", suite.now.Add(time.Hour))
+	assert.Nil(suite.T(), err)
+	assert.False(suite.T(), processed)
+
+	processed, err = suite.repo.IsProcessed("proc-2", suite.now)
+	assert.Nil(suite.T(), err)
+	assert.False(suite.T(), processed)
+}
+
+func (suite *ProcessedMessageRepositorySuite) TestSaveRenewsRecord() {
+	suite.save("proc-1", time.Minute)
+	suite.save("proc-1", time.Hour)
+
+	processed, err := suite.repo.IsProcessed("proc-1", suite.now.Add(30*time.Minute))
+	assert.Nil(suite.T(), err)
+	assert.True(suite.T(), processed)
+}
+
+func (suite *ProcessedMessageRepositorySuite) TestDeleteExpired() {
+	suite.save("proc-1", time.Minute)
+	suite.save("proc-2", time.Hour)
+
+	deleted, err := suite.repo.DeleteExpired(suite.now.Add(30 * time.Minute))
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 1, deleted)
+
+	processed, _ := suite.repo.IsProcessed("proc-2", suite.now)
+	assert.True(suite.T(), processed)
+	processed, _ = suite.repo.IsProcessed("proc-1", suite.now)
+	assert.False(suite.T(), processed)
+}
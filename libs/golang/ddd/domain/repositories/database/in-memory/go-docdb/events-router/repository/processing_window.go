@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"errors"
+	"libs/golang/clients/resources/go-docdb/client"
+	"libs/golang/database/go-docdb/database"
+	"libs/golang/ddd/domain/entities/events-router/entity"
+	"log"
+)
+
+var (
+	processingWindowCollection = "processing-windows"
+)
+
+// ProcessingWindowRepository is a repository for ProcessingWindow entities.
+type ProcessingWindowRepository struct {
+	log            *log.Logger
+	client         *client.Client
+	database       string
+	collectionName string
+}
+
+// NewProcessingWindowRepository creates a new instance of ProcessingWindowRepository.
+//
+// Parameters:
+//   - client: The client instance to interact with the document-based database.
+//   - database: The name of the database.
+//
+// Returns:
+//   - A pointer to the newly created ProcessingWindowRepository instance.
+func NewProcessingWindowRepository(
+	client *client.Client,
+	database string,
+) *ProcessingWindowRepository {
+	inMemoryRepository := &ProcessingWindowRepository{
+		log:            log.New(log.Writer(), "[PROCESSING-WINDOW-REPOSITORY] ", log.LstdFlags),
+		client:         client,
+		database:       database,
+		collectionName: processingWindowCollection,
+	}
+	inMemoryRepository.client.CreateCollection(inMemoryRepository.collectionName)
+	return inMemoryRepository
+}
+
+// AddCompletion records that an upstream job completed within a window. The window is created on the
+// first completion; later calls refresh its dependencies from the given window and keep its completions.
+// The read and the write run in one transaction, so concurrent completions of the same window are not lost.
+//
+// Parameters:
+//   - window: The window of the dependent job.
+//   - completed: The upstream job that completed.
+//
+// Returns:
+//   - A pointer to the window as stored after the update.
+//   - An error if the window cannot be read or saved.
+func (r *ProcessingWindowRepository) AddCompletion(window *entity.ProcessingWindow, completed entity.JobDependency) (*entity.ProcessingWindow, error) {
+	windowMap, err := window.ToMap()
+	if err != nil {
+		return nil, err
+	}
+	completedMap := map[string]interface{}{"service": completed.Service, "source": completed.Source}
+	entityID := window.GetEntityID()
+
+	err = r.client.WithTransaction(func(tx *database.Transaction) error {
+		_, err := tx.FindOne(r.collectionName, entityID)
+		if errors.Is(err, database.ErrWriteConflict) {
+			return err
+		}
+		if err != nil {
+			windowMap["completed"] = []interface{}{completedMap}
+			return tx.InsertOne(r.collectionName, windowMap)
+		}
+		return tx.UpdateOne(r.collectionName, entityID, map[string]interface{}{
+			"$set":      map[string]interface{}{"depends_on": windowMap["depends_on"]},
+			"$addToSet": map[string]interface{}{"completed": completedMap},
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r.log.Printf("Completion of %s/%s recorded in window %s\n", completed.Service, completed.Source, entityID)
+	return r.FindByID(entityID)
+}
+
+// MarkDispatched flags a window as dispatched, unless it already is.
+//
+// Parameters:
+//   - id: The ID of the window.
+//
+// Returns:
+//   - True if this call flagged the window, false if it was already dispatched or does not exist.
+//   - An error if the window cannot be updated.
+func (r *ProcessingWindowRepository) MarkDispatched(id string) (bool, error) {
+	result, err := r.client.UpdateMany(
+		r.collectionName,
+		map[string]interface{}{"_id": id, "dispatched": false},
+		map[string]interface{}{"$set": map[string]interface{}{"dispatched": true}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount == 1, nil
+}
+
+// UnmarkDispatched clears the dispatched flag of a window whose process order could not be dispatched, so
+// the next MarkDispatched call on the window flags it again.
+//
+// Parameters:
+//   - id: The ID of the window.
+//
+// Returns:
+//   - An error if the window cannot be updated.
+func (r *ProcessingWindowRepository) UnmarkDispatched(id string) error {
+	_, err := r.client.UpdateMany(
+		r.collectionName,
+		map[string]interface{}{"_id": id},
+		map[string]interface{}{"$set": map[string]interface{}{"dispatched": false}},
+	)
+	return err
+}
+
+// FindByID retrieves a ProcessingWindow by its ID.
+//
+// Parameters:
+//   - id: The ID of the ProcessingWindow to retrieve.
+//
+// Returns:
+//   - A pointer to the ProcessingWindow if found, otherwise nil.
+//   - An error if the document is not found or cannot be mapped to a ProcessingWindow entity.
+func (r *ProcessingWindowRepository) FindByID(id string) (*entity.ProcessingWindow, error) {
+	document, err := r.client.FindOne(r.collectionName, id)
+	if err != nil {
+		return nil, err
+	}
+	return (&entity.ProcessingWindow{}).MapToEntity(copyDocument(document))
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"libs/golang/clients/resources/go-docdb/client"
+	"libs/golang/database/go-docdb/database"
+	"libs/golang/ddd/domain/entities/events-router/entity"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+// ProcessingWindowRepositorySuite is a test suite for the ProcessingWindowRepository.
+type ProcessingWindowRepositorySuite struct {
+	suite.Suite
+	repo   *ProcessingWindowRepository
+	window *entity.ProcessingWindow
+	deps   []entity.JobDependency
+}
+
+func TestProcessingWindowRepositorySuite(t *testing.T) {
+	suite.Run(t, new(ProcessingWindowRepositorySuite))
+}
+
+func (suite *ProcessingWindowRepositorySuite) SetupTest() {
+	db := database.NewInMemoryDocBD("test_database")
+	suite.repo = NewProcessingWindowRepository(client.NewClient(db), "test_database")
+	suite.deps = []entity.JobDependency{
+		{Service: "dep_service1", Source: "dep_source1"},
+		{Service: "dep_service2", Source: "dep_source2"},
+	}
+	suite.window, _ = entity.NewProcessingWindow(entity.ProcessingWindowProps{
+		Service:   "test_service",
+		Source:    "test_source",
+		Provider:  "test_provider",
+		Window:    "2024-06-01 00:00:00",
+		DependsOn: suite.deps,
+	})
+}
+
+func (suite *ProcessingWindowRepositorySuite) TestAddCompletion() {
+	window, err := suite.repo.AddCompletion(suite.window, suite.deps[0])
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), []entity.JobDependency{suite.deps[0]}, window.Completed)
+	assert.False(suite.T(), window.IsSatisfied())
+
+	// Repeated completions are recorded once.
+	window, err = suite.repo.AddCompletion(suite.window, suite.deps[0])
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 1, len(window.Completed))
+
+	window, err = suite.repo.AddCompletion(suite.window, suite.deps[1])
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), suite.deps, window.Completed)
+	assert.True(suite.T(), window.IsSatisfied())
+}
+
+func (suite *ProcessingWindowRepositorySuite) TestConcurrentCompletionsAreKept() {
+	var wg sync.WaitGroup
+	for _, dep := range suite.deps {
+		wg.Add(1)
+		go func(dep entity.JobDependency) {
+			defer wg.Done()
+			_, err := suite.repo.AddCompletion(suite.window, dep)
+			assert.Nil(suite.T(), err)
+		}(dep)
+	}
+	wg.Wait()
+
+	window, err := suite.repo.FindByID(suite.window.GetEntityID())
+	assert.Nil(suite.T(), err)
+	assert.True(suite.T(), window.IsSatisfied())
+}
+
+func (suite *ProcessingWindowRepositorySuite) TestMarkDispatched() {
+	marked, err := suite.repo.MarkDispatched(suite.window.GetEntityID())
+	assert.Nil(suite.T(), err)
+	assert.False(suite.T(), marked)
+
+	_, err = suite.repo.AddCompletion(suite.window, suite.deps[0])
+	assert.Nil(suite.T(), err)
+
+	marked, err = suite.repo.MarkDispatched(suite.window.GetEntityID())
+	assert.Nil(suite.T(), err)
+	assert.True(suite.T(), marked)
+
+	marked, err = suite.repo.MarkDispatched(suite.window.GetEntityID())
+	assert.Nil(suite.T(), err)
+	assert.False(suite.T(), marked)
+
+	window, err := suite.repo.FindByID(suite.window.GetEntityID())
+	assert.Nil(suite.T(), err)
+	assert.True(suite.T(), window.Dispatched)
+}
+
+func (suite *ProcessingWindowRepositorySuite) TestUnmarkDispatched() {
+	_, err := suite.repo.AddCompletion(suite.window, suite.deps[0])
+	assert.Nil(suite.T(), err)
+	marked, err := suite.repo.MarkDispatched(suite.window.GetEntityID())
+	assert.Nil(suite.T(), err)
+	assert.True(suite.T(), marked)
+
+	err = suite.repo.UnmarkDispatched(suite.window.GetEntityID())
+	assert.Nil(suite.T(), err)
+
+	window, err := suite.repo.FindByID(suite.window.GetEntityID())
+	assert.Nil(suite.T(), err)
+	assert.False(suite.T(), window.Dispatched)
+
+	marked, err = suite.repo.MarkDispatched(suite.window.GetEntityID())
+	assert.Nil(suite.T(), err)
+	assert.True(suite.T(), marked)
+}
+
+func (suite *ProcessingWindowRepositorySuite) TestFindByIDNotFound() {
+	_, err := suite.repo.FindByID("missing")
+	assert.NotNil(suite.T(), err)
+}
@@ -2,6 +2,7 @@ package mockrepository
 
 import (
 	"libs/golang/ddd/domain/entities/config-vault/entity"
+	"libs/golang/shared/go-criteria/criteria"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -49,9 +50,9 @@ func (m *ConfigRepositoryMock) Delete(id string) error {
 	return args.Error(0)
 }
 
-// FindAllByServiceAndProvider is a mock implementation of ConfigRepositoryInterface's FindAllByServiceAndProvider method
-func (m *ConfigRepositoryMock) FindAllByServiceAndProvider(provider, service string) ([]*entity.Config, error) {
-	args := m.Called(provider, service)
+// FindAllByFilter is a mock implementation of ConfigRepositoryInterface's FindAllByFilter method
+func (m *ConfigRepositoryMock) FindAllByFilter(filter entity.ConfigFilter) ([]*entity.Config, error) {
+	args := m.Called(filter)
 	result := args.Get(0)
 	if result == nil {
 		return nil, args.Error(1)
@@ -59,34 +60,14 @@ func (m *ConfigRepositoryMock) FindAllByServiceAndProvider(provider, service str
 	return result.([]*entity.Config), args.Error(1)
 }
 
-// FindAllBySourceAndProvider is a mock implementation of ConfigRepositoryInterface's FindAllBySourceAndProvider method
-func (m *ConfigRepositoryMock) FindAllBySourceAndProvider(provider, source string) ([]*entity.Config, error) {
-	args := m.Called(provider, source)
+// FindPageByFilter is a mock implementation of ConfigRepositoryInterface's FindPageByFilter method
+func (m *ConfigRepositoryMock) FindPageByFilter(filter entity.ConfigFilter, page criteria.Page) ([]*entity.Config, string, error) {
+	args := m.Called(filter, page)
 	result := args.Get(0)
 	if result == nil {
-		return nil, args.Error(1)
-	}
-	return result.([]*entity.Config), args.Error(1)
-}
-
-// FindAllByServiceAndSourceAndProvider is a mock implementation of ConfigRepositoryInterface's FindAllByServiceAndSourceAndProvider method
-func (m *ConfigRepositoryMock) FindAllByServiceAndSourceAndProvider(service, source, provider string) ([]*entity.Config, error) {
-	args := m.Called(service, source, provider)
-	result := args.Get(0)
-	if result == nil {
-		return nil, args.Error(1)
+		return nil, args.String(1), args.Error(2)
 	}
-	return result.([]*entity.Config), args.Error(1)
-}
-
-// FindAllByServiceAndProviderAndActive is a mock implementation of ConfigRepositoryInterface's FindAllByServiceAndProviderAndActive method
-func (m *ConfigRepositoryMock) FindAllByServiceAndProviderAndActive(service, provider string, active bool) ([]*entity.Config, error) {
-	args := m.Called(service, provider, active)
-	result := args.Get(0)
-	if result == nil {
-		return nil, args.Error(1)
-	}
-	return result.([]*entity.Config), args.Error(1)
+	return result.([]*entity.Config), args.String(1), args.Error(2)
 }
 
 // FindAllByProviderAndDependsOn is a mock implementation of ConfigRepositoryInterface's FindAllByProviderAndDependsOn method
@@ -2,6 +2,7 @@ package mockrepository
 
 import (
 	"libs/golang/ddd/domain/entities/events-router/entity"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -26,6 +27,56 @@ func (m *EventOrderRepositoryMock) FindByID(id string) (*entity.EventOrder, erro
 	return result.(*entity.EventOrder), args.Error(1)
 }
 
+// FindByProcessingID is a mock implementation of EventOrderRepositoryInterface's FindByProcessingID method
+func (m *EventOrderRepositoryMock) FindByProcessingID(processingID string) (*entity.EventOrder, error) {
+	args := m.Called(processingID)
+	result := args.Get(0)
+	if result == nil {
+		return nil, args.Error(1)
+	}
+	return result.(*entity.EventOrder), args.Error(1)
+}
+
+// UpdateStage is a mock implementation of EventOrderRepositoryInterface's UpdateStage method
+func (m *EventOrderRepositoryMock) UpdateStage(id, stage, detail string) (*entity.EventOrder, error) {
+	args := m.Called(id, stage, detail)
+	result := args.Get(0)
+	if result == nil {
+		return nil, args.Error(1)
+	}
+	return result.(*entity.EventOrder), args.Error(1)
+}
+
+// FindByStages is a mock implementation of EventOrderRepositoryInterface's FindByStages method
+func (m *EventOrderRepositoryMock) FindByStages(stages ...string) ([]*entity.EventOrder, error) {
+	args := m.Called(stages)
+	result := args.Get(0)
+	if result == nil {
+		return nil, args.Error(1)
+	}
+	return result.([]*entity.EventOrder), args.Error(1)
+}
+
+// Dispatch is a mock implementation of EventOrderRepositoryInterface's Dispatch method
+func (m *EventOrderRepositoryMock) Dispatch(id string, data map[string]interface{}) (*entity.EventOrder, error) {
+	args := m.Called(id, data)
+	result := args.Get(0)
+	if result == nil {
+		return nil, args.Error(1)
+	}
+	return result.(*entity.EventOrder), args.Error(1)
+}
+
+// Redispatch is a mock implementation of EventOrderRepositoryInterface's Redispatch method
+func (m *EventOrderRepositoryMock) Redispatch(id, detail string) (*entity.EventOrder, error) {
+	args := m.Called(id, detail)
+	result := args.Get(0)
+	if result == nil {
+		return nil, args.Error(1)
+	}
+	return result.(*entity.EventOrder), args.Error(1)
+}
+
 // FindAll is a mock implementation of EventOrderRepositoryInterface's FindAll method
 func (m *EventOrderRepositoryMock) FindAll() ([]*entity.EventOrder, error) {
 	args := m.Called()
@@ -41,3 +92,61 @@ func (m *EventOrderRepositoryMock) Delete(id string) error {
 	args := m.Called(id)
 	return args.Error(0)
 }
+
+type ProcessingWindowRepositoryMock struct {
+	mock.Mock
+}
+
+// AddCompletion is a mock implementation of ProcessingWindowRepositoryInterface's AddCompletion method
+func (m *ProcessingWindowRepositoryMock) AddCompletion(window *entity.ProcessingWindow, completed entity.JobDependency) (*entity.ProcessingWindow, error) {
+	args := m.Called(window, completed)
+	result := args.Get(0)
+	if result == nil {
+		return nil, args.Error(1)
+	}
+	return result.(*entity.ProcessingWindow), args.Error(1)
+}
+
+// MarkDispatched is a mock implementation of ProcessingWindowRepositoryInterface's MarkDispatched method
+func (m *ProcessingWindowRepositoryMock) MarkDispatched(id string) (bool, error) {
+	args := m.Called(id)
+	return args.Bool(0), args.Error(1)
+}
+
+// UnmarkDispatched is a mock implementation of ProcessingWindowRepositoryInterface's UnmarkDispatched method
+func (m *ProcessingWindowRepositoryMock) UnmarkDispatched(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+// FindByID is a mock implementation of ProcessingWindowRepositoryInterface's FindByID method
+func (m *ProcessingWindowRepositoryMock) FindByID(id string) (*entity.ProcessingWindow, error) {
+	args := m.Called(id)
+	result := args.Get(0)
+	if result == nil {
+		return nil, args.Error(1)
+	}
+	return result.(*entity.ProcessingWindow), args.Error(1)
+}
+
+type ProcessedMessageRepositoryMock struct {
+	mock.Mock
+}
+
+// Save is a mock implementation of ProcessedMessageRepositoryInterface's Save method
+func (m *ProcessedMessageRepositoryMock) Save(message *entity.ProcessedMessage) error {
+	args := m.Called(message)
+	return args.Error(0)
+}
+
+// IsProcessed is a mock implementation of ProcessedMessageRepositoryInterface's IsProcessed method
+func (m *ProcessedMessageRepositoryMock) IsProcessed(processingID string, at time.Time) (bool, error) {
+	args := m.Called(processingID, at)
+	return args.Bool(0), args.Error(1)
+}
+
+// DeleteExpired is a mock implementation of ProcessedMessageRepositoryInterface's DeleteExpired method
+func (m *ProcessedMessageRepositoryMock) DeleteExpired(at time.Time) (int, error) {
+	args := m.Called(at)
+	return args.Int(0), args.Error(1)
+}
@@ -2,6 +2,8 @@ package mockrepository
 
 import (
 	"libs/golang/ddd/domain/entities/input-broker/entity"
+	"libs/golang/shared/go-criteria/criteria"
+	events "libs/golang/shared/go-events/amqp_events"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -17,6 +19,12 @@ func (m *InputRepositoryMock) Create(input *entity.Input) error {
 	return args.Error(0)
 }
 
+// CreateWithEvent is a mock implementation of InputRepositoryInterface's CreateWithEvent method
+func (m *InputRepositoryMock) CreateWithEvent(input *entity.Input, event events.EventInterface, routingKey string) error {
+	args := m.Called(input, event, routingKey)
+	return args.Error(0)
+}
+
 // FindByID is a mock implementation of InputRepositoryInterface's FindByID method
 func (m *InputRepositoryMock) FindByID(id string) (*entity.Input, error) {
 	args := m.Called(id)
@@ -27,6 +35,16 @@ func (m *InputRepositoryMock) FindByID(id string) (*entity.Input, error) {
 	return result.(*entity.Input), args.Error(1)
 }
 
+// FindByIdempotencyKey is a mock implementation of InputRepositoryInterface's FindByIdempotencyKey method
+func (m *InputRepositoryMock) FindByIdempotencyKey(key string) (*entity.Input, error) {
+	args := m.Called(key)
+	result := args.Get(0)
+	if result == nil {
+		return nil, args.Error(1)
+	}
+	return result.(*entity.Input), args.Error(1)
+}
+
 // FindAll is a mock implementation of InputRepositoryInterface's FindAll method
 func (m *InputRepositoryMock) FindAll() ([]*entity.Input, error) {
 	args := m.Called()
@@ -49,19 +67,9 @@ func (m *InputRepositoryMock) Delete(id string) error {
 	return args.Error(0)
 }
 
-// FindAllByServiceAndProvider is a mock implementation of InputRepositoryInterface's FindAllByServiceAndProvider method
-func (m *InputRepositoryMock) FindAllByServiceAndProvider(provider, service string) ([]*entity.Input, error) {
-	args := m.Called(provider, service)
-	result := args.Get(0)
-	if result == nil {
-		return nil, args.Error(1)
-	}
-	return result.([]*entity.Input), args.Error(1)
-}
-
-// FindAllBySourceAndProvider is a mock implementation of InputRepositoryInterface's FindAllBySourceAndProvider method
-func (m *InputRepositoryMock) FindAllBySourceAndProvider(provider, source string) ([]*entity.Input, error) {
-	args := m.Called(provider, source)
+// FindAllByFilter is a mock implementation of InputRepositoryInterface's FindAllByFilter method
+func (m *InputRepositoryMock) FindAllByFilter(filter entity.InputFilter) ([]*entity.Input, error) {
+	args := m.Called(filter)
 	result := args.Get(0)
 	if result == nil {
 		return nil, args.Error(1)
@@ -69,52 +77,12 @@ func (m *InputRepositoryMock) FindAllBySourceAndProvider(provider, source string
 	return result.([]*entity.Input), args.Error(1)
 }
 
-// FindAllByServiceAndSourceAndProvider is a mock implementation of InputRepositoryInterface's FindAllByServiceAndSourceAndProvider method
-func (m *InputRepositoryMock) FindAllByServiceAndSourceAndProvider(provider, service, source string) ([]*entity.Input, error) {
-	args := m.Called(provider, service, source)
+// FindPageByFilter is a mock implementation of InputRepositoryInterface's FindPageByFilter method
+func (m *InputRepositoryMock) FindPageByFilter(filter entity.InputFilter, page criteria.Page) ([]*entity.Input, string, error) {
+	args := m.Called(filter, page)
 	result := args.Get(0)
 	if result == nil {
-		return nil, args.Error(1)
+		return nil, args.String(1), args.Error(2)
 	}
-	return result.([]*entity.Input), args.Error(1)
-}
-
-// FindAllByStatusAndProvider is a mock implementation of InputRepositoryInterface's FindAllByStatusAndProvider method
-func (m *InputRepositoryMock) FindAllByStatusAndProvider(provider string, status int) ([]*entity.Input, error) {
-	args := m.Called(provider, status)
-	result := args.Get(0)
-	if result == nil {
-		return nil, args.Error(1)
-	}
-	return result.([]*entity.Input), args.Error(1)
-}
-
-// FindAllByStatusAndServiceAndProvider is a mock implementation of InputRepositoryInterface's FindAllByStatusAndServiceAndProvider method
-func (m *InputRepositoryMock) FindAllByStatusAndServiceAndProvider(provider, service string, status int) ([]*entity.Input, error) {
-	args := m.Called(provider, service, status)
-	result := args.Get(0)
-	if result == nil {
-		return nil, args.Error(1)
-	}
-	return result.([]*entity.Input), args.Error(1)
-}
-
-// FindAllByStatusAndSourceAndProvider is a mock implementation of InputRepositoryInterface's FindAllByStatusAndSourceAndProvider method
-func (m *InputRepositoryMock) FindAllByStatusAndSourceAndProvider(provider, source string, status int) ([]*entity.Input, error) {
-	args := m.Called(provider, source, status)
-	result := args.Get(0)
-	if result == nil {
-		return nil, args.Error(1)
-	}
-	return result.([]*entity.Input), args.Error(1)
-}
-
-// FindAllByStatusAndServiceAndSourceAndProvider is a mock implementation of InputRepositoryInterface's FindAllByStatusAndServiceAndSourceAndProvider method
-func (m *InputRepositoryMock) FindAllByStatusAndServiceAndSourceAndProvider(provider, service, source string, status int) ([]*entity.Input, error) {
-	args := m.Called(provider, service, source, status)
-	result := args.Get(0)
-	if result == nil {
-		return nil, args.Error(1)
-	}
-	return result.([]*entity.Input), args.Error(1)
+	return result.([]*entity.Input), args.String(1), args.Error(2)
 }
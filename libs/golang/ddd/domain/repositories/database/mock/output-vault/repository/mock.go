@@ -2,6 +2,8 @@ package mockrepository
 
 import (
 	"libs/golang/ddd/domain/entities/output-vault/entity"
+	"libs/golang/shared/go-criteria/criteria"
+	events "libs/golang/shared/go-events/amqp_events"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -17,6 +19,12 @@ func (m *OutputRepositoryMock) Create(output *entity.Output) error {
 	return args.Error(0)
 }
 
+// CreateWithEvent is a mock implementation of OutputRepositoryInterface's CreateWithEvent method
+func (m *OutputRepositoryMock) CreateWithEvent(output *entity.Output, event events.EventInterface, routingKey string) error {
+	args := m.Called(output, event, routingKey)
+	return args.Error(0)
+}
+
 // FindByID is a mock implementation of OutputRepositoryInterface's FindByID method
 func (m *OutputRepositoryMock) FindByID(id string) (*entity.Output, error) {
 	args := m.Called(id)
@@ -49,19 +57,9 @@ func (m *OutputRepositoryMock) Delete(id string) error {
 	return args.Error(0)
 }
 
-// FindAllByServiceAndProvider is a mock implementation of OutputRepositoryInterface's FindAllByServiceAndProvider method
-func (m *OutputRepositoryMock) FindAllByServiceAndProvider(provider, service string) ([]*entity.Output, error) {
-	args := m.Called(provider, service)
-	result := args.Get(0)
-	if result == nil {
-		return nil, args.Error(1)
-	}
-	return result.([]*entity.Output), args.Error(1)
-}
-
-// FindAllBySourceAndProvider is a mock implementation of OutputRepositoryInterface's FindAllBySourceAndProvider method
-func (m *OutputRepositoryMock) FindAllBySourceAndProvider(provider, source string) ([]*entity.Output, error) {
-	args := m.Called(provider, source)
+// FindAllByFilter is a mock implementation of OutputRepositoryInterface's FindAllByFilter method
+func (m *OutputRepositoryMock) FindAllByFilter(filter entity.OutputFilter) ([]*entity.Output, error) {
+	args := m.Called(filter)
 	result := args.Get(0)
 	if result == nil {
 		return nil, args.Error(1)
@@ -69,12 +67,12 @@ func (m *OutputRepositoryMock) FindAllBySourceAndProvider(provider, source strin
 	return result.([]*entity.Output), args.Error(1)
 }
 
-// FindAllByServiceAndSourceAndProvider is a mock implementation of OutputRepositoryInterface's FindAllByServiceAndSourceAndProvider method
-func (m *OutputRepositoryMock) FindAllByServiceAndSourceAndProvider(service, source, provider string) ([]*entity.Output, error) {
-	args := m.Called(service, source, provider)
+// FindPageByFilter is a mock implementation of OutputRepositoryInterface's FindPageByFilter method
+func (m *OutputRepositoryMock) FindPageByFilter(filter entity.OutputFilter, page criteria.Page) ([]*entity.Output, string, error) {
+	args := m.Called(filter, page)
 	result := args.Get(0)
 	if result == nil {
-		return nil, args.Error(1)
+		return nil, args.String(1), args.Error(2)
 	}
-	return result.([]*entity.Output), args.Error(1)
+	return result.([]*entity.Output), args.String(1), args.Error(2)
 }
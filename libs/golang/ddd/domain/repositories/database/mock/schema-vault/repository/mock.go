@@ -2,6 +2,7 @@ package mockrepository
 
 import (
 	"libs/golang/ddd/domain/entities/schema-vault/entity"
+	"libs/golang/shared/go-criteria/criteria"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -49,9 +50,9 @@ func (m *SchemaRepositoryMock) Delete(id string) error {
 	return args.Error(0)
 }
 
-// FindAllByServiceAndProvider is a mock implementation of SchemaRepositoryInterface's FindAllByServiceAndProvider method
-func (m *SchemaRepositoryMock) FindAllByServiceAndProvider(provider, service string) ([]*entity.Schema, error) {
-	args := m.Called(provider, service)
+// FindAllByFilter is a mock implementation of SchemaRepositoryInterface's FindAllByFilter method
+func (m *SchemaRepositoryMock) FindAllByFilter(filter entity.SchemaFilter) ([]*entity.Schema, error) {
+	args := m.Called(filter)
 	result := args.Get(0)
 	if result == nil {
 		return nil, args.Error(1)
@@ -59,24 +60,14 @@ func (m *SchemaRepositoryMock) FindAllByServiceAndProvider(provider, service str
 	return result.([]*entity.Schema), args.Error(1)
 }
 
-// FindAllBySourceAndProvider is a mock implementation of SchemaRepositoryInterface's FindAllBySourceAndProvider method
-func (m *SchemaRepositoryMock) FindAllBySourceAndProvider(provider, source string) ([]*entity.Schema, error) {
-	args := m.Called(provider, source)
+// FindPageByFilter is a mock implementation of SchemaRepositoryInterface's FindPageByFilter method
+func (m *SchemaRepositoryMock) FindPageByFilter(filter entity.SchemaFilter, page criteria.Page) ([]*entity.Schema, string, error) {
+	args := m.Called(filter, page)
 	result := args.Get(0)
 	if result == nil {
-		return nil, args.Error(1)
+		return nil, args.String(1), args.Error(2)
 	}
-	return result.([]*entity.Schema), args.Error(1)
-}
-
-// FindAllByServiceAndSourceAndProvider is a mock implementation of SchemaRepositoryInterface's FindAllByServiceAndSourceAndProvider method
-func (m *SchemaRepositoryMock) FindAllByServiceAndSourceAndProvider(service, source, provider string) ([]*entity.Schema, error) {
-	args := m.Called(service, source, provider)
-	result := args.Get(0)
-	if result == nil {
-		return nil, args.Error(1)
-	}
-	return result.([]*entity.Schema), args.Error(1)
+	return result.([]*entity.Schema), args.String(1), args.Error(2)
 }
 
 // FindOneByServiceAndSourceAndProviderAndSchemaType is a mock implementation of SchemaRepositoryInterface's FindOneByServiceAndSourceAndProviderAndSchemaType method
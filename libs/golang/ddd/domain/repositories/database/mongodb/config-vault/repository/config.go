@@ -2,14 +2,18 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"os"
+	"strings"
 
 	"libs/golang/ddd/domain/entities/config-vault/entity"
+	"libs/golang/shared/go-criteria/criteria"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 var (
@@ -54,7 +58,7 @@ func NewConfigRepository(client *mongo.Client, database string) *ConfigRepositor
 //
 // Returns:
 //   - A pointer to the Config entity.
-//   - An error if the document is not found or cannot be decoded.
+//   - An error wrapping entity.ErrNotFound if the document is not found, or an error if it cannot be decoded.
 //
 // Example:
 //
@@ -65,6 +69,9 @@ func NewConfigRepository(client *mongo.Client, database string) *ConfigRepositor
 func (r *ConfigRepository) getOneByID(id string) (*entity.Config, error) {
 	filter := bson.M{"_id": id}
 	document := r.collection.FindOne(context.Background(), filter)
+	if errors.Is(document.Err(), mongo.ErrNoDocuments) {
+		return nil, fmt.Errorf("%w: ID %s", entity.ErrNotFound, id)
+	}
 	if document.Err() != nil {
 		return nil, document.Err()
 	}
@@ -82,7 +89,7 @@ func (r *ConfigRepository) getOneByID(id string) (*entity.Config, error) {
 //   - config: The Config entity to be inserted.
 //
 // Returns:
-//   - An error if the document already exists or cannot be inserted.
+//   - An error wrapping entity.ErrAlreadyExists if the document already exists, or an error if it cannot be inserted.
 //
 // Example:
 //
@@ -100,7 +107,7 @@ func (r *ConfigRepository) Create(config *entity.Config) error {
 	_, err = r.getOneByID(entityID)
 	if err == nil {
 		r.log.Printf("Config with ID: %s already exists\n", entityID)
-		return fmt.Errorf("config with ID: %s already exists", entityID)
+		return fmt.Errorf("%w: ID %s", entity.ErrAlreadyExists, entityID)
 	}
 
 	doc, err := r.collection.InsertOne(context.Background(), configMap)
@@ -119,7 +126,7 @@ func (r *ConfigRepository) Create(config *entity.Config) error {
 //
 // Returns:
 //   - A pointer to the Config entity.
-//   - An error if the document is not found or cannot be decoded.
+//   - An error wrapping entity.ErrNotFound if the document is not found, or an error if it cannot be decoded.
 //
 // Example:
 //
@@ -171,7 +178,7 @@ func (r *ConfigRepository) FindAll() ([]*entity.Config, error) {
 //   - config: The Config entity with updated data.
 //
 // Returns:
-//   - An error if the document is not found or cannot be updated.
+//   - An error wrapping entity.ErrNotFound if the document is not found, or an error if it cannot be updated.
 //
 // Example:
 //
@@ -185,8 +192,8 @@ func (r *ConfigRepository) Update(config *entity.Config) error {
 	configID := config.GetEntityID()
 	configStored, err := r.getOneByID(configID)
 	if err != nil {
-		r.log.Printf("Config with ID: %s not found\n", configID)
-		return fmt.Errorf("config with ID: %s not found", configID)
+		r.log.Printf("Failed to find config with ID: %s: %v\n", configID, err)
+		return err
 	}
 
 	config.SetCreatedAt(configStored.CreatedAt)
@@ -213,7 +220,7 @@ func (r *ConfigRepository) Update(config *entity.Config) error {
 //   - id: The ID of the Config document to be deleted.
 //
 // Returns:
-//   - An error if the document is not found or cannot be deleted.
+//   - An error wrapping entity.ErrNotFound if the document is not found, or an error if it cannot be deleted.
 //
 // Example:
 //
@@ -226,8 +233,8 @@ func (r *ConfigRepository) Delete(id string) error {
 	filter := bson.M{"_id": id}
 	_, err := r.getOneByID(id)
 	if err != nil {
-		r.log.Printf("Config with ID: %s not found\n", id)
-		return fmt.Errorf("config with ID: %s not found", id)
+		r.log.Printf("Failed to find config with ID: %s: %v\n", id, err)
+		return err
 	}
 	_, err = r.collection.DeleteOne(context.Background(), filter)
 	if err != nil {
@@ -279,58 +286,11 @@ func (r *ConfigRepository) find(query bson.M) ([]*entity.Config, error) {
 	return configs, nil
 }
 
-// FindAllByServiceAndProvider retrieves all Config documents that match the given provider and service.
-//
-// Parameters:
-//   - service: The service name to match.
-//
-// Returns:
-//   - A slice of pointers to Config entities.
-//   - An error if the query fails.
-//
-// Example:
-//
-//	configs, err := repository.FindAllByServiceAndProvider("myprovider", "myservice")
-//	if err != nil {
-//	    log.Fatal(err)
-//	}
-//	for _, config := range configs {
-//	    fmt.Printf("Config: %+v\n", config)
-//	}
-func (r *ConfigRepository) FindAllByServiceAndProvider(provider, service string) ([]*entity.Config, error) {
-	query := bson.M{"provider": provider, "service": service}
-	return r.find(query)
-}
-
-// FindAllBySourceAndProvider retrieves all Config documents that match the given provider and source.
-//
-// Parameters:
-//   - source: The source name to match.
-//
-// Returns:
-//   - A slice of pointers to Config entities.
-//   - An error if the query fails.
-//
-// Example:
-//
-//	configs, err := repository.FindAllBySourceAndProvider("myprovider", "mysource")
-//	if err != nil {
-//	    log.Fatal(err)
-//	}
-//	for _, config := range configs {
-//	    fmt.Printf("Config: %+v\n", config)
-//	}
-func (r *ConfigRepository) FindAllBySourceAndProvider(provider, source string) ([]*entity.Config, error) {
-	query := bson.M{"provider": provider, "source": source}
-	return r.find(query)
-}
-
-// FindAllByServiceAndSourceAndProvider retrieves all Config documents that match the given service, source, and provider.
+// FindAllByFilter retrieves all Config documents selected by the filter. Every field of the filter that is
+// set narrows the selection, and the zero filter selects every document.
 //
 // Parameters:
-//   - service: The service name to match.
-//   - source: The source name to match.
-//   - provider: The provider name to match.
+//   - filter: The filter selecting the documents.
 //
 // Returns:
 //   - A slice of pointers to Config entities.
@@ -338,41 +298,15 @@ func (r *ConfigRepository) FindAllBySourceAndProvider(provider, source string) (
 //
 // Example:
 //
-//	configs, err := repository.FindAllByServiceAndSourceAndProvider("myservice", "mysource", "myprovider")
+//	configs, err := repository.FindAllByFilter(entity.ConfigFilter{Provider: "myprovider", Active: &active})
 //	if err != nil {
 //	    log.Fatal(err)
 //	}
 //	for _, config := range configs {
 //	    fmt.Printf("Config: %+v\n", config)
 //	}
-func (r *ConfigRepository) FindAllByServiceAndSourceAndProvider(service, source, provider string) ([]*entity.Config, error) {
-	query := bson.M{"service": service, "source": source, "provider": provider}
-	return r.find(query)
-}
-
-// FindAllByServiceAndProviderAndActive retrieves all Config documents that match the given service, provider, and active status.
-//
-// Parameters:
-//   - service: The service name to match.
-//   - provider: The provider name to match.
-//   - active: The active status to match.
-//
-// Returns:
-//   - A slice of pointers to Config entities.
-//   - An error if the query fails.
-//
-// Example:
-//
-//	configs, err := repository.FindAllByServiceAndProviderAndActive("myservice", "myprovider", true)
-//	if err != nil {
-//	    log.Fatal(err)
-//	}
-//	for _, config := range configs {
-//	    fmt.Printf("Config: %+v\n", config)
-//	}
-func (r *ConfigRepository) FindAllByServiceAndProviderAndActive(service, provider string, active bool) ([]*entity.Config, error) {
-	query := bson.M{"service": service, "provider": provider, "active": active}
-	return r.find(query)
+func (r *ConfigRepository) FindAllByFilter(filter entity.ConfigFilter) ([]*entity.Config, error) {
+	return r.find(bson.M(filter.Criteria().Query()))
 }
 
 // FindAllByProviderAndDependsOn retrieves all Config documents that have dependencies matching the given service and source.
@@ -406,3 +340,79 @@ func (r *ConfigRepository) FindAllByProviderAndDependsOn(provider, service, sour
 	}
 	return r.find(query)
 }
+
+// FindPageByFilter retrieves a page of the Config documents selected by the filter, in the order of the page
+// sort. The returned cursor is the cursor of the page following this one. The cursor holds the sort value as a
+// string, so the sort must be built with criteria.ParseSort from string fields such as criteria.TimestampSortFields.
+//
+// Parameters:
+//   - filter: The filter selecting the documents.
+//   - page: The limit, cursor and sort of the page.
+//
+// Returns:
+//   - A slice of pointers to Config entities, empty when no document follows the cursor.
+//   - The cursor of the next page, empty when this page is the last one.
+//   - An error wrapping criteria.ErrInvalidCursor if the cursor is invalid, or an error if the query fails or the
+//     sort field of a document is not a string.
+//
+// Example:
+//
+//	page := criteria.Page{Limit: 50, Sort: criteria.NewSort("-created_at")}
+//	for {
+//	    configs, next, err := repository.FindPageByFilter(entity.ConfigFilter{Provider: "myprovider"}, page)
+//	    if err != nil {
+//	        log.Fatal(err)
+//	    }
+//	    for _, config := range configs {
+//	        fmt.Printf("Config: %+v\n", config)
+//	    }
+//	    if next == "" {
+//	        break
+//	    }
+//	    page.Cursor = next
+//	}
+func (r *ConfigRepository) FindPageByFilter(filter entity.ConfigFilter, page criteria.Page) ([]*entity.Config, string, error) {
+	query, err := page.Query(filter.Criteria().Query())
+	if err != nil {
+		return nil, "", err
+	}
+	sort := bson.D{}
+	for _, key := range page.Sort.Keys() {
+		sort = append(sort, bson.E{Key: key.Field, Value: int(key.Order)})
+	}
+	// One more document than the page holds is read to know whether a next page exists.
+	opts := options.Find().SetSort(sort).SetLimit(int64(page.Size() + 1))
+
+	cursor, err := r.collection.Find(context.Background(), bson.M(query), opts)
+	if err != nil {
+		return nil, "", err
+	}
+	defer cursor.Close(context.Background())
+
+	configs := []*entity.Config{}
+	var lastValue string
+	for len(configs) < page.Size() && cursor.Next(context.Background()) {
+		var config entity.Config
+		if err := cursor.Decode(&config); err != nil {
+			return nil, "", err
+		}
+		configs = append(configs, &config)
+		if page.Sort.Field != "" {
+			value, ok := cursor.Current.Lookup(strings.Split(page.Sort.Field, ".")...).StringValueOK()
+			if !ok {
+				return nil, "", fmt.Errorf("sort field %s of config %s is not a string", page.Sort.Field, config.ID)
+			}
+			lastValue = value
+		}
+	}
+
+	next := ""
+	if len(configs) == page.Size() && cursor.Next(context.Background()) {
+		next = page.Next(lastValue, string(configs[len(configs)-1].ID))
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, "", err
+	}
+
+	return configs, next, nil
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	gomongodb "libs/golang/clients/resources/go-mongo/client"
 	"libs/golang/ddd/domain/entities/config-vault/entity"
+	"libs/golang/shared/go-criteria/criteria"
 	mongowrapper "libs/golang/wrappers/resources/mongo-wrapper/wrapper"
 	"os"
 	"testing"
@@ -96,7 +97,7 @@ func (suite *ConfigVaultMongoDBRepositorySuite) TestCreateConfigAlreadyExists()
 	assert.Nil(suite.T(), err)
 
 	err = repository.Create(suite.config)
-	assert.NotNil(suite.T(), err)
+	assert.ErrorIs(suite.T(), err, entity.ErrAlreadyExists)
 }
 
 func (suite *ConfigVaultMongoDBRepositorySuite) TestGetOneByID() {
@@ -122,7 +123,7 @@ func (suite *ConfigVaultMongoDBRepositorySuite) TestGetOneByIDNotFound() {
 	repository := NewConfigRepository(suite.client, databaseName)
 	config, err := repository.getOneByID(suite.config.GetEntityID())
 	assert.Nil(suite.T(), config)
-	assert.NotNil(suite.T(), err)
+	assert.ErrorIs(suite.T(), err, entity.ErrNotFound)
 }
 
 func (suite *ConfigVaultMongoDBRepositorySuite) TestGetOneByIDInvalidID() {
@@ -229,7 +230,7 @@ func (suite *ConfigVaultMongoDBRepositorySuite) TestUpdateNotFound() {
 	assert.Nil(suite.T(), err)
 
 	err = repository.Update(config)
-	assert.NotNil(suite.T(), err)
+	assert.ErrorIs(suite.T(), err, entity.ErrNotFound)
 }
 
 func (suite *ConfigVaultMongoDBRepositorySuite) TestUpdateError() {
@@ -279,7 +280,7 @@ func (suite *ConfigVaultMongoDBRepositorySuite) TestDelete() {
 func (suite *ConfigVaultMongoDBRepositorySuite) TestDeleteNotFound() {
 	repository := NewConfigRepository(suite.client, databaseName)
 	err := repository.Delete(suite.config.GetEntityID())
-	assert.NotNil(suite.T(), err)
+	assert.ErrorIs(suite.T(), err, entity.ErrNotFound)
 }
 
 func (suite *ConfigVaultMongoDBRepositorySuite) TestDeleteError() {
@@ -323,7 +324,25 @@ func (suite *ConfigVaultMongoDBRepositorySuite) TestFindEmpty() {
 	assert.Equal(suite.T(), 0, len(configs))
 }
 
-func (suite *ConfigVaultMongoDBRepositorySuite) TestFindAllByServiceAndProvider() {
+func (suite *ConfigVaultMongoDBRepositorySuite) TestFindAllByFilterWithProvider() {
+	repository := NewConfigRepository(suite.client, databaseName)
+	err := repository.Create(suite.config)
+	assert.Nil(suite.T(), err)
+
+	secDoc := suite.configProps
+	secDoc.Provider = "test_provider2"
+	secConfig, err := entity.NewConfig(secDoc)
+	assert.Nil(suite.T(), err)
+	err = repository.Create(secConfig)
+	assert.Nil(suite.T(), err)
+
+	configs, err := repository.FindAllByFilter(entity.ConfigFilter{Provider: suite.config.Provider})
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 1, len(configs))
+	assert.Equal(suite.T(), suite.config.ID, configs[0].ID)
+}
+
+func (suite *ConfigVaultMongoDBRepositorySuite) TestFindAllByFilterWithServiceAndProvider() {
 	repository := NewConfigRepository(suite.client, databaseName)
 	err := repository.Create(suite.config)
 	assert.Nil(suite.T(), err)
@@ -335,13 +354,13 @@ func (suite *ConfigVaultMongoDBRepositorySuite) TestFindAllByServiceAndProvider(
 	err = repository.Create(secConfig)
 	assert.Nil(suite.T(), err)
 
-	configs, err := repository.FindAllByServiceAndProvider(suite.config.Provider, suite.config.Service)
+	configs, err := repository.FindAllByFilter(entity.ConfigFilter{Provider: suite.config.Provider, Service: suite.config.Service})
 	assert.Nil(suite.T(), err)
 	assert.NotNil(suite.T(), configs)
 	assert.Equal(suite.T(), 2, len(configs))
 }
 
-func (suite *ConfigVaultMongoDBRepositorySuite) TestFindAllBySourceAndProvider() {
+func (suite *ConfigVaultMongoDBRepositorySuite) TestFindAllByFilterWithSourceAndProvider() {
 	repository := NewConfigRepository(suite.client, databaseName)
 	err := repository.Create(suite.config)
 	assert.Nil(suite.T(), err)
@@ -354,13 +373,13 @@ func (suite *ConfigVaultMongoDBRepositorySuite) TestFindAllBySourceAndProvider()
 	err = repository.Create(secConfig)
 	assert.Nil(suite.T(), err)
 
-	configs, err := repository.FindAllBySourceAndProvider(suite.config.Provider, suite.config.Source)
+	configs, err := repository.FindAllByFilter(entity.ConfigFilter{Provider: suite.config.Provider, Source: suite.config.Source})
 	assert.Nil(suite.T(), err)
 	assert.NotNil(suite.T(), configs)
 	assert.Equal(suite.T(), 2, len(configs))
 }
 
-func (suite *ConfigVaultMongoDBRepositorySuite) TestFindAllByServiceAndSourceAndProvider() {
+func (suite *ConfigVaultMongoDBRepositorySuite) TestFindAllByFilterWithServiceAndSourceAndProvider() {
 	repository := NewConfigRepository(suite.client, databaseName)
 	err := repository.Create(suite.config)
 	assert.Nil(suite.T(), err)
@@ -373,13 +392,13 @@ func (suite *ConfigVaultMongoDBRepositorySuite) TestFindAllByServiceAndSourceAnd
 	err = repository.Create(secConfig)
 	assert.Nil(suite.T(), err)
 
-	configs, err := repository.FindAllByServiceAndSourceAndProvider(suite.config.Service, suite.config.Source, suite.config.Provider)
+	configs, err := repository.FindAllByFilter(entity.ConfigFilter{Provider: suite.config.Provider, Service: suite.config.Service, Source: suite.config.Source})
 	assert.Nil(suite.T(), err)
 	assert.NotNil(suite.T(), configs)
 	assert.Equal(suite.T(), 1, len(configs))
 }
 
-func (suite *ConfigVaultMongoDBRepositorySuite) TestFindAllByServiceAndProviderAndActive() {
+func (suite *ConfigVaultMongoDBRepositorySuite) TestFindAllByFilterWithServiceAndProviderAndActive() {
 	repository := NewConfigRepository(suite.client, databaseName)
 	err := repository.Create(suite.config)
 	assert.Nil(suite.T(), err)
@@ -393,7 +412,7 @@ func (suite *ConfigVaultMongoDBRepositorySuite) TestFindAllByServiceAndProviderA
 	err = repository.Create(secConfig)
 	assert.Nil(suite.T(), err)
 
-	configs, err := repository.FindAllByServiceAndProviderAndActive(suite.config.Service, suite.config.Provider, suite.config.Active)
+	configs, err := repository.FindAllByFilter(entity.ConfigFilter{Provider: suite.config.Provider, Service: suite.config.Service, Active: &suite.config.Active})
 	assert.Nil(suite.T(), err)
 	assert.NotNil(suite.T(), configs)
 	assert.Equal(suite.T(), 1, len(configs))
@@ -409,3 +428,39 @@ func (suite *ConfigVaultMongoDBRepositorySuite) TestFindAllByProviderAndDependsO
 	assert.NotNil(suite.T(), configs)
 	assert.Equal(suite.T(), 1, len(configs))
 }
+
+func (suite *ConfigVaultMongoDBRepositorySuite) TestFindPageByFilter() {
+	repository := NewConfigRepository(suite.client, databaseName)
+	for _, source := range []string{"test_source1", "test_source2", "test_source3"} {
+		props := suite.configProps
+		props.Source = source
+		config, err := entity.NewConfig(props)
+		assert.Nil(suite.T(), err)
+		err = repository.Create(config)
+		assert.Nil(suite.T(), err)
+	}
+
+	page := criteria.Page{Limit: 2, Sort: criteria.NewSort("-created_at")}
+	firstPage, next, err := repository.FindPageByFilter(entity.ConfigFilter{Provider: suite.configProps.Provider}, page)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 2, len(firstPage))
+	assert.NotEmpty(suite.T(), next)
+
+	page.Cursor = next
+	secondPage, next, err := repository.FindPageByFilter(entity.ConfigFilter{Provider: suite.configProps.Provider}, page)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 1, len(secondPage))
+	assert.Empty(suite.T(), next)
+	for _, config := range firstPage {
+		assert.NotEqual(suite.T(), config.ID, secondPage[0].ID)
+	}
+}
+
+func (suite *ConfigVaultMongoDBRepositorySuite) TestFindPageByFilterWithInvalidCursor() {
+	repository := NewConfigRepository(suite.client, databaseName)
+
+	configs, next, err := repository.FindPageByFilter(entity.ConfigFilter{}, criteria.Page{Cursor: "invalid"})
+	assert.ErrorIs(suite.T(), err, criteria.ErrInvalidCursor)
+	assert.Nil(suite.T(), configs)
+	assert.Empty(suite.T(), next)
+}
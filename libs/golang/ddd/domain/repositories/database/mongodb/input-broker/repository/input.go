@@ -2,16 +2,23 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"libs/golang/ddd/domain/entities/input-broker/entity"
+	"libs/golang/shared/go-criteria/criteria"
+	events "libs/golang/shared/go-events/amqp_events"
+	"libs/golang/shared/go-outbox/outbox"
 	"log"
+	"strings"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 var (
-	schemaCollection = "inputs"
+	schemaCollection    = "inputs"
+	idempotencyKeyIndex = "idempotency_key_unique" // Name of the unique index of the idempotency keys
 )
 
 // InputRepository manages the operations on the inputs collection in MongoDB
@@ -20,10 +27,11 @@ type InputRepository struct {
 	client     *mongo.Client
 	database   string
 	collection *mongo.Collection
+	outbox     *outbox.MongoStore
 }
 
 // NewInputRepository creates a new InputRepository instance.
-// It initializes the collection for the specified database.
+// It initializes the collection for the specified database and ensures its indexes.
 //
 // Parameters:
 //   - client: The MongoDB client.
@@ -37,12 +45,55 @@ type InputRepository struct {
 //	client := mongo.Connect(context.Background(), options.Client().ApplyURI("mongodb://localhost:27017"))
 //	repository := NewInputRepository(client, "testdb")
 func NewInputRepository(client *mongo.Client, database string) *InputRepository {
-	return &InputRepository{
+	repository := &InputRepository{
 		log:        log.New(log.Writer(), "[INPUT-REPOSITORY] ", log.LstdFlags),
 		client:     client,
 		database:   database,
 		collection: client.Database(database).Collection(schemaCollection),
+		outbox:     outbox.NewMongoStore(client, database),
 	}
+	if err := repository.ensureIndexes(); err != nil {
+		repository.log.Printf("Failed to ensure indexes of collection: %s: %v\n", schemaCollection, err)
+	}
+	return repository
+}
+
+// ensureIndexes creates the unique index of the idempotency keys, so two concurrent requests with the same
+// key cannot both create an input. The index is partial rather than sparse, since the inputs created without
+// a key store it as an empty string.
+//
+// Returns:
+//   - An error if the index cannot be created.
+func (r *InputRepository) ensureIndexes() error {
+	_, err := r.collection.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys: bson.D{{Key: "idempotency_key", Value: 1}},
+		Options: options.Index().
+			SetName(idempotencyKeyIndex).
+			SetUnique(true).
+			SetPartialFilterExpression(bson.M{"idempotency_key": bson.M{"$gt": ""}}),
+	})
+	return err
+}
+
+// insertError classifies the error of an insertion. A duplicate key error names the index that collided, which
+// tells a reused idempotency key from a reused ID.
+//
+// Parameters:
+//   - input: The inserted input.
+//   - err: The error returned by MongoDB.
+//
+// Returns:
+//   - An error wrapping entity.ErrAlreadyExists, naming the idempotency key or the ID of the input that is already
+//     stored, otherwise err.
+func insertError(input *entity.Input, err error) error {
+	if !mongo.IsDuplicateKeyError(err) {
+		return err
+	}
+	var serverErr mongo.ServerError
+	if errors.As(err, &serverErr) && serverErr.HasErrorMessage(idempotencyKeyIndex) {
+		return fmt.Errorf("%w: idempotency key %s: %v", entity.ErrAlreadyExists, input.IdempotencyKey, err)
+	}
+	return fmt.Errorf("%w: ID %s: %v", entity.ErrAlreadyExists, input.GetEntityID(), err)
 }
 
 // getOneByID retrieves a single Input document by its ID.
@@ -52,7 +103,7 @@ func NewInputRepository(client *mongo.Client, database string) *InputRepository
 //
 // Returns:
 //   - A pointer to the Input entity.
-//   - An error if the document is not found or cannot be decoded.
+//   - An error wrapping entity.ErrNotFound if the document is not found, or an error if it cannot be decoded.
 //
 // Example:
 //
@@ -62,6 +113,9 @@ func (r *InputRepository) getOneByID(id string) (*entity.Input, error) {
 	filter := bson.M{"_id": id}
 	document := r.collection.FindOne(context.Background(), filter)
 
+	if errors.Is(document.Err(), mongo.ErrNoDocuments) {
+		return nil, fmt.Errorf("%w: ID %s", entity.ErrNotFound, id)
+	}
 	if document.Err() != nil {
 		return nil, document.Err()
 	}
@@ -80,7 +134,8 @@ func (r *InputRepository) getOneByID(id string) (*entity.Input, error) {
 //   - input: The Input entity to insert.
 //
 // Returns:
-//   - An error if the document already exists or cannot be inserted.
+//   - An error wrapping entity.ErrAlreadyExists if the document or its idempotency key already exists, or an error
+//     if it cannot be inserted.
 //
 // Example:
 //
@@ -98,18 +153,74 @@ func (r *InputRepository) Create(input *entity.Input) error {
 	_, err = r.getOneByID(entityID)
 	if err == nil {
 		r.log.Printf("Input with ID: %s already exists\n", entityID)
-		return fmt.Errorf("input with ID: %s already exists", entityID)
+		return fmt.Errorf("%w: ID %s", entity.ErrAlreadyExists, entityID)
 	}
 
 	doc, err := r.collection.InsertOne(context.Background(), inputMap)
 	if err != nil {
-		return err
+		return insertError(input, err)
 	}
 	r.log.Printf("Inserted document with ID: %s\n", doc.InsertedID)
 
 	return nil
 }
 
+// CreateWithEvent inserts a new Input document and stores the given event in the outbox collection
+// within the same transaction, so the event is published if and only if the input is persisted.
+//
+// Parameters:
+//   - input: The Input entity to insert.
+//   - event: The event to store in the outbox.
+//   - routingKey: The routing key the event must be published with.
+//
+// Returns:
+//   - An error wrapping entity.ErrAlreadyExists if the document or its idempotency key already exists, or an error
+//     if the transaction fails.
+//
+// Example:
+//
+//	err := repository.CreateWithEvent(newInput, inputCreated, "input.created.provider.service.source")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+func (r *InputRepository) CreateWithEvent(input *entity.Input, event events.EventInterface, routingKey string) error {
+	r.log.Printf("Saving input: %+v with event: %s to collection: %s\n", input, event.GetName(), schemaCollection)
+	inputMap, err := input.ToMap()
+	if err != nil {
+		return err
+	}
+	message, err := outbox.NewMessage(event, routingKey)
+	if err != nil {
+		return err
+	}
+	entityID := input.GetEntityID()
+	_, err = r.getOneByID(entityID)
+	if err == nil {
+		r.log.Printf("Input with ID: %s already exists\n", entityID)
+		return fmt.Errorf("%w: ID %s", entity.ErrAlreadyExists, entityID)
+	}
+
+	ctx := context.Background()
+	session, err := r.client.StartSession()
+	if err != nil {
+		return fmt.Errorf("failed to start session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
+		if _, err := r.collection.InsertOne(sessCtx, inputMap); err != nil {
+			return nil, err
+		}
+		return nil, r.outbox.InsertOne(sessCtx, message)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save input with ID: %s: %w", entityID, insertError(input, err))
+	}
+	r.log.Printf("Inserted document with ID: %s and outbox message: %s\n", entityID, message.ID)
+
+	return nil
+}
+
 // FindByID retrieves a single Input document by its ID.
 //
 // Parameters:
@@ -117,7 +228,7 @@ func (r *InputRepository) Create(input *entity.Input) error {
 //
 // Returns:
 //   - A pointer to the Input entity.
-//   - An error if the document is not found or cannot be decoded.
+//   - An error wrapping entity.ErrNotFound if the document is not found, or an error if it cannot be decoded.
 //
 // Example:
 //
@@ -130,6 +241,37 @@ func (r *InputRepository) FindByID(id string) (*entity.Input, error) {
 	return r.getOneByID(id)
 }
 
+// FindByIdempotencyKey retrieves the Input document created by the request with the given idempotency key.
+//
+// Parameters:
+//   - key: The idempotency key of the request.
+//
+// Returns:
+//   - A pointer to the Input entity, or nil if no input was created with the key.
+//   - An error if the document cannot be read or decoded.
+//
+// Example:
+//
+//	input, err := repository.FindByIdempotencyKey("7c1e9a52-0b4f-4e38-9d0b-1f7a3e2b5c60")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+func (r *InputRepository) FindByIdempotencyKey(key string) (*entity.Input, error) {
+	document := r.collection.FindOne(context.Background(), bson.M{"idempotency_key": key})
+	if errors.Is(document.Err(), mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if document.Err() != nil {
+		return nil, document.Err()
+	}
+
+	var input entity.Input
+	if err := document.Decode(&input); err != nil {
+		return nil, err
+	}
+	return &input, nil
+}
+
 // FindAll retrieves all Input documents from the collection.
 //
 // Returns:
@@ -170,7 +312,7 @@ func (r *InputRepository) FindAll() ([]*entity.Input, error) {
 //   - input: The Input entity to update.
 //
 // Returns:
-//   - An error if the document does not exist or cannot be updated.
+//   - An error wrapping entity.ErrNotFound if the document does not exist, or an error if it cannot be updated.
 //
 // Example:
 //
@@ -183,11 +325,14 @@ func (r *InputRepository) Update(input *entity.Input) error {
 	inputID := input.GetEntityID()
 	inputStored, err := r.getOneByID(inputID)
 	if err != nil {
-		r.log.Printf("Input with ID: %s does not exist\n", inputID)
-		return fmt.Errorf("input with ID: %s does not exist", inputID)
+		r.log.Printf("Failed to find input with ID: %s: %v\n", inputID, err)
+		return err
 	}
 
 	input.SetCreatedAt(inputStored.CreatedAt)
+	if input.IdempotencyKey == "" {
+		input.SetIdempotencyKey(inputStored.IdempotencyKey)
+	}
 
 	inputMap, err := input.ToMap()
 	if err != nil {
@@ -211,7 +356,7 @@ func (r *InputRepository) Update(input *entity.Input) error {
 //   - id: The ID of the Input document.
 //
 // Returns:
-//   - An error if the document does not exist or cannot be deleted.
+//   - An error wrapping entity.ErrNotFound if the document does not exist, or an error if it cannot be deleted.
 //
 // Example:
 //
@@ -224,8 +369,8 @@ func (r *InputRepository) Delete(id string) error {
 	filter := bson.M{"_id": id}
 	_, err := r.getOneByID(id)
 	if err != nil {
-		r.log.Printf("Input with ID: %s not found\n", id)
-		return fmt.Errorf("input with ID: %s not found", id)
+		r.log.Printf("Failed to find input with ID: %s: %v\n", id, err)
+		return err
 	}
 	_, err = r.collection.DeleteOne(context.Background(), filter)
 	if err != nil {
@@ -281,174 +426,101 @@ func (r *InputRepository) find(query bson.M) ([]*entity.Input, error) {
 	return inputs, nil
 }
 
-// FindAllByStatusAndProvider retrieves all Input documents from the collection with the specified status.
-//
-// Parameters:
-//   - status: The status code.
-//
-// Returns:
-//   - A slice of Input entities.
-//   - An error if the documents cannot be decoded.
-//
-// Example:
-//
-//	inputs, err := repository.FindAllByStatusAndProvider("myprovider", 0)
-//	if err != nil {
-//		log.Fatal(err)
-//	}
-//	for _, input := range inputs {
-//		fmt.Println(input)
-//	}
-func (r *InputRepository) FindAllByStatusAndProvider(provider string, status int) ([]*entity.Input, error) {
-	query := bson.M{"metadata.provider": provider, "status.code": status}
-	return r.find(query)
-}
-
-// FindAllByServiceAndProvider retrieves all Input documents that match the given provider and service.
+// FindAllByFilter retrieves all Input documents selected by the filter. Every field of the filter that is
+// set narrows the selection, and the zero filter selects every document.
 //
 // Parameters:
-//   - provider: The provider name to match.
-//   - service: The service name to match.
+//   - filter: The filter selecting the documents.
 //
 // Returns:
-//   - A slice of Input entities.
-//   - An error if the documents cannot be decoded.
+//   - A slice of pointers to Input entities.
+//   - An error if the query fails.
 //
 // Example:
 //
-//	inputs, err := repository.FindAllByServiceAndProvider("myprovider", "myservice")
+//	inputs, err := repository.FindAllByFilter(entity.InputFilter{Provider: "myprovider", Status: &status, CreatedAfter: since})
 //	if err != nil {
 //	    log.Fatal(err)
 //	}
 //	for _, input := range inputs {
-//		fmt.Println(input)
+//	    fmt.Printf("Input: %+v\n", input)
 //	}
-func (r *InputRepository) FindAllByServiceAndProvider(provider, service string) ([]*entity.Input, error) {
-	query := bson.M{"metadata.provider": provider, "metadata.service": service}
-	return r.find(query)
+func (r *InputRepository) FindAllByFilter(filter entity.InputFilter) ([]*entity.Input, error) {
+	return r.find(bson.M(filter.Criteria().Query()))
 }
 
-// FindAllBySourceAndProvider retrieves all Input documents that match the given provider and source.
+// FindPageByFilter retrieves a page of the Input documents selected by the filter, in the order of the page
+// sort. The returned cursor is the cursor of the page following this one. The cursor holds the sort value as a
+// string, so the sort must be built with criteria.ParseSort from string fields such as criteria.TimestampSortFields.
 //
 // Parameters:
-//   - provider: The provider name to match.
-//   - source: The source name to match.
+//   - filter: The filter selecting the documents.
+//   - page: The limit, cursor and sort of the page.
 //
 // Returns:
-//   - A slice of Input entities.
-//   - An error if the documents cannot be decoded.
+//   - A slice of pointers to Input entities, empty when no document follows the cursor.
+//   - The cursor of the next page, empty when this page is the last one.
+//   - An error wrapping criteria.ErrInvalidCursor if the cursor is invalid, or an error if the query fails or the
+//     sort field of a document is not a string.
 //
 // Example:
 //
-//	inputs, err := repository.FindAllBySourceAndProvider("myprovider", "mysource")
-//	if err != nil {
-//	    log.Fatal(err)
-//	}
-//	for _, input := range inputs {
-//		fmt.Println(input)
+//	page := criteria.Page{Limit: 50, Sort: criteria.NewSort("-created_at")}
+//	for {
+//	    inputs, next, err := repository.FindPageByFilter(entity.InputFilter{Provider: "myprovider"}, page)
+//	    if err != nil {
+//	        log.Fatal(err)
+//	    }
+//	    for _, input := range inputs {
+//	        fmt.Printf("Input: %+v\n", input)
+//	    }
+//	    if next == "" {
+//	        break
+//	    }
+//	    page.Cursor = next
 //	}
-func (r *InputRepository) FindAllBySourceAndProvider(provider, service string) ([]*entity.Input, error) {
-	query := bson.M{"metadata.provider": provider, "metadata.source": service}
-	return r.find(query)
-}
+func (r *InputRepository) FindPageByFilter(filter entity.InputFilter, page criteria.Page) ([]*entity.Input, string, error) {
+	query, err := page.Query(filter.Criteria().Query())
+	if err != nil {
+		return nil, "", err
+	}
+	sort := bson.D{}
+	for _, key := range page.Sort.Keys() {
+		sort = append(sort, bson.E{Key: key.Field, Value: int(key.Order)})
+	}
+	// One more document than the page holds is read to know whether a next page exists.
+	opts := options.Find().SetSort(sort).SetLimit(int64(page.Size() + 1))
 
-// FindAllByServiceAndSourceAndProvider retrieves all Input documents that match the given provider, service and source.
-//
-// Parameters:
-//   - provider: The provider name to match.
-//   - service: The service name to match.
-//   - source: The source name to match.
-//
-// Returns:
-//   - A slice of Input entities.
-//   - An error if the documents cannot be decoded.
-//
-// Example:
-//
-//	inputs, err := repository.FindAllByServiceAndSourceAndProvider("myprovider", "myservice", "mysource")
-//	if err != nil {
-//	    log.Fatal(err)
-//	}
-//	for _, input := range inputs {
-//		fmt.Println(input)
-//	}
-func (r *InputRepository) FindAllByServiceAndSourceAndProvider(provider, service, source string) ([]*entity.Input, error) {
-	query := bson.M{"metadata.provider": provider, "metadata.service": service, "metadata.source": source}
-	return r.find(query)
-}
+	cursor, err := r.collection.Find(context.Background(), bson.M(query), opts)
+	if err != nil {
+		return nil, "", err
+	}
+	defer cursor.Close(context.Background())
 
-// FindAllByStatusAndServiceAndProvider retrieves all Input documents that match the given provider, service and status.
-//
-// Parameters:
-//   - provider: The provider name to match.
-//   - service: The service name to match.
-//   - status: The status code.
-//
-// Returns:
-//   - A slice of Input entities.
-//   - An error if the documents cannot be decoded.
-//
-// Example:
-//
-//	inputs, err := repository.FindAllByStatusAndServiceAndProvider("myprovider", "myservice", 0)
-//	if err != nil {
-//	    log.Fatal(err)
-//	}
-//	for _, input := range inputs {
-//		fmt.Println(input)
-//	}
-func (r *InputRepository) FindAllByStatusAndServiceAndProvider(provider, service string, status int) ([]*entity.Input, error) {
-	query := bson.M{"metadata.provider": provider, "metadata.service": service, "status.code": status}
-	return r.find(query)
-}
+	inputs := []*entity.Input{}
+	var lastValue string
+	for len(inputs) < page.Size() && cursor.Next(context.Background()) {
+		var input entity.Input
+		if err := cursor.Decode(&input); err != nil {
+			return nil, "", err
+		}
+		inputs = append(inputs, &input)
+		if page.Sort.Field != "" {
+			value, ok := cursor.Current.Lookup(strings.Split(page.Sort.Field, ".")...).StringValueOK()
+			if !ok {
+				return nil, "", fmt.Errorf("sort field %s of input %s is not a string", page.Sort.Field, input.ID)
+			}
+			lastValue = value
+		}
+	}
 
-// FindAllByStatusAndSourceAndProvider retrieves all Input documents that match the given provider, source and status.
-//
-// Parameters:
-//   - provider: The provider name to match.
-//   - source: The source name to match.
-//   - status: The status code.
-//
-// Returns:
-//   - A slice of Input entities.
-//   - An error if the documents cannot be decoded.
-//
-// Example:
-//
-//	inputs, err := repository.FindAllByStatusAndSourceAndProvider("myprovider", "mysource", 0)
-//	if err != nil {
-//	    log.Fatal(err)
-//	}
-//	for _, input := range inputs {
-//		fmt.Println(input)
-//	}
-func (r *InputRepository) FindAllByStatusAndSourceAndProvider(provider, source string, status int) ([]*entity.Input, error) {
-	query := bson.M{"metadata.provider": provider, "metadata.source": source, "status.code": status}
-	return r.find(query)
-}
+	next := ""
+	if len(inputs) == page.Size() && cursor.Next(context.Background()) {
+		next = page.Next(lastValue, string(inputs[len(inputs)-1].ID))
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, "", err
+	}
 
-// FindAllByStatusAndServiceAndSourceAndProvider retrieves all Input documents that match the given provider, service, source and status.
-//
-// Parameters:
-//   - provider: The provider name to match.
-//   - service: The service name to match.
-//   - source: The source name to match.
-//   - status: The status code.
-//
-// Returns:
-//   - A slice of Input entities.
-//   - An error if the documents cannot be decoded.
-//
-// Example:
-//
-//	inputs, err := repository.FindAllByStatusAndServiceAndSourceAndProvider("myprovider", "myservice", "mysource", 0)
-//	if err != nil {
-//	    log.Fatal(err)
-//	}
-//	for _, input := range inputs {
-//		fmt.Println(input)
-//	}
-func (r *InputRepository) FindAllByStatusAndServiceAndSourceAndProvider(provider, service, source string, status int) ([]*entity.Input, error) {
-	query := bson.M{"metadata.provider": provider, "metadata.service": service, "metadata.source": source, "status.code": status}
-	return r.find(query)
+	return inputs, next, nil
 }
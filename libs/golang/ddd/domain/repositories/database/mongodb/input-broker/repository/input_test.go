@@ -1,8 +1,12 @@
 package repository
 
 import (
+	"context"
 	gomongodb "libs/golang/clients/resources/go-mongo/client"
 	"libs/golang/ddd/domain/entities/input-broker/entity"
+	inputevent "libs/golang/ddd/events/input-broker/event"
+	"libs/golang/shared/go-criteria/criteria"
+	"libs/golang/shared/go-outbox/outbox"
 	mongowrapper "libs/golang/wrappers/resources/mongo-wrapper/wrapper"
 	"os"
 	"testing"
@@ -86,13 +90,72 @@ func (suite *InputBrokerMongoDBRepositorySuite) TestCreateInput() {
 	assert.Nil(suite.T(), err)
 }
 
+func (suite *InputBrokerMongoDBRepositorySuite) TestCreateInputWithUsedIdempotencyKey() {
+	repository := NewInputRepository(suite.client, databaseName)
+	suite.input.SetIdempotencyKey("request-1")
+	err := repository.Create(suite.input)
+	assert.Nil(suite.T(), err)
+
+	other, err := entity.NewInput(entity.InputProps{
+		Service:  "test-service",
+		Source:   "test-source",
+		Provider: "test-provider",
+		Data:     map[string]interface{}{"test-key": "other-value"},
+	})
+	assert.Nil(suite.T(), err)
+	other.SetIdempotencyKey("request-1")
+	err = repository.CreateWithEvent(other, inputevent.NewInputCreated(), "input.created")
+	assert.ErrorIs(suite.T(), err, entity.ErrAlreadyExists)
+	assert.ErrorContains(suite.T(), err, "idempotency key request-1")
+
+	for _, value := range []string{"first-value", "second-value"} {
+		withoutKey, err := entity.NewInput(entity.InputProps{
+			Service:  "test-service",
+			Source:   "test-source",
+			Provider: "test-provider",
+			Data:     map[string]interface{}{"test-key": value},
+		})
+		assert.Nil(suite.T(), err)
+		err = repository.Create(withoutKey)
+		assert.Nil(suite.T(), err, "The inputs created without a key do not collide")
+	}
+}
+
 func (suite *InputBrokerMongoDBRepositorySuite) TestCreateInputAlreadyExists() {
 	repository := NewInputRepository(suite.client, databaseName)
 	err := repository.Create(suite.input)
 	assert.Nil(suite.T(), err)
 
 	err = repository.Create(suite.input)
-	assert.NotNil(suite.T(), err)
+	assert.ErrorIs(suite.T(), err, entity.ErrAlreadyExists)
+}
+
+func (suite *InputBrokerMongoDBRepositorySuite) TestCreateInputWithEvent() {
+	repository := NewInputRepository(suite.client, databaseName)
+	event := inputevent.NewInputCreated()
+	event.SetPayload(map[string]interface{}{"id": suite.input.ID})
+
+	err := repository.CreateWithEvent(suite.input, event, "input.created.test-provider.test-service.test-source")
+	assert.Nil(suite.T(), err)
+
+	messages, err := outbox.NewMongoStore(suite.client, databaseName).FindPending(context.Background(), 10)
+	assert.Nil(suite.T(), err)
+	assert.Len(suite.T(), messages, 1)
+	assert.Equal(suite.T(), "InputCreated", messages[0].EventName)
+	assert.Equal(suite.T(), "input.created.test-provider.test-service.test-source", messages[0].RoutingKey)
+}
+
+func (suite *InputBrokerMongoDBRepositorySuite) TestCreateInputWithEventAlreadyExists() {
+	repository := NewInputRepository(suite.client, databaseName)
+	err := repository.Create(suite.input)
+	assert.Nil(suite.T(), err)
+
+	err = repository.CreateWithEvent(suite.input, inputevent.NewInputCreated(), "input.created")
+	assert.ErrorIs(suite.T(), err, entity.ErrAlreadyExists)
+
+	messages, err := outbox.NewMongoStore(suite.client, databaseName).FindPending(context.Background(), 10)
+	assert.Nil(suite.T(), err)
+	assert.Empty(suite.T(), messages)
 }
 
 func (suite *InputBrokerMongoDBRepositorySuite) TestGetOneByID() {
@@ -114,7 +177,7 @@ func (suite *InputBrokerMongoDBRepositorySuite) TestGetOneByID() {
 func (suite *InputBrokerMongoDBRepositorySuite) TestGetOneByIDNotFound() {
 	repository := NewInputRepository(suite.client, databaseName)
 	input, err := repository.getOneByID(suite.input.GetEntityID())
-	assert.NotNil(suite.T(), err)
+	assert.ErrorIs(suite.T(), err, entity.ErrNotFound)
 	assert.Nil(suite.T(), input)
 }
 
@@ -141,6 +204,39 @@ func (suite *InputBrokerMongoDBRepositorySuite) TestFindByID() {
 	assert.Equal(suite.T(), suite.input.Status.Detail, input.Status.Detail)
 }
 
+func (suite *InputBrokerMongoDBRepositorySuite) TestFindByIdempotencyKey() {
+	repository := NewInputRepository(suite.client, databaseName)
+	suite.input.SetIdempotencyKey("request-1")
+	err := repository.Create(suite.input)
+	assert.Nil(suite.T(), err)
+
+	input, err := repository.FindByIdempotencyKey("request-1")
+	assert.Nil(suite.T(), err)
+	assert.NotNil(suite.T(), input)
+	assert.Equal(suite.T(), suite.input.ID, input.ID)
+
+	input, err = repository.FindByIdempotencyKey("request-2")
+	assert.Nil(suite.T(), err)
+	assert.Nil(suite.T(), input)
+}
+
+func (suite *InputBrokerMongoDBRepositorySuite) TestUpdateKeepsIdempotencyKey() {
+	repository := NewInputRepository(suite.client, databaseName)
+	suite.input.SetIdempotencyKey("request-1")
+	err := repository.Create(suite.input)
+	assert.Nil(suite.T(), err)
+
+	suite.input.SetIdempotencyKey("")
+	suite.input.SetStatus(200, "completed")
+	err = repository.Update(suite.input)
+	assert.Nil(suite.T(), err)
+
+	input, err := repository.FindByIdempotencyKey("request-1")
+	assert.Nil(suite.T(), err)
+	assert.NotNil(suite.T(), input)
+	assert.Equal(suite.T(), 200, input.Status.Code)
+}
+
 func (suite *InputBrokerMongoDBRepositorySuite) TestFindAll() {
 	repository := NewInputRepository(suite.client, databaseName)
 	err := repository.Create(suite.input)
@@ -220,7 +316,7 @@ func (suite *InputBrokerMongoDBRepositorySuite) TestUpdateNotFound() {
 	assert.Nil(suite.T(), err)
 
 	err = repository.Update(newInput)
-	assert.NotNil(suite.T(), err)
+	assert.ErrorIs(suite.T(), err, entity.ErrNotFound)
 }
 
 func (suite *InputBrokerMongoDBRepositorySuite) TestUpdateError() {
@@ -280,7 +376,7 @@ func (suite *InputBrokerMongoDBRepositorySuite) TestDelete() {
 func (suite *InputBrokerMongoDBRepositorySuite) TestDeleteNotFound() {
 	repository := NewInputRepository(suite.client, databaseName)
 	err := repository.Delete("non-existent-id")
-	assert.NotNil(suite.T(), err)
+	assert.ErrorIs(suite.T(), err, entity.ErrNotFound)
 }
 
 func (suite *InputBrokerMongoDBRepositorySuite) TestDeleteError() {
@@ -324,7 +420,7 @@ func (suite *InputBrokerMongoDBRepositorySuite) TestFindEmpty() {
 	assert.Equal(suite.T(), 0, len(inputs))
 }
 
-func (suite *InputBrokerMongoDBRepositorySuite) TestFindAllByServiceAndProvider() {
+func (suite *InputBrokerMongoDBRepositorySuite) TestFindAllByFilterWithServiceAndProvider() {
 	repository := NewInputRepository(suite.client, databaseName)
 	err := repository.Create(suite.input)
 	assert.Nil(suite.T(), err)
@@ -336,13 +432,13 @@ func (suite *InputBrokerMongoDBRepositorySuite) TestFindAllByServiceAndProvider(
 	err = repository.Create(secInput)
 	assert.Nil(suite.T(), err)
 
-	inputs, err := repository.FindAllByServiceAndProvider(suite.input.Metadata.Provider, suite.input.Metadata.Service)
+	inputs, err := repository.FindAllByFilter(entity.InputFilter{Provider: suite.input.Metadata.Provider, Service: suite.input.Metadata.Service})
 	assert.Nil(suite.T(), err)
 	assert.NotNil(suite.T(), inputs)
 	assert.Equal(suite.T(), 2, len(inputs))
 }
 
-func (suite *InputBrokerMongoDBRepositorySuite) TestFindAllBySourceAndProvider() {
+func (suite *InputBrokerMongoDBRepositorySuite) TestFindAllByFilterWithSourceAndProvider() {
 	repository := NewInputRepository(suite.client, databaseName)
 	err := repository.Create(suite.input)
 	assert.Nil(suite.T(), err)
@@ -354,13 +450,13 @@ func (suite *InputBrokerMongoDBRepositorySuite) TestFindAllBySourceAndProvider()
 	err = repository.Create(secInput)
 	assert.Nil(suite.T(), err)
 
-	inputs, err := repository.FindAllBySourceAndProvider(suite.input.Metadata.Provider, suite.input.Metadata.Source)
+	inputs, err := repository.FindAllByFilter(entity.InputFilter{Provider: suite.input.Metadata.Provider, Source: suite.input.Metadata.Source})
 	assert.Nil(suite.T(), err)
 	assert.NotNil(suite.T(), inputs)
 	assert.Equal(suite.T(), 2, len(inputs))
 }
 
-func (suite *InputBrokerMongoDBRepositorySuite) TestFindAllByServiceAndSourceAndProvider() {
+func (suite *InputBrokerMongoDBRepositorySuite) TestFindAllByFilterWithServiceAndSourceAndProvider() {
 	repository := NewInputRepository(suite.client, databaseName)
 	err := repository.Create(suite.input)
 	assert.Nil(suite.T(), err)
@@ -372,13 +468,13 @@ func (suite *InputBrokerMongoDBRepositorySuite) TestFindAllByServiceAndSourceAnd
 	err = repository.Create(secInput)
 	assert.Nil(suite.T(), err)
 
-	inputs, err := repository.FindAllByServiceAndSourceAndProvider(suite.input.Metadata.Provider, suite.input.Metadata.Service, suite.input.Metadata.Source)
+	inputs, err := repository.FindAllByFilter(entity.InputFilter{Provider: suite.input.Metadata.Provider, Service: suite.input.Metadata.Service, Source: suite.input.Metadata.Source})
 	assert.Nil(suite.T(), err)
 	assert.NotNil(suite.T(), inputs)
 	assert.Equal(suite.T(), 1, len(inputs))
 }
 
-func (suite *InputBrokerMongoDBRepositorySuite) TestFindAllByStatus() {
+func (suite *InputBrokerMongoDBRepositorySuite) TestFindAllByFilterWithStatus() {
 	repository := NewInputRepository(suite.client, databaseName)
 	err := repository.Create(suite.input)
 	assert.Nil(suite.T(), err)
@@ -391,13 +487,14 @@ func (suite *InputBrokerMongoDBRepositorySuite) TestFindAllByStatus() {
 	err = repository.Create(secInput)
 	assert.Nil(suite.T(), err)
 
-	inputs, err := repository.FindAllByStatusAndProvider("test-provider", 1)
+	status := 1
+	inputs, err := repository.FindAllByFilter(entity.InputFilter{Provider: "test-provider", Status: &status})
 	assert.Nil(suite.T(), err)
 	assert.NotNil(suite.T(), inputs)
 	assert.Equal(suite.T(), 1, len(inputs))
 }
 
-func (suite *InputBrokerMongoDBRepositorySuite) TestFindAllByStatusAndServiceAndProvider() {
+func (suite *InputBrokerMongoDBRepositorySuite) TestFindAllByFilterWithStatusAndServiceAndProvider() {
 	repository := NewInputRepository(suite.client, databaseName)
 	err := repository.Create(suite.input)
 	assert.Nil(suite.T(), err)
@@ -410,13 +507,14 @@ func (suite *InputBrokerMongoDBRepositorySuite) TestFindAllByStatusAndServiceAnd
 	err = repository.Create(secInput)
 	assert.Nil(suite.T(), err)
 
-	inputs, err := repository.FindAllByStatusAndServiceAndProvider(suite.input.Metadata.Provider, suite.input.Metadata.Service, 0)
+	status := 0
+	inputs, err := repository.FindAllByFilter(entity.InputFilter{Provider: suite.input.Metadata.Provider, Service: suite.input.Metadata.Service, Status: &status})
 	assert.Nil(suite.T(), err)
 	assert.NotNil(suite.T(), inputs)
 	assert.Equal(suite.T(), 1, len(inputs))
 }
 
-func (suite *InputBrokerMongoDBRepositorySuite) TestFindAllByStatusAndSourceAndProvider() {
+func (suite *InputBrokerMongoDBRepositorySuite) TestFindAllByFilterWithStatusAndSourceAndProvider() {
 	repository := NewInputRepository(suite.client, databaseName)
 	err := repository.Create(suite.input)
 	assert.Nil(suite.T(), err)
@@ -429,13 +527,14 @@ func (suite *InputBrokerMongoDBRepositorySuite) TestFindAllByStatusAndSourceAndP
 	err = repository.Create(secInput)
 	assert.Nil(suite.T(), err)
 
-	inputs, err := repository.FindAllByStatusAndSourceAndProvider(suite.input.Metadata.Provider, suite.input.Metadata.Source, 0)
+	status := 0
+	inputs, err := repository.FindAllByFilter(entity.InputFilter{Provider: suite.input.Metadata.Provider, Source: suite.input.Metadata.Source, Status: &status})
 	assert.Nil(suite.T(), err)
 	assert.NotNil(suite.T(), inputs)
 	assert.Equal(suite.T(), 1, len(inputs))
 }
 
-func (suite *InputBrokerMongoDBRepositorySuite) TestFindAllByStatusAndServiceAndSourceAndProvider() {
+func (suite *InputBrokerMongoDBRepositorySuite) TestFindAllByFilterWithStatusAndServiceAndSourceAndProvider() {
 	repository := NewInputRepository(suite.client, databaseName)
 	err := repository.Create(suite.input)
 	assert.Nil(suite.T(), err)
@@ -449,8 +548,60 @@ func (suite *InputBrokerMongoDBRepositorySuite) TestFindAllByStatusAndServiceAnd
 	err = repository.Create(secInput)
 	assert.Nil(suite.T(), err)
 
-	inputs, err := repository.FindAllByStatusAndServiceAndSourceAndProvider(suite.input.Metadata.Provider, suite.input.Metadata.Service, suite.input.Metadata.Source, 0)
+	status := 0
+	inputs, err := repository.FindAllByFilter(entity.InputFilter{Provider: suite.input.Metadata.Provider, Service: suite.input.Metadata.Service, Source: suite.input.Metadata.Source, Status: &status})
 	assert.Nil(suite.T(), err)
 	assert.NotNil(suite.T(), inputs)
 	assert.Equal(suite.T(), 1, len(inputs))
 }
+
+func (suite *InputBrokerMongoDBRepositorySuite) TestFindAllByFilterWithCreatedAt() {
+	repository := NewInputRepository(suite.client, databaseName)
+	err := repository.Create(suite.input)
+	assert.Nil(suite.T(), err)
+
+	anHourAgo := time.Now().Add(-time.Hour)
+	inputs, err := repository.FindAllByFilter(entity.InputFilter{CreatedAfter: anHourAgo})
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 1, len(inputs))
+
+	inputs, err = repository.FindAllByFilter(entity.InputFilter{CreatedBefore: anHourAgo})
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 0, len(inputs))
+}
+
+func (suite *InputBrokerMongoDBRepositorySuite) TestFindPageByFilter() {
+	repository := NewInputRepository(suite.client, databaseName)
+	for _, source := range []string{"test_source1", "test_source2", "test_source3"} {
+		props := suite.inputProps
+		props.Source = source
+		input, err := entity.NewInput(props)
+		assert.Nil(suite.T(), err)
+		err = repository.Create(input)
+		assert.Nil(suite.T(), err)
+	}
+
+	page := criteria.Page{Limit: 2, Sort: criteria.NewSort("-created_at")}
+	firstPage, next, err := repository.FindPageByFilter(entity.InputFilter{Provider: suite.inputProps.Provider}, page)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 2, len(firstPage))
+	assert.NotEmpty(suite.T(), next)
+
+	page.Cursor = next
+	secondPage, next, err := repository.FindPageByFilter(entity.InputFilter{Provider: suite.inputProps.Provider}, page)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 1, len(secondPage))
+	assert.Empty(suite.T(), next)
+	for _, input := range firstPage {
+		assert.NotEqual(suite.T(), input.ID, secondPage[0].ID)
+	}
+}
+
+func (suite *InputBrokerMongoDBRepositorySuite) TestFindPageByFilterWithInvalidCursor() {
+	repository := NewInputRepository(suite.client, databaseName)
+
+	inputs, next, err := repository.FindPageByFilter(entity.InputFilter{}, criteria.Page{Cursor: "invalid"})
+	assert.ErrorIs(suite.T(), err, criteria.ErrInvalidCursor)
+	assert.Nil(suite.T(), inputs)
+	assert.Empty(suite.T(), next)
+}
@@ -2,12 +2,18 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"libs/golang/ddd/domain/entities/output-vault/entity"
+	"libs/golang/shared/go-criteria/criteria"
+	events "libs/golang/shared/go-events/amqp_events"
+	"libs/golang/shared/go-outbox/outbox"
 	"log"
+	"strings"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 var (
@@ -20,6 +26,7 @@ type OutputRepository struct {
 	client     *mongo.Client
 	database   string
 	collection *mongo.Collection
+	outbox     *outbox.MongoStore
 }
 
 // NewOutputRepository creates a new OutputRepository instance.
@@ -42,6 +49,7 @@ func NewOutputRepository(client *mongo.Client, database string) *OutputRepositor
 		client:     client,
 		database:   database,
 		collection: client.Database(database).Collection(schemaCollection),
+		outbox:     outbox.NewMongoStore(client, database),
 	}
 }
 
@@ -52,7 +60,7 @@ func NewOutputRepository(client *mongo.Client, database string) *OutputRepositor
 //
 // Returns:
 //   - A pointer to the Output entity.
-//   - An error if the document is not found or cannot be decoded.
+//   - An error wrapping entity.ErrNotFound if the document is not found, or an error if it cannot be decoded.
 //
 // Example:
 //
@@ -64,6 +72,9 @@ func NewOutputRepository(client *mongo.Client, database string) *OutputRepositor
 func (r *OutputRepository) getOneByID(id string) (*entity.Output, error) {
 	filter := bson.M{"_id": id}
 	document := r.collection.FindOne(context.Background(), filter)
+	if errors.Is(document.Err(), mongo.ErrNoDocuments) {
+		return nil, fmt.Errorf("%w: ID %s", entity.ErrNotFound, id)
+	}
 	if document.Err() != nil {
 		return nil, document.Err()
 	}
@@ -82,7 +93,7 @@ func (r *OutputRepository) getOneByID(id string) (*entity.Output, error) {
 //   - output: The Output entity to insert.
 //
 // Returns:
-//   - An error if the document already exists or cannot be inserted.
+//   - An error wrapping entity.ErrAlreadyExists if the document already exists, or an error if it cannot be inserted.
 //
 // Example:
 //
@@ -100,7 +111,7 @@ func (r *OutputRepository) Create(output *entity.Output) error {
 	_, err = r.getOneByID(entityID)
 	if err == nil {
 		r.log.Printf("Output with ID: %s already exists\n", entityID)
-		return fmt.Errorf("output with ID: %s already exists", entityID)
+		return fmt.Errorf("%w: ID %s", entity.ErrAlreadyExists, entityID)
 	}
 
 	doc, err := r.collection.InsertOne(context.Background(), outputMap)
@@ -112,6 +123,61 @@ func (r *OutputRepository) Create(output *entity.Output) error {
 	return nil
 }
 
+// CreateWithEvent inserts a new Output document and stores the given event in the outbox collection
+// within the same transaction, so the event is published if and only if the output is persisted.
+//
+// Parameters:
+//   - output: The Output entity to insert.
+//   - event: The event to store in the outbox.
+//   - routingKey: The routing key the event must be published with.
+//
+// Returns:
+//   - An error wrapping entity.ErrAlreadyExists if the document already exists, or an error if the transaction fails.
+//
+// Example:
+//
+//	err := repository.CreateWithEvent(newOutput, outputCreated, "output.created.provider.service.source")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+func (r *OutputRepository) CreateWithEvent(output *entity.Output, event events.EventInterface, routingKey string) error {
+	r.log.Printf("Saving output: %+v with event: %s to collection: %s\n", output, event.GetName(), schemaCollection)
+	outputMap, err := output.ToMap()
+	if err != nil {
+		return err
+	}
+	message, err := outbox.NewMessage(event, routingKey)
+	if err != nil {
+		return err
+	}
+	entityID := output.GetEntityID()
+	_, err = r.getOneByID(entityID)
+	if err == nil {
+		r.log.Printf("Output with ID: %s already exists\n", entityID)
+		return fmt.Errorf("%w: ID %s", entity.ErrAlreadyExists, entityID)
+	}
+
+	ctx := context.Background()
+	session, err := r.client.StartSession()
+	if err != nil {
+		return fmt.Errorf("failed to start session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
+		if _, err := r.collection.InsertOne(sessCtx, outputMap); err != nil {
+			return nil, err
+		}
+		return nil, r.outbox.InsertOne(sessCtx, message)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save output with ID: %s: %w", entityID, err)
+	}
+	r.log.Printf("Inserted document with ID: %s and outbox message: %s\n", entityID, message.ID)
+
+	return nil
+}
+
 // FindByID retrieves a single Output document by its ID.
 //
 // Parameters:
@@ -119,7 +185,7 @@ func (r *OutputRepository) Create(output *entity.Output) error {
 //
 // Returns:
 //   - A pointer to the Output entity.
-//   - An error if the document is not found or cannot be decoded.
+//   - An error wrapping entity.ErrNotFound if the document is not found, or an error if it cannot be decoded.
 //
 // Example:
 //
@@ -180,7 +246,7 @@ func (r *OutputRepository) FindAll() ([]*entity.Output, error) {
 //   - output: The Output entity to update.
 //
 // Returns:
-//   - An error if the document does not exist or cannot be updated.
+//   - An error wrapping entity.ErrNotFound if the document does not exist, or an error if it cannot be updated.
 //
 // Example:
 //
@@ -193,8 +259,8 @@ func (r *OutputRepository) Update(output *entity.Output) error {
 	outputID := output.GetEntityID()
 	outputStored, err := r.getOneByID(outputID)
 	if err != nil {
-		r.log.Printf("Output with ID: %s not found\n", outputID)
-		return fmt.Errorf("output with ID: %s not found", outputID)
+		r.log.Printf("Failed to find output with ID: %s: %v\n", outputID, err)
+		return err
 	}
 
 	output.SetCreatedAt(outputStored.CreatedAt)
@@ -221,7 +287,7 @@ func (r *OutputRepository) Update(output *entity.Output) error {
 //   - id: The ID of the Output document.
 //
 // Returns:
-//   - An error if the document does not exist or cannot be removed.
+//   - An error wrapping entity.ErrNotFound if the document does not exist, or an error if it cannot be removed.
 //
 // Example:
 //
@@ -234,8 +300,8 @@ func (r *OutputRepository) Delete(id string) error {
 	filter := bson.M{"_id": id}
 	_, err := r.getOneByID(id)
 	if err != nil {
-		r.log.Printf("Output with ID: %s not found\n", id)
-		return fmt.Errorf("output with ID: %s not found", id)
+		r.log.Printf("Failed to find output with ID: %s: %v\n", id, err)
+		return err
 	}
 	_, err = r.collection.DeleteOne(context.Background(), filter)
 	if err != nil {
@@ -291,11 +357,11 @@ func (r *OutputRepository) find(query bson.M) ([]*entity.Output, error) {
 	return outputs, nil
 }
 
-// FindAllByServiceAndProvider retrieves all Outputs documents that match the given provider and service.
+// FindAllByFilter retrieves all Output documents selected by the filter. Every field of the filter that is
+// set narrows the selection, and the zero filter selects every document.
 //
 // Parameters:
-//   - service: The service name to match.
-//   - provider: The provider name to match.
+//   - filter: The filter selecting the documents.
 //
 // Returns:
 //   - A slice of pointers to Output entities.
@@ -303,60 +369,89 @@ func (r *OutputRepository) find(query bson.M) ([]*entity.Output, error) {
 //
 // Example:
 //
-//	outputs, err := repository.FindAllByServiceAndProvider("myprovider", "myservice")
+//	outputs, err := repository.FindAllByFilter(entity.OutputFilter{Provider: "myprovider", CreatedAfter: since})
 //	if err != nil {
 //	    log.Fatal(err)
 //	}
 //	for _, output := range outputs {
-//		fmt.Println(output)
+//	    fmt.Printf("Output: %+v\n", output)
 //	}
-func (r *OutputRepository) FindAllByServiceAndProvider(provider, service string) ([]*entity.Output, error) {
-	return r.find(bson.M{"provider": provider, "service": service})
+func (r *OutputRepository) FindAllByFilter(filter entity.OutputFilter) ([]*entity.Output, error) {
+	return r.find(bson.M(filter.Criteria().Query()))
 }
 
-// FindAllBySourceAndProvider retrieves all Outputs documents that match the given provider and source.
+// FindPageByFilter retrieves a page of the Output documents selected by the filter, in the order of the page
+// sort. The returned cursor is the cursor of the page following this one. The cursor holds the sort value as a
+// string, so the sort must be built with criteria.ParseSort from string fields such as criteria.TimestampSortFields.
 //
 // Parameters:
-//   - source: The source name to match.
-//   - provider: The provider name to match.
+//   - filter: The filter selecting the documents.
+//   - page: The limit, cursor and sort of the page.
 //
 // Returns:
-//   - A slice of pointers to Output entities.
-//   - An error if the query fails.
+//   - A slice of pointers to Output entities, empty when no document follows the cursor.
+//   - The cursor of the next page, empty when this page is the last one.
+//   - An error wrapping criteria.ErrInvalidCursor if the cursor is invalid, or an error if the query fails or the
+//     sort field of a document is not a string.
 //
 // Example:
 //
-//	outputs, err := repository.FindAllBySourceAndProvider("myprovider", "mysource")
-//	if err != nil {
-//	    log.Fatal(err)
-//	}
-//	for _, output := range outputs {
-//		fmt.Println(output)
+//	page := criteria.Page{Limit: 50, Sort: criteria.NewSort("-created_at")}
+//	for {
+//	    outputs, next, err := repository.FindPageByFilter(entity.OutputFilter{Provider: "myprovider"}, page)
+//	    if err != nil {
+//	        log.Fatal(err)
+//	    }
+//	    for _, output := range outputs {
+//	        fmt.Printf("Output: %+v\n", output)
+//	    }
+//	    if next == "" {
+//	        break
+//	    }
+//	    page.Cursor = next
 //	}
-func (r *OutputRepository) FindAllBySourceAndProvider(provider, source string) ([]*entity.Output, error) {
-	return r.find(bson.M{"provider": provider, "source": source})
-}
+func (r *OutputRepository) FindPageByFilter(filter entity.OutputFilter, page criteria.Page) ([]*entity.Output, string, error) {
+	query, err := page.Query(filter.Criteria().Query())
+	if err != nil {
+		return nil, "", err
+	}
+	sort := bson.D{}
+	for _, key := range page.Sort.Keys() {
+		sort = append(sort, bson.E{Key: key.Field, Value: int(key.Order)})
+	}
+	// One more document than the page holds is read to know whether a next page exists.
+	opts := options.Find().SetSort(sort).SetLimit(int64(page.Size() + 1))
 
-// FindAllByServiceAndSourceAndProvider retrieves all Outputs documents that match the given provider, service and source.
-//
-// Parameters:
-//   - service: The service name to match.
-//   - source: The source name to match.
-//   - provider: The provider name to match.
-//
-// Returns:
-//   - A slice of pointers to Output entities.
-//   - An error if the query fails.
-//
-// Example:
-//
-//	outputs, err := repository.FindAllByServiceAndSourceAndProvider("myprovider", "myservice", "mysource")
-//	if err != nil {
-//		log.Fatal(err)
-//	}
-//	for _, output := range outputs {
-//		fmt.Println(output)
-//	}
-func (r *OutputRepository) FindAllByServiceAndSourceAndProvider(provider, service, source string) ([]*entity.Output, error) {
-	return r.find(bson.M{"provider": provider, "service": service, "source": source})
+	cursor, err := r.collection.Find(context.Background(), bson.M(query), opts)
+	if err != nil {
+		return nil, "", err
+	}
+	defer cursor.Close(context.Background())
+
+	outputs := []*entity.Output{}
+	var lastValue string
+	for len(outputs) < page.Size() && cursor.Next(context.Background()) {
+		var output entity.Output
+		if err := cursor.Decode(&output); err != nil {
+			return nil, "", err
+		}
+		outputs = append(outputs, &output)
+		if page.Sort.Field != "" {
+			value, ok := cursor.Current.Lookup(strings.Split(page.Sort.Field, ".")...).StringValueOK()
+			if !ok {
+				return nil, "", fmt.Errorf("sort field %s of output %s is not a string", page.Sort.Field, output.ID)
+			}
+			lastValue = value
+		}
+	}
+
+	next := ""
+	if len(outputs) == page.Size() && cursor.Next(context.Background()) {
+		next = page.Next(lastValue, string(outputs[len(outputs)-1].ID))
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, "", err
+	}
+
+	return outputs, next, nil
 }
@@ -1,8 +1,12 @@
 package repository
 
 import (
+	"context"
 	gomongodb "libs/golang/clients/resources/go-mongo/client"
 	"libs/golang/ddd/domain/entities/output-vault/entity"
+	outputevent "libs/golang/ddd/events/output-vault/event"
+	"libs/golang/shared/go-criteria/criteria"
+	"libs/golang/shared/go-outbox/outbox"
 	mongowrapper "libs/golang/wrappers/resources/mongo-wrapper/wrapper"
 	"os"
 	"testing"
@@ -101,7 +105,35 @@ func (suite *OutputVaultMongoDBRepositorySuite) TestCreateOutputAlreadyExists()
 	assert.Nil(suite.T(), err)
 
 	err = repository.Create(suite.output)
-	assert.NotNil(suite.T(), err)
+	assert.ErrorIs(suite.T(), err, entity.ErrAlreadyExists)
+}
+
+func (suite *OutputVaultMongoDBRepositorySuite) TestCreateOutputWithEvent() {
+	repository := NewOutputRepository(suite.client, databaseName)
+	event := outputevent.NewOutputCreated()
+	event.SetPayload(map[string]interface{}{"id": suite.output.ID})
+
+	err := repository.CreateWithEvent(suite.output, event, "output.created.test_provider.test_service.test_source")
+	assert.Nil(suite.T(), err)
+
+	messages, err := outbox.NewMongoStore(suite.client, databaseName).FindPending(context.Background(), 10)
+	assert.Nil(suite.T(), err)
+	assert.Len(suite.T(), messages, 1)
+	assert.Equal(suite.T(), "OutputCreated", messages[0].EventName)
+	assert.Equal(suite.T(), "output.created.test_provider.test_service.test_source", messages[0].RoutingKey)
+}
+
+func (suite *OutputVaultMongoDBRepositorySuite) TestCreateOutputWithEventAlreadyExists() {
+	repository := NewOutputRepository(suite.client, databaseName)
+	err := repository.Create(suite.output)
+	assert.Nil(suite.T(), err)
+
+	err = repository.CreateWithEvent(suite.output, outputevent.NewOutputCreated(), "output.created")
+	assert.ErrorIs(suite.T(), err, entity.ErrAlreadyExists)
+
+	messages, err := outbox.NewMongoStore(suite.client, databaseName).FindPending(context.Background(), 10)
+	assert.Nil(suite.T(), err)
+	assert.Empty(suite.T(), messages)
 }
 
 func (suite *OutputVaultMongoDBRepositorySuite) TestGetOneByID() {
@@ -122,7 +154,7 @@ func (suite *OutputVaultMongoDBRepositorySuite) TestGetOneByIDNotFound() {
 	repository := NewOutputRepository(suite.client, databaseName)
 	output, err := repository.getOneByID(suite.output.GetEntityID())
 	assert.Nil(suite.T(), output)
-	assert.NotNil(suite.T(), err)
+	assert.ErrorIs(suite.T(), err, entity.ErrNotFound)
 }
 
 func (suite *OutputVaultMongoDBRepositorySuite) TestGetOneByIDInvalidID() {
@@ -239,7 +271,7 @@ func (suite *OutputVaultMongoDBRepositorySuite) TestUpdateNotFound() {
 	assert.Nil(suite.T(), err)
 
 	err = repository.Update(output)
-	assert.NotNil(suite.T(), err)
+	assert.ErrorIs(suite.T(), err, entity.ErrNotFound)
 }
 
 func (suite *OutputVaultMongoDBRepositorySuite) TestUpdateError() {
@@ -300,7 +332,7 @@ func (suite *OutputVaultMongoDBRepositorySuite) TestDeleteNotFound() {
 	assert.Nil(suite.T(), output)
 
 	err = repository.Delete(suite.output.GetEntityID())
-	assert.NotNil(suite.T(), err)
+	assert.ErrorIs(suite.T(), err, entity.ErrNotFound)
 }
 
 func (suite *OutputVaultMongoDBRepositorySuite) TestFind() {
@@ -329,7 +361,7 @@ func (suite *OutputVaultMongoDBRepositorySuite) TestFindEmpty() {
 	assert.Equal(suite.T(), 0, len(outputs))
 }
 
-func (suite *OutputVaultMongoDBRepositorySuite) TestFindAllByServiceAndProvider() {
+func (suite *OutputVaultMongoDBRepositorySuite) TestFindAllByFilterWithServiceAndProvider() {
 	repository := NewOutputRepository(suite.client, databaseName)
 	err := repository.Create(suite.output)
 	assert.Nil(suite.T(), err)
@@ -341,13 +373,13 @@ func (suite *OutputVaultMongoDBRepositorySuite) TestFindAllByServiceAndProvider(
 	err = repository.Create(secOutput)
 	assert.Nil(suite.T(), err)
 
-	configs, err := repository.FindAllByServiceAndProvider(suite.output.Provider, suite.output.Service)
+	configs, err := repository.FindAllByFilter(entity.OutputFilter{Provider: suite.output.Provider, Service: suite.output.Service})
 	assert.Nil(suite.T(), err)
 	assert.NotNil(suite.T(), configs)
 	assert.Equal(suite.T(), 2, len(configs))
 }
 
-func (suite *OutputVaultMongoDBRepositorySuite) TestFindAllBySourceAndProvider() {
+func (suite *OutputVaultMongoDBRepositorySuite) TestFindAllByFilterWithSourceAndProvider() {
 	repository := NewOutputRepository(suite.client, databaseName)
 	err := repository.Create(suite.output)
 	assert.Nil(suite.T(), err)
@@ -359,13 +391,13 @@ func (suite *OutputVaultMongoDBRepositorySuite) TestFindAllBySourceAndProvider()
 	err = repository.Create(secOutput)
 	assert.Nil(suite.T(), err)
 
-	schemas, err := repository.FindAllBySourceAndProvider(suite.output.Provider, suite.output.Source)
+	schemas, err := repository.FindAllByFilter(entity.OutputFilter{Provider: suite.output.Provider, Source: suite.output.Source})
 	assert.Nil(suite.T(), err)
 	assert.NotNil(suite.T(), schemas)
 	assert.Equal(suite.T(), 1, len(schemas))
 }
 
-func (suite *OutputVaultMongoDBRepositorySuite) TestFindAllByServiceAndSourceAndProvider() {
+func (suite *OutputVaultMongoDBRepositorySuite) TestFindAllByFilterWithServiceAndSourceAndProvider() {
 	repository := NewOutputRepository(suite.client, databaseName)
 	err := repository.Create(suite.output)
 	assert.Nil(suite.T(), err)
@@ -377,8 +409,44 @@ func (suite *OutputVaultMongoDBRepositorySuite) TestFindAllByServiceAndSourceAnd
 	err = repository.Create(secOutput)
 	assert.Nil(suite.T(), err)
 
-	schemas, err := repository.FindAllByServiceAndSourceAndProvider(suite.output.Provider, suite.output.Service, suite.output.Source)
+	schemas, err := repository.FindAllByFilter(entity.OutputFilter{Provider: suite.output.Provider, Service: suite.output.Service, Source: suite.output.Source})
 	assert.Nil(suite.T(), err)
 	assert.NotNil(suite.T(), schemas)
 	assert.Equal(suite.T(), 1, len(schemas))
 }
+
+func (suite *OutputVaultMongoDBRepositorySuite) TestFindPageByFilter() {
+	repository := NewOutputRepository(suite.client, databaseName)
+	for _, source := range []string{"test_source1", "test_source2", "test_source3"} {
+		props := suite.outputProps
+		props.Source = source
+		output, err := entity.NewOutput(props)
+		assert.Nil(suite.T(), err)
+		err = repository.Create(output)
+		assert.Nil(suite.T(), err)
+	}
+
+	page := criteria.Page{Limit: 2, Sort: criteria.NewSort("-created_at")}
+	firstPage, next, err := repository.FindPageByFilter(entity.OutputFilter{Provider: suite.outputProps.Provider}, page)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 2, len(firstPage))
+	assert.NotEmpty(suite.T(), next)
+
+	page.Cursor = next
+	secondPage, next, err := repository.FindPageByFilter(entity.OutputFilter{Provider: suite.outputProps.Provider}, page)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 1, len(secondPage))
+	assert.Empty(suite.T(), next)
+	for _, output := range firstPage {
+		assert.NotEqual(suite.T(), output.ID, secondPage[0].ID)
+	}
+}
+
+func (suite *OutputVaultMongoDBRepositorySuite) TestFindPageByFilterWithInvalidCursor() {
+	repository := NewOutputRepository(suite.client, databaseName)
+
+	outputs, next, err := repository.FindPageByFilter(entity.OutputFilter{}, criteria.Page{Cursor: "invalid"})
+	assert.ErrorIs(suite.T(), err, criteria.ErrInvalidCursor)
+	assert.Nil(suite.T(), outputs)
+	assert.Empty(suite.T(), next)
+}
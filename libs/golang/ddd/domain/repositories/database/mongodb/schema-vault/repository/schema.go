@@ -2,14 +2,18 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"os"
+	"strings"
 
 	"libs/golang/ddd/domain/entities/schema-vault/entity"
+	"libs/golang/shared/go-criteria/criteria"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 var (
@@ -54,7 +58,7 @@ func NewSchemaRepository(client *mongo.Client, database string) *SchemaRepositor
 //
 // Returns:
 //   - A pointer to the Schema entity.
-//   - An error if the document is not found or cannot be decoded.
+//   - An error wrapping entity.ErrNotFound if the document is not found, or an error if it cannot be decoded.
 //
 // Example:
 //
@@ -66,6 +70,9 @@ func NewSchemaRepository(client *mongo.Client, database string) *SchemaRepositor
 func (r *SchemaRepository) getOneByID(id string) (*entity.Schema, error) {
 	filter := bson.M{"_id": id}
 	document := r.collection.FindOne(context.Background(), filter)
+	if errors.Is(document.Err(), mongo.ErrNoDocuments) {
+		return nil, fmt.Errorf("%w: ID %s", entity.ErrNotFound, id)
+	}
 	if document.Err() != nil {
 		return nil, document.Err()
 	}
@@ -84,7 +91,7 @@ func (r *SchemaRepository) getOneByID(id string) (*entity.Schema, error) {
 //   - schema: The Schema entity to be inserted.
 //
 // Returns:
-//   - An error if the document already exists or cannot be inserted.
+//   - An error wrapping entity.ErrAlreadyExists if the document already exists, or an error if it cannot be inserted.
 //
 // Example:
 //
@@ -102,7 +109,7 @@ func (r *SchemaRepository) Create(schema *entity.Schema) error {
 	_, err = r.getOneByID(entityID)
 	if err == nil {
 		r.log.Printf("Schema with ID: %s already exists\n", entityID)
-		return fmt.Errorf("schema with ID: %s already exists", entityID)
+		return fmt.Errorf("%w: ID %s", entity.ErrAlreadyExists, entityID)
 	}
 	log.Printf("Schema map created: %+v\n", schemaMap)
 	if jsonSchema, ok := schemaMap["json_schema"].(map[string]interface{}); ok {
@@ -132,7 +139,7 @@ func (r *SchemaRepository) Create(schema *entity.Schema) error {
 //
 // Returns:
 //   - A pointer to the Schema entity.
-//   - An error if the document is not found or cannot be decoded.
+//   - An error wrapping entity.ErrNotFound if the document is not found, or an error if it cannot be decoded.
 //
 // Example:
 //
@@ -193,7 +200,7 @@ func (r *SchemaRepository) FindAll() ([]*entity.Schema, error) {
 //   - schema: The Schema entity with updated data.
 //
 // Returns:
-//   - An error if the document is not found or cannot be updated.
+//   - An error wrapping entity.ErrNotFound if the document is not found, or an error if it cannot be updated.
 //
 // Example:
 //
@@ -207,8 +214,8 @@ func (r *SchemaRepository) Update(schema *entity.Schema) error {
 	schemaID := schema.GetEntityID()
 	schemaStored, err := r.getOneByID(schemaID)
 	if err != nil {
-		r.log.Printf("Schema with ID: %s not found\n", schemaID)
-		return fmt.Errorf("schema with ID: %s not found", schemaID)
+		r.log.Printf("Failed to find schema with ID: %s: %v\n", schemaID, err)
+		return err
 	}
 
 	schema.SetCreatedAt(schemaStored.CreatedAt)
@@ -245,7 +252,7 @@ func (r *SchemaRepository) Update(schema *entity.Schema) error {
 //   - id: The ID of the Schema document to be deleted.
 //
 // Returns:
-//   - An error if the document is not found or cannot be deleted.
+//   - An error wrapping entity.ErrNotFound if the document is not found, or an error if it cannot be deleted.
 //
 // Example:
 //
@@ -258,8 +265,8 @@ func (r *SchemaRepository) Delete(id string) error {
 	filter := bson.M{"_id": id}
 	_, err := r.getOneByID(id)
 	if err != nil {
-		r.log.Printf("Schema with ID: %s not found\n", id)
-		return fmt.Errorf("schema with ID: %s not found", id)
+		r.log.Printf("Failed to find schema with ID: %s: %v\n", id, err)
+		return err
 	}
 	_, err = r.collection.DeleteOne(context.Background(), filter)
 	if err != nil {
@@ -316,57 +323,11 @@ func (r *SchemaRepository) find(query bson.M) ([]*entity.Schema, error) {
 	return schemas, nil
 }
 
-// FindAllByServiceAndProvider retrieves all Schema documents that match the given provider and service.
-//
-// Parameters:
-//   - service: The service name to match.
-//   - provider: The provider name to match.
-//
-// Returns:
-//   - A slice of pointers to Schema entities.
-//   - An error if the query fails.
-//
-// Example:
-//
-//	schemas, err := repository.FindAllByServiceAndProvider("myprovider", "myservice")
-//	if err != nil {
-//	    log.Fatal(err)
-//	}
-//	for _, schema := range schemas {
-//	    fmt.Printf("Schema: %+v\n", schema)
-//	}
-func (r *SchemaRepository) FindAllByServiceAndProvider(provider, service string) ([]*entity.Schema, error) {
-	return r.find(bson.M{"provider": provider, "service": service})
-}
-
-// FindAllBySourceAndProvider retrieves all Schema documents that match the given provider and source.
-//
-// Parameters:
-//   - source: The source name to match.
-//
-// Returns:
-//   - A slice of pointers to Schema entities.
-//   - An error if the query fails.
-//
-// Example:
-//
-//	schemas, err := repository.FindAllBySourceAndProvider("myprovider", "mysource")
-//	if err != nil {
-//	    log.Fatal(err)
-//	}
-//	for _, schema := range schemas {
-//	    fmt.Printf("Schema: %+v\n", schema)
-//	}
-func (r *SchemaRepository) FindAllBySourceAndProvider(provider, source string) ([]*entity.Schema, error) {
-	return r.find(bson.M{"provider": provider, "source": source})
-}
-
-// FindAllByServiceAndSourceAndProvider retrieves all Schema documents that match the given service, source, and provider.
+// FindAllByFilter retrieves all Schema documents selected by the filter. Every field of the filter that is
+// set narrows the selection, and the zero filter selects every document.
 //
 // Parameters:
-//   - service: The service name to match.
-//   - source: The source name to match.
-//   - provider: The provider name to match.
+//   - filter: The filter selecting the documents.
 //
 // Returns:
 //   - A slice of pointers to Schema entities.
@@ -374,15 +335,15 @@ func (r *SchemaRepository) FindAllBySourceAndProvider(provider, source string) (
 //
 // Example:
 //
-//	schemas, err := repository.FindAllByServiceAndSourceAndProvider("myservice", "mysource", "myprovider")
+//	schemas, err := repository.FindAllByFilter(entity.SchemaFilter{Provider: "myprovider", SchemaType: "input"})
 //	if err != nil {
 //	    log.Fatal(err)
 //	}
 //	for _, schema := range schemas {
 //	    fmt.Printf("Schema: %+v\n", schema)
 //	}
-func (r *SchemaRepository) FindAllByServiceAndSourceAndProvider(service, source, provider string) ([]*entity.Schema, error) {
-	return r.find(bson.M{"provider": provider, "service": service, "source": source})
+func (r *SchemaRepository) FindAllByFilter(filter entity.SchemaFilter) ([]*entity.Schema, error) {
+	return r.find(bson.M(filter.Criteria().Query()))
 }
 
 // FindAllByServiceAndSourceAndProviderAndSchemaType retrieves one Schema document that matches the given service, source, provider, and schema type.
@@ -395,7 +356,7 @@ func (r *SchemaRepository) FindAllByServiceAndSourceAndProvider(service, source,
 //
 // Returns:
 //   - A pointer to the Schema entity.
-//   - An error if the query fails.
+//   - An error wrapping entity.ErrNotFound if no schema matches, or an error if the query fails.
 //
 // Example:
 //
@@ -411,8 +372,84 @@ func (r *SchemaRepository) FindOneByServiceAndSourceAndProviderAndSchemaType(ser
 	}
 
 	if len(schemas) == 0 {
-		return nil, fmt.Errorf("schema not found")
+		return nil, fmt.Errorf("%w: provider %s, service %s, source %s and schema type %s", entity.ErrNotFound, provider, service, source, schemaType)
 	}
 
 	return schemas[0], nil
 }
+
+// FindPageByFilter retrieves a page of the Schema documents selected by the filter, in the order of the page
+// sort. The returned cursor is the cursor of the page following this one. The cursor holds the sort value as a
+// string, so the sort must be built with criteria.ParseSort from string fields such as criteria.TimestampSortFields.
+//
+// Parameters:
+//   - filter: The filter selecting the documents.
+//   - page: The limit, cursor and sort of the page.
+//
+// Returns:
+//   - A slice of pointers to Schema entities, empty when no document follows the cursor.
+//   - The cursor of the next page, empty when this page is the last one.
+//   - An error wrapping criteria.ErrInvalidCursor if the cursor is invalid, or an error if the query fails or the
+//     sort field of a document is not a string.
+//
+// Example:
+//
+//	page := criteria.Page{Limit: 50, Sort: criteria.NewSort("-created_at")}
+//	for {
+//	    schemas, next, err := repository.FindPageByFilter(entity.SchemaFilter{Provider: "myprovider"}, page)
+//	    if err != nil {
+//	        log.Fatal(err)
+//	    }
+//	    for _, schema := range schemas {
+//	        fmt.Printf("Schema: %+v\n", schema)
+//	    }
+//	    if next == "" {
+//	        break
+//	    }
+//	    page.Cursor = next
+//	}
+func (r *SchemaRepository) FindPageByFilter(filter entity.SchemaFilter, page criteria.Page) ([]*entity.Schema, string, error) {
+	query, err := page.Query(filter.Criteria().Query())
+	if err != nil {
+		return nil, "", err
+	}
+	sort := bson.D{}
+	for _, key := range page.Sort.Keys() {
+		sort = append(sort, bson.E{Key: key.Field, Value: int(key.Order)})
+	}
+	// One more document than the page holds is read to know whether a next page exists.
+	opts := options.Find().SetSort(sort).SetLimit(int64(page.Size() + 1))
+
+	cursor, err := r.collection.Find(context.Background(), bson.M(query), opts)
+	if err != nil {
+		return nil, "", err
+	}
+	defer cursor.Close(context.Background())
+
+	schemas := []*entity.Schema{}
+	var lastValue string
+	for len(schemas) < page.Size() && cursor.Next(context.Background()) {
+		var schema entity.Schema
+		if err := cursor.Decode(&schema); err != nil {
+			return nil, "", err
+		}
+		schemas = append(schemas, &schema)
+		if page.Sort.Field != "" {
+			value, ok := cursor.Current.Lookup(strings.Split(page.Sort.Field, ".")...).StringValueOK()
+			if !ok {
+				return nil, "", fmt.Errorf("sort field %s of schema %s is not a string", page.Sort.Field, schema.ID)
+			}
+			lastValue = value
+		}
+	}
+
+	next := ""
+	if len(schemas) == page.Size() && cursor.Next(context.Background()) {
+		next = page.Next(lastValue, string(schemas[len(schemas)-1].ID))
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, "", err
+	}
+
+	return schemas, next, nil
+}
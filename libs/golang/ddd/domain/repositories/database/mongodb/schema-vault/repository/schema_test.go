@@ -13,6 +13,7 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 
 	"libs/golang/ddd/domain/entities/schema-vault/entity"
+	"libs/golang/shared/go-criteria/criteria"
 	mongowrapper "libs/golang/wrappers/resources/mongo-wrapper/wrapper"
 )
 
@@ -105,7 +106,7 @@ func (suite *SchemaRepositoryTestSuite) TestSchemaAlreadyExists() {
 	assert.Nil(suite.T(), err)
 
 	err = repository.Create(suite.schema)
-	assert.NotNil(suite.T(), err)
+	assert.ErrorIs(suite.T(), err, entity.ErrAlreadyExists)
 }
 
 func (suite *SchemaRepositoryTestSuite) TestGetOneByID() {
@@ -125,7 +126,7 @@ func (suite *SchemaRepositoryTestSuite) TestGetOneByID() {
 func (suite *SchemaRepositoryTestSuite) TestGetOneByIDNotFound() {
 	repository := NewSchemaRepository(suite.client, databaseName)
 	schema, err := repository.getOneByID(suite.schema.GetEntityID())
-	assert.NotNil(suite.T(), err)
+	assert.ErrorIs(suite.T(), err, entity.ErrNotFound)
 	assert.Nil(suite.T(), schema)
 }
 
@@ -238,7 +239,7 @@ func (suite *SchemaRepositoryTestSuite) TestUpdateNotFound() {
 	assert.Nil(suite.T(), err)
 
 	err = repository.Update(schema)
-	assert.NotNil(suite.T(), err)
+	assert.ErrorIs(suite.T(), err, entity.ErrNotFound)
 }
 
 func (suite *SchemaRepositoryTestSuite) TestUpdateError() {
@@ -303,7 +304,7 @@ func (suite *SchemaRepositoryTestSuite) TestDeleteNotFound() {
 	assert.Nil(suite.T(), schema)
 
 	err = repository.Delete(suite.schema.GetEntityID())
-	assert.NotNil(suite.T(), err)
+	assert.ErrorIs(suite.T(), err, entity.ErrNotFound)
 }
 
 func (suite *SchemaRepositoryTestSuite) TestFind() {
@@ -332,7 +333,7 @@ func (suite *SchemaRepositoryTestSuite) TestFindEmpty() {
 	assert.Equal(suite.T(), 0, len(schemas))
 }
 
-func (suite *SchemaRepositoryTestSuite) TestFindAllByServiceAndProvider() {
+func (suite *SchemaRepositoryTestSuite) TestFindAllByFilterWithServiceAndProvider() {
 	repository := NewSchemaRepository(suite.client, databaseName)
 	err := repository.Create(suite.schema)
 	assert.Nil(suite.T(), err)
@@ -344,13 +345,13 @@ func (suite *SchemaRepositoryTestSuite) TestFindAllByServiceAndProvider() {
 	err = repository.Create(secSchema)
 	assert.Nil(suite.T(), err)
 
-	configs, err := repository.FindAllByServiceAndProvider(suite.schema.Provider, suite.schema.Service)
+	configs, err := repository.FindAllByFilter(entity.SchemaFilter{Provider: suite.schema.Provider, Service: suite.schema.Service})
 	assert.Nil(suite.T(), err)
 	assert.NotNil(suite.T(), configs)
 	assert.Equal(suite.T(), 2, len(configs))
 }
 
-func (suite *SchemaRepositoryTestSuite) TestFindAllBySourceAndProvider() {
+func (suite *SchemaRepositoryTestSuite) TestFindAllByFilterWithSourceAndProvider() {
 	repository := NewSchemaRepository(suite.client, databaseName)
 	err := repository.Create(suite.schema)
 	assert.Nil(suite.T(), err)
@@ -363,13 +364,13 @@ func (suite *SchemaRepositoryTestSuite) TestFindAllBySourceAndProvider() {
 	err = repository.Create(seSchema)
 	assert.Nil(suite.T(), err)
 
-	schemas, err := repository.FindAllBySourceAndProvider(suite.schema.Provider, suite.schema.Source)
+	schemas, err := repository.FindAllByFilter(entity.SchemaFilter{Provider: suite.schema.Provider, Source: suite.schema.Source})
 	assert.Nil(suite.T(), err)
 	assert.NotNil(suite.T(), schemas)
 	assert.Equal(suite.T(), 1, len(schemas))
 }
 
-func (suite *SchemaRepositoryTestSuite) TestFindAllByServiceAndSourceAndProvider() {
+func (suite *SchemaRepositoryTestSuite) TestFindAllByFilterWithServiceAndSourceAndProvider() {
 	repository := NewSchemaRepository(suite.client, databaseName)
 	err := repository.Create(suite.schema)
 	assert.Nil(suite.T(), err)
@@ -382,7 +383,7 @@ func (suite *SchemaRepositoryTestSuite) TestFindAllByServiceAndSourceAndProvider
 	err = repository.Create(seSchema)
 	assert.Nil(suite.T(), err)
 
-	schemas, err := repository.FindAllByServiceAndSourceAndProvider(suite.schema.Service, suite.schema.Source, suite.schema.Provider)
+	schemas, err := repository.FindAllByFilter(entity.SchemaFilter{Provider: suite.schema.Provider, Service: suite.schema.Service, Source: suite.schema.Source})
 	assert.Nil(suite.T(), err)
 	assert.NotNil(suite.T(), schemas)
 	assert.Equal(suite.T(), 1, len(schemas))
@@ -428,3 +429,39 @@ func (suite *SchemaRepositoryTestSuite) TestFindOneByServiceAndSourceAndProvider
 	assert.Equal(suite.T(), suite.schema.SchemaType, schema.SchemaType)
 	assert.Equal(suite.T(), suite.schema.JsonSchema, schema.JsonSchema)
 }
+
+func (suite *SchemaRepositoryTestSuite) TestFindPageByFilter() {
+	repository := NewSchemaRepository(suite.client, databaseName)
+	for _, source := range []string{"test_source1", "test_source2", "test_source3"} {
+		props := suite.schemaProps
+		props.Source = source
+		schema, err := entity.NewSchema(props)
+		assert.Nil(suite.T(), err)
+		err = repository.Create(schema)
+		assert.Nil(suite.T(), err)
+	}
+
+	page := criteria.Page{Limit: 2, Sort: criteria.NewSort("-created_at")}
+	firstPage, next, err := repository.FindPageByFilter(entity.SchemaFilter{Provider: suite.schemaProps.Provider}, page)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 2, len(firstPage))
+	assert.NotEmpty(suite.T(), next)
+
+	page.Cursor = next
+	secondPage, next, err := repository.FindPageByFilter(entity.SchemaFilter{Provider: suite.schemaProps.Provider}, page)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 1, len(secondPage))
+	assert.Empty(suite.T(), next)
+	for _, schema := range firstPage {
+		assert.NotEqual(suite.T(), schema.ID, secondPage[0].ID)
+	}
+}
+
+func (suite *SchemaRepositoryTestSuite) TestFindPageByFilterWithInvalidCursor() {
+	repository := NewSchemaRepository(suite.client, databaseName)
+
+	schemas, next, err := repository.FindPageByFilter(entity.SchemaFilter{}, criteria.Page{Cursor: "invalid"})
+	assert.ErrorIs(suite.T(), err, criteria.ErrInvalidCursor)
+	assert.Nil(suite.T(), schemas)
+	assert.Empty(suite.T(), next)
+}
@@ -0,0 +1,65 @@
+package inputdto
+
+import (
+	"fmt"
+	"libs/golang/shared/go-criteria/criteria"
+	"net/url"
+	"strconv"
+)
+
+// SortFields lists the values of the sort parameter. A "-" prefix sorts in descending order.
+var SortFields = criteria.TimestampSortFields
+
+// ConfigFilterDTO represents the query parameters selecting the configs to list and the page to read, such as
+// `?provider=acme&service=billing&active=true`. Every field is optional.
+type ConfigFilterDTO struct {
+	Provider           string `json:"provider"` // Provider of the configs, any when empty.
+	Service            string `json:"service"`  // Service of the configs, any when empty.
+	Source             string `json:"source"`   // Source of the configs, any when empty.
+	Active             *bool  `json:"active"`   // Whether the configs are active, either when nil.
+	criteria.ListQuery        // Creation time bounds and page of the configs, sorted on one of SortFields.
+}
+
+// NewConfigFilterDTO reads a ConfigFilterDTO from query parameters. The times are RFC 3339 timestamps.
+//
+// Parameters:
+//   - query: The query parameters of the request.
+//
+// Returns:
+//   - The filter read from the query parameters.
+//   - An error if active is not a boolean, a time is not an RFC 3339 timestamp, the limit is not a positive integer or the sort is not one of SortFields.
+func NewConfigFilterDTO(query url.Values) (ConfigFilterDTO, error) {
+	filter := ConfigFilterDTO{
+		Provider: query.Get("provider"),
+		Service:  query.Get("service"),
+		Source:   query.Get("source"),
+	}
+	if value := query.Get("active"); value != "" {
+		active, err := strconv.ParseBool(value)
+		if err != nil {
+			return ConfigFilterDTO{}, fmt.Errorf("invalid active %q: must be a boolean", value)
+		}
+		filter.Active = &active
+	}
+	listQuery, err := criteria.NewListQuery(query, SortFields)
+	if err != nil {
+		return ConfigFilterDTO{}, err
+	}
+	filter.ListQuery = listQuery
+	return filter, nil
+}
+
+// Values writes the filter as query parameters, leaving out the fields that are not set.
+//
+// Returns:
+//   - The query parameters of the filter.
+func (f ConfigFilterDTO) Values() url.Values {
+	query := f.ListQuery.Values()
+	criteria.SetNotEmpty(query, "provider", f.Provider)
+	criteria.SetNotEmpty(query, "service", f.Service)
+	criteria.SetNotEmpty(query, "source", f.Source)
+	if f.Active != nil {
+		query.Set("active", strconv.FormatBool(*f.Active))
+	}
+	return query
+}
@@ -19,3 +19,33 @@ type ConfigDTO struct {
 	CreatedAt       string                         `json:"created_at"`        // CreatedAt is the timestamp when the configuration was created.
 	UpdatedAt       string                         `json:"updated_at"`        // UpdatedAt is the timestamp when the configuration was last updated.
 }
+
+// ConfigGraphNodeDTO represents a job of the dependency graph of a provider.
+type ConfigGraphNodeDTO struct {
+	Service  string `json:"service"`   // Service represents the name of the service of the job.
+	Source   string `json:"source"`    // Source indicates the source of the job.
+	ConfigID string `json:"config_id"` // ConfigID is the identifier of the configuration of the job, empty if the job is only a dependency.
+	Active   bool   `json:"active"`    // Active indicates whether the configuration of the job is active.
+}
+
+// ConfigGraphEdgeDTO represents a dependency between two jobs of a provider.
+type ConfigGraphEdgeDTO struct {
+	From shareddto.JobDependenciesDTO `json:"from"` // From is the upstream job.
+	To   shareddto.JobDependenciesDTO `json:"to"`   // To is the job depending on the upstream job.
+}
+
+// ConfigGraphDTO represents the dependency graph of the configurations of a provider.
+type ConfigGraphDTO struct {
+	Provider string                         `json:"provider"`        // Provider specifies the provider of the graph.
+	Nodes    []ConfigGraphNodeDTO           `json:"nodes"`           // Nodes lists the jobs, sorted by service and source.
+	Edges    []ConfigGraphEdgeDTO           `json:"edges"`           // Edges lists the dependencies between the jobs.
+	Order    []shareddto.JobDependenciesDTO `json:"order"`           // Order lists the jobs so that each job comes after its dependencies.
+	Cycle    []shareddto.JobDependenciesDTO `json:"cycle,omitempty"` // Cycle lists the jobs of a cycle, if the stored configurations contain one.
+}
+
+// ConfigPageDTO represents a page of configurations. The next page is read by sending NextCursor as the cursor of
+// the same query.
+type ConfigPageDTO struct {
+	Items      []ConfigDTO `json:"items"`                 // Items lists the configurations of the page.
+	NextCursor string      `json:"next_cursor,omitempty"` // NextCursor is the cursor of the next page, empty on the last page.
+}
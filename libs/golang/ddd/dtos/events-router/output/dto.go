@@ -1,10 +1,60 @@
 package outputdto
 
-// ErrMsgDTO represents the error message data transfer object.
+import (
+	"encoding/json"
+	"time"
+)
+
+// Error categories of ErrMsgDTO.
+const (
+	ErrCategoryUnmarshal        = "unmarshal"         // The message is not a valid input
+	ErrCategorySchemaInvalid    = "schema-invalid"    // The input does not match its schema
+	ErrCategoryDependencyLookup = "dependency-lookup" // The configs depending on the input could not be listed
+	ErrCategoryRepository       = "repository"        // The event order, processing window or processed message could not be stored
+	ErrCategoryStageTransition  = "stage-transition"  // The event order cannot move to the reported stage
+	ErrCategoryTimeout          = "timeout"           // The job of the event order did not report back in time
+	ErrCategoryInputStatus      = "input-status"      // The status of the input could not be updated
+	ErrCategoryAction           = "action"            // A pre-processing action failed without classifying its error
+	ErrCategoryTransformation   = "transformation"    // The input could not be reshaped to its output schema
+	ErrCategoryPublish          = "publish"           // A message could not be published to the broker
+	ErrCategorySchemaLookup     = "schema-lookup"     // The schema-vault could not be reached or failed to answer
+)
+
+// Error codes of ErrMsgDTO. Each code belongs to one category.
+const (
+	ErrCodeMalformedMessage      = "MALFORMED_MESSAGE"             // unmarshal
+	ErrCodeInvalidEventOrder     = "INVALID_EVENT_ORDER"           // schema-invalid
+	ErrCodeSchemaValidation      = "SCHEMA_VALIDATION"             // schema-invalid
+	ErrCodeDependencyLookup      = "DEPENDENCY_LOOKUP"             // dependency-lookup
+	ErrCodeEventOrderPersistence = "EVENT_ORDER_PERSISTENCE"       // repository
+	ErrCodeInvalidWindow         = "INVALID_PROCESSING_WINDOW"     // schema-invalid
+	ErrCodeWindowPersistence     = "PROCESSING_WINDOW_PERSISTENCE" // repository
+	ErrCodeEventOrderNotFound    = "EVENT_ORDER_NOT_FOUND"         // repository
+	ErrCodeIllegalTransition     = "ILLEGAL_STAGE_TRANSITION"      // stage-transition
+	ErrCodeStageTimeout          = "STAGE_TIMEOUT"                 // timeout
+	ErrCodeInputStatusUpdate     = "INPUT_STATUS_UPDATE"           // input-status
+	ErrCodeActionFailed          = "ACTION_FAILED"                 // action
+	ErrCodeOutputSchemaLookup    = "OUTPUT_SCHEMA_LOOKUP"          // schema-lookup
+	ErrCodeTransformation        = "TRANSFORMATION"                // transformation
+	ErrCodeOutputValidation      = "OUTPUT_SCHEMA_VALIDATION"      // schema-invalid
+	ErrCodeDeduplication         = "DEDUPLICATION"                 // repository
+	ErrCodePublish               = "PUBLISH"                       // publish
+	ErrCodeSchemaLookup          = "SCHEMA_LOOKUP"                 // schema-lookup
+)
+
+// ErrMsgDTO represents the error message data transfer object published on error.created.* routing keys.
 type ErrMsgDTO struct {
-	Err         error  `json:"error"`
-	ListenerTag string `json:"listener_tag"`
-	Msg         []byte `json:"msg"`
+	Code         string          `json:"code"`          // Code is one of the ErrCode constants.
+	Category     string          `json:"category"`      // Category is one of the ErrCategory constants.
+	Message      string          `json:"message"`       // Message describes the error.
+	Causes       []string        `json:"causes"`        // Causes lists the wrapped errors, outermost first.
+	Payload      json.RawMessage `json:"payload"`       // Payload is the original message, or a JSON string if it is not valid JSON.
+	ProcessingID string          `json:"processing_id"` // ProcessingID identifies the processing of the input, when known.
+	InputID      string          `json:"input_id"`      // InputID identifies the input, when known.
+	ListenerTag  string          `json:"listener_tag"`  // ListenerTag identifies the listener that consumed the message.
+	Stage        string          `json:"stage"`         // Stage is the processing stage that failed.
+	Attempt      int             `json:"attempt"`       // Attempt is the delivery attempt of the message, starting at 1.
+	Timestamp    time.Time       `json:"timestamp"`     // Timestamp is the time of the error.
 }
 
 // ProcessOrderDTO represents the data transfer object for processing orders.
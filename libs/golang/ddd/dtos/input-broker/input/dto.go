@@ -2,8 +2,8 @@ package inputdto
 
 // InputDTO represents the input data transfer object.
 type InputDTO struct {
-	Provider string                 `json:"provider"` // Provider represents the provider of the input data.
-	Service  string                 `json:"service"`  // Service represents the service of the input data.
-	Source   string                 `json:"source"`   // Source represents the source of the input data.
-	Data     map[string]interface{} `json:"data"`     // Data represents the input data.
+	Provider string                 `json:"provider" validate:"required,identifier,max=64"` // Provider represents the provider of the input data.
+	Service  string                 `json:"service" validate:"required,identifier,max=64"`  // Service represents the service of the input data.
+	Source   string                 `json:"source" validate:"required,identifier,max=64"`   // Source represents the source of the input data.
+	Data     map[string]interface{} `json:"data" validate:"required,maxbytes=1048576"`      // Data represents the input data.
 }
@@ -0,0 +1,65 @@
+package inputdto
+
+import (
+	"fmt"
+	"libs/golang/shared/go-criteria/criteria"
+	"net/url"
+	"strconv"
+)
+
+// SortFields lists the values of the sort parameter. A "-" prefix sorts in descending order.
+var SortFields = criteria.TimestampSortFields
+
+// InputFilterDTO represents the query parameters selecting the inputs to list and the page to read, such as
+// `?provider=acme&status=0&created_after=2024-01-01T00:00:00Z`. Every field is optional.
+type InputFilterDTO struct {
+	Provider           string `json:"provider"` // Provider of the inputs, any when empty.
+	Service            string `json:"service"`  // Service of the inputs, any when empty.
+	Source             string `json:"source"`   // Source of the inputs, any when empty.
+	Status             *int   `json:"status"`   // Status code of the inputs, any when nil.
+	criteria.ListQuery        // Creation time bounds and page of the inputs, sorted on one of SortFields.
+}
+
+// NewInputFilterDTO reads an InputFilterDTO from query parameters. The times are RFC 3339 timestamps.
+//
+// Parameters:
+//   - query: The query parameters of the request.
+//
+// Returns:
+//   - The filter read from the query parameters.
+//   - An error if the status is not an integer, a time is not an RFC 3339 timestamp, the limit is not a positive integer or the sort is not one of SortFields.
+func NewInputFilterDTO(query url.Values) (InputFilterDTO, error) {
+	filter := InputFilterDTO{
+		Provider: query.Get("provider"),
+		Service:  query.Get("service"),
+		Source:   query.Get("source"),
+	}
+	if value := query.Get("status"); value != "" {
+		status, err := strconv.Atoi(value)
+		if err != nil {
+			return InputFilterDTO{}, fmt.Errorf("invalid status %q: must be an integer", value)
+		}
+		filter.Status = &status
+	}
+	listQuery, err := criteria.NewListQuery(query, SortFields)
+	if err != nil {
+		return InputFilterDTO{}, err
+	}
+	filter.ListQuery = listQuery
+	return filter, nil
+}
+
+// Values writes the filter as query parameters, leaving out the fields that are not set.
+//
+// Returns:
+//   - The query parameters of the filter.
+func (f InputFilterDTO) Values() url.Values {
+	query := f.ListQuery.Values()
+	criteria.SetNotEmpty(query, "provider", f.Provider)
+	criteria.SetNotEmpty(query, "service", f.Service)
+	criteria.SetNotEmpty(query, "source", f.Source)
+	if f.Status != nil {
+		query.Set("status", strconv.Itoa(*f.Status))
+	}
+	return query
+}
@@ -13,3 +13,10 @@ type InputDTO struct {
 	CreatedAt string                 `json:"created_at"` // CreatedAt represents the timestamp when the input data was created.
 	UpdatedAt string                 `json:"updated_at"` // UpdatedAt represents the timestamp when the input data was last updated.
 }
+
+// InputPageDTO represents a page of inputs. The next page is read by sending NextCursor as the cursor of
+// the same query.
+type InputPageDTO struct {
+	Items      []InputDTO `json:"items"`                 // Items lists the inputs of the page.
+	NextCursor string     `json:"next_cursor,omitempty"` // NextCursor is the cursor of the next page, empty on the last page.
+}
@@ -0,0 +1,52 @@
+package inputdto
+
+import (
+	"libs/golang/shared/go-criteria/criteria"
+	"net/url"
+)
+
+// SortFields lists the values of the sort parameter. A "-" prefix sorts in descending order.
+var SortFields = criteria.TimestampSortFields
+
+// OutputFilterDTO represents the query parameters selecting the outputs to list and the page to read, such as
+// `?provider=acme&service=billing&created_after=2024-01-01T00:00:00Z`. Every field is optional.
+type OutputFilterDTO struct {
+	Provider           string `json:"provider"` // Provider of the outputs, any when empty.
+	Service            string `json:"service"`  // Service of the outputs, any when empty.
+	Source             string `json:"source"`   // Source of the outputs, any when empty.
+	criteria.ListQuery        // Creation time bounds and page of the outputs, sorted on one of SortFields.
+}
+
+// NewOutputFilterDTO reads an OutputFilterDTO from query parameters. The times are RFC 3339 timestamps.
+//
+// Parameters:
+//   - query: The query parameters of the request.
+//
+// Returns:
+//   - The filter read from the query parameters.
+//   - An error if a time is not an RFC 3339 timestamp, the limit is not a positive integer or the sort is not one of SortFields.
+func NewOutputFilterDTO(query url.Values) (OutputFilterDTO, error) {
+	filter := OutputFilterDTO{
+		Provider: query.Get("provider"),
+		Service:  query.Get("service"),
+		Source:   query.Get("source"),
+	}
+	listQuery, err := criteria.NewListQuery(query, SortFields)
+	if err != nil {
+		return OutputFilterDTO{}, err
+	}
+	filter.ListQuery = listQuery
+	return filter, nil
+}
+
+// Values writes the filter as query parameters, leaving out the fields that are not set.
+//
+// Returns:
+//   - The query parameters of the filter.
+func (f OutputFilterDTO) Values() url.Values {
+	query := f.ListQuery.Values()
+	criteria.SetNotEmpty(query, "provider", f.Provider)
+	criteria.SetNotEmpty(query, "service", f.Service)
+	criteria.SetNotEmpty(query, "source", f.Source)
+	return query
+}
@@ -17,3 +17,10 @@ type OutputDTO struct {
 	CreatedAt string                 `json:"created_at"` // CreatedAt is the timestamp when the output was created.
 	UpdatedAt string                 `json:"updated_at"` // UpdatedAt is the timestamp when the output was last updated.
 }
+
+// OutputPageDTO represents a page of outputs. The next page is read by sending NextCursor as the cursor of
+// the same query.
+type OutputPageDTO struct {
+	Items      []OutputDTO `json:"items"`                 // Items lists the outputs of the page.
+	NextCursor string      `json:"next_cursor,omitempty"` // NextCursor is the cursor of the next page, empty on the last page.
+}
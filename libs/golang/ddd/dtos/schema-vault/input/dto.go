@@ -8,17 +8,18 @@ import (
 // It includes the necessary details required for creating or updating
 // a schema, such as service details, source, provider, and JSON schema.
 type SchemaDTO struct {
-	Service    string                  `json:"service"`     // Service represents the name of the service for which the configuration is created.
-	Source     string                  `json:"source"`      // Source indicates the origin or source of the configuration.
-	Provider   string                  `json:"provider"`    // Provider specifies the provider of the configuration.
-	SchemaType string                  `json:"schema_type"` // SchemaType specifies the type of schema.
-	JsonSchema shareddto.JsonSchemaDTO `json:"json_schema"` // JsonSchemaDTO represents the JSON schema of the configuration.
+	Service        string                      `json:"service" validate:"required,identifier,max=64"`     // Service represents the name of the service for which the configuration is created.
+	Source         string                      `json:"source" validate:"required,identifier,max=64"`      // Source indicates the origin or source of the configuration.
+	Provider       string                      `json:"provider" validate:"required,identifier,max=64"`    // Provider specifies the provider of the configuration.
+	SchemaType     string                      `json:"schema_type" validate:"required,identifier,max=64"` // SchemaType specifies the type of schema.
+	JsonSchema     shareddto.JsonSchemaDTO     `json:"json_schema"`                                       // JsonSchemaDTO represents the JSON schema of the configuration.
+	Transformation []shareddto.FieldMappingDTO `json:"transformation,omitempty"`                          // Transformation reshapes the data to the schema, for the output schemas.
 }
 
 type SchemaDataDTO struct {
-	Service    string                 `json:"service"`     // Service represents the name of the service for which the configuration is created.
-	Source     string                 `json:"source"`      // Source indicates the origin or source of the configuration.
-	Provider   string                 `json:"provider"`    // Provider specifies the provider of the configuration.
-	SchemaType string                 `json:"schema_type"` // SchemaType specifies the type of schema.
-	Data       map[string]interface{} `json:"data"`        // Data represents the data of the respective schema type.
+	Service    string                 `json:"service" validate:"required,identifier,max=64"`     // Service represents the name of the service for which the configuration is created.
+	Source     string                 `json:"source" validate:"required,identifier,max=64"`      // Source indicates the origin or source of the configuration.
+	Provider   string                 `json:"provider" validate:"required,identifier,max=64"`    // Provider specifies the provider of the configuration.
+	SchemaType string                 `json:"schema_type" validate:"required,identifier,max=64"` // SchemaType specifies the type of schema.
+	Data       map[string]interface{} `json:"data" validate:"required,maxbytes=1048576"`         // Data represents the data of the respective schema type.
 }
@@ -0,0 +1,55 @@
+package inputdto
+
+import (
+	"libs/golang/shared/go-criteria/criteria"
+	"net/url"
+)
+
+// SortFields lists the values of the sort parameter. A "-" prefix sorts in descending order.
+var SortFields = criteria.TimestampSortFields
+
+// SchemaFilterDTO represents the query parameters selecting the schemas to list and the page to read, such as
+// `?provider=acme&schema_type=input`. Every field is optional.
+type SchemaFilterDTO struct {
+	Provider           string `json:"provider"`    // Provider of the schemas, any when empty.
+	Service            string `json:"service"`     // Service of the schemas, any when empty.
+	Source             string `json:"source"`      // Source of the schemas, any when empty.
+	SchemaType         string `json:"schema_type"` // Type of the schemas, such as "input" or "output", any when empty.
+	criteria.ListQuery        // Creation time bounds and page of the schemas, sorted on one of SortFields.
+}
+
+// NewSchemaFilterDTO reads a SchemaFilterDTO from query parameters. The times are RFC 3339 timestamps.
+//
+// Parameters:
+//   - query: The query parameters of the request.
+//
+// Returns:
+//   - The filter read from the query parameters.
+//   - An error if a time is not an RFC 3339 timestamp, the limit is not a positive integer or the sort is not one of SortFields.
+func NewSchemaFilterDTO(query url.Values) (SchemaFilterDTO, error) {
+	filter := SchemaFilterDTO{
+		Provider:   query.Get("provider"),
+		Service:    query.Get("service"),
+		Source:     query.Get("source"),
+		SchemaType: query.Get("schema_type"),
+	}
+	listQuery, err := criteria.NewListQuery(query, SortFields)
+	if err != nil {
+		return SchemaFilterDTO{}, err
+	}
+	filter.ListQuery = listQuery
+	return filter, nil
+}
+
+// Values writes the filter as query parameters, leaving out the fields that are not set.
+//
+// Returns:
+//   - The query parameters of the filter.
+func (f SchemaFilterDTO) Values() url.Values {
+	query := f.ListQuery.Values()
+	criteria.SetNotEmpty(query, "provider", f.Provider)
+	criteria.SetNotEmpty(query, "service", f.Service)
+	criteria.SetNotEmpty(query, "source", f.Source)
+	criteria.SetNotEmpty(query, "schema_type", f.SchemaType)
+	return query
+}
@@ -8,17 +8,25 @@ import (
 // It includes the necessary details required for fetching or displaying
 // a schema, such as service details, source, provider, and JSON schema.
 type SchemaDTO struct {
-	ID              string                  `json:"_id"`               // ID is the unique identifier of the Schema entity.
-	Service         string                  `json:"service"`           // Service represents the name of the service for which the configuration is created.
-	Source          string                  `json:"source"`            // Source indicates the origin or source of the configuration.
-	Provider        string                  `json:"provider"`          // Provider specifies the provider of the configuration.
-	SchemaType      string                  `json:"schema_type"`       // SchemaType specifies the type of schema.
-	JsonSchema      shareddto.JsonSchemaDTO `json:"json_schema"`       // JsonSchemaDTO represents the JSON schema of the configuration.
-	SchemaVersionID string                  `json:"schema_version_id"` // SchemaVersionID is the unique identifier of the schema version.
-	CreatedAt       string                  `json:"created_at"`        // CreatedAt is the timestamp when the Schema entity was created.
-	UpdatedAt       string                  `json:"updated_at"`        // UpdatedAt is the timestamp when the Schema entity was last updated.
+	ID              string                      `json:"_id"`                      // ID is the unique identifier of the Schema entity.
+	Service         string                      `json:"service"`                  // Service represents the name of the service for which the configuration is created.
+	Source          string                      `json:"source"`                   // Source indicates the origin or source of the configuration.
+	Provider        string                      `json:"provider"`                 // Provider specifies the provider of the configuration.
+	SchemaType      string                      `json:"schema_type"`              // SchemaType specifies the type of schema.
+	JsonSchema      shareddto.JsonSchemaDTO     `json:"json_schema"`              // JsonSchemaDTO represents the JSON schema of the configuration.
+	Transformation  []shareddto.FieldMappingDTO `json:"transformation,omitempty"` // Transformation reshapes the data to the schema, for the output schemas.
+	SchemaVersionID string                      `json:"schema_version_id"`        // SchemaVersionID is the unique identifier of the schema version.
+	CreatedAt       string                      `json:"created_at"`               // CreatedAt is the timestamp when the Schema entity was created.
+	UpdatedAt       string                      `json:"updated_at"`               // UpdatedAt is the timestamp when the Schema entity was last updated.
 }
 
 type SchemaValidationDTO struct {
 	Valid bool `json:"valid"`
 }
+
+// SchemaPageDTO represents a page of schemas. The next page is read by sending NextCursor as the cursor of
+// the same query.
+type SchemaPageDTO struct {
+	Items      []SchemaDTO `json:"items"`                 // Items lists the schemas of the page.
+	NextCursor string      `json:"next_cursor,omitempty"` // NextCursor is the cursor of the next page, empty on the last page.
+}
@@ -3,7 +3,16 @@ package shareddto
 // JsonSchemaDTO is a DTO that represents a JSON schema.
 // It includes the required fields, properties, and type of the JSON schema.
 type JsonSchemaDTO struct {
-	Required   []string               `json:"required"`   // Required lists the required fields in the JSON schema.
-	Properties map[string]interface{} `json:"properties"` // Properties lists the properties in the JSON schema.
-	JsonType   string                 `json:"type"`       // JsonType specifies the type of JSON schema.
+	Required   []string               `json:"required" validate:"required"` // Required lists the required fields in the JSON schema.
+	Properties map[string]interface{} `json:"properties"`                   // Properties lists the properties in the JSON schema.
+	JsonType   string                 `json:"type" validate:"required"`     // JsonType specifies the type of JSON schema.
+}
+
+// FieldMappingDTO is a DTO that represents how a field of the data is reshaped to the schema.
+// It includes the source and target paths of the field, the type it is cast to, and its default value.
+type FieldMappingDTO struct {
+	From    string      `json:"from,omitempty"`                                                // From is the dot-separated path of the field in the source data, To when empty.
+	To      string      `json:"to" validate:"required"`                                        // To is the dot-separated path of the field in the transformed data.
+	Type    string      `json:"type,omitempty" validate:"oneof=string integer number boolean"` // Type is the JSON type the value is cast to: string, integer, number or boolean.
+	Default interface{} `json:"default,omitempty"`                                             // Default is the value used when the source field is missing.
 }
@@ -0,0 +1,30 @@
+package event
+
+import "time"
+
+type OutputCreated struct {
+	Name    string
+	Payload interface{}
+}
+
+func NewOutputCreated() *OutputCreated {
+	return &OutputCreated{
+		Name: "OutputCreated",
+	}
+}
+
+func (e *OutputCreated) GetName() string {
+	return e.Name
+}
+
+func (e *OutputCreated) GetPayload() interface{} {
+	return e.Payload
+}
+
+func (e *OutputCreated) SetPayload(payload interface{}) {
+	e.Payload = payload
+}
+
+func (e *OutputCreated) GetDateTime() time.Time {
+	return time.Now()
+}
@@ -0,0 +1,3 @@
+module libs/golang/ddd/events/output-vault
+
+go 1.22
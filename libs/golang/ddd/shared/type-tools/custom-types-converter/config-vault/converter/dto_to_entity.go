@@ -2,7 +2,9 @@ package converter
 
 import (
 	"libs/golang/ddd/domain/entities/config-vault/entity"
+	inputdto "libs/golang/ddd/dtos/config-vault/input"
 	shareddto "libs/golang/ddd/dtos/config-vault/shared"
+	"libs/golang/shared/go-criteria/criteria"
 )
 
 // ConvertJobDependenciesDTOToEntity converts a slice of JobDependenciesDTO to a slice of JobDependencies entities.
@@ -78,3 +80,51 @@ func ConvertJobParametersDTOToMap(params shareddto.JobParametersDTO) map[string]
 	entityParams["parser_module"] = params.ParserModule
 	return entityParams
 }
+
+// ConvertConfigFilterDTOToEntity converts a ConfigFilterDTO to a ConfigFilter entity.
+// This function maps the ConfigFilterDTO fields to the corresponding ConfigFilter fields.
+//
+// Parameters:
+//
+//	filterDTO: An inputdto.ConfigFilterDTO to be converted.
+//
+// Returns:
+//
+//	An entity.ConfigFilter selecting the same configs.
+func ConvertConfigFilterDTOToEntity(filterDTO inputdto.ConfigFilterDTO) entity.ConfigFilter {
+	return entity.ConfigFilter{
+		Provider:      filterDTO.Provider,
+		Service:       filterDTO.Service,
+		Source:        filterDTO.Source,
+		Active:        filterDTO.Active,
+		CreatedAfter:  filterDTO.CreatedAfter,
+		CreatedBefore: filterDTO.CreatedBefore,
+	}
+}
+
+// ConvertConfigFilterDTOToPage converts the paging fields of a ConfigFilterDTO to a page of the query.
+// The configs are sorted by creation time when the sort is not set.
+//
+// Parameters:
+//
+//	filterDTO: An inputdto.ConfigFilterDTO to be converted.
+//
+// Returns:
+//
+//	A criteria.Page with the limit, cursor and sort of the filter, and an error wrapping criteria.ErrInvalidSort
+//	if the sort is not one of inputdto.SortFields.
+func ConvertConfigFilterDTOToPage(filterDTO inputdto.ConfigFilterDTO) (criteria.Page, error) {
+	sort := filterDTO.Sort
+	if sort == "" {
+		sort = "created_at"
+	}
+	pageSort, err := criteria.ParseSort(sort, inputdto.SortFields)
+	if err != nil {
+		return criteria.Page{}, err
+	}
+	return criteria.Page{
+		Limit:  filterDTO.Limit,
+		Cursor: filterDTO.Cursor,
+		Sort:   pageSort,
+	}, nil
+}
@@ -2,8 +2,11 @@ package converter
 
 import (
 	"libs/golang/ddd/domain/entities/config-vault/entity"
+	inputdto "libs/golang/ddd/dtos/config-vault/input"
 	shareddto "libs/golang/ddd/dtos/config-vault/shared"
+	"libs/golang/shared/go-criteria/criteria"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
@@ -158,3 +161,31 @@ func TestConvertJobParametersDTOToEntity(t *testing.T) {
 
 	assert.Equal(t, expected, entityParams)
 }
+
+func (suite *ConfigConverterDTOToEntitySuite) TestConvertConfigFilterDTOToEntity() {
+	active := true
+	createdAfter := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	filterDTO := inputdto.ConfigFilterDTO{Provider: "test_provider", Service: "test_service", Active: &active, ListQuery: criteria.ListQuery{CreatedAfter: createdAfter}}
+
+	expectedFilter := entity.ConfigFilter{Provider: "test_provider", Service: "test_service", Active: &active, CreatedAfter: createdAfter}
+
+	result := ConvertConfigFilterDTOToEntity(filterDTO)
+	assert.Equal(suite.T(), expectedFilter, result)
+}
+
+func (suite *ConfigConverterDTOToEntitySuite) TestConvertConfigFilterDTOToPage() {
+	filterDTO := inputdto.ConfigFilterDTO{Provider: "test_provider", ListQuery: criteria.ListQuery{Limit: 10, Cursor: "cursor", Sort: "-updated_at"}}
+
+	expectedPage := criteria.Page{Limit: 10, Cursor: "cursor", Sort: criteria.Sort{Field: "updated_at", Order: criteria.Descending}}
+
+	result, err := ConvertConfigFilterDTOToPage(filterDTO)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expectedPage, result)
+
+	result, err = ConvertConfigFilterDTOToPage(inputdto.ConfigFilterDTO{})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), criteria.NewSort("created_at"), result.Sort)
+
+	_, err = ConvertConfigFilterDTOToPage(inputdto.ConfigFilterDTO{ListQuery: criteria.ListQuery{Sort: "provider"}})
+	assert.ErrorIs(suite.T(), err, criteria.ErrInvalidSort)
+}
@@ -2,7 +2,9 @@ package converter
 
 import (
 	"libs/golang/ddd/domain/entities/input-broker/entity"
+	inputdto "libs/golang/ddd/dtos/input-broker/input"
 	shareddto "libs/golang/ddd/dtos/input-broker/shared"
+	"libs/golang/shared/go-criteria/criteria"
 )
 
 // ConvertMetadataDTOToEntity converts a MetadataDTO to a Metadata entity.
@@ -41,3 +43,51 @@ func ConvertStatusDTOToEntity(statusDTO shareddto.StatusDTO) entity.Status {
 		Detail: statusDTO.Detail,
 	}
 }
+
+// ConvertInputFilterDTOToEntity converts a InputFilterDTO to a InputFilter entity.
+// This function maps the InputFilterDTO fields to the corresponding InputFilter fields.
+//
+// Parameters:
+//
+//	filterDTO: An inputdto.InputFilterDTO to be converted.
+//
+// Returns:
+//
+//	An entity.InputFilter selecting the same inputs.
+func ConvertInputFilterDTOToEntity(filterDTO inputdto.InputFilterDTO) entity.InputFilter {
+	return entity.InputFilter{
+		Provider:      filterDTO.Provider,
+		Service:       filterDTO.Service,
+		Source:        filterDTO.Source,
+		Status:        filterDTO.Status,
+		CreatedAfter:  filterDTO.CreatedAfter,
+		CreatedBefore: filterDTO.CreatedBefore,
+	}
+}
+
+// ConvertInputFilterDTOToPage converts the paging fields of an InputFilterDTO to a page of the query.
+// The inputs are sorted by creation time when the sort is not set.
+//
+// Parameters:
+//
+//	filterDTO: An inputdto.InputFilterDTO to be converted.
+//
+// Returns:
+//
+//	A criteria.Page with the limit, cursor and sort of the filter, and an error wrapping criteria.ErrInvalidSort
+//	if the sort is not one of inputdto.SortFields.
+func ConvertInputFilterDTOToPage(filterDTO inputdto.InputFilterDTO) (criteria.Page, error) {
+	sort := filterDTO.Sort
+	if sort == "" {
+		sort = "created_at"
+	}
+	pageSort, err := criteria.ParseSort(sort, inputdto.SortFields)
+	if err != nil {
+		return criteria.Page{}, err
+	}
+	return criteria.Page{
+		Limit:  filterDTO.Limit,
+		Cursor: filterDTO.Cursor,
+		Sort:   pageSort,
+	}, nil
+}
@@ -2,8 +2,11 @@ package converter
 
 import (
 	"libs/golang/ddd/domain/entities/input-broker/entity"
+	inputdto "libs/golang/ddd/dtos/input-broker/input"
 	shareddto "libs/golang/ddd/dtos/input-broker/shared"
+	"libs/golang/shared/go-criteria/criteria"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
@@ -52,3 +55,31 @@ func (suite *InputConverterDTOToEntitySuite) TestConvertStatusDTOToEntity() {
 	result := ConvertStatusDTOToEntity(statusDTO)
 	assert.Equal(suite.T(), expectedStatus, result)
 }
+
+func (suite *InputConverterDTOToEntitySuite) TestConvertInputFilterDTOToEntity() {
+	status := 1
+	createdAfter := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	filterDTO := inputdto.InputFilterDTO{Provider: "test_provider", Service: "test_service", Status: &status, ListQuery: criteria.ListQuery{CreatedAfter: createdAfter}}
+
+	expectedFilter := entity.InputFilter{Provider: "test_provider", Service: "test_service", Status: &status, CreatedAfter: createdAfter}
+
+	result := ConvertInputFilterDTOToEntity(filterDTO)
+	assert.Equal(suite.T(), expectedFilter, result)
+}
+
+func (suite *InputConverterDTOToEntitySuite) TestConvertInputFilterDTOToPage() {
+	filterDTO := inputdto.InputFilterDTO{Provider: "test_provider", ListQuery: criteria.ListQuery{Limit: 10, Cursor: "cursor", Sort: "-updated_at"}}
+
+	expectedPage := criteria.Page{Limit: 10, Cursor: "cursor", Sort: criteria.Sort{Field: "updated_at", Order: criteria.Descending}}
+
+	result, err := ConvertInputFilterDTOToPage(filterDTO)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expectedPage, result)
+
+	result, err = ConvertInputFilterDTOToPage(inputdto.InputFilterDTO{})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), criteria.NewSort("created_at"), result.Sort)
+
+	_, err = ConvertInputFilterDTOToPage(inputdto.InputFilterDTO{ListQuery: criteria.ListQuery{Sort: "provider"}})
+	assert.ErrorIs(suite.T(), err, criteria.ErrInvalidSort)
+}
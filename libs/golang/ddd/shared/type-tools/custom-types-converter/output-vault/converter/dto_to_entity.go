@@ -2,7 +2,9 @@ package converter
 
 import (
 	"libs/golang/ddd/domain/entities/output-vault/entity"
+	inputdto "libs/golang/ddd/dtos/output-vault/input"
 	shareddto "libs/golang/ddd/dtos/output-vault/shared"
+	"libs/golang/shared/go-criteria/criteria"
 )
 
 // ConvertOutputDTOToEntity converts an OutputDTO to an Output entity.
@@ -30,3 +32,50 @@ func ConvertMetadataDTOToMap(metadataDTO shareddto.MetadataDTO) map[string]inter
 		},
 	}
 }
+
+// ConvertOutputFilterDTOToEntity converts a OutputFilterDTO to a OutputFilter entity.
+// This function maps the OutputFilterDTO fields to the corresponding OutputFilter fields.
+//
+// Parameters:
+//
+//	filterDTO: An inputdto.OutputFilterDTO to be converted.
+//
+// Returns:
+//
+//	An entity.OutputFilter selecting the same outputs.
+func ConvertOutputFilterDTOToEntity(filterDTO inputdto.OutputFilterDTO) entity.OutputFilter {
+	return entity.OutputFilter{
+		Provider:      filterDTO.Provider,
+		Service:       filterDTO.Service,
+		Source:        filterDTO.Source,
+		CreatedAfter:  filterDTO.CreatedAfter,
+		CreatedBefore: filterDTO.CreatedBefore,
+	}
+}
+
+// ConvertOutputFilterDTOToPage converts the paging fields of an OutputFilterDTO to a page of the query.
+// The outputs are sorted by creation time when the sort is not set.
+//
+// Parameters:
+//
+//	filterDTO: An inputdto.OutputFilterDTO to be converted.
+//
+// Returns:
+//
+//	A criteria.Page with the limit, cursor and sort of the filter, and an error wrapping criteria.ErrInvalidSort
+//	if the sort is not one of inputdto.SortFields.
+func ConvertOutputFilterDTOToPage(filterDTO inputdto.OutputFilterDTO) (criteria.Page, error) {
+	sort := filterDTO.Sort
+	if sort == "" {
+		sort = "created_at"
+	}
+	pageSort, err := criteria.ParseSort(sort, inputdto.SortFields)
+	if err != nil {
+		return criteria.Page{}, err
+	}
+	return criteria.Page{
+		Limit:  filterDTO.Limit,
+		Cursor: filterDTO.Cursor,
+		Sort:   pageSort,
+	}, nil
+}
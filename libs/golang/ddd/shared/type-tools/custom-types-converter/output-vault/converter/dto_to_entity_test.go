@@ -2,8 +2,11 @@ package converter
 
 import (
 	"libs/golang/ddd/domain/entities/output-vault/entity"
+	inputdto "libs/golang/ddd/dtos/output-vault/input"
 	shareddto "libs/golang/ddd/dtos/output-vault/shared"
+	"libs/golang/shared/go-criteria/criteria"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
@@ -64,3 +67,30 @@ func (suite *OutputConverterDTOToEntitySuite) TestConvertMetadataDTOToMap() {
 
 	assert.Equal(suite.T(), expected, entityMetadata)
 }
+
+func (suite *OutputConverterDTOToEntitySuite) TestConvertOutputFilterDTOToEntity() {
+	createdAfter := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	filterDTO := inputdto.OutputFilterDTO{Provider: "test_provider", Service: "test_service", ListQuery: criteria.ListQuery{CreatedAfter: createdAfter}}
+
+	expectedFilter := entity.OutputFilter{Provider: "test_provider", Service: "test_service", CreatedAfter: createdAfter}
+
+	result := ConvertOutputFilterDTOToEntity(filterDTO)
+	assert.Equal(suite.T(), expectedFilter, result)
+}
+
+func (suite *OutputConverterDTOToEntitySuite) TestConvertOutputFilterDTOToPage() {
+	filterDTO := inputdto.OutputFilterDTO{Provider: "test_provider", ListQuery: criteria.ListQuery{Limit: 10, Cursor: "cursor", Sort: "-updated_at"}}
+
+	expectedPage := criteria.Page{Limit: 10, Cursor: "cursor", Sort: criteria.Sort{Field: "updated_at", Order: criteria.Descending}}
+
+	result, err := ConvertOutputFilterDTOToPage(filterDTO)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expectedPage, result)
+
+	result, err = ConvertOutputFilterDTOToPage(inputdto.OutputFilterDTO{})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), criteria.NewSort("created_at"), result.Sort)
+
+	_, err = ConvertOutputFilterDTOToPage(inputdto.OutputFilterDTO{ListQuery: criteria.ListQuery{Sort: "provider"}})
+	assert.ErrorIs(suite.T(), err, criteria.ErrInvalidSort)
+}
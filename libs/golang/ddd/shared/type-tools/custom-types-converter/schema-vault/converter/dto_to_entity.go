@@ -2,7 +2,9 @@ package converter
 
 import (
 	"libs/golang/ddd/domain/entities/schema-vault/entity"
+	inputdto "libs/golang/ddd/dtos/schema-vault/input"
 	shareddto "libs/golang/ddd/dtos/schema-vault/shared"
+	"libs/golang/shared/go-criteria/criteria"
 )
 
 // ConvertJsonSchemaDTOToEntity converts a JsonSchemaDTO DTO to a JsonSchema entity.
@@ -44,3 +46,77 @@ func ConvertJsonSchemaDTOToMap(jsonSchemaDTO shareddto.JsonSchemaDTO) map[string
 		"type":       jsonSchemaDTO.JsonType,
 	}
 }
+
+// ConvertFieldMappingsDTOToEntity converts FieldMappingDTO DTOs to FieldMapping entities.
+// This function maps the fields of each FieldMappingDTO DTO to the corresponding FieldMapping entity fields.
+//
+// Parameters:
+//
+//	fieldMappingsDTO: The shareddto.FieldMappingDTO slice to be converted.
+//
+// Returns:
+//
+//	An entity.FieldMapping slice containing the converted data, nil when there is no mapping.
+func ConvertFieldMappingsDTOToEntity(fieldMappingsDTO []shareddto.FieldMappingDTO) []entity.FieldMapping {
+	if len(fieldMappingsDTO) == 0 {
+		return nil
+	}
+	fieldMappings := make([]entity.FieldMapping, len(fieldMappingsDTO))
+	for i, fieldMapping := range fieldMappingsDTO {
+		fieldMappings[i] = entity.FieldMapping{
+			From:    fieldMapping.From,
+			To:      fieldMapping.To,
+			Type:    fieldMapping.Type,
+			Default: fieldMapping.Default,
+		}
+	}
+	return fieldMappings
+}
+
+// ConvertSchemaFilterDTOToEntity converts a SchemaFilterDTO to a SchemaFilter entity.
+// This function maps the SchemaFilterDTO fields to the corresponding SchemaFilter fields.
+//
+// Parameters:
+//
+//	filterDTO: An inputdto.SchemaFilterDTO to be converted.
+//
+// Returns:
+//
+//	An entity.SchemaFilter selecting the same schemas.
+func ConvertSchemaFilterDTOToEntity(filterDTO inputdto.SchemaFilterDTO) entity.SchemaFilter {
+	return entity.SchemaFilter{
+		Provider:      filterDTO.Provider,
+		Service:       filterDTO.Service,
+		Source:        filterDTO.Source,
+		SchemaType:    filterDTO.SchemaType,
+		CreatedAfter:  filterDTO.CreatedAfter,
+		CreatedBefore: filterDTO.CreatedBefore,
+	}
+}
+
+// ConvertSchemaFilterDTOToPage converts the paging fields of a SchemaFilterDTO to a page of the query.
+// The schemas are sorted by creation time when the sort is not set.
+//
+// Parameters:
+//
+//	filterDTO: An inputdto.SchemaFilterDTO to be converted.
+//
+// Returns:
+//
+//	A criteria.Page with the limit, cursor and sort of the filter, and an error wrapping criteria.ErrInvalidSort
+//	if the sort is not one of inputdto.SortFields.
+func ConvertSchemaFilterDTOToPage(filterDTO inputdto.SchemaFilterDTO) (criteria.Page, error) {
+	sort := filterDTO.Sort
+	if sort == "" {
+		sort = "created_at"
+	}
+	pageSort, err := criteria.ParseSort(sort, inputdto.SortFields)
+	if err != nil {
+		return criteria.Page{}, err
+	}
+	return criteria.Page{
+		Limit:  filterDTO.Limit,
+		Cursor: filterDTO.Cursor,
+		Sort:   pageSort,
+	}, nil
+}
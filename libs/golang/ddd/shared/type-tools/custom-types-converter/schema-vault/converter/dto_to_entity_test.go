@@ -2,8 +2,11 @@ package converter
 
 import (
 	"libs/golang/ddd/domain/entities/schema-vault/entity"
+	inputdto "libs/golang/ddd/dtos/schema-vault/input"
 	shareddto "libs/golang/ddd/dtos/schema-vault/shared"
+	"libs/golang/shared/go-criteria/criteria"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
@@ -61,3 +64,45 @@ func (s *SchemaConverterDTOToEntitySuite) TestConvertJsonSchemaDTOToMap() {
 	assert.Equal(s.T(), expected["required"], jsonSchemaMap["required"])
 	assert.Equal(s.T(), expected["properties"], jsonSchemaMap["properties"])
 }
+
+func (suite *SchemaConverterDTOToEntitySuite) TestConvertFieldMappingsDTOToEntity() {
+	fieldMappingsDTO := []shareddto.FieldMappingDTO{
+		{From: "customer.id", To: "customer_id", Type: "integer"},
+		{To: "country", Default: "BR"},
+	}
+
+	expected := []entity.FieldMapping{
+		{From: "customer.id", To: "customer_id", Type: "integer"},
+		{To: "country", Default: "BR"},
+	}
+
+	assert.Equal(suite.T(), expected, ConvertFieldMappingsDTOToEntity(fieldMappingsDTO))
+	assert.Nil(suite.T(), ConvertFieldMappingsDTOToEntity(nil))
+}
+
+func (suite *SchemaConverterDTOToEntitySuite) TestConvertSchemaFilterDTOToEntity() {
+	createdAfter := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	filterDTO := inputdto.SchemaFilterDTO{Provider: "test_provider", Service: "test_service", SchemaType: "input", ListQuery: criteria.ListQuery{CreatedAfter: createdAfter}}
+
+	expectedFilter := entity.SchemaFilter{Provider: "test_provider", Service: "test_service", SchemaType: "input", CreatedAfter: createdAfter}
+
+	result := ConvertSchemaFilterDTOToEntity(filterDTO)
+	assert.Equal(suite.T(), expectedFilter, result)
+}
+
+func (suite *SchemaConverterDTOToEntitySuite) TestConvertSchemaFilterDTOToPage() {
+	filterDTO := inputdto.SchemaFilterDTO{Provider: "test_provider", ListQuery: criteria.ListQuery{Limit: 10, Cursor: "cursor", Sort: "-updated_at"}}
+
+	expectedPage := criteria.Page{Limit: 10, Cursor: "cursor", Sort: criteria.Sort{Field: "updated_at", Order: criteria.Descending}}
+
+	result, err := ConvertSchemaFilterDTOToPage(filterDTO)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expectedPage, result)
+
+	result, err = ConvertSchemaFilterDTOToPage(inputdto.SchemaFilterDTO{})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), criteria.NewSort("created_at"), result.Sort)
+
+	_, err = ConvertSchemaFilterDTOToPage(inputdto.SchemaFilterDTO{ListQuery: criteria.ListQuery{Sort: "provider"}})
+	assert.ErrorIs(suite.T(), err, criteria.ErrInvalidSort)
+}
@@ -22,3 +22,29 @@ func ConvertJsonSchemaEntityToDTO(jsonSchema entity.JsonSchema) shareddto.JsonSc
 		JsonType:   jsonSchema.JsonType,
 	}
 }
+
+// ConvertFieldMappingsEntityToDTO converts FieldMapping entities to FieldMappingDTO DTOs.
+// This function maps the fields of each FieldMapping entity to the corresponding FieldMappingDTO DTO fields.
+//
+// Parameters:
+//
+//	fieldMappings: The entity.FieldMapping slice to be converted.
+//
+// Returns:
+//
+//	A shareddto.FieldMappingDTO slice containing the converted data, nil when there is no mapping.
+func ConvertFieldMappingsEntityToDTO(fieldMappings []entity.FieldMapping) []shareddto.FieldMappingDTO {
+	if len(fieldMappings) == 0 {
+		return nil
+	}
+	fieldMappingsDTO := make([]shareddto.FieldMappingDTO, len(fieldMappings))
+	for i, fieldMapping := range fieldMappings {
+		fieldMappingsDTO[i] = shareddto.FieldMappingDTO{
+			From:    fieldMapping.From,
+			To:      fieldMapping.To,
+			Type:    fieldMapping.Type,
+			Default: fieldMapping.Default,
+		}
+	}
+	return fieldMappingsDTO
+}
@@ -35,3 +35,18 @@ func (suite *SchemaConverterEntityToDTOSuite) TestConvertJsonSchemaEntityToDTO()
 	suite.Equal(expected.Required, dtoJsonSchema.Required)
 	suite.Equal(expected.Properties, dtoJsonSchema.Properties)
 }
+
+func (suite *SchemaConverterEntityToDTOSuite) TestConvertFieldMappingsEntityToDTO() {
+	fieldMappings := []entity.FieldMapping{
+		{From: "customer.id", To: "customer_id", Type: "integer"},
+		{To: "country", Default: "BR"},
+	}
+
+	expected := []shareddto.FieldMappingDTO{
+		{From: "customer.id", To: "customer_id", Type: "integer"},
+		{To: "country", Default: "BR"},
+	}
+
+	suite.Equal(expected, ConvertFieldMappingsEntityToDTO(fieldMappings))
+	suite.Nil(ConvertFieldMappingsEntityToDTO(nil))
+}
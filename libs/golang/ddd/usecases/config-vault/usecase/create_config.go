@@ -31,7 +31,8 @@ func NewCreateConfigUseCase(
 }
 
 // Execute creates a new configuration entity based on the provided input DTO and saves it using the repository.
-// It then converts the created entity to an output DTO and returns it.
+// It then converts the created entity to an output DTO and returns it. A configuration whose dependencies would
+// close a cycle in the job graph of its provider is rejected with an error wrapping entity.ErrDependencyCycle.
 //
 // Parameters:
 //
@@ -57,6 +58,11 @@ func (uc *CreateConfigUseCase) Execute(input inputdto.ConfigDTO) (outputdto.Conf
 		return outputdto.ConfigDTO{}, err
 	}
 
+	err = checkDependencyCycle(uc.ConfigRepository, entityConfig)
+	if err != nil {
+		return outputdto.ConfigDTO{}, err
+	}
+
 	err = uc.ConfigRepository.Create(entityConfig)
 	if err != nil {
 		return outputdto.ConfigDTO{}, err
@@ -84,3 +90,21 @@ func (uc *CreateConfigUseCase) Execute(input inputdto.ConfigDTO) (outputdto.Conf
 
 	return dto, nil
 }
+
+// checkDependencyCycle checks that saving a configuration keeps the job graph of its provider acyclic.
+//
+// Parameters:
+//
+//	configRepository: The repository holding the configurations of the provider.
+//	config: The configuration to save.
+//
+// Returns:
+//
+//	An error wrapping entity.ErrDependencyCycle if the configuration closes a cycle, or an error if the configurations cannot be listed.
+func checkDependencyCycle(configRepository entity.ConfigRepositoryInterface, config *entity.Config) error {
+	configs, err := configRepository.FindAllByFilter(entity.ConfigFilter{Provider: config.Provider})
+	if err != nil {
+		return err
+	}
+	return entity.CheckDependencyCycle(config, configs)
+}
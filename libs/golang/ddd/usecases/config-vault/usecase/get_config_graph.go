@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"libs/golang/ddd/domain/entities/config-vault/entity"
+	outputdto "libs/golang/ddd/dtos/config-vault/output"
+	shareddto "libs/golang/ddd/dtos/config-vault/shared"
+)
+
+// GetConfigGraphUseCase is the use case for building the dependency graph of the configurations of a provider.
+type GetConfigGraphUseCase struct {
+	ConfigRepository entity.ConfigRepositoryInterface
+}
+
+// NewGetConfigGraphUseCase initializes a new instance of GetConfigGraphUseCase with the provided ConfigRepositoryInterface.
+//
+// Parameters:
+//
+//	configRepository: The repository interface for managing Config entities.
+//
+// Returns:
+//
+//	A pointer to an instance of GetConfigGraphUseCase.
+func NewGetConfigGraphUseCase(
+	configRepository entity.ConfigRepositoryInterface,
+) *GetConfigGraphUseCase {
+	return &GetConfigGraphUseCase{
+		ConfigRepository: configRepository,
+	}
+}
+
+// Execute builds the job graph of a provider from the DependsOn of its configurations.
+// Configurations stored before cycles were rejected may still form one: it is then reported in Cycle and Order is empty.
+//
+// Parameters:
+//
+//	provider: The provider name of the graph.
+//
+// Returns:
+//
+//	An output DTO containing the nodes, edges and dependency order of the graph, and an error if the configurations cannot be listed.
+func (uc *GetConfigGraphUseCase) Execute(provider string) (outputdto.ConfigGraphDTO, error) {
+	configs, err := uc.ConfigRepository.FindAllByFilter(entity.ConfigFilter{Provider: provider})
+	if err != nil {
+		return outputdto.ConfigGraphDTO{}, err
+	}
+
+	graph := entity.NewConfigGraph(provider, configs)
+	dto := outputdto.ConfigGraphDTO{
+		Provider: provider,
+		Nodes:    make([]outputdto.ConfigGraphNodeDTO, 0, len(graph.Nodes())),
+		Edges:    []outputdto.ConfigGraphEdgeDTO{},
+		Order:    []shareddto.JobDependenciesDTO{},
+	}
+
+	for _, key := range graph.Nodes() {
+		node := outputdto.ConfigGraphNodeDTO{Service: key.Service, Source: key.Source}
+		if config := graph.Config(key); config != nil {
+			node.ConfigID = string(config.ID)
+			node.Active = config.Active
+		}
+		dto.Nodes = append(dto.Nodes, node)
+
+		for _, dependent := range graph.Dependents(key) {
+			dto.Edges = append(dto.Edges, outputdto.ConfigGraphEdgeDTO{
+				From: jobKeyToDTO(key),
+				To:   jobKeyToDTO(dependent),
+			})
+		}
+	}
+
+	if cycle := graph.FindCycle(); cycle != nil {
+		dto.Cycle = jobKeysToDTO(cycle)
+		return dto, nil
+	}
+	order, err := graph.TopologicalOrder()
+	if err != nil {
+		return outputdto.ConfigGraphDTO{}, err
+	}
+	dto.Order = jobKeysToDTO(order)
+	return dto, nil
+}
+
+// jobKeyToDTO converts a job key to a JobDependenciesDTO.
+func jobKeyToDTO(key entity.JobKey) shareddto.JobDependenciesDTO {
+	return shareddto.JobDependenciesDTO{Service: key.Service, Source: key.Source}
+}
+
+// jobKeysToDTO converts job keys to JobDependenciesDTOs.
+func jobKeysToDTO(keys []entity.JobKey) []shareddto.JobDependenciesDTO {
+	dtos := make([]shareddto.JobDependenciesDTO, len(keys))
+	for i, key := range keys {
+		dtos[i] = jobKeyToDTO(key)
+	}
+	return dtos
+}
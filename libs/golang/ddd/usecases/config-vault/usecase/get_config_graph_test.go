@@ -0,0 +1,80 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	"libs/golang/ddd/domain/entities/config-vault/entity"
+	mockrepository "libs/golang/ddd/domain/repositories/database/mock/config-vault/repository"
+	outputdto "libs/golang/ddd/dtos/config-vault/output"
+	shareddto "libs/golang/ddd/dtos/config-vault/shared"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type GetConfigGraphUseCaseSuite struct {
+	suite.Suite
+	repoMock *mockrepository.ConfigRepositoryMock
+	useCase  *GetConfigGraphUseCase
+}
+
+func TestGetConfigGraphUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(GetConfigGraphUseCaseSuite))
+}
+
+func (suite *GetConfigGraphUseCaseSuite) SetupTest() {
+	suite.repoMock = new(mockrepository.ConfigRepositoryMock)
+	suite.useCase = NewGetConfigGraphUseCase(suite.repoMock)
+}
+
+func (suite *GetConfigGraphUseCaseSuite) TestExecuteWhenSuccess() {
+	suite.repoMock.On("FindAllByFilter", entity.ConfigFilter{Provider: "test_provider"}).Return([]*entity.Config{
+		{
+			ID:        "clean",
+			Active:    true,
+			Service:   "cleaner",
+			Source:    "clean",
+			Provider:  "test_provider",
+			DependsOn: []entity.JobDependencies{{Service: "crawler", Source: "raw"}},
+		},
+	}, nil)
+
+	output, err := suite.useCase.Execute("test_provider")
+
+	raw := shareddto.JobDependenciesDTO{Service: "crawler", Source: "raw"}
+	clean := shareddto.JobDependenciesDTO{Service: "cleaner", Source: "clean"}
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), outputdto.ConfigGraphDTO{
+		Provider: "test_provider",
+		Nodes: []outputdto.ConfigGraphNodeDTO{
+			{Service: "cleaner", Source: "clean", ConfigID: "clean", Active: true},
+			{Service: "crawler", Source: "raw"},
+		},
+		Edges: []outputdto.ConfigGraphEdgeDTO{{From: raw, To: clean}},
+		Order: []shareddto.JobDependenciesDTO{raw, clean},
+	}, output)
+}
+
+func (suite *GetConfigGraphUseCaseSuite) TestExecuteWhenCycleIsStored() {
+	suite.repoMock.On("FindAllByFilter", entity.ConfigFilter{Provider: "test_provider"}).Return([]*entity.Config{
+		{ID: "a", Service: "a", Source: "a", Provider: "test_provider", DependsOn: []entity.JobDependencies{{Service: "b", Source: "b"}}},
+		{ID: "b", Service: "b", Source: "b", Provider: "test_provider", DependsOn: []entity.JobDependencies{{Service: "a", Source: "a"}}},
+	}, nil)
+
+	output, err := suite.useCase.Execute("test_provider")
+
+	assert.Nil(suite.T(), err)
+	assert.Empty(suite.T(), output.Order)
+	assert.Equal(suite.T(), 3, len(output.Cycle))
+	assert.Equal(suite.T(), 2, len(output.Edges))
+}
+
+func (suite *GetConfigGraphUseCaseSuite) TestExecuteError() {
+	suite.repoMock.On("FindAllByFilter", entity.ConfigFilter{Provider: "test_provider"}).Return(nil, errors.New("database error"))
+
+	output, err := suite.useCase.Execute("test_provider")
+
+	assert.NotNil(suite.T(), err)
+	assert.Equal(suite.T(), outputdto.ConfigGraphDTO{}, output)
+}
@@ -2,16 +2,17 @@ package usecase
 
 import (
 	"libs/golang/ddd/domain/entities/config-vault/entity"
+	inputdto "libs/golang/ddd/dtos/config-vault/input"
 	outputdto "libs/golang/ddd/dtos/config-vault/output"
 	"libs/golang/ddd/shared/type-tools/custom-types-converter/config-vault/converter"
 )
 
-// ListAllByServiceAndProviderConfigUseCase is the use case for listing all configurations by service.
-type ListAllByServiceAndProviderConfigUseCase struct {
+// ListPageByFilterConfigUseCase is the use case for listing a page of the configs selected by a filter.
+type ListPageByFilterConfigUseCase struct {
 	ConfigRepository entity.ConfigRepositoryInterface
 }
 
-// NewListAllByServiceConfigUseCase initializes a new instance of ListAllByServiceAndProviderConfigUseCase with the provided ConfigRepositoryInterface.
+// NewListPageByFilterConfigUseCase initializes a new instance of ListPageByFilterConfigUseCase with the provided ConfigRepositoryInterface.
 //
 // Parameters:
 //
@@ -19,33 +20,39 @@ type ListAllByServiceAndProviderConfigUseCase struct {
 //
 // Returns:
 //
-//	A pointer to an instance of ListAllByServiceAndProviderConfigUseCase.
-func NewListAllByServiceAndProviderConfigUseCase(
+//	A pointer to an instance of ListPageByFilterConfigUseCase.
+func NewListPageByFilterConfigUseCase(
 	configRepository entity.ConfigRepositoryInterface,
-) *ListAllByServiceAndProviderConfigUseCase {
-	return &ListAllByServiceAndProviderConfigUseCase{
+) *ListPageByFilterConfigUseCase {
+	return &ListPageByFilterConfigUseCase{
 		ConfigRepository: configRepository,
 	}
 }
 
-// Execute retrieves all configurations by service from the repository.
+// Execute retrieves the configs selected by the filter from the repository and converts them to output DTOs.
+// An empty filter selects every config.
 //
 // Parameters:
 //
-//	provider: The provider name to filter configurations by.
-//	service: The service name to filter configurations by.
+//	filterDTO: The filter selecting the configs and the page.
 //
 // Returns:
 //
 //	A slice of output DTOs containing the configuration data, and an error if any occurred during the process.
-func (uc *ListAllByServiceAndProviderConfigUseCase) Execute(provider, service string) ([]outputdto.ConfigDTO, error) {
-	configs, err := uc.ConfigRepository.FindAllByServiceAndProvider(provider, service)
+func (uc *ListPageByFilterConfigUseCase) Execute(filterDTO inputdto.ConfigFilterDTO) (outputdto.ConfigPageDTO, error) {
+	page, err := converter.ConvertConfigFilterDTOToPage(filterDTO)
 	if err != nil {
-		return []outputdto.ConfigDTO{}, err
+		return outputdto.ConfigPageDTO{}, err
+	}
+
+	configs, next, err := uc.ConfigRepository.FindPageByFilter(converter.ConvertConfigFilterDTOToEntity(filterDTO), page)
+	if err != nil {
+		return outputdto.ConfigPageDTO{}, err
 	}
 
 	configDTOs := make([]outputdto.ConfigDTO, 0, len(configs))
 	for _, config := range configs {
+
 		configDTOs = append(configDTOs, outputdto.ConfigDTO{
 			ID:              string(config.ID),
 			Active:          config.Active,
@@ -60,5 +67,5 @@ func (uc *ListAllByServiceAndProviderConfigUseCase) Execute(provider, service st
 		})
 	}
 
-	return configDTOs, nil
+	return outputdto.ConfigPageDTO{Items: configDTOs, NextCursor: next}, nil
 }
@@ -6,29 +6,32 @@ import (
 
 	"libs/golang/ddd/domain/entities/config-vault/entity"
 	mockrepository "libs/golang/ddd/domain/repositories/database/mock/config-vault/repository"
+	inputdto "libs/golang/ddd/dtos/config-vault/input"
 	outputdto "libs/golang/ddd/dtos/config-vault/output"
 	shareddto "libs/golang/ddd/dtos/config-vault/shared"
+	"libs/golang/shared/go-criteria/criteria"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
 
-type ListAllConfigUseCaseSuite struct {
+type ListPageByFilterConfigUseCaseSuite struct {
 	suite.Suite
 	repoMock *mockrepository.ConfigRepositoryMock
-	useCase  *ListAllConfigUseCase
+	useCase  *ListPageByFilterConfigUseCase
 }
 
-func TestListAllConfigUseCaseSuite(t *testing.T) {
-	suite.Run(t, new(ListAllConfigUseCaseSuite))
+func TestListPageByFilterConfigUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(ListPageByFilterConfigUseCaseSuite))
 }
 
-func (suite *ListAllConfigUseCaseSuite) SetupTest() {
+func (suite *ListPageByFilterConfigUseCaseSuite) SetupTest() {
 	suite.repoMock = new(mockrepository.ConfigRepositoryMock)
-	suite.useCase = NewListAllConfigUseCase(suite.repoMock)
+	suite.useCase = NewListPageByFilterConfigUseCase(suite.repoMock)
 }
 
-func (suite *ListAllConfigUseCaseSuite) TestExecutewhenSuccess() {
+func (suite *ListPageByFilterConfigUseCaseSuite) TestExecutewhenSuccess() {
 	entityConfigs := []*entity.Config{
 		{
 			ID:              "1",
@@ -64,7 +67,8 @@ func (suite *ListAllConfigUseCaseSuite) TestExecutewhenSuccess() {
 		},
 	}
 
-	suite.repoMock.On("FindAll").Return(entityConfigs, nil)
+	active := true
+	suite.repoMock.On("FindPageByFilter", entity.ConfigFilter{Provider: "test_provider", Active: &active}, criteria.Page{Sort: criteria.NewSort("created_at")}).Return(entityConfigs, "next", nil)
 
 	expectedOutput := []outputdto.ConfigDTO{
 		{
@@ -101,19 +105,28 @@ func (suite *ListAllConfigUseCaseSuite) TestExecutewhenSuccess() {
 		},
 	}
 
-	output, err := suite.useCase.Execute()
+	output, err := suite.useCase.Execute(inputdto.ConfigFilterDTO{Provider: "test_provider", Active: &active})
 
 	assert.Nil(suite.T(), err)
-	assert.Equal(suite.T(), expectedOutput, output)
+	assert.Equal(suite.T(), outputdto.ConfigPageDTO{Items: expectedOutput, NextCursor: "next"}, output)
 	suite.repoMock.AssertExpectations(suite.T())
 }
 
-func (suite *ListAllConfigUseCaseSuite) TestExecuteWhenError() {
-	suite.repoMock.On("FindAll").Return(nil, fmt.Errorf("database error"))
+func (suite *ListPageByFilterConfigUseCaseSuite) TestExecuteWhenError() {
+	active := true
+	suite.repoMock.On("FindPageByFilter", entity.ConfigFilter{Provider: "test_provider", Active: &active}, criteria.Page{Sort: criteria.NewSort("created_at")}).Return(nil, "", fmt.Errorf("database error"))
 
-	output, err := suite.useCase.Execute()
+	output, err := suite.useCase.Execute(inputdto.ConfigFilterDTO{Provider: "test_provider", Active: &active})
 
 	assert.NotNil(suite.T(), err)
-	assert.Equal(suite.T(), []outputdto.ConfigDTO{}, output)
+	assert.Equal(suite.T(), outputdto.ConfigPageDTO{}, output)
 	suite.repoMock.AssertExpectations(suite.T())
 }
+
+func (suite *ListPageByFilterConfigUseCaseSuite) TestExecuteWithInvalidSort() {
+	output, err := suite.useCase.Execute(inputdto.ConfigFilterDTO{ListQuery: criteria.ListQuery{Sort: "provider"}})
+
+	assert.ErrorIs(suite.T(), err, criteria.ErrInvalidSort)
+	assert.Equal(suite.T(), outputdto.ConfigPageDTO{}, output)
+	suite.repoMock.AssertNotCalled(suite.T(), "FindPageByFilter", mock.Anything, mock.Anything)
+}
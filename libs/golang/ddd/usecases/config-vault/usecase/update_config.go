@@ -30,7 +30,8 @@ func NewUpdateConfigUseCase(
 }
 
 // Execute updates an existing configuration entity based on the provided input DTO and saves it using the repository.
-// It then converts the updated entity to an output DTO and returns it.
+// It then converts the updated entity to an output DTO and returns it. An update whose dependencies would close a
+// cycle in the job graph of its provider is rejected with an error wrapping entity.ErrDependencyCycle.
 //
 // Parameters:
 //
@@ -54,6 +55,11 @@ func (uc *UpdateConfigUseCase) Execute(input inputdto.ConfigDTO) (outputdto.Conf
 		return outputdto.ConfigDTO{}, err
 	}
 
+	err = checkDependencyCycle(uc.ConfigRepository, entityConfig)
+	if err != nil {
+		return outputdto.ConfigDTO{}, err
+	}
+
 	err = uc.ConfigRepository.Update(entityConfig)
 	if err != nil {
 		return outputdto.ConfigDTO{}, err
@@ -12,6 +12,7 @@ import (
 	"libs/golang/ddd/shared/type-tools/custom-types-converter/config-vault/converter"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -50,6 +51,7 @@ func (suite *UpdateConfigUseCaseSuite) SetupTest() {
 		JobParameters: converter.ConvertJobParametersDTOToMap(suite.inputDTO.JobParameters),
 		DependsOn:     converter.ConvertJobDependenciesDTOToMap(suite.inputDTO.DependsOn),
 	}
+	suite.repoMock.On("FindAllByFilter", entity.ConfigFilter{Provider: suite.inputDTO.Provider}).Return([]*entity.Config{}, nil).Maybe()
 }
 
 func (suite *UpdateConfigUseCaseSuite) TestExecuteWhenSuccess() {
@@ -80,3 +82,21 @@ func (suite *UpdateConfigUseCaseSuite) TestExecuteError() {
 	assert.Equal(suite.T(), outputdto.ConfigDTO{}, output)
 	suite.repoMock.AssertExpectations(suite.T())
 }
+
+func (suite *UpdateConfigUseCaseSuite) TestExecuteWhenDependencyCycle() {
+	suite.repoMock.ExpectedCalls = nil
+	upstream := &entity.Config{
+		ID:        "dep",
+		Service:   "dep_service",
+		Source:    "dep_source",
+		Provider:  "test_provider",
+		DependsOn: []entity.JobDependencies{{Service: "test_service", Source: "test_source"}},
+	}
+	suite.repoMock.On("FindAllByFilter", entity.ConfigFilter{Provider: "test_provider"}).Return([]*entity.Config{upstream}, nil)
+
+	output, err := suite.useCase.Execute(suite.inputDTO)
+
+	assert.ErrorIs(suite.T(), err, entity.ErrDependencyCycle)
+	assert.Equal(suite.T(), outputdto.ConfigDTO{}, output)
+	suite.repoMock.AssertNotCalled(suite.T(), "Update", mock.Anything)
+}
@@ -0,0 +1,35 @@
+package actions
+
+import (
+	configoutputdto "libs/golang/ddd/dtos/config-vault/output"
+	inputoutputdto "libs/golang/ddd/dtos/input-broker/output"
+	inputshareddto "libs/golang/ddd/dtos/input-broker/shared"
+	schemainputdto "libs/golang/ddd/dtos/schema-vault/input"
+	schemaoutputdto "libs/golang/ddd/dtos/schema-vault/output"
+)
+
+// SchemaValidatorClientInterface validates data against the schemas of the schema-vault.
+type SchemaValidatorClientInterface interface {
+	ValidateSchema(schemaData schemainputdto.SchemaDataDTO) error
+}
+
+// SchemaReaderClientInterface reads the schemas of the schema-vault.
+type SchemaReaderClientInterface interface {
+	ListSchemaByServiceAndSourceAndProviderAndSchemaType(provider, service, source, schemaType string) (schemaoutputdto.SchemaDTO, error)
+}
+
+// SchemaClientInterface validates data against the schemas of the schema-vault, and reads them.
+type SchemaClientInterface interface {
+	SchemaValidatorClientInterface
+	SchemaReaderClientInterface
+}
+
+// InputStatusClientInterface updates the status of the inputs of the input-broker.
+type InputStatusClientInterface interface {
+	UpdateInputStatus(id string, status inputshareddto.StatusDTO) (inputoutputdto.InputDTO, error)
+}
+
+// DependenciesClientInterface lists the configs of the config-vault that depend on a job.
+type DependenciesClientInterface interface {
+	ListConfigsByProviderAndDependencies(provider, service, source string) ([]configoutputdto.ConfigDTO, error)
+}
@@ -1,20 +1,40 @@
 package actions
 
 import (
-	"libs/golang/clients/apis/config-vault/client"
+	"fmt"
 	outputdto "libs/golang/ddd/dtos/config-vault/output"
+	eventsrouteroutputdto "libs/golang/ddd/dtos/events-router/output"
+	"log"
 )
 
+// ListAllByDependenciesAction lists the configs of the config-vault depending on a job.
 type ListAllByDependenciesAction struct {
-	client *client.Client
+	client DependenciesClientInterface
 }
 
-func NewListAllByDependenciesAction() *ListAllByDependenciesAction {
+// NewListAllByDependenciesAction creates a new instance of ListAllByDependenciesAction.
+//
+// Parameters:
+//   - client: The config-vault client.
+//
+// Returns:
+//   - A new instance of ListAllByDependenciesAction.
+func NewListAllByDependenciesAction(client DependenciesClientInterface) *ListAllByDependenciesAction {
 	return &ListAllByDependenciesAction{
-		client: client.NewClient(),
+		client: client,
 	}
 }
 
+// Execute lists the configs depending on a job.
+//
+// Parameters:
+//   - provider: The provider of the job.
+//   - service: The service of the job.
+//   - source: The source of the job.
+//
+// Returns:
+//   - The configs depending on the job.
+//   - An error if the configs cannot be listed.
 func (a *ListAllByDependenciesAction) Execute(provider, service, source string) ([]outputdto.ConfigDTO, error) {
 	configs, err := a.client.ListConfigsByProviderAndDependencies(provider, service, source)
 	if err != nil {
@@ -22,3 +42,30 @@ func (a *ListAllByDependenciesAction) Execute(provider, service, source string)
 	}
 	return configs, nil
 }
+
+// Name returns the name of the action in a pipeline.
+//
+// Returns:
+//   - "list-dependencies".
+func (a *ListAllByDependenciesAction) Name() string {
+	return "list-dependencies"
+}
+
+// Apply lists the configs depending on the job of a process order.
+//
+// Parameters:
+//   - order: The process order.
+//
+// Returns:
+//   - The result letting the order through.
+//   - An ActionError in the dependency-lookup category if the configs cannot be listed.
+func (a *ListAllByDependenciesAction) Apply(order *eventsrouteroutputdto.ProcessOrderDTO) (Result, error) {
+	dependencies, err := a.Execute(order.Provider, order.Service, order.Source)
+	if err != nil {
+		return Result{}, NewActionError(eventsrouteroutputdto.ErrCategoryDependencyLookup, eventsrouteroutputdto.ErrCodeDependencyLookup, fmt.Errorf("failed to list dependencies: %w", err))
+	}
+	for _, dep := range dependencies {
+		log.Printf("Dependency: %v", dep)
+	}
+	return Continue(), nil
+}
@@ -0,0 +1,54 @@
+package actions
+
+import (
+	configoutputdto "libs/golang/ddd/dtos/config-vault/output"
+	inputoutputdto "libs/golang/ddd/dtos/input-broker/output"
+	inputshareddto "libs/golang/ddd/dtos/input-broker/shared"
+	schemainputdto "libs/golang/ddd/dtos/schema-vault/input"
+	schemaoutputdto "libs/golang/ddd/dtos/schema-vault/output"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// SchemaClientMock is a mock implementation of SchemaClientInterface.
+type SchemaClientMock struct {
+	mock.Mock
+}
+
+// ValidateSchema is the mock implementation of the ValidateSchema method.
+func (m *SchemaClientMock) ValidateSchema(schemaData schemainputdto.SchemaDataDTO) error {
+	args := m.Called(schemaData)
+	return args.Error(0)
+}
+
+// ListSchemaByServiceAndSourceAndProviderAndSchemaType is the mock implementation of the ListSchemaByServiceAndSourceAndProviderAndSchemaType method.
+func (m *SchemaClientMock) ListSchemaByServiceAndSourceAndProviderAndSchemaType(provider, service, source, schemaType string) (schemaoutputdto.SchemaDTO, error) {
+	args := m.Called(provider, service, source, schemaType)
+	return args.Get(0).(schemaoutputdto.SchemaDTO), args.Error(1)
+}
+
+// InputStatusClientMock is a mock implementation of InputStatusClientInterface.
+type InputStatusClientMock struct {
+	mock.Mock
+}
+
+// UpdateInputStatus is the mock implementation of the UpdateInputStatus method.
+func (m *InputStatusClientMock) UpdateInputStatus(id string, status inputshareddto.StatusDTO) (inputoutputdto.InputDTO, error) {
+	args := m.Called(id, status)
+	return args.Get(0).(inputoutputdto.InputDTO), args.Error(1)
+}
+
+// DependenciesClientMock is a mock implementation of DependenciesClientInterface.
+type DependenciesClientMock struct {
+	mock.Mock
+}
+
+// ListConfigsByProviderAndDependencies is the mock implementation of the ListConfigsByProviderAndDependencies method.
+func (m *DependenciesClientMock) ListConfigsByProviderAndDependencies(provider, service, source string) ([]configoutputdto.ConfigDTO, error) {
+	args := m.Called(provider, service, source)
+	result := args.Get(0)
+	if result == nil {
+		return nil, args.Error(1)
+	}
+	return result.([]configoutputdto.ConfigDTO), args.Error(1)
+}
@@ -0,0 +1,236 @@
+package actions
+
+import (
+	"fmt"
+	outputdto "libs/golang/ddd/dtos/events-router/output"
+	"strings"
+)
+
+// Action is a step of a pre-processing pipeline. An action can enrich the process order, stop the
+// pipeline so the order is not dispatched, or fail.
+type Action interface {
+	// Name returns the name the action is registered and configured with.
+	Name() string
+	// Apply runs the action on a process order, which it may modify.
+	Apply(order *outputdto.ProcessOrderDTO) (Result, error)
+}
+
+// Result tells a pipeline whether to run its next action.
+type Result struct {
+	Stop   bool   // The order must not go further, without being an error
+	Reason string // Why the order was stopped
+}
+
+// Continue returns the result of an action letting the order through.
+//
+// Returns:
+//   - A result running the next action.
+func Continue() Result {
+	return Result{}
+}
+
+// Stop returns the result of an action short-circuiting the pipeline.
+//
+// Parameters:
+//   - reason: Why the order was stopped.
+//
+// Returns:
+//   - A result stopping the pipeline.
+func Stop(reason string) Result {
+	return Result{Stop: true, Reason: reason}
+}
+
+// ActionError classifies the failure of an action in the error envelope of the events-router.
+type ActionError struct {
+	Category string // One of the outputdto.ErrCategory constants
+	Code     string // One of the outputdto.ErrCode constants
+	Err      error  // Underlying error
+}
+
+// NewActionError creates a new ActionError.
+//
+// Parameters:
+//   - category: The category of the error.
+//   - code: The code of the error.
+//   - err: The underlying error.
+//
+// Returns:
+//   - A new instance of ActionError.
+func NewActionError(category, code string, err error) *ActionError {
+	return &ActionError{Category: category, Code: code, Err: err}
+}
+
+// Error returns the message of the underlying error.
+//
+// Returns:
+//   - The error message.
+func (e *ActionError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+//
+// Returns:
+//   - The underlying error.
+func (e *ActionError) Unwrap() error {
+	return e.Err
+}
+
+// Pipeline runs actions on a process order, in order.
+type Pipeline struct {
+	actions []Action
+}
+
+// NewPipeline creates a pipeline running the given actions in order.
+//
+// Parameters:
+//   - actions: The actions of the pipeline.
+//
+// Returns:
+//   - A new instance of Pipeline.
+func NewPipeline(actions ...Action) *Pipeline {
+	return &Pipeline{actions: actions}
+}
+
+// Actions returns the names of the actions of the pipeline, in order.
+//
+// Returns:
+//   - The names of the actions.
+func (p *Pipeline) Actions() []string {
+	names := make([]string, len(p.actions))
+	for i, action := range p.actions {
+		names[i] = action.Name()
+	}
+	return names
+}
+
+// Run applies the actions of the pipeline to a process order until one of them stops or fails.
+//
+// Parameters:
+//   - order: The process order, enriched in place by the actions.
+//
+// Returns:
+//   - The result of the pipeline. The reason of a stop is prefixed with the name of the action.
+//   - An error wrapping the error of the failed action, prefixed with its name.
+func (p *Pipeline) Run(order *outputdto.ProcessOrderDTO) (Result, error) {
+	for _, action := range p.actions {
+		result, err := action.Apply(order)
+		if err != nil {
+			return Result{}, fmt.Errorf("action %s: %w", action.Name(), err)
+		}
+		if result.Stop {
+			return Stop(fmt.Sprintf("%s: %s", action.Name(), result.Reason)), nil
+		}
+	}
+	return Continue(), nil
+}
+
+// Pipelines holds the pipeline of each route, keyed by "provider/service".
+type Pipelines struct {
+	Default *Pipeline            // Pipeline of the routes without their own
+	Routes  map[string]*Pipeline // Pipelines keyed by "provider/service"
+}
+
+// For returns the pipeline of a provider's service.
+//
+// Parameters:
+//   - provider: The provider of the service.
+//   - service: The service.
+//
+// Returns:
+//   - The pipeline of the service, or the default pipeline. An empty pipeline when there is neither.
+func (p Pipelines) For(provider, service string) *Pipeline {
+	if pipeline, ok := p.Routes[provider+"/"+service]; ok {
+		return pipeline
+	}
+	if p.Default != nil {
+		return p.Default
+	}
+	return NewPipeline()
+}
+
+// Registry holds the actions pipelines can be configured with, by name.
+type Registry map[string]Action
+
+// NewRegistry creates a registry of actions.
+//
+// Parameters:
+//   - actions: The actions to register under their names.
+//
+// Returns:
+//   - A new instance of Registry.
+func NewRegistry(actions ...Action) Registry {
+	registry := Registry{}
+	for _, action := range actions {
+		registry.Register(action)
+	}
+	return registry
+}
+
+// Register adds an action to the registry, replacing the action registered under the same name.
+//
+// Parameters:
+//   - action: The action to register.
+func (r Registry) Register(action Action) {
+	r[action.Name()] = action
+}
+
+// Pipeline builds a pipeline from a comma-separated list of action names.
+//
+// Parameters:
+//   - spec: The names of the actions, in order, such as "validate-schema,list-dependencies".
+//
+// Returns:
+//   - The pipeline.
+//   - An error if an action is not registered.
+func (r Registry) Pipeline(spec string) (*Pipeline, error) {
+	var actions []Action
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		action, ok := r[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown action %q", name)
+		}
+		actions = append(actions, action)
+	}
+	return NewPipeline(actions...), nil
+}
+
+// ParsePipelines builds the pipelines of the routes from their configuration.
+//
+// Parameters:
+//   - defaultSpec: The actions of the default pipeline, such as "validate-schema,list-dependencies".
+//   - routesSpec: Semicolon-separated "provider/service=actions" pairs, such as "acme/crawler=list-dependencies".
+//   - registry: The actions the pipelines can use.
+//
+// Returns:
+//   - The pipelines.
+//   - An error if a pair is invalid or an action is not registered.
+func ParsePipelines(defaultSpec, routesSpec string, registry Registry) (Pipelines, error) {
+	defaultPipeline, err := registry.Pipeline(defaultSpec)
+	if err != nil {
+		return Pipelines{}, fmt.Errorf("invalid default pipeline: %w", err)
+	}
+	pipelines := Pipelines{Default: defaultPipeline, Routes: map[string]*Pipeline{}}
+
+	for _, pair := range strings.Split(routesSpec, ";") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		key, spec, ok := strings.Cut(pair, "=")
+		provider, service, validKey := strings.Cut(strings.TrimSpace(key), "/")
+		if !ok || !validKey || provider == "" || service == "" {
+			return Pipelines{}, fmt.Errorf("invalid pipeline %q, expected provider/service=actions", pair)
+		}
+		pipeline, err := registry.Pipeline(spec)
+		if err != nil {
+			return Pipelines{}, fmt.Errorf("invalid pipeline for %s/%s: %w", provider, service, err)
+		}
+		pipelines.Routes[provider+"/"+service] = pipeline
+	}
+	return pipelines, nil
+}
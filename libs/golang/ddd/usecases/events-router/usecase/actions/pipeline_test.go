@@ -0,0 +1,177 @@
+package actions
+
+import (
+	"errors"
+	"testing"
+
+	configoutputdto "libs/golang/ddd/dtos/config-vault/output"
+	outputdto "libs/golang/ddd/dtos/events-router/output"
+	inputoutputdto "libs/golang/ddd/dtos/input-broker/output"
+	inputshareddto "libs/golang/ddd/dtos/input-broker/shared"
+	schemaoutputdto "libs/golang/ddd/dtos/schema-vault/output"
+	schemashareddto "libs/golang/ddd/dtos/schema-vault/shared"
+	"libs/golang/shared/go-problem/problem"
+	"net/http"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type PipelineSuite struct {
+	suite.Suite
+	schemaMock       *SchemaClientMock
+	statusMock       *InputStatusClientMock
+	dependenciesMock *DependenciesClientMock
+	validateSchema   *ValidateSchemaAction
+	listDependencies *ListAllByDependenciesAction
+	order            outputdto.ProcessOrderDTO
+}
+
+func TestPipelineSuite(t *testing.T) {
+	suite.Run(t, new(PipelineSuite))
+}
+
+func (suite *PipelineSuite) SetupTest() {
+	suite.schemaMock = new(SchemaClientMock)
+	suite.statusMock = new(InputStatusClientMock)
+	suite.dependenciesMock = new(DependenciesClientMock)
+	suite.validateSchema = NewValidateSchemaAction(suite.schemaMock, NewUpdateInputStatusAction(suite.statusMock), "input")
+	suite.listDependencies = NewListAllByDependenciesAction(suite.dependenciesMock)
+	suite.order = outputdto.ProcessOrderDTO{
+		Provider: "prv",
+		Service:  "svc",
+		Source:   "src",
+		InputID:  "input-1",
+		Data:     map[string]interface{}{"key": "value"},
+	}
+}
+
+func (suite *PipelineSuite) TestRunsActionsInOrder() {
+	suite.schemaMock.On("ValidateSchema", mock.Anything).Return(nil)
+	suite.dependenciesMock.On("ListConfigsByProviderAndDependencies", "prv", "svc", "src").Return([]configoutputdto.ConfigDTO{}, nil)
+	pipeline := NewPipeline(suite.validateSchema, suite.listDependencies)
+
+	result, err := pipeline.Run(&suite.order)
+
+	assert.Nil(suite.T(), err)
+	assert.False(suite.T(), result.Stop)
+	assert.Equal(suite.T(), []string{"validate-schema", "list-dependencies"}, pipeline.Actions())
+	suite.schemaMock.AssertExpectations(suite.T())
+	suite.dependenciesMock.AssertExpectations(suite.T())
+}
+
+func (suite *PipelineSuite) TestInvalidSchemaFailsAndUpdatesStatus() {
+	suite.schemaMock.On("ValidateSchema", mock.Anything).Return(problem.ErrValidationFailed.WithDetail("missing field"))
+	suite.statusMock.On("UpdateInputStatus", "input-1", inputshareddto.StatusDTO{Code: 401, Detail: "invalid schema"}).Return(inputoutputdto.InputDTO{}, nil)
+
+	_, err := NewPipeline(suite.validateSchema, suite.listDependencies).Run(&suite.order)
+
+	var actionErr *ActionError
+	assert.ErrorAs(suite.T(), err, &actionErr)
+	assert.Equal(suite.T(), outputdto.ErrCategorySchemaInvalid, actionErr.Category)
+	assert.Equal(suite.T(), outputdto.ErrCodeSchemaValidation, actionErr.Code)
+	assert.Equal(suite.T(), "action validate-schema: failed to validate input schema: Unprocessable Entity (validation_failed): missing field", err.Error())
+	suite.statusMock.AssertExpectations(suite.T())
+	suite.dependenciesMock.AssertNotCalled(suite.T(), "ListConfigsByProviderAndDependencies", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *PipelineSuite) TestSchemaVaultFailureKeepsInputStatus() {
+	failures := []error{
+		errors.New("failed to send HTTP request: connection refused"),
+		problem.New(http.StatusServiceUnavailable, ""),
+		problem.ErrNotFound.WithDetail("schema not found"),
+	}
+	for _, failure := range failures {
+		suite.schemaMock.On("ValidateSchema", mock.Anything).Return(failure).Once()
+
+		_, err := suite.validateSchema.Apply(&suite.order)
+
+		var actionErr *ActionError
+		assert.ErrorAs(suite.T(), err, &actionErr)
+		assert.Equal(suite.T(), outputdto.ErrCategorySchemaLookup, actionErr.Category)
+		assert.Equal(suite.T(), outputdto.ErrCodeSchemaLookup, actionErr.Code)
+	}
+	suite.statusMock.AssertNotCalled(suite.T(), "UpdateInputStatus", mock.Anything, mock.Anything)
+}
+
+func (suite *PipelineSuite) TestDependencyLookupFailure() {
+	suite.dependenciesMock.On("ListConfigsByProviderAndDependencies", "prv", "svc", "src").Return(nil, errors.New("timeout"))
+
+	_, err := NewPipeline(suite.listDependencies).Run(&suite.order)
+
+	var actionErr *ActionError
+	assert.ErrorAs(suite.T(), err, &actionErr)
+	assert.Equal(suite.T(), outputdto.ErrCategoryDependencyLookup, actionErr.Category)
+}
+
+func (suite *PipelineSuite) TestParsePipelines() {
+	registry := NewRegistry(suite.validateSchema, suite.listDependencies)
+
+	pipelines, err := ParsePipelines("validate-schema, list-dependencies", " acme/crawler=list-dependencies ; acme/raw= ;", registry)
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), []string{"validate-schema", "list-dependencies"}, pipelines.For("acme", "other").Actions())
+	assert.Equal(suite.T(), []string{"list-dependencies"}, pipelines.For("acme", "crawler").Actions())
+	assert.Empty(suite.T(), pipelines.For("acme", "raw").Actions())
+	assert.Empty(suite.T(), Pipelines{}.For("acme", "crawler").Actions())
+
+	_, err = ParsePipelines("mask-pii", "", registry)
+	assert.ErrorContains(suite.T(), err, `unknown action "mask-pii"`)
+	_, err = ParsePipelines("", "acme=list-dependencies", registry)
+	assert.NotNil(suite.T(), err)
+}
+
+func (suite *PipelineSuite) outputSchema() schemaoutputdto.SchemaDTO {
+	return schemaoutputdto.SchemaDTO{
+		SchemaType: "output",
+		JsonSchema: schemashareddto.JsonSchemaDTO{
+			JsonType: "object",
+			Required: []string{"label", "count"},
+			Properties: map[string]interface{}{
+				"label":    map[string]interface{}{"type": "string"},
+				"count":    map[string]interface{}{"type": "integer"},
+				"currency": map[string]interface{}{"type": "string", "default": "BRL"},
+			},
+		},
+		Transformation: []schemashareddto.FieldMappingDTO{
+			{From: "key", To: "label"},
+			{From: "raw.count", To: "count", Type: "integer"},
+		},
+	}
+}
+
+func (suite *PipelineSuite) TestTransformReshapesOrder() {
+	suite.order.Data["raw"] = map[string]interface{}{"count": "3"}
+	suite.schemaMock.On("ListSchemaByServiceAndSourceAndProviderAndSchemaType", "prv", "svc", "src", "output").Return(suite.outputSchema(), nil)
+
+	result, err := NewPipeline(NewTransformAction(suite.schemaMock, "output")).Run(&suite.order)
+
+	assert.Nil(suite.T(), err)
+	assert.False(suite.T(), result.Stop)
+	assert.Equal(suite.T(), map[string]interface{}{"label": "value", "count": int64(3), "currency": "BRL"}, suite.order.Data)
+}
+
+func (suite *PipelineSuite) TestTransformFailures() {
+	transform := NewTransformAction(suite.schemaMock, "output")
+	var actionErr *ActionError
+
+	suite.schemaMock.On("ListSchemaByServiceAndSourceAndProviderAndSchemaType", "prv", "svc", "src", "output").Return(schemaoutputdto.SchemaDTO{}, errors.New("not found")).Once()
+	_, err := transform.Apply(&suite.order)
+	assert.ErrorAs(suite.T(), err, &actionErr)
+	assert.Equal(suite.T(), outputdto.ErrCategorySchemaLookup, actionErr.Category)
+	assert.Equal(suite.T(), outputdto.ErrCodeOutputSchemaLookup, actionErr.Code)
+
+	suite.order.Data["raw"] = map[string]interface{}{"count": "three"}
+	suite.schemaMock.On("ListSchemaByServiceAndSourceAndProviderAndSchemaType", "prv", "svc", "src", "output").Return(suite.outputSchema(), nil)
+	_, err = transform.Apply(&suite.order)
+	assert.ErrorAs(suite.T(), err, &actionErr)
+	assert.Equal(suite.T(), outputdto.ErrCategoryTransformation, actionErr.Category)
+	assert.Equal(suite.T(), outputdto.ErrCodeTransformation, actionErr.Code)
+
+	delete(suite.order.Data, "raw")
+	_, err = transform.Apply(&suite.order)
+	assert.ErrorAs(suite.T(), err, &actionErr)
+	assert.Equal(suite.T(), outputdto.ErrCodeOutputValidation, actionErr.Code)
+	assert.Equal(suite.T(), "value", suite.order.Data["key"], "A failed transformation should leave the order as is")
+}
@@ -0,0 +1,90 @@
+package actions
+
+import (
+	"fmt"
+	outputdto "libs/golang/ddd/dtos/events-router/output"
+	schemaoutputdto "libs/golang/ddd/dtos/schema-vault/output"
+	schematools "libs/golang/shared/json-schema/schema-tools"
+)
+
+// TransformAction reshapes the data of a process order to its schema in the schema-vault, typically the
+// "output" schema, and validates the result against it. The field mappings and defaults of the
+// transformation are stored alongside the JSON schema.
+type TransformAction struct {
+	client     SchemaReaderClientInterface
+	schemaType string
+}
+
+// NewTransformAction creates a new instance of TransformAction.
+//
+// Parameters:
+//   - client: The schema-vault client.
+//   - schemaType: The type of the schema the orders are reshaped to.
+//
+// Returns:
+//   - A new instance of TransformAction.
+func NewTransformAction(client SchemaReaderClientInterface, schemaType string) *TransformAction {
+	return &TransformAction{
+		client:     client,
+		schemaType: schemaType,
+	}
+}
+
+// Name returns the name of the action in a pipeline.
+//
+// Returns:
+//   - "transform".
+func (a *TransformAction) Name() string {
+	return "transform"
+}
+
+// Apply replaces the data of a process order with its transformation to the schema of the provider, service
+// and source of the order.
+//
+// Parameters:
+//   - order: The process order to transform.
+//
+// Returns:
+//   - The result letting the transformed order through.
+//   - An ActionError in the schema-lookup category if the schema cannot be read, in the transformation category
+//     if the data cannot be transformed, or in the schema-invalid category if the transformed data does not match
+//     the schema.
+func (a *TransformAction) Apply(order *outputdto.ProcessOrderDTO) (Result, error) {
+	schema, err := a.client.ListSchemaByServiceAndSourceAndProviderAndSchemaType(order.Provider, order.Service, order.Source, a.schemaType)
+	if err != nil {
+		err = fmt.Errorf("failed to get %s schema: %w", a.schemaType, err)
+		return Result{}, NewActionError(outputdto.ErrCategorySchemaLookup, outputdto.ErrCodeOutputSchemaLookup, err)
+	}
+
+	jsonSchema := map[string]interface{}{
+		"required":   schema.JsonSchema.Required,
+		"properties": schema.JsonSchema.Properties,
+		"type":       schema.JsonSchema.JsonType,
+	}
+	data, err := schematools.Transform(order.Data, fieldMappings(schema), jsonSchema)
+	if err != nil {
+		err = fmt.Errorf("failed to transform data to %s schema: %w", a.schemaType, err)
+		return Result{}, NewActionError(outputdto.ErrCategoryTransformation, outputdto.ErrCodeTransformation, err)
+	}
+	if err := schematools.ValidateJSONData(jsonSchema, data); err != nil {
+		err = fmt.Errorf("failed to validate %s schema: %w", a.schemaType, err)
+		return Result{}, NewActionError(outputdto.ErrCategorySchemaInvalid, outputdto.ErrCodeOutputValidation, err)
+	}
+
+	order.Data = data
+	return Continue(), nil
+}
+
+// fieldMappings converts the transformation of a schema to field mappings of schema-tools.
+func fieldMappings(schema schemaoutputdto.SchemaDTO) []schematools.FieldMapping {
+	mappings := make([]schematools.FieldMapping, len(schema.Transformation))
+	for i, mapping := range schema.Transformation {
+		mappings[i] = schematools.FieldMapping{
+			From:    mapping.From,
+			To:      mapping.To,
+			Type:    mapping.Type,
+			Default: mapping.Default,
+		}
+	}
+	return mappings
+}
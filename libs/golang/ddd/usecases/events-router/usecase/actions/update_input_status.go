@@ -1,21 +1,37 @@
 package actions
 
 import (
-	"libs/golang/clients/apis/input-broker/client"
 	outputdto "libs/golang/ddd/dtos/events-router/output"
 	shareddto "libs/golang/ddd/dtos/input-broker/shared"
 )
 
+// UpdateInputStatusAction updates the status of the input of a process order in the input-broker.
 type UpdateInputStatusAction struct {
-	client *client.Client
+	client InputStatusClientInterface
 }
 
-func NewUpdateInputStatusAction() *UpdateInputStatusAction {
+// NewUpdateInputStatusAction creates a new instance of UpdateInputStatusAction.
+//
+// Parameters:
+//   - client: The input-broker client.
+//
+// Returns:
+//   - A new instance of UpdateInputStatusAction.
+func NewUpdateInputStatusAction(client InputStatusClientInterface) *UpdateInputStatusAction {
 	return &UpdateInputStatusAction{
-		client: client.NewClient(),
+		client: client,
 	}
 }
 
+// Execute updates the status of the input of a process order.
+//
+// Parameters:
+//   - inputMsg: The process order of the input.
+//   - statusCode: The status code.
+//   - statusDetail: The status detail.
+//
+// Returns:
+//   - An error if the status cannot be updated.
 func (a *UpdateInputStatusAction) Execute(inputMsg outputdto.ProcessOrderDTO, statusCode int, statusDetail string) error {
 	status := shareddto.StatusDTO{
 		Code:   statusCode,
@@ -1,21 +1,50 @@
 package actions
 
 import (
-	"libs/golang/clients/apis/schema-vault/client"
+	"errors"
+	"fmt"
 	outputdto "libs/golang/ddd/dtos/events-router/output"
 	inputdto "libs/golang/ddd/dtos/schema-vault/input"
+	"libs/golang/shared/go-problem/problem"
 )
 
+var (
+	invalidSchemaStatus = 401
+	invalidSchemaDetail = "invalid schema"
+)
+
+// ValidateSchemaAction validates the data of a process order against its schema in the schema-vault.
 type ValidateSchemaAction struct {
-	client *client.Client
+	client        SchemaValidatorClientInterface
+	statusUpdater *UpdateInputStatusAction
+	schemaType    string
 }
 
-func NewValidateSchemaAction() *ValidateSchemaAction {
+// NewValidateSchemaAction creates a new instance of ValidateSchemaAction.
+//
+// Parameters:
+//   - client: The schema-vault client.
+//   - statusUpdater: The action giving the invalid schema status to the inputs that fail the validation, or nil.
+//   - schemaType: The type of the schema the orders are validated against when applied in a pipeline.
+//
+// Returns:
+//   - A new instance of ValidateSchemaAction.
+func NewValidateSchemaAction(client SchemaValidatorClientInterface, statusUpdater *UpdateInputStatusAction, schemaType string) *ValidateSchemaAction {
 	return &ValidateSchemaAction{
-		client: client.NewClient(),
+		client:        client,
+		statusUpdater: statusUpdater,
+		schemaType:    schemaType,
 	}
 }
 
+// Execute validates the data of a process order against a schema.
+//
+// Parameters:
+//   - inputMsg: The process order to validate.
+//   - schemaType: The type of the schema.
+//
+// Returns:
+//   - An error if the data does not match the schema, or if it cannot be validated.
 func (a *ValidateSchemaAction) Execute(inputMsg outputdto.ProcessOrderDTO, schemaType string) error {
 	schemaData := inputdto.SchemaDataDTO{
 		Service:    inputMsg.Service,
@@ -30,3 +59,40 @@ func (a *ValidateSchemaAction) Execute(inputMsg outputdto.ProcessOrderDTO, schem
 	}
 	return nil
 }
+
+// Name returns the name of the action in a pipeline.
+//
+// Returns:
+//   - "validate-schema".
+func (a *ValidateSchemaAction) Name() string {
+	return "validate-schema"
+}
+
+// Apply validates a process order against the schema type of the action. Only the validation problem answered by
+// the schema-vault means the data is invalid, in which case the input gets the invalid schema status; any other
+// failure, such as an unreachable schema-vault, a 5xx response or a missing schema, leaves the input as it is so the
+// order can be retried.
+//
+// Parameters:
+//   - order: The process order to validate.
+//
+// Returns:
+//   - The result letting the order through.
+//   - An ActionError in the schema-invalid category if the order does not match its schema, or in the
+//     schema-lookup category if it cannot be validated.
+func (a *ValidateSchemaAction) Apply(order *outputdto.ProcessOrderDTO) (Result, error) {
+	err := a.Execute(*order, a.schemaType)
+	if err == nil {
+		return Continue(), nil
+	}
+	err = fmt.Errorf("failed to validate %s schema: %w", a.schemaType, err)
+	if !errors.Is(err, problem.ErrValidationFailed) {
+		return Result{}, NewActionError(outputdto.ErrCategorySchemaLookup, outputdto.ErrCodeSchemaLookup, err)
+	}
+	if a.statusUpdater != nil {
+		if statusErr := a.statusUpdater.Execute(*order, invalidSchemaStatus, invalidSchemaDetail); statusErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to update input status: %w", statusErr))
+		}
+	}
+	return Result{}, NewActionError(outputdto.ErrCategorySchemaInvalid, outputdto.ErrCodeSchemaValidation, err)
+}
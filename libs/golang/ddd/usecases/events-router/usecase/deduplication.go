@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"libs/golang/ddd/domain/entities/events-router/entity"
+	"log"
+	"sync/atomic"
+	"time"
+)
+
+// Deduplicator recognizes the redeliveries of inputs that were already pre-processed, by remembering
+// their processing IDs for a retention window.
+type Deduplicator struct {
+	ProcessedMessageRepository entity.ProcessedMessageRepositoryInterface
+	Retention                  time.Duration // How long a processed message is recognized as a duplicate
+	duplicates                 atomic.Int64
+}
+
+// NewDeduplicator creates a new instance of Deduplicator.
+//
+// Parameters:
+//   - processedMessageRepository: The repository interface for processed messages.
+//   - retention: How long a processed message is recognized as a duplicate.
+//
+// Returns:
+//   - A new instance of Deduplicator.
+func NewDeduplicator(
+	processedMessageRepository entity.ProcessedMessageRepositoryInterface,
+	retention time.Duration,
+) *Deduplicator {
+	return &Deduplicator{
+		ProcessedMessageRepository: processedMessageRepository,
+		Retention:                  retention,
+	}
+}
+
+// IsDuplicate reports whether a processing was already pre-processed within the retention window,
+// and counts it when it was.
+//
+// Parameters:
+//   - processingID: The processing ID of the input.
+//   - now: The time the retention is checked against.
+//
+// Returns:
+//   - True if the input is a duplicate.
+//   - An error if the processed messages cannot be read.
+func (d *Deduplicator) IsDuplicate(processingID string, now time.Time) (bool, error) {
+	processed, err := d.ProcessedMessageRepository.IsProcessed(processingID, now)
+	if err != nil {
+		return false, fmt.Errorf("failed to check processing %s: %w", processingID, err)
+	}
+	if processed {
+		d.duplicates.Add(1)
+	}
+	return processed, nil
+}
+
+// Remember records that a processing was pre-processed.
+//
+// Parameters:
+//   - processingID: The processing ID of the input.
+//   - eventOrderID: The ID of the event order of the processing.
+//   - now: The time the processing was pre-processed.
+//
+// Returns:
+//   - An error if the processed message cannot be saved.
+func (d *Deduplicator) Remember(processingID, eventOrderID string, now time.Time) error {
+	message, err := entity.NewProcessedMessage(processingID, eventOrderID, now, d.Retention)
+	if err != nil {
+		return err
+	}
+	return d.ProcessedMessageRepository.Save(message)
+}
+
+// Duplicates returns the number of duplicates recognized since the Deduplicator was created.
+func (d *Deduplicator) Duplicates() int64 {
+	return d.duplicates.Load()
+}
+
+// Purge removes the processed messages whose retention is over.
+//
+// Parameters:
+//   - now: The time the retention is checked against.
+//
+// Returns:
+//   - The number of removed processed messages.
+//   - An error if they cannot be removed.
+func (d *Deduplicator) Purge(now time.Time) (int, error) {
+	return d.ProcessedMessageRepository.DeleteExpired(now)
+}
+
+// Run purges the expired processed messages at each interval until the context is done.
+//
+// Parameters:
+//   - ctx: The context that stops the purge.
+//   - interval: The time between two purges.
+func (d *Deduplicator) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if _, err := d.Purge(now); err != nil {
+				log.Printf("Error purging processed messages: %v", err)
+			}
+		}
+	}
+}
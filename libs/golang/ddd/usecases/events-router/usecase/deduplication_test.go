@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"libs/golang/ddd/domain/entities/events-router/entity"
+	mockrepository "libs/golang/ddd/domain/repositories/database/mock/events-router/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type DeduplicatorSuite struct {
+	suite.Suite
+	repoMock     *mockrepository.ProcessedMessageRepositoryMock
+	deduplicator *Deduplicator
+	now          time.Time
+}
+
+func TestDeduplicatorSuite(t *testing.T) {
+	suite.Run(t, new(DeduplicatorSuite))
+}
+
+func (suite *DeduplicatorSuite) SetupTest() {
+	suite.repoMock = new(mockrepository.ProcessedMessageRepositoryMock)
+	suite.deduplicator = NewDeduplicator(suite.repoMock, 24*time.Hour)
+	suite.now = time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+}
+
+func (suite *DeduplicatorSuite) TestIsDuplicateCountsDuplicates() {
+	suite.repoMock.On("IsProcessed", "proc-1", suite.now).Return(true, nil)
+	suite.repoMock.On("IsProcessed", "proc-2", suite.now).Return(false, nil)
+
+	duplicate, err := suite.deduplicator.IsDuplicate("proc-1", suite.now)
+	assert.Nil(suite.T(), err)
+	assert.True(suite.T(), duplicate)
+	duplicate, err = suite.deduplicator.IsDuplicate("proc-2", suite.now)
+	assert.Nil(suite.T(), err)
+	assert.False(suite.T(), duplicate)
+
+	assert.Equal(suite.T(), int64(1), suite.deduplicator.Duplicates())
+}
+
+func (suite *DeduplicatorSuite) TestIsDuplicateError() {
+	suite.repoMock.On("IsProcessed", "proc-1", suite.now).Return(false, errors.New("collection not found"))
+
+	_, err := suite.deduplicator.IsDuplicate("proc-1", suite.now)
+
+	assert.ErrorContains(suite.T(), err, "failed to check processing proc-1: collection not found")
+	assert.Equal(suite.T(), int64(0), suite.deduplicator.Duplicates())
+}
+
+func (suite *DeduplicatorSuite) TestRememberUsesRetention() {
+	suite.repoMock.On("Save", mock.Anything).Return(nil)
+
+	err := suite.deduplicator.Remember("proc-1", "order-1", suite.now)
+
+	assert.Nil(suite.T(), err)
+	suite.repoMock.AssertCalled(suite.T(), "Save", &entity.ProcessedMessage{
+		ID:           "proc-1",
+		EventOrderID: "order-1",
+		ProcessedAt:  "2024-06-01 12:00:00",
+		ExpiresAt:    "2024-06-02 12:00:00",
+	})
+}
+
+func (suite *DeduplicatorSuite) TestPurge() {
+	suite.repoMock.On("DeleteExpired", suite.now).Return(3, nil)
+
+	deleted, err := suite.deduplicator.Purge(suite.now)
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 3, deleted)
+}
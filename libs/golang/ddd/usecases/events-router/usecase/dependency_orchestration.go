@@ -0,0 +1,284 @@
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"libs/golang/ddd/domain/entities/events-router/entity"
+	configoutputdto "libs/golang/ddd/dtos/config-vault/output"
+	outputdto "libs/golang/ddd/dtos/events-router/output"
+	inputdto "libs/golang/ddd/dtos/input-broker/output"
+	inputshareddto "libs/golang/ddd/dtos/input-broker/shared"
+	outputvaultdto "libs/golang/ddd/dtos/output-vault/output"
+	usecaseprotocol "libs/golang/server/events/usecase-impl/protocol"
+	events "libs/golang/shared/go-events/amqp_events"
+	gouuid "libs/golang/shared/id/go-uuid"
+	"log"
+	"time"
+)
+
+var (
+	orchestrationErrorQueue = "error.created.dependency-orchestration"
+	orchestrationStage      = "dependency-orchestration"
+
+	// defaultProcessingWindow is the length of the windows in which the dependencies of a job must complete,
+	// unless the use case sets another Window.
+	defaultProcessingWindow = 24 * time.Hour
+	windowLayout            = "2006-01-02 15:04:05"
+)
+
+// DependentsListerInterface lists the configs that depend on a job.
+type DependentsListerInterface interface {
+	Execute(provider, service, source string) ([]configoutputdto.ConfigDTO, error)
+}
+
+// DependencyOrchestrationUseCase dispatches the jobs whose dependencies all completed within the same
+// processing window. It consumes the outputs created by the upstream jobs.
+type DependencyOrchestrationUseCase struct {
+	ProcessingWindowRepository entity.ProcessingWindowRepositoryInterface
+	DependentsLister           DependentsListerInterface
+	NewErrorCreated            func() events.EventInterface
+	NewProcessOrderCreated     func() events.EventInterface
+	EventDispatcher            events.EventDispatcherInterface
+	Window                     time.Duration // The length of the processing windows, 24 hours by default.
+}
+
+// NewDependencyOrchestrationUseCase creates a new instance of DependencyOrchestrationUseCase.
+//
+// Parameters:
+//   - processingWindowRepository: The repository interface for processing windows.
+//   - dependentsLister: The lister of the configs depending on a job, such as a ListAllByDependenciesAction.
+//   - newErrorCreated: Builds the error creation event of each failure.
+//   - newProcessOrderCreated: Builds the process order creation event of each dispatched order.
+//   - eventDispatcher: The event dispatcher interface.
+//
+// Returns:
+//   - A new instance of DependencyOrchestrationUseCase.
+func NewDependencyOrchestrationUseCase(
+	processingWindowRepository entity.ProcessingWindowRepositoryInterface,
+	dependentsLister DependentsListerInterface,
+	newErrorCreated func() events.EventInterface,
+	newProcessOrderCreated func() events.EventInterface,
+	eventDispatcher events.EventDispatcherInterface,
+) *DependencyOrchestrationUseCase {
+	return &DependencyOrchestrationUseCase{
+		ProcessingWindowRepository: processingWindowRepository,
+		DependentsLister:           dependentsLister,
+		NewErrorCreated:            newErrorCreated,
+		NewProcessOrderCreated:     newProcessOrderCreated,
+		EventDispatcher:            eventDispatcher,
+		Window:                     defaultProcessingWindow,
+	}
+}
+
+// ProcessMessageChannel processes the created outputs from the provided channel.
+//
+// Messages that cannot be unmarshalled, and outputs whose processing window is invalid, are dead-lettered
+// straight away, while other failures are nacked for redelivery. Redelivering an output is safe: completions are recorded once per window and
+// a window is dispatched once.
+//
+// Parameters:
+//   - msgCh: The channel from which message deliveries are received.
+//   - listenerTag: The tag of the listener processing the messages.
+func (uc *DependencyOrchestrationUseCase) ProcessMessageChannel(msgCh <-chan usecaseprotocol.DeliveryInterface, listenerTag string) {
+	for delivery := range msgCh {
+		msg := delivery.Body()
+		attempt := delivery.RetryCount() + 1
+		var msgDTO outputvaultdto.OutputDTO
+		err := json.Unmarshal(msg, &msgDTO)
+		if err != nil {
+			log.Printf("Error unmarshalling message: %v", err)
+			err = newProcessingError(outputdto.ErrCategoryUnmarshal, outputdto.ErrCodeMalformedMessage, err)
+			uc.dispatchError(err, msg, outputvaultdto.OutputDTO{}, listenerTag, attempt)
+			uc.settle(delivery, delivery.Nack(false))
+			continue
+		}
+
+		log.Printf("Output received: %s/%s/%s", msgDTO.Provider, msgDTO.Service, msgDTO.Source)
+		err = uc.execute(msgDTO)
+		if err != nil {
+			log.Printf("Error orchestrating dependents: %v", err)
+			uc.dispatchError(err, msg, msgDTO, listenerTag, attempt)
+			uc.settle(delivery, delivery.Nack(retryable(err)))
+			continue
+		}
+		uc.settle(delivery, delivery.Ack())
+	}
+}
+
+// dispatchError dispatches an error event describing why an output could not be orchestrated.
+//
+// Parameters:
+//   - err: The error to be dispatched, classified by a ProcessingError.
+//   - msg: The original message that caused the error.
+//   - msgDTO: The decoded output, empty if it could not be unmarshalled.
+//   - listenerTag: The tag of the listener that processed the message.
+//   - attempt: The delivery attempt of the message, starting at 1.
+func (uc *DependencyOrchestrationUseCase) dispatchError(err error, msg []byte, msgDTO outputvaultdto.OutputDTO, listenerTag string, attempt int) {
+	input := inputdto.InputDTO{
+		ID: msgDTO.Metadata.InputID,
+		Metadata: inputshareddto.MetadataDTO{
+			Provider:     msgDTO.Provider,
+			Service:      msgDTO.Service,
+			Source:       msgDTO.Source,
+			ProcessingID: msgDTO.Metadata.Input.ProcessingID,
+		},
+	}
+	errMsg := newErrMsg(err, msg, input, listenerTag, attempt)
+	errMsg.Stage = orchestrationStage
+	publish(uc.EventDispatcher, uc.NewErrorCreated, errMsg, orchestrationErrorQueue)
+}
+
+// settle logs the outcome of acknowledging or rejecting a delivery.
+//
+// Parameters:
+//   - delivery: The delivery that was settled.
+//   - err: The error returned by Ack or Nack, if any.
+func (uc *DependencyOrchestrationUseCase) settle(delivery usecaseprotocol.DeliveryInterface, err error) {
+	if err != nil {
+		log.Printf("Error settling message %s: %v", string(delivery.Body()), err)
+	}
+}
+
+// execute records the completion of the output's job in the window of each active dependent, and
+// dispatches the dependents whose dependencies are all satisfied. A failing dependent does not
+// prevent the others from being orchestrated.
+//
+// Parameters:
+//   - msgDTO: The created output.
+//
+// Returns:
+//   - An error if the window or the dependents cannot be resolved, or if any dependent fails.
+func (uc *DependencyOrchestrationUseCase) execute(msgDTO outputvaultdto.OutputDTO) error {
+	window, err := windowOf(msgDTO.Metadata.Input.ProcessingTimestamp, uc.Window)
+	if err != nil {
+		return newProcessingError(outputdto.ErrCategorySchemaInvalid, outputdto.ErrCodeInvalidWindow, err)
+	}
+
+	dependents, err := uc.DependentsLister.Execute(msgDTO.Provider, msgDTO.Service, msgDTO.Source)
+	if err != nil {
+		return newProcessingError(outputdto.ErrCategoryDependencyLookup, outputdto.ErrCodeDependencyLookup, fmt.Errorf("failed to list dependents: %w", err))
+	}
+
+	completed := entity.JobDependency{Service: msgDTO.Service, Source: msgDTO.Source}
+	var errs []error
+	for _, dependent := range dependents {
+		if !dependent.Active {
+			continue
+		}
+		if err := uc.orchestrate(dependent, window, completed); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// orchestrate records a completion in the window of a dependent and dispatches it once satisfied. The
+// window is marked as dispatched before its process order is published, so a single worker dispatches it,
+// and unmarked when the publication fails, so the redelivered output dispatches it again.
+//
+// Parameters:
+//   - dependent: The config of the dependent job.
+//   - window: The processing window of the completion.
+//   - completed: The upstream job that completed.
+//
+// Returns:
+//   - A ProcessingError if the window cannot be built or stored, or if its process order cannot be published.
+func (uc *DependencyOrchestrationUseCase) orchestrate(dependent configoutputdto.ConfigDTO, window string, completed entity.JobDependency) error {
+	dependsOn := make([]entity.JobDependency, len(dependent.DependsOn))
+	for i, dep := range dependent.DependsOn {
+		dependsOn[i] = entity.JobDependency{Service: dep.Service, Source: dep.Source}
+	}
+
+	processingWindow, err := entity.NewProcessingWindow(entity.ProcessingWindowProps{
+		Service:   dependent.Service,
+		Source:    dependent.Source,
+		Provider:  dependent.Provider,
+		Window:    window,
+		DependsOn: dependsOn,
+	})
+	if err != nil {
+		return newProcessingError(outputdto.ErrCategorySchemaInvalid, outputdto.ErrCodeInvalidWindow, fmt.Errorf("invalid window of %s/%s: %w", dependent.Service, dependent.Source, err))
+	}
+
+	processingWindow, err = uc.ProcessingWindowRepository.AddCompletion(processingWindow, completed)
+	if err != nil {
+		return newProcessingError(outputdto.ErrCategoryRepository, outputdto.ErrCodeWindowPersistence, fmt.Errorf("failed to record completion for %s/%s: %w", dependent.Service, dependent.Source, err))
+	}
+	if !processingWindow.IsSatisfied() {
+		log.Printf("Window %s of %s/%s waiting for dependencies", window, dependent.Service, dependent.Source)
+		return nil
+	}
+
+	marked, err := uc.ProcessingWindowRepository.MarkDispatched(processingWindow.GetEntityID())
+	if err != nil {
+		return newProcessingError(outputdto.ErrCategoryRepository, outputdto.ErrCodeWindowPersistence, fmt.Errorf("failed to mark window of %s/%s as dispatched: %w", dependent.Service, dependent.Source, err))
+	}
+	if !marked {
+		return nil
+	}
+	if err := uc.dispatchOrder(processingWindow); err != nil {
+		if unmarkErr := uc.ProcessingWindowRepository.UnmarkDispatched(processingWindow.GetEntityID()); unmarkErr != nil {
+			log.Printf("Error unmarking window %s of %s/%s: %v", window, dependent.Service, dependent.Source, unmarkErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// dispatchOrder dispatches the process order of a satisfied window.
+//
+// Parameters:
+//   - window: The satisfied window.
+//
+// Returns:
+//   - An error if the processing ID cannot be generated, or a ProcessingError in the publish category if
+//     the process order cannot be published.
+func (uc *DependencyOrchestrationUseCase) dispatchOrder(window *entity.ProcessingWindow) error {
+	processingID, err := gouuid.GenerateUUIDFromMap(map[string]interface{}{"window_id": window.GetEntityID()})
+	if err != nil {
+		return err
+	}
+
+	dependencies := make([]map[string]interface{}, len(window.DependsOn))
+	for i, dep := range window.DependsOn {
+		dependencies[i] = map[string]interface{}{"service": dep.Service, "source": dep.Source}
+	}
+
+	dto := outputdto.ProcessOrderDTO{
+		ID:           window.GetEntityID(),
+		ProcessingID: processingID,
+		Service:      window.Service,
+		Source:       window.Source,
+		Provider:     window.Provider,
+		Stage:        processStage,
+		Data: map[string]interface{}{
+			"processing_window": window.Window,
+			"depends_on":        dependencies,
+		},
+	}
+
+	routingKey := fmt.Sprintf("%s.%s.%s.%s", baseRoutingKey, dto.Provider, dto.Service, dto.Source)
+	if err := publish(uc.EventDispatcher, uc.NewProcessOrderCreated, dto, routingKey); err != nil {
+		return newProcessingError(outputdto.ErrCategoryPublish, outputdto.ErrCodePublish, fmt.Errorf("failed to publish process order of window %s of %s/%s: %w", window.Window, window.Service, window.Source, err))
+	}
+	log.Printf("Window %s of %s/%s dispatched", window.Window, window.Service, window.Source)
+	return nil
+}
+
+// windowOf returns the processing window that contains a processing timestamp.
+//
+// Parameters:
+//   - processingTimestamp: The processing timestamp of the upstream input.
+//   - length: The length of the windows.
+//
+// Returns:
+//   - The start of the window, in the layout of the timestamp.
+//   - An error if the timestamp cannot be parsed.
+func windowOf(processingTimestamp string, length time.Duration) (string, error) {
+	timestamp, err := time.Parse(windowLayout, processingTimestamp)
+	if err != nil {
+		return "", fmt.Errorf("invalid processing timestamp %q: %w", processingTimestamp, err)
+	}
+	return timestamp.Truncate(length).Format(windowLayout), nil
+}
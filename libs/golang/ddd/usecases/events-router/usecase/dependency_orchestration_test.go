@@ -0,0 +1,246 @@
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"libs/golang/ddd/domain/entities/events-router/entity"
+	mockrepository "libs/golang/ddd/domain/repositories/database/mock/events-router/repository"
+	configoutputdto "libs/golang/ddd/dtos/config-vault/output"
+	configshareddto "libs/golang/ddd/dtos/config-vault/shared"
+	outputdto "libs/golang/ddd/dtos/events-router/output"
+	outputvaultdto "libs/golang/ddd/dtos/output-vault/output"
+	outputvaultshareddto "libs/golang/ddd/dtos/output-vault/shared"
+	mockevent "libs/golang/ddd/events/event-mock/mock"
+	usecaseprotocol "libs/golang/server/events/usecase-impl/protocol"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// dependentsListerMock is a mock of DependentsListerInterface.
+type dependentsListerMock struct {
+	mock.Mock
+}
+
+func (m *dependentsListerMock) Execute(provider, service, source string) ([]configoutputdto.ConfigDTO, error) {
+	args := m.Called(provider, service, source)
+	result := args.Get(0)
+	if result == nil {
+		return nil, args.Error(1)
+	}
+	return result.([]configoutputdto.ConfigDTO), args.Error(1)
+}
+
+type DependencyOrchestrationUseCaseSuite struct {
+	suite.Suite
+	repoMock       *mockrepository.ProcessingWindowRepositoryMock
+	listerMock     *dependentsListerMock
+	errorEvent     *mockevent.MockEvent
+	processEvent   *mockevent.MockEvent
+	dispatcherMock *mockevent.MockEventDispatcher
+	useCase        *DependencyOrchestrationUseCase
+	errMsg         outputdto.ErrMsgDTO
+	order          outputdto.ProcessOrderDTO
+	output         outputvaultdto.OutputDTO
+	dependent      configoutputdto.ConfigDTO
+}
+
+func TestDependencyOrchestrationUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(DependencyOrchestrationUseCaseSuite))
+}
+
+func (suite *DependencyOrchestrationUseCaseSuite) SetupTest() {
+	suite.repoMock = new(mockrepository.ProcessingWindowRepositoryMock)
+	suite.listerMock = new(dependentsListerMock)
+	suite.errorEvent = new(mockevent.MockEvent)
+	suite.processEvent = new(mockevent.MockEvent)
+	suite.dispatcherMock = new(mockevent.MockEventDispatcher)
+	suite.useCase = NewDependencyOrchestrationUseCase(suite.repoMock, suite.listerMock, eventFactory(suite.errorEvent), eventFactory(suite.processEvent), suite.dispatcherMock)
+	suite.errMsg = outputdto.ErrMsgDTO{}
+	suite.order = outputdto.ProcessOrderDTO{}
+
+	suite.errorEvent.On("SetPayload", mock.Anything).Run(func(args mock.Arguments) {
+		suite.errMsg = args.Get(0).(outputdto.ErrMsgDTO)
+	}).Return()
+	suite.dispatcherMock.On("Dispatch", suite.errorEvent, orchestrationErrorQueue).Return(nil)
+	suite.processEvent.On("SetPayload", mock.Anything).Run(func(args mock.Arguments) {
+		suite.order = args.Get(0).(outputdto.ProcessOrderDTO)
+	}).Return()
+
+	suite.output = outputvaultdto.OutputDTO{
+		ID:       "output-1",
+		Service:  "dep_service1",
+		Source:   "dep_source1",
+		Provider: "test_provider",
+		Metadata: outputvaultshareddto.MetadataDTO{
+			InputID: "input-1",
+			Input: outputvaultshareddto.InputDTO{
+				ProcessingID:        "processing-1",
+				ProcessingTimestamp: "2024-06-01 13:45:00",
+			},
+		},
+	}
+	suite.dependent = configoutputdto.ConfigDTO{
+		Active:   true,
+		Service:  "test_service",
+		Source:   "test_source",
+		Provider: "test_provider",
+		DependsOn: []configshareddto.JobDependenciesDTO{
+			{Service: "dep_service1", Source: "dep_source1"},
+			{Service: "dep_service2", Source: "dep_source2"},
+		},
+	}
+}
+
+// process runs a single output through the use case.
+func (suite *DependencyOrchestrationUseCaseSuite) process(output interface{}) *fakeDelivery {
+	body, _ := json.Marshal(output)
+	delivery := &fakeDelivery{body: body}
+	msgCh := make(chan usecaseprotocol.DeliveryInterface, 1)
+	msgCh <- delivery
+	close(msgCh)
+	suite.useCase.ProcessMessageChannel(msgCh, "listener-1")
+	return delivery
+}
+
+// windowWith returns the window of the dependent for 2024-06-01 with the given completions.
+func (suite *DependencyOrchestrationUseCaseSuite) windowWith(completed ...entity.JobDependency) *entity.ProcessingWindow {
+	window, _ := entity.NewProcessingWindow(entity.ProcessingWindowProps{
+		Service:  "test_service",
+		Source:   "test_source",
+		Provider: "test_provider",
+		Window:   "2024-06-01 00:00:00",
+		DependsOn: []entity.JobDependency{
+			{Service: "dep_service1", Source: "dep_source1"},
+			{Service: "dep_service2", Source: "dep_source2"},
+		},
+	})
+	window.Completed = append(window.Completed, completed...)
+	return window
+}
+
+func (suite *DependencyOrchestrationUseCaseSuite) TestWaitsForPendingDependencies() {
+	completed := entity.JobDependency{Service: "dep_service1", Source: "dep_source1"}
+	suite.listerMock.On("Execute", "test_provider", "dep_service1", "dep_source1").Return([]configoutputdto.ConfigDTO{suite.dependent}, nil)
+	suite.repoMock.On("AddCompletion", suite.windowWith(), completed).Return(suite.windowWith(completed), nil)
+
+	delivery := suite.process(suite.output)
+
+	assert.True(suite.T(), delivery.acked)
+	suite.repoMock.AssertNotCalled(suite.T(), "MarkDispatched", mock.Anything)
+	suite.processEvent.AssertNotCalled(suite.T(), "SetPayload", mock.Anything)
+}
+
+func (suite *DependencyOrchestrationUseCaseSuite) TestDispatchesSatisfiedWindow() {
+	completed := entity.JobDependency{Service: "dep_service1", Source: "dep_source1"}
+	satisfied := suite.windowWith(entity.JobDependency{Service: "dep_service2", Source: "dep_source2"}, completed)
+	inactive := suite.dependent
+	inactive.Active = false
+	inactive.Service = "inactive_service"
+	suite.listerMock.On("Execute", "test_provider", "dep_service1", "dep_source1").Return([]configoutputdto.ConfigDTO{inactive, suite.dependent}, nil)
+	suite.repoMock.On("AddCompletion", suite.windowWith(), completed).Return(satisfied, nil)
+	suite.repoMock.On("MarkDispatched", satisfied.GetEntityID()).Return(true, nil)
+	suite.dispatcherMock.On("Dispatch", suite.processEvent, "input.pre-processed.test_provider.test_service.test_source").Return(nil)
+
+	delivery := suite.process(suite.output)
+
+	assert.True(suite.T(), delivery.acked)
+	suite.repoMock.AssertNumberOfCalls(suite.T(), "AddCompletion", 1)
+	assert.Equal(suite.T(), satisfied.GetEntityID(), suite.order.ID)
+	assert.NotEmpty(suite.T(), suite.order.ProcessingID)
+	assert.Equal(suite.T(), processStage, suite.order.Stage)
+	assert.Equal(suite.T(), "2024-06-01 00:00:00", suite.order.Data["processing_window"])
+}
+
+func (suite *DependencyOrchestrationUseCaseSuite) TestDoesNotDispatchTwice() {
+	completed := entity.JobDependency{Service: "dep_service1", Source: "dep_source1"}
+	satisfied := suite.windowWith(entity.JobDependency{Service: "dep_service2", Source: "dep_source2"}, completed)
+	suite.listerMock.On("Execute", "test_provider", "dep_service1", "dep_source1").Return([]configoutputdto.ConfigDTO{suite.dependent}, nil)
+	suite.repoMock.On("AddCompletion", suite.windowWith(), completed).Return(satisfied, nil)
+	suite.repoMock.On("MarkDispatched", satisfied.GetEntityID()).Return(false, nil)
+
+	delivery := suite.process(suite.output)
+
+	assert.True(suite.T(), delivery.acked)
+	suite.processEvent.AssertNotCalled(suite.T(), "SetPayload", mock.Anything)
+}
+
+func (suite *DependencyOrchestrationUseCaseSuite) TestPublishFailureUnmarksWindow() {
+	completed := entity.JobDependency{Service: "dep_service1", Source: "dep_source1"}
+	satisfied := suite.windowWith(entity.JobDependency{Service: "dep_service2", Source: "dep_source2"}, completed)
+	suite.listerMock.On("Execute", "test_provider", "dep_service1", "dep_source1").Return([]configoutputdto.ConfigDTO{suite.dependent}, nil)
+	suite.repoMock.On("AddCompletion", suite.windowWith(), completed).Return(satisfied, nil)
+	suite.repoMock.On("MarkDispatched", satisfied.GetEntityID()).Return(true, nil)
+	suite.repoMock.On("UnmarkDispatched", satisfied.GetEntityID()).Return(nil)
+	suite.dispatcherMock.On("Dispatch", suite.processEvent, "input.pre-processed.test_provider.test_service.test_source").Return(errors.New("channel closed"))
+
+	delivery := suite.process(suite.output)
+
+	assert.True(suite.T(), delivery.requeued)
+	assert.Equal(suite.T(), outputdto.ErrCategoryPublish, suite.errMsg.Category)
+	assert.Equal(suite.T(), outputdto.ErrCodePublish, suite.errMsg.Code)
+	suite.repoMock.AssertCalled(suite.T(), "UnmarkDispatched", satisfied.GetEntityID())
+}
+
+func (suite *DependencyOrchestrationUseCaseSuite) TestWindowLength() {
+	suite.useCase.Window = time.Hour
+	completed := entity.JobDependency{Service: "dep_service1", Source: "dep_source1"}
+	hourly := suite.windowWith()
+	hourly.Window = "2024-06-01 13:00:00"
+	suite.listerMock.On("Execute", "test_provider", "dep_service1", "dep_source1").Return([]configoutputdto.ConfigDTO{suite.dependent}, nil)
+	suite.repoMock.On("AddCompletion", mock.MatchedBy(func(window *entity.ProcessingWindow) bool {
+		return window.Window == "2024-06-01 13:00:00"
+	}), completed).Return(hourly, nil)
+
+	delivery := suite.process(suite.output)
+
+	assert.True(suite.T(), delivery.acked)
+	suite.repoMock.AssertExpectations(suite.T())
+}
+
+func (suite *DependencyOrchestrationUseCaseSuite) TestMalformedMessage() {
+	delivery := suite.process("not an output")
+
+	assert.True(suite.T(), delivery.nacked)
+	assert.False(suite.T(), delivery.requeued)
+	assert.Equal(suite.T(), outputdto.ErrCodeMalformedMessage, suite.errMsg.Code)
+	assert.Equal(suite.T(), orchestrationStage, suite.errMsg.Stage)
+}
+
+func (suite *DependencyOrchestrationUseCaseSuite) TestInvalidProcessingTimestamp() {
+	suite.output.Metadata.Input.ProcessingTimestamp = "yesterday"
+
+	delivery := suite.process(suite.output)
+
+	assert.True(suite.T(), delivery.nacked)
+	assert.False(suite.T(), delivery.requeued, "A redelivery cannot fix the timestamp")
+	assert.Equal(suite.T(), outputdto.ErrCategorySchemaInvalid, suite.errMsg.Category)
+	assert.Equal(suite.T(), outputdto.ErrCodeInvalidWindow, suite.errMsg.Code)
+	assert.Equal(suite.T(), "processing-1", suite.errMsg.ProcessingID)
+	assert.Equal(suite.T(), "input-1", suite.errMsg.InputID)
+}
+
+func (suite *DependencyOrchestrationUseCaseSuite) TestDependentsLookupFailure() {
+	suite.listerMock.On("Execute", "test_provider", "dep_service1", "dep_source1").Return(nil, errors.New("config-vault unavailable"))
+
+	delivery := suite.process(suite.output)
+
+	assert.True(suite.T(), delivery.requeued)
+	assert.Equal(suite.T(), outputdto.ErrCodeDependencyLookup, suite.errMsg.Code)
+}
+
+func (suite *DependencyOrchestrationUseCaseSuite) TestWindowPersistenceFailure() {
+	completed := entity.JobDependency{Service: "dep_service1", Source: "dep_source1"}
+	suite.listerMock.On("Execute", "test_provider", "dep_service1", "dep_source1").Return([]configoutputdto.ConfigDTO{suite.dependent}, nil)
+	suite.repoMock.On("AddCompletion", suite.windowWith(), completed).Return(nil, errors.New("write conflict"))
+
+	delivery := suite.process(suite.output)
+
+	assert.True(suite.T(), delivery.requeued)
+	assert.Equal(suite.T(), outputdto.ErrCategoryRepository, suite.errMsg.Category)
+	assert.Equal(suite.T(), outputdto.ErrCodeWindowPersistence, suite.errMsg.Code)
+}
@@ -0,0 +1,166 @@
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"time"
+
+	"libs/golang/ddd/domain/entities/events-router/entity"
+	outputdto "libs/golang/ddd/dtos/events-router/output"
+	inputdto "libs/golang/ddd/dtos/input-broker/output"
+)
+
+var (
+	// errorStage is the stage reported in the error events of the pre-processing.
+	errorStage = "pre-processing"
+
+	// permanentCategories are the categories of the failures a redelivery cannot fix, such as an input that does
+	// not match its schema or a field that cannot be cast to the type of its mapping, so their messages are
+	// dead-lettered rather than requeued.
+	permanentCategories = []string{
+		outputdto.ErrCategoryUnmarshal,
+		outputdto.ErrCategorySchemaInvalid,
+		outputdto.ErrCategoryStageTransition,
+		outputdto.ErrCategoryTransformation,
+	}
+)
+
+// ProcessingError classifies a failure of the pre-processing so it can be reported in an ErrMsgDTO.
+type ProcessingError struct {
+	Code     string // One of the outputdto.ErrCode constants
+	Category string // One of the outputdto.ErrCategory constants
+	Err      error  // Underlying error
+}
+
+// newProcessingError creates a new ProcessingError.
+//
+// Parameters:
+//   - category: The category of the error.
+//   - code: The code of the error.
+//   - err: The underlying error.
+//
+// Returns:
+//   - A pointer to the new ProcessingError.
+func newProcessingError(category, code string, err error) *ProcessingError {
+	return &ProcessingError{
+		Code:     code,
+		Category: category,
+		Err:      err,
+	}
+}
+
+// Error returns the message of the underlying error.
+//
+// Returns:
+//   - The error message.
+func (e *ProcessingError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+//
+// Returns:
+//   - The underlying error.
+func (e *ProcessingError) Unwrap() error {
+	return e.Err
+}
+
+// Retryable reports whether a redelivery of the message may succeed, which is the case unless the category of
+// the error is permanent.
+//
+// Returns:
+//   - True if the message should be requeued.
+func (e *ProcessingError) Retryable() bool {
+	return !slices.Contains(permanentCategories, e.Category)
+}
+
+// retryable reports whether the message of a failed processing should be requeued. An illegal stage transition
+// is never retried, as the event order stays in its stage; other errors that were not classified by a
+// ProcessingError are retried.
+//
+// Parameters:
+//   - err: The error of the processing.
+//
+// Returns:
+//   - True if the message should be requeued.
+func retryable(err error) bool {
+	var processingErr *ProcessingError
+	if errors.As(err, &processingErr) {
+		return processingErr.Retryable()
+	}
+	return !errors.Is(err, entity.ErrIllegalStageTransition)
+}
+
+// newErrMsg builds the error envelope of a failed message. Errors that were not classified
+// by a ProcessingError are reported in the repository category.
+//
+// Parameters:
+//   - err: The error that occurred.
+//   - msg: The original message.
+//   - msgDTO: The decoded message, empty if it could not be unmarshalled.
+//   - listenerTag: The tag of the listener that consumed the message.
+//   - attempt: The delivery attempt, starting at 1.
+//
+// Returns:
+//   - The error envelope.
+func newErrMsg(err error, msg []byte, msgDTO inputdto.InputDTO, listenerTag string, attempt int) outputdto.ErrMsgDTO {
+	processingErr := newProcessingError(outputdto.ErrCategoryRepository, outputdto.ErrCodeEventOrderPersistence, err)
+	errors.As(err, &processingErr)
+
+	return outputdto.ErrMsgDTO{
+		Code:         processingErr.Code,
+		Category:     processingErr.Category,
+		Message:      err.Error(),
+		Causes:       causes(processingErr.Err),
+		Payload:      rawPayload(msg),
+		ProcessingID: msgDTO.Metadata.ProcessingID,
+		InputID:      msgDTO.ID,
+		ListenerTag:  listenerTag,
+		Stage:        errorStage,
+		Attempt:      attempt,
+		Timestamp:    time.Now().UTC(),
+	}
+}
+
+// causes lists the messages of an error and of the errors it wraps, outermost first.
+// Joined errors are listed depth-first.
+//
+// Parameters:
+//   - err: The error to unwrap.
+//
+// Returns:
+//   - The messages of the chain.
+func causes(err error) []string {
+	if err == nil {
+		return []string{}
+	}
+	chain := []string{err.Error()}
+	switch wrapped := err.(type) {
+	case interface{ Unwrap() error }:
+		if next := wrapped.Unwrap(); next != nil {
+			chain = append(chain, causes(next)...)
+		}
+	case interface{ Unwrap() []error }:
+		for _, next := range wrapped.Unwrap() {
+			chain = append(chain, causes(next)...)
+		}
+	}
+	return chain
+}
+
+// rawPayload embeds the original message in the envelope. Messages that are not valid JSON
+// are embedded as a JSON string so the envelope can always be marshalled.
+//
+// Parameters:
+//   - msg: The original message.
+//
+// Returns:
+//   - The message as raw JSON.
+func rawPayload(msg []byte) json.RawMessage {
+	if json.Valid(msg) {
+		return json.RawMessage(msg)
+	}
+	encoded, _ := json.Marshal(string(msg))
+	return encoded
+}
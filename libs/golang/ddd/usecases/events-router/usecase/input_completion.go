@@ -0,0 +1,197 @@
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"libs/golang/ddd/domain/entities/events-router/entity"
+	outputdto "libs/golang/ddd/dtos/events-router/output"
+	inputdto "libs/golang/ddd/dtos/input-broker/output"
+	inputshareddto "libs/golang/ddd/dtos/input-broker/shared"
+	outputvaultdto "libs/golang/ddd/dtos/output-vault/output"
+	usecaseprotocol "libs/golang/server/events/usecase-impl/protocol"
+	events "libs/golang/shared/go-events/amqp_events"
+	"log"
+)
+
+var (
+	completionErrorQueue  = "error.created.input-completion"
+	completionStage       = "input-completion"
+	completedStatus       = 200
+	completedStatusDetail = "completed"
+)
+
+// InputCompletionUseCase closes the loop of a processing when its output is created (output.created.*):
+// the event order moves to the output-stored stage, the input gets the completed status through the
+// input-broker, and the order moves to the completed stage.
+//
+// Outputs of orders dispatched by the dependency orchestration have no input to complete and are only
+// acknowledged; the orchestration consumes the same events to unblock the dependent jobs.
+type InputCompletionUseCase struct {
+	EventOrderRepository entity.EventOrderRepositoryInterface
+	InputStatusUpdater   InputStatusUpdaterInterface
+	NewErrorCreated      func() events.EventInterface
+	EventDispatcher      events.EventDispatcherInterface
+}
+
+// NewInputCompletionUseCase creates a new instance of InputCompletionUseCase.
+//
+// Parameters:
+//   - eventOrderRepository: The repository interface for event orders.
+//   - inputStatusUpdater: The updater of the input statuses, such as an UpdateInputStatusAction.
+//   - newErrorCreated: Builds the error creation event of each failure.
+//   - eventDispatcher: The event dispatcher interface.
+//
+// Returns:
+//   - A new instance of InputCompletionUseCase.
+func NewInputCompletionUseCase(
+	eventOrderRepository entity.EventOrderRepositoryInterface,
+	inputStatusUpdater InputStatusUpdaterInterface,
+	newErrorCreated func() events.EventInterface,
+	eventDispatcher events.EventDispatcherInterface,
+) *InputCompletionUseCase {
+	return &InputCompletionUseCase{
+		EventOrderRepository: eventOrderRepository,
+		InputStatusUpdater:   inputStatusUpdater,
+		NewErrorCreated:      newErrorCreated,
+		EventDispatcher:      eventDispatcher,
+	}
+}
+
+// ProcessMessageChannel completes the input of each created output.
+//
+// Messages that cannot be unmarshalled, and outputs of orders that cannot move to the output-stored stage,
+// are dead-lettered straight away. Other failures are nacked for redelivery.
+//
+// Parameters:
+//   - msgCh: The channel from which message deliveries are received.
+//   - listenerTag: The tag of the listener processing the messages.
+func (uc *InputCompletionUseCase) ProcessMessageChannel(msgCh <-chan usecaseprotocol.DeliveryInterface, listenerTag string) {
+	for delivery := range msgCh {
+		msg := delivery.Body()
+		attempt := delivery.RetryCount() + 1
+		event, err := decodeOutputCreated(msg)
+		if err != nil {
+			log.Printf("Error unmarshalling message: %v", err)
+			err = newProcessingError(outputdto.ErrCategoryUnmarshal, outputdto.ErrCodeMalformedMessage, err)
+			uc.dispatchError(err, msg, stageEvent{}, listenerTag, attempt)
+			uc.settle(delivery, delivery.Nack(false))
+			continue
+		}
+
+		err = uc.execute(event)
+		if err != nil {
+			log.Printf("Error completing input %s: %v", event.InputID, err)
+			uc.dispatchError(err, msg, event, listenerTag, attempt)
+			uc.settle(delivery, delivery.Nack(retryable(err)))
+			continue
+		}
+		uc.settle(delivery, delivery.Ack())
+	}
+}
+
+// execute completes the input of a processing whose output was created. A redelivered output of a
+// completed order is a no-op.
+//
+// Parameters:
+//   - event: The stage event of the output.
+//
+// Returns:
+//   - A ProcessingError if the event order cannot be found or moved, or if the input status cannot be updated.
+func (uc *InputCompletionUseCase) execute(event stageEvent) error {
+	if event.InputID == "" {
+		log.Printf("Output of processing %s has no input to complete", event.ProcessingID)
+		return nil
+	}
+
+	eventOrder, err := uc.EventOrderRepository.FindByProcessingID(event.ProcessingID)
+	if err != nil {
+		return newProcessingError(outputdto.ErrCategoryRepository, outputdto.ErrCodeEventOrderNotFound, err)
+	}
+	if eventOrder.Stage == entity.StageCompleted {
+		return nil
+	}
+
+	if err := uc.moveTo(eventOrder, entity.StageOutputStored, event.Detail); err != nil {
+		return err
+	}
+
+	dto := newProcessOrderDTO(eventOrder)
+	if err := uc.InputStatusUpdater.Execute(dto, completedStatus, completedStatusDetail); err != nil {
+		return newProcessingError(outputdto.ErrCategoryInputStatus, outputdto.ErrCodeInputStatusUpdate, fmt.Errorf("failed to update status of input %s: %w", dto.InputID, err))
+	}
+
+	return uc.moveTo(eventOrder, entity.StageCompleted, "")
+}
+
+// moveTo moves an event order to a stage.
+//
+// Parameters:
+//   - eventOrder: The event order to move.
+//   - stage: The requested stage.
+//   - detail: An optional reason, recorded in the history.
+//
+// Returns:
+//   - A ProcessingError if the transition is illegal or the order cannot be stored.
+func (uc *InputCompletionUseCase) moveTo(eventOrder *entity.EventOrder, stage, detail string) error {
+	_, err := uc.EventOrderRepository.UpdateStage(eventOrder.GetEntityID(), stage, detail)
+	if errors.Is(err, entity.ErrIllegalStageTransition) {
+		return newProcessingError(outputdto.ErrCategoryStageTransition, outputdto.ErrCodeIllegalTransition, err)
+	}
+	if err != nil {
+		return newProcessingError(outputdto.ErrCategoryRepository, outputdto.ErrCodeEventOrderPersistence, fmt.Errorf("failed to move event order to %s: %w", stage, err))
+	}
+	return nil
+}
+
+// dispatchError dispatches an error event describing why the input of an output could not be completed.
+//
+// Parameters:
+//   - err: The error to be dispatched, classified by a ProcessingError.
+//   - msg: The original message that caused the error.
+//   - event: The decoded stage event, empty if it could not be unmarshalled.
+//   - listenerTag: The tag of the listener that processed the message.
+//   - attempt: The delivery attempt of the message, starting at 1.
+func (uc *InputCompletionUseCase) dispatchError(err error, msg []byte, event stageEvent, listenerTag string, attempt int) {
+	input := inputdto.InputDTO{
+		ID:       event.InputID,
+		Metadata: inputshareddto.MetadataDTO{ProcessingID: event.ProcessingID},
+	}
+	errMsg := newErrMsg(err, msg, input, listenerTag, attempt)
+	errMsg.Stage = completionStage
+	publish(uc.EventDispatcher, uc.NewErrorCreated, errMsg, completionErrorQueue)
+}
+
+// settle logs the outcome of acknowledging or rejecting a delivery.
+//
+// Parameters:
+//   - delivery: The delivery that was settled.
+//   - err: The error returned by Ack or Nack, if any.
+func (uc *InputCompletionUseCase) settle(delivery usecaseprotocol.DeliveryInterface, err error) {
+	if err != nil {
+		log.Printf("Error settling message %s: %v", string(delivery.Body()), err)
+	}
+}
+
+// decodeOutputCreated extracts the stage event of a created output.
+//
+// Parameters:
+//   - msg: The output, as published by the output-vault.
+//
+// Returns:
+//   - The stage event of the output.
+//   - An error if the message is not an output of a processing.
+func decodeOutputCreated(msg []byte) (stageEvent, error) {
+	var output outputvaultdto.OutputDTO
+	if err := json.Unmarshal(msg, &output); err != nil {
+		return stageEvent{}, err
+	}
+	if output.Metadata.Input.ProcessingID == "" {
+		return stageEvent{}, errors.New("output has no processing ID")
+	}
+	return stageEvent{
+		ProcessingID: output.Metadata.Input.ProcessingID,
+		InputID:      output.Metadata.InputID,
+		Detail:       fmt.Sprintf("output %s", output.ID),
+	}, nil
+}
@@ -0,0 +1,144 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"libs/golang/ddd/domain/entities/events-router/entity"
+	mockrepository "libs/golang/ddd/domain/repositories/database/mock/events-router/repository"
+	outputdto "libs/golang/ddd/dtos/events-router/output"
+	mockevent "libs/golang/ddd/events/event-mock/mock"
+	usecaseprotocol "libs/golang/server/events/usecase-impl/protocol"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type InputCompletionUseCaseSuite struct {
+	suite.Suite
+	repoMock       *mockrepository.EventOrderRepositoryMock
+	statusMock     *inputStatusUpdaterMock
+	errorEvent     *mockevent.MockEvent
+	dispatcherMock *mockevent.MockEventDispatcher
+	useCase        *InputCompletionUseCase
+	errMsg         outputdto.ErrMsgDTO
+	eventOrder     *entity.EventOrder
+}
+
+func TestInputCompletionUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(InputCompletionUseCaseSuite))
+}
+
+func (suite *InputCompletionUseCaseSuite) SetupTest() {
+	suite.repoMock = new(mockrepository.EventOrderRepositoryMock)
+	suite.statusMock = new(inputStatusUpdaterMock)
+	suite.errorEvent = new(mockevent.MockEvent)
+	suite.dispatcherMock = new(mockevent.MockEventDispatcher)
+	suite.useCase = NewInputCompletionUseCase(suite.repoMock, suite.statusMock, eventFactory(suite.errorEvent), suite.dispatcherMock)
+	suite.errMsg = outputdto.ErrMsgDTO{}
+
+	suite.errorEvent.On("SetPayload", mock.Anything).Run(func(args mock.Arguments) {
+		suite.errMsg = args.Get(0).(outputdto.ErrMsgDTO)
+	}).Return()
+	suite.dispatcherMock.On("Dispatch", suite.errorEvent, completionErrorQueue).Return(nil)
+
+	suite.eventOrder, _ = entity.NewEventOrder(entity.EventOrderProps{
+		Service:      "svc",
+		Source:       "src",
+		Provider:     "prv",
+		ProcessingID: "proc-1",
+		InputID:      "input-1",
+	})
+}
+
+// process runs a single message through the use case.
+func (suite *InputCompletionUseCaseSuite) process(msg string) *fakeDelivery {
+	delivery := &fakeDelivery{body: []byte(msg)}
+	msgCh := make(chan usecaseprotocol.DeliveryInterface, 1)
+	msgCh <- delivery
+	close(msgCh)
+	suite.useCase.ProcessMessageChannel(msgCh, "listener-1")
+	return delivery
+}
+
+const createdOutput = `{"_id":"output-1","provider":"prv","service":"svc","source":"src","metadata":{"input_id":"input-1","input":{"processing_id":"proc-1"}}}`
+
+func (suite *InputCompletionUseCaseSuite) TestCompletesInput() {
+	suite.repoMock.On("FindByProcessingID", "proc-1").Return(suite.eventOrder, nil)
+	suite.repoMock.On("UpdateStage", suite.eventOrder.GetEntityID(), entity.StageOutputStored, "output output-1").Return(suite.eventOrder, nil)
+	suite.repoMock.On("UpdateStage", suite.eventOrder.GetEntityID(), entity.StageCompleted, "").Return(suite.eventOrder, nil)
+	suite.statusMock.On("Execute", mock.MatchedBy(func(dto outputdto.ProcessOrderDTO) bool {
+		return dto.InputID == "input-1" && dto.ProcessingID == "proc-1"
+	}), completedStatus, completedStatusDetail).Return(nil)
+
+	delivery := suite.process(createdOutput)
+
+	assert.True(suite.T(), delivery.acked)
+	suite.repoMock.AssertExpectations(suite.T())
+	suite.statusMock.AssertExpectations(suite.T())
+}
+
+func (suite *InputCompletionUseCaseSuite) TestRedeliveryOfCompletedOrder() {
+	suite.eventOrder.Stage = entity.StageCompleted
+	suite.repoMock.On("FindByProcessingID", "proc-1").Return(suite.eventOrder, nil)
+
+	delivery := suite.process(createdOutput)
+
+	assert.True(suite.T(), delivery.acked)
+	suite.repoMock.AssertNotCalled(suite.T(), "UpdateStage", mock.Anything, mock.Anything, mock.Anything)
+	suite.statusMock.AssertNotCalled(suite.T(), "Execute", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *InputCompletionUseCaseSuite) TestOutputWithoutInput() {
+	delivery := suite.process(`{"_id":"output-1","metadata":{"input":{"processing_id":"proc-2"}}}`)
+
+	assert.True(suite.T(), delivery.acked)
+	suite.repoMock.AssertNotCalled(suite.T(), "FindByProcessingID", mock.Anything)
+}
+
+func (suite *InputCompletionUseCaseSuite) TestInputStatusFailureIsRetried() {
+	suite.repoMock.On("FindByProcessingID", "proc-1").Return(suite.eventOrder, nil)
+	suite.repoMock.On("UpdateStage", suite.eventOrder.GetEntityID(), entity.StageOutputStored, mock.Anything).Return(suite.eventOrder, nil)
+	suite.statusMock.On("Execute", mock.Anything, completedStatus, completedStatusDetail).Return(errors.New("input-broker unavailable"))
+
+	delivery := suite.process(createdOutput)
+
+	assert.True(suite.T(), delivery.requeued)
+	assert.Equal(suite.T(), outputdto.ErrCategoryInputStatus, suite.errMsg.Category)
+	assert.Equal(suite.T(), outputdto.ErrCodeInputStatusUpdate, suite.errMsg.Code)
+	assert.Equal(suite.T(), completionStage, suite.errMsg.Stage)
+	assert.Equal(suite.T(), "input-1", suite.errMsg.InputID)
+	suite.repoMock.AssertNotCalled(suite.T(), "UpdateStage", mock.Anything, entity.StageCompleted, mock.Anything)
+}
+
+func (suite *InputCompletionUseCaseSuite) TestFailedOrderIsDeadLettered() {
+	illegal := fmt.Errorf("%w: from %q to %q", entity.ErrIllegalStageTransition, entity.StageFailed, entity.StageOutputStored)
+	suite.repoMock.On("FindByProcessingID", "proc-1").Return(suite.eventOrder, nil)
+	suite.repoMock.On("UpdateStage", suite.eventOrder.GetEntityID(), entity.StageOutputStored, mock.Anything).Return(nil, illegal)
+
+	delivery := suite.process(createdOutput)
+
+	assert.True(suite.T(), delivery.nacked)
+	assert.False(suite.T(), delivery.requeued)
+	assert.Equal(suite.T(), outputdto.ErrCodeIllegalTransition, suite.errMsg.Code)
+	suite.statusMock.AssertNotCalled(suite.T(), "Execute", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *InputCompletionUseCaseSuite) TestUnknownOrderIsRetried() {
+	suite.repoMock.On("FindByProcessingID", "proc-1").Return(nil, errors.New("not found"))
+
+	delivery := suite.process(createdOutput)
+
+	assert.True(suite.T(), delivery.requeued)
+	assert.Equal(suite.T(), outputdto.ErrCodeEventOrderNotFound, suite.errMsg.Code)
+}
+
+func (suite *InputCompletionUseCaseSuite) TestOutputWithoutProcessingID() {
+	delivery := suite.process(`{"_id":"output-1","metadata":{}}`)
+
+	assert.True(suite.T(), delivery.nacked)
+	assert.False(suite.T(), delivery.requeued)
+	assert.Equal(suite.T(), outputdto.ErrCodeMalformedMessage, suite.errMsg.Code)
+}
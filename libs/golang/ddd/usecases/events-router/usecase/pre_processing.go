@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"errors"
 	"fmt"
 	"libs/golang/ddd/domain/entities/events-router/entity"
 	outputdto "libs/golang/ddd/dtos/events-router/output"
@@ -8,94 +9,155 @@ import (
 
 	"encoding/json"
 	usecaseActions "libs/golang/ddd/usecases/events-router/usecase/actions"
+	usecaseprotocol "libs/golang/server/events/usecase-impl/protocol"
 	events "libs/golang/shared/go-events/amqp_events"
 	"log"
+	"time"
 )
 
 var (
-	errorQueue      = "error.created.pre-processing"
-	baseRoutingKey  = "input.pre-processed"
-	processStage    = "pre-processed"
-	inputSchemaType = "input"
+	errorQueue       = "error.created.pre-processing"
+	baseRoutingKey   = "input.pre-processed"
+	processStage     = "pre-processed"
+	inputSchemaType  = "input"
+	outputSchemaType = "output"
 
-	invalidSchemaStatus = 401
-	invalidSchemaDetail = "invalid schema"
+	// DefaultPipelineActions are the actions run on the inputs of the routes without a pipeline of their own.
+	DefaultPipelineActions = "validate-schema,list-dependencies"
 )
 
 // PreProcessingUseCase handles the pre-processing of input messages, including
-// dispatching errors and processing orders.
+// dispatching errors and processing orders. ProcessMessageChannel can run on several workers at once.
 type PreProcessingUseCase struct {
-	EventOrderRepository entity.EventOrderRepositoryInterface
-	ErrorCreated         events.EventInterface
-	ProcessOrderCreated  events.EventInterface
-	EventDispatcher      events.EventDispatcherInterface
+	EventOrderRepository   entity.EventOrderRepositoryInterface
+	Deduplicator           *Deduplicator // Recognizes redelivered inputs, no deduplication when nil
+	Pipelines              usecaseActions.Pipelines
+	NewErrorCreated        func() events.EventInterface
+	NewProcessOrderCreated func() events.EventInterface
+	EventDispatcher        events.EventDispatcherInterface
 }
 
 // NewPreProcessingUseCase creates a new instance of PreProcessingUseCase.
 //
 // Parameters:
 //   - eventOrderRepository: The repository interface for event orders.
-//   - errorCreated: The event interface for error creation events.
-//   - processOrderCreated: The event interface for process order creation events.
+//   - deduplicator: The deduplicator of the redelivered inputs, or nil to process every delivery.
+//   - pipelines: The pipelines of actions run on the inputs, per route.
+//   - newErrorCreated: Builds the error creation event of each failure.
+//   - newProcessOrderCreated: Builds the process order creation event of each dispatched order.
 //   - eventDispatcher: The event dispatcher interface.
 //
 // Returns:
 //   - A new instance of PreProcessingUseCase.
 func NewPreProcessingUseCase(
 	eventOrderRepository entity.EventOrderRepositoryInterface,
-	errorCreated events.EventInterface,
-	processOrderCreated events.EventInterface,
+	deduplicator *Deduplicator,
+	pipelines usecaseActions.Pipelines,
+	newErrorCreated func() events.EventInterface,
+	newProcessOrderCreated func() events.EventInterface,
 	eventDispatcher events.EventDispatcherInterface,
 ) *PreProcessingUseCase {
 	return &PreProcessingUseCase{
-		EventOrderRepository: eventOrderRepository,
-		ErrorCreated:         errorCreated,
-		ProcessOrderCreated:  processOrderCreated,
-		EventDispatcher:      eventDispatcher,
+		EventOrderRepository:   eventOrderRepository,
+		Deduplicator:           deduplicator,
+		Pipelines:              pipelines,
+		NewErrorCreated:        newErrorCreated,
+		NewProcessOrderCreated: newProcessOrderCreated,
+		EventDispatcher:        eventDispatcher,
 	}
 }
 
-// dispatchError dispatches an error event with the provided error message, original message, and listener tag.
+// NewPreProcessingRegistry registers the built-in pre-processing actions:
+//   - validate-schema: validates the input against its input schema, and gives the invalid schema status to
+//     the inputs that do not match it.
+//   - list-dependencies: lists the configs depending on the job of the input.
+//   - transform: reshapes the input to its output schema and validates it. Not part of the default pipeline,
+//     as only the services with an output schema can use it.
 //
 // Parameters:
-//   - err: The error to be dispatched.
+//   - schemaClient: The schema-vault client.
+//   - inputStatus: The input-broker client.
+//   - dependencies: The config-vault client.
+//
+// Returns:
+//   - The registry of the built-in actions, to which custom actions can be added.
+func NewPreProcessingRegistry(
+	schemaClient usecaseActions.SchemaClientInterface,
+	inputStatus usecaseActions.InputStatusClientInterface,
+	dependencies usecaseActions.DependenciesClientInterface,
+) usecaseActions.Registry {
+	return usecaseActions.NewRegistry(
+		usecaseActions.NewValidateSchemaAction(schemaClient, usecaseActions.NewUpdateInputStatusAction(inputStatus), inputSchemaType),
+		usecaseActions.NewListAllByDependenciesAction(dependencies),
+		usecaseActions.NewTransformAction(schemaClient, outputSchemaType),
+	)
+}
+
+// dispatchError dispatches an error event describing why a message could not be processed.
+//
+// Parameters:
+//   - err: The error to be dispatched, classified by a ProcessingError.
 //   - msg: The original message that caused the error.
+//   - msgDTO: The decoded message, empty if it could not be unmarshalled.
 //   - listenerTag: The tag of the listener that processed the message.
-func (uc *PreProcessingUseCase) dispatchError(err error, msg []byte, listenerTag string) {
-	errMsg := outputdto.ErrMsgDTO{
-		Err:         err,
-		Msg:         msg,
-		ListenerTag: listenerTag,
-	}
-	uc.ErrorCreated.SetPayload(errMsg)
-	uc.EventDispatcher.Dispatch(uc.ErrorCreated, errorQueue)
+//   - attempt: The delivery attempt of the message, starting at 1.
+func (uc *PreProcessingUseCase) dispatchError(err error, msg []byte, msgDTO inputdto.InputDTO, listenerTag string, attempt int) {
+	errMsg := newErrMsg(err, msg, msgDTO, listenerTag, attempt)
+	publish(uc.EventDispatcher, uc.NewErrorCreated, errMsg, errorQueue)
 }
 
 // ProcessMessageChannel processes messages from the provided channel and dispatches them for further processing.
 //
+// Each delivery is acknowledged once it has been processed. Messages that cannot be unmarshalled and
+// permanent failures, such as an input that does not match its schema, are dead-lettered straight away,
+// while the other processing failures are nacked for redelivery.
+//
 // Parameters:
-//   - msgCh: The channel from which messages are received.
+//   - msgCh: The channel from which message deliveries are received.
 //   - listenerTag: The tag of the listener processing the messages.
-func (uc *PreProcessingUseCase) ProcessMessageChannel(msgCh <-chan []byte, listenerTag string) {
-	for msg := range msgCh {
+func (uc *PreProcessingUseCase) ProcessMessageChannel(msgCh <-chan usecaseprotocol.DeliveryInterface, listenerTag string) {
+	for delivery := range msgCh {
+		msg := delivery.Body()
+		attempt := delivery.RetryCount() + 1
 		var msgDTO inputdto.InputDTO
 		err := json.Unmarshal(msg, &msgDTO)
 		if err != nil {
 			log.Printf("Error unmarshalling message: %v", err)
-			uc.dispatchError(err, msg, listenerTag)
-		} else {
-			log.Printf("Message received: %v", msgDTO)
-			err = uc.execute(msgDTO)
-
-			if err != nil {
-				log.Printf("Error processing message: %v", err)
-				uc.dispatchError(err, msg, listenerTag)
-			}
+			err = newProcessingError(outputdto.ErrCategoryUnmarshal, outputdto.ErrCodeMalformedMessage, err)
+			uc.dispatchError(err, msg, inputdto.InputDTO{}, listenerTag, attempt)
+			uc.settle(delivery, delivery.Nack(false))
+			continue
 		}
+
+		log.Printf("Message received: %v", msgDTO)
+		err = uc.execute(msgDTO)
+		if err != nil {
+			log.Printf("Error processing message: %v", err)
+			uc.dispatchError(err, msg, msgDTO, listenerTag, attempt)
+			uc.settle(delivery, delivery.Nack(retryable(err)))
+			continue
+		}
+		uc.settle(delivery, delivery.Ack())
 	}
 }
 
-// execute processes the input message and dispatches the processed order.
+// settle logs the outcome of acknowledging or rejecting a delivery.
+//
+// Parameters:
+//   - delivery: The delivery that was settled.
+//   - err: The error returned by Ack or Nack, if any.
+func (uc *PreProcessingUseCase) settle(delivery usecaseprotocol.DeliveryInterface, err error) {
+	if err != nil {
+		log.Printf("Error settling message %s: %v", string(delivery.Body()), err)
+	}
+}
+
+// execute runs the pipeline of the input's route on its process order and dispatches the order.
+// The event order of the input moves from received to pre-processed, then to dispatched once the order
+// is published. A failed attempt leaves the order received or pre-processed, so a redelivery resumes it;
+// an order that was already dispatched is not dispatched again. An order stopped by an action is skipped.
+// With a Deduplicator, an input whose processing was already handled within the retention window is
+// acknowledged without being processed, and the processing is remembered once its order is dispatched or skipped.
 //
 // Parameters:
 //   - msgDTO: The input message DTO to be processed.
@@ -109,65 +171,160 @@ func (uc *PreProcessingUseCase) execute(msgDTO inputdto.InputDTO) error {
 		Provider:     msgDTO.Metadata.Provider,
 		InputID:      msgDTO.ID,
 		ProcessingID: msgDTO.Metadata.ProcessingID,
-		Stage:        processStage,
+		Stage:        entity.StageReceived,
 		Data:         msgDTO.Data,
 	}
 
 	eventOrder, err := entity.NewEventOrder(eventOrcerProps)
 	if err != nil {
-		return err
+		return newProcessingError(outputdto.ErrCategorySchemaInvalid, outputdto.ErrCodeInvalidEventOrder, err)
 	}
 
-	err = uc.EventOrderRepository.Create(eventOrder)
-	if err != nil {
+	if uc.Deduplicator != nil {
+		duplicate, err := uc.Deduplicator.IsDuplicate(eventOrder.ProcessingID, time.Now())
+		if err != nil {
+			return newProcessingError(outputdto.ErrCategoryRepository, outputdto.ErrCodeDeduplication, err)
+		}
+		if duplicate {
+			log.Printf("Processing %s already handled, acknowledging duplicate (%d duplicates so far)", eventOrder.ProcessingID, uc.Deduplicator.Duplicates())
+			return nil
+		}
+	}
+
+	if err := uc.process(eventOrder); err != nil {
 		return err
 	}
+	if uc.Deduplicator != nil {
+		if err := uc.Deduplicator.Remember(eventOrder.ProcessingID, eventOrder.GetEntityID(), time.Now()); err != nil {
+			log.Printf("Error remembering processing %s: %v", eventOrder.ProcessingID, err)
+		}
+	}
+	return nil
+}
 
-	dto := outputdto.ProcessOrderDTO{
-		ID:           eventOrder.GetEntityID(),
-		ProcessingID: eventOrder.ProcessingID,
-		Service:      eventOrder.Service,
-		Source:       eventOrder.Source,
-		Provider:     eventOrder.Provider,
-		Stage:        eventOrder.Stage,
-		Data:         eventOrder.Data,
+// process runs the pipeline of the input's route on the process order of a new or resumed event order,
+// and dispatches the order. A resumed order that is still pre-processed runs its pipeline again, since
+// the enrichments are not stored, and is published again when it could not be moved to dispatched.
+//
+// Parameters:
+//   - eventOrder: The event order of the input.
+//
+// Returns:
+//   - An error if the processing fails, otherwise nil.
+func (uc *PreProcessingUseCase) process(eventOrder *entity.EventOrder) error {
+	eventOrder, err := uc.receiveEventOrder(eventOrder)
+	if err != nil {
+		return newProcessingError(outputdto.ErrCategoryRepository, outputdto.ErrCodeEventOrderPersistence, err)
+	}
+	if eventOrder.Stage != entity.StageReceived && eventOrder.Stage != entity.StagePreProcessed {
+		log.Printf("Event order %s already %s, skipping", eventOrder.GetEntityID(), eventOrder.Stage)
+		return nil
 	}
 
-	err = uc.prepareInputToProcess(dto)
+	dto := newProcessOrderDTO(eventOrder)
+	result, err := uc.runPipeline(&dto)
 	if err != nil {
 		return err
 	}
+	if result.Stop {
+		log.Printf("Event order %s stopped by %s", eventOrder.GetEntityID(), result.Reason)
+		// A pre-processed order can no longer be skipped, so the pipeline stopping it fails it.
+		stage := entity.StageSkipped
+		if eventOrder.Stage == entity.StagePreProcessed {
+			stage = entity.StageFailed
+		}
+		return uc.moveEventOrder(eventOrder, stage, result.Reason)
+	}
+
+	if eventOrder.Stage == entity.StageReceived {
+		if err := uc.moveEventOrder(eventOrder, entity.StagePreProcessed, ""); err != nil {
+			return err
+		}
+	}
 
-	uc.ProcessOrderCreated.SetPayload(dto)
 	routingKey := fmt.Sprintf("%s.%s.%s.%s", baseRoutingKey, dto.Provider, dto.Service, dto.Source)
-	uc.EventDispatcher.Dispatch(uc.ProcessOrderCreated, routingKey)
-	uc.EventOrderRepository.Delete(eventOrder.GetEntityID())
+	if err := publish(uc.EventDispatcher, uc.NewProcessOrderCreated, dto, routingKey); err != nil {
+		return newProcessingError(outputdto.ErrCategoryPublish, outputdto.ErrCodePublish, fmt.Errorf("failed to publish process order %s: %w", dto.ID, err))
+	}
+	if _, err := uc.EventOrderRepository.Dispatch(eventOrder.GetEntityID(), dto.Data); err != nil {
+		return newProcessingError(outputdto.ErrCategoryRepository, outputdto.ErrCodeEventOrderPersistence, fmt.Errorf("failed to move event order to %s: %w", entity.StageDispatched, err))
+	}
 	return nil
 }
 
-func (uc *PreProcessingUseCase) prepareInputToProcess(inputMsg outputdto.ProcessOrderDTO) error {
-	log.Printf("Preparing input to process: %v", inputMsg)
-	// TODO: create pre-processing methods
-	// 1. Validate input
-	validateSchemaAction := usecaseActions.NewValidateSchemaAction()
-	err := validateSchemaAction.Execute(inputMsg, inputSchemaType)
-	if err != nil {
-		updateInputStatusAction := usecaseActions.NewUpdateInputStatusAction()
-		err := updateInputStatusAction.Execute(inputMsg, invalidSchemaStatus, invalidSchemaDetail)
-		return err
+// moveEventOrder moves an event order to a stage.
+//
+// Parameters:
+//   - eventOrder: The event order to move.
+//   - stage: The stage to move the event order to.
+//   - reason: Why the event order moves, empty when it progresses normally.
+//
+// Returns:
+//   - A ProcessingError in the repository category if the stage cannot be stored, otherwise nil.
+func (uc *PreProcessingUseCase) moveEventOrder(eventOrder *entity.EventOrder, stage, reason string) error {
+	if _, err := uc.EventOrderRepository.UpdateStage(eventOrder.GetEntityID(), stage, reason); err != nil {
+		return newProcessingError(outputdto.ErrCategoryRepository, outputdto.ErrCodeEventOrderPersistence, fmt.Errorf("failed to move event order to %s: %w", stage, err))
+	}
+	return nil
+}
+
+// newProcessOrderDTO builds the process order of an event order.
+//
+// Parameters:
+//   - eventOrder: The event order to dispatch.
+//
+// Returns:
+//   - The process order.
+func newProcessOrderDTO(eventOrder *entity.EventOrder) outputdto.ProcessOrderDTO {
+	return outputdto.ProcessOrderDTO{
+		ID:           eventOrder.GetEntityID(),
+		ProcessingID: eventOrder.ProcessingID,
+		Service:      eventOrder.Service,
+		Source:       eventOrder.Source,
+		Provider:     eventOrder.Provider,
+		Stage:        processStage,
+		InputID:      eventOrder.InputID,
+		Data:         eventOrder.Data,
 	}
+}
 
-	// 2. List Configs by dependencies
-	dependenciesAction := usecaseActions.NewListAllByDependenciesAction()
-	dependencie, err := dependenciesAction.Execute(inputMsg.Provider, inputMsg.Service, inputMsg.Source)
+// receiveEventOrder stores a new event order, or returns the stored one when the input is redelivered.
+//
+// Parameters:
+//   - eventOrder: The event order of the input.
+//
+// Returns:
+//   - The stored event order.
+//   - An error if the event order can neither be created nor found.
+func (uc *PreProcessingUseCase) receiveEventOrder(eventOrder *entity.EventOrder) (*entity.EventOrder, error) {
+	createErr := uc.EventOrderRepository.Create(eventOrder)
+	if createErr == nil {
+		return eventOrder, nil
+	}
+	stored, err := uc.EventOrderRepository.FindByID(eventOrder.GetEntityID())
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("failed to create event order: %w", createErr)
 	}
+	return stored, nil
+}
 
-	for _, dep := range dependencie {
-		log.Printf("Dependency: %v", dep)
+// runPipeline runs the pipeline of the route of a process order.
+//
+// Parameters:
+//   - dto: The process order, enriched in place by the actions.
+//
+// Returns:
+//   - The result of the pipeline.
+//   - A ProcessingError classified by the failed action, or in the action category when it is not classified.
+func (uc *PreProcessingUseCase) runPipeline(dto *outputdto.ProcessOrderDTO) (usecaseActions.Result, error) {
+	log.Printf("Preparing input to process: %v", *dto)
+	result, err := uc.Pipelines.For(dto.Provider, dto.Service).Run(dto)
+	if err != nil {
+		var actionErr *usecaseActions.ActionError
+		if errors.As(err, &actionErr) {
+			return result, newProcessingError(actionErr.Category, actionErr.Code, err)
+		}
+		return result, newProcessingError(outputdto.ErrCategoryAction, outputdto.ErrCodeActionFailed, err)
 	}
-	// 3. Create processing staging
-	// 4. Create processing lineage ??
-	return nil
+	return result, nil
 }
@@ -0,0 +1,415 @@
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"libs/golang/ddd/domain/entities/events-router/entity"
+	mockrepository "libs/golang/ddd/domain/repositories/database/mock/events-router/repository"
+	outputdto "libs/golang/ddd/dtos/events-router/output"
+	inputdto "libs/golang/ddd/dtos/input-broker/output"
+	shareddto "libs/golang/ddd/dtos/input-broker/shared"
+	mockevent "libs/golang/ddd/events/event-mock/mock"
+	usecaseActions "libs/golang/ddd/usecases/events-router/usecase/actions"
+	usecaseprotocol "libs/golang/server/events/usecase-impl/protocol"
+	"libs/golang/shared/go-problem/problem"
+	"net/http"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// fakeDelivery records how a message was settled.
+type fakeDelivery struct {
+	body       []byte
+	retryCount int
+	acked      bool
+	nacked     bool
+	requeued   bool
+}
+
+func (d *fakeDelivery) Body() []byte    { return d.body }
+func (d *fakeDelivery) RetryCount() int { return d.retryCount }
+func (d *fakeDelivery) Ack() error      { d.acked = true; return nil }
+func (d *fakeDelivery) Nack(requeue bool) error {
+	d.nacked, d.requeued = true, requeue
+	return nil
+}
+
+// fakeAction is a pre-processing action running a function.
+type fakeAction struct {
+	name  string
+	apply func(order *outputdto.ProcessOrderDTO) (usecaseActions.Result, error)
+}
+
+func (a *fakeAction) Name() string { return a.name }
+func (a *fakeAction) Apply(order *outputdto.ProcessOrderDTO) (usecaseActions.Result, error) {
+	return a.apply(order)
+}
+
+type PreProcessingUseCaseSuite struct {
+	suite.Suite
+	repoMock       *mockrepository.EventOrderRepositoryMock
+	errorEvent     *mockevent.MockEvent
+	processEvent   *mockevent.MockEvent
+	dispatcherMock *mockevent.MockEventDispatcher
+	useCase        *PreProcessingUseCase
+	errMsg         outputdto.ErrMsgDTO
+}
+
+func TestPreProcessingUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(PreProcessingUseCaseSuite))
+}
+
+func (suite *PreProcessingUseCaseSuite) SetupTest() {
+	suite.repoMock = new(mockrepository.EventOrderRepositoryMock)
+	suite.errorEvent = new(mockevent.MockEvent)
+	suite.processEvent = new(mockevent.MockEvent)
+	suite.dispatcherMock = new(mockevent.MockEventDispatcher)
+	suite.useCase = NewPreProcessingUseCase(suite.repoMock, nil, usecaseActions.Pipelines{}, eventFactory(suite.errorEvent), eventFactory(suite.processEvent), suite.dispatcherMock)
+
+	suite.errorEvent.On("SetPayload", mock.Anything).Run(func(args mock.Arguments) {
+		suite.errMsg = args.Get(0).(outputdto.ErrMsgDTO)
+	}).Return()
+	suite.dispatcherMock.On("Dispatch", suite.errorEvent, errorQueue).Return(nil)
+}
+
+// process runs a single delivery through the use case.
+func (suite *PreProcessingUseCaseSuite) process(delivery *fakeDelivery) {
+	msgCh := make(chan usecaseprotocol.DeliveryInterface, 1)
+	msgCh <- delivery
+	close(msgCh)
+	suite.useCase.ProcessMessageChannel(msgCh, "listener-1")
+}
+
+func (suite *PreProcessingUseCaseSuite) TestMalformedMessage() {
+	delivery := &fakeDelivery{body: []byte("not json"), retryCount: 2}
+
+	suite.process(delivery)
+
+	assert.True(suite.T(), delivery.nacked)
+	assert.False(suite.T(), delivery.requeued)
+	assert.Equal(suite.T(), outputdto.ErrCategoryUnmarshal, suite.errMsg.Category)
+	assert.Equal(suite.T(), outputdto.ErrCodeMalformedMessage, suite.errMsg.Code)
+	assert.Equal(suite.T(), json.RawMessage(`"not json"`), suite.errMsg.Payload)
+	assert.Equal(suite.T(), "listener-1", suite.errMsg.ListenerTag)
+	assert.Equal(suite.T(), errorStage, suite.errMsg.Stage)
+	assert.Equal(suite.T(), 3, suite.errMsg.Attempt)
+	assert.False(suite.T(), suite.errMsg.Timestamp.IsZero())
+}
+
+func (suite *PreProcessingUseCaseSuite) TestInvalidEventOrder() {
+	msg := `{"_id":"input-1","data":{"key":"value"},"metadata":{"service":"svc","source":"src","processing_id":"proc-1"}}`
+	delivery := &fakeDelivery{body: []byte(msg)}
+
+	suite.process(delivery)
+
+	assert.True(suite.T(), delivery.nacked)
+	assert.False(suite.T(), delivery.requeued, "An invalid event order is dead-lettered rather than retried")
+	assert.Equal(suite.T(), outputdto.ErrCategorySchemaInvalid, suite.errMsg.Category)
+	assert.Equal(suite.T(), outputdto.ErrCodeInvalidEventOrder, suite.errMsg.Code)
+	assert.Equal(suite.T(), "proc-1", suite.errMsg.ProcessingID)
+	assert.Equal(suite.T(), "input-1", suite.errMsg.InputID)
+	assert.JSONEq(suite.T(), msg, string(suite.errMsg.Payload))
+	assert.Equal(suite.T(), 1, suite.errMsg.Attempt)
+}
+
+func (suite *PreProcessingUseCaseSuite) TestRepositoryError() {
+	suite.repoMock.On("Create", mock.Anything).Return(errors.New("collection not found"))
+	suite.repoMock.On("FindByID", mock.Anything).Return(nil, errors.New("document not found"))
+	msg := `{"_id":"input-1","data":{"key":"value"},"metadata":{"provider":"prv","service":"svc","source":"src","processing_id":"proc-1"}}`
+	delivery := &fakeDelivery{body: []byte(msg)}
+
+	suite.process(delivery)
+
+	assert.True(suite.T(), delivery.requeued)
+	assert.Equal(suite.T(), outputdto.ErrCategoryRepository, suite.errMsg.Category)
+	assert.Equal(suite.T(), outputdto.ErrCodeEventOrderPersistence, suite.errMsg.Code)
+	assert.Equal(suite.T(), "failed to create event order: collection not found", suite.errMsg.Message)
+	assert.Equal(suite.T(), []string{"failed to create event order: collection not found", "collection not found"}, suite.errMsg.Causes)
+}
+
+func (suite *PreProcessingUseCaseSuite) TestRedeliveryOfDispatchedOrder() {
+	stored, _ := entity.NewEventOrder(entity.EventOrderProps{
+		Service:      "svc",
+		Source:       "src",
+		Provider:     "prv",
+		ProcessingID: "proc-1",
+		Data:         map[string]interface{}{"key": "value"},
+	})
+	stored.Transition(entity.StagePreProcessed, "")
+	stored.Transition(entity.StageDispatched, "")
+	suite.repoMock.On("Create", mock.Anything).Return(errors.New("already exists"))
+	suite.repoMock.On("FindByID", stored.GetEntityID()).Return(stored, nil)
+	msg := `{"_id":"input-1","data":{"key":"value"},"metadata":{"provider":"prv","service":"svc","source":"src","processing_id":"proc-1"}}`
+	delivery := &fakeDelivery{body: []byte(msg)}
+
+	suite.process(delivery)
+
+	assert.True(suite.T(), delivery.acked)
+	suite.repoMock.AssertNotCalled(suite.T(), "UpdateStage", mock.Anything, mock.Anything, mock.Anything)
+	suite.processEvent.AssertNotCalled(suite.T(), "SetPayload", mock.Anything)
+}
+
+// receive makes the repository store a new event order for the input of validInput.
+func (suite *PreProcessingUseCaseSuite) receive() *entity.EventOrder {
+	eventOrder, _ := entity.NewEventOrder(entity.EventOrderProps{
+		Service:      "svc",
+		Source:       "src",
+		Provider:     "prv",
+		InputID:      "input-1",
+		ProcessingID: "proc-1",
+		Data:         map[string]interface{}{"key": "value"},
+	})
+	suite.repoMock.On("Create", mock.Anything).Return(nil)
+	return eventOrder
+}
+
+const validInput = `{"_id":"input-1","data":{"key":"value"},"metadata":{"provider":"prv","service":"svc","source":"src","processing_id":"proc-1"}}`
+
+func (suite *PreProcessingUseCaseSuite) TestPipelineEnrichesDispatchedOrder() {
+	eventOrder := suite.receive()
+	enrich := &fakeAction{name: "enrich", apply: func(order *outputdto.ProcessOrderDTO) (usecaseActions.Result, error) {
+		order.Data["region"] = "eu"
+		return usecaseActions.Continue(), nil
+	}}
+	suite.useCase.Pipelines = usecaseActions.Pipelines{Routes: map[string]*usecaseActions.Pipeline{"prv/svc": usecaseActions.NewPipeline(enrich)}}
+	suite.repoMock.On("UpdateStage", eventOrder.GetEntityID(), entity.StagePreProcessed, "").Return(eventOrder, nil)
+	suite.repoMock.On("Dispatch", eventOrder.GetEntityID(), map[string]interface{}{"key": "value", "region": "eu"}).Return(eventOrder, nil)
+	var dispatched outputdto.ProcessOrderDTO
+	suite.processEvent.On("SetPayload", mock.Anything).Run(func(args mock.Arguments) {
+		dispatched = args.Get(0).(outputdto.ProcessOrderDTO)
+	}).Return()
+	suite.dispatcherMock.On("Dispatch", suite.processEvent, "input.pre-processed.prv.svc.src").Return(nil)
+	delivery := &fakeDelivery{body: []byte(validInput)}
+
+	suite.process(delivery)
+
+	assert.True(suite.T(), delivery.acked)
+	assert.Equal(suite.T(), map[string]interface{}{"key": "value", "region": "eu"}, dispatched.Data)
+	suite.repoMock.AssertExpectations(suite.T())
+}
+
+func (suite *PreProcessingUseCaseSuite) TestPublishFailureLeavesOrderPreProcessed() {
+	eventOrder := suite.receive()
+	suite.repoMock.On("UpdateStage", eventOrder.GetEntityID(), entity.StagePreProcessed, "").Return(eventOrder, nil)
+	suite.processEvent.On("SetPayload", mock.Anything).Return()
+	suite.dispatcherMock.On("Dispatch", suite.processEvent, "input.pre-processed.prv.svc.src").Return(errors.New("channel closed"))
+	delivery := &fakeDelivery{body: []byte(validInput)}
+
+	suite.process(delivery)
+
+	assert.True(suite.T(), delivery.nacked)
+	assert.True(suite.T(), delivery.requeued)
+	assert.Equal(suite.T(), outputdto.ErrCategoryPublish, suite.errMsg.Category)
+	assert.Equal(suite.T(), outputdto.ErrCodePublish, suite.errMsg.Code)
+	assert.Equal(suite.T(), "failed to publish process order "+eventOrder.GetEntityID()+": channel closed", suite.errMsg.Message)
+	suite.repoMock.AssertNotCalled(suite.T(), "Dispatch", eventOrder.GetEntityID(), mock.Anything)
+}
+
+func (suite *PreProcessingUseCaseSuite) TestRedeliveryResumesPreProcessedOrder() {
+	stored := suite.receivePreProcessed()
+	suite.repoMock.On("Dispatch", stored.GetEntityID(), map[string]interface{}{"key": "value"}).Return(stored, nil)
+	suite.processEvent.On("SetPayload", mock.Anything).Return()
+	suite.dispatcherMock.On("Dispatch", suite.processEvent, "input.pre-processed.prv.svc.src").Return(nil)
+	delivery := &fakeDelivery{body: []byte(validInput)}
+
+	suite.process(delivery)
+
+	assert.True(suite.T(), delivery.acked)
+	suite.repoMock.AssertNotCalled(suite.T(), "UpdateStage", stored.GetEntityID(), entity.StagePreProcessed, "")
+	suite.processEvent.AssertCalled(suite.T(), "SetPayload", mock.Anything)
+	suite.repoMock.AssertExpectations(suite.T())
+}
+
+func (suite *PreProcessingUseCaseSuite) TestPipelineStopFailsResumedOrder() {
+	stored := suite.receivePreProcessed()
+	dedup := &fakeAction{name: "dedup", apply: func(order *outputdto.ProcessOrderDTO) (usecaseActions.Result, error) {
+		return usecaseActions.Stop("duplicate"), nil
+	}}
+	suite.useCase.Pipelines = usecaseActions.Pipelines{Default: usecaseActions.NewPipeline(dedup)}
+	suite.repoMock.On("UpdateStage", stored.GetEntityID(), entity.StageFailed, "dedup: duplicate").Return(stored, nil)
+	delivery := &fakeDelivery{body: []byte(validInput)}
+
+	suite.process(delivery)
+
+	assert.True(suite.T(), delivery.acked)
+	suite.processEvent.AssertNotCalled(suite.T(), "SetPayload", mock.Anything)
+	suite.repoMock.AssertExpectations(suite.T())
+}
+
+// receivePreProcessed makes the repository return the event order of validInput, left pre-processed by a
+// previous attempt.
+func (suite *PreProcessingUseCaseSuite) receivePreProcessed() *entity.EventOrder {
+	stored, _ := entity.NewEventOrder(entity.EventOrderProps{
+		Service:      "svc",
+		Source:       "src",
+		Provider:     "prv",
+		InputID:      "input-1",
+		ProcessingID: "proc-1",
+		Data:         map[string]interface{}{"key": "value"},
+	})
+	stored.Transition(entity.StagePreProcessed, "")
+	suite.repoMock.On("Create", mock.Anything).Return(errors.New("already exists"))
+	suite.repoMock.On("FindByID", stored.GetEntityID()).Return(stored, nil)
+	return stored
+}
+
+func (suite *PreProcessingUseCaseSuite) TestPipelineStopSkipsOrder() {
+	eventOrder := suite.receive()
+	ran := false
+	dedup := &fakeAction{name: "dedup", apply: func(order *outputdto.ProcessOrderDTO) (usecaseActions.Result, error) {
+		return usecaseActions.Stop("duplicate"), nil
+	}}
+	next := &fakeAction{name: "next", apply: func(order *outputdto.ProcessOrderDTO) (usecaseActions.Result, error) {
+		ran = true
+		return usecaseActions.Continue(), nil
+	}}
+	suite.useCase.Pipelines = usecaseActions.Pipelines{Default: usecaseActions.NewPipeline(dedup, next)}
+	suite.repoMock.On("UpdateStage", eventOrder.GetEntityID(), entity.StageSkipped, "dedup: duplicate").Return(eventOrder, nil)
+	delivery := &fakeDelivery{body: []byte(validInput)}
+
+	suite.process(delivery)
+
+	assert.True(suite.T(), delivery.acked)
+	assert.False(suite.T(), ran)
+	suite.processEvent.AssertNotCalled(suite.T(), "SetPayload", mock.Anything)
+	suite.repoMock.AssertExpectations(suite.T())
+}
+
+func (suite *PreProcessingUseCaseSuite) TestPipelineFailures() {
+	suite.receive()
+	classified := &fakeAction{name: "validate-schema", apply: func(order *outputdto.ProcessOrderDTO) (usecaseActions.Result, error) {
+		return usecaseActions.Result{}, usecaseActions.NewActionError(outputdto.ErrCategorySchemaInvalid, outputdto.ErrCodeSchemaValidation, errors.New("missing field"))
+	}}
+	unclassified := &fakeAction{name: "mask-pii", apply: func(order *outputdto.ProcessOrderDTO) (usecaseActions.Result, error) {
+		return usecaseActions.Result{}, errors.New("vault sealed")
+	}}
+
+	suite.useCase.Pipelines = usecaseActions.Pipelines{Default: usecaseActions.NewPipeline(classified)}
+	delivery := &fakeDelivery{body: []byte(validInput)}
+	suite.process(delivery)
+
+	assert.True(suite.T(), delivery.nacked)
+	assert.False(suite.T(), delivery.requeued, "An input failing its schema is dead-lettered rather than retried")
+	assert.Equal(suite.T(), outputdto.ErrCategorySchemaInvalid, suite.errMsg.Category)
+	assert.Equal(suite.T(), outputdto.ErrCodeSchemaValidation, suite.errMsg.Code)
+	assert.Equal(suite.T(), "action validate-schema: missing field", suite.errMsg.Message)
+
+	suite.useCase.Pipelines = usecaseActions.Pipelines{Default: usecaseActions.NewPipeline(unclassified)}
+	delivery = &fakeDelivery{body: []byte(validInput)}
+	suite.process(delivery)
+
+	assert.True(suite.T(), delivery.requeued)
+	assert.Equal(suite.T(), outputdto.ErrCategoryAction, suite.errMsg.Category)
+	assert.Equal(suite.T(), outputdto.ErrCodeActionFailed, suite.errMsg.Code)
+	suite.repoMock.AssertNotCalled(suite.T(), "UpdateStage", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *PreProcessingUseCaseSuite) TestSchemaVaultOutageRequeuesInput() {
+	suite.receive()
+	schemaMock := new(usecaseActions.SchemaClientMock)
+	statusMock := new(usecaseActions.InputStatusClientMock)
+	schemaMock.On("ValidateSchema", mock.Anything).Return(fmt.Errorf("HTTP request failed: %w", problem.New(http.StatusServiceUnavailable, "")))
+	validate := usecaseActions.NewValidateSchemaAction(schemaMock, usecaseActions.NewUpdateInputStatusAction(statusMock), "input")
+	suite.useCase.Pipelines = usecaseActions.Pipelines{Default: usecaseActions.NewPipeline(validate)}
+	delivery := &fakeDelivery{body: []byte(validInput)}
+
+	suite.process(delivery)
+
+	assert.True(suite.T(), delivery.nacked)
+	assert.True(suite.T(), delivery.requeued, "An input that could not be validated is retried")
+	assert.Equal(suite.T(), outputdto.ErrCategorySchemaLookup, suite.errMsg.Category)
+	assert.Equal(suite.T(), outputdto.ErrCodeSchemaLookup, suite.errMsg.Code)
+	statusMock.AssertNotCalled(suite.T(), "UpdateInputStatus", mock.Anything, mock.Anything)
+	suite.repoMock.AssertNotCalled(suite.T(), "UpdateStage", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *PreProcessingUseCaseSuite) TestTransformationFailureIsDeadLettered() {
+	suite.receive()
+	transform := &fakeAction{name: "transform", apply: func(order *outputdto.ProcessOrderDTO) (usecaseActions.Result, error) {
+		return usecaseActions.Result{}, usecaseActions.NewActionError(outputdto.ErrCategoryTransformation, outputdto.ErrCodeTransformation, errors.New("cannot cast value"))
+	}}
+	suite.useCase.Pipelines = usecaseActions.Pipelines{Default: usecaseActions.NewPipeline(transform)}
+	delivery := &fakeDelivery{body: []byte(validInput)}
+
+	suite.process(delivery)
+
+	assert.True(suite.T(), delivery.nacked)
+	assert.False(suite.T(), delivery.requeued, "A cast failure gives the same result on every attempt")
+	assert.Equal(suite.T(), outputdto.ErrCategoryTransformation, suite.errMsg.Category)
+}
+
+func (suite *PreProcessingUseCaseSuite) TestDuplicateIsAcknowledged() {
+	processedMock := new(mockrepository.ProcessedMessageRepositoryMock)
+	processedMock.On("IsProcessed", "proc-1", mock.Anything).Return(true, nil)
+	suite.useCase.Deduplicator = NewDeduplicator(processedMock, time.Hour)
+	delivery := &fakeDelivery{body: []byte(validInput)}
+
+	suite.process(delivery)
+
+	assert.True(suite.T(), delivery.acked)
+	assert.Equal(suite.T(), int64(1), suite.useCase.Deduplicator.Duplicates())
+	suite.repoMock.AssertNotCalled(suite.T(), "Create", mock.Anything)
+	processedMock.AssertNotCalled(suite.T(), "Save", mock.Anything)
+}
+
+func (suite *PreProcessingUseCaseSuite) TestDispatchedProcessingIsRemembered() {
+	eventOrder := suite.receive()
+	processedMock := new(mockrepository.ProcessedMessageRepositoryMock)
+	processedMock.On("IsProcessed", "proc-1", mock.Anything).Return(false, nil)
+	processedMock.On("Save", mock.MatchedBy(func(message *entity.ProcessedMessage) bool {
+		return message.ID == "proc-1" && message.EventOrderID == eventOrder.GetEntityID()
+	})).Return(errors.New("store unavailable"))
+	suite.useCase.Deduplicator = NewDeduplicator(processedMock, time.Hour)
+	suite.repoMock.On("UpdateStage", eventOrder.GetEntityID(), entity.StagePreProcessed, "").Return(eventOrder, nil)
+	suite.repoMock.On("Dispatch", eventOrder.GetEntityID(), mock.Anything).Return(eventOrder, nil)
+	suite.processEvent.On("SetPayload", mock.Anything).Return()
+	suite.dispatcherMock.On("Dispatch", suite.processEvent, "input.pre-processed.prv.svc.src").Return(nil)
+	delivery := &fakeDelivery{body: []byte(validInput)}
+
+	suite.process(delivery)
+
+	assert.True(suite.T(), delivery.acked, "A processing that cannot be remembered is still acknowledged")
+	assert.Equal(suite.T(), int64(0), suite.useCase.Deduplicator.Duplicates())
+	processedMock.AssertExpectations(suite.T())
+}
+
+func (suite *PreProcessingUseCaseSuite) TestDeduplicationError() {
+	processedMock := new(mockrepository.ProcessedMessageRepositoryMock)
+	processedMock.On("IsProcessed", "proc-1", mock.Anything).Return(false, errors.New("collection not found"))
+	suite.useCase.Deduplicator = NewDeduplicator(processedMock, time.Hour)
+	delivery := &fakeDelivery{body: []byte(validInput)}
+
+	suite.process(delivery)
+
+	assert.True(suite.T(), delivery.requeued)
+	assert.Equal(suite.T(), outputdto.ErrCategoryRepository, suite.errMsg.Category)
+	assert.Equal(suite.T(), outputdto.ErrCodeDeduplication, suite.errMsg.Code)
+	suite.repoMock.AssertNotCalled(suite.T(), "Create", mock.Anything)
+}
+
+func (suite *PreProcessingUseCaseSuite) TestErrMsgMarshalling() {
+	err := newProcessingError(outputdto.ErrCategoryDependencyLookup, outputdto.ErrCodeDependencyLookup, errors.Join(errors.New("a"), errors.New("b")))
+	errMsg := newErrMsg(err, []byte(`{"k":1}`), inputdto.InputDTO{
+		ID:       "input-1",
+		Metadata: shareddto.MetadataDTO{ProcessingID: "proc-1"},
+	}, "listener-1", 1)
+
+	data, marshalErr := json.Marshal(errMsg)
+	assert.Nil(suite.T(), marshalErr)
+
+	var decoded map[string]interface{}
+	assert.Nil(suite.T(), json.Unmarshal(data, &decoded))
+	assert.Equal(suite.T(), "DEPENDENCY_LOOKUP", decoded["code"])
+	assert.Equal(suite.T(), "dependency-lookup", decoded["category"])
+	assert.Equal(suite.T(), "a\nb", decoded["message"])
+	assert.Equal(suite.T(), []interface{}{"a\nb", "a", "b"}, decoded["causes"])
+	assert.Equal(suite.T(), map[string]interface{}{"k": float64(1)}, decoded["payload"])
+	assert.Equal(suite.T(), "proc-1", decoded["processing_id"])
+	assert.Equal(suite.T(), "input-1", decoded["input_id"])
+}
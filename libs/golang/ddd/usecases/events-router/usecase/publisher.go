@@ -0,0 +1,22 @@
+package usecase
+
+import (
+	events "libs/golang/shared/go-events/amqp_events"
+)
+
+// publish builds the event of a message, sets its payload and dispatches it. Each message gets its own event,
+// so the workers processing messages at once never share a payload.
+//
+// Parameters:
+//   - dispatcher: The event dispatcher.
+//   - newEvent: Builds the event to publish.
+//   - payload: The payload of the event.
+//   - routingKey: The routing key of the event.
+//
+// Returns:
+//   - An error if the event cannot be dispatched.
+func publish(dispatcher events.EventDispatcherInterface, newEvent func() events.EventInterface, payload interface{}, routingKey string) error {
+	event := newEvent()
+	event.SetPayload(payload)
+	return dispatcher.Dispatch(event, routingKey)
+}
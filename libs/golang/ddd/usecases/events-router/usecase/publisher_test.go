@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"sync"
+	"testing"
+
+	mockevent "libs/golang/ddd/events/event-mock/mock"
+	events "libs/golang/shared/go-events/amqp_events"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// eventFactory returns a factory building the same mock event, for the tests inspecting its payload.
+func eventFactory(event *mockevent.MockEvent) func() events.EventInterface {
+	return func() events.EventInterface { return event }
+}
+
+func TestPublishBuildsEventPerMessage(t *testing.T) {
+	dispatcherMock := new(mockevent.MockEventDispatcher)
+	var mu sync.Mutex
+	payloads := map[events.EventInterface]interface{}{}
+	dispatcherMock.On("Dispatch", mock.Anything, "key").Run(func(args mock.Arguments) {
+		event := args.Get(0).(*mockevent.MockEvent)
+		mu.Lock()
+		defer mu.Unlock()
+		payloads[event] = event.Calls[0].Arguments.Get(0)
+	}).Return(nil)
+	newEvent := func() events.EventInterface {
+		event := new(mockevent.MockEvent)
+		event.On("SetPayload", mock.Anything).Return()
+		return event
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			assert.NoError(t, publish(dispatcherMock, newEvent, i, "key"))
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Len(t, payloads, 10, "Every message is published with its own event")
+	seen := map[interface{}]bool{}
+	for _, payload := range payloads {
+		seen[payload] = true
+	}
+	assert.Len(t, seen, 10)
+}
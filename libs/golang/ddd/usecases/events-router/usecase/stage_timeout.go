@@ -0,0 +1,271 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"libs/golang/ddd/domain/entities/events-router/entity"
+	outputdto "libs/golang/ddd/dtos/events-router/output"
+	inputdto "libs/golang/ddd/dtos/input-broker/output"
+	inputshareddto "libs/golang/ddd/dtos/input-broker/shared"
+	events "libs/golang/shared/go-events/amqp_events"
+	"log"
+	"strings"
+	"time"
+)
+
+var (
+	timeoutErrorQueue   = "error.created.stage-timeout"
+	timeoutListenerTag  = "stage-timeout-sweeper"
+	timeoutStatus       = 408
+	timeoutStatusDetail = "timed out"
+
+	// awaitingJobStages are the stages in which an event order waits for its job to report back.
+	awaitingJobStages = []string{entity.StageDispatched, entity.StageProcessing}
+)
+
+// StageTimeouts holds the time the jobs have to report back, per provider and service.
+type StageTimeouts struct {
+	Default  time.Duration            // Timeout of the services without their own, no timeout when zero
+	Services map[string]time.Duration // Timeouts keyed by "provider/service"
+}
+
+// ParseStageTimeouts parses stage timeouts.
+//
+// Parameters:
+//   - defaultTimeout: The default timeout as a duration, such as "1h". Empty disables the default timeout.
+//   - spec: Comma-separated "provider/service=duration" pairs, such as "acme/crawler=30m,acme/reporter=2h".
+//
+// Returns:
+//   - The parsed timeouts.
+//   - An error if a duration or a pair is invalid.
+func ParseStageTimeouts(defaultTimeout, spec string) (StageTimeouts, error) {
+	timeouts := StageTimeouts{Services: map[string]time.Duration{}}
+	if defaultTimeout != "" {
+		timeout, err := time.ParseDuration(defaultTimeout)
+		if err != nil {
+			return StageTimeouts{}, fmt.Errorf("invalid default stage timeout: %w", err)
+		}
+		timeouts.Default = timeout
+	}
+
+	for _, pair := range strings.Split(spec, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(pair, "=")
+		provider, service, validKey := strings.Cut(strings.TrimSpace(key), "/")
+		if !ok || !validKey || provider == "" || service == "" {
+			return StageTimeouts{}, fmt.Errorf("invalid stage timeout %q, expected provider/service=duration", pair)
+		}
+		timeout, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil {
+			return StageTimeouts{}, fmt.Errorf("invalid stage timeout for %s/%s: %w", provider, service, err)
+		}
+		timeouts.Services[provider+"/"+service] = timeout
+	}
+	return timeouts, nil
+}
+
+// For returns the timeout of a provider's service.
+//
+// Parameters:
+//   - provider: The provider of the service.
+//   - service: The service.
+//
+// Returns:
+//   - The timeout of the service, or the default timeout. Zero means no timeout.
+func (t StageTimeouts) For(provider, service string) time.Duration {
+	if timeout, ok := t.Services[provider+"/"+service]; ok {
+		return timeout
+	}
+	return t.Default
+}
+
+// InputStatusUpdaterInterface updates the status of the input of a process order.
+type InputStatusUpdaterInterface interface {
+	Execute(inputMsg outputdto.ProcessOrderDTO, statusCode int, statusDetail string) error
+}
+
+// StageTimeoutSweeper notices the event orders whose job did not report back within the timeout of its
+// provider and service. An expired order is dispatched again while its re-dispatch budget lasts; after
+// that it fails, its input gets the timed out status and an error event is emitted.
+type StageTimeoutSweeper struct {
+	EventOrderRepository   entity.EventOrderRepositoryInterface
+	Timeouts               StageTimeouts
+	RedispatchBudget       int // Number of times an expired order is dispatched again before it fails
+	InputStatusUpdater     InputStatusUpdaterInterface
+	NewErrorCreated        func() events.EventInterface
+	NewProcessOrderCreated func() events.EventInterface
+	EventDispatcher        events.EventDispatcherInterface
+}
+
+// NewStageTimeoutSweeper creates a new instance of StageTimeoutSweeper.
+//
+// Parameters:
+//   - eventOrderRepository: The repository interface for event orders.
+//   - timeouts: The timeouts per provider and service.
+//   - redispatchBudget: The number of times an expired order is dispatched again before it fails.
+//   - inputStatusUpdater: The updater of the input statuses, such as an UpdateInputStatusAction.
+//   - newErrorCreated: Builds the error creation event of each failure.
+//   - newProcessOrderCreated: Builds the process order creation event of each dispatched order.
+//   - eventDispatcher: The event dispatcher interface.
+//
+// Returns:
+//   - A new instance of StageTimeoutSweeper.
+func NewStageTimeoutSweeper(
+	eventOrderRepository entity.EventOrderRepositoryInterface,
+	timeouts StageTimeouts,
+	redispatchBudget int,
+	inputStatusUpdater InputStatusUpdaterInterface,
+	newErrorCreated func() events.EventInterface,
+	newProcessOrderCreated func() events.EventInterface,
+	eventDispatcher events.EventDispatcherInterface,
+) *StageTimeoutSweeper {
+	return &StageTimeoutSweeper{
+		EventOrderRepository:   eventOrderRepository,
+		Timeouts:               timeouts,
+		RedispatchBudget:       redispatchBudget,
+		InputStatusUpdater:     inputStatusUpdater,
+		NewErrorCreated:        newErrorCreated,
+		NewProcessOrderCreated: newProcessOrderCreated,
+		EventDispatcher:        eventDispatcher,
+	}
+}
+
+// Run sweeps the event orders at each interval until the context is done.
+//
+// Parameters:
+//   - ctx: The context that stops the sweeper.
+//   - interval: The time between two sweeps.
+func (s *StageTimeoutSweeper) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := s.Sweep(now); err != nil {
+				log.Printf("Error sweeping event orders: %v", err)
+			}
+		}
+	}
+}
+
+// Sweep handles the event orders waiting for their job longer than their timeout. An order that moves
+// on while it is swept is left alone.
+//
+// Parameters:
+//   - now: The time the timeouts are measured against.
+//
+// Returns:
+//   - An error if the orders cannot be listed, or if any expired order cannot be handled.
+func (s *StageTimeoutSweeper) Sweep(now time.Time) error {
+	eventOrders, err := s.EventOrderRepository.FindByStages(awaitingJobStages...)
+	if err != nil {
+		return fmt.Errorf("failed to list event orders: %w", err)
+	}
+
+	var errs []error
+	for _, eventOrder := range eventOrders {
+		timeout := s.Timeouts.For(eventOrder.Provider, eventOrder.Service)
+		if timeout <= 0 {
+			continue
+		}
+		enteredAt, err := eventOrder.StageEnteredAt()
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if now.Sub(enteredAt) < timeout {
+			continue
+		}
+		if err := s.expire(eventOrder, timeout); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// expire dispatches an expired event order again, or fails it once its re-dispatch budget is spent. The
+// process order or the error event is published before the event order is updated, so an order whose event
+// cannot be published stays expired and is handled again by the next sweep. An order that moves on while it
+// is swept is left alone, though its event may already be published.
+//
+// Parameters:
+//   - eventOrder: The expired event order.
+//   - timeout: The timeout of the order.
+//
+// Returns:
+//   - An error if an event cannot be published, if the order cannot be updated, or if its input status
+//     cannot be updated.
+func (s *StageTimeoutSweeper) expire(eventOrder *entity.EventOrder, timeout time.Duration) error {
+	detail := fmt.Sprintf("timed out in stage %s after %s", eventOrder.Stage, timeout)
+	dto := newProcessOrderDTO(eventOrder)
+	if eventOrder.DispatchedData != nil {
+		// The process order is sent again as the pre-processing actions enriched or transformed it.
+		dto.Data = eventOrder.DispatchedData
+	}
+
+	if eventOrder.DispatchCount() <= s.RedispatchBudget {
+		routingKey := fmt.Sprintf("%s.%s.%s.%s", baseRoutingKey, dto.Provider, dto.Service, dto.Source)
+		if err := publish(s.EventDispatcher, s.NewProcessOrderCreated, dto, routingKey); err != nil {
+			return fmt.Errorf("failed to re-dispatch event order %s: %w", eventOrder.GetEntityID(), err)
+		}
+		_, err := s.EventOrderRepository.Redispatch(eventOrder.GetEntityID(), detail)
+		if errors.Is(err, entity.ErrIllegalStageTransition) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to record re-dispatch of event order %s: %w", eventOrder.GetEntityID(), err)
+		}
+		log.Printf("Event order %s %s, dispatched again", eventOrder.GetEntityID(), detail)
+		return nil
+	}
+
+	timeoutErr := newProcessingError(outputdto.ErrCategoryTimeout, outputdto.ErrCodeStageTimeout, errors.New(detail))
+	if err := s.dispatchError(eventOrder, dto, timeoutErr); err != nil {
+		return fmt.Errorf("failed to publish timeout of event order %s: %w", eventOrder.GetEntityID(), err)
+	}
+	_, err := s.EventOrderRepository.UpdateStage(eventOrder.GetEntityID(), entity.StageFailed, detail)
+	if errors.Is(err, entity.ErrIllegalStageTransition) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to fail event order %s: %w", eventOrder.GetEntityID(), err)
+	}
+	log.Printf("Event order %s %s, failed", eventOrder.GetEntityID(), detail)
+
+	if err := s.InputStatusUpdater.Execute(dto, timeoutStatus, timeoutStatusDetail); err != nil {
+		return fmt.Errorf("failed to update status of input %s: %w", dto.InputID, err)
+	}
+	return nil
+}
+
+// dispatchError publishes the error event of an event order that timed out.
+//
+// Parameters:
+//   - eventOrder: The event order, as it was before it failed.
+//   - dto: The process order of the event order.
+//   - err: The timeout error.
+//
+// Returns:
+//   - An error if the event cannot be published.
+func (s *StageTimeoutSweeper) dispatchError(eventOrder *entity.EventOrder, dto outputdto.ProcessOrderDTO, err error) error {
+	payload, _ := json.Marshal(dto)
+	input := inputdto.InputDTO{
+		ID: eventOrder.InputID,
+		Metadata: inputshareddto.MetadataDTO{
+			Provider:     eventOrder.Provider,
+			Service:      eventOrder.Service,
+			Source:       eventOrder.Source,
+			ProcessingID: eventOrder.ProcessingID,
+		},
+	}
+	errMsg := newErrMsg(err, payload, input, timeoutListenerTag, eventOrder.DispatchCount())
+	errMsg.Stage = eventOrder.Stage
+	return publish(s.EventDispatcher, s.NewErrorCreated, errMsg, timeoutErrorQueue)
+}
@@ -0,0 +1,224 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"libs/golang/ddd/domain/entities/events-router/entity"
+	mockrepository "libs/golang/ddd/domain/repositories/database/mock/events-router/repository"
+	outputdto "libs/golang/ddd/dtos/events-router/output"
+	mockevent "libs/golang/ddd/events/event-mock/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// inputStatusUpdaterMock is a mock of InputStatusUpdaterInterface.
+type inputStatusUpdaterMock struct {
+	mock.Mock
+}
+
+func (m *inputStatusUpdaterMock) Execute(inputMsg outputdto.ProcessOrderDTO, statusCode int, statusDetail string) error {
+	args := m.Called(inputMsg, statusCode, statusDetail)
+	return args.Error(0)
+}
+
+type StageTimeoutSweeperSuite struct {
+	suite.Suite
+	repoMock       *mockrepository.EventOrderRepositoryMock
+	statusMock     *inputStatusUpdaterMock
+	errorEvent     *mockevent.MockEvent
+	processEvent   *mockevent.MockEvent
+	dispatcherMock *mockevent.MockEventDispatcher
+	sweeper        *StageTimeoutSweeper
+	errMsg         outputdto.ErrMsgDTO
+	eventOrder     *entity.EventOrder
+	now            time.Time
+}
+
+func TestStageTimeoutSweeperSuite(t *testing.T) {
+	suite.Run(t, new(StageTimeoutSweeperSuite))
+}
+
+func (suite *StageTimeoutSweeperSuite) SetupTest() {
+	suite.repoMock = new(mockrepository.EventOrderRepositoryMock)
+	suite.statusMock = new(inputStatusUpdaterMock)
+	suite.errorEvent = new(mockevent.MockEvent)
+	suite.processEvent = new(mockevent.MockEvent)
+	suite.dispatcherMock = new(mockevent.MockEventDispatcher)
+	timeouts := StageTimeouts{
+		Default:  time.Hour,
+		Services: map[string]time.Duration{"prv/fast": time.Minute, "prv/untimed": 0},
+	}
+	suite.sweeper = NewStageTimeoutSweeper(suite.repoMock, timeouts, 1, suite.statusMock, eventFactory(suite.errorEvent), eventFactory(suite.processEvent), suite.dispatcherMock)
+	suite.errMsg = outputdto.ErrMsgDTO{}
+
+	suite.errorEvent.On("SetPayload", mock.Anything).Run(func(args mock.Arguments) {
+		suite.errMsg = args.Get(0).(outputdto.ErrMsgDTO)
+	}).Return()
+	suite.dispatcherMock.On("Dispatch", suite.errorEvent, timeoutErrorQueue).Return(nil)
+	suite.processEvent.On("SetPayload", mock.Anything).Return()
+
+	suite.eventOrder = suite.dispatchedOrder("svc")
+	suite.now = time.Now().Add(2 * time.Hour)
+}
+
+// dispatchedOrder returns an order of the service dispatched at the current time.
+func (suite *StageTimeoutSweeperSuite) dispatchedOrder(service string) *entity.EventOrder {
+	eventOrder, _ := entity.NewEventOrder(entity.EventOrderProps{
+		Service:      service,
+		Source:       "src",
+		Provider:     "prv",
+		ProcessingID: "proc-" + service,
+		InputID:      "input-" + service,
+	})
+	eventOrder.Transition(entity.StagePreProcessed, "")
+	eventOrder.Transition(entity.StageDispatched, "")
+	return eventOrder
+}
+
+func (suite *StageTimeoutSweeperSuite) TestRedispatchesWithinBudget() {
+	suite.repoMock.On("FindByStages", awaitingJobStages).Return([]*entity.EventOrder{suite.eventOrder}, nil)
+	calls := []string{}
+	suite.repoMock.On("Redispatch", suite.eventOrder.GetEntityID(), "timed out in stage dispatched after 1h0m0s").Run(func(mock.Arguments) {
+		calls = append(calls, "redispatch")
+	}).Return(suite.eventOrder, nil)
+	suite.dispatcherMock.On("Dispatch", suite.processEvent, "input.pre-processed.prv.svc.src").Run(func(mock.Arguments) {
+		calls = append(calls, "publish")
+	}).Return(nil)
+
+	err := suite.sweeper.Sweep(suite.now)
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), []string{"publish", "redispatch"}, calls, "The order is marked dispatched once it is published")
+	suite.repoMock.AssertNotCalled(suite.T(), "UpdateStage", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *StageTimeoutSweeperSuite) TestRedispatchResendsTransformedData() {
+	eventOrder, _ := entity.NewEventOrder(entity.EventOrderProps{
+		Service:      "svc",
+		Source:       "src",
+		Provider:     "prv",
+		ProcessingID: "proc-svc",
+		InputID:      "input-svc",
+		Data:         map[string]interface{}{"name": "raw"},
+	})
+	eventOrder.Transition(entity.StagePreProcessed, "")
+	eventOrder.Dispatch(map[string]interface{}{"name": "transformed", "region": "eu"})
+	suite.repoMock.On("FindByStages", awaitingJobStages).Return([]*entity.EventOrder{eventOrder}, nil)
+	suite.repoMock.On("Redispatch", eventOrder.GetEntityID(), mock.Anything).Return(eventOrder, nil)
+	var resent outputdto.ProcessOrderDTO
+	suite.processEvent.ExpectedCalls = nil
+	suite.processEvent.On("SetPayload", mock.Anything).Run(func(args mock.Arguments) {
+		resent = args.Get(0).(outputdto.ProcessOrderDTO)
+	}).Return()
+	suite.dispatcherMock.On("Dispatch", suite.processEvent, "input.pre-processed.prv.svc.src").Return(nil)
+
+	err := suite.sweeper.Sweep(suite.now)
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), map[string]interface{}{"name": "transformed", "region": "eu"}, resent.Data, "The order is resent as the pipeline transformed it")
+	assert.Equal(suite.T(), "input-svc", resent.InputID)
+}
+
+func (suite *StageTimeoutSweeperSuite) TestFailsWhenBudgetIsSpent() {
+	suite.eventOrder.Redispatch("timed out")
+	suite.repoMock.On("FindByStages", awaitingJobStages).Return([]*entity.EventOrder{suite.eventOrder}, nil)
+	suite.repoMock.On("UpdateStage", suite.eventOrder.GetEntityID(), entity.StageFailed, mock.Anything).Return(suite.eventOrder, nil)
+	suite.statusMock.On("Execute", mock.Anything, timeoutStatus, timeoutStatusDetail).Return(nil)
+
+	err := suite.sweeper.Sweep(suite.now)
+
+	assert.Nil(suite.T(), err)
+	suite.repoMock.AssertNotCalled(suite.T(), "Redispatch", mock.Anything, mock.Anything)
+	suite.statusMock.AssertCalled(suite.T(), "Execute", mock.MatchedBy(func(dto outputdto.ProcessOrderDTO) bool {
+		return dto.InputID == "input-svc"
+	}), timeoutStatus, timeoutStatusDetail)
+	assert.Equal(suite.T(), outputdto.ErrCategoryTimeout, suite.errMsg.Category)
+	assert.Equal(suite.T(), outputdto.ErrCodeStageTimeout, suite.errMsg.Code)
+	assert.Equal(suite.T(), entity.StageDispatched, suite.errMsg.Stage)
+	assert.Equal(suite.T(), "proc-svc", suite.errMsg.ProcessingID)
+	assert.Equal(suite.T(), 2, suite.errMsg.Attempt)
+}
+
+func (suite *StageTimeoutSweeperSuite) TestSkipsOrdersWithinTimeout() {
+	untimed := suite.dispatchedOrder("untimed")
+	fast := suite.dispatchedOrder("fast")
+	suite.repoMock.On("FindByStages", awaitingJobStages).Return([]*entity.EventOrder{suite.eventOrder, untimed, fast}, nil)
+	suite.repoMock.On("Redispatch", fast.GetEntityID(), mock.Anything).Return(fast, nil)
+	suite.dispatcherMock.On("Dispatch", suite.processEvent, "input.pre-processed.prv.fast.src").Return(nil)
+
+	err := suite.sweeper.Sweep(time.Now().Add(2 * time.Minute))
+
+	assert.Nil(suite.T(), err)
+	suite.repoMock.AssertNumberOfCalls(suite.T(), "Redispatch", 1)
+}
+
+func (suite *StageTimeoutSweeperSuite) TestOrderMovedOnWhileSwept() {
+	illegal := errors.Join(entity.ErrIllegalStageTransition)
+	suite.repoMock.On("FindByStages", awaitingJobStages).Return([]*entity.EventOrder{suite.eventOrder}, nil)
+	suite.repoMock.On("Redispatch", suite.eventOrder.GetEntityID(), mock.Anything).Return(nil, illegal)
+	suite.dispatcherMock.On("Dispatch", suite.processEvent, "input.pre-processed.prv.svc.src").Return(nil)
+
+	err := suite.sweeper.Sweep(suite.now)
+
+	assert.Nil(suite.T(), err)
+	suite.dispatcherMock.AssertNumberOfCalls(suite.T(), "Dispatch", 1)
+}
+
+func (suite *StageTimeoutSweeperSuite) TestPublishFailureLeavesOrderExpired() {
+	suite.repoMock.On("FindByStages", awaitingJobStages).Return([]*entity.EventOrder{suite.eventOrder}, nil)
+	suite.dispatcherMock.On("Dispatch", suite.processEvent, "input.pre-processed.prv.svc.src").Return(errors.New("channel closed"))
+
+	err := suite.sweeper.Sweep(suite.now)
+
+	assert.ErrorContains(suite.T(), err, "failed to re-dispatch event order "+suite.eventOrder.GetEntityID()+": channel closed")
+	suite.repoMock.AssertNotCalled(suite.T(), "Redispatch", mock.Anything, mock.Anything)
+}
+
+func (suite *StageTimeoutSweeperSuite) TestErrorEventFailureLeavesOrderExpired() {
+	suite.sweeper.RedispatchBudget = 0
+	suite.dispatcherMock.ExpectedCalls = nil
+	suite.dispatcherMock.On("Dispatch", suite.errorEvent, timeoutErrorQueue).Return(errors.New("channel closed"))
+	suite.repoMock.On("FindByStages", awaitingJobStages).Return([]*entity.EventOrder{suite.eventOrder}, nil)
+
+	err := suite.sweeper.Sweep(suite.now)
+
+	assert.ErrorContains(suite.T(), err, "failed to publish timeout of event order "+suite.eventOrder.GetEntityID()+": channel closed")
+	suite.repoMock.AssertNotCalled(suite.T(), "UpdateStage", mock.Anything, mock.Anything, mock.Anything)
+	suite.statusMock.AssertNotCalled(suite.T(), "Execute", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *StageTimeoutSweeperSuite) TestInputStatusFailure() {
+	suite.sweeper.RedispatchBudget = 0
+	suite.repoMock.On("FindByStages", awaitingJobStages).Return([]*entity.EventOrder{suite.eventOrder}, nil)
+	suite.repoMock.On("UpdateStage", suite.eventOrder.GetEntityID(), entity.StageFailed, mock.Anything).Return(suite.eventOrder, nil)
+	suite.statusMock.On("Execute", mock.Anything, timeoutStatus, timeoutStatusDetail).Return(errors.New("input-broker unavailable"))
+
+	err := suite.sweeper.Sweep(suite.now)
+
+	assert.ErrorContains(suite.T(), err, "input-broker unavailable")
+	assert.Equal(suite.T(), outputdto.ErrCodeStageTimeout, suite.errMsg.Code)
+}
+
+func (suite *StageTimeoutSweeperSuite) TestParseStageTimeouts() {
+	timeouts, err := ParseStageTimeouts("1h", " acme/crawler=30m, acme/reporter=2h ,")
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 30*time.Minute, timeouts.For("acme", "crawler"))
+	assert.Equal(suite.T(), 2*time.Hour, timeouts.For("acme", "reporter"))
+	assert.Equal(suite.T(), time.Hour, timeouts.For("acme", "other"))
+
+	timeouts, err = ParseStageTimeouts("", "")
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), time.Duration(0), timeouts.For("acme", "crawler"))
+
+	_, err = ParseStageTimeouts("", "acme=30m")
+	assert.NotNil(suite.T(), err)
+	_, err = ParseStageTimeouts("", "acme/crawler=soon")
+	assert.NotNil(suite.T(), err)
+	_, err = ParseStageTimeouts("forever", "")
+	assert.NotNil(suite.T(), err)
+}
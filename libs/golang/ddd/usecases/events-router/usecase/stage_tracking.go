@@ -0,0 +1,168 @@
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"libs/golang/ddd/domain/entities/events-router/entity"
+	outputdto "libs/golang/ddd/dtos/events-router/output"
+	inputdto "libs/golang/ddd/dtos/input-broker/output"
+	inputshareddto "libs/golang/ddd/dtos/input-broker/shared"
+	usecaseprotocol "libs/golang/server/events/usecase-impl/protocol"
+	events "libs/golang/shared/go-events/amqp_events"
+	"log"
+)
+
+var (
+	stageTrackingErrorQueue = "error.created.stage-tracking"
+	stageTrackingStage      = "stage-tracking"
+)
+
+// stageEvent is the part of a message that identifies the event order to advance.
+type stageEvent struct {
+	ProcessingID string
+	InputID      string
+	Detail       string
+}
+
+// stageEventDecoder extracts the stage event of a message.
+type stageEventDecoder func(msg []byte) (stageEvent, error)
+
+// StageTrackingUseCase advances event orders to a stage when the messages reporting it are consumed.
+type StageTrackingUseCase struct {
+	EventOrderRepository entity.EventOrderRepositoryInterface
+	Stage                string
+	NewErrorCreated      func() events.EventInterface
+	EventDispatcher      events.EventDispatcherInterface
+	decode               stageEventDecoder
+}
+
+// NewProcessingStartedUseCase creates a StageTrackingUseCase that moves event orders to the processing stage
+// when the jobs report the process orders they started (input.processing.*).
+//
+// Parameters:
+//   - eventOrderRepository: The repository interface for event orders.
+//   - newErrorCreated: Builds the error creation event of each failure.
+//   - eventDispatcher: The event dispatcher interface.
+//
+// Returns:
+//   - A new instance of StageTrackingUseCase.
+func NewProcessingStartedUseCase(
+	eventOrderRepository entity.EventOrderRepositoryInterface,
+	newErrorCreated func() events.EventInterface,
+	eventDispatcher events.EventDispatcherInterface,
+) *StageTrackingUseCase {
+	return &StageTrackingUseCase{
+		EventOrderRepository: eventOrderRepository,
+		Stage:                entity.StageProcessing,
+		NewErrorCreated:      newErrorCreated,
+		EventDispatcher:      eventDispatcher,
+		decode:               decodeProcessOrder,
+	}
+}
+
+// ProcessMessageChannel advances the event order of each message to the stage of the use case.
+//
+// Messages that cannot be unmarshalled, and messages reporting an illegal transition, are dead-lettered
+// straight away. Other failures are nacked for redelivery.
+//
+// Parameters:
+//   - msgCh: The channel from which message deliveries are received.
+//   - listenerTag: The tag of the listener processing the messages.
+func (uc *StageTrackingUseCase) ProcessMessageChannel(msgCh <-chan usecaseprotocol.DeliveryInterface, listenerTag string) {
+	for delivery := range msgCh {
+		msg := delivery.Body()
+		attempt := delivery.RetryCount() + 1
+		event, err := uc.decode(msg)
+		if err != nil {
+			log.Printf("Error unmarshalling message: %v", err)
+			err = newProcessingError(outputdto.ErrCategoryUnmarshal, outputdto.ErrCodeMalformedMessage, err)
+			uc.dispatchError(err, msg, stageEvent{}, listenerTag, attempt)
+			uc.settle(delivery, delivery.Nack(false))
+			continue
+		}
+
+		err = uc.execute(event)
+		if err != nil {
+			log.Printf("Error moving event order to %s: %v", uc.Stage, err)
+			uc.dispatchError(err, msg, event, listenerTag, attempt)
+			uc.settle(delivery, delivery.Nack(retryable(err)))
+			continue
+		}
+		uc.settle(delivery, delivery.Ack())
+	}
+}
+
+// execute moves the event order of a processing to the stage of the use case.
+//
+// Parameters:
+//   - event: The stage event of the message.
+//
+// Returns:
+//   - A ProcessingError if the event order cannot be found or moved.
+func (uc *StageTrackingUseCase) execute(event stageEvent) error {
+	eventOrder, err := uc.EventOrderRepository.FindByProcessingID(event.ProcessingID)
+	if err != nil {
+		return newProcessingError(outputdto.ErrCategoryRepository, outputdto.ErrCodeEventOrderNotFound, err)
+	}
+
+	_, err = uc.EventOrderRepository.UpdateStage(eventOrder.GetEntityID(), uc.Stage, event.Detail)
+	if errors.Is(err, entity.ErrIllegalStageTransition) {
+		return newProcessingError(outputdto.ErrCategoryStageTransition, outputdto.ErrCodeIllegalTransition, err)
+	}
+	if err != nil {
+		return newProcessingError(outputdto.ErrCategoryRepository, outputdto.ErrCodeEventOrderPersistence, fmt.Errorf("failed to move event order to %s: %w", uc.Stage, err))
+	}
+	return nil
+}
+
+// dispatchError dispatches an error event describing why a message could not advance its event order.
+//
+// Parameters:
+//   - err: The error to be dispatched, classified by a ProcessingError.
+//   - msg: The original message that caused the error.
+//   - event: The decoded stage event, empty if it could not be unmarshalled.
+//   - listenerTag: The tag of the listener that processed the message.
+//   - attempt: The delivery attempt of the message, starting at 1.
+func (uc *StageTrackingUseCase) dispatchError(err error, msg []byte, event stageEvent, listenerTag string, attempt int) {
+	input := inputdto.InputDTO{
+		ID:       event.InputID,
+		Metadata: inputshareddto.MetadataDTO{ProcessingID: event.ProcessingID},
+	}
+	errMsg := newErrMsg(err, msg, input, listenerTag, attempt)
+	errMsg.Stage = stageTrackingStage
+	publish(uc.EventDispatcher, uc.NewErrorCreated, errMsg, stageTrackingErrorQueue)
+}
+
+// settle logs the outcome of acknowledging or rejecting a delivery.
+//
+// Parameters:
+//   - delivery: The delivery that was settled.
+//   - err: The error returned by Ack or Nack, if any.
+func (uc *StageTrackingUseCase) settle(delivery usecaseprotocol.DeliveryInterface, err error) {
+	if err != nil {
+		log.Printf("Error settling message %s: %v", string(delivery.Body()), err)
+	}
+}
+
+// decodeProcessOrder extracts the stage event of a process order.
+//
+// Parameters:
+//   - msg: The process order, as dispatched by the pre-processing.
+//
+// Returns:
+//   - The stage event of the order.
+//   - An error if the message is not a process order.
+func decodeProcessOrder(msg []byte) (stageEvent, error) {
+	var order outputdto.ProcessOrderDTO
+	if err := json.Unmarshal(msg, &order); err != nil {
+		return stageEvent{}, err
+	}
+	if order.ProcessingID == "" {
+		return stageEvent{}, errors.New("process order has no processing ID")
+	}
+	return stageEvent{
+		ProcessingID: order.ProcessingID,
+		InputID:      order.InputID,
+	}, nil
+}
@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"libs/golang/ddd/domain/entities/events-router/entity"
+	mockrepository "libs/golang/ddd/domain/repositories/database/mock/events-router/repository"
+	outputdto "libs/golang/ddd/dtos/events-router/output"
+	mockevent "libs/golang/ddd/events/event-mock/mock"
+	usecaseprotocol "libs/golang/server/events/usecase-impl/protocol"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type StageTrackingUseCaseSuite struct {
+	suite.Suite
+	repoMock       *mockrepository.EventOrderRepositoryMock
+	errorEvent     *mockevent.MockEvent
+	dispatcherMock *mockevent.MockEventDispatcher
+	useCase        *StageTrackingUseCase
+	errMsg         outputdto.ErrMsgDTO
+	eventOrder     *entity.EventOrder
+}
+
+func TestStageTrackingUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(StageTrackingUseCaseSuite))
+}
+
+func (suite *StageTrackingUseCaseSuite) SetupTest() {
+	suite.repoMock = new(mockrepository.EventOrderRepositoryMock)
+	suite.errorEvent = new(mockevent.MockEvent)
+	suite.dispatcherMock = new(mockevent.MockEventDispatcher)
+	suite.useCase = NewProcessingStartedUseCase(suite.repoMock, eventFactory(suite.errorEvent), suite.dispatcherMock)
+	suite.errMsg = outputdto.ErrMsgDTO{}
+
+	suite.errorEvent.On("SetPayload", mock.Anything).Run(func(args mock.Arguments) {
+		suite.errMsg = args.Get(0).(outputdto.ErrMsgDTO)
+	}).Return()
+	suite.dispatcherMock.On("Dispatch", suite.errorEvent, stageTrackingErrorQueue).Return(nil)
+
+	suite.eventOrder, _ = entity.NewEventOrder(entity.EventOrderProps{
+		Service:      "svc",
+		Source:       "src",
+		Provider:     "prv",
+		ProcessingID: "proc-1",
+		InputID:      "input-1",
+	})
+}
+
+// process runs a single message through the use case.
+func (suite *StageTrackingUseCaseSuite) process(msg string) *fakeDelivery {
+	delivery := &fakeDelivery{body: []byte(msg)}
+	msgCh := make(chan usecaseprotocol.DeliveryInterface, 1)
+	msgCh <- delivery
+	close(msgCh)
+	suite.useCase.ProcessMessageChannel(msgCh, "listener-1")
+	return delivery
+}
+
+const startedOrder = `{"_id":"order-1","processing_id":"proc-1","input_id":"input-1"}`
+
+func (suite *StageTrackingUseCaseSuite) TestProcessingStarted() {
+	suite.repoMock.On("FindByProcessingID", "proc-1").Return(suite.eventOrder, nil)
+	suite.repoMock.On("UpdateStage", suite.eventOrder.GetEntityID(), entity.StageProcessing, "").Return(suite.eventOrder, nil)
+
+	delivery := suite.process(startedOrder)
+
+	assert.True(suite.T(), delivery.acked)
+	suite.repoMock.AssertExpectations(suite.T())
+}
+
+func (suite *StageTrackingUseCaseSuite) TestIllegalTransitionIsDeadLettered() {
+	illegal := fmt.Errorf("%w: from %q to %q", entity.ErrIllegalStageTransition, entity.StageReceived, entity.StageProcessing)
+	suite.repoMock.On("FindByProcessingID", "proc-1").Return(suite.eventOrder, nil)
+	suite.repoMock.On("UpdateStage", suite.eventOrder.GetEntityID(), entity.StageProcessing, mock.Anything).Return(nil, illegal)
+
+	delivery := suite.process(startedOrder)
+
+	assert.True(suite.T(), delivery.nacked)
+	assert.False(suite.T(), delivery.requeued)
+	assert.Equal(suite.T(), outputdto.ErrCategoryStageTransition, suite.errMsg.Category)
+	assert.Equal(suite.T(), outputdto.ErrCodeIllegalTransition, suite.errMsg.Code)
+	assert.Equal(suite.T(), stageTrackingStage, suite.errMsg.Stage)
+	assert.Equal(suite.T(), "proc-1", suite.errMsg.ProcessingID)
+	assert.Equal(suite.T(), "input-1", suite.errMsg.InputID)
+}
+
+func (suite *StageTrackingUseCaseSuite) TestUnknownOrderIsRetried() {
+	suite.repoMock.On("FindByProcessingID", "proc-1").Return(nil, errors.New("not found"))
+
+	delivery := suite.process(startedOrder)
+
+	assert.True(suite.T(), delivery.requeued)
+	assert.Equal(suite.T(), outputdto.ErrCodeEventOrderNotFound, suite.errMsg.Code)
+}
+
+func (suite *StageTrackingUseCaseSuite) TestOrderWithoutProcessingID() {
+	delivery := suite.process(`{"_id":"order-1"}`)
+
+	assert.True(suite.T(), delivery.nacked)
+	assert.False(suite.T(), delivery.requeued)
+	assert.Equal(suite.T(), outputdto.ErrCodeMalformedMessage, suite.errMsg.Code)
+}
@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"errors"
 	"fmt"
 	"libs/golang/ddd/domain/entities/input-broker/entity"
 	inputdto "libs/golang/ddd/dtos/input-broker/input"
@@ -10,15 +11,16 @@ import (
 )
 
 var (
-	exchangeName = "services"
-	routingKey   = "input.created"
+	routingKey = "input.created"
+
+	// ErrIdempotencyKeyReused is returned when an idempotency key is sent again with a different input.
+	ErrIdempotencyKeyReused = errors.New("idempotency key already used for a different input")
 )
 
 // CreateInputUseCase represents the use case for creating an input.
 type CreateInputUseCase struct {
 	InputRepository entity.InputRepositoryInterface
-	InputCreated    events.EventInterface
-	EventDispatcher events.EventDispatcherInterface
+	NewInputCreated func() events.EventInterface
 }
 
 // NewCreateInputUseCase creates a new CreateInputUseCase.
@@ -26,25 +28,24 @@ type CreateInputUseCase struct {
 // Parameters:
 //
 //	inputRepository: The repository interface for managing Input entities.
-//	inputCreated: The event to be dispatched when an input is created.
-//	eventDispatcher: The event dispatcher to dispatch the input created event.
+//	newInputCreated: Builds the event stored in the outbox when an input is created. Each input gets its own
+//	event, so concurrent executions never share a payload.
 //
 // Returns:
 //
 //	A pointer to an instance of CreateInputUseCase.
 func NewCreateInputUseCase(
 	inputRepository entity.InputRepositoryInterface,
-	inputCreated events.EventInterface,
-	eventDispatcher events.EventDispatcherInterface,
+	newInputCreated func() events.EventInterface,
 ) *CreateInputUseCase {
 	return &CreateInputUseCase{
 		InputRepository: inputRepository,
-		InputCreated:    inputCreated,
-		EventDispatcher: eventDispatcher,
+		NewInputCreated: newInputCreated,
 	}
 }
 
-// Execute creates a new input entity based on the provided input DTO and saves it using the repository.
+// Execute creates a new input entity based on the provided input DTO and saves it using the repository,
+// together with the InputCreated event in the outbox so the event is published once the input is stored.
 // It then returns the created entity and an error if any occurred during the process.
 //
 // Parameters:
@@ -55,6 +56,26 @@ func NewCreateInputUseCase(
 //
 //	An input DTO containing the created input data, and an error if any occurred during the process.
 func (uc *CreateInputUseCase) Execute(input inputdto.InputDTO) (outputdto.InputDTO, error) {
+	dto, _, err := uc.ExecuteWithIdempotencyKey(input, "")
+	return dto, err
+}
+
+// ExecuteWithIdempotencyKey creates a new input entity like Execute, unless an input was already created
+// with the same idempotency key. A retried request then gets the stored input back instead of an error,
+// and no InputCreated event is stored again. This holds when the retry runs concurrently with the original
+// request: the repository rejects a second input with the same key, and the input stored first is replayed.
+//
+// Parameters:
+//
+//	input: The input DTO containing the input data.
+//	idempotencyKey: The key identifying the request, or an empty string to always create the input.
+//
+// Returns:
+//
+//	An input DTO containing the created or stored input data, whether the stored input was replayed,
+//	and an error if any occurred during the process. ErrIdempotencyKeyReused is returned when the key
+//	was used to create a different input.
+func (uc *CreateInputUseCase) ExecuteWithIdempotencyKey(input inputdto.InputDTO, idempotencyKey string) (outputdto.InputDTO, bool, error) {
 	inputProps := entity.InputProps{
 		Provider: input.Provider,
 		Service:  input.Service,
@@ -64,25 +85,73 @@ func (uc *CreateInputUseCase) Execute(input inputdto.InputDTO) (outputdto.InputD
 
 	entityInput, err := entity.NewInput(inputProps)
 	if err != nil {
-		return outputdto.InputDTO{}, err
+		return outputdto.InputDTO{}, false, err
 	}
 
-	err = uc.InputRepository.Create(entityInput)
-	if err != nil {
-		return outputdto.InputDTO{}, err
+	if idempotencyKey != "" {
+		stored, err := uc.InputRepository.FindByIdempotencyKey(idempotencyKey)
+		if err != nil {
+			return outputdto.InputDTO{}, false, err
+		}
+		if stored != nil {
+			return replay(stored, entityInput)
+		}
+		entityInput.SetIdempotencyKey(idempotencyKey)
 	}
 
-	dto := outputdto.InputDTO{
-		ID:        string(entityInput.ID),
-		Data:      input.Data,
-		Metadata:  converter.ConvertMetadataEntityToDTO(entityInput.Metadata),
-		Status:    converter.ConvertStatusEntityToDTO(entityInput.Status),
-		CreatedAt: entityInput.CreatedAt,
-		UpdatedAt: entityInput.UpdatedAt,
+	dto := newInputDTO(entityInput)
+	inputCreated := uc.NewInputCreated()
+	inputCreated.SetPayload(dto)
+	eventRoutingKey := fmt.Sprintf("%s.%s.%s.%s", routingKey, input.Provider, input.Service, input.Source)
+	err = uc.InputRepository.CreateWithEvent(entityInput, inputCreated, eventRoutingKey)
+	if err != nil {
+		if idempotencyKey != "" && errors.Is(err, entity.ErrAlreadyExists) {
+			// A concurrent request with the same key may have created the input since it was looked up.
+			stored, findErr := uc.InputRepository.FindByIdempotencyKey(idempotencyKey)
+			if findErr == nil && stored != nil {
+				return replay(stored, entityInput)
+			}
+		}
+		return outputdto.InputDTO{}, false, err
 	}
 
-	uc.InputCreated.SetPayload(dto)
-	uc.EventDispatcher.Dispatch(uc.InputCreated, fmt.Sprintf("%s.%s.%s.%s", routingKey, input.Provider, input.Service, input.Source))
+	return dto, false, nil
+}
 
-	return dto, nil
+// replay returns the input stored with the idempotency key of a retried request.
+//
+// Parameters:
+//
+//	stored: The input created with the key.
+//	requested: The input of the retried request.
+//
+// Returns:
+//
+//	The output DTO of the stored input and true, or ErrIdempotencyKeyReused when the key was used to create a
+//	different input.
+func replay(stored, requested *entity.Input) (outputdto.InputDTO, bool, error) {
+	if stored.ID != requested.ID {
+		return outputdto.InputDTO{}, false, ErrIdempotencyKeyReused
+	}
+	return newInputDTO(stored), true, nil
+}
+
+// newInputDTO converts an input entity to its output DTO.
+//
+// Parameters:
+//
+//	input: The input entity.
+//
+// Returns:
+//
+//	The output DTO of the input.
+func newInputDTO(input *entity.Input) outputdto.InputDTO {
+	return outputdto.InputDTO{
+		ID:        string(input.ID),
+		Data:      input.Data,
+		Metadata:  converter.ConvertMetadataEntityToDTO(input.Metadata),
+		Status:    converter.ConvertStatusEntityToDTO(input.Status),
+		CreatedAt: input.CreatedAt,
+		UpdatedAt: input.UpdatedAt,
+	}
 }
@@ -9,6 +9,7 @@ import (
 	inputdto "libs/golang/ddd/dtos/input-broker/input"
 	outputdto "libs/golang/ddd/dtos/input-broker/output"
 	mockevent "libs/golang/ddd/events/event-mock/mock"
+	events "libs/golang/shared/go-events/amqp_events"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -17,12 +18,11 @@ import (
 
 type CreateInputUseCaseSuite struct {
 	suite.Suite
-	repoMock       *mockrepository.InputRepositoryMock
-	eventMock      *mockevent.MockEvent
-	dispatcherMock *mockevent.MockEventDispatcher
-	useCase        *CreateInputUseCase
-	inputDTO       inputdto.InputDTO
-	inputProps     entity.InputProps
+	repoMock   *mockrepository.InputRepositoryMock
+	eventMock  *mockevent.MockEvent
+	useCase    *CreateInputUseCase
+	inputDTO   inputdto.InputDTO
+	inputProps entity.InputProps
 }
 
 func TestCreateInputUseCaseSuite(t *testing.T) {
@@ -32,8 +32,7 @@ func TestCreateInputUseCaseSuite(t *testing.T) {
 func (suite *CreateInputUseCaseSuite) SetupTest() {
 	suite.repoMock = new(mockrepository.InputRepositoryMock)
 	suite.eventMock = new(mockevent.MockEvent)
-	suite.dispatcherMock = new(mockevent.MockEventDispatcher)
-	suite.useCase = NewCreateInputUseCase(suite.repoMock, suite.eventMock, suite.dispatcherMock)
+	suite.useCase = NewCreateInputUseCase(suite.repoMock, func() events.EventInterface { return suite.eventMock })
 	suite.inputDTO = inputdto.InputDTO{
 		Provider: "test_provider",
 		Service:  "test_service",
@@ -50,9 +49,8 @@ func (suite *CreateInputUseCaseSuite) SetupTest() {
 
 func (suite *CreateInputUseCaseSuite) TestExecuteWhenSuccess() {
 	expectedInput, _ := entity.NewInput(suite.inputProps)
-	suite.repoMock.On("Create", expectedInput).Return(nil)
 	suite.eventMock.On("SetPayload", mock.Anything).Return()
-	suite.dispatcherMock.On("Dispatch", suite.eventMock, fmt.Sprintf("input.created.%s.%s.%s", suite.inputDTO.Provider, suite.inputDTO.Service, suite.inputDTO.Source)).Return(nil)
+	suite.repoMock.On("CreateWithEvent", expectedInput, suite.eventMock, fmt.Sprintf("input.created.%s.%s.%s", suite.inputDTO.Provider, suite.inputDTO.Service, suite.inputDTO.Source)).Return(nil)
 
 	output, err := suite.useCase.Execute(suite.inputDTO)
 
@@ -63,18 +61,101 @@ func (suite *CreateInputUseCaseSuite) TestExecuteWhenSuccess() {
 	assert.Equal(suite.T(), suite.inputDTO.Data, output.Data)
 	suite.repoMock.AssertExpectations(suite.T())
 	suite.eventMock.AssertExpectations(suite.T())
-	suite.dispatcherMock.AssertExpectations(suite.T())
 }
 
 func (suite *CreateInputUseCaseSuite) TestExecuteWhenErrorCreatingInput() {
 	expectedInput, _ := entity.NewInput(suite.inputProps)
-	suite.repoMock.On("Create", expectedInput).Return(fmt.Errorf("Input with ID: %s already exists", expectedInput.ID))
+	suite.eventMock.On("SetPayload", mock.Anything).Return()
+	suite.repoMock.On("CreateWithEvent", expectedInput, suite.eventMock, mock.Anything).Return(fmt.Errorf("Input with ID: %s already exists", expectedInput.ID))
 
 	input, err := suite.useCase.Execute(suite.inputDTO)
 
 	assert.NotNil(suite.T(), err)
 	assert.Equal(suite.T(), outputdto.InputDTO{}, input)
 	suite.repoMock.AssertExpectations(suite.T())
+}
+
+func (suite *CreateInputUseCaseSuite) TestExecuteBuildsEventPerInput() {
+	var built []*mockevent.MockEvent
+	suite.useCase.NewInputCreated = func() events.EventInterface {
+		event := new(mockevent.MockEvent)
+		event.On("SetPayload", mock.Anything).Return()
+		built = append(built, event)
+		return event
+	}
+	suite.repoMock.On("CreateWithEvent", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	other := suite.inputDTO
+	other.Data = map[string]interface{}{"key": "other"}
+
+	first, _ := suite.useCase.Execute(suite.inputDTO)
+	second, _ := suite.useCase.Execute(other)
+
+	assert.Len(suite.T(), built, 2)
+	built[0].AssertCalled(suite.T(), "SetPayload", first)
+	built[1].AssertCalled(suite.T(), "SetPayload", second)
+	suite.repoMock.AssertCalled(suite.T(), "CreateWithEvent", mock.Anything, built[0], mock.Anything)
+	suite.repoMock.AssertCalled(suite.T(), "CreateWithEvent", mock.Anything, built[1], mock.Anything)
+}
+
+func (suite *CreateInputUseCaseSuite) TestExecuteWithIdempotencyKeyWhenNew() {
+	expectedInput, _ := entity.NewInput(suite.inputProps)
+	expectedInput.SetIdempotencyKey("request-1")
+	suite.eventMock.On("SetPayload", mock.Anything).Return()
+	suite.repoMock.On("FindByIdempotencyKey", "request-1").Return(nil, nil)
+	suite.repoMock.On("CreateWithEvent", expectedInput, suite.eventMock, mock.Anything).Return(nil)
+
+	output, replayed, err := suite.useCase.ExecuteWithIdempotencyKey(suite.inputDTO, "request-1")
+
+	assert.Nil(suite.T(), err)
+	assert.False(suite.T(), replayed)
+	assert.Equal(suite.T(), string(expectedInput.ID), output.ID)
+	suite.repoMock.AssertExpectations(suite.T())
+}
+
+func (suite *CreateInputUseCaseSuite) TestExecuteWithIdempotencyKeyWhenReplayed() {
+	storedInput, _ := entity.NewInput(suite.inputProps)
+	storedInput.SetStatus(200, "completed")
+	suite.repoMock.On("FindByIdempotencyKey", "request-1").Return(storedInput, nil)
+
+	output, replayed, err := suite.useCase.ExecuteWithIdempotencyKey(suite.inputDTO, "request-1")
+
+	assert.Nil(suite.T(), err)
+	assert.True(suite.T(), replayed)
+	assert.Equal(suite.T(), string(storedInput.ID), output.ID)
+	assert.Equal(suite.T(), 200, output.Status.Code)
+	suite.repoMock.AssertNotCalled(suite.T(), "CreateWithEvent", mock.Anything, mock.Anything, mock.Anything)
 	suite.eventMock.AssertNotCalled(suite.T(), "SetPayload", mock.Anything)
-	suite.dispatcherMock.AssertNotCalled(suite.T(), "Dispatch", mock.Anything, mock.Anything)
+}
+
+func (suite *CreateInputUseCaseSuite) TestExecuteWithIdempotencyKeyWhenCreatedConcurrently() {
+	storedInput, _ := entity.NewInput(suite.inputProps)
+	storedInput.SetIdempotencyKey("request-1")
+	suite.eventMock.On("SetPayload", mock.Anything).Return()
+	suite.repoMock.On("FindByIdempotencyKey", "request-1").Return(nil, nil).Once()
+	suite.repoMock.On("FindByIdempotencyKey", "request-1").Return(storedInput, nil).Once()
+	suite.repoMock.On("CreateWithEvent", mock.Anything, suite.eventMock, mock.Anything).Return(fmt.Errorf("%w: ID %s", entity.ErrAlreadyExists, storedInput.ID))
+
+	output, replayed, err := suite.useCase.ExecuteWithIdempotencyKey(suite.inputDTO, "request-1")
+
+	assert.Nil(suite.T(), err)
+	assert.True(suite.T(), replayed)
+	assert.Equal(suite.T(), string(storedInput.ID), output.ID)
+	suite.repoMock.AssertExpectations(suite.T())
+}
+
+func (suite *CreateInputUseCaseSuite) TestExecuteWithIdempotencyKeyWhenReused() {
+	otherInput, _ := entity.NewInput(entity.InputProps{
+		Provider: "test_provider",
+		Service:  "test_service",
+		Source:   "test_source",
+		Data:     map[string]interface{}{"key": "other"},
+	})
+	suite.repoMock.On("FindByIdempotencyKey", "request-1").Return(otherInput, nil)
+
+	output, replayed, err := suite.useCase.ExecuteWithIdempotencyKey(suite.inputDTO, "request-1")
+
+	assert.ErrorIs(suite.T(), err, ErrIdempotencyKeyReused)
+	assert.False(suite.T(), replayed)
+	assert.Equal(suite.T(), outputdto.InputDTO{}, output)
+	suite.repoMock.AssertNotCalled(suite.T(), "CreateWithEvent", mock.Anything, mock.Anything, mock.Anything)
 }
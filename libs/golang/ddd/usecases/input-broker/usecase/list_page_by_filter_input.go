@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"libs/golang/ddd/domain/entities/input-broker/entity"
+	inputdto "libs/golang/ddd/dtos/input-broker/input"
+	outputdto "libs/golang/ddd/dtos/input-broker/output"
+	"libs/golang/ddd/shared/type-tools/custom-types-converter/input-broker/converter"
+)
+
+// ListPageByFilterInputUseCase is the use case for listing a page of the inputs selected by a filter.
+type ListPageByFilterInputUseCase struct {
+	InputRepository entity.InputRepositoryInterface
+}
+
+// NewListPageByFilterInputUseCase initializes a new instance of ListPageByFilterInputUseCase with the provided InputRepositoryInterface.
+//
+// Parameters:
+//
+//	inputRepository: The repository interface for managing Input entities.
+//
+// Returns:
+//
+//	A pointer to an instance of ListPageByFilterInputUseCase.
+func NewListPageByFilterInputUseCase(
+	inputRepository entity.InputRepositoryInterface,
+) *ListPageByFilterInputUseCase {
+	return &ListPageByFilterInputUseCase{
+		InputRepository: inputRepository,
+	}
+}
+
+// Execute retrieves the inputs selected by the filter from the repository and converts them to output DTOs.
+// An empty filter selects every input.
+//
+// Parameters:
+//
+//	filterDTO: The filter selecting the inputs and the page.
+//
+// Returns:
+//
+//	A slice of output DTOs containing the input data, and an error if any occurred during the process.
+func (uc *ListPageByFilterInputUseCase) Execute(filterDTO inputdto.InputFilterDTO) (outputdto.InputPageDTO, error) {
+	page, err := converter.ConvertInputFilterDTOToPage(filterDTO)
+	if err != nil {
+		return outputdto.InputPageDTO{}, err
+	}
+
+	inputs, next, err := uc.InputRepository.FindPageByFilter(converter.ConvertInputFilterDTOToEntity(filterDTO), page)
+	if err != nil {
+		return outputdto.InputPageDTO{}, err
+	}
+
+	inputDTOs := make([]outputdto.InputDTO, 0, len(inputs))
+	for _, input := range inputs {
+		inputDTOs = append(inputDTOs, outputdto.InputDTO{
+			ID:        string(input.ID),
+			Metadata:  converter.ConvertMetadataEntityToDTO(input.Metadata),
+			Status:    converter.ConvertStatusEntityToDTO(input.Status),
+			Data:      input.Data,
+			CreatedAt: input.CreatedAt,
+			UpdatedAt: input.UpdatedAt,
+		})
+	}
+
+	return outputdto.InputPageDTO{Items: inputDTOs, NextCursor: next}, nil
+}
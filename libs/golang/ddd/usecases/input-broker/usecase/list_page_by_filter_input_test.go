@@ -6,29 +6,32 @@ import (
 
 	"libs/golang/ddd/domain/entities/input-broker/entity"
 	mockrepository "libs/golang/ddd/domain/repositories/database/mock/input-broker/repository"
+	inputdto "libs/golang/ddd/dtos/input-broker/input"
 	outputdto "libs/golang/ddd/dtos/input-broker/output"
 	shareddto "libs/golang/ddd/dtos/input-broker/shared"
+	"libs/golang/shared/go-criteria/criteria"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
 
-type ListAllInputUseCaseSuite struct {
+type ListPageByFilterInputUseCaseSuite struct {
 	suite.Suite
 	repoMock *mockrepository.InputRepositoryMock
-	useCase  *ListAllInputUseCase
+	useCase  *ListPageByFilterInputUseCase
 }
 
-func TestListAllInputUseCaseSuite(t *testing.T) {
-	suite.Run(t, new(ListAllInputUseCaseSuite))
+func TestListPageByFilterInputUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(ListPageByFilterInputUseCaseSuite))
 }
 
-func (suite *ListAllInputUseCaseSuite) SetupTest() {
+func (suite *ListPageByFilterInputUseCaseSuite) SetupTest() {
 	suite.repoMock = new(mockrepository.InputRepositoryMock)
-	suite.useCase = NewListAllInputUseCase(suite.repoMock)
+	suite.useCase = NewListPageByFilterInputUseCase(suite.repoMock)
 }
 
-func (suite *ListAllInputUseCaseSuite) TestExecuteWhenSuccess() {
+func (suite *ListPageByFilterInputUseCaseSuite) TestExecuteWhenSuccess() {
 	entityInputs := []*entity.Input{
 		{
 			ID: "test_id",
@@ -70,7 +73,8 @@ func (suite *ListAllInputUseCaseSuite) TestExecuteWhenSuccess() {
 		},
 	}
 
-	suite.repoMock.On("FindAll").Return(entityInputs, nil)
+	status := 0
+	suite.repoMock.On("FindPageByFilter", entity.InputFilter{Provider: "test_provider", Status: &status}, criteria.Page{Sort: criteria.NewSort("created_at")}).Return(entityInputs, "next", nil)
 
 	expectedOutput := []outputdto.InputDTO{
 		{
@@ -113,19 +117,28 @@ func (suite *ListAllInputUseCaseSuite) TestExecuteWhenSuccess() {
 		},
 	}
 
-	output, err := suite.useCase.Execute()
+	output, err := suite.useCase.Execute(inputdto.InputFilterDTO{Provider: "test_provider", Status: &status})
 
 	assert.Nil(suite.T(), err)
-	assert.Equal(suite.T(), expectedOutput, output)
+	assert.Equal(suite.T(), outputdto.InputPageDTO{Items: expectedOutput, NextCursor: "next"}, output)
 	suite.repoMock.AssertExpectations(suite.T())
 }
 
-func (suite *ListAllInputUseCaseSuite) TestExecuteWhenError() {
-	suite.repoMock.On("FindAll").Return(nil, fmt.Errorf("database error"))
+func (suite *ListPageByFilterInputUseCaseSuite) TestExecuteWhenError() {
+	status := 0
+	suite.repoMock.On("FindPageByFilter", entity.InputFilter{Provider: "test_provider", Status: &status}, criteria.Page{Sort: criteria.NewSort("created_at")}).Return(nil, "", fmt.Errorf("database error"))
 
-	output, err := suite.useCase.Execute()
+	output, err := suite.useCase.Execute(inputdto.InputFilterDTO{Provider: "test_provider", Status: &status})
 
 	assert.NotNil(suite.T(), err)
-	assert.Equal(suite.T(), []outputdto.InputDTO{}, output)
+	assert.Equal(suite.T(), outputdto.InputPageDTO{}, output)
 	suite.repoMock.AssertExpectations(suite.T())
 }
+
+func (suite *ListPageByFilterInputUseCaseSuite) TestExecuteWithInvalidSort() {
+	output, err := suite.useCase.Execute(inputdto.InputFilterDTO{ListQuery: criteria.ListQuery{Sort: "provider"}})
+
+	assert.ErrorIs(suite.T(), err, criteria.ErrInvalidSort)
+	assert.Equal(suite.T(), outputdto.InputPageDTO{}, output)
+	suite.repoMock.AssertNotCalled(suite.T(), "FindPageByFilter", mock.Anything, mock.Anything)
+}
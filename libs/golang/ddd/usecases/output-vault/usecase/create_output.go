@@ -1,26 +1,47 @@
 package usecase
 
 import (
+	"fmt"
 	"libs/golang/ddd/domain/entities/output-vault/entity"
 	inputdto "libs/golang/ddd/dtos/output-vault/input"
 	outputdto "libs/golang/ddd/dtos/output-vault/output"
 	"libs/golang/ddd/shared/type-tools/custom-types-converter/output-vault/converter"
+	events "libs/golang/shared/go-events/amqp_events"
+)
+
+var (
+	routingKey = "output.created"
 )
 
 // CreateOutputUseCase is the use case for creating a new output.
 type CreateOutputUseCase struct {
 	OutputRepository entity.OutputRepositoryInterface
+	NewOutputCreated func() events.EventInterface
 }
 
+// NewCreateOutputUseCase creates a new CreateOutputUseCase.
+//
+// Parameters:
+//
+//	outputRepository: The repository interface for managing Output entities.
+//	newOutputCreated: Builds the event stored in the outbox when an output is created. Each output gets its own
+//	event, so concurrent executions never share a payload.
+//
+// Returns:
+//
+//	A pointer to a CreateOutputUseCase instance.
 func NewCreateOutputUseCase(
 	outputRepository entity.OutputRepositoryInterface,
+	newOutputCreated func() events.EventInterface,
 ) *CreateOutputUseCase {
 	return &CreateOutputUseCase{
 		OutputRepository: outputRepository,
+		NewOutputCreated: newOutputCreated,
 	}
 }
 
-// Execute creates a new output entity based on the provided input DTO and saves it using the repository.
+// Execute creates a new output entity based on the provided input DTO and saves it using the repository,
+// together with the OutputCreated event in the outbox so the event is published once the output is stored.
 // It then converts the created entity to an output DTO and returns it.
 //
 // Parameters:
@@ -44,12 +65,7 @@ func (uc *CreateOutputUseCase) Execute(input inputdto.OutputDTO) (outputdto.Outp
 		return outputdto.OutputDTO{}, err
 	}
 
-	err = uc.OutputRepository.Create(entityOutput)
-	if err != nil {
-		return outputdto.OutputDTO{}, err
-	}
-
-	return outputdto.OutputDTO{
+	dto := outputdto.OutputDTO{
 		ID:        string(entityOutput.ID),
 		Service:   entityOutput.Service,
 		Source:    entityOutput.Source,
@@ -58,5 +74,15 @@ func (uc *CreateOutputUseCase) Execute(input inputdto.OutputDTO) (outputdto.Outp
 		Metadata:  converter.ConvertMetadataEntityToDTO(entityOutput.Metadata),
 		CreatedAt: entityOutput.CreatedAt,
 		UpdatedAt: entityOutput.UpdatedAt,
-	}, nil
+	}
+
+	outputCreated := uc.NewOutputCreated()
+	outputCreated.SetPayload(dto)
+	eventRoutingKey := fmt.Sprintf("%s.%s.%s.%s", routingKey, input.Provider, input.Service, input.Source)
+	err = uc.OutputRepository.CreateWithEvent(entityOutput, outputCreated, eventRoutingKey)
+	if err != nil {
+		return outputdto.OutputDTO{}, err
+	}
+
+	return dto, nil
 }
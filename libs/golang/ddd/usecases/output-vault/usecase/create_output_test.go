@@ -1,20 +1,26 @@
 package usecase
 
 import (
+	"fmt"
 	"libs/golang/ddd/domain/entities/output-vault/entity"
 	mockrepository "libs/golang/ddd/domain/repositories/database/mock/output-vault/repository"
 	inputdto "libs/golang/ddd/dtos/output-vault/input"
+	outputdto "libs/golang/ddd/dtos/output-vault/output"
 	shareddto "libs/golang/ddd/dtos/output-vault/shared"
+	mockevent "libs/golang/ddd/events/event-mock/mock"
 	"libs/golang/ddd/shared/type-tools/custom-types-converter/output-vault/converter"
+	events "libs/golang/shared/go-events/amqp_events"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
 
 type CreateOutputUseCaseSuite struct {
 	suite.Suite
 	repoMock    *mockrepository.OutputRepositoryMock
+	eventMock   *mockevent.MockEvent
 	useCase     *CreateOutputUseCase
 	inputDTO    inputdto.OutputDTO
 	outputProps entity.OutputProps
@@ -26,7 +32,8 @@ func TestCreateOutputUseCaseSuite(t *testing.T) {
 
 func (suite *CreateOutputUseCaseSuite) SetupTest() {
 	suite.repoMock = new(mockrepository.OutputRepositoryMock)
-	suite.useCase = NewCreateOutputUseCase(suite.repoMock)
+	suite.eventMock = new(mockevent.MockEvent)
+	suite.useCase = NewCreateOutputUseCase(suite.repoMock, func() events.EventInterface { return suite.eventMock })
 	suite.inputDTO = inputdto.OutputDTO{
 		Service:  "test_service",
 		Source:   "test_source",
@@ -55,7 +62,8 @@ func (suite *CreateOutputUseCaseSuite) SetupTest() {
 
 func (suite *CreateOutputUseCaseSuite) TestExecuteWhenSuccess() {
 	expectedOutput, _ := entity.NewOutput(suite.outputProps)
-	suite.repoMock.On("Create", expectedOutput).Return(nil)
+	suite.eventMock.On("SetPayload", mock.AnythingOfType("outputdto.OutputDTO")).Return()
+	suite.repoMock.On("CreateWithEvent", expectedOutput, suite.eventMock, fmt.Sprintf("output.created.%s.%s.%s", suite.inputDTO.Provider, suite.inputDTO.Service, suite.inputDTO.Source)).Return(nil)
 
 	output, err := suite.useCase.Execute(suite.inputDTO)
 
@@ -64,4 +72,40 @@ func (suite *CreateOutputUseCaseSuite) TestExecuteWhenSuccess() {
 	assert.Equal(suite.T(), suite.inputDTO.Source, output.Source)
 	assert.Equal(suite.T(), suite.inputDTO.Provider, output.Provider)
 	assert.Equal(suite.T(), suite.inputDTO.Data, output.Data)
+	suite.repoMock.AssertExpectations(suite.T())
+	suite.eventMock.AssertExpectations(suite.T())
+}
+
+func (suite *CreateOutputUseCaseSuite) TestExecuteBuildsEventPerOutput() {
+	var built []*mockevent.MockEvent
+	suite.useCase.NewOutputCreated = func() events.EventInterface {
+		event := new(mockevent.MockEvent)
+		event.On("SetPayload", mock.Anything).Return()
+		built = append(built, event)
+		return event
+	}
+	suite.repoMock.On("CreateWithEvent", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	other := suite.inputDTO
+	other.Data = map[string]interface{}{"key": "other"}
+
+	first, _ := suite.useCase.Execute(suite.inputDTO)
+	second, _ := suite.useCase.Execute(other)
+
+	assert.Len(suite.T(), built, 2)
+	built[0].AssertCalled(suite.T(), "SetPayload", first)
+	built[1].AssertCalled(suite.T(), "SetPayload", second)
+	suite.repoMock.AssertCalled(suite.T(), "CreateWithEvent", mock.Anything, built[0], mock.Anything)
+	suite.repoMock.AssertCalled(suite.T(), "CreateWithEvent", mock.Anything, built[1], mock.Anything)
+}
+
+func (suite *CreateOutputUseCaseSuite) TestExecuteWhenErrorCreatingOutput() {
+	expectedOutput, _ := entity.NewOutput(suite.outputProps)
+	suite.eventMock.On("SetPayload", mock.Anything).Return()
+	suite.repoMock.On("CreateWithEvent", expectedOutput, suite.eventMock, mock.Anything).Return(fmt.Errorf("output with ID: %s already exists", expectedOutput.ID))
+
+	output, err := suite.useCase.Execute(suite.inputDTO)
+
+	assert.NotNil(suite.T(), err)
+	assert.Equal(suite.T(), outputdto.OutputDTO{}, output)
+	suite.repoMock.AssertExpectations(suite.T())
 }
@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"libs/golang/ddd/domain/entities/output-vault/entity"
+	inputdto "libs/golang/ddd/dtos/output-vault/input"
+	outputdto "libs/golang/ddd/dtos/output-vault/output"
+	"libs/golang/ddd/shared/type-tools/custom-types-converter/output-vault/converter"
+)
+
+// ListPageByFilterOutputUseCase is the use case for listing a page of the outputs selected by a filter.
+type ListPageByFilterOutputUseCase struct {
+	OutputRepository entity.OutputRepositoryInterface
+}
+
+// NewListPageByFilterOutputUseCase initializes a new instance of ListPageByFilterOutputUseCase with the provided OutputRepositoryInterface.
+//
+// Parameters:
+//
+//	outputRepository: The repository interface for managing Output entities.
+//
+// Returns:
+//
+//	A pointer to an instance of ListPageByFilterOutputUseCase.
+func NewListPageByFilterOutputUseCase(
+	outputRepository entity.OutputRepositoryInterface,
+) *ListPageByFilterOutputUseCase {
+	return &ListPageByFilterOutputUseCase{
+		OutputRepository: outputRepository,
+	}
+}
+
+// Execute retrieves a page of the outputs selected by the filter from the repository and converts them to output DTOs.
+// An empty filter selects every output, and the limit, cursor and sort of the filter select the page.
+//
+// Parameters:
+//
+//	filterDTO: The filter selecting the outputs and the page.
+//
+// Returns:
+//
+//	A page of output DTOs with the cursor of the next page, and an error if any occurred during the process.
+func (uc *ListPageByFilterOutputUseCase) Execute(filterDTO inputdto.OutputFilterDTO) (outputdto.OutputPageDTO, error) {
+	page, err := converter.ConvertOutputFilterDTOToPage(filterDTO)
+	if err != nil {
+		return outputdto.OutputPageDTO{}, err
+	}
+
+	outputs, next, err := uc.OutputRepository.FindPageByFilter(converter.ConvertOutputFilterDTOToEntity(filterDTO), page)
+	if err != nil {
+		return outputdto.OutputPageDTO{}, err
+	}
+
+	outputDTOs := make([]outputdto.OutputDTO, 0, len(outputs))
+	for _, output := range outputs {
+		outputDTOs = append(outputDTOs, outputdto.OutputDTO{
+			ID:        string(output.ID),
+			Service:   output.Service,
+			Source:    output.Source,
+			Provider:  output.Provider,
+			Data:      output.Data,
+			Metadata:  converter.ConvertMetadataEntityToDTO(output.Metadata),
+			CreatedAt: output.CreatedAt,
+			UpdatedAt: output.UpdatedAt,
+		})
+	}
+
+	return outputdto.OutputPageDTO{Items: outputDTOs, NextCursor: next}, nil
+}
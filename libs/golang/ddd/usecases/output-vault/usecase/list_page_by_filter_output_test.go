@@ -6,29 +6,32 @@ import (
 
 	"libs/golang/ddd/domain/entities/output-vault/entity"
 	mockrepository "libs/golang/ddd/domain/repositories/database/mock/output-vault/repository"
+	inputdto "libs/golang/ddd/dtos/output-vault/input"
 	outputdto "libs/golang/ddd/dtos/output-vault/output"
 	shareddto "libs/golang/ddd/dtos/output-vault/shared"
+	"libs/golang/shared/go-criteria/criteria"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
 
-type ListAllOutputUseCaseSuite struct {
+type ListPageByFilterOutputUseCaseSuite struct {
 	suite.Suite
 	repoMock *mockrepository.OutputRepositoryMock
-	useCase  *ListAllOutputUseCase
+	useCase  *ListPageByFilterOutputUseCase
 }
 
-func TestListAllOutputUseCaseSuite(t *testing.T) {
-	suite.Run(t, new(ListAllOutputUseCaseSuite))
+func TestListPageByFilterOutputUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(ListPageByFilterOutputUseCaseSuite))
 }
 
-func (suite *ListAllOutputUseCaseSuite) SetupTest() {
+func (suite *ListPageByFilterOutputUseCaseSuite) SetupTest() {
 	suite.repoMock = new(mockrepository.OutputRepositoryMock)
-	suite.useCase = NewListAllOutputUseCase(suite.repoMock)
+	suite.useCase = NewListPageByFilterOutputUseCase(suite.repoMock)
 }
 
-func (suite *ListAllOutputUseCaseSuite) TestExecuteWhenSuccess() {
+func (suite *ListPageByFilterOutputUseCaseSuite) TestExecuteWhenSuccess() {
 	entityOutputs := []*entity.Output{
 		{
 			ID:       "1",
@@ -52,7 +55,7 @@ func (suite *ListAllOutputUseCaseSuite) TestExecuteWhenSuccess() {
 		},
 	}
 
-	suite.repoMock.On("FindAll").Return(entityOutputs, nil)
+	suite.repoMock.On("FindPageByFilter", entity.OutputFilter{Provider: "test_provider"}, criteria.Page{Sort: criteria.NewSort("created_at")}).Return(entityOutputs, "next", nil)
 
 	expectedOutput := []outputdto.OutputDTO{
 		{
@@ -77,19 +80,27 @@ func (suite *ListAllOutputUseCaseSuite) TestExecuteWhenSuccess() {
 		},
 	}
 
-	output, err := suite.useCase.Execute()
+	output, err := suite.useCase.Execute(inputdto.OutputFilterDTO{Provider: "test_provider"})
 
 	assert.Nil(suite.T(), err)
-	assert.Equal(suite.T(), expectedOutput, output)
+	assert.Equal(suite.T(), outputdto.OutputPageDTO{Items: expectedOutput, NextCursor: "next"}, output)
 	suite.repoMock.AssertExpectations(suite.T())
 }
 
-func (suite *ListAllOutputUseCaseSuite) TestExecuteWhenError() {
-	suite.repoMock.On("FindAll").Return(nil, fmt.Errorf("error"))
+func (suite *ListPageByFilterOutputUseCaseSuite) TestExecuteWhenError() {
+	suite.repoMock.On("FindPageByFilter", entity.OutputFilter{Provider: "test_provider"}, criteria.Page{Sort: criteria.NewSort("created_at")}).Return(nil, "", fmt.Errorf("error"))
 
-	output, err := suite.useCase.Execute()
+	output, err := suite.useCase.Execute(inputdto.OutputFilterDTO{Provider: "test_provider"})
 
 	assert.NotNil(suite.T(), err)
-	assert.Equal(suite.T(), []outputdto.OutputDTO{}, output)
+	assert.Equal(suite.T(), outputdto.OutputPageDTO{}, output)
 	suite.repoMock.AssertExpectations(suite.T())
 }
+
+func (suite *ListPageByFilterOutputUseCaseSuite) TestExecuteWithInvalidSort() {
+	output, err := suite.useCase.Execute(inputdto.OutputFilterDTO{ListQuery: criteria.ListQuery{Sort: "provider"}})
+
+	assert.ErrorIs(suite.T(), err, criteria.ErrInvalidSort)
+	assert.Equal(suite.T(), outputdto.OutputPageDTO{}, output)
+	suite.repoMock.AssertNotCalled(suite.T(), "FindPageByFilter", mock.Anything, mock.Anything)
+}
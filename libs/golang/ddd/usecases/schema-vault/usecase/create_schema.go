@@ -41,11 +41,12 @@ func NewCreateSchemaUseCase(
 //	An output DTO containing the created schema data, and an error if any occurred during the process.
 func (uc *CreateSchemaUseCase) Execute(input inputdto.SchemaDTO) (outputdto.SchemaDTO, error) {
 	schemaProps := entity.SchemaProps{
-		Service:    input.Service,
-		Source:     input.Source,
-		Provider:   input.Provider,
-		SchemaType: input.SchemaType,
-		JsonSchema: converter.ConvertJsonSchemaDTOToMap(input.JsonSchema),
+		Service:        input.Service,
+		Source:         input.Source,
+		Provider:       input.Provider,
+		SchemaType:     input.SchemaType,
+		JsonSchema:     converter.ConvertJsonSchemaDTOToMap(input.JsonSchema),
+		Transformation: converter.ConvertFieldMappingsDTOToEntity(input.Transformation),
 	}
 
 	entitySchema, err := entity.NewSchema(schemaProps)
@@ -67,6 +68,7 @@ func (uc *CreateSchemaUseCase) Execute(input inputdto.SchemaDTO) (outputdto.Sche
 		Provider:        entitySchema.Provider,
 		SchemaType:      entitySchema.SchemaType,
 		JsonSchema:      dtoJsonSchema,
+		Transformation:  converter.ConvertFieldMappingsEntityToDTO(entitySchema.Transformation),
 		SchemaVersionID: string(entitySchema.SchemaVersionID),
 		CreatedAt:       entitySchema.CreatedAt,
 		UpdatedAt:       entitySchema.UpdatedAt,
@@ -12,6 +12,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -73,6 +74,32 @@ func (suite *CreateSchemaUseCaseSuite) TestExecuteWhenSuccess() {
 	assert.Equal(suite.T(), suite.inputDTO.JsonSchema, output.JsonSchema)
 }
 
+func (suite *CreateSchemaUseCaseSuite) TestExecuteWithTransformation() {
+	suite.inputDTO.SchemaType = "output"
+	suite.inputDTO.Transformation = []shareddto.FieldMappingDTO{
+		{From: "raw.field1", To: "field1", Type: "string"},
+		{To: "field2", Default: "none"},
+	}
+	suite.repoMock.On("Create", mock.MatchedBy(func(schema *entity.Schema) bool {
+		return len(schema.Transformation) == 2 && schema.Transformation[0].From == "raw.field1"
+	})).Return(nil)
+
+	output, err := suite.useCase.Execute(suite.inputDTO)
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), suite.inputDTO.Transformation, output.Transformation)
+	suite.repoMock.AssertExpectations(suite.T())
+}
+
+func (suite *CreateSchemaUseCaseSuite) TestExecuteWhenInvalidTransformation() {
+	suite.inputDTO.Transformation = []shareddto.FieldMappingDTO{{From: "raw.field1"}}
+
+	_, err := suite.useCase.Execute(suite.inputDTO)
+
+	assert.ErrorIs(suite.T(), err, entity.ErrTransformationInvalid)
+	suite.repoMock.AssertNotCalled(suite.T(), "Create", mock.Anything)
+}
+
 func (suite *CreateSchemaUseCaseSuite) TestExecuteError() {
 	expectedSchema, _ := entity.NewSchema(suite.schemaProps)
 	suite.repoMock.On("Create", expectedSchema).Return(fmt.Errorf("Schema with ID: %s already exists", expectedSchema.ID))
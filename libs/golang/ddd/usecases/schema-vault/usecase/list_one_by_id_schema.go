@@ -50,6 +50,7 @@ func (uc *ListOneByIDSchemaUseCase) Execute(id string) (outputdto.SchemaDTO, err
 		Provider:        schema.Provider,
 		SchemaType:      schema.SchemaType,
 		JsonSchema:      converter.ConvertJsonSchemaEntityToDTO(schema.JsonSchema),
+		Transformation:  converter.ConvertFieldMappingsEntityToDTO(schema.Transformation),
 		SchemaVersionID: string(schema.SchemaVersionID),
 		CreatedAt:       schema.CreatedAt,
 		UpdatedAt:       schema.UpdatedAt,
@@ -56,6 +56,7 @@ func (uc *ListOneByServiceAndSourceAndProviderAndSchemaTypeSchemaUseCase) Execut
 		Provider:        schema.Provider,
 		SchemaType:      schema.SchemaType,
 		JsonSchema:      converter.ConvertJsonSchemaEntityToDTO(schema.JsonSchema),
+		Transformation:  converter.ConvertFieldMappingsEntityToDTO(schema.Transformation),
 		SchemaVersionID: string(schema.SchemaVersionID),
 		CreatedAt:       schema.CreatedAt,
 		UpdatedAt:       schema.UpdatedAt,
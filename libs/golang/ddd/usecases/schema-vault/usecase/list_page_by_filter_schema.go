@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"libs/golang/ddd/domain/entities/schema-vault/entity"
+	inputdto "libs/golang/ddd/dtos/schema-vault/input"
+	outputdto "libs/golang/ddd/dtos/schema-vault/output"
+	"libs/golang/ddd/shared/type-tools/custom-types-converter/schema-vault/converter"
+)
+
+// ListPageByFilterSchemaUseCase is the use case for listing a page of the schemas selected by a filter.
+type ListPageByFilterSchemaUseCase struct {
+	SchemaRepository entity.SchemaRepositoryInterface
+}
+
+// NewListPageByFilterSchemaUseCase initializes a new instance of ListPageByFilterSchemaUseCase with the provided SchemaRepositoryInterface.
+//
+// Parameters:
+//
+//	schemaRepository: The repository interface for managing Schema entities.
+//
+// Returns:
+//
+//	A pointer to an instance of ListPageByFilterSchemaUseCase.
+func NewListPageByFilterSchemaUseCase(
+	schemaRepository entity.SchemaRepositoryInterface,
+) *ListPageByFilterSchemaUseCase {
+	return &ListPageByFilterSchemaUseCase{
+		SchemaRepository: schemaRepository,
+	}
+}
+
+// Execute retrieves the schemas selected by the filter from the repository and converts them to output DTOs.
+// An empty filter selects every schema.
+//
+// Parameters:
+//
+//	filterDTO: The filter selecting the schemas and the page.
+//
+// Returns:
+//
+//	A slice of output DTOs containing the schema data, and an error if any occurred during the process.
+func (uc *ListPageByFilterSchemaUseCase) Execute(filterDTO inputdto.SchemaFilterDTO) (outputdto.SchemaPageDTO, error) {
+	page, err := converter.ConvertSchemaFilterDTOToPage(filterDTO)
+	if err != nil {
+		return outputdto.SchemaPageDTO{}, err
+	}
+
+	schemas, next, err := uc.SchemaRepository.FindPageByFilter(converter.ConvertSchemaFilterDTOToEntity(filterDTO), page)
+	if err != nil {
+		return outputdto.SchemaPageDTO{}, err
+	}
+
+	schemaDTOs := make([]outputdto.SchemaDTO, 0, len(schemas))
+	for _, schema := range schemas {
+		schemaDTOs = append(schemaDTOs, outputdto.SchemaDTO{
+			ID:              string(schema.ID),
+			Service:         schema.Service,
+			Source:          schema.Source,
+			Provider:        schema.Provider,
+			SchemaType:      schema.SchemaType,
+			JsonSchema:      converter.ConvertJsonSchemaEntityToDTO(schema.JsonSchema),
+			Transformation:  converter.ConvertFieldMappingsEntityToDTO(schema.Transformation),
+			SchemaVersionID: string(schema.SchemaVersionID),
+			CreatedAt:       schema.CreatedAt,
+			UpdatedAt:       schema.UpdatedAt,
+		})
+	}
+
+	return outputdto.SchemaPageDTO{Items: schemaDTOs, NextCursor: next}, nil
+}
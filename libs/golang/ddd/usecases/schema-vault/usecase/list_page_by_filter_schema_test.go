@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"fmt"
+	"testing"
+
+	"libs/golang/ddd/domain/entities/schema-vault/entity"
+	mockrepository "libs/golang/ddd/domain/repositories/database/mock/schema-vault/repository"
+	inputdto "libs/golang/ddd/dtos/schema-vault/input"
+	outputdto "libs/golang/ddd/dtos/schema-vault/output"
+	shareddto "libs/golang/ddd/dtos/schema-vault/shared"
+	"libs/golang/shared/go-criteria/criteria"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ListPageByFilterSchemaUseCaseSuite struct {
+	suite.Suite
+	repoMock *mockrepository.SchemaRepositoryMock
+	useCase  *ListPageByFilterSchemaUseCase
+}
+
+func TestListPageByFilterSchemaUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(ListPageByFilterSchemaUseCaseSuite))
+}
+
+func (suite *ListPageByFilterSchemaUseCaseSuite) SetupTest() {
+	suite.repoMock = new(mockrepository.SchemaRepositoryMock)
+	suite.useCase = NewListPageByFilterSchemaUseCase(suite.repoMock)
+}
+
+func (suite *ListPageByFilterSchemaUseCaseSuite) TestExecuteWhenSuccess() {
+	entitySchemas := []*entity.Schema{
+		{
+			ID:         "1",
+			Service:    "service1",
+			Source:     "source1",
+			Provider:   "provider",
+			SchemaType: "type1",
+			JsonSchema: entity.JsonSchema{
+				Required: []string{"field1"},
+				Properties: map[string]interface{}{
+					"field1": map[string]interface{}{
+						"type": "string",
+					},
+					"field2": map[string]interface{}{
+						"type": "string",
+					},
+				},
+				JsonType: "object",
+			},
+			CreatedAt: "2023-06-01T00:00:00Z",
+			UpdatedAt: "2023-06-01T00:00:00Z",
+		},
+	}
+
+	suite.repoMock.On("FindPageByFilter", entity.SchemaFilter{Provider: "test_provider", SchemaType: "input"}, criteria.Page{Sort: criteria.NewSort("created_at")}).Return(entitySchemas, "next", nil)
+
+	expectedOutput := []outputdto.SchemaDTO{
+		{
+			ID:         "1",
+			Service:    "service1",
+			Source:     "source1",
+			Provider:   "provider",
+			SchemaType: "type1",
+			JsonSchema: shareddto.JsonSchemaDTO{
+				JsonType: "object",
+				Properties: map[string]interface{}{
+					"field1": map[string]interface{}{
+						"type": "string",
+					},
+					"field2": map[string]interface{}{
+						"type": "string",
+					},
+				},
+				Required: []string{"field1"},
+			},
+			CreatedAt: "2023-06-01T00:00:00Z",
+			UpdatedAt: "2023-06-01T00:00:00Z",
+		},
+	}
+
+	output, err := suite.useCase.Execute(inputdto.SchemaFilterDTO{Provider: "test_provider", SchemaType: "input"})
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), outputdto.SchemaPageDTO{Items: expectedOutput, NextCursor: "next"}, output)
+	suite.repoMock.AssertExpectations(suite.T())
+}
+
+func (suite *ListPageByFilterSchemaUseCaseSuite) TestExecuteWhenError() {
+	suite.repoMock.On("FindPageByFilter", entity.SchemaFilter{Provider: "test_provider", SchemaType: "input"}, criteria.Page{Sort: criteria.NewSort("created_at")}).Return(nil, "", fmt.Errorf("error"))
+
+	output, err := suite.useCase.Execute(inputdto.SchemaFilterDTO{Provider: "test_provider", SchemaType: "input"})
+
+	assert.NotNil(suite.T(), err)
+	assert.Equal(suite.T(), outputdto.SchemaPageDTO{}, output)
+	suite.repoMock.AssertExpectations(suite.T())
+}
+
+func (suite *ListPageByFilterSchemaUseCaseSuite) TestExecuteWithInvalidSort() {
+	output, err := suite.useCase.Execute(inputdto.SchemaFilterDTO{ListQuery: criteria.ListQuery{Sort: "provider"}})
+
+	assert.ErrorIs(suite.T(), err, criteria.ErrInvalidSort)
+	assert.Equal(suite.T(), outputdto.SchemaPageDTO{}, output)
+	suite.repoMock.AssertNotCalled(suite.T(), "FindPageByFilter", mock.Anything, mock.Anything)
+}
@@ -4,9 +4,14 @@ import (
 	"context"
 	"fmt"
 	queue "libs/golang/clients/resources/go-rabbitmq/client"
+	usecaseprotocol "libs/golang/server/events/usecase-impl/protocol"
 	"log"
+	"sync"
+)
 
-	amqp "github.com/rabbitmq/amqp091-go"
+var (
+	// maxRetries is the number of redeliveries allowed before a message is dead-lettered.
+	maxRetries = 3
 )
 
 // AmqpConsumer handles consuming messages from a RabbitMQ queue.
@@ -15,8 +20,9 @@ type AmqpConsumer struct {
 	rabbitMQConsumer *queue.RabbitMQConsumer
 	queueName        string
 	routingKey       string
-	msgCh            chan []byte
+	msgCh            chan usecaseprotocol.DeliveryInterface
 	quitCh           chan struct{}
+	stopOnce         sync.Once
 }
 
 // NewAmqpConsumer creates a new instance of AmqpConsumer.
@@ -34,6 +40,7 @@ func NewAmqpConsumer(rmqClient *queue.Client, queueName, consumerName, routingKe
 		ConsumerName: consumerName,
 		AutoAck:      false,
 		Args:         nil,
+		MaxRetries:   maxRetries,
 	}
 
 	consumer := queue.NewRabbitMQConsumer(
@@ -46,7 +53,7 @@ func NewAmqpConsumer(rmqClient *queue.Client, queueName, consumerName, routingKe
 		rabbitMQConsumer: consumer,
 		queueName:        queueName,
 		routingKey:       routingKey,
-		msgCh:            make(chan []byte),
+		msgCh:            make(chan usecaseprotocol.DeliveryInterface),
 		quitCh:           make(chan struct{}),
 	}
 }
@@ -59,12 +66,23 @@ func (al *AmqpConsumer) GetListenerTag() string {
 	return fmt.Sprintf("%s:%s:%s", al.rabbitMQConsumer.ConsumerName, al.queueName, al.routingKey)
 }
 
+// SetPrefetch sets the number of unacknowledged messages the broker delivers to the consumer at once,
+// so the broker stops delivering while they are being processed. It must be called before Consume.
+//
+// Parameters:
+//   - count: The prefetch count, unlimited when 0.
+func (al *AmqpConsumer) SetPrefetch(count int) {
+	al.rabbitMQConsumer.SetPrefetch(count)
+}
+
 // Consume starts consuming messages from the queue and processes them.
 //
-// It listens for messages and sends them to the msgCh channel. If the quitCh
-// channel receives a signal, the consumption stops.
+// It listens for messages and sends their delivery handles to the msgCh channel, leaving
+// the acknowledgement decision to the receiver. If the quitCh channel receives a signal,
+// the consumer is cancelled on the broker and msgCh is closed: the receiver settles the deliveries
+// it already got, and the ones it did not get are requeued.
 func (al *AmqpConsumer) Consume() {
-	msgCh := make(chan amqp.Delivery)
+	msgCh := make(chan *queue.Delivery)
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
@@ -74,30 +92,39 @@ func (al *AmqpConsumer) Consume() {
 mainloop:
 	for {
 		select {
-		case msg := <-msgCh:
-			if msg.Body == nil {
-				log.Println("Received nil message, continuing...")
+		case msg, ok := <-msgCh:
+			if !ok {
+				log.Println("Deliveries channel closed, stopping consumer...")
+				break mainloop
+			}
+			if msg.Body() == nil {
+				log.Println("Received nil message, rejecting...")
+				msg.Nack(false)
 				continue
 			}
-			log.Printf("Received message: %s from queue: %s", string(msg.Body), al.queueName)
-			al.msgCh <- msg.Body
+			log.Printf("Received message: %s from queue: %s", string(msg.Body()), al.queueName)
+			al.msgCh <- msg
 		case <-al.quitCh:
 			log.Println("Received quit signal, stopping consumer...")
+			cancel()
 			break mainloop
 		}
 	}
+	close(al.msgCh)
 	log.Println("Consumer main loop exited")
 }
 
-// GetMsgCh returns the channel where messages are sent.
+// GetMsgCh returns the channel where message deliveries are sent.
 //
 // Returns:
-//   - A read-only channel of byte slices containing messages.
-func (al *AmqpConsumer) GetMsgCh() <-chan []byte {
+//   - A read-only channel of delivery handles.
+func (al *AmqpConsumer) GetMsgCh() <-chan usecaseprotocol.DeliveryInterface {
 	return al.msgCh
 }
 
-// Stop stops the consumer by closing the quitCh channel.
+// Stop stops the consumer by closing the quitCh channel. It can be called more than once.
 func (al *AmqpConsumer) Stop() {
-	close(al.quitCh)
+	al.stopOnce.Do(func() {
+		close(al.quitCh)
+	})
 }
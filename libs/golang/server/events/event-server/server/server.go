@@ -1,14 +1,17 @@
 package server
 
 import (
+	"context"
 	eventListener "libs/golang/server/events/listener/listener"
 	"log"
+	"sync"
 )
 
 // ListenerServer represents a server that manages event listeners.
 type ListenerServer struct {
 	controller *eventListener.EventListener // Controller for managing event listeners.
 	quitCh     chan struct{}                // Channel to signal the server to stop.
+	stopOnce   sync.Once                    // Ensures quitCh is closed only once.
 }
 
 // NewListenerServer creates a new instance of ListenerServer.
@@ -29,11 +32,14 @@ func NewListenerServer(
 
 // Start begins the execution of the listener server.
 //
-// This method starts all the listeners managed by the controller in separate goroutines.
-// It runs a main loop that waits for a signal on the quitCh channel to shut down the server.
+// This method starts all the listeners managed by the controller, each consuming in its own goroutine,
+// before waiting for a signal on the quitCh channel to shut down the server. A listener is started once
+// the controller registered it, so Shutdown either stops it or, when called first, keeps it from starting.
 func (es *ListenerServer) Start() {
 	for listenerTag := range es.controller.GetListeners() {
-		go es.controller.StartListener(listenerTag)
+		if err := es.controller.StartListener(listenerTag); err != nil {
+			log.Printf("Failed to start listener %s: %v", listenerTag, err)
+		}
 	}
 mainloop:
 	for {
@@ -44,3 +50,23 @@ mainloop:
 		}
 	}
 }
+
+// Stop signals the server to stop, which makes Start return. It can be called more than once.
+func (es *ListenerServer) Stop() {
+	es.stopOnce.Do(func() {
+		close(es.quitCh)
+	})
+}
+
+// Shutdown stops the server and its listeners, waiting for the messages the listeners already received
+// to be processed.
+//
+// Parameters:
+//   - ctx: The context bounding the wait for the listeners.
+//
+// Returns:
+//   - An error if the context is done before every listener stopped.
+func (es *ListenerServer) Shutdown(ctx context.Context) error {
+	es.Stop()
+	return es.controller.Shutdown(ctx)
+}
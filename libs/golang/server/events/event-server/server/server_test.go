@@ -0,0 +1,90 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	eventListener "libs/golang/server/events/listener/listener"
+	usecaseprotocol "libs/golang/server/events/usecase-impl/protocol"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// fakeConsumer counts the consumers running until they are stopped.
+type fakeConsumer struct {
+	tag      string
+	running  *atomic.Int32
+	msgCh    chan usecaseprotocol.DeliveryInterface
+	quitCh   chan struct{}
+	stopOnce sync.Once
+}
+
+func newFakeConsumer(tag string, running *atomic.Int32) *fakeConsumer {
+	return &fakeConsumer{
+		tag:     tag,
+		running: running,
+		msgCh:   make(chan usecaseprotocol.DeliveryInterface),
+		quitCh:  make(chan struct{}),
+	}
+}
+
+func (c *fakeConsumer) Consume() {
+	c.running.Add(1)
+	defer c.running.Add(-1)
+	<-c.quitCh
+	close(c.msgCh)
+}
+
+func (c *fakeConsumer) GetListenerTag() string { return c.tag }
+
+func (c *fakeConsumer) GetMsgCh() <-chan usecaseprotocol.DeliveryInterface { return c.msgCh }
+
+func (c *fakeConsumer) Stop() {
+	c.stopOnce.Do(func() { close(c.quitCh) })
+}
+
+// drainUseCase settles nothing, it only reads the messages until the channel is closed.
+type drainUseCase struct{}
+
+func (drainUseCase) ProcessMessageChannel(msgCh <-chan usecaseprotocol.DeliveryInterface, listenerTag string) {
+	for range msgCh {
+	}
+}
+
+type ListenerServerSuite struct {
+	suite.Suite
+}
+
+func TestListenerServerSuite(t *testing.T) {
+	suite.Run(t, new(ListenerServerSuite))
+}
+
+func (suite *ListenerServerSuite) TestShutdownRightAfterStartLeavesNoListenerRunning() {
+	for i := 0; i < 100; i++ {
+		var running atomic.Int32
+		controller := eventListener.NewEventListener()
+		suite.NoError(controller.AddListener(newFakeConsumer("listener-1", &running), drainUseCase{}))
+		suite.NoError(controller.AddListener(newFakeConsumer("listener-2", &running), drainUseCase{}))
+		listenerServer := NewListenerServer(controller)
+
+		returned := make(chan struct{})
+		go func() {
+			defer close(returned)
+			listenerServer.Start()
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		suite.NoError(listenerServer.Shutdown(ctx))
+		cancel()
+
+		select {
+		case <-returned:
+		case <-time.After(time.Second):
+			suite.FailNow("Start did not return after Shutdown")
+		}
+		suite.Equal(int32(0), running.Load(), "A listener kept consuming after Shutdown")
+	}
+}
@@ -1,8 +1,18 @@
 package listener
 
+import usecaseprotocol "libs/golang/server/events/usecase-impl/protocol"
+
 // ConsumerInterface defines the interface for a consumer.
 type ConsumerInterface interface {
 	Consume()
 	GetListenerTag() string
-	GetMsgCh() <-chan []byte
+	GetMsgCh() <-chan usecaseprotocol.DeliveryInterface
+	Stop()
+}
+
+// PrefetchConsumerInterface is implemented by the consumers that can bound the number of messages the
+// broker delivers before they are settled. The listener sets it to the size of its worker pool, so the
+// broker stops delivering while every worker is busy.
+type PrefetchConsumerInterface interface {
+	SetPrefetch(count int)
 }
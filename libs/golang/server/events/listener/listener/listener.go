@@ -1,6 +1,8 @@
 package listener
 
 import (
+	"context"
+	"fmt"
 	usecaseprotocol "libs/golang/server/events/usecase-impl/protocol"
 	"sync"
 
@@ -11,11 +13,43 @@ import (
 type Listener struct {
 	consumer        ConsumerInterface
 	usecaseProtocol usecaseprotocol.UseCaseProtocol
+	pool            WorkerPool
+	done            chan struct{} // Closed once the workers processed the last message, nil until started
+}
+
+// ListenerOption configures a Listener.
+type ListenerOption func(listener *Listener)
+
+// WithWorkers processes the messages of a listener on a pool of workers.
+//
+// Parameters:
+//   - workers: The number of workers, 1 when not positive.
+//
+// Returns:
+//   - The ListenerOption.
+func WithWorkers(workers int) ListenerOption {
+	return func(listener *Listener) {
+		listener.pool.Workers = workers
+	}
+}
+
+// WithOrderingKey processes the messages with the same key in order, on the same worker.
+//
+// Parameters:
+//   - keyFunc: The ordering key of the messages, such as a JSONFieldsKey.
+//
+// Returns:
+//   - The ListenerOption.
+func WithOrderingKey(keyFunc KeyFunc) ListenerOption {
+	return func(listener *Listener) {
+		listener.pool.KeyFunc = keyFunc
+	}
 }
 
 // EventListener manages a collection of listeners.
 type EventListener struct {
 	listeners map[string]*Listener
+	closed    bool // Set by Shutdown, after which no listener starts
 	mu        sync.RWMutex
 }
 
@@ -34,20 +68,25 @@ func NewEventListener() *EventListener {
 // Parameters:
 //   - consumer: The consumer to be added.
 //   - usecaseProtocol: The use case protocol associated with the consumer.
+//   - opts: The options of the listener. Without WithWorkers, the messages are processed one at a time.
 //
 // Returns:
 //   - An error if the listener already exists, otherwise nil.
-func (c *EventListener) AddListener(consumer ConsumerInterface, usecaseProtocol usecaseprotocol.UseCaseProtocol) error {
+func (c *EventListener) AddListener(consumer ConsumerInterface, usecaseProtocol usecaseprotocol.UseCaseProtocol, opts ...ListenerOption) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	_, ok := c.listeners[consumer.GetListenerTag()]
 	if ok {
 		return errors.New("Listener already exists")
 	}
-	c.listeners[consumer.GetListenerTag()] = &Listener{
+	listener := &Listener{
 		consumer:        consumer,
 		usecaseProtocol: usecaseProtocol,
 	}
+	for _, opt := range opts {
+		opt(listener)
+	}
+	c.listeners[consumer.GetListenerTag()] = listener
 	return nil
 }
 
@@ -96,23 +135,67 @@ func (c *EventListener) GetListeners() map[string]*Listener {
 	return c.listeners
 }
 
-// StartListener starts a listener by its tag.
+// StartListener starts a listener by its tag. A consumer implementing PrefetchConsumerInterface gets a
+// prefetch of the size of the listener's worker pool. Once Shutdown is called no listener starts, so a
+// listener is either stopped by Shutdown or never consumes.
 //
 // Parameters:
 //   - listenerTag: The tag of the listener to be started.
 //
 // Returns:
-//   - An error if the listener is not found, otherwise nil.
+//   - An error if the listener is not found, was already started or Shutdown was called, otherwise nil.
 func (c *EventListener) StartListener(listenerTag string) error {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return errors.New("Event listener is shut down")
+	}
 	listener, ok := c.listeners[listenerTag]
 	if !ok {
 		return errors.New("Listener not found")
 	}
+	if listener.done != nil {
+		return errors.New("Listener already started")
+	}
+	listener.done = make(chan struct{})
+	if prefetcher, ok := listener.consumer.(PrefetchConsumerInterface); ok {
+		prefetcher.SetPrefetch(listener.pool.Size())
+	}
 	go func(listener *Listener) {
+		defer close(listener.done)
 		go listener.consumer.Consume()
-		listener.usecaseProtocol.ProcessMessageChannel(listener.consumer.GetMsgCh(), listenerTag)
+		listener.pool.Run(listener.consumer.GetMsgCh(), listener.usecaseProtocol, listenerTag)
 	}(listener)
 	return nil
 }
+
+// Shutdown stops the consumers of the started listeners and waits for their workers to process the
+// messages they already received. The listeners not started yet are never started. The messages left
+// unsettled when the context is done are requeued by the broker once the connection closes.
+//
+// Parameters:
+//   - ctx: The context bounding the wait for the workers.
+//
+// Returns:
+//   - An error if the context is done before every listener stopped.
+func (c *EventListener) Shutdown(ctx context.Context) error {
+	c.mu.Lock()
+	c.closed = true
+	pending := make(map[string]chan struct{})
+	for listenerTag, listener := range c.listeners {
+		if listener.done != nil {
+			listener.consumer.Stop()
+			pending[listenerTag] = listener.done
+		}
+	}
+	c.mu.Unlock()
+
+	for listenerTag, done := range pending {
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return fmt.Errorf("listener %s did not stop: %w", listenerTag, ctx.Err())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package listener
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	usecaseprotocol "libs/golang/server/events/usecase-impl/protocol"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+// fakeConsumer sends its bodies to the listener, then waits to be stopped.
+type fakeConsumer struct {
+	tag      string
+	bodies   []string
+	msgCh    chan usecaseprotocol.DeliveryInterface
+	quitCh   chan struct{}
+	stopOnce sync.Once
+}
+
+func newFakeConsumer(tag string, bodies ...string) *fakeConsumer {
+	return &fakeConsumer{
+		tag:    tag,
+		bodies: bodies,
+		msgCh:  make(chan usecaseprotocol.DeliveryInterface),
+		quitCh: make(chan struct{}),
+	}
+}
+
+func (c *fakeConsumer) Consume() {
+	for _, body := range c.bodies {
+		c.msgCh <- &fakeDelivery{body: []byte(body)}
+	}
+	<-c.quitCh
+	close(c.msgCh)
+}
+
+func (c *fakeConsumer) GetListenerTag() string { return c.tag }
+
+func (c *fakeConsumer) GetMsgCh() <-chan usecaseprotocol.DeliveryInterface { return c.msgCh }
+
+func (c *fakeConsumer) Stop() {
+	c.stopOnce.Do(func() { close(c.quitCh) })
+}
+
+type EventListenerSuite struct {
+	suite.Suite
+	eventListener *EventListener
+}
+
+func TestEventListenerSuite(t *testing.T) {
+	suite.Run(t, new(EventListenerSuite))
+}
+
+func (suite *EventListenerSuite) SetupTest() {
+	suite.eventListener = NewEventListener()
+}
+
+func (suite *EventListenerSuite) TestShutdownWaitsForWorkers() {
+	usecase := &recordingUseCase{delay: 20 * time.Millisecond}
+	consumer := newFakeConsumer("listener-1", `{"n":1}`, `{"n":2}`)
+	suite.NoError(suite.eventListener.AddListener(consumer, usecase, WithWorkers(2)))
+	suite.NoError(suite.eventListener.StartListener("listener-1"))
+	suite.Error(suite.eventListener.StartListener("listener-1"), "A listener should start only once")
+
+	suite.NoError(suite.eventListener.Shutdown(context.Background()))
+
+	usecase.mu.Lock()
+	defer usecase.mu.Unlock()
+	assert.ElementsMatch(suite.T(), []string{`{"n":1}`, `{"n":2}`}, usecase.processed)
+}
+
+func (suite *EventListenerSuite) TestShutdownDeadline() {
+	usecase := &recordingUseCase{delay: time.Second}
+	consumer := newFakeConsumer("listener-1", `{"n":1}`)
+	suite.NoError(suite.eventListener.AddListener(consumer, usecase))
+	suite.NoError(suite.eventListener.StartListener("listener-1"))
+	assert.Eventually(suite.T(), func() bool { return usecase.running.Load() == 1 }, time.Second, time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	suite.ErrorIs(suite.eventListener.Shutdown(ctx), context.DeadlineExceeded)
+}
+
+func (suite *EventListenerSuite) TestShutdownSkipsListenersNotStarted() {
+	consumer := newFakeConsumer("listener-1")
+	suite.NoError(suite.eventListener.AddListener(consumer, &recordingUseCase{}))
+
+	suite.NoError(suite.eventListener.Shutdown(context.Background()))
+	suite.Error(suite.eventListener.StartListener("listener-1"), "A listener should not start after Shutdown")
+}
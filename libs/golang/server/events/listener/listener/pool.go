@@ -0,0 +1,147 @@
+package listener
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	usecaseprotocol "libs/golang/server/events/usecase-impl/protocol"
+	"strings"
+	"sync"
+)
+
+// KeyFunc returns the ordering key of a delivery. Deliveries with the same key are processed in order.
+type KeyFunc func(delivery usecaseprotocol.DeliveryInterface) string
+
+// WorkerPool runs the processing of a listener's messages on a number of workers.
+//
+// Without a KeyFunc, every worker takes the next message from the consumer, so messages are processed in
+// any order. With a KeyFunc, the messages are partitioned by key and each partition is processed by a
+// single worker, in the order the messages were consumed.
+type WorkerPool struct {
+	Workers int     // Number of workers, 1 when not positive
+	KeyFunc KeyFunc // Ordering key of the messages, unordered when nil
+}
+
+// Size returns the number of workers of the pool.
+//
+// Returns:
+//   - The number of workers, at least 1.
+func (p WorkerPool) Size() int {
+	if p.Workers < 1 {
+		return 1
+	}
+	return p.Workers
+}
+
+// Run processes the messages of a channel on the workers of the pool, each worker running the
+// ProcessMessageChannel of the use case on its share of the messages. The use case must be safe for
+// concurrent use when the pool has more than one worker.
+//
+// Parameters:
+//   - msgCh: The channel from which message deliveries are received.
+//   - usecase: The use case processing the messages.
+//   - listenerTag: The tag of the listener processing the messages.
+//
+// Run returns once msgCh is closed and every worker processed its messages.
+func (p WorkerPool) Run(msgCh <-chan usecaseprotocol.DeliveryInterface, usecase usecaseprotocol.UseCaseProtocol, listenerTag string) {
+	workers := p.Size()
+	if workers == 1 {
+		usecase.ProcessMessageChannel(msgCh, listenerTag)
+		return
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	if p.KeyFunc == nil {
+		for i := 0; i < workers; i++ {
+			go func() {
+				defer wg.Done()
+				usecase.ProcessMessageChannel(msgCh, listenerTag)
+			}()
+		}
+		wg.Wait()
+		return
+	}
+
+	partitions := make([]chan usecaseprotocol.DeliveryInterface, workers)
+	for i := range partitions {
+		partitions[i] = make(chan usecaseprotocol.DeliveryInterface)
+		go func(partition <-chan usecaseprotocol.DeliveryInterface) {
+			defer wg.Done()
+			usecase.ProcessMessageChannel(partition, listenerTag)
+		}(partitions[i])
+	}
+	for delivery := range msgCh {
+		partitions[partitionOf(p.KeyFunc(delivery), workers)] <- delivery
+	}
+	for _, partition := range partitions {
+		close(partition)
+	}
+	wg.Wait()
+}
+
+// partitionOf returns the partition of an ordering key.
+//
+// Parameters:
+//   - key: The ordering key.
+//   - partitions: The number of partitions.
+//
+// Returns:
+//   - The index of the partition of the key.
+func partitionOf(key string, partitions int) int {
+	hash := fnv.New32a()
+	hash.Write([]byte(key))
+	return int(hash.Sum32() % uint32(partitions))
+}
+
+// JSONFieldsKey returns a KeyFunc reading the ordering key from the JSON body of the deliveries.
+//
+// Parameters:
+//   - paths: The dot-separated paths of the fields making up the key, such as "metadata.provider".
+//
+// Returns:
+//   - A KeyFunc joining the values of the fields with "/". Missing fields are empty, and a body that is not
+//     a JSON object has an empty key.
+//
+// Example:
+//
+//	keyFunc := JSONFieldsKey("metadata.provider", "metadata.service", "metadata.source")
+//	// {"metadata": {"provider": "acme", "service": "crawler", "source": "web"}} -> "acme/crawler/web"
+func JSONFieldsKey(paths ...string) KeyFunc {
+	return func(delivery usecaseprotocol.DeliveryInterface) string {
+		var body map[string]interface{}
+		if err := json.Unmarshal(delivery.Body(), &body); err != nil {
+			return ""
+		}
+		values := make([]string, len(paths))
+		for i, path := range paths {
+			values[i] = fieldValue(body, path)
+		}
+		return strings.Join(values, "/")
+	}
+}
+
+// fieldValue reads the value at a dot-separated path of a JSON object.
+//
+// Parameters:
+//   - body: The JSON object.
+//   - path: The dot-separated path of the field.
+//
+// Returns:
+//   - The value of the field formatted as a string, empty when the field is missing.
+func fieldValue(body map[string]interface{}, path string) string {
+	keys := strings.Split(path, ".")
+	current := body
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := current[key].(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		current = next
+	}
+	value, ok := current[keys[len(keys)-1]]
+	if !ok || value == nil {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
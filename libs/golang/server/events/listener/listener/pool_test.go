@@ -0,0 +1,131 @@
+package listener
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	usecaseprotocol "libs/golang/server/events/usecase-impl/protocol"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+// fakeDelivery is a delivery with a JSON body.
+type fakeDelivery struct {
+	body []byte
+}
+
+func (d *fakeDelivery) Body() []byte            { return d.body }
+func (d *fakeDelivery) RetryCount() int         { return 0 }
+func (d *fakeDelivery) Ack() error              { return nil }
+func (d *fakeDelivery) Nack(requeue bool) error { return nil }
+
+// recordingUseCase records the bodies it processes and the highest number of concurrent workers.
+type recordingUseCase struct {
+	mu        sync.Mutex
+	processed []string
+	running   atomic.Int32
+	peak      atomic.Int32
+	delay     time.Duration
+}
+
+func (uc *recordingUseCase) ProcessMessageChannel(msgCh <-chan usecaseprotocol.DeliveryInterface, listenerTag string) {
+	for delivery := range msgCh {
+		running := uc.running.Add(1)
+		for {
+			peak := uc.peak.Load()
+			if running <= peak || uc.peak.CompareAndSwap(peak, running) {
+				break
+			}
+		}
+		time.Sleep(uc.delay)
+		uc.mu.Lock()
+		uc.processed = append(uc.processed, string(delivery.Body()))
+		uc.mu.Unlock()
+		uc.running.Add(-1)
+	}
+}
+
+type WorkerPoolSuite struct {
+	suite.Suite
+}
+
+func TestWorkerPoolSuite(t *testing.T) {
+	suite.Run(t, new(WorkerPoolSuite))
+}
+
+// feed returns a closed channel holding a delivery per body.
+func feed(bodies ...string) <-chan usecaseprotocol.DeliveryInterface {
+	msgCh := make(chan usecaseprotocol.DeliveryInterface, len(bodies))
+	for _, body := range bodies {
+		msgCh <- &fakeDelivery{body: []byte(body)}
+	}
+	close(msgCh)
+	return msgCh
+}
+
+func (suite *WorkerPoolSuite) TestRunsWorkersConcurrently() {
+	usecase := &recordingUseCase{delay: 20 * time.Millisecond}
+	bodies := make([]string, 8)
+	for i := range bodies {
+		bodies[i] = fmt.Sprintf(`{"n":%d}`, i)
+	}
+
+	WorkerPool{Workers: 4}.Run(feed(bodies...), usecase, "listener-1")
+
+	assert.Len(suite.T(), usecase.processed, 8)
+	assert.Greater(suite.T(), usecase.peak.Load(), int32(1))
+	assert.LessOrEqual(suite.T(), usecase.peak.Load(), int32(4))
+}
+
+func (suite *WorkerPoolSuite) TestSingleWorker() {
+	usecase := &recordingUseCase{}
+
+	WorkerPool{}.Run(feed(`{"n":1}`, `{"n":2}`), usecase, "listener-1")
+
+	assert.Equal(suite.T(), []string{`{"n":1}`, `{"n":2}`}, usecase.processed)
+	assert.Equal(suite.T(), int32(1), usecase.peak.Load())
+}
+
+func (suite *WorkerPoolSuite) TestKeepsOrderPerKey() {
+	usecase := &recordingUseCase{delay: time.Millisecond}
+	var bodies []string
+	for i := 0; i < 10; i++ {
+		for _, key := range []string{"a", "b", "c"} {
+			bodies = append(bodies, fmt.Sprintf(`{"metadata":{"source":%q},"n":%d}`, key, i))
+		}
+	}
+	pool := WorkerPool{Workers: 3, KeyFunc: JSONFieldsKey("metadata.source")}
+
+	pool.Run(feed(bodies...), usecase, "listener-1")
+
+	assert.Len(suite.T(), usecase.processed, len(bodies))
+	for _, key := range []string{"a", "b", "c"} {
+		var order []string
+		for _, body := range usecase.processed {
+			if fieldValue(decode(body), "metadata.source") == key {
+				order = append(order, fieldValue(decode(body), "n"))
+			}
+		}
+		assert.Equal(suite.T(), []string{"0", "1", "2", "3", "4", "5", "6", "7", "8", "9"}, order, "Messages of key %s are out of order", key)
+	}
+}
+
+func (suite *WorkerPoolSuite) TestJSONFieldsKey() {
+	keyFunc := JSONFieldsKey("metadata.provider", "metadata.service", "source")
+
+	assert.Equal(suite.T(), "acme/crawler/web", keyFunc(&fakeDelivery{body: []byte(`{"metadata":{"provider":"acme","service":"crawler"},"source":"web"}`)}))
+	assert.Equal(suite.T(), "acme//", keyFunc(&fakeDelivery{body: []byte(`{"metadata":{"provider":"acme"}}`)}))
+	assert.Equal(suite.T(), "", keyFunc(&fakeDelivery{body: []byte(`not json`)}))
+}
+
+// decode decodes a JSON object.
+func decode(body string) map[string]interface{} {
+	var decoded map[string]interface{}
+	json.Unmarshal([]byte(body), &decoded)
+	return decoded
+}
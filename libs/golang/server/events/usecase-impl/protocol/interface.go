@@ -1,6 +1,15 @@
 package usecaseprotocol
 
+// DeliveryInterface defines a handle over a consumed message. Whoever processes the
+// message decides whether it is acknowledged, retried or dead-lettered.
+type DeliveryInterface interface {
+	Body() []byte
+	RetryCount() int
+	Ack() error
+	Nack(requeue bool) error
+}
+
 // UseCaseProtocol defines the interface for a use case protocol.
 type UseCaseProtocol interface {
-	ProcessMessageChannel(msgCh <-chan []byte, listenerTag string)
+	ProcessMessageChannel(msgCh <-chan DeliveryInterface, listenerTag string)
 }
@@ -1,6 +1,8 @@
 package webserver
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"time"
 
@@ -10,8 +12,9 @@ import (
 
 // Server represents an HTTP server with a router and address.
 type Server struct {
-	router *chi.Mux
-	addr   string
+	router     *chi.Mux
+	addr       string
+	httpServer *http.Server
 }
 
 // NewWebServer creates and returns a new Server instance with the specified address.
@@ -24,9 +27,11 @@ type Server struct {
 //
 //	A new Server instance.
 func NewWebServer(addr string) *Server {
+	router := chi.NewRouter()
 	return &Server{
-		router: chi.NewRouter(),
-		addr:   addr,
+		router:     router,
+		addr:       addr,
+		httpServer: &http.Server{Addr: addr, Handler: router},
 	}
 }
 
@@ -113,6 +118,19 @@ func (s *Server) RegisterRouteGroup(prefix string, routes func(r chi.Router)) {
 	s.router.Route(prefix, routes)
 }
 
+// Routes returns the routes registered on the server, such as to walk them with chi.Walk.
+//
+// Parameters:
+//
+//	None.
+//
+// Returns:
+//
+//	The routes of the server router.
+func (s *Server) Routes() chi.Routes {
+	return s.router
+}
+
 // Start runs the web server on the specified address.
 //
 // Parameters:
@@ -121,7 +139,23 @@ func (s *Server) RegisterRouteGroup(prefix string, routes func(r chi.Router)) {
 //
 // Returns:
 //
-//	An error if the server fails to start.
+//	An error if the server fails to start, or nil once the server is shut down.
 func (s *Server) Start() error {
-	return http.ListenAndServe(s.addr, s.router)
+	if err := s.httpServer.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// Shutdown stops the server from accepting new requests and waits for the in-flight ones to complete.
+//
+// Parameters:
+//
+//	ctx: The context bounding the wait for the in-flight requests.
+//
+// Returns:
+//
+//	An error if the context is done before the in-flight requests complete.
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.httpServer.Shutdown(ctx)
 }
@@ -1,9 +1,11 @@
 package webserver
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/stretchr/testify/assert"
@@ -62,6 +64,20 @@ func (suite *HTTPServerTestSuite) TestRouteGroupRegistration() {
 	assert.Equal(suite.T(), http.StatusOK, recorder.Code)
 }
 
+func (suite *HTTPServerTestSuite) TestRoutes() {
+	suite.server.RegisterRoute("GET", "/test", func(w http.ResponseWriter, r *http.Request) {})
+	suite.server.RegisterRoute("POST", "/test", func(w http.ResponseWriter, r *http.Request) {})
+
+	var routes []string
+	err := chi.Walk(suite.server.Routes(), func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		routes = append(routes, method+" "+route)
+		return nil
+	})
+
+	assert.NoError(suite.T(), err)
+	assert.ElementsMatch(suite.T(), []string{"GET /test", "POST /test"}, routes)
+}
+
 func (suite *HTTPServerTestSuite) TestServerStart() {
 	go func() {
 		err := suite.server.Start()
@@ -69,6 +85,24 @@ func (suite *HTTPServerTestSuite) TestServerStart() {
 	}()
 }
 
+func (suite *HTTPServerTestSuite) TestServerShutdown() {
+	server := NewWebServer("127.0.0.1:0")
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.Start()
+	}()
+
+	assert.Eventually(suite.T(), func() bool {
+		return server.Shutdown(context.Background()) == nil
+	}, time.Second, 10*time.Millisecond)
+	select {
+	case err := <-errCh:
+		assert.Nil(suite.T(), err)
+	case <-time.After(time.Second):
+		suite.T().Fatal("Start did not return after Shutdown")
+	}
+}
+
 func (suite *HTTPServerTestSuite) TestStartHandler() {
 	suite.server.RegisterRoute("GET", "/test", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -0,0 +1,5 @@
+module libs/golang/server/http/openapi
+
+go 1.22
+
+require github.com/go-chi/chi/v5 v5.0.12
@@ -0,0 +1,197 @@
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	"libs/golang/shared/go-problem/problem"
+	"net/http"
+	"reflect"
+	"regexp"
+	"runtime"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+var (
+	// methods lists the HTTP methods an OpenAPI document can describe.
+	methods = []string{
+		http.MethodGet, http.MethodPut, http.MethodPost, http.MethodDelete,
+		http.MethodOptions, http.MethodHead, http.MethodPatch, http.MethodTrace,
+	}
+
+	// pathParamPattern matches the parameters of a chi pattern, such as {id} or {id:[0-9]+}.
+	pathParamPattern = regexp.MustCompile(`\{([^}:]+)(?::[^}]+)?\}`)
+)
+
+// Generate builds the OpenAPI document of the routes registered on a chi router. Each route is documented by the
+// operation named after its handler, and the routes serving the document itself, SpecPath and DocsPath, are skipped.
+//
+// Parameters:
+//   - info: The description of the service.
+//   - routes: The routes to document, such as the ones of a chi-webserver Server.
+//   - operations: The operations documenting the handlers of the routes.
+//
+// Returns:
+//   - A pointer to the generated document.
+//   - An error listing every route whose handler is not documented or whose method is not a standard HTTP method.
+//
+// Example:
+//
+//	document, err := openapi.Generate(
+//		openapi.Info{Title: "config-vault", Version: "1.0.0"},
+//		httpServer.Routes(),
+//		healthz.Operations,
+//		handlers.Operations,
+//	)
+func Generate(info Info, routes chi.Routes, operations ...Operations) (*Document, error) {
+	documented := Operations{}
+	for _, handlerOperations := range operations {
+		for name, operation := range handlerOperations {
+			if _, ok := documented[name]; ok {
+				return nil, fmt.Errorf("operation %s is documented twice", name)
+			}
+			documented[name] = operation
+		}
+	}
+
+	schemas := newSchemas()
+	document := &Document{OpenAPI: Version, Info: info, Paths: map[string]PathItem{}}
+	var errs []error
+	err := chi.Walk(routes, func(method, route string, handler http.Handler, _ ...func(http.Handler) http.Handler) error {
+		if route == SpecPath || route == DocsPath {
+			return nil
+		}
+		if !slices.Contains(methods, method) {
+			errs = append(errs, fmt.Errorf("%s %s: %s is not a standard HTTP method", method, route, method))
+			return nil
+		}
+		name := handlerName(handler)
+		operation, ok := documented[name]
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s %s: handler %s is not documented", method, route, name))
+			return nil
+		}
+
+		path := pathParamPattern.ReplaceAllString(route, "{$1}")
+		if document.Paths[path] == nil {
+			document.Paths[path] = PathItem{}
+		}
+		document.Paths[path][strings.ToLower(method)] = schemas.operation(name, route, operation)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	document.Components.Schemas = schemas.components
+	return document, nil
+}
+
+// handlerName returns the name of the function or method of a handler, such as "CreateConfig" for the method value
+// handler.CreateConfig.
+func handlerName(handler http.Handler) string {
+	value := reflect.ValueOf(handler)
+	if value.Kind() != reflect.Func {
+		return value.Type().String()
+	}
+	name := strings.TrimSuffix(runtime.FuncForPC(value.Pointer()).Name(), "-fm")
+	return name[strings.LastIndex(name, ".")+1:]
+}
+
+// operation describes the route of a pattern documented by an operation.
+func (s *schemas) operation(name, route string, operation Operation) *PathOperation {
+	pathOperation := &PathOperation{
+		OperationID: name,
+		Summary:     operation.Summary,
+		Description: operation.Description,
+		Responses:   map[string]Response{},
+	}
+
+	for _, match := range pathParamPattern.FindAllStringSubmatch(route, -1) {
+		pathOperation.Parameters = append(pathOperation.Parameters, Parameter{
+			Name:     match[1],
+			In:       "path",
+			Required: true,
+			Schema:   &Schema{Type: "string"},
+		})
+	}
+	if operation.Query != nil {
+		pathOperation.Parameters = append(pathOperation.Parameters, s.parameters(reflect.TypeOf(operation.Query))...)
+	}
+	for _, header := range sortedKeys(operation.Headers) {
+		pathOperation.Parameters = append(pathOperation.Parameters, Parameter{
+			Name:        header,
+			In:          "header",
+			Description: operation.Headers[header],
+			Schema:      &Schema{Type: "string"},
+		})
+	}
+
+	if operation.Request != nil {
+		pathOperation.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]MediaType{"application/json": {Schema: s.of(reflect.TypeOf(operation.Request))}},
+		}
+	}
+
+	pathOperation.Responses[strconv.Itoa(http.StatusOK)] = s.response(operation)
+	for _, status := range operation.Errors {
+		pathOperation.Responses[strconv.Itoa(status)] = s.errorResponse(status, operation.TextErrors)
+	}
+	return pathOperation
+}
+
+// response describes the successful response of an operation.
+func (s *schemas) response(operation Operation) Response {
+	response := Response{Description: http.StatusText(http.StatusOK)}
+	for _, header := range sortedKeys(operation.ResponseHeaders) {
+		if response.Headers == nil {
+			response.Headers = map[string]Header{}
+		}
+		response.Headers[header] = Header{Description: operation.ResponseHeaders[header], Schema: &Schema{Type: "string"}}
+	}
+
+	switch body := operation.Response.(type) {
+	case nil:
+	case string:
+		schema := &Schema{Type: "string"}
+		if body != "" {
+			schema.Example = body
+		}
+		response.Content = map[string]MediaType{"text/plain": {Schema: schema}}
+	default:
+		response.Content = map[string]MediaType{"application/json": {Schema: s.of(reflect.TypeOf(body))}}
+	}
+	return response
+}
+
+// errorResponse describes a failed response, answered with problem details or with plain text.
+func (s *schemas) errorResponse(status int, text bool) Response {
+	if text {
+		return Response{
+			Description: http.StatusText(status),
+			Content:     map[string]MediaType{"text/plain": {Schema: &Schema{Type: "string"}}},
+		}
+	}
+	return Response{
+		Description: http.StatusText(status),
+		Content:     map[string]MediaType{problem.ContentType: {Schema: s.of(reflect.TypeOf(problem.Problem{}))}},
+	}
+}
+
+// sortedKeys returns the keys of a map in lexical order, so the generated documents do not change between runs.
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
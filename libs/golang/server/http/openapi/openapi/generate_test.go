@@ -0,0 +1,191 @@
+package openapi
+
+import (
+	"libs/golang/shared/go-problem/problem"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/suite"
+)
+
+type dependencyDTO struct {
+	Service string `json:"service" validate:"required,identifier"`
+}
+
+type configDTO struct {
+	Service   string                 `json:"service" validate:"required,identifier,max=64"`
+	Kind      string                 `json:"kind" validate:"oneof=input output"`
+	DependsOn []dependencyDTO        `json:"depends_on" validate:"max=2"`
+	Parent    *dependencyDTO         `json:"parent" validate:"required"`
+	Data      map[string]interface{} `json:"data" validate:"required,maxbytes=1024"`
+	CreatedAt time.Time              `json:"created_at"`
+	Ignored   string                 `json:"-"`
+	internal  string
+}
+
+// pageDTO is embedded in filterDTO, so its fields are parameters of their own.
+type pageDTO struct {
+	Limit int `json:"limit"`
+}
+
+type filterDTO struct {
+	Active *bool     `json:"active"`
+	After  time.Time `json:"created_after"`
+	pageDTO
+}
+
+type configHandler struct{}
+
+func (h *configHandler) CreateConfig(w http.ResponseWriter, r *http.Request) {}
+func (h *configHandler) ListConfigs(w http.ResponseWriter, r *http.Request)  {}
+func (h *configHandler) DeleteConfig(w http.ResponseWriter, r *http.Request) {}
+
+var operations = Operations{
+	"CreateConfig": {
+		Summary:         "Create a config",
+		Headers:         map[string]string{"Idempotency-Key": "Key of the request across its retries"},
+		Request:         configDTO{},
+		Response:        configDTO{},
+		ResponseHeaders: map[string]string{"Idempotent-Replayed": "Set when the config was already created"},
+		Errors:          []int{http.StatusBadRequest, http.StatusUnprocessableEntity},
+	},
+	"ListConfigs": {
+		Query:    filterDTO{},
+		Response: []configDTO{},
+	},
+	"DeleteConfig": {
+		Response:   "Config deleted successfully",
+		Errors:     []int{http.StatusNotFound},
+		TextErrors: true,
+	},
+}
+
+type GenerateSuite struct {
+	suite.Suite
+	router  *chi.Mux
+	handler *configHandler
+}
+
+func TestGenerateSuite(t *testing.T) {
+	suite.Run(t, new(GenerateSuite))
+}
+
+func (suite *GenerateSuite) SetupTest() {
+	suite.router = chi.NewRouter()
+	suite.handler = &configHandler{}
+	suite.router.Post("/config", suite.handler.CreateConfig)
+	suite.router.Get("/config/provider/{provider}", suite.handler.ListConfigs)
+	suite.router.Delete("/config/{id:[a-z0-9]+}", suite.handler.DeleteConfig)
+	suite.router.Get(SpecPath, SpecHandler(&Document{}))
+	suite.router.Get(DocsPath, DocsHandler())
+}
+
+func (suite *GenerateSuite) generate() *Document {
+	document, err := Generate(Info{Title: "config-vault", Version: "1.0.0"}, suite.router, operations)
+	suite.Require().NoError(err)
+	return document
+}
+
+func (suite *GenerateSuite) TestGenerateDocumentsEveryRoute() {
+	document := suite.generate()
+
+	suite.Equal("3.0.3", document.OpenAPI)
+	suite.Equal(Info{Title: "config-vault", Version: "1.0.0"}, document.Info)
+	suite.Len(document.Paths, 3)
+	suite.Equal("CreateConfig", document.Paths["/config"]["post"].OperationID)
+	suite.Equal("ListConfigs", document.Paths["/config/provider/{provider}"]["get"].OperationID)
+	suite.Equal("DeleteConfig", document.Paths["/config/{id}"]["delete"].OperationID)
+}
+
+func (suite *GenerateSuite) TestGenerateParameters() {
+	document := suite.generate()
+
+	suite.Equal([]Parameter{
+		{Name: "provider", In: "path", Required: true, Schema: &Schema{Type: "string"}},
+		{Name: "active", In: "query", Schema: &Schema{Type: "boolean"}},
+		{Name: "created_after", In: "query", Schema: &Schema{Type: "string", Format: "date-time"}},
+		{Name: "limit", In: "query", Schema: &Schema{Type: "integer"}},
+	}, document.Paths["/config/provider/{provider}"]["get"].Parameters)
+	suite.Equal([]Parameter{
+		{Name: "Idempotency-Key", In: "header", Description: "Key of the request across its retries", Schema: &Schema{Type: "string"}},
+	}, document.Paths["/config"]["post"].Parameters)
+}
+
+func (suite *GenerateSuite) TestGenerateBodies() {
+	operation := suite.generate().Paths["/config"]["post"]
+
+	ref := &Schema{Ref: "#/components/schemas/openapi.configDTO"}
+	suite.Equal(&RequestBody{Required: true, Content: map[string]MediaType{"application/json": {Schema: ref}}}, operation.RequestBody)
+	suite.Equal(Response{
+		Description: "OK",
+		Headers:     map[string]Header{"Idempotent-Replayed": {Description: "Set when the config was already created", Schema: &Schema{Type: "string"}}},
+		Content:     map[string]MediaType{"application/json": {Schema: ref}},
+	}, operation.Responses["200"])
+	suite.Equal(Response{
+		Description: "Unprocessable Entity",
+		Content:     map[string]MediaType{problem.ContentType: {Schema: &Schema{Ref: "#/components/schemas/problem.Problem"}}},
+	}, operation.Responses["422"])
+	suite.Len(operation.Responses, 3)
+}
+
+func (suite *GenerateSuite) TestGenerateTextResponses() {
+	operation := suite.generate().Paths["/config/{id}"]["delete"]
+
+	suite.Equal(Response{
+		Description: "OK",
+		Content:     map[string]MediaType{"text/plain": {Schema: &Schema{Type: "string", Example: "Config deleted successfully"}}},
+	}, operation.Responses["200"])
+	suite.Equal(Response{
+		Description: "Not Found",
+		Content:     map[string]MediaType{"text/plain": {Schema: &Schema{Type: "string"}}},
+	}, operation.Responses["404"])
+}
+
+func (suite *GenerateSuite) TestGenerateSchemasFromValidationRules() {
+	schemas := suite.generate().Components.Schemas
+
+	maxLength, maxItems := 64, 2
+	suite.Equal(&Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"service":    {Type: "string", Pattern: "^[A-Za-z0-9_-]+$", MaxLength: &maxLength},
+			"kind":       {Type: "string", Enum: []string{"input", "output"}},
+			"depends_on": {Type: "array", Items: &Schema{Ref: "#/components/schemas/openapi.dependencyDTO"}, MaxItems: &maxItems},
+			"parent":     {Ref: "#/components/schemas/openapi.dependencyDTO"},
+			"data":       {Type: "object", AdditionalProperties: &Schema{}, Description: "At most 1024 bytes once encoded as JSON."},
+			"created_at": {Type: "string", Format: "date-time"},
+		},
+		Required: []string{"service", "parent", "data"},
+	}, schemas["openapi.configDTO"])
+	suite.Contains(schemas, "openapi.dependencyDTO")
+	suite.Contains(schemas, "problem.Problem")
+	suite.Contains(schemas, "problem.FieldError")
+}
+
+func (suite *GenerateSuite) TestGenerateWhenHandlerIsNotDocumented() {
+	suite.router.Put("/config", suite.handler.CreateConfig)
+	suite.router.Get("/config", func(w http.ResponseWriter, r *http.Request) {})
+
+	_, err := Generate(Info{}, suite.router, Operations{"CreateConfig": operations["CreateConfig"]})
+
+	suite.ErrorContains(err, "GET /config: handler func1 is not documented")
+	suite.ErrorContains(err, "DELETE /config/{id:[a-z0-9]+}: handler DeleteConfig is not documented")
+	suite.NotContains(err.Error(), "PUT /config")
+}
+
+func (suite *GenerateSuite) TestGenerateWhenMethodIsNotStandard() {
+	chi.RegisterMethod("UPDATE")
+	suite.router.MethodFunc("UPDATE", "/config/{id}", suite.handler.CreateConfig)
+
+	_, err := Generate(Info{}, suite.router, operations)
+
+	suite.EqualError(err, "UPDATE /config/{id}: UPDATE is not a standard HTTP method")
+}
+
+func (suite *GenerateSuite) TestGenerateWhenOperationIsDocumentedTwice() {
+	_, err := Generate(Info{}, suite.router, operations, Operations{"ListConfigs": {}})
+
+	suite.EqualError(err, "operation ListConfigs is documented twice")
+}
@@ -0,0 +1,52 @@
+package openapi
+
+import (
+	_ "embed"
+	"libs/golang/shared/go-problem/problem"
+	"net/http"
+)
+
+var (
+	SpecPath = "/openapi.json" // Path serving the OpenAPI document of a service
+	DocsPath = "/docs"         // Path serving the documentation page of a service
+)
+
+// docsPage is the documentation page, which renders the document served at SpecPath.
+//
+//go:embed docs.html
+var docsPage []byte
+
+// SpecHandler returns the handler serving an OpenAPI document as JSON.
+//
+// Parameters:
+//   - document: The document to serve.
+//
+// Returns:
+//   - The handler to register on SpecPath.
+//
+// Example:
+//
+//	httpServer.RegisterRoute("GET", openapi.SpecPath, openapi.SpecHandler(document))
+func SpecHandler(document *Document) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		encoded, err := document.JSON()
+		if err != nil {
+			problem.Write(w, r, problem.ErrInternal.WithDetail(err.Error()))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(encoded)
+	}
+}
+
+// DocsHandler returns the handler serving the documentation page, which browses the document served at SpecPath.
+// The page is embedded in the binary and loads no external resources.
+//
+// Returns:
+//   - The handler to register on DocsPath.
+func DocsHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(docsPage)
+	}
+}
@@ -0,0 +1,33 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpecHandler(t *testing.T) {
+	document := &Document{OpenAPI: Version, Info: Info{Title: "config-vault", Version: "1.0.0"}, Paths: map[string]PathItem{}}
+	recorder := httptest.NewRecorder()
+
+	SpecHandler(document)(recorder, httptest.NewRequest(http.MethodGet, SpecPath, nil))
+
+	var served Document
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &served))
+	assert.Equal(t, *document, served)
+}
+
+func TestDocsHandler(t *testing.T) {
+	recorder := httptest.NewRecorder()
+
+	DocsHandler()(recorder, httptest.NewRequest(http.MethodGet, DocsPath, nil))
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "text/html; charset=utf-8", recorder.Header().Get("Content-Type"))
+	assert.Contains(t, recorder.Body.String(), `fetch("openapi.json")`)
+}
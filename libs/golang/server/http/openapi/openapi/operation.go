@@ -0,0 +1,28 @@
+package openapi
+
+// Operation documents the route served by a handler: the parameters and body it reads, and the responses it writes.
+// The path parameters are read from the pattern of the route, and the failed requests are answered with the problem
+// details of go-problem unless TextErrors is set.
+type Operation struct {
+	Summary         string            // Short summary of the operation
+	Description     string            // Longer description of the operation, in CommonMark
+	Query           interface{}       // Struct whose JSON fields are the query parameters, none when nil
+	Headers         map[string]string // Descriptions of the request headers, keyed by their names
+	Request         interface{}       // Value whose type is the JSON body of the requests, none when nil
+	Response        interface{}       // Value whose type is the JSON body of the responses, a string for plain text bodies
+	ResponseHeaders map[string]string // Descriptions of the response headers, keyed by their names
+	Errors          []int             // HTTP statuses of the failed responses
+	TextErrors      bool              // Whether the failed responses are plain text rather than problem details
+}
+
+// Operations documents the handlers of a package, keyed by the names of their functions or methods, such as
+// "CreateConfig" for the method WebConfigHandler.CreateConfig.
+type Operations map[string]Operation
+
+// PageQuery documents the query parameters selecting a page of the list routes, for the routes filtering on path
+// parameters rather than on the query.
+type PageQuery struct {
+	Limit  int    `json:"limit"`  // Number of items of the page, the server default when zero
+	Cursor string `json:"cursor"` // Cursor of the page, returned as the next cursor of the previous page
+	Sort   string `json:"sort"`   // Order of the items, such as "created_at" or "-created_at"
+}
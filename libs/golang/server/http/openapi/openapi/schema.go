@@ -0,0 +1,198 @@
+package openapi
+
+import (
+	"fmt"
+	"libs/golang/shared/go-validator/validator"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	schemasRef = "#/components/schemas/"     // Prefix of the references to the schemas of the components
+	timeType   = reflect.TypeOf(time.Time{}) // Times are encoded as RFC 3339 strings
+)
+
+// schemas builds the schemas of Go types from their JSON encoding, and collects the schemas of the named structs
+// as components.
+type schemas struct {
+	components map[string]*Schema
+	types      map[string]reflect.Type
+}
+
+// newSchemas creates an empty collection of schemas.
+func newSchemas() *schemas {
+	return &schemas{
+		components: map[string]*Schema{},
+		types:      map[string]reflect.Type{},
+	}
+}
+
+// of returns the schema of a type. The named structs are referenced, their schema being added to the components
+// under the qualified name of their type, such as "outputdto.ConfigDTO".
+//
+// Parameters:
+//   - t: The type to describe.
+//
+// Returns:
+//   - The schema of the type, the empty schema when the type has no JSON encoding.
+func (s *schemas) of(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == timeType {
+		return &Schema{Type: "string", Format: "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: s.of(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: s.of(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return s.object(t)
+		}
+		return &Schema{Ref: schemasRef + s.component(t)}
+	default:
+		return &Schema{}
+	}
+}
+
+// component adds the schema of a named struct to the components, unless it was already added, and returns its name.
+// A struct named like the struct of another package is named after its full package path.
+func (s *schemas) component(t reflect.Type) string {
+	name := t.String()
+	if known, ok := s.types[name]; ok && known != t {
+		name = strings.ReplaceAll(t.PkgPath(), "/", ".") + "." + t.Name()
+	}
+	if _, ok := s.types[name]; ok {
+		return name
+	}
+
+	// The type is registered before its fields are described, so a struct nesting itself is referenced.
+	s.types[name] = t
+	s.components[name] = &Schema{}
+	*s.components[name] = *s.object(t)
+	return name
+}
+
+// object returns the schema of a struct, whose properties are its exported fields named by their JSON names.
+func (s *schemas) object(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if embedded(field) {
+			fields := s.object(field.Type)
+			for name, property := range fields.Properties {
+				schema.Properties[name] = property
+			}
+			schema.Required = append(schema.Required, fields.Required...)
+			continue
+		}
+		name := jsonName(field)
+		if !field.IsExported() || name == "-" {
+			continue
+		}
+
+		property, required := s.field(field)
+		schema.Properties[name] = property
+		if required {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+	return schema
+}
+
+// field returns the schema of a struct field, constrained by the rules of its validate tag, and whether the rules
+// require it.
+func (s *schemas) field(field reflect.StructField) (*Schema, bool) {
+	schema := s.of(field.Type)
+	required := false
+	for _, rule := range strings.Split(field.Tag.Get(validator.TagName), ",") {
+		name, param, _ := strings.Cut(rule, "=")
+		if name == "required" {
+			required = true
+			continue
+		}
+		if schema.Ref != "" {
+			// The siblings of a reference are ignored, so only the required rule applies to the named structs.
+			continue
+		}
+
+		switch name {
+		case "identifier":
+			schema.Pattern = validator.IdentifierPattern.String()
+		case "max":
+			limit, err := strconv.Atoi(param)
+			if err != nil {
+				continue
+			}
+			switch schema.Type {
+			case "string":
+				schema.MaxLength = &limit
+			case "array":
+				schema.MaxItems = &limit
+			case "object":
+				schema.MaxProperties = &limit
+			}
+		case "maxbytes":
+			schema.Description = fmt.Sprintf("At most %s bytes once encoded as JSON.", param)
+		case "oneof":
+			schema.Enum = strings.Fields(param)
+		}
+	}
+	return schema, required
+}
+
+// parameters returns the query parameters described by the fields of a struct.
+func (s *schemas) parameters(t reflect.Type) []Parameter {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	var parameters []Parameter
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if embedded(field) {
+			parameters = append(parameters, s.parameters(field.Type)...)
+			continue
+		}
+		name := jsonName(field)
+		if !field.IsExported() || name == "-" {
+			continue
+		}
+
+		schema, required := s.field(field)
+		parameters = append(parameters, Parameter{Name: name, In: "query", Required: required, Schema: schema})
+	}
+	return parameters
+}
+
+// embedded reports whether a field is an embedded struct whose fields are encoded as fields of the embedding struct,
+// as encoding/json does for the embedded structs without a json tag.
+func embedded(field reflect.StructField) bool {
+	return field.Anonymous && field.Type.Kind() == reflect.Struct && field.Tag.Get("json") == ""
+}
+
+// jsonName returns the name of a field in JSON, its Go name when it has no json tag.
+func jsonName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
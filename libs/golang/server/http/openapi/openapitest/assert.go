@@ -0,0 +1,74 @@
+package openapitest
+
+import (
+	"libs/golang/server/http/openapi/openapi"
+	"net/http"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+var (
+	responseWriterType = reflect.TypeOf((*http.ResponseWriter)(nil)).Elem()
+	requestType        = reflect.TypeOf(&http.Request{})
+)
+
+// AssertDocumentsHandlers fails the test unless the operations document exactly the handler methods of a value, so
+// a handler added without being documented, or an operation left behind by a removed handler, is caught by the tests
+// of its package rather than when a service generates its document.
+//
+// Parameters:
+//   - t: The test to fail.
+//   - handler: The value whose methods with the signature of an http.HandlerFunc are the handlers, such as
+//     &WebConfigHandler{}.
+//   - operations: The operations documenting the handlers.
+//
+// Returns:
+//   - True if every handler is documented and every operation documents a handler.
+func AssertDocumentsHandlers(t testing.TB, handler interface{}, operations openapi.Operations) bool {
+	t.Helper()
+
+	handlers := handlerNames(handler)
+	served := make(map[string]bool, len(handlers))
+	ok := true
+	for _, name := range handlers {
+		served[name] = true
+		if _, documented := operations[name]; !documented {
+			t.Errorf("handler %s is not documented", name)
+			ok = false
+		}
+	}
+
+	names := make([]string, 0, len(operations))
+	for name := range operations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !served[name] {
+			t.Errorf("operation %s documents no handler of %T", name, handler)
+			ok = false
+		}
+	}
+	return ok
+}
+
+// handlerNames lists the methods of a value with the signature of an http.HandlerFunc.
+//
+// Parameters:
+//   - handler: The value whose methods are listed.
+//
+// Returns:
+//   - The names of the handler methods, sorted.
+func handlerNames(handler interface{}) []string {
+	handlerType := reflect.TypeOf(handler)
+	names := []string{}
+	for i := 0; i < handlerType.NumMethod(); i++ {
+		method := handlerType.Method(i).Type // The receiver is the first argument
+		if method.NumIn() == 3 && method.NumOut() == 0 && method.In(1) == responseWriterType && method.In(2) == requestType {
+			names = append(names, handlerType.Method(i).Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,73 @@
+package openapitest
+
+import (
+	"fmt"
+	"libs/golang/server/http/openapi/openapi"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+// recordingT records the failures of an assertion instead of failing the test running it.
+type recordingT struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recordingT) Helper() {}
+
+func (r *recordingT) Errorf(format string, args ...interface{}) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+// schemaHandler has handler methods, and methods that are not handlers and need no documentation.
+type schemaHandler struct{}
+
+func (h *schemaHandler) CreateSchema(w http.ResponseWriter, r *http.Request) {}
+func (h *schemaHandler) ListSchemas(w http.ResponseWriter, r *http.Request)  {}
+func (h *schemaHandler) DeleteSchema(w http.ResponseWriter, r *http.Request) {}
+func (h *schemaHandler) Validate(w http.ResponseWriter) error                { return nil }
+func (h *schemaHandler) Routes() []string                                    { return nil }
+
+type AssertSuite struct {
+	suite.Suite
+	operations openapi.Operations
+}
+
+func TestAssertSuite(t *testing.T) {
+	suite.Run(t, new(AssertSuite))
+}
+
+func (suite *AssertSuite) SetupTest() {
+	suite.operations = openapi.Operations{
+		"CreateSchema": {Summary: "Create a schema"},
+		"ListSchemas":  {Summary: "List the schemas"},
+		"DeleteSchema": {Summary: "Delete a schema"},
+	}
+}
+
+func (suite *AssertSuite) TestHandlerNames() {
+	assert.Equal(suite.T(), []string{"CreateSchema", "DeleteSchema", "ListSchemas"}, handlerNames(&schemaHandler{}))
+}
+
+func (suite *AssertSuite) TestDocumentsHandlers() {
+	assert.True(suite.T(), AssertDocumentsHandlers(suite.T(), &schemaHandler{}, suite.operations))
+}
+
+func (suite *AssertSuite) TestUndocumentedHandler() {
+	delete(suite.operations, "ListSchemas")
+	t := &recordingT{TB: suite.T()}
+
+	assert.False(suite.T(), AssertDocumentsHandlers(t, &schemaHandler{}, suite.operations))
+	assert.Equal(suite.T(), []string{"handler ListSchemas is not documented"}, t.failures)
+}
+
+func (suite *AssertSuite) TestOperationWithoutHandler() {
+	suite.operations["UpdateSchema"] = openapi.Operation{Summary: "Update a schema"}
+	t := &recordingT{TB: suite.T()}
+
+	assert.False(suite.T(), AssertDocumentsHandlers(t, &schemaHandler{}, suite.operations))
+	assert.Equal(suite.T(), []string{"operation UpdateSchema documents no handler of *openapitest.schemaHandler"}, t.failures)
+}
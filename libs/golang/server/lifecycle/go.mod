@@ -0,0 +1,3 @@
+module libs/golang/server/lifecycle
+
+go 1.22
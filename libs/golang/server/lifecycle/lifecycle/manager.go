@@ -0,0 +1,207 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+var (
+	// DefaultTimeout is the deadline of the shutdown when none is configured.
+	DefaultTimeout = 30 * time.Second
+
+	// shutdownSignals are the signals that start the shutdown.
+	shutdownSignals = []os.Signal{syscall.SIGINT, syscall.SIGTERM}
+)
+
+// StopFunc stops a component. It returns once the component stopped, or with an error when the context
+// is done first.
+type StopFunc func(ctx context.Context) error
+
+// component is a registered component and the function stopping it.
+type component struct {
+	name string
+	stop StopFunc
+}
+
+// Manager runs the long-lived components of a service and stops them when the service terminates.
+//
+// Components are stopped in the reverse order of their registration, like deferred calls: the clients a
+// component depends on are registered before it, so they are closed after it stopped. The whole shutdown
+// shares a single deadline.
+type Manager struct {
+	timeout    time.Duration
+	mu         sync.Mutex
+	components []component
+	failed     chan error // First failure of a component run by Serve
+}
+
+// NewManager creates a new instance of Manager.
+//
+// Parameters:
+//   - timeout: The deadline of the shutdown, DefaultTimeout when not positive.
+//
+// Returns:
+//   - A new instance of Manager.
+func NewManager(timeout time.Duration) *Manager {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &Manager{
+		timeout: timeout,
+		failed:  make(chan error, 1),
+	}
+}
+
+// ParseTimeout parses the deadline of the shutdown, such as "30s".
+//
+// Parameters:
+//   - value: The deadline as a duration string, DefaultTimeout when empty.
+//
+// Returns:
+//   - The deadline of the shutdown.
+//   - An error if the value is not a positive duration.
+func ParseTimeout(value string) (time.Duration, error) {
+	if value == "" {
+		return DefaultTimeout, nil
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid shutdown timeout %q: %w", value, err)
+	}
+	if timeout <= 0 {
+		return 0, fmt.Errorf("invalid shutdown timeout %q: must be positive", value)
+	}
+	return timeout, nil
+}
+
+// Register registers a component stopped on shutdown.
+//
+// Parameters:
+//   - name: The name of the component, used in logs and errors.
+//   - stop: The function stopping the component, such as the Disconnect of a MongoDB client.
+func (m *Manager) Register(name string, stop StopFunc) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.components = append(m.components, component{name: name, stop: stop})
+}
+
+// RegisterCloser registers a component closed on shutdown by a function without a context, such as the
+// Close of a RabbitMQ client. The shutdown does not wait for it past its deadline.
+//
+// Parameters:
+//   - name: The name of the component, used in logs and errors.
+//   - closeFunc: The function closing the component.
+func (m *Manager) RegisterCloser(name string, closeFunc func() error) {
+	m.Register(name, func(ctx context.Context) error {
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- closeFunc()
+		}()
+		select {
+		case err := <-errCh:
+			return err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+}
+
+// Run runs a background loop, such as a relay or a sweeper, until the shutdown cancels its context. The
+// shutdown waits for the loop to return.
+//
+// Parameters:
+//   - name: The name of the loop, used in logs and errors.
+//   - run: The loop. It must return once its context is done.
+func (m *Manager) Run(name string, run func(ctx context.Context)) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		run(ctx)
+	}()
+	m.Register(name, func(stopCtx context.Context) error {
+		cancel()
+		select {
+		case <-done:
+			return nil
+		case <-stopCtx.Done():
+			return stopCtx.Err()
+		}
+	})
+}
+
+// Serve runs a server, such as an HTTP server, and registers the function shutting it down. A server that
+// fails starts the shutdown of the other components.
+//
+// Parameters:
+//   - name: The name of the server, used in logs and errors.
+//   - serve: The function running the server. It blocks until the server stops, and returns nil when the
+//     server was shut down.
+//   - shutdown: The function stopping the server.
+func (m *Manager) Serve(name string, serve func() error, shutdown StopFunc) {
+	m.Register(name, shutdown)
+	go func() {
+		if err := serve(); err != nil {
+			select {
+			case m.failed <- fmt.Errorf("%s failed: %w", name, err):
+			default:
+			}
+		}
+	}()
+}
+
+// Wait blocks until the service receives SIGINT or SIGTERM, the context is done, or a server run by Serve
+// fails, then shuts the components down.
+//
+// Parameters:
+//   - ctx: The context of the service.
+//
+// Returns:
+//   - The failure of the server that started the shutdown, joined with the errors of the shutdown.
+func (m *Manager) Wait(ctx context.Context) error {
+	ctx, stop := signal.NotifyContext(ctx, shutdownSignals...)
+	defer stop()
+
+	var failure error
+	select {
+	case <-ctx.Done():
+		log.Println("Received shutdown signal")
+	case failure = <-m.failed:
+		log.Printf("Shutting down after failure: %v", failure)
+	}
+	return errors.Join(failure, m.Shutdown())
+}
+
+// Shutdown stops the registered components in the reverse order of their registration, within the
+// deadline of the Manager. Once the deadline is over, the remaining components are still stopped, with
+// an expired context, so the clients are closed in order. A component is stopped only once.
+//
+// Returns:
+//   - The errors of the components that failed to stop, joined.
+func (m *Manager) Shutdown() error {
+	m.mu.Lock()
+	components := m.components
+	m.components = nil
+	m.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+	defer cancel()
+
+	var errs []error
+	for i := len(components) - 1; i >= 0; i-- {
+		component := components[i]
+		log.Printf("Stopping %s", component.name)
+		if err := component.stop(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("failed to stop %s: %w", component.name, err))
+		}
+	}
+	log.Println("Shutdown complete")
+	return errors.Join(errs...)
+}
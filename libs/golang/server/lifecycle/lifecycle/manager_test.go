@@ -0,0 +1,131 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ManagerSuite struct {
+	suite.Suite
+	manager *Manager
+	stopped []string
+}
+
+func TestManagerSuite(t *testing.T) {
+	suite.Run(t, new(ManagerSuite))
+}
+
+func (suite *ManagerSuite) SetupTest() {
+	suite.manager = NewManager(100 * time.Millisecond)
+	suite.stopped = nil
+}
+
+func (suite *ManagerSuite) stopFunc(name string, err error) StopFunc {
+	return func(ctx context.Context) error {
+		suite.stopped = append(suite.stopped, name)
+		return err
+	}
+}
+
+func (suite *ManagerSuite) TestShutdownStopsInReverseOrder() {
+	suite.manager.Register("mongodb", suite.stopFunc("mongodb", nil))
+	suite.manager.RegisterCloser("rabbitmq", func() error {
+		suite.stopped = append(suite.stopped, "rabbitmq")
+		return nil
+	})
+	suite.manager.Register("http server", suite.stopFunc("http server", nil))
+
+	suite.NoError(suite.manager.Shutdown())
+	suite.Equal([]string{"http server", "rabbitmq", "mongodb"}, suite.stopped)
+
+	suite.NoError(suite.manager.Shutdown())
+	suite.Len(suite.stopped, 3, "Components should be stopped only once")
+}
+
+func (suite *ManagerSuite) TestShutdownJoinsErrors() {
+	errStop := errors.New("stop failed")
+	suite.manager.Register("mongodb", suite.stopFunc("mongodb", errStop))
+	suite.manager.Register("http server", suite.stopFunc("http server", nil))
+
+	err := suite.manager.Shutdown()
+
+	suite.ErrorIs(err, errStop)
+	suite.ErrorContains(err, "failed to stop mongodb")
+	suite.Equal([]string{"http server", "mongodb"}, suite.stopped)
+}
+
+func (suite *ManagerSuite) TestShutdownDeadline() {
+	suite.manager.Register("mongodb", suite.stopFunc("mongodb", nil))
+	suite.manager.Register("listener", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	err := suite.manager.Shutdown()
+
+	suite.ErrorIs(err, context.DeadlineExceeded)
+	suite.Equal([]string{"mongodb"}, suite.stopped, "Components after the deadline should still be stopped")
+}
+
+func (suite *ManagerSuite) TestRunCancelsAndWaits() {
+	returned := make(chan struct{})
+	suite.manager.Run("relay", func(ctx context.Context) {
+		<-ctx.Done()
+		close(returned)
+	})
+
+	suite.NoError(suite.manager.Shutdown())
+	suite.Require().Eventually(func() bool {
+		select {
+		case <-returned:
+			return true
+		default:
+			return false
+		}
+	}, time.Second, time.Millisecond)
+}
+
+func (suite *ManagerSuite) TestWaitShutsDownWhenServerFails() {
+	errServe := errors.New("address already in use")
+	suite.manager.Register("mongodb", suite.stopFunc("mongodb", nil))
+	suite.manager.Serve("http server", func() error { return errServe }, suite.stopFunc("http server", nil))
+
+	err := suite.manager.Wait(context.Background())
+
+	suite.ErrorIs(err, errServe)
+	suite.Equal([]string{"http server", "mongodb"}, suite.stopped)
+}
+
+func (suite *ManagerSuite) TestWaitShutsDownWhenContextIsDone() {
+	stopped := make(chan struct{})
+	suite.manager.Serve("http server", func() error {
+		<-stopped
+		return nil
+	}, func(ctx context.Context) error {
+		close(stopped)
+		return nil
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	suite.NoError(suite.manager.Wait(ctx))
+}
+
+func (suite *ManagerSuite) TestParseTimeout() {
+	timeout, err := ParseTimeout("")
+	suite.NoError(err)
+	suite.Equal(DefaultTimeout, timeout)
+
+	timeout, err = ParseTimeout("5s")
+	suite.NoError(err)
+	suite.Equal(5*time.Second, timeout)
+
+	_, err = ParseTimeout("soon")
+	suite.Error(err)
+	_, err = ParseTimeout("-1s")
+	suite.Error(err)
+}
@@ -0,0 +1,115 @@
+package criteria
+
+import (
+	"time"
+)
+
+// Operator is a comparison applied to a field, named after its MongoDB query operator.
+type Operator string
+
+const (
+	Eq  Operator = "$eq"  // Eq matches the values equal to the argument.
+	Gt  Operator = "$gt"  // Gt matches the values greater than the argument.
+	Gte Operator = "$gte" // Gte matches the values greater than or equal to the argument.
+	Lt  Operator = "$lt"  // Lt matches the values less than the argument.
+	Lte Operator = "$lte" // Lte matches the values less than or equal to the argument.
+	In  Operator = "$in"  // In matches the values equal to one of the elements of the argument.
+)
+
+// Condition constrains a field of the documents.
+type Condition struct {
+	Field    string      // Dot-separated path of the field, such as "metadata.provider"
+	Operator Operator    // Comparison applied to the field
+	Value    interface{} // Argument of the comparison
+}
+
+// Criteria is a conjunction of conditions: a document matches when it matches every condition.
+type Criteria []Condition
+
+// Where adds a condition to the criteria.
+//
+// Parameters:
+//   - field: The dot-separated path of the field.
+//   - operator: The comparison applied to the field.
+//   - value: The argument of the comparison.
+//
+// Returns:
+//   - The criteria with the condition.
+func (c Criteria) Where(field string, operator Operator, value interface{}) Criteria {
+	return append(c, Condition{Field: field, Operator: operator, Value: value})
+}
+
+// WhereNotEmpty adds an equality condition unless the value is empty, so optional filters can be
+// added unconditionally.
+//
+// Parameters:
+//   - field: The dot-separated path of the field.
+//   - value: The value the field must equal, no condition when empty.
+//
+// Returns:
+//   - The criteria with the condition, if any.
+func (c Criteria) WhereNotEmpty(field, value string) Criteria {
+	if value == "" {
+		return c
+	}
+	return c.Where(field, Eq, value)
+}
+
+// WhereTime adds a comparison of a timestamp field unless the time is zero. The time is formatted
+// with the layout the field is stored with, in local time, so the timestamps compare as strings.
+//
+// Parameters:
+//   - field: The dot-separated path of the timestamp field.
+//   - operator: The comparison applied to the field, such as Gt for a lower bound.
+//   - value: The time to compare with, no condition when zero.
+//   - layout: The layout of the stored timestamps.
+//
+// Returns:
+//   - The criteria with the condition, if any.
+func (c Criteria) WhereTime(field string, operator Operator, value time.Time, layout string) Criteria {
+	if value.IsZero() {
+		return c
+	}
+	return c.Where(field, operator, value.Local().Format(layout))
+}
+
+// Query translates the criteria into a query document, understood by both the MongoDB driver and
+// go-docdb. Equalities are plain field values, so the go-docdb indexes can serve them, and the other
+// comparisons of a field are grouped into a single operator document.
+//
+// Returns:
+//   - The query document, empty when there is no condition.
+//
+// Example:
+//
+//	query := Criteria{}.
+//	    Where("provider", Eq, "acme").
+//	    Where("created_at", Gt, "2024-01-01 00:00:00").
+//	    Query()
+//	// {"provider": "acme", "created_at": {"$gt": "2024-01-01 00:00:00"}}
+func (c Criteria) Query() map[string]interface{} {
+	equals := make(map[string]interface{})
+	operators := make(map[string]map[string]interface{})
+	for _, condition := range c {
+		if _, ok := equals[condition.Field]; condition.Operator == Eq && !ok {
+			equals[condition.Field] = condition.Value
+			continue
+		}
+		if operators[condition.Field] == nil {
+			operators[condition.Field] = make(map[string]interface{})
+		}
+		operators[condition.Field][string(condition.Operator)] = condition.Value
+	}
+
+	query := make(map[string]interface{}, len(equals)+len(operators))
+	for field, value := range equals {
+		query[field] = value
+	}
+	for field, fieldOperators := range operators {
+		if value, ok := equals[field]; ok {
+			fieldOperators[string(Eq)] = value
+		}
+		query[field] = fieldOperators
+	}
+	return query
+}
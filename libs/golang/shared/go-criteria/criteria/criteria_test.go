@@ -0,0 +1,65 @@
+package criteria
+
+import (
+	"testing"
+	"time"
+
+	"libs/golang/database/go-docdb/database"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuery(t *testing.T) {
+	query := Criteria{}.
+		Where("metadata.provider", Eq, "acme").
+		Where("created_at", Gt, "2024-01-01 00:00:00").
+		Where("created_at", Lt, "2024-02-01 00:00:00").
+		Where("stage", In, []string{"dispatched", "running"}).
+		Query()
+
+	assert.Equal(t, map[string]interface{}{
+		"metadata.provider": "acme",
+		"created_at":        map[string]interface{}{"$gt": "2024-01-01 00:00:00", "$lt": "2024-02-01 00:00:00"},
+		"stage":             map[string]interface{}{"$in": []string{"dispatched", "running"}},
+	}, query)
+	assert.Empty(t, Criteria{}.Query())
+}
+
+func TestQueryMergesEqualityWithOperators(t *testing.T) {
+	query := Criteria{}.Where("code", Eq, 1).Where("code", Lt, 5).Query()
+	assert.Equal(t, map[string]interface{}{"code": map[string]interface{}{"$eq": 1, "$lt": 5}}, query)
+}
+
+func TestOptionalConditions(t *testing.T) {
+	createdAfter := time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)
+	c := Criteria{}.
+		WhereNotEmpty("provider", "acme").
+		WhereNotEmpty("service", "").
+		WhereTime("created_at", Gt, createdAfter, "2006-01-02 15:04:05").
+		WhereTime("created_at", Lt, time.Time{}, "2006-01-02 15:04:05")
+
+	assert.Equal(t, Criteria{
+		{Field: "provider", Operator: Eq, Value: "acme"},
+		{Field: "created_at", Operator: Gt, Value: "2024-01-01 00:00:00"},
+	}, c)
+}
+
+func TestQueryOnDocDB(t *testing.T) {
+	collection := database.NewCollection()
+	for _, document := range []database.Document{
+		{"_id": "1", "provider": "acme", "created_at": "2024-01-10 00:00:00"},
+		{"_id": "2", "provider": "acme", "created_at": "2023-12-10 00:00:00"},
+		{"_id": "3", "provider": "other", "created_at": "2024-01-10 00:00:00"},
+	} {
+		assert.NoError(t, collection.InsertOne(document))
+	}
+
+	query := Criteria{}.
+		Where("provider", Eq, "acme").
+		Where("created_at", Gt, "2024-01-01 00:00:00").
+		Query()
+	documents := collection.Find(query)
+
+	assert.Len(t, documents, 1)
+	assert.Equal(t, "1", documents[0]["_id"])
+}
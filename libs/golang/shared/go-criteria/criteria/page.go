@@ -0,0 +1,212 @@
+package criteria
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+var (
+	DefaultLimit = 100  // Number of documents of a page when the limit is not set
+	MaxLimit     = 1000 // Largest number of documents of a page, larger limits are lowered to it
+	idField      = "_id"
+)
+
+var (
+	// ErrInvalidCursor is returned when a cursor cannot be decoded or was issued for another sort.
+	ErrInvalidCursor = errors.New("invalid cursor")
+
+	// ErrInvalidSort is returned when a sort is not one of the sort fields allowed by ParseSort.
+	ErrInvalidSort = errors.New("invalid sort")
+)
+
+// Order is the direction of a sort, with the values of the MongoDB sort documents.
+type Order int
+
+const (
+	Ascending  Order = 1  // Ascending orders the smallest values first.
+	Descending Order = -1 // Descending orders the largest values first.
+)
+
+// Sort orders the documents on a field, then on their ID, so documents with the same value keep a
+// stable order across pages. The zero Sort orders the documents on their ID only.
+type Sort struct {
+	Field string // Dot-separated path of a string field, such as "created_at", the ID only when empty
+	Order Order  // Direction of the sort, Ascending when zero
+}
+
+// Key is a field of a sort document with its direction.
+type Key struct {
+	Field string
+	Order Order
+}
+
+// NewSort reads a sort written as a field name, prefixed with "-" for a descending sort.
+//
+// Parameters:
+//   - value: The sort, such as "created_at" or "-created_at".
+//
+// Returns:
+//   - The sort on the field.
+func NewSort(value string) Sort {
+	if field, ok := strings.CutPrefix(value, "-"); ok {
+		return Sort{Field: field, Order: Descending}
+	}
+	return Sort{Field: value, Order: Ascending}
+}
+
+// ParseSort reads a sort like NewSort, checking that it is one of the allowed sort fields. The cursors
+// hold the sort value of the last document as a string, so the allowed fields must be strings that sort
+// in the order of the documents, such as the timestamps stored in the "2006-01-02 15:04:05" layout of
+// TimestampSortFields.
+//
+// Parameters:
+//   - value: The sort, such as "created_at" or "-created_at".
+//   - fields: The allowed sorts, such as TimestampSortFields.
+//
+// Returns:
+//   - The sort on the field.
+//   - An error wrapping ErrInvalidSort if the sort is not one of the fields.
+func ParseSort(value string, fields []string) (Sort, error) {
+	if !slices.Contains(fields, value) {
+		return Sort{}, fmt.Errorf("%w %q: must be one of %s", ErrInvalidSort, value, strings.Join(fields, ", "))
+	}
+	return NewSort(value), nil
+}
+
+// String writes the sort the way NewSort reads it.
+func (s Sort) String() string {
+	if s.order() == Descending {
+		return "-" + s.Field
+	}
+	return s.Field
+}
+
+// Keys lists the fields the documents are ordered on: the sort field, if any, then the ID.
+//
+// Returns:
+//   - The keys of the sort document, in order.
+func (s Sort) Keys() []Key {
+	keys := []Key{{Field: idField, Order: s.order()}}
+	if s.Field == "" {
+		return keys
+	}
+	return append([]Key{{Field: s.Field, Order: s.order()}}, keys...)
+}
+
+func (s Sort) order() Order {
+	if s.Order == Descending {
+		return Descending
+	}
+	return Ascending
+}
+
+// Page selects a page of the documents matching a query. Pages are read with keyset pagination: the
+// cursor holds the sort value and ID of the last document of the previous page, so a page is as cheap
+// to read as the first one and documents inserted meanwhile do not shift the pages.
+type Page struct {
+	Limit  int    // Number of documents of the page, DefaultLimit when zero and at most MaxLimit
+	Cursor string // Cursor returned with the previous page, the first page when empty
+	Sort   Sort   // Order of the documents
+}
+
+// cursor is the position of the last document of a page. It is encoded as base64 JSON, so clients
+// handle it as an opaque string.
+type cursor struct {
+	Sort  string `json:"s"`
+	Value string `json:"v,omitempty"`
+	ID    string `json:"id"`
+}
+
+// Size returns the number of documents of the page, with the limit bounded to [1, MaxLimit].
+//
+// Returns:
+//   - The number of documents to read.
+func (p Page) Size() int {
+	switch {
+	case p.Limit <= 0:
+		return DefaultLimit
+	case p.Limit > MaxLimit:
+		return MaxLimit
+	default:
+		return p.Limit
+	}
+}
+
+// Query restricts a query document to the documents after the cursor. The restriction is joined with
+// `$and`, so it never conflicts with the conditions of the query.
+//
+// Parameters:
+//   - query: The query document selecting the documents, such as the result of Criteria.Query.
+//
+// Returns:
+//   - The query document of the page, the query itself when there is no cursor.
+//   - An error wrapping ErrInvalidCursor if the cursor cannot be decoded or was issued for another sort.
+//
+// Example:
+//
+//	page := Page{Cursor: next, Sort: NewSort("created_at")}
+//	query, err := page.Query(Criteria{}.Where("provider", Eq, "acme").Query())
+//	// {"$and": [{"provider": "acme"}, {"$or": [
+//	//     {"created_at": {"$gt": "2024-01-01 00:00:00"}},
+//	//     {"created_at": "2024-01-01 00:00:00", "_id": {"$gt": "a1b2"}}]}]}
+func (p Page) Query(query map[string]interface{}) (map[string]interface{}, error) {
+	if p.Cursor == "" {
+		return query, nil
+	}
+	position, err := p.decode()
+	if err != nil {
+		return nil, err
+	}
+
+	operator := Gt
+	if p.Sort.order() == Descending {
+		operator = Lt
+	}
+	after := map[string]interface{}{idField: map[string]interface{}{string(operator): position.ID}}
+	if p.Sort.Field != "" {
+		after = map[string]interface{}{"$or": []interface{}{
+			map[string]interface{}{p.Sort.Field: map[string]interface{}{string(operator): position.Value}},
+			map[string]interface{}{p.Sort.Field: position.Value, idField: after[idField]},
+		}}
+	}
+	if len(query) == 0 {
+		return after, nil
+	}
+	return map[string]interface{}{"$and": []interface{}{query, after}}, nil
+}
+
+// Next returns the cursor of the page following the document last read.
+//
+// Parameters:
+//   - value: The value of the sort field of the last document, ignored when sorting on the ID only.
+//   - id: The ID of the last document.
+//
+// Returns:
+//   - The opaque cursor of the next page.
+func (p Page) Next(value, id string) string {
+	position := cursor{Sort: p.Sort.String(), ID: id}
+	if p.Sort.Field != "" {
+		position.Value = value
+	}
+	encoded, _ := json.Marshal(position)
+	return base64.RawURLEncoding.EncodeToString(encoded)
+}
+
+func (p Page) decode() (cursor, error) {
+	var position cursor
+	decoded, err := base64.RawURLEncoding.DecodeString(p.Cursor)
+	if err != nil {
+		return cursor{}, fmt.Errorf("%w: %q is not a cursor", ErrInvalidCursor, p.Cursor)
+	}
+	if err := json.Unmarshal(decoded, &position); err != nil || position.ID == "" {
+		return cursor{}, fmt.Errorf("%w: %q is not a cursor", ErrInvalidCursor, p.Cursor)
+	}
+	if position.Sort != p.Sort.String() {
+		return cursor{}, fmt.Errorf("%w: the cursor was issued for the sort %q, not %q", ErrInvalidCursor, position.Sort, p.Sort.String())
+	}
+	return position, nil
+}
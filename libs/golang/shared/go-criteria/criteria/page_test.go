@@ -0,0 +1,77 @@
+package criteria
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSort(t *testing.T) {
+	assert.Equal(t, Sort{Field: "created_at", Order: Ascending}, NewSort("created_at"))
+	assert.Equal(t, Sort{Field: "created_at", Order: Descending}, NewSort("-created_at"))
+	assert.Equal(t, "-created_at", NewSort("-created_at").String())
+	assert.Equal(t, "created_at", Sort{Field: "created_at"}.String())
+}
+
+func TestParseSort(t *testing.T) {
+	sort, err := ParseSort("-updated_at", TimestampSortFields)
+	assert.NoError(t, err)
+	assert.Equal(t, Sort{Field: "updated_at", Order: Descending}, sort)
+
+	_, err = ParseSort("data.amount", TimestampSortFields)
+	assert.ErrorIs(t, err, ErrInvalidSort)
+	assert.EqualError(t, err, `invalid sort "data.amount": must be one of created_at, -created_at, updated_at, -updated_at`)
+}
+
+func TestSortKeys(t *testing.T) {
+	assert.Equal(t, []Key{{Field: "created_at", Order: Descending}, {Field: "_id", Order: Descending}}, NewSort("-created_at").Keys())
+	assert.Equal(t, []Key{{Field: "_id", Order: Ascending}}, Sort{}.Keys())
+}
+
+func TestPageSize(t *testing.T) {
+	assert.Equal(t, DefaultLimit, Page{}.Size())
+	assert.Equal(t, 10, Page{Limit: 10}.Size())
+	assert.Equal(t, MaxLimit, Page{Limit: MaxLimit + 1}.Size())
+}
+
+func TestPageQueryWithoutCursor(t *testing.T) {
+	query := map[string]interface{}{"provider": "acme"}
+
+	paged, err := Page{Sort: NewSort("created_at")}.Query(query)
+
+	assert.NoError(t, err)
+	assert.Equal(t, query, paged)
+}
+
+func TestPageQueryAfterCursor(t *testing.T) {
+	page := Page{Sort: NewSort("created_at")}
+	page.Cursor = page.Next("2024-01-01 00:00:00", "a1b2")
+
+	paged, err := page.Query(map[string]interface{}{"provider": "acme"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"$and": []interface{}{
+		map[string]interface{}{"provider": "acme"},
+		map[string]interface{}{"$or": []interface{}{
+			map[string]interface{}{"created_at": map[string]interface{}{"$gt": "2024-01-01 00:00:00"}},
+			map[string]interface{}{"created_at": "2024-01-01 00:00:00", "_id": map[string]interface{}{"$gt": "a1b2"}},
+		}},
+	}}, paged)
+}
+
+func TestPageQueryAfterCursorDescendingOnID(t *testing.T) {
+	page := Page{Sort: Sort{Order: Descending}}
+	page.Cursor = page.Next("ignored", "a1b2")
+
+	paged, err := page.Query(map[string]interface{}{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"_id": map[string]interface{}{"$lt": "a1b2"}}, paged)
+}
+
+func TestPageQueryWithInvalidCursor(t *testing.T) {
+	for _, c := range []string{"not base64!", "bm90IGpzb24", Page{Sort: NewSort("updated_at")}.Next("v", "id")} {
+		_, err := Page{Cursor: c, Sort: NewSort("created_at")}.Query(nil)
+		assert.ErrorIs(t, err, ErrInvalidCursor, c)
+	}
+}
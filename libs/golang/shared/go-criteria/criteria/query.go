@@ -0,0 +1,126 @@
+package criteria
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// TimestampSortFields lists the sort values ordering the documents on their creation or update time. A "-" prefix
+// sorts in descending order. The times are stored as strings in the "2006-01-02 15:04:05" layout, which sorts in
+// chronological order, so the cursors of ParseSort can hold them.
+var TimestampSortFields = []string{"created_at", "-created_at", "updated_at", "-updated_at"}
+
+// ListQuery represents the query parameters shared by the list routes: the bounds of the creation time and the
+// page to read, such as `?created_after=2024-01-01T00:00:00Z&limit=50&sort=-created_at`. It is embedded in the
+// filters of the list routes, which add their own fields. Every field is optional.
+type ListQuery struct {
+	CreatedAfter  time.Time `json:"created_after"`  // Items created strictly after this time, no lower bound when zero.
+	CreatedBefore time.Time `json:"created_before"` // Items created strictly before this time, no upper bound when zero.
+	Limit         int       `json:"limit"`          // Number of items of the page, the server default when zero.
+	Cursor        string    `json:"cursor"`         // Cursor of the page, returned as the next cursor of the previous page, the first page when empty.
+	Sort          string    `json:"sort"`           // Order of the items, one of the sort fields of the route, by creation time when empty.
+}
+
+// NewListQuery reads a ListQuery from query parameters. The times are RFC 3339 timestamps.
+//
+// Parameters:
+//   - query: The query parameters of the request.
+//   - sortFields: The values the sort parameter may take, such as TimestampSortFields.
+//
+// Returns:
+//   - The list query read from the query parameters.
+//   - An error if a time is not an RFC 3339 timestamp, the limit is not a positive integer or the sort is not
+//     one of sortFields.
+func NewListQuery(query url.Values, sortFields []string) (ListQuery, error) {
+	var listQuery ListQuery
+	var err error
+	if listQuery.CreatedAfter, err = parseTime(query, "created_after"); err != nil {
+		return ListQuery{}, err
+	}
+	if listQuery.CreatedBefore, err = parseTime(query, "created_before"); err != nil {
+		return ListQuery{}, err
+	}
+	if listQuery.Limit, err = parseLimit(query); err != nil {
+		return ListQuery{}, err
+	}
+	listQuery.Cursor = query.Get("cursor")
+	if listQuery.Sort, err = parseSort(query, sortFields); err != nil {
+		return ListQuery{}, err
+	}
+	return listQuery, nil
+}
+
+// Values writes the list query as query parameters, leaving out the fields that are not set.
+//
+// Returns:
+//   - The query parameters of the list query.
+func (q ListQuery) Values() url.Values {
+	query := url.Values{}
+	setTime(query, "created_after", q.CreatedAfter)
+	setTime(query, "created_before", q.CreatedBefore)
+	if q.Limit > 0 {
+		query.Set("limit", strconv.Itoa(q.Limit))
+	}
+	SetNotEmpty(query, "cursor", q.Cursor)
+	SetNotEmpty(query, "sort", q.Sort)
+	return query
+}
+
+// SetNotEmpty sets a query parameter unless the value is empty.
+//
+// Parameters:
+//   - query: The query parameters to write to.
+//   - key: The name of the parameter.
+//   - value: The value of the parameter.
+func SetNotEmpty(query url.Values, key, value string) {
+	if value != "" {
+		query.Set(key, value)
+	}
+}
+
+// parseTime reads an optional RFC 3339 timestamp from a query parameter.
+func parseTime(query url.Values, key string) (time.Time, error) {
+	value := query.Get(key)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s %q: must be an RFC 3339 timestamp", key, value)
+	}
+	return parsed, nil
+}
+
+// parseLimit reads the optional limit query parameter, which must be a positive integer.
+func parseLimit(query url.Values) (int, error) {
+	value := query.Get("limit")
+	if value == "" {
+		return 0, nil
+	}
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit <= 0 {
+		return 0, fmt.Errorf("invalid limit %q: must be a positive integer", value)
+	}
+	return limit, nil
+}
+
+// parseSort reads the optional sort query parameter, which must be one of the sort fields.
+func parseSort(query url.Values, sortFields []string) (string, error) {
+	value := query.Get("sort")
+	if value == "" {
+		return "", nil
+	}
+	if _, err := ParseSort(value, sortFields); err != nil {
+		return "", err
+	}
+	return value, nil
+}
+
+// setTime sets a query parameter to an RFC 3339 timestamp unless the time is zero.
+func setTime(query url.Values, key string, value time.Time) {
+	if !value.IsZero() {
+		query.Set(key, value.Format(time.RFC3339))
+	}
+}
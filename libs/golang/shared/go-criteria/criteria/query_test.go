@@ -0,0 +1,44 @@
+package criteria
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewListQuery(t *testing.T) {
+	query := url.Values{
+		"created_after": {"2024-01-01T00:00:00Z"},
+		"limit":         {"50"},
+		"cursor":        {"abc"},
+		"sort":          {"-updated_at"},
+	}
+
+	listQuery, err := NewListQuery(query, TimestampSortFields)
+
+	assert.NoError(t, err)
+	assert.Equal(t, ListQuery{
+		CreatedAfter: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Limit:        50,
+		Cursor:       "abc",
+		Sort:         "-updated_at",
+	}, listQuery)
+	assert.Equal(t, query, listQuery.Values())
+}
+
+func TestNewListQueryRejectsInvalidParameters(t *testing.T) {
+	_, err := NewListQuery(url.Values{"created_before": {"yesterday"}}, TimestampSortFields)
+	assert.EqualError(t, err, `invalid created_before "yesterday": must be an RFC 3339 timestamp`)
+
+	_, err = NewListQuery(url.Values{"limit": {"0"}}, TimestampSortFields)
+	assert.EqualError(t, err, `invalid limit "0": must be a positive integer`)
+
+	_, err = NewListQuery(url.Values{"sort": {"provider"}}, TimestampSortFields)
+	assert.EqualError(t, err, `invalid sort "provider": must be one of created_at, -created_at, updated_at, -updated_at`)
+}
+
+func TestListQueryValuesLeaveOutEmptyFields(t *testing.T) {
+	assert.Empty(t, ListQuery{}.Values())
+}
@@ -0,0 +1,3 @@
+module libs/golang/shared/go-criteria
+
+go 1.22
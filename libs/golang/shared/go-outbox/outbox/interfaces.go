@@ -0,0 +1,16 @@
+package outbox
+
+import "context"
+
+// StoreInterface defines the methods an outbox store should implement.
+type StoreInterface interface {
+	InsertOne(ctx context.Context, message *Message) error
+	FindPending(ctx context.Context, limit int) ([]*Message, error)
+	MarkSent(ctx context.Context, id string) error
+	MarkFailed(ctx context.Context, id string, cause error) error
+}
+
+// PublisherInterface defines a publisher that only returns once the broker confirmed the message.
+type PublisherInterface interface {
+	NotifyWithConfirm(ctx context.Context, message []byte, routingKey string) error
+}
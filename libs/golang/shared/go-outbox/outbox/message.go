@@ -0,0 +1,69 @@
+package outbox
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	gouuid "libs/golang/shared/id/go-uuid"
+	events "libs/golang/shared/go-events/amqp_events"
+)
+
+// Status represents the delivery status of an outbox message.
+type Status string
+
+const (
+	// StatusPending means the message was stored but not yet confirmed by the broker.
+	StatusPending Status = "pending"
+	// StatusSent means the message was published and confirmed by the broker.
+	StatusSent Status = "sent"
+)
+
+// Message is an event waiting in the outbox to be published.
+type Message struct {
+	ID         string     `bson:"_id"`
+	EventName  string     `bson:"event_name"`
+	RoutingKey string     `bson:"routing_key"`
+	Payload    string     `bson:"payload"`
+	Status     Status     `bson:"status"`
+	Attempts   int        `bson:"attempts"`
+	LastError  string     `bson:"last_error,omitempty"`
+	CreatedAt  time.Time  `bson:"created_at"`
+	SentAt     *time.Time `bson:"sent_at,omitempty"`
+}
+
+// NewMessage creates a pending outbox message from an event and the routing key it must be published with.
+//
+// Parameters:
+//   - event: The event whose payload will be published.
+//   - routingKey: The routing key to publish the event with.
+//
+// Returns:
+//   - A pointer to the newly created Message.
+//   - An error if the payload cannot be serialized or the ID cannot be generated.
+func NewMessage(event events.EventInterface, routingKey string) (*Message, error) {
+	payload, err := json.Marshal(event.GetPayload())
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal payload of event %s: %w", event.GetName(), err)
+	}
+
+	createdAt := event.GetDateTime().UTC()
+	id, err := gouuid.GenerateUUIDFromMap(map[string]interface{}{
+		"event_name":  event.GetName(),
+		"routing_key": routingKey,
+		"payload":     string(payload),
+		"created_at":  createdAt.Format(time.RFC3339Nano),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate outbox message ID: %w", err)
+	}
+
+	return &Message{
+		ID:         id,
+		EventName:  event.GetName(),
+		RoutingKey: routingKey,
+		Payload:    string(payload),
+		Status:     StatusPending,
+		CreatedAt:  createdAt,
+	}, nil
+}
@@ -0,0 +1,51 @@
+package outbox
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockStore is a mock implementation of StoreInterface for testing purposes.
+type MockStore struct {
+	mock.Mock
+}
+
+// InsertOne is the mock implementation of the InsertOne method.
+func (m *MockStore) InsertOne(ctx context.Context, message *Message) error {
+	args := m.Called(ctx, message)
+	return args.Error(0)
+}
+
+// FindPending is the mock implementation of the FindPending method.
+func (m *MockStore) FindPending(ctx context.Context, limit int) ([]*Message, error) {
+	args := m.Called(ctx, limit)
+	result := args.Get(0)
+	if result == nil {
+		return nil, args.Error(1)
+	}
+	return result.([]*Message), args.Error(1)
+}
+
+// MarkSent is the mock implementation of the MarkSent method.
+func (m *MockStore) MarkSent(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+// MarkFailed is the mock implementation of the MarkFailed method.
+func (m *MockStore) MarkFailed(ctx context.Context, id string, cause error) error {
+	args := m.Called(ctx, id, cause)
+	return args.Error(0)
+}
+
+// MockPublisher is a mock implementation of PublisherInterface for testing purposes.
+type MockPublisher struct {
+	mock.Mock
+}
+
+// NotifyWithConfirm is the mock implementation of the NotifyWithConfirm method.
+func (m *MockPublisher) NotifyWithConfirm(ctx context.Context, message []byte, routingKey string) error {
+	args := m.Called(ctx, message, routingKey)
+	return args.Error(0)
+}
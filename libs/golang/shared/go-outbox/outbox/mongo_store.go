@@ -0,0 +1,117 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var (
+	outboxCollection = "outbox"
+)
+
+// MongoStore keeps outbox messages in the outbox collection of a MongoDB database.
+//
+// InsertOne accepts a mongo.SessionContext, so the message can be written in the same
+// transaction as the entity that produced it.
+type MongoStore struct {
+	collection *mongo.Collection
+}
+
+// NewMongoStore creates a new MongoStore for the outbox collection of the given database.
+//
+// Parameters:
+//   - client: The MongoDB client.
+//   - database: The name of the database.
+//
+// Returns:
+//   - A pointer to a MongoStore instance.
+func NewMongoStore(client *mongo.Client, database string) *MongoStore {
+	return &MongoStore{
+		collection: client.Database(database).Collection(outboxCollection),
+	}
+}
+
+// InsertOne stores a new outbox message.
+//
+// Parameters:
+//   - ctx: The context of the write, usually the session context of a transaction.
+//   - message: The message to store.
+//
+// Returns:
+//   - An error if the message cannot be inserted.
+func (s *MongoStore) InsertOne(ctx context.Context, message *Message) error {
+	if _, err := s.collection.InsertOne(ctx, message); err != nil {
+		return fmt.Errorf("failed to insert outbox message %s: %w", message.ID, err)
+	}
+	return nil
+}
+
+// FindPending retrieves the oldest messages that were not confirmed yet.
+//
+// Parameters:
+//   - ctx: The context of the query.
+//   - limit: The maximum number of messages to return.
+//
+// Returns:
+//   - A slice of pending messages ordered by creation time.
+//   - An error if the messages cannot be retrieved or decoded.
+func (s *MongoStore) FindPending(ctx context.Context, limit int) ([]*Message, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: 1}}).
+		SetLimit(int64(limit))
+	cursor, err := s.collection.Find(ctx, bson.M{"status": StatusPending}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pending outbox messages: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var messages []*Message
+	if err := cursor.All(ctx, &messages); err != nil {
+		return nil, fmt.Errorf("failed to decode pending outbox messages: %w", err)
+	}
+	return messages, nil
+}
+
+// MarkSent flags a message as confirmed by the broker.
+//
+// Parameters:
+//   - ctx: The context of the update.
+//   - id: The ID of the message.
+//
+// Returns:
+//   - An error if the message cannot be updated.
+func (s *MongoStore) MarkSent(ctx context.Context, id string) error {
+	update := bson.M{
+		"$set": bson.M{"status": StatusSent, "sent_at": time.Now().UTC()},
+		"$inc": bson.M{"attempts": 1},
+	}
+	if _, err := s.collection.UpdateByID(ctx, id, update); err != nil {
+		return fmt.Errorf("failed to mark outbox message %s as sent: %w", id, err)
+	}
+	return nil
+}
+
+// MarkFailed records a failed publish attempt, keeping the message pending.
+//
+// Parameters:
+//   - ctx: The context of the update.
+//   - id: The ID of the message.
+//   - cause: The error returned by the publisher.
+//
+// Returns:
+//   - An error if the message cannot be updated.
+func (s *MongoStore) MarkFailed(ctx context.Context, id string, cause error) error {
+	update := bson.M{
+		"$set": bson.M{"last_error": cause.Error()},
+		"$inc": bson.M{"attempts": 1},
+	}
+	if _, err := s.collection.UpdateByID(ctx, id, update); err != nil {
+		return fmt.Errorf("failed to record failed attempt for outbox message %s: %w", id, err)
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+)
+
+var (
+	// pollInterval is how often the relay looks for pending messages.
+	pollInterval = 500 * time.Millisecond
+
+	// batchSize is the maximum number of messages published per poll.
+	batchSize = 100
+
+	// publishTimeout bounds how long the relay waits for a single publisher confirm.
+	publishTimeout = 10 * time.Second
+)
+
+// Relay publishes pending outbox messages and marks them as sent once the broker confirms them.
+//
+// Delivery is at-least-once: a message confirmed by the broker but not marked as sent
+// (for example because the process crashed in between) is published again.
+type Relay struct {
+	log       *log.Logger
+	store     StoreInterface
+	publisher PublisherInterface
+	wakeup    chan struct{}
+}
+
+// NewRelay creates a new Relay.
+//
+// Parameters:
+//   - store: The store holding the outbox messages.
+//   - publisher: The publisher used to send the messages with publisher confirms.
+//
+// Returns:
+//   - A pointer to a Relay instance.
+func NewRelay(store StoreInterface, publisher PublisherInterface) *Relay {
+	return &Relay{
+		log:       log.New(log.Writer(), "[OUTBOX-RELAY] ", log.LstdFlags),
+		store:     store,
+		publisher: publisher,
+		wakeup:    make(chan struct{}, 1),
+	}
+}
+
+// Start polls the store and publishes pending messages until the context is cancelled.
+//
+// Parameters:
+//   - ctx: The context controlling the lifetime of the relay.
+func (r *Relay) Start(ctx context.Context) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := r.Flush(ctx); err != nil {
+			r.log.Printf("Failed to relay outbox messages: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-r.wakeup:
+		}
+	}
+}
+
+// Trigger wakes up the relay so newly stored messages are published without waiting for the next poll.
+func (r *Relay) Trigger() {
+	select {
+	case r.wakeup <- struct{}{}:
+	default:
+	}
+}
+
+// Flush publishes one batch of pending messages in creation order. It stops at the first
+// message that cannot be published so the order of the events is preserved.
+//
+// Parameters:
+//   - ctx: The context of the flush.
+//
+// Returns:
+//   - The number of messages published and marked as sent.
+//   - An error if the store cannot be read or a message cannot be published or updated.
+func (r *Relay) Flush(ctx context.Context) (int, error) {
+	messages, err := r.store.FindPending(ctx, batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, message := range messages {
+		if err := r.publish(ctx, message); err != nil {
+			if markErr := r.store.MarkFailed(ctx, message.ID, err); markErr != nil {
+				r.log.Printf("%v", markErr)
+			}
+			return sent, fmt.Errorf("failed to publish outbox message %s: %w", message.ID, err)
+		}
+		if err := r.store.MarkSent(ctx, message.ID); err != nil {
+			return sent, err
+		}
+		sent++
+	}
+	if sent > 0 {
+		r.log.Printf("Relayed %d outbox message(s)", sent)
+	}
+	return sent, nil
+}
+
+// publish sends a single message and waits for its confirmation.
+//
+// Parameters:
+//   - ctx: The parent context of the publish.
+//   - message: The message to publish.
+//
+// Returns:
+//   - An error if the message was not confirmed by the broker.
+func (r *Relay) publish(ctx context.Context, message *Message) error {
+	ctx, cancel := context.WithTimeout(ctx, publishTimeout)
+	defer cancel()
+	return r.publisher.NotifyWithConfirm(ctx, []byte(message.Payload), message.RoutingKey)
+}
@@ -0,0 +1,102 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type testEvent struct {
+	payload interface{}
+}
+
+func (e *testEvent) GetName() string                { return "TestEvent" }
+func (e *testEvent) GetDateTime() time.Time         { return time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC) }
+func (e *testEvent) GetPayload() interface{}        { return e.payload }
+func (e *testEvent) SetPayload(payload interface{}) { e.payload = payload }
+
+type RelaySuite struct {
+	suite.Suite
+	store     *MockStore
+	publisher *MockPublisher
+	relay     *Relay
+}
+
+func TestRelaySuite(t *testing.T) {
+	suite.Run(t, new(RelaySuite))
+}
+
+func (suite *RelaySuite) SetupTest() {
+	suite.store = new(MockStore)
+	suite.publisher = new(MockPublisher)
+	suite.relay = NewRelay(suite.store, suite.publisher)
+}
+
+func (suite *RelaySuite) TestNewMessage() {
+	event := &testEvent{payload: map[string]string{"key": "value"}}
+
+	message, err := NewMessage(event, "input.created.p.s.src")
+
+	assert.NoError(suite.T(), err)
+	assert.NotEmpty(suite.T(), message.ID)
+	assert.Equal(suite.T(), "TestEvent", message.EventName)
+	assert.Equal(suite.T(), "input.created.p.s.src", message.RoutingKey)
+	assert.JSONEq(suite.T(), `{"key":"value"}`, message.Payload)
+	assert.Equal(suite.T(), StatusPending, message.Status)
+
+	again, err := NewMessage(event, "input.created.p.s.src")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), message.ID, again.ID)
+}
+
+func (suite *RelaySuite) TestFlushPublishesAndMarksSent() {
+	messages := []*Message{
+		{ID: "1", RoutingKey: "key.1", Payload: `{"n":1}`},
+		{ID: "2", RoutingKey: "key.2", Payload: `{"n":2}`},
+	}
+	suite.store.On("FindPending", mock.Anything, batchSize).Return(messages, nil)
+	suite.publisher.On("NotifyWithConfirm", mock.Anything, []byte(`{"n":1}`), "key.1").Return(nil)
+	suite.publisher.On("NotifyWithConfirm", mock.Anything, []byte(`{"n":2}`), "key.2").Return(nil)
+	suite.store.On("MarkSent", mock.Anything, "1").Return(nil)
+	suite.store.On("MarkSent", mock.Anything, "2").Return(nil)
+
+	sent, err := suite.relay.Flush(context.Background())
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 2, sent)
+	suite.store.AssertExpectations(suite.T())
+	suite.publisher.AssertExpectations(suite.T())
+}
+
+func (suite *RelaySuite) TestFlushStopsAtFirstFailure() {
+	messages := []*Message{
+		{ID: "1", RoutingKey: "key.1", Payload: `{"n":1}`},
+		{ID: "2", RoutingKey: "key.2", Payload: `{"n":2}`},
+	}
+	publishErr := fmt.Errorf("nacked")
+	suite.store.On("FindPending", mock.Anything, batchSize).Return(messages, nil)
+	suite.publisher.On("NotifyWithConfirm", mock.Anything, []byte(`{"n":1}`), "key.1").Return(publishErr)
+	suite.store.On("MarkFailed", mock.Anything, "1", publishErr).Return(nil)
+
+	sent, err := suite.relay.Flush(context.Background())
+
+	assert.ErrorIs(suite.T(), err, publishErr)
+	assert.Equal(suite.T(), 0, sent)
+	suite.store.AssertNotCalled(suite.T(), "MarkSent", mock.Anything, mock.Anything)
+	suite.publisher.AssertNotCalled(suite.T(), "NotifyWithConfirm", mock.Anything, []byte(`{"n":2}`), "key.2")
+}
+
+func (suite *RelaySuite) TestFlushWhenStoreFails() {
+	suite.store.On("FindPending", mock.Anything, batchSize).Return(nil, fmt.Errorf("store unavailable"))
+
+	sent, err := suite.relay.Flush(context.Background())
+
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), 0, sent)
+	suite.publisher.AssertNotCalled(suite.T(), "NotifyWithConfirm", mock.Anything, mock.Anything, mock.Anything)
+}
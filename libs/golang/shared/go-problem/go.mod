@@ -0,0 +1,3 @@
+module libs/golang/shared/go-problem
+
+go 1.22
@@ -0,0 +1,105 @@
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+)
+
+var (
+	maxBodySize int64 = 1 << 20 // Largest number of bytes of a response body read by Decode
+)
+
+// Mapping maps the errors matching Err, as reported by errors.Is, to a kind of problem.
+type Mapping struct {
+	Err     error
+	Problem *Problem
+}
+
+// Mapper maps the errors of a domain to problems. The first mapping matching an error is used, and the errors
+// matching no mapping are internal errors.
+type Mapper []Mapping
+
+// Problem returns the problem of an error, detailed with the error message. An error that already is a *Problem is
+// returned as it is.
+//
+// Parameters:
+//   - err: The error to map.
+//
+// Returns:
+//   - *Problem: The problem of the error, ErrInternal when no mapping matches it.
+func (m Mapper) Problem(err error) *Problem {
+	for _, mapping := range m {
+		if errors.Is(err, mapping.Err) {
+			return mapping.Problem.WithDetail(err.Error())
+		}
+	}
+	var p *Problem
+	if errors.As(err, &p) {
+		return p
+	}
+	return ErrInternal.WithDetail(err.Error())
+}
+
+// Write writes the problem of an error as the response of a request. Internal errors are logged, since they are
+// not caused by the request.
+//
+// Parameters:
+//   - w: The HTTP response writer.
+//   - r: The HTTP request that failed.
+//   - err: The error to write.
+func (m Mapper) Write(w http.ResponseWriter, r *http.Request, err error) {
+	p := m.Problem(err)
+	if p.Status >= http.StatusInternalServerError {
+		log.Printf("%s %s failed: %v", r.Method, r.URL.Path, err)
+	}
+	Write(w, r, p)
+}
+
+// Write writes a problem as the response of a request, with the problem+json media type and the status of the
+// problem. The path of the request is set as the instance of the problem.
+//
+// Parameters:
+//   - w: The HTTP response writer.
+//   - r: The HTTP request that failed.
+//   - p: The problem to write.
+//
+// Example:
+//
+//	problem.Write(w, r, problem.ErrInvalidRequest.WithDetail("ID is required"))
+func Write(w http.ResponseWriter, r *http.Request, p *Problem) {
+	occurrence := *p
+	occurrence.Instance = r.URL.Path
+	w.Header().Set("Content-Type", ContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(occurrence.Status)
+	if err := json.NewEncoder(w).Encode(occurrence); err != nil {
+		log.Printf("Failed to write problem %s: %v", occurrence.Code, err)
+	}
+}
+
+// Decode reads the problem of a failed response. A response without problem details, such as a plain text error,
+// is read as a problem without code, of the status of the response, detailed with the body.
+//
+// Parameters:
+//   - resp: The failed HTTP response, whose body is read but not closed.
+//
+// Returns:
+//   - *Problem: The problem of the response.
+func Decode(resp *http.Response) *Problem {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+
+	var p Problem
+	if strings.HasPrefix(resp.Header.Get("Content-Type"), ContentType) && json.Unmarshal(body, &p) == nil && p.Status != 0 {
+		return &p
+	}
+	return &Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(resp.StatusCode),
+		Status: resp.StatusCode,
+		Detail: strings.TrimSpace(string(body)),
+	}
+}
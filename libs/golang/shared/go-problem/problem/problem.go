@@ -0,0 +1,146 @@
+package problem
+
+import (
+	"fmt"
+	"net/http"
+)
+
+var (
+	ContentType = "application/problem+json" // Media type of the problem details responses
+	typeBaseURI = "urn:problem:"             // Prefix of the type URIs, followed by the code of the problem
+)
+
+// Stable codes of the problems, written in the `code` member of the responses. A code never changes meaning, so
+// clients can rely on it where the title and detail are meant for humans.
+const (
+	CodeInvalidRequest       = "invalid_request"        // The request body, path or query cannot be read.
+	CodeInvalidCursor        = "invalid_cursor"         // The page cursor is malformed or was issued for another sort.
+	CodeNotFound             = "not_found"              // The resource does not exist.
+	CodeAlreadyExists        = "already_exists"         // A resource with the same ID already exists.
+	CodeInvalidEntity        = "invalid_entity"         // The resource breaks a rule of the domain.
+	CodeValidationFailed     = "validation_failed"      // Fields of the request body are missing or invalid, listed in `errors`.
+	CodeIdempotencyKeyReused = "idempotency_key_reused" // The idempotency key was already used for another request.
+	CodeInternal             = "internal_error"         // The server failed to handle the request.
+)
+
+var (
+	// ErrInvalidRequest is the problem of a request whose body, path or query cannot be read.
+	ErrInvalidRequest = New(http.StatusBadRequest, CodeInvalidRequest)
+
+	// ErrInvalidCursor is the problem of a request whose page cursor cannot be read.
+	ErrInvalidCursor = New(http.StatusBadRequest, CodeInvalidCursor)
+
+	// ErrNotFound is the problem of a request on a resource that does not exist.
+	ErrNotFound = New(http.StatusNotFound, CodeNotFound)
+
+	// ErrAlreadyExists is the problem of a request creating a resource that already exists.
+	ErrAlreadyExists = New(http.StatusConflict, CodeAlreadyExists)
+
+	// ErrInvalidEntity is the problem of a request whose resource breaks a rule of the domain.
+	ErrInvalidEntity = New(http.StatusUnprocessableEntity, CodeInvalidEntity)
+
+	// ErrValidationFailed is the problem of a request whose body has missing or invalid fields.
+	ErrValidationFailed = New(http.StatusUnprocessableEntity, CodeValidationFailed)
+
+	// ErrIdempotencyKeyReused is the problem of a request reusing the idempotency key of another request.
+	ErrIdempotencyKeyReused = New(http.StatusUnprocessableEntity, CodeIdempotencyKeyReused)
+
+	// ErrInternal is the problem of a request the server failed to handle.
+	ErrInternal = New(http.StatusInternalServerError, CodeInternal)
+)
+
+// Problem is the problem details of a failed request, as defined by RFC 7807, extended with a stable code.
+// A Problem is an error, so the problems decoded from a response can be compared with errors.Is:
+//
+//	if errors.Is(err, problem.ErrNotFound) {
+//	    // the resource does not exist
+//	}
+type Problem struct {
+	Type     string       `json:"type"`               // URI identifying the kind of problem
+	Title    string       `json:"title"`              // Short summary of the kind of problem
+	Status   int          `json:"status"`             // HTTP status code of the response
+	Detail   string       `json:"detail,omitempty"`   // Explanation of this occurrence of the problem
+	Instance string       `json:"instance,omitempty"` // Path of the request the problem occurred on
+	Code     string       `json:"code,omitempty"`     // Stable code of the kind of problem
+	Errors   []FieldError `json:"errors,omitempty"`   // Invalid fields of the request, for the validation problems
+}
+
+// FieldError is an invalid field of a request body, named by its JSON path such as `depends_on[0].service`.
+type FieldError struct {
+	Field   string `json:"field"`   // JSON path of the field
+	Message string `json:"message"` // Rule the field breaks, such as "is required"
+}
+
+// New creates the problem of a kind, identified by its code.
+//
+// Parameters:
+//   - status: The HTTP status code of the problem.
+//   - code: The stable code of the kind of problem.
+//
+// Returns:
+//   - *Problem: The problem, titled with the text of the status.
+func New(status int, code string) *Problem {
+	return &Problem{
+		Type:   typeBaseURI + code,
+		Title:  http.StatusText(status),
+		Status: status,
+		Code:   code,
+	}
+}
+
+// WithDetail returns a copy of the problem explaining an occurrence of it.
+//
+// Parameters:
+//   - detail: The explanation of the occurrence.
+//
+// Returns:
+//   - *Problem: The copy of the problem with the detail.
+func (p *Problem) WithDetail(detail string) *Problem {
+	occurrence := *p
+	occurrence.Detail = detail
+	return &occurrence
+}
+
+// WithErrors returns a copy of the problem listing the invalid fields of an occurrence of it.
+//
+// Parameters:
+//   - fieldErrors: The invalid fields of the request.
+//
+// Returns:
+//   - *Problem: The copy of the problem with the invalid fields.
+func (p *Problem) WithErrors(fieldErrors []FieldError) *Problem {
+	occurrence := *p
+	occurrence.Errors = fieldErrors
+	return &occurrence
+}
+
+// Error describes the problem with its title, code and detail.
+func (p *Problem) Error() string {
+	message := p.Title
+	if p.Code != "" {
+		message = fmt.Sprintf("%s (%s)", message, p.Code)
+	}
+	if p.Detail != "" {
+		message = fmt.Sprintf("%s: %s", message, p.Detail)
+	}
+	return message
+}
+
+// Is reports whether the problem is of the kind of target. Problems of the same code are of the same kind, and a
+// problem without code, such as one decoded from a plain text response, is of the kind of the problems of its status.
+//
+// Parameters:
+//   - target: The error to compare the problem with.
+//
+// Returns:
+//   - bool: true if target is a *Problem of the same kind.
+func (p *Problem) Is(target error) bool {
+	t, ok := target.(*Problem)
+	if !ok {
+		return false
+	}
+	if p.Code != "" && t.Code != "" {
+		return p.Code == t.Code
+	}
+	return p.Status == t.Status
+}
@@ -0,0 +1,95 @@
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var errMissing = errors.New("thing not found")
+
+func TestProblemIs(t *testing.T) {
+	assert.ErrorIs(t, ErrNotFound.WithDetail("gone"), ErrNotFound)
+	assert.ErrorIs(t, fmt.Errorf("request failed: %w", ErrNotFound.WithDetail("gone")), ErrNotFound)
+	assert.NotErrorIs(t, ErrInvalidCursor, ErrInvalidRequest)
+	assert.ErrorIs(t, &Problem{Status: http.StatusNotFound}, ErrNotFound)
+	assert.NotErrorIs(t, &Problem{Status: http.StatusBadRequest}, ErrNotFound)
+}
+
+func TestProblemError(t *testing.T) {
+	assert.Equal(t, "Not Found (not_found): gone", ErrNotFound.WithDetail("gone").Error())
+	assert.Equal(t, "Bad Gateway", (&Problem{Title: "Bad Gateway"}).Error())
+}
+
+func TestMapperProblem(t *testing.T) {
+	mapper := Mapper{{Err: errMissing, Problem: ErrNotFound}}
+
+	assert.Equal(t, ErrNotFound.WithDetail("lookup: thing not found"), mapper.Problem(fmt.Errorf("lookup: %w", errMissing)))
+	assert.Equal(t, ErrInvalidRequest.WithDetail("bad"), mapper.Problem(ErrInvalidRequest.WithDetail("bad")))
+	assert.Equal(t, ErrInternal.WithDetail("boom"), mapper.Problem(errors.New("boom")))
+}
+
+func TestWrite(t *testing.T) {
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/thing/1", nil)
+
+	Mapper{{Err: errMissing, Problem: ErrNotFound}}.Write(rr, req, errMissing)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Equal(t, ContentType, rr.Header().Get("Content-Type"))
+	var p Problem
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &p))
+	assert.Equal(t, Problem{
+		Type:     "urn:problem:not_found",
+		Title:    "Not Found",
+		Status:   http.StatusNotFound,
+		Detail:   "thing not found",
+		Instance: "/thing/1",
+		Code:     CodeNotFound,
+	}, p)
+}
+
+func TestDecode(t *testing.T) {
+	rr := httptest.NewRecorder()
+	Write(rr, httptest.NewRequest(http.MethodGet, "/thing", nil), ErrInvalidCursor.WithDetail("bad cursor"))
+
+	p := Decode(rr.Result())
+
+	assert.ErrorIs(t, p, ErrInvalidCursor)
+	assert.Equal(t, "bad cursor", p.Detail)
+	assert.Equal(t, "/thing", p.Instance)
+}
+
+func TestDecodeWithErrors(t *testing.T) {
+	fieldErrors := []FieldError{{Field: "service", Message: "is required"}, {Field: "depends_on[0].source", Message: "is required"}}
+	rr := httptest.NewRecorder()
+	Write(rr, httptest.NewRequest(http.MethodPost, "/thing", nil), ErrValidationFailed.WithDetail("2 invalid fields").WithErrors(fieldErrors))
+
+	p := Decode(rr.Result())
+
+	assert.Equal(t, http.StatusUnprocessableEntity, p.Status)
+	assert.ErrorIs(t, p, ErrValidationFailed)
+	assert.NotErrorIs(t, p, ErrInvalidEntity)
+	assert.Equal(t, fieldErrors, p.Errors)
+}
+
+func TestDecodePlainText(t *testing.T) {
+	resp := &http.Response{
+		StatusCode: http.StatusNotFound,
+		Header:     http.Header{"Content-Type": {"text/plain"}},
+		Body:       io.NopCloser(strings.NewReader("404 page not found\n")),
+	}
+
+	p := Decode(resp)
+
+	assert.ErrorIs(t, p, ErrNotFound)
+	assert.Equal(t, "404 page not found", p.Detail)
+	assert.Empty(t, p.Code)
+}
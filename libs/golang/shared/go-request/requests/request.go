@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"libs/golang/shared/go-problem/problem"
 	"log"
 	"net/http"
 	"net/url"
@@ -273,7 +274,8 @@ func CreateRequest(
 
 // SendRequest sends the given HTTP request using the provided client.
 // It waits for the response or times out after the specified duration. The response body is decoded into the result parameter.
-// Returns an error if the request fails, times out, or the response status is not 2xx.
+// Returns an error if the request fails, times out, or the response status is not 2xx. The error of a response whose
+// status is not 2xx wraps the *problem.Problem decoded from it, so it can be compared with errors.Is and errors.As.
 //
 // Parameters:
 //   - ctx: The context for the request.
@@ -288,6 +290,9 @@ func CreateRequest(
 // Example:
 //
 //	err := SendRequest(context.Background(), req, client, result, time.Second)
+//	if errors.Is(err, problem.ErrNotFound) {
+//	    // the resource does not exist
+//	}
 func SendRequest(
 	ctx context.Context,
 	req *http.Request,
@@ -320,7 +325,7 @@ func SendRequest(
 		defer res.resp.Body.Close()
 
 		if res.resp.StatusCode < http.StatusOK || res.resp.StatusCode >= http.StatusMultipleChoices {
-			return fmt.Errorf("HTTP request failed: %s", res.resp.Status)
+			return fmt.Errorf("HTTP request failed: %w", problem.Decode(res.resp))
 		}
 
 		if result != nil {
@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"encoding/xml"
+	"errors"
+	"libs/golang/shared/go-problem/problem"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -242,6 +244,27 @@ func (suite *RequestTestSuite) TestSendRequest_HttpError() {
 	var result MockResponse
 	err = SendRequest(ctx, req, server.Client(), &result, 200*time.Millisecond)
 	assert.NotNil(suite.T(), err)
+	assert.ErrorIs(suite.T(), err, problem.ErrInternal)
+}
+
+func (suite *RequestTestSuite) TestSendRequest_ProblemDetails() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		problem.Write(w, r, problem.ErrNotFound.WithDetail("input not found: ID 1"))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	req, err := CreateRequest(ctx, server.URL, []string{"input", "1"}, nil, nil, map[string]string{"Content-Type": "application/json"}, http.MethodGet)
+	assert.Nil(suite.T(), err)
+
+	var result MockResponse
+	err = SendRequest(ctx, req, server.Client(), &result, 200*time.Millisecond)
+	assert.ErrorIs(suite.T(), err, problem.ErrNotFound)
+
+	var problemDetails *problem.Problem
+	assert.True(suite.T(), errors.As(err, &problemDetails))
+	assert.Equal(suite.T(), "input not found: ID 1", problemDetails.Detail)
+	assert.Equal(suite.T(), "/input/1", problemDetails.Instance)
 }
 
 func (suite *RequestTestSuite) TestSendRequest_FailedToDecode() {
@@ -0,0 +1,3 @@
+module libs/golang/shared/go-validator
+
+go 1.22
@@ -0,0 +1,53 @@
+package validator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"libs/golang/shared/go-problem/problem"
+	"net/http"
+)
+
+var (
+	MaxBodySize int64 = 4 << 20 // Largest request body, in bytes, read by Decode
+)
+
+// Decode reads the JSON body of a request into dst and validates it, so the handlers only pass valid DTOs to the
+// use cases. Every invalid field is reported at once.
+//
+// Parameters:
+//   - w: The HTTP response writer, used to close the connection when the body is too large.
+//   - r: The HTTP request whose body is read.
+//   - dst: A pointer to the DTO to read the body into.
+//
+// Returns:
+//   - error: problem.ErrInvalidRequest if the body is not JSON or is larger than MaxBodySize, problem.ErrValidationFailed
+//     listing the invalid fields if it breaks the rules of the DTO, or an error if the DTO has an unknown rule.
+//
+// Example:
+//
+//	var dto inputdto.ConfigDTO
+//	if err := validator.Decode(w, r, &dto); err != nil {
+//	    problems.Write(w, r, err)
+//	    return
+//	}
+func Decode(w http.ResponseWriter, r *http.Request, dst interface{}) error {
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, MaxBodySize)).Decode(dst); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return problem.ErrInvalidRequest.WithDetail(fmt.Sprintf("request body is larger than %d bytes", maxBytesErr.Limit))
+		}
+		return problem.ErrInvalidRequest.WithDetail(err.Error())
+	}
+
+	err := Validate(dst)
+	var fieldErrors Errors
+	if !errors.As(err, &fieldErrors) {
+		return err
+	}
+	problemErrors := make([]problem.FieldError, len(fieldErrors))
+	for i, fieldError := range fieldErrors {
+		problemErrors[i] = problem.FieldError{Field: fieldError.Field, Message: fieldError.Message}
+	}
+	return problem.ErrValidationFailed.WithDetail(fieldErrors.Error()).WithErrors(problemErrors)
+}
@@ -0,0 +1,52 @@
+package validator
+
+import (
+	"libs/golang/shared/go-problem/problem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func decode(body string) (config, error) {
+	var dto config
+	req := httptest.NewRequest(http.MethodPost, "/config", strings.NewReader(body))
+	err := Decode(httptest.NewRecorder(), req, &dto)
+	return dto, err
+}
+
+func TestDecode(t *testing.T) {
+	dto, err := decode(`{"service": "billing", "data": {"key": 1}}`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, config{Service: "billing", Data: map[string]interface{}{"key": float64(1)}}, dto)
+}
+
+func TestDecodeWhenInvalid(t *testing.T) {
+	_, err := decode(`{"depends_on": [{"service": "ledger"}]}`)
+
+	assert.Equal(t, problem.ErrValidationFailed.
+		WithDetail("service is required; depends_on[0].source is required; data is required").
+		WithErrors([]problem.FieldError{
+			{Field: "service", Message: "is required"},
+			{Field: "depends_on[0].source", Message: "is required"},
+			{Field: "data", Message: "is required"},
+		}), err)
+}
+
+func TestDecodeWhenNotJSON(t *testing.T) {
+	_, err := decode(`{"service": `)
+
+	assert.ErrorIs(t, err, problem.ErrInvalidRequest)
+}
+
+func TestDecodeWhenTooLarge(t *testing.T) {
+	defer func(size int64) { MaxBodySize = size }(MaxBodySize)
+	MaxBodySize = 16
+
+	_, err := decode(`{"service": "billing", "data": {}}`)
+
+	assert.Equal(t, problem.ErrInvalidRequest.WithDetail("request body is larger than 16 bytes"), err)
+}
@@ -0,0 +1,214 @@
+package validator
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var (
+	TagName           = "validate"                             // Struct tag holding the rules of a field
+	IdentifierPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`) // Characters allowed in the service, source and provider identifiers
+)
+
+// FieldError is a field breaking a rule, named by its JSON path such as `depends_on[0].service`.
+type FieldError struct {
+	Field   string // JSON path of the field
+	Message string // Rule the field breaks, such as "is required"
+}
+
+// Error describes the field and the rule it breaks.
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s %s", e.Field, e.Message)
+}
+
+// Errors lists every field of a value breaking a rule, in the order of the fields.
+type Errors []FieldError
+
+// Error describes the fields and the rules they break.
+func (e Errors) Error() string {
+	messages := make([]string, len(e))
+	for i, fieldError := range e {
+		messages[i] = fieldError.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Validate checks the fields of a struct against the rules of their `validate` tags, and the fields of the nested
+// structs, slices of structs and pointers to structs. The rules of a tag are separated by commas:
+//
+//   - required: the field is not empty. A slice or map must be present, but may have no items.
+//   - identifier: the string only has letters, digits, '_' and '-', so it can be part of an ID or routing key.
+//   - max=N: the string has at most N characters, or the slice or map at most N items.
+//   - maxbytes=N: the field is at most N bytes long once encoded as JSON.
+//   - oneof=A B C: the string is empty or one of the values separated by spaces.
+//
+// Fields are named by their JSON names, and the rules other than required are skipped on empty fields.
+//
+// Parameters:
+//   - v: The struct, or pointer to a struct, to validate.
+//
+// Returns:
+//   - error: Errors listing every invalid field, an error if a tag has an unknown rule, or nil if the value is valid.
+//
+// Example:
+//
+//	type ConfigDTO struct {
+//	    Service string `json:"service" validate:"required,identifier,max=64"`
+//	}
+//
+//	var fieldErrors validator.Errors
+//	if err := validator.Validate(dto); errors.As(err, &fieldErrors) {
+//	    // fieldErrors[0].Field == "service"
+//	}
+func Validate(v interface{}) error {
+	var fieldErrors Errors
+	if err := validateValue(reflect.ValueOf(v), "", &fieldErrors); err != nil {
+		return err
+	}
+	if len(fieldErrors) > 0 {
+		return fieldErrors
+	}
+	return nil
+}
+
+// validateValue validates the fields of a struct, the items of a slice or the value of a pointer, named after path.
+func validateValue(value reflect.Value, path string, fieldErrors *Errors) error {
+	switch value.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if value.IsNil() {
+			return nil
+		}
+		return validateValue(value.Elem(), path, fieldErrors)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			if err := validateValue(value.Index(i), fmt.Sprintf("%s[%d]", path, i), fieldErrors); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Struct:
+		return validateStruct(value, path, fieldErrors)
+	default:
+		return nil
+	}
+}
+
+// validateStruct validates the exported fields of a struct against their rules, then the values nested in them.
+func validateStruct(value reflect.Value, path string, fieldErrors *Errors) error {
+	structType := value.Type()
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := jsonName(field)
+		if name == "-" {
+			continue
+		}
+		if path != "" {
+			name = path + "." + name
+		}
+
+		message, err := checkRules(value.Field(i), field.Tag.Get(TagName))
+		if err != nil {
+			return fmt.Errorf("field %s: %w", name, err)
+		}
+		if message != "" {
+			*fieldErrors = append(*fieldErrors, FieldError{Field: name, Message: message})
+			continue
+		}
+		if err := validateValue(value.Field(i), name, fieldErrors); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// jsonName returns the name of a field in JSON, its Go name when it has no json tag.
+func jsonName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
+
+// checkRules checks a field against the rules of its tag, and returns the message of the first rule it breaks.
+func checkRules(value reflect.Value, tag string) (string, error) {
+	if tag == "" {
+		return "", nil
+	}
+	for _, rule := range strings.Split(tag, ",") {
+		name, param, _ := strings.Cut(rule, "=")
+		if name != "required" && value.IsZero() {
+			continue
+		}
+		message, err := checkRule(value, name, param)
+		if err != nil || message != "" {
+			return message, err
+		}
+	}
+	return "", nil
+}
+
+// checkRule checks a field against a rule, and returns the message of the rule when the field breaks it.
+func checkRule(value reflect.Value, name, param string) (string, error) {
+	switch name {
+	case "required":
+		if value.IsZero() {
+			return "is required", nil
+		}
+	case "identifier":
+		if value.Kind() != reflect.String {
+			return "", fmt.Errorf("rule identifier applies to strings, not %s", value.Kind())
+		}
+		if !IdentifierPattern.MatchString(value.String()) {
+			return "must only contain letters, digits, '_' and '-'", nil
+		}
+	case "max":
+		limit, err := strconv.Atoi(param)
+		if err != nil {
+			return "", fmt.Errorf("invalid rule max=%s: %w", param, err)
+		}
+		switch value.Kind() {
+		case reflect.String:
+			if len([]rune(value.String())) > limit {
+				return fmt.Sprintf("must be at most %d characters long", limit), nil
+			}
+		case reflect.Slice, reflect.Array, reflect.Map:
+			if value.Len() > limit {
+				return fmt.Sprintf("must have at most %d items", limit), nil
+			}
+		default:
+			return "", fmt.Errorf("rule max applies to strings, slices and maps, not %s", value.Kind())
+		}
+	case "maxbytes":
+		limit, err := strconv.Atoi(param)
+		if err != nil {
+			return "", fmt.Errorf("invalid rule maxbytes=%s: %w", param, err)
+		}
+		encoded, err := json.Marshal(value.Interface())
+		if err != nil {
+			return "must be encodable as JSON", nil
+		}
+		if len(encoded) > limit {
+			return fmt.Sprintf("must be at most %d bytes long", limit), nil
+		}
+	case "oneof":
+		if value.Kind() != reflect.String {
+			return "", fmt.Errorf("rule oneof applies to strings, not %s", value.Kind())
+		}
+		allowed := strings.Fields(param)
+		if !slices.Contains(allowed, value.String()) {
+			return fmt.Sprintf("must be one of %s", strings.Join(allowed, ", ")), nil
+		}
+	default:
+		return "", fmt.Errorf("unknown rule %q", name)
+	}
+	return "", nil
+}
@@ -0,0 +1,85 @@
+package validator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type dependency struct {
+	Service string `json:"service" validate:"required,identifier"`
+	Source  string `json:"source" validate:"required,identifier"`
+}
+
+type config struct {
+	Service   string                 `json:"service" validate:"required,identifier,max=8"`
+	Kind      string                 `json:"kind" validate:"oneof=input output"`
+	DependsOn []dependency           `json:"depends_on" validate:"max=2"`
+	Data      map[string]interface{} `json:"data" validate:"required,maxbytes=16"`
+	Parent    *dependency            `json:"parent"`
+	internal  string
+}
+
+func TestValidateWhenValid(t *testing.T) {
+	value := config{
+		Service:   "billing",
+		Kind:      "input",
+		DependsOn: []dependency{{Service: "ledger", Source: "s3"}},
+		Data:      map[string]interface{}{},
+	}
+
+	assert.NoError(t, Validate(value))
+	assert.NoError(t, Validate(&value))
+}
+
+func TestValidateListsEveryInvalidField(t *testing.T) {
+	err := Validate(config{
+		Service:   "bill.ing",
+		Kind:      "other",
+		DependsOn: []dependency{{Service: "ledger"}, {Source: "s3"}},
+		Data:      map[string]interface{}{"key": "a long value"},
+		Parent:    &dependency{Service: "a b", Source: "s3"},
+	})
+
+	assert.Equal(t, Errors{
+		{Field: "service", Message: "must only contain letters, digits, '_' and '-'"},
+		{Field: "kind", Message: "must be one of input, output"},
+		{Field: "depends_on[0].source", Message: "is required"},
+		{Field: "depends_on[1].service", Message: "is required"},
+		{Field: "data", Message: "must be at most 16 bytes long"},
+		{Field: "parent.service", Message: "must only contain letters, digits, '_' and '-'"},
+	}, err)
+}
+
+func TestValidateRequired(t *testing.T) {
+	err := Validate(config{})
+
+	assert.Equal(t, Errors{
+		{Field: "service", Message: "is required"},
+		{Field: "data", Message: "is required"},
+	}, err)
+	assert.Equal(t, "service is required; data is required", err.Error())
+}
+
+func TestValidateMax(t *testing.T) {
+	err := Validate(config{
+		Service:   "accounting",
+		DependsOn: []dependency{{"a", "b"}, {"c", "d"}, {"e", "f"}},
+		Data:      map[string]interface{}{},
+	})
+
+	assert.Equal(t, Errors{
+		{Field: "service", Message: "must be at most 8 characters long"},
+		{Field: "depends_on", Message: "must have at most 2 items"},
+	}, err)
+}
+
+func TestValidateWhenRuleIsUnknown(t *testing.T) {
+	err := Validate(struct {
+		Name string `json:"name" validate:"required,email"`
+	}{Name: "a"})
+
+	assert.EqualError(t, err, `field name: unknown rule "email"`)
+	assert.False(t, errors.As(err, new(Errors)))
+}
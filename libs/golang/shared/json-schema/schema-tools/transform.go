@@ -0,0 +1,232 @@
+package schematools
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalidFieldMapping is returned when a field mapping cannot be applied.
+	ErrInvalidFieldMapping = errors.New("invalid field mapping")
+
+	// ErrCastFailed is returned when a value cannot be cast to the type of its field mapping.
+	ErrCastFailed = errors.New("cannot cast value")
+
+	// castTypes are the JSON types a field mapping can cast its value to.
+	castTypes = map[string]bool{"": true, "string": true, "integer": true, "number": true, "boolean": true}
+)
+
+// FieldMapping describes how a field of the transformed data is produced from the source data.
+type FieldMapping struct {
+	From    string      `json:"from"`    // Dot-separated path of the field in the source data, To when empty
+	To      string      `json:"to"`      // Dot-separated path of the field in the transformed data
+	Type    string      `json:"type"`    // JSON type the value is cast to: string, integer, number or boolean. Kept as is when empty
+	Default interface{} `json:"default"` // Value used when the source field is missing or null
+}
+
+// ValidateFieldMappings checks that field mappings can be applied.
+//
+// Parameters:
+//   - mappings: The field mappings to check.
+//
+// Returns:
+//   - An error wrapping ErrInvalidFieldMapping if a mapping has no target, targets the same field as another
+//     mapping, or casts to an unsupported type.
+func ValidateFieldMappings(mappings []FieldMapping) error {
+	targets := make(map[string]bool, len(mappings))
+	for i, mapping := range mappings {
+		if mapping.To == "" {
+			return fmt.Errorf("%w: mapping %d has no target field", ErrInvalidFieldMapping, i)
+		}
+		if targets[mapping.To] {
+			return fmt.Errorf("%w: field %q is mapped more than once", ErrInvalidFieldMapping, mapping.To)
+		}
+		targets[mapping.To] = true
+		if !castTypes[mapping.Type] {
+			return fmt.Errorf("%w: unsupported type %q for field %q", ErrInvalidFieldMapping, mapping.Type, mapping.To)
+		}
+	}
+	return nil
+}
+
+// Transform reshapes data into the shape of a JSON schema.
+//
+// With field mappings, the transformed data only holds the mapped fields: each one is read from its source
+// path, or takes its default when missing, and is cast to its type. Without mappings, the data is kept as is.
+// The top-level properties of the schema that are still missing then take the "default" of their definition.
+// The source data is not modified.
+//
+// Parameters:
+//   - data: The data to transform.
+//   - mappings: The field mappings, validated by ValidateFieldMappings.
+//   - jsonSchema: The JSON schema the data is reshaped to. Only the defaults of its properties are used.
+//
+// Returns:
+//   - The transformed data.
+//   - An error if the mappings are invalid or a value cannot be cast.
+//
+// Example:
+//
+//	mappings := []FieldMapping{
+//	    {From: "customer.id", To: "customer_id", Type: "integer"},
+//	    {To: "country", Default: "BR"},
+//	}
+//	out, err := Transform(map[string]interface{}{"customer": map[string]interface{}{"id": "42"}}, mappings, nil)
+//	// out: {"customer_id": 42, "country": "BR"}
+func Transform(data map[string]interface{}, mappings []FieldMapping, jsonSchema map[string]interface{}) (map[string]interface{}, error) {
+	if err := ValidateFieldMappings(mappings); err != nil {
+		return nil, err
+	}
+
+	transformed := make(map[string]interface{}, len(data))
+	if len(mappings) == 0 {
+		for key, value := range data {
+			transformed[key] = value
+		}
+	}
+
+	for _, mapping := range mappings {
+		from := mapping.From
+		if from == "" {
+			from = mapping.To
+		}
+		value, ok := getPath(data, from)
+		if !ok || value == nil {
+			if mapping.Default == nil {
+				continue
+			}
+			value = mapping.Default
+		}
+		value, err := CastValue(value, mapping.Type)
+		if err != nil {
+			return nil, fmt.Errorf("field %q: %w", mapping.To, err)
+		}
+		if err := setPath(transformed, mapping.To, value); err != nil {
+			return nil, err
+		}
+	}
+
+	properties, _ := jsonSchema["properties"].(map[string]interface{})
+	for name, definition := range properties {
+		if _, ok := transformed[name]; ok {
+			continue
+		}
+		if property, ok := definition.(map[string]interface{}); ok && property["default"] != nil {
+			transformed[name] = property["default"]
+		}
+	}
+	return transformed, nil
+}
+
+// CastValue casts a JSON value to a JSON type.
+//
+// Parameters:
+//   - value: The value to cast.
+//   - jsonType: The type to cast to: string, integer, number or boolean. The value is kept as is when empty.
+//
+// Returns:
+//   - The cast value: a string, an int64, a float64 or a bool.
+//   - An error wrapping ErrCastFailed if the value has no representation in the type.
+func CastValue(value interface{}, jsonType string) (interface{}, error) {
+	if jsonType == "" {
+		return value, nil
+	}
+	number, isNumber := toFloat(value)
+
+	switch jsonType {
+	case "string":
+		switch v := value.(type) {
+		case string:
+			return v, nil
+		case bool:
+			return strconv.FormatBool(v), nil
+		}
+		if isNumber {
+			return strconv.FormatFloat(number, 'f', -1, 64), nil
+		}
+	case "integer":
+		if s, ok := value.(string); ok {
+			if i, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64); err == nil {
+				return i, nil
+			}
+		}
+		if isNumber && number == math.Trunc(number) {
+			return int64(number), nil
+		}
+	case "number":
+		if s, ok := value.(string); ok {
+			if f, err := strconv.ParseFloat(strings.TrimSpace(s), 64); err == nil {
+				return f, nil
+			}
+		}
+		if isNumber {
+			return number, nil
+		}
+	case "boolean":
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case string:
+			if b, err := strconv.ParseBool(strings.TrimSpace(v)); err == nil {
+				return b, nil
+			}
+		}
+	default:
+		return nil, fmt.Errorf("%w: unsupported type %q", ErrInvalidFieldMapping, jsonType)
+	}
+	return nil, fmt.Errorf("%w %v (%T) to %s", ErrCastFailed, value, value, jsonType)
+}
+
+// toFloat converts a numeric value to a float64.
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	}
+	return 0, false
+}
+
+// getPath reads the value at a dot-separated path of nested maps.
+func getPath(data map[string]interface{}, path string) (interface{}, bool) {
+	keys := strings.Split(path, ".")
+	current := data
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := current[key].(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		current = next
+	}
+	value, ok := current[keys[len(keys)-1]]
+	return value, ok
+}
+
+// setPath writes a value at a dot-separated path of nested maps, creating the missing maps.
+func setPath(data map[string]interface{}, path string, value interface{}) error {
+	keys := strings.Split(path, ".")
+	current := data
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := current[key].(map[string]interface{})
+		if !ok {
+			if _, exists := current[key]; exists {
+				return fmt.Errorf("%w: field %q is not an object", ErrInvalidFieldMapping, key)
+			}
+			next = map[string]interface{}{}
+			current[key] = next
+		}
+		current = next
+	}
+	current[keys[len(keys)-1]] = value
+	return nil
+}
@@ -0,0 +1,98 @@
+package schematools
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransform(t *testing.T) {
+	data := map[string]interface{}{
+		"customer": map[string]interface{}{"id": "42", "name": "Ada"},
+		"amount":   "10.5",
+		"active":   "true",
+		"ignored":  "value",
+	}
+	mappings := []FieldMapping{
+		{From: "customer.id", To: "customer_id", Type: "integer"},
+		{From: "customer.name", To: "profile.name"},
+		{To: "amount", Type: "number"},
+		{From: "active", To: "enabled", Type: "boolean"},
+		{To: "country", Default: "BR"},
+		{To: "missing"},
+	}
+	jsonSchema := map[string]interface{}{
+		"properties": map[string]interface{}{
+			"currency": map[string]interface{}{"type": "string", "default": "BRL"},
+			"country":  map[string]interface{}{"type": "string", "default": "US"},
+		},
+	}
+
+	transformed, err := Transform(data, mappings, jsonSchema)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"customer_id": int64(42),
+		"profile":     map[string]interface{}{"name": "Ada"},
+		"amount":      10.5,
+		"enabled":     true,
+		"country":     "BR",
+		"currency":    "BRL",
+	}, transformed)
+	assert.Equal(t, "value", data["ignored"], "Source data should not be modified")
+}
+
+func TestTransformWithoutMappings(t *testing.T) {
+	data := map[string]interface{}{"name": "Ada"}
+	jsonSchema := map[string]interface{}{
+		"properties": map[string]interface{}{"country": map[string]interface{}{"default": "BR"}},
+	}
+
+	transformed, err := Transform(data, nil, jsonSchema)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"name": "Ada", "country": "BR"}, transformed)
+	assert.NotContains(t, data, "country")
+}
+
+func TestTransformErrors(t *testing.T) {
+	_, err := Transform(map[string]interface{}{"id": "abc"}, []FieldMapping{{To: "id", Type: "integer"}}, nil)
+	assert.ErrorIs(t, err, ErrCastFailed)
+
+	_, err = Transform(map[string]interface{}{"id": 1.0, "a": "b"}, []FieldMapping{{To: "id"}, {From: "a", To: "id.b"}}, nil)
+	assert.ErrorIs(t, err, ErrInvalidFieldMapping)
+}
+
+func TestValidateFieldMappings(t *testing.T) {
+	assert.NoError(t, ValidateFieldMappings([]FieldMapping{{From: "a", To: "b", Type: "string"}, {To: "c"}}))
+	assert.ErrorIs(t, ValidateFieldMappings([]FieldMapping{{From: "a"}}), ErrInvalidFieldMapping)
+	assert.ErrorIs(t, ValidateFieldMappings([]FieldMapping{{To: "a"}, {From: "b", To: "a"}}), ErrInvalidFieldMapping)
+	assert.ErrorIs(t, ValidateFieldMappings([]FieldMapping{{To: "a", Type: "date"}}), ErrInvalidFieldMapping)
+}
+
+func TestCastValue(t *testing.T) {
+	cases := []struct {
+		value    interface{}
+		jsonType string
+		expected interface{}
+	}{
+		{12.0, "string", "12"},
+		{true, "string", "true"},
+		{"7", "integer", int64(7)},
+		{3.0, "integer", int64(3)},
+		{int32(3), "number", 3.0},
+		{" 1.25 ", "number", 1.25},
+		{"false", "boolean", false},
+		{"kept", "", "kept"},
+	}
+	for _, c := range cases {
+		value, err := CastValue(c.value, c.jsonType)
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, value)
+	}
+
+	_, err := CastValue(3.5, "integer")
+	assert.ErrorIs(t, err, ErrCastFailed)
+	_, err = CastValue(1.0, "boolean")
+	assert.ErrorIs(t, err, ErrCastFailed)
+}
@@ -3,6 +3,7 @@ package schematools
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"strings"
 
 	"github.com/xeipuuv/gojsonschema"
@@ -10,6 +11,9 @@ import (
 
 const metaschemaURL = "http://json-schema.org/draft-07/schema#" // URL of the JSON Schema metaschema
 
+// ErrDataInvalid is returned when data does not match its JSON schema.
+var ErrDataInvalid = errors.New("data validation failed")
+
 // ValidateJSONSchema validates a JSON Schema to ensure it adheres to the JSON Schema Draft-07 specification.
 // It takes a map representation of the JSON Schema as input and returns an error if the schema is invalid.
 // If the schema is valid, it returns nil.
@@ -82,7 +86,8 @@ func ValidateJSONSchema(jsonSchema map[string]interface{}) error {
 // - jsonData: map[string]interface{}: The data to be validated.
 //
 // Returns:
-// - error: An error object if the data is invalid according to the JSON Schema, otherwise nil.
+// - error: An error wrapping ErrDataInvalid if the data is invalid according to the JSON Schema, another error if
+// the schema cannot be loaded, otherwise nil.
 func ValidateJSONData(jsonSchema map[string]interface{}, jsonData map[string]interface{}) error {
 	schemaLoader := gojsonschema.NewGoLoader(jsonSchema)
 	dataLoader := gojsonschema.NewGoLoader(jsonData)
@@ -98,7 +103,7 @@ func ValidateJSONData(jsonSchema map[string]interface{}, jsonData map[string]int
 		for i, err := range validationErrors {
 			errorMessages[i] = err.String()
 		}
-		return errors.New("data validation failed: " + strings.Join(errorMessages, ", "))
+		return fmt.Errorf("%w: %s", ErrDataInvalid, strings.Join(errorMessages, ", "))
 	}
 
 	return nil
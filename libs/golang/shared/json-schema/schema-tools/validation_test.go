@@ -75,6 +75,7 @@ func TestValidateJSONData(t *testing.T) {
 	err = ValidateJSONData(schema, invalidData)
 	assert.Error(t, err, "Invalid data should produce an error")
 	assert.Contains(t, err.Error(), "data validation failed", "Error message should contain validation failure info")
+	assert.ErrorIs(t, err, ErrDataInvalid)
 
 	err = ValidateJSONData(schema, missingRequiredData)
 	assert.Error(t, err, "Data missing required fields should produce an error")
@@ -62,8 +62,7 @@ class AsyncPyConfigVaultClient:
         Returns:
             List[ConfigDTO]: A list of all configurations in the form of data classes.
         """
-        configs_data = await self.client.make_request("GET", self.configs_endpoint)
-        return [serialize_to_dataclass(config, ConfigDTO) for config in configs_data]
+        return await self._list_pages(self.configs_endpoint)
 
     async def get_config_by_id(self, config_id: str) -> ConfigDTO:
         """
@@ -105,8 +104,7 @@ class AsyncPyConfigVaultClient:
             of data classes.
         """
         endpoint = f"{self.configs_endpoint}/provider/{provider}/service/{service}"
-        configs_data = await self.client.make_request("GET", endpoint)
-        return [serialize_to_dataclass(config, ConfigDTO) for config in configs_data]
+        return await self._list_pages(endpoint)
 
     async def list_configs_by_source_and_provider(self, provider: str, source: str) -> List[ConfigDTO]:
         """
@@ -121,8 +119,7 @@ class AsyncPyConfigVaultClient:
             of data classes.
         """
         endpoint = f"{self.configs_endpoint}/provider/{provider}/source/{source}"
-        configs_data = await self.client.make_request("GET", endpoint)
-        return [serialize_to_dataclass(config, ConfigDTO) for config in configs_data]
+        return await self._list_pages(endpoint)
 
     async def list_configs_by_service_provider_and_active(
         self,
@@ -143,8 +140,7 @@ class AsyncPyConfigVaultClient:
             form of data classes.
         """
         endpoint = f"{self.configs_endpoint}/provider/{provider}/service/{service}/active/{active}"
-        configs_data = await self.client.make_request("GET", endpoint)
-        return [serialize_to_dataclass(config, ConfigDTO) for config in configs_data]
+        return await self._list_pages(endpoint)
 
     async def list_configs_by_service_source_and_provider(
         self,
@@ -165,8 +161,7 @@ class AsyncPyConfigVaultClient:
             data classes.
         """
         endpoint = f"{self.configs_endpoint}/provider/{provider}/service/{service}/source/{source}"
-        configs_data = await self.client.make_request("GET", endpoint)
-        return [serialize_to_dataclass(config, ConfigDTO) for config in configs_data]
+        return await self._list_pages(endpoint)
 
     async def list_configs_by_provider_and_dependencies(
         self,
@@ -191,6 +186,27 @@ class AsyncPyConfigVaultClient:
         return [serialize_to_dataclass(config, ConfigDTO) for config in configs_data]
 
 
+    async def _list_pages(self, endpoint: str) -> List[ConfigDTO]:
+        """
+        Retrieve every page of a configuration listing, following the next cursor of each page.
+
+        Args:
+            endpoint (str): The endpoint of the listing.
+
+        Returns:
+            List[ConfigDTO]: The configurations of all the pages in the form of data classes.
+        """
+        configs = []
+        params = None
+        while True:
+            page = await self.client.make_request("GET", endpoint, params=params)
+            configs.extend(serialize_to_dataclass(config, ConfigDTO) for config in page["items"])
+            next_cursor = page.get("next_cursor")
+            if not next_cursor:
+                return configs
+            params = {"cursor": next_cursor}
+
+
 def async_py_config_vault_client() -> AsyncPyConfigVaultClient:
     """
     Create an instance of the AsyncPyConfigHandlerClient using service discovery information.
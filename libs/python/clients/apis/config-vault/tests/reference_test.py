@@ -40,3 +40,10 @@ def get_configs() -> Dict[str, Any]:
             dep_source="dep-source-2"
         )
     ]
+
+
+def get_config_page(configs, next_cursor=None) -> Dict[str, Any]:
+    page = {"items": configs}
+    if next_cursor:
+        page["next_cursor"] = next_cursor
+    return page
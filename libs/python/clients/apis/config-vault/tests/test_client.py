@@ -5,7 +5,7 @@ import httpx
 from cli_config_vault.client import async_py_config_vault_client, AsyncPyConfigVaultClient
 from dto_config_vault.output import ConfigDTO
 from typing import Dict
-from tests.reference_test import get_config, get_configs
+from tests.reference_test import get_config, get_configs, get_config_page
 
 
 class TestAsyncPyConfigHandlerClient(unittest.IsolatedAsyncioTestCase):
@@ -45,7 +45,7 @@ class TestAsyncPyConfigHandlerClient(unittest.IsolatedAsyncioTestCase):
 
     @respx.mock
     async def test_list_all_configs(self):
-        configs_data = get_configs()
+        configs_data = get_config_page(get_configs())
         endpoint = f"{self.client.client.base_url}{self.client.configs_endpoint}"
         respx.get(endpoint).mock(return_value=self.mock_response(configs_data))
 
@@ -53,6 +53,18 @@ class TestAsyncPyConfigHandlerClient(unittest.IsolatedAsyncioTestCase):
         self.assertIsInstance(result, list)
         self.assertTrue(all(isinstance(config, ConfigDTO) for config in result))
 
+    @respx.mock
+    async def test_list_all_configs_follows_next_cursor(self):
+        endpoint = f"{self.client.client.base_url}{self.client.configs_endpoint}"
+        route = respx.get(endpoint).mock(side_effect=[
+            self.mock_response(get_config_page([get_config(config_id="123")], next_cursor="cursor-1")),
+            self.mock_response(get_config_page([get_config(config_id="456")])),
+        ])
+
+        result = await self.client.list_all_configs()
+        self.assertEqual([config.config_id for config in result], ["123", "456"])
+        self.assertEqual(route.calls[1].request.url.params["cursor"], "cursor-1")
+
     @respx.mock
     async def test_get_config_by_id(self):
         config_id = "123"
@@ -76,7 +88,7 @@ class TestAsyncPyConfigHandlerClient(unittest.IsolatedAsyncioTestCase):
     async def test_list_configs_by_service_and_provider(self):
         provider = "provider"
         service = "test-service"
-        configs_data = get_configs()
+        configs_data = get_config_page(get_configs())
         endpoint = f"{self.client.client.base_url}{self.client.configs_endpoint}/provider/{provider}/service/{service}"
         respx.get(endpoint).mock(return_value=self.mock_response(configs_data))
 
@@ -88,7 +100,7 @@ class TestAsyncPyConfigHandlerClient(unittest.IsolatedAsyncioTestCase):
     async def test_list_configs_by_source_and_provider(self):
         provider = "provider"
         source = "test-source"
-        configs_data = get_configs()
+        configs_data = get_config_page(get_configs())
         endpoint = f"{self.client.client.base_url}{self.client.configs_endpoint}/provider/{provider}/source/{source}"
         respx.get(endpoint).mock(return_value=self.mock_response(configs_data))
 
@@ -101,7 +113,7 @@ class TestAsyncPyConfigHandlerClient(unittest.IsolatedAsyncioTestCase):
         provider = "provider"
         service = "test-service"
         active = True
-        configs_data = get_configs()
+        configs_data = get_config_page(get_configs())
         endpoint = (
             f"{self.client.client.base_url}{self.client.configs_endpoint}"
             f"/provider/{provider}"
@@ -119,7 +131,7 @@ class TestAsyncPyConfigHandlerClient(unittest.IsolatedAsyncioTestCase):
         provider = "provider"
         service = "test-service"
         source = "test-source"
-        configs_data = get_configs()
+        configs_data = get_config_page(get_configs())
         endpoint = (
             f"{self.client.client.base_url}{self.client.configs_endpoint}"
             f"/provider/{provider}"
@@ -59,8 +59,7 @@ class AsyncPySchemaVaultClient:
         Returns:
             List[SchemaDTO]: A list of all schemas in the form of data classes.
         """
-        schemas_data = await self.client.make_request("GET", self.schemas_endpoint)
-        return [serialize_to_dataclass(schema, SchemaDTO) for schema in schemas_data]
+        return await self._list_pages(self.schemas_endpoint)
 
     async def get_schema_by_id(self, schema_id: str) -> SchemaDTO:
         """
@@ -101,8 +100,7 @@ class AsyncPySchemaVaultClient:
             List[SchemaDTO]: A list of schemas for the specified service and provider in the form of data classes.
         """
         endpoint = f"{self.schemas_endpoint}/provider/{provider}/service/{service}"
-        schemas_data = await self.client.make_request("GET", endpoint)
-        return [serialize_to_dataclass(schema, SchemaDTO) for schema in schemas_data]
+        return await self._list_pages(endpoint)
 
     async def list_schemas_by_source_and_provider(self, provider: str, source: str) -> List[SchemaDTO]:
         """
@@ -117,8 +115,7 @@ class AsyncPySchemaVaultClient:
             data classes.
         """
         endpoint = f"{self.schemas_endpoint}/provider/{provider}/source/{source}"
-        schemas_data = await self.client.make_request("GET", endpoint)
-        return [serialize_to_dataclass(schema, SchemaDTO) for schema in schemas_data]
+        return await self._list_pages(endpoint)
 
     async def list_schemas_by_service_source_and_provider(
         self,
@@ -139,8 +136,7 @@ class AsyncPySchemaVaultClient:
             form of data classes.
         """
         endpoint = f"{self.schemas_endpoint}/provider/{provider}/service/{service}/source/{source}"
-        schemas_data = await self.client.make_request("GET", endpoint)
-        return [serialize_to_dataclass(schema, SchemaDTO) for schema in schemas_data]
+        return await self._list_pages(endpoint)
 
     async def list_schemas_by_service_source_provider_and_schema_type(
         self,
@@ -188,6 +184,27 @@ class AsyncPySchemaVaultClient:
         return validation_response.get("valid", False)
 
 
+    async def _list_pages(self, endpoint: str) -> List[SchemaDTO]:
+        """
+        Retrieve every page of a schema listing, following the next cursor of each page.
+
+        Args:
+            endpoint (str): The endpoint of the listing.
+
+        Returns:
+            List[SchemaDTO]: The schemas of all the pages in the form of data classes.
+        """
+        schemas = []
+        params = None
+        while True:
+            page = await self.client.make_request("GET", endpoint, params=params)
+            schemas.extend(serialize_to_dataclass(schema, SchemaDTO) for schema in page["items"])
+            next_cursor = page.get("next_cursor")
+            if not next_cursor:
+                return schemas
+            params = {"cursor": next_cursor}
+
+
 def async_py_schema_vault_client() -> AsyncPySchemaVaultClient:
     """
     Create an instance of the AsyncPySchemaVaultClient using service discovery information.
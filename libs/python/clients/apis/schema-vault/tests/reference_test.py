@@ -46,6 +46,13 @@ def get_schemas() -> List[Dict[str, Any]]:
     ]
 
 
+def get_schema_page(schemas, next_cursor=None) -> Dict[str, Any]:
+    page = {"items": schemas}
+    if next_cursor:
+        page["next_cursor"] = next_cursor
+    return page
+
+
 def get_schema_data_dto() -> SchemaDataDTO:
     return SchemaDataDTO(
         service="test-service",
@@ -5,7 +5,7 @@ import httpx
 from typing import Dict
 from dto_schema_vault.output import SchemaDTO
 from cli_schema_vault.client import AsyncPySchemaVaultClient
-from tests.reference_test import get_schema, get_schemas, get_schema_data_dto
+from tests.reference_test import get_schema, get_schemas, get_schema_page, get_schema_data_dto
 
 
 class TestAsyncPySchemaVaultClient(unittest.IsolatedAsyncioTestCase):
@@ -45,7 +45,7 @@ class TestAsyncPySchemaVaultClient(unittest.IsolatedAsyncioTestCase):
 
     @respx.mock
     async def test_list_all_schemas(self):
-        schemas_data = get_schemas()
+        schemas_data = get_schema_page(get_schemas())
         endpoint = f"{self.client.client.base_url}{self.client.schemas_endpoint}"
         respx.get(endpoint).mock(return_value=self.mock_response(schemas_data))
 
@@ -53,6 +53,18 @@ class TestAsyncPySchemaVaultClient(unittest.IsolatedAsyncioTestCase):
         self.assertIsInstance(result, list)
         self.assertTrue(all(isinstance(schema, SchemaDTO) for schema in result))
 
+    @respx.mock
+    async def test_list_all_schemas_follows_next_cursor(self):
+        endpoint = f"{self.client.client.base_url}{self.client.schemas_endpoint}"
+        route = respx.get(endpoint).mock(side_effect=[
+            self.mock_response(get_schema_page([get_schema(schema_id="schema-id-1")], next_cursor="cursor-1")),
+            self.mock_response(get_schema_page([get_schema(schema_id="schema-id-2")])),
+        ])
+
+        result = await self.client.list_all_schemas()
+        self.assertEqual([schema.schema_id for schema in result], ["schema-id-1", "schema-id-2"])
+        self.assertEqual(route.calls[1].request.url.params["cursor"], "cursor-1")
+
     @respx.mock
     async def test_get_schema_by_id(self):
         schema_id = "schema-id"
@@ -76,7 +88,7 @@ class TestAsyncPySchemaVaultClient(unittest.IsolatedAsyncioTestCase):
     async def test_list_schemas_by_service_and_provider(self):
         provider = "provider"
         service = "test-service"
-        schemas_data = get_schemas()
+        schemas_data = get_schema_page(get_schemas())
         endpoint = f"{self.client.client.base_url}{self.client.schemas_endpoint}/provider/{provider}/service/{service}"
         respx.get(endpoint).mock(return_value=self.mock_response(schemas_data))
 
@@ -88,7 +100,7 @@ class TestAsyncPySchemaVaultClient(unittest.IsolatedAsyncioTestCase):
     async def test_list_schemas_by_source_and_provider(self):
         provider = "provider"
         source = "test-source"
-        schemas_data = get_schemas()
+        schemas_data = get_schema_page(get_schemas())
         endpoint = f"{self.client.client.base_url}{self.client.schemas_endpoint}/provider/{provider}/source/{source}"
         respx.get(endpoint).mock(return_value=self.mock_response(schemas_data))
 
@@ -101,7 +113,7 @@ class TestAsyncPySchemaVaultClient(unittest.IsolatedAsyncioTestCase):
         provider = "provider"
         service = "test-service"
         source = "test-source"
-        schemas_data = get_schemas()
+        schemas_data = get_schema_page(get_schemas())
         endpoint = (
             f"{self.client.client.base_url}{self.client.schemas_endpoint}"
             f"/provider/{provider}"
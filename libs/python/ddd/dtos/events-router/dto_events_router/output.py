@@ -1,15 +1,31 @@
 from dataclasses import dataclass, field
-from typing import Dict, Any
+from typing import Dict, Any, List
 
 
 @dataclass
 class ErrMsgDTO:
+    # Error code
+    code: str = field(metadata={"json": "code"})
+    # Error category: unmarshal, schema-invalid, dependency-lookup, repository, stage-transition, timeout, input-status, action or transformation
+    category: str = field(metadata={"json": "category"})
     # Error message
-    err: Exception = field(metadata={"json": "error"})
+    message: str = field(metadata={"json": "message"})
+    # Messages of the wrapped errors, outermost first
+    causes: List[str] = field(metadata={"json": "causes"})
+    # Original message
+    payload: Any = field(metadata={"json": "payload"})
+    # Processing ID
+    processing_id: str = field(metadata={"json": "processing_id"})
+    # Input ID
+    input_id: str = field(metadata={"json": "input_id"})
     # Listener tag
     listener_tag: str = field(metadata={"json": "listener_tag"})
-    # Message
-    msg: bytes = field(metadata={"json": "msg"})
+    # Stage
+    stage: str = field(metadata={"json": "stage"})
+    # Attempt number, starting at 1
+    attempt: int = field(metadata={"json": "attempt"})
+    # Timestamp
+    timestamp: str = field(metadata={"json": "timestamp"})
 
 
 @dataclass
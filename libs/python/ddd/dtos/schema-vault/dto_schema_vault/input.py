@@ -1,6 +1,6 @@
 from dataclasses import dataclass, field
-from typing import Dict, Any
-from dto_schema_vault.shared import JsonSchemaDTO
+from typing import Dict, Any, List
+from dto_schema_vault.shared import FieldMappingDTO, JsonSchemaDTO
 
 
 @dataclass
@@ -15,6 +15,8 @@ class SchemaDTO:
     schema_type: str = field(metadata={"json": "schema_type"})
     # JsonSchemaDTO represents the JSON schema of the configuration.
     json_schema: JsonSchemaDTO = field(metadata={"json": "json_schema"})
+    # Transformation reshapes the data to the schema, for the output schemas.
+    transformation: List[FieldMappingDTO] = field(default_factory=list, metadata={"json": "transformation"})
 
 
 @dataclass
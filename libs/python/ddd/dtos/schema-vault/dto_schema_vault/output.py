@@ -1,5 +1,6 @@
 from dataclasses import dataclass, field
-from dto_schema_vault.shared import JsonSchemaDTO
+from typing import List
+from dto_schema_vault.shared import FieldMappingDTO, JsonSchemaDTO
 
 
 @dataclass
@@ -22,3 +23,5 @@ class SchemaDTO:
     created_at: str = field(metadata={"json": "created_at"}, repr=False)
     # UpdatedAt is the timestamp when the Schema entity was last updated.
     updated_at: str = field(metadata={"json": "updated_at"}, repr=False)
+    # Transformation reshapes the data to the schema, for the output schemas.
+    transformation: List[FieldMappingDTO] = field(default_factory=list, metadata={"json": "transformation"})
@@ -10,3 +10,15 @@ class JsonSchemaDTO:
     properties: Dict[str, Any] = field(metadata={"json": "properties"})
     # JsonType specifies the type of JSON schema.
     json_type: str = field(metadata={"json": "type"})
+
+
+@dataclass
+class FieldMappingDTO:
+    # To is the dot-separated path of the field in the transformed data.
+    to: str = field(metadata={"json": "to"})
+    # From is the dot-separated path of the field in the source data, To when empty.
+    from_: str = field(default="", metadata={"json": "from"})
+    # Type is the JSON type the value is cast to: string, integer, number or boolean.
+    type: str = field(default="", metadata={"json": "type"})
+    # Default is the value used when the source field is missing.
+    default: Any = field(default=None, metadata={"json": "default"})
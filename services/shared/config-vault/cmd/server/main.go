@@ -6,6 +6,8 @@ import (
 	webHandler "libs/golang/ddd/adapters/http/handlers/config-vault/handlers"
 	"libs/golang/ddd/adapters/http/handlers/health-check/healthz"
 	webserver "libs/golang/server/http/chi-webserver/server"
+	"libs/golang/server/http/openapi/openapi"
+	"libs/golang/server/lifecycle/lifecycle"
 	servicediscovery "libs/golang/service-discovery/sd"
 	"log"
 	"os"
@@ -13,10 +15,30 @@ import (
 )
 
 var (
-	webServerPort = ":8000"
-	databaseName  = os.Getenv("MONGODB_DBNAME")
+	webServerPort   = ":8000"
+	databaseName    = os.Getenv("MONGODB_DBNAME")
+	shutdownTimeout = os.Getenv("SHUTDOWN_TIMEOUT") // "30s" when empty
+	openAPIInfo     = openapi.Info{
+		Title:       "config-vault",
+		Version:     "1.0.0",
+		Description: "Manages the job configurations of the providers and the dependencies between them.",
+	}
 )
 
+// getLifecycleManager creates the manager stopping the components of the service within the shutdown timeout.
+//
+// Returns:
+//   - A pointer to the lifecycle manager.
+//
+// Panics if the shutdown timeout is invalid.
+func getLifecycleManager() *lifecycle.Manager {
+	timeout, err := lifecycle.ParseTimeout(shutdownTimeout)
+	if err != nil {
+		panic(err)
+	}
+	return lifecycle.NewManager(timeout)
+}
+
 // getMongoResource retrieves the MongoDB wrapper client resource from the service discovery.
 //
 // Parameters:
@@ -73,20 +95,46 @@ func makeHTTPConfigTransport(httpServer *webserver.Server, configHandler *webHan
 	httpServer.RegisterRoute("GET", "/config/provider/{provider}/service/{service}/active/{active}", configHandler.ListConfigsByServiceAndProviderAndActive)
 	httpServer.RegisterRoute("GET", "/config/provider/{provider}/service/{service}/source/{source}", configHandler.ListConfigsByServiceAndSourceAndProvider)
 	httpServer.RegisterRoute("GET", "/config/provider/{provider}/dependencies/service/{service}/source/{source}", configHandler.ListConfigsByProviderAndDependencies)
+	httpServer.RegisterRoute("GET", "/config/provider/{provider}/graph", configHandler.GetConfigGraph)
+}
+
+// getOpenAPIDocument generates the OpenAPI document of the routes registered on the HTTP server.
+//
+// Parameters:
+//   - httpServer: The web server instance, with the routes of the service registered.
+//
+// Returns:
+//   - A pointer to the OpenAPI document.
+//
+// Panics if a route is not documented by the operations of its handler.
+func getOpenAPIDocument(httpServer *webserver.Server) *openapi.Document {
+	document, err := openapi.Generate(openAPIInfo, httpServer.Routes(), healthz.Operations, webHandler.Operations)
+	if err != nil {
+		panic(err)
+	}
+	return document
+}
+
+// makeHTTPOpenAPITransport registers the routes serving the OpenAPI document and its documentation page on the HTTP server.
+//
+// Parameters:
+//   - httpServer: The web server instance.
+//   - document: The OpenAPI document of the service.
+func makeHTTPOpenAPITransport(httpServer *webserver.Server, document *openapi.Document) {
+	httpServer.RegisterRoute("GET", openapi.SpecPath, openapi.SpecHandler(document))
+	httpServer.RegisterRoute("GET", openapi.DocsPath, openapi.DocsHandler())
 }
 
 // main is the entry point of the application.
 // It initializes the service discovery, MongoDB client, HTTP server, and handlers,
-// then starts the HTTP server.
+// then starts the HTTP server
+// until the service is asked to terminate.
 func main() {
 	log.New(os.Stdout, "[CONFIG-VAULT] - ", log.LstdFlags)
 	sd := servicediscovery.NewServiceDiscovery()
+	manager := getLifecycleManager()
 	mongoClient := getMongoResource(sd)
-
-	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
-	defer cancel()
-
-	defer mongoClient.Disconnect(ctx)
+	manager.Register("mongodb", mongoClient.Disconnect)
 
 	healthzHandler := healthz.NewWebHealthzHandler(&healthz.RealTimeProvider{}, 5*time.Second)
 	configHandler := NewWebServiceConfigHandler(mongoClient.Client, databaseName)
@@ -94,8 +142,10 @@ func main() {
 	httpServer := getHTTPServer()
 	makeHTTPHealthzTransport(httpServer, healthzHandler)
 	makeHTTPConfigTransport(httpServer, configHandler)
+	makeHTTPOpenAPITransport(httpServer, getOpenAPIDocument(httpServer))
 
-	if err := httpServer.Start(); err != nil {
-		log.Fatalf("Failed to start server: %v", err)
+	manager.Serve("http server", httpServer.Start, httpServer.Shutdown)
+	if err := manager.Wait(context.Background()); err != nil {
+		log.Fatalf("Server stopped with errors: %v", err)
 	}
 }
@@ -1,6 +1,10 @@
 package main
 
 import (
+	"context"
+	configVaultClient "libs/golang/clients/apis/config-vault/client"
+	inputBrokerClient "libs/golang/clients/apis/input-broker/client"
+	schemaVaultClient "libs/golang/clients/apis/schema-vault/client"
 	inMemoryDBClient "libs/golang/clients/resources/go-docdb/client"
 	gorabbitmq "libs/golang/clients/resources/go-rabbitmq/client"
 	inMemoryDB "libs/golang/database/go-docdb/database"
@@ -8,20 +12,43 @@ import (
 	event "libs/golang/ddd/events/events-router/event"
 	eventHandlers "libs/golang/ddd/events/events-router/handlers"
 	"libs/golang/ddd/usecases/events-router/usecase"
+	usecaseActions "libs/golang/ddd/usecases/events-router/usecase/actions"
 	amqpConsumer "libs/golang/server/events/amqp-consumer/consumer"
 	eventServer "libs/golang/server/events/event-server/server"
 	eventListener "libs/golang/server/events/listener/listener"
+	"libs/golang/server/lifecycle/lifecycle"
 	servicediscovery "libs/golang/service-discovery/sd"
 	events "libs/golang/shared/go-events/amqp_events"
 	"log"
 	"os"
+	"strconv"
+	"time"
 )
 
 var (
-	dbName                  = os.Getenv("DOCDB_DBNAME")  // "events-order"
-	consumerName            = os.Getenv("CONSUMER_NAME") // "events-router"
+	dbName                  = os.Getenv("DOCDB_DBNAME")                 // "events-order"
+	dbDataDir               = os.Getenv("DOCDB_DATA_DIR")               // "/data/docdb", in-memory only when empty
+	dbSyncPolicy            = os.Getenv("DOCDB_SYNC_POLICY")            // "always", "interval" or "never"
+	consumerName            = os.Getenv("CONSUMER_NAME")                // "events-router"
+	stageTimeoutDefault     = os.Getenv("STAGE_TIMEOUT_DEFAULT")        // "1h", no timeout when empty
+	stageTimeouts           = os.Getenv("STAGE_TIMEOUTS")               // "provider/service=30m,..."
+	stageSweepInterval      = os.Getenv("STAGE_TIMEOUT_SWEEP_INTERVAL") // "1m" when empty
+	stageRedispatchBudget   = os.Getenv("STAGE_REDISPATCH_BUDGET")      // "0" when empty
+	preProcessingActions    = os.Getenv("PREPROCESSING_ACTIONS")        // "validate-schema,list-dependencies" when empty
+	preProcessingRoutes     = os.Getenv("PREPROCESSING_ROUTES")         // "provider/service=action,...;..."
+	dedupRetention          = os.Getenv("DEDUP_RETENTION")              // "24h" when empty, no deduplication when "0"
+	dedupPurgeInterval      = os.Getenv("DEDUP_PURGE_INTERVAL")         // "1h" when empty
+	orchestrationWindow     = os.Getenv("ORCHESTRATION_WINDOW")         // "24h" when empty
+	listenerWorkers         = os.Getenv("LISTENER_WORKERS")             // "1" when empty
+	shutdownTimeout         = os.Getenv("SHUTDOWN_TIMEOUT")             // "30s" when empty
 	preProcessingQueueName  = "pre-processing"
 	preProcessingRoutingKey = "input.created.*"
+	orchestrationQueueName  = "dag-orchestration"
+	orchestrationRoutingKey = "output.created.#"
+	completionQueueName     = "input-completion"
+	completionRoutingKey    = "output.created.#"
+	processingQueueName     = "stage-processing"
+	processingRoutingKey    = "input.processing.#"
 )
 
 func getRabbitMQResource(sd *servicediscovery.ServiceDiscovery) *gorabbitmq.Client {
@@ -36,6 +63,162 @@ func getRabbitMQResource(sd *servicediscovery.ServiceDiscovery) *gorabbitmq.Clie
 	return client
 }
 
+// getDatabase opens the events-router document database, backed by files when DOCDB_DATA_DIR is set.
+//
+// Returns:
+//   - A pointer to the opened database.
+//
+// Panics if the persisted data cannot be restored.
+func getDatabase() *inMemoryDB.InMemoryDocBD {
+	if dbDataDir == "" {
+		return inMemoryDB.NewInMemoryDocBD(dbName)
+	}
+	syncPolicy, err := inMemoryDB.ParseSyncPolicy(dbSyncPolicy)
+	if err != nil {
+		panic(err)
+	}
+	db, err := inMemoryDB.OpenInMemoryDocBD(dbName, inMemoryDB.WithPersistence(inMemoryDB.PersistenceConfig{
+		Dir:        dbDataDir,
+		SyncPolicy: syncPolicy,
+	}))
+	if err != nil {
+		panic(err)
+	}
+	return db
+}
+
+// getStageTimeoutSettings reads the settings of the stage timeout sweeper from the environment.
+//
+// Returns:
+//   - The timeouts per provider and service.
+//   - The interval between two sweeps.
+//   - The re-dispatch budget of the expired orders.
+//
+// Panics if a setting is invalid.
+func getStageTimeoutSettings() (usecase.StageTimeouts, time.Duration, int) {
+	timeouts, err := usecase.ParseStageTimeouts(stageTimeoutDefault, stageTimeouts)
+	if err != nil {
+		panic(err)
+	}
+	interval := time.Minute
+	if stageSweepInterval != "" {
+		if interval, err = time.ParseDuration(stageSweepInterval); err != nil {
+			panic(err)
+		}
+	}
+	budget := 0
+	if stageRedispatchBudget != "" {
+		if budget, err = strconv.Atoi(stageRedispatchBudget); err != nil {
+			panic(err)
+		}
+	}
+	return timeouts, interval, budget
+}
+
+// getPreProcessingPipelines builds the pre-processing pipelines from the environment.
+//
+// Parameters:
+//   - registry: The actions the pipelines can use.
+//
+// Returns:
+//   - The pipelines per provider and service.
+//
+// Panics if a pipeline uses an unknown action.
+func getPreProcessingPipelines(registry usecaseActions.Registry) usecaseActions.Pipelines {
+	defaultActions := preProcessingActions
+	if defaultActions == "" {
+		defaultActions = usecase.DefaultPipelineActions
+	}
+	pipelines, err := usecaseActions.ParsePipelines(defaultActions, preProcessingRoutes, registry)
+	if err != nil {
+		panic(err)
+	}
+	return pipelines
+}
+
+// getDeduplicationSettings reads the settings of the duplicate suppression from the environment.
+//
+// Returns:
+//   - How long a processed input is recognized as a duplicate, zero when the deduplication is disabled.
+//   - The interval between two purges of the expired processed inputs.
+//
+// Panics if a setting is invalid.
+func getDeduplicationSettings() (time.Duration, time.Duration) {
+	var err error
+	retention := 24 * time.Hour
+	if dedupRetention != "" {
+		if retention, err = time.ParseDuration(dedupRetention); err != nil {
+			panic(err)
+		}
+	}
+	interval := time.Hour
+	if dedupPurgeInterval != "" {
+		if interval, err = time.ParseDuration(dedupPurgeInterval); err != nil {
+			panic(err)
+		}
+	}
+	return retention, interval
+}
+
+// getOrchestrationWindow reads the length of the processing windows of the dependency orchestration from
+// the environment.
+//
+// Returns:
+//   - The length of the windows in which the dependencies of a job must complete.
+//
+// Panics if the setting is not a positive duration.
+func getOrchestrationWindow() time.Duration {
+	if orchestrationWindow == "" {
+		return 24 * time.Hour
+	}
+	window, err := time.ParseDuration(orchestrationWindow)
+	if err != nil {
+		panic(err)
+	}
+	if window <= 0 {
+		panic("ORCHESTRATION_WINDOW must be a positive duration")
+	}
+	return window
+}
+
+// getListenerOptions reads the worker pool of the listeners from the environment. The messages of a job,
+// identified by its provider, service and source, are processed in order.
+//
+// Parameters:
+//   - jobFields: The paths of the provider, service and source fields in the messages of the listener.
+//
+// Returns:
+//   - The options of the listener.
+//
+// Panics if the number of workers is invalid.
+func getListenerOptions(jobFields ...string) []eventListener.ListenerOption {
+	workers := 1
+	if listenerWorkers != "" {
+		var err error
+		if workers, err = strconv.Atoi(listenerWorkers); err != nil {
+			panic(err)
+		}
+	}
+	return []eventListener.ListenerOption{
+		eventListener.WithWorkers(workers),
+		eventListener.WithOrderingKey(eventListener.JSONFieldsKey(jobFields...)),
+	}
+}
+
+// getLifecycleManager creates the manager stopping the components of the service within the shutdown timeout.
+//
+// Returns:
+//   - A pointer to the lifecycle manager.
+//
+// Panics if the shutdown timeout is invalid.
+func getLifecycleManager() *lifecycle.Manager {
+	timeout, err := lifecycle.ParseTimeout(shutdownTimeout)
+	if err != nil {
+		panic(err)
+	}
+	return lifecycle.NewManager(timeout)
+}
+
 func getRabbitMQNotifier(rmqClient *gorabbitmq.Client) *gorabbitmq.RabbitMQNotifier {
 	return gorabbitmq.NewRabbitMQNotifier(rmqClient)
 }
@@ -43,11 +226,16 @@ func getRabbitMQNotifier(rmqClient *gorabbitmq.Client) *gorabbitmq.RabbitMQNotif
 func main() {
 	log.New(os.Stdout, "[EVENT-ROUTER] - ", log.LstdFlags)
 	sd := servicediscovery.NewServiceDiscovery()
-	db := inMemoryDB.NewInMemoryDocBD(dbName)
+	manager := getLifecycleManager()
+	db := getDatabase()
+	manager.RegisterCloser("docdb", db.Close)
 	dbClient := inMemoryDBClient.NewClient(db)
 	eventOrderRepository := inMemoryDBRepository.NewEventOrderRepository(dbClient, dbName)
+	processingWindowRepository := inMemoryDBRepository.NewProcessingWindowRepository(dbClient, dbName)
+	processedMessageRepository := inMemoryDBRepository.NewProcessedMessageRepository(dbClient, dbName)
 
 	rmq := getRabbitMQResource(sd)
+	manager.RegisterCloser("rabbitmq", rmq.Close)
 	notifier := getRabbitMQNotifier(rmq)
 
 	eventDispatcher := events.NewEventDispatcher()
@@ -58,21 +246,77 @@ func main() {
 		Notifier: notifier,
 	})
 
-	errorEventHandler := event.NewErrorCreated()
-	eventOrderEventHandler := event.NewOrderedProcess()
+	// Each published message gets its own event, so the workers of a listener never share a payload.
+	newErrorCreated := func() events.EventInterface { return event.NewErrorCreated() }
+	newOrderedProcess := func() events.EventInterface { return event.NewOrderedProcess() }
+
+	configClient := configVaultClient.NewClient()
+	inputClient := inputBrokerClient.NewClient()
+	schemaClient := schemaVaultClient.NewClient()
+	inputStatusUpdater := usecaseActions.NewUpdateInputStatusAction(inputClient)
+	pipelines := getPreProcessingPipelines(usecase.NewPreProcessingRegistry(schemaClient, inputClient, configClient))
+
+	var deduplicator *usecase.Deduplicator
+	retention, purgeInterval := getDeduplicationSettings()
+	if retention > 0 {
+		deduplicator = usecase.NewDeduplicator(processedMessageRepository, retention)
+		manager.Run("deduplication purge", func(ctx context.Context) {
+			deduplicator.Run(ctx, purgeInterval)
+		})
+	}
 
 	eventOrderUsecase := usecase.NewPreProcessingUseCase(
 		eventOrderRepository,
-		errorEventHandler,
-		eventOrderEventHandler,
+		deduplicator,
+		pipelines,
+		newErrorCreated,
+		newOrderedProcess,
 		eventDispatcher,
 	)
 
+	orchestrationUsecase := usecase.NewDependencyOrchestrationUseCase(
+		processingWindowRepository,
+		usecaseActions.NewListAllByDependenciesAction(configClient),
+		newErrorCreated,
+		newOrderedProcess,
+		eventDispatcher,
+	)
+	orchestrationUsecase.Window = getOrchestrationWindow()
+
+	completionUsecase := usecase.NewInputCompletionUseCase(eventOrderRepository, inputStatusUpdater, newErrorCreated, eventDispatcher)
+	processingStartedUsecase := usecase.NewProcessingStartedUseCase(eventOrderRepository, newErrorCreated, eventDispatcher)
+
+	timeouts, sweepInterval, redispatchBudget := getStageTimeoutSettings()
+	stageTimeoutSweeper := usecase.NewStageTimeoutSweeper(
+		eventOrderRepository,
+		timeouts,
+		redispatchBudget,
+		inputStatusUpdater,
+		newErrorCreated,
+		newOrderedProcess,
+		eventDispatcher,
+	)
+	manager.Run("stage timeout sweeper", func(ctx context.Context) {
+		stageTimeoutSweeper.Run(ctx, sweepInterval)
+	})
+
 	listener := eventListener.NewEventListener()
 	preProcessingConsumer := amqpConsumer.NewAmqpConsumer(rmq, preProcessingQueueName, consumerName, preProcessingRoutingKey)
+	orchestrationConsumer := amqpConsumer.NewAmqpConsumer(rmq, orchestrationQueueName, consumerName, orchestrationRoutingKey)
+	completionConsumer := amqpConsumer.NewAmqpConsumer(rmq, completionQueueName, consumerName, completionRoutingKey)
+	processingConsumer := amqpConsumer.NewAmqpConsumer(rmq, processingQueueName, consumerName, processingRoutingKey)
 
-	listener.AddListener(preProcessingConsumer, eventOrderUsecase)
+	listener.AddListener(preProcessingConsumer, eventOrderUsecase, getListenerOptions("metadata.provider", "metadata.service", "metadata.source")...)
+	listener.AddListener(orchestrationConsumer, orchestrationUsecase, getListenerOptions("provider", "service", "source")...)
+	listener.AddListener(completionConsumer, completionUsecase, getListenerOptions("provider", "service", "source")...)
+	listener.AddListener(processingConsumer, processingStartedUsecase, getListenerOptions("provider", "service", "source")...)
 
 	listenerServer := eventServer.NewListenerServer(listener)
-	listenerServer.Start()
+	manager.Serve("listener server", func() error {
+		listenerServer.Start()
+		return nil
+	}, listenerServer.Shutdown)
+	if err := manager.Wait(context.Background()); err != nil {
+		log.Fatalf("Server stopped with errors: %v", err)
+	}
 }
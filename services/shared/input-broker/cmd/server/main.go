@@ -6,20 +6,41 @@ import (
 	gorabbitmq "libs/golang/clients/resources/go-rabbitmq/client"
 	"libs/golang/ddd/adapters/http/handlers/health-check/healthz"
 	webHandler "libs/golang/ddd/adapters/http/handlers/input-broker/handlers"
-	eventHandlers "libs/golang/ddd/events/input-broker/handlers"
 	webserver "libs/golang/server/http/chi-webserver/server"
+	"libs/golang/server/http/openapi/openapi"
+	"libs/golang/server/lifecycle/lifecycle"
 	servicediscovery "libs/golang/service-discovery/sd"
-	events "libs/golang/shared/go-events/amqp_events"
+	"libs/golang/shared/go-outbox/outbox"
 	"log"
 	"os"
 	"time"
 )
 
 var (
-	webServerPort = ":8000"
-	databaseName  = os.Getenv("MONGODB_DBNAME")
+	webServerPort   = ":8000"
+	databaseName    = os.Getenv("MONGODB_DBNAME")
+	shutdownTimeout = os.Getenv("SHUTDOWN_TIMEOUT") // "30s" when empty
+	openAPIInfo     = openapi.Info{
+		Title:       "input-broker",
+		Version:     "1.0.0",
+		Description: "Stores the inputs of the jobs and dispatches them to the services processing them.",
+	}
 )
 
+// getLifecycleManager creates the manager stopping the components of the service within the shutdown timeout.
+//
+// Returns:
+//   - A pointer to the lifecycle manager.
+//
+// Panics if the shutdown timeout is invalid.
+func getLifecycleManager() *lifecycle.Manager {
+	timeout, err := lifecycle.ParseTimeout(shutdownTimeout)
+	if err != nil {
+		panic(err)
+	}
+	return lifecycle.NewManager(timeout)
+}
+
 // getMongoResource retrieves the MongoDB wrapper client resource from the service discovery.
 //
 // Parameters:
@@ -62,18 +83,6 @@ func getRabbitMQResource(sd *servicediscovery.ServiceDiscovery) *gorabbitmq.Clie
 	return client
 }
 
-// getRabbitMQNotifier initializes and configures the RabbitMQ notifier.
-//
-// Parameters:
-//   - sd: The service discovery instance.
-//
-// Returns:
-//   - A pointer to the configured RabbitMQ notifier.
-func getRabbitMQNotifier(sd *servicediscovery.ServiceDiscovery) *gorabbitmq.RabbitMQNotifier {
-	rabbitmqClient := getRabbitMQResource(sd)
-	return gorabbitmq.NewRabbitMQNotifier(rabbitmqClient)
-}
-
 // getHTTPServer initializes and configures the HTTP server.
 //
 // Returns:
@@ -102,9 +111,9 @@ func makeHTTPConfigTransport(httpServer *webserver.Server, configHandler *webHan
 	httpServer.RegisterRoute("POST", "/input", configHandler.CreateInput)
 	httpServer.RegisterRoute("GET", "/input", configHandler.ListAllInputs)
 	httpServer.RegisterRoute("GET", "/input/{id}", configHandler.ListInputByID)
-	httpServer.RegisterRoute("UPDATE", "/input/{id}", configHandler.UpdateInput)
+	httpServer.RegisterRoute("PUT", "/input/{id}", configHandler.UpdateInput)
 	httpServer.RegisterRoute("DELETE", "/input/{id}", configHandler.DeleteInput)
-	httpServer.RegisterRoute("UPDATE", "/input/{id}/status", configHandler.UpdateInputStatus)
+	httpServer.RegisterRoute("PUT", "/input/{id}/status", configHandler.UpdateInputStatus)
 	httpServer.RegisterRoute("GET", "/input/provider/{provider}/service/{service}", configHandler.ListInputsByServiceAndProvider)
 	httpServer.RegisterRoute("GET", "/input/provider/{provider}/source/{source}", configHandler.ListInputsBySourceAndProvider)
 	httpServer.RegisterRoute("GET", "/input/provider/{provider}/service/{service}/source/{source}", configHandler.ListInputsByServiceAndSourceAndProvider)
@@ -114,30 +123,58 @@ func makeHTTPConfigTransport(httpServer *webserver.Server, configHandler *webHan
 	httpServer.RegisterRoute("GET", "/input/provider/{provider}/status/{status}", configHandler.ListInputsByStatusAndProvider)
 }
 
+// getOpenAPIDocument generates the OpenAPI document of the routes registered on the HTTP server.
+//
+// Parameters:
+//   - httpServer: The web server instance, with the routes of the service registered.
+//
+// Returns:
+//   - A pointer to the OpenAPI document.
+//
+// Panics if a route is not documented by the operations of its handler.
+func getOpenAPIDocument(httpServer *webserver.Server) *openapi.Document {
+	document, err := openapi.Generate(openAPIInfo, httpServer.Routes(), healthz.Operations, webHandler.Operations)
+	if err != nil {
+		panic(err)
+	}
+	return document
+}
+
+// makeHTTPOpenAPITransport registers the routes serving the OpenAPI document and its documentation page on the HTTP server.
+//
+// Parameters:
+//   - httpServer: The web server instance.
+//   - document: The OpenAPI document of the service.
+func makeHTTPOpenAPITransport(httpServer *webserver.Server, document *openapi.Document) {
+	httpServer.RegisterRoute("GET", openapi.SpecPath, openapi.SpecHandler(document))
+	httpServer.RegisterRoute("GET", openapi.DocsPath, openapi.DocsHandler())
+}
+
 func main() {
 	log.New(os.Stdout, "[INPUT-BROKER] - ", log.LstdFlags)
 	sd := servicediscovery.NewServiceDiscovery()
+	manager := getLifecycleManager()
 	mongoClient := getMongoResource(sd)
+	manager.Register("mongodb", mongoClient.Disconnect)
 
-	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
-	defer cancel()
-
-	defer mongoClient.Disconnect(ctx)
+	rabbitmqClient := getRabbitMQResource(sd)
+	manager.RegisterCloser("rabbitmq", rabbitmqClient.Close)
+	notifier := gorabbitmq.NewRabbitMQNotifier(rabbitmqClient)
 
-	notifier := getRabbitMQNotifier(sd)
-	eventDispatcher := events.NewEventDispatcher()
-	eventDispatcher.Register("InputCreated", &eventHandlers.InputCreatedHandler{
-		Notifier: notifier,
-	})
+	outboxRelay := outbox.NewRelay(outbox.NewMongoStore(mongoClient.Client, databaseName), notifier)
+	manager.Run("outbox relay", outboxRelay.Start)
 
 	healthzHandler := healthz.NewWebHealthzHandler(&healthz.RealTimeProvider{}, 5*time.Second)
-	inputHandler := NewWebServiceInputHandler(mongoClient.Client, eventDispatcher, databaseName)
+	healthzHandler.AddDependencyCheck("rabbitmq", rabbitmqClient.CheckConnection)
+	inputHandler := NewWebServiceInputHandler(mongoClient.Client, databaseName)
 
 	httpServer := getHTTPServer()
 	makeHTTPHealthzTransport(httpServer, healthzHandler)
 	makeHTTPConfigTransport(httpServer, inputHandler)
+	makeHTTPOpenAPITransport(httpServer, getOpenAPIDocument(httpServer))
 
-	if err := httpServer.Start(); err != nil {
-		log.Fatalf("Failed to start server: %v", err)
+	manager.Serve("http server", httpServer.Start, httpServer.Shutdown)
+	if err := manager.Wait(context.Background()); err != nil {
+		log.Fatalf("Server stopped with errors: %v", err)
 	}
 }
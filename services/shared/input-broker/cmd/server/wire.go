@@ -23,12 +23,17 @@ var setInputRepositoryDependency = wire.NewSet(
 	),
 )
 
-var setInputCreatedEvent = wire.NewSet(
-	event.NewInputCreated,
-	wire.Bind(new(events.EventInterface), new(*event.InputCreated)),
-)
+var setInputCreatedEvent = wire.NewSet(newInputCreatedEvent)
+
+// newInputCreatedEvent provides the constructor of the InputCreated events, so each created input is stored
+// with its own event.
+func newInputCreatedEvent() func() events.EventInterface {
+	return func() events.EventInterface {
+		return event.NewInputCreated()
+	}
+}
 
-func NewWebServiceInputHandler(client *mongo.Client, eventDispatcher events.EventDispatcherInterface, database string) *webHandler.WebInputHandler {
+func NewWebServiceInputHandler(client *mongo.Client, database string) *webHandler.WebInputHandler {
 	wire.Build(
 		setInputRepositoryDependency,
 		setInputCreatedEvent,
@@ -18,10 +18,10 @@ import (
 
 // Injectors from wire.go:
 
-func NewWebServiceInputHandler(client *mongo.Client, eventDispatcher amqpevents.EventDispatcherInterface, database string) *handlers.WebInputHandler {
+func NewWebServiceInputHandler(client *mongo.Client, database string) *handlers.WebInputHandler {
 	inputRepository := repository.NewInputRepository(client, database)
-	inputCreated := event.NewInputCreated()
-	webInputHandler := handlers.NewWebInputHandler(inputRepository, eventDispatcher, inputCreated)
+	v := newInputCreatedEvent()
+	webInputHandler := handlers.NewWebInputHandler(inputRepository, v)
 	return webInputHandler
 }
 
@@ -33,4 +33,12 @@ var setInputRepositoryDependency = wire.NewSet(repository.NewInputRepository, wi
 ),
 )
 
-var setInputCreatedEvent = wire.NewSet(event.NewInputCreated, wire.Bind(new(amqpevents.EventInterface), new(*event.InputCreated)))
+var setInputCreatedEvent = wire.NewSet(newInputCreatedEvent)
+
+// newInputCreatedEvent provides the constructor of the InputCreated events, so each created input is stored
+// with its own event.
+func newInputCreatedEvent() func() amqpevents.EventInterface {
+	return func() amqpevents.EventInterface {
+		return event.NewInputCreated()
+	}
+}
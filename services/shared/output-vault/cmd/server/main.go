@@ -3,20 +3,44 @@ package main
 import (
 	"context"
 	gomongodb "libs/golang/clients/resources/go-mongo/client"
+	gorabbitmq "libs/golang/clients/resources/go-rabbitmq/client"
 	"libs/golang/ddd/adapters/http/handlers/health-check/healthz"
 	webHandler "libs/golang/ddd/adapters/http/handlers/output-vault/handlers"
 	webserver "libs/golang/server/http/chi-webserver/server"
+	"libs/golang/server/http/openapi/openapi"
+	"libs/golang/server/lifecycle/lifecycle"
 	servicediscovery "libs/golang/service-discovery/sd"
+	"libs/golang/shared/go-outbox/outbox"
 	"log"
 	"os"
 	"time"
 )
 
 var (
-	webServerPort = ":8000"
-	databaseName  = os.Getenv("MONGODB_DBNAME")
+	webServerPort   = ":8000"
+	databaseName    = os.Getenv("MONGODB_DBNAME")
+	shutdownTimeout = os.Getenv("SHUTDOWN_TIMEOUT") // "30s" when empty
+	openAPIInfo     = openapi.Info{
+		Title:       "output-vault",
+		Version:     "1.0.0",
+		Description: "Stores the outputs produced by the jobs.",
+	}
 )
 
+// getLifecycleManager creates the manager stopping the components of the service within the shutdown timeout.
+//
+// Returns:
+//   - A pointer to the lifecycle manager.
+//
+// Panics if the shutdown timeout is invalid.
+func getLifecycleManager() *lifecycle.Manager {
+	timeout, err := lifecycle.ParseTimeout(shutdownTimeout)
+	if err != nil {
+		panic(err)
+	}
+	return lifecycle.NewManager(timeout)
+}
+
 // getMongoResource retrieves the MongoDB wrapper client resource from the service discovery.
 //
 // Parameters:
@@ -38,6 +62,27 @@ func getMongoResource(sd *servicediscovery.ServiceDiscovery) *gomongodb.Client {
 	return client
 }
 
+// getRabbitMQResource retrieves the RabbitMQ client resource from the service discovery.
+//
+// Parameters:
+//   - sd: The service discovery instance.
+//
+// Returns:
+//   - A pointer to the RabbitMQ client.
+//
+// Panics if the RabbitMQ resource is not found or if the client type is invalid.
+func getRabbitMQResource(sd *servicediscovery.ServiceDiscovery) *gorabbitmq.Client {
+	rabbitmq, err := sd.GetResource("rabbitmq")
+	if err != nil {
+		panic(err)
+	}
+	client, ok := rabbitmq.GetClient().(*gorabbitmq.Client)
+	if !ok {
+		panic("invalid RabbitMQ client type")
+	}
+	return client
+}
+
 // getHTTPServer initializes and configures the HTTP server.
 //
 // Returns:
@@ -73,27 +118,62 @@ func makeHTTPOutputTransport(httpServer *webserver.Server, outputHandler *webHan
 	httpServer.RegisterRoute("GET", "/output/provider/{provider}/service/{service}/source/{source}", outputHandler.ListOutputsByServiceAndSourceAndProvider)
 }
 
+// getOpenAPIDocument generates the OpenAPI document of the routes registered on the HTTP server.
+//
+// Parameters:
+//   - httpServer: The web server instance, with the routes of the service registered.
+//
+// Returns:
+//   - A pointer to the OpenAPI document.
+//
+// Panics if a route is not documented by the operations of its handler.
+func getOpenAPIDocument(httpServer *webserver.Server) *openapi.Document {
+	document, err := openapi.Generate(openAPIInfo, httpServer.Routes(), healthz.Operations, webHandler.Operations)
+	if err != nil {
+		panic(err)
+	}
+	return document
+}
+
+// makeHTTPOpenAPITransport registers the routes serving the OpenAPI document and its documentation page on the HTTP server.
+//
+// Parameters:
+//   - httpServer: The web server instance.
+//   - document: The OpenAPI document of the service.
+func makeHTTPOpenAPITransport(httpServer *webserver.Server, document *openapi.Document) {
+	httpServer.RegisterRoute("GET", openapi.SpecPath, openapi.SpecHandler(document))
+	httpServer.RegisterRoute("GET", openapi.DocsPath, openapi.DocsHandler())
+}
+
 // main is the entry point of the application.
-// It initializes the service discovery, MongoDB client, HTTP server, and handlers,
-// then starts the HTTP server.
+// It initializes the service discovery, MongoDB and RabbitMQ clients, the outbox relay publishing the
+// OutputCreated events, the HTTP server, and handlers, then starts the HTTP server
+// until the service is asked to terminate.
 func main() {
 	log.New(os.Stdout, "[OUTPUT-VAULT] - ", log.LstdFlags)
 	sd := servicediscovery.NewServiceDiscovery()
+	manager := getLifecycleManager()
 	mongoClient := getMongoResource(sd)
+	manager.Register("mongodb", mongoClient.Disconnect)
 
-	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
-	defer cancel()
+	rabbitmqClient := getRabbitMQResource(sd)
+	manager.RegisterCloser("rabbitmq", rabbitmqClient.Close)
+	notifier := gorabbitmq.NewRabbitMQNotifier(rabbitmqClient)
 
-	defer mongoClient.Disconnect(ctx)
+	outboxRelay := outbox.NewRelay(outbox.NewMongoStore(mongoClient.Client, databaseName), notifier)
+	manager.Run("outbox relay", outboxRelay.Start)
 
 	healthzHandler := healthz.NewWebHealthzHandler(&healthz.RealTimeProvider{}, 5*time.Second)
+	healthzHandler.AddDependencyCheck("rabbitmq", rabbitmqClient.CheckConnection)
 	outputHandler := NewWebServiceOutputHandler(mongoClient.Client, databaseName)
 
 	httpServer := getHTTPServer()
 	makeHTTPHealthzTransport(httpServer, healthzHandler)
 	makeHTTPOutputTransport(httpServer, outputHandler)
+	makeHTTPOpenAPITransport(httpServer, getOpenAPIDocument(httpServer))
 
-	if err := httpServer.Start(); err != nil {
-		log.Fatalf("Failed to start server: %v", err)
+	manager.Serve("http server", httpServer.Start, httpServer.Shutdown)
+	if err := manager.Wait(context.Background()); err != nil {
+		log.Fatalf("Server stopped with errors: %v", err)
 	}
 }
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"flag"
+	"libs/golang/ddd/adapters/http/handlers/health-check/healthz"
+	webHandler "libs/golang/ddd/adapters/http/handlers/output-vault/handlers"
+	"libs/golang/server/http/openapi/openapitest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	specFile   = "../../openapi.json"                                                          // OpenAPI document committed beside the service
+	updateSpec = flag.Bool("update", false, "rewrite openapi.json from the registered routes") // Regenerates the committed document
+)
+
+type OpenAPISuite struct {
+	suite.Suite
+}
+
+func TestOpenAPISuite(t *testing.T) {
+	suite.Run(t, new(OpenAPISuite))
+}
+
+func (suite *OpenAPISuite) TestCommittedDocumentMatchesRoutes() {
+	httpServer := getHTTPServer()
+	makeHTTPHealthzTransport(httpServer, &healthz.WebHealthzHandler{})
+	makeHTTPOutputTransport(httpServer, &webHandler.WebOutputHandler{})
+	document, err := getOpenAPIDocument(httpServer).JSON()
+	suite.Require().NoError(err)
+
+	if *updateSpec {
+		suite.Require().NoError(os.WriteFile(specFile, document, 0o644))
+	}
+	committed, err := os.ReadFile(specFile)
+	suite.Require().NoError(err)
+	suite.Equal(string(committed), string(document), "openapi.json is out of date, regenerate it with: go test ./cmd/server -update")
+}
+
+func (suite *OpenAPISuite) TestOperationsDocumentEveryHandler() {
+	openapitest.AssertDocumentsHandlers(suite.T(), &healthz.WebHealthzHandler{}, healthz.Operations)
+	openapitest.AssertDocumentsHandlers(suite.T(), &webHandler.WebOutputHandler{}, webHandler.Operations)
+}
@@ -8,6 +8,8 @@ import (
 	webHandler "libs/golang/ddd/adapters/http/handlers/output-vault/handlers"
 	"libs/golang/ddd/domain/entities/output-vault/entity"
 	"libs/golang/ddd/domain/repositories/database/mongodb/output-vault/repository"
+	event "libs/golang/ddd/events/output-vault/event"
+	events "libs/golang/shared/go-events/amqp_events"
 
 	"github.com/google/wire"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -21,9 +23,20 @@ var setOutputRepositoryDependency = wire.NewSet(
 	),
 )
 
+var setOutputCreatedEvent = wire.NewSet(newOutputCreatedEvent)
+
+// newOutputCreatedEvent provides the constructor of the OutputCreated events, so each created output is stored
+// with its own event.
+func newOutputCreatedEvent() func() events.EventInterface {
+	return func() events.EventInterface {
+		return event.NewOutputCreated()
+	}
+}
+
 func NewWebServiceOutputHandler(client *mongo.Client, database string) *webHandler.WebOutputHandler {
 	wire.Build(
 		setOutputRepositoryDependency,
+		setOutputCreatedEvent,
 		webHandler.NewWebOutputHandler,
 	)
 	return &webHandler.WebOutputHandler{}
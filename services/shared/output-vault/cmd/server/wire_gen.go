@@ -12,13 +12,16 @@ import (
 	"libs/golang/ddd/adapters/http/handlers/output-vault/handlers"
 	"libs/golang/ddd/domain/entities/output-vault/entity"
 	"libs/golang/ddd/domain/repositories/database/mongodb/output-vault/repository"
+	"libs/golang/ddd/events/output-vault/event"
+	"libs/golang/shared/go-events/amqp_events"
 )
 
 // Injectors from wire.go:
 
 func NewWebServiceOutputHandler(client *mongo.Client, database string) *handlers.WebOutputHandler {
 	outputRepository := repository.NewOutputRepository(client, database)
-	webOutputHandler := handlers.NewWebOutputHandler(outputRepository)
+	v := newOutputCreatedEvent()
+	webOutputHandler := handlers.NewWebOutputHandler(outputRepository, v)
 	return webOutputHandler
 }
 
@@ -29,3 +32,13 @@ var setOutputRepositoryDependency = wire.NewSet(repository.NewOutputRepository,
 	new(*repository.OutputRepository),
 ),
 )
+
+var setOutputCreatedEvent = wire.NewSet(newOutputCreatedEvent)
+
+// newOutputCreatedEvent provides the constructor of the OutputCreated events, so each created output is stored
+// with its own event.
+func newOutputCreatedEvent() func() amqpevents.EventInterface {
+	return func() amqpevents.EventInterface {
+		return event.NewOutputCreated()
+	}
+}